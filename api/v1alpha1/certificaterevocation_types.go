@@ -0,0 +1,92 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Unspecified;KeyCompromise;AffiliationChanged;Superseded;CessationOfOperation
+
+// RevocationReason is the RFC 5280 reason of a certificate revocation.
+type RevocationReason string
+
+const (
+	RevocationReasonUnspecified          RevocationReason = "Unspecified"
+	RevocationReasonKeyCompromise        RevocationReason = "KeyCompromise"
+	RevocationReasonAffiliationChanged   RevocationReason = "AffiliationChanged"
+	RevocationReasonSuperseded           RevocationReason = "Superseded"
+	RevocationReasonCessationOfOperation RevocationReason = "CessationOfOperation"
+)
+
+// ReasonCode returns the RFC 5280 code of the revocation reason.
+func (r RevocationReason) ReasonCode() int {
+	return map[RevocationReason]int{
+		RevocationReasonUnspecified:          0,
+		RevocationReasonKeyCompromise:        1,
+		RevocationReasonAffiliationChanged:   3,
+		RevocationReasonSuperseded:           4,
+		RevocationReasonCessationOfOperation: 5,
+	}[r]
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.kubeconfigSecret) != has(self.serialNumber)",message="exactly one of kubeconfigSecret, or serialNumber, must be specified"
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the certificate revocation is immutable"
+
+// CertificateRevocationSpec defines the certificate issued by the Tenant CA to revoke.
+type CertificateRevocationSpec struct {
+	//+kubebuilder:validation:MinLength=1
+	// TenantControlPlane is the name of the Tenant Control Plane, in the same namespace, whose CA issued the certificate.
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// KubeconfigSecret references the kubeconfig, in the same namespace, whose client certificate is revoked:
+	// when the Secret is generated by Kamaji, its certificate is rotated.
+	KubeconfigSecret *corev1.SecretKeySelector `json:"kubeconfigSecret,omitempty"`
+	//+kubebuilder:validation:Pattern=`^[0-9a-fA-F]+$`
+	// SerialNumber is the hexadecimal serial number of the revoked certificate.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	//+kubebuilder:default=Unspecified
+	Reason RevocationReason `json:"reason,omitempty"`
+}
+
+// CertificateRevocationStatus defines the observed state of CertificateRevocation.
+type CertificateRevocationStatus struct {
+	// SerialNumber is the lowercase hexadecimal serial number of the revoked certificate, listed in the Tenant Control Plane CRL.
+	SerialNumber string `json:"serialNumber,omitempty"`
+	// Subject is the distinguished name of the revoked certificate, when resolved from the kubeconfig.
+	Subject   string      `json:"subject,omitempty"`
+	RevokedAt metav1.Time `json:"revokedAt,omitempty"`
+	// Error reports why the revoked certificate cannot be resolved.
+	Error string `json:"error,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories=kamaji,shortName=certrev
+//+kubebuilder:printcolumn:name="Tenant Control Plane",type="string",JSONPath=".spec.tenantControlPlane",description="Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Serial",type="string",JSONPath=".status.serialNumber",description="Revoked certificate serial number"
+//+kubebuilder:printcolumn:name="Revoked",type="date",JSONPath=".status.revokedAt",description="Revocation time"
+
+// CertificateRevocation is the Schema for the certificaterevocations API:
+// it revokes a certificate issued by the Tenant CA, listing it in the CRL published for the Tenant Control Plane.
+type CertificateRevocation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CertificateRevocationSpec   `json:"spec,omitempty"`
+	Status CertificateRevocationStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CertificateRevocationList contains a list of CertificateRevocation.
+type CertificateRevocationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CertificateRevocation `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CertificateRevocation{}, &CertificateRevocationList{})
+}
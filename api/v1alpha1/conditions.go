@@ -0,0 +1,104 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Standard conditions, following the Kubernetes API conventions:
+// GitOps tools, and kstatus, compute the health of the Kamaji objects from them, along with the observed generation.
+const (
+	ConditionReady       = "Ready"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+
+	ReasonReconciled  = "Reconciled"
+	ReasonReconciling = "Reconciling"
+	ReasonFailed      = "Failed"
+	// ReasonConnectionFailed is reported by the DataStore which cannot be reached with the declared endpoints, credentials, and TLS settings.
+	ReasonConnectionFailed = "ConnectionFailed"
+	// ReasonProvisioning is reported by the DataStore whose backend is being provisioned by an external operator.
+	ReasonProvisioning = "Provisioning"
+)
+
+// SetStandardConditions sets the Ready, Progressing, and Degraded conditions according to the Tenant Control Plane phase:
+// the Tenant Control Plane is progressing until the latest generation has been reconciled, and the phase is a final one.
+func (in *TenantControlPlane) SetStandardConditions() {
+	generation := in.GetGeneration()
+	phase, message := in.Status.Phase, in.Status.PhaseMessage
+
+	ready := metav1.Condition{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: string(phase), Message: message}
+	progressing := metav1.Condition{Type: ConditionProgressing, Status: metav1.ConditionTrue, Reason: string(phase), Message: message}
+	degraded := metav1.Condition{Type: ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonReconciled}
+
+	switch phase {
+	case PhaseReady:
+		ready.Status = metav1.ConditionTrue
+		progressing.Status = metav1.ConditionFalse
+	case PhaseSleeping:
+		progressing.Status = metav1.ConditionFalse
+	case PhaseFailed:
+		progressing.Status = metav1.ConditionFalse
+		degraded.Status, degraded.Reason, degraded.Message = metav1.ConditionTrue, ReasonFailed, message
+	}
+
+	if in.Status.ObservedGeneration != generation && phase != PhaseFailed {
+		progressing.Status, progressing.Reason, progressing.Message = metav1.ConditionTrue, ReasonReconciling, "the latest generation is being reconciled"
+	}
+
+	for _, condition := range []metav1.Condition{ready, progressing, degraded} {
+		if len(condition.Reason) == 0 {
+			condition.Reason = ReasonReconciling
+		}
+
+		condition.ObservedGeneration = generation
+
+		meta.SetStatusCondition(&in.Status.Conditions, condition)
+	}
+}
+
+// SetStandardConditions marks the DataStore as ready, once the latest generation has been reconciled:
+// a non-nil probe error marks it as not ready, and degraded, reporting the connection error.
+func (in *DataStore) SetStandardConditions(probeErr error) {
+	generation := in.GetGeneration()
+
+	in.Status.ObservedGeneration = generation
+
+	conditions := []metav1.Condition{
+		{Type: ConditionReady, Status: metav1.ConditionTrue, Reason: ReasonReconciled},
+		{Type: ConditionProgressing, Status: metav1.ConditionFalse, Reason: ReasonReconciled},
+		{Type: ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonReconciled},
+	}
+
+	if probeErr != nil {
+		conditions[0].Status, conditions[0].Reason, conditions[0].Message = metav1.ConditionFalse, ReasonConnectionFailed, probeErr.Error()
+		conditions[2].Status, conditions[2].Reason, conditions[2].Message = metav1.ConditionTrue, ReasonConnectionFailed, probeErr.Error()
+	}
+
+	for _, condition := range conditions {
+		condition.ObservedGeneration = generation
+
+		meta.SetStatusCondition(&in.Status.Conditions, condition)
+	}
+}
+
+// SetProvisioningConditions marks the DataStore as not ready, and progressing,
+// until its backend is reported as ready by the external operator.
+func (in *DataStore) SetProvisioningConditions(message string) {
+	generation := in.GetGeneration()
+
+	in.Status.ObservedGeneration = generation
+
+	for _, condition := range []metav1.Condition{
+		{Type: ConditionReady, Status: metav1.ConditionFalse, Reason: ReasonProvisioning, Message: message},
+		{Type: ConditionProgressing, Status: metav1.ConditionTrue, Reason: ReasonProvisioning, Message: message},
+		{Type: ConditionDegraded, Status: metav1.ConditionFalse, Reason: ReasonReconciled},
+	} {
+		condition.ObservedGeneration = generation
+
+		meta.SetStatusCondition(&in.Status.Conditions, condition)
+	}
+}
@@ -6,8 +6,12 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -37,3 +41,149 @@ func (in *ContentRef) GetContent(ctx context.Context, client client.Client) ([]b
 
 	return v, nil
 }
+
+// ZoneReplicas distributes the given replicas across the zones of the DataStore endpoints, proportionally to their count:
+// the remaining replicas are assigned to the zones with the largest remainders, and then by name.
+func (in DataStoreSpec) ZoneReplicas(replicas int32) []DataStoreZoneReplicas {
+	if in.Topology == nil || len(in.Topology.Zones) == 0 {
+		return nil
+	}
+
+	zones := slices.Clone(in.Topology.Zones)
+	slices.SortFunc(zones, func(a, b DataStoreZone) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var total int32
+	for _, zone := range zones {
+		total += int32(len(zone.Endpoints)) //nolint:gosec
+	}
+
+	result, remainders := make([]DataStoreZoneReplicas, len(zones)), make([]int32, len(zones))
+	assigned := int32(0)
+
+	for i, zone := range zones {
+		share := replicas * int32(len(zone.Endpoints)) //nolint:gosec
+
+		result[i] = DataStoreZoneReplicas{Zone: zone.Name, Endpoints: zone.Endpoints, Replicas: share / total}
+		remainders[i] = share % total
+		assigned += result[i].Replicas
+	}
+
+	order := make([]int, len(zones))
+	for i := range order {
+		order[i] = i
+	}
+
+	slices.SortStableFunc(order, func(a, b int) int {
+		return int(remainders[b] - remainders[a])
+	})
+
+	for _, i := range order[:replicas-assigned] {
+		result[i].Replicas++
+	}
+
+	return result
+}
+
+// GetTenantPrefixStrategy returns the declared tenant prefix strategy, or the default one of the driver.
+func (in DataStoreSpec) GetTenantPrefixStrategy() TenantPrefixStrategy {
+	if in.TenantPrefixStrategy != "" {
+		return in.TenantPrefixStrategy
+	}
+
+	switch in.Driver {
+	case EtcdDriver:
+		return TenantPrefixStrategyKeyPrefix
+	case KineNatsDriver:
+		return TenantPrefixStrategyBucket
+	default:
+		return TenantPrefixStrategyDatabase
+	}
+}
+
+// GetSharedDatabase returns the database hosting the tenant schemas with the Schema strategy.
+func (in DataStoreSpec) GetSharedDatabase() string {
+	if in.SharedDatabase != "" {
+		return in.SharedDatabase
+	}
+
+	return DefaultSharedDatabase
+}
+
+// IsProvisioning returns true when the DataStore backend is requested from an external operator,
+// and it has not been reported as ready yet: the later readiness changes don't affect the Tenant Control Planes using it.
+func (in *DataStore) IsProvisioning() bool {
+	if in.Spec.Provisioner == nil {
+		return false
+	}
+
+	ready := meta.FindStatusCondition(in.Status.Conditions, ConditionReady)
+
+	return ready == nil || ready.Reason == ReasonProvisioning
+}
+
+// RetainTenant records the retained contents of a deleted Tenant Control Plane,
+// replacing a previous record of the same Tenant Control Plane.
+func (in *DataStoreStatus) RetainTenant(retained DataStoreRetainedTenant) {
+	for i := range in.Retained {
+		if in.Retained[i].TenantControlPlane == retained.TenantControlPlane {
+			in.Retained[i] = retained
+
+			return
+		}
+	}
+
+	in.Retained = append(in.Retained, retained)
+}
+
+// SchemaVersion returns the kine schema version of the given Tenant Control Plane, if tracked.
+func (in DataStoreStatus) SchemaVersion(tenantControlPlane string) (DataStoreTenantSchemaVersion, bool) {
+	for _, version := range in.SchemaVersions {
+		if version.TenantControlPlane == tenantControlPlane {
+			return version, true
+		}
+	}
+
+	return DataStoreTenantSchemaVersion{}, false
+}
+
+// SetSchemaVersion records the kine schema version of a Tenant Control Plane,
+// replacing a previous record of the same Tenant Control Plane.
+func (in *DataStoreStatus) SetSchemaVersion(version DataStoreTenantSchemaVersion) {
+	for i := range in.SchemaVersions {
+		if in.SchemaVersions[i].TenantControlPlane == version.TenantControlPlane {
+			in.SchemaVersions[i] = version
+
+			return
+		}
+	}
+
+	in.SchemaVersions = append(in.SchemaVersions, version)
+}
+
+// SchemaMigrationsInFlight returns the number of Tenant Control Planes rolling out a new kine image.
+func (in DataStoreStatus) SchemaMigrationsInFlight() int {
+	var count int
+
+	for _, version := range in.SchemaVersions {
+		if len(version.TargetVersion) > 0 {
+			count++
+		}
+	}
+
+	return count
+}
+
+// NextRetentionExpiry returns the earliest expiration of the retained contents, if any.
+func (in DataStoreStatus) NextRetentionExpiry() (time.Time, bool) {
+	var next time.Time
+
+	for _, retained := range in.Retained {
+		if next.IsZero() || retained.ExpiresAt.Time.Before(next) {
+			next = retained.ExpiresAt.Time
+		}
+	}
+
+	return next, !next.IsZero()
+}
@@ -0,0 +1,121 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DataStore zones", func() {
+	It("returns no zones when the topology is not declared", func() {
+		Expect(DataStoreSpec{}.ZoneReplicas(3)).To(BeNil())
+	})
+
+	It("distributes the replicas proportionally to the zone endpoints", func() {
+		spec := DataStoreSpec{
+			Topology: &DataStoreTopology{
+				Zones: []DataStoreZone{
+					{Name: "zone-b", Endpoints: []string{"etcd-1:2379"}},
+					{Name: "zone-a", Endpoints: []string{"etcd-0:2379", "etcd-2:2379"}},
+				},
+			},
+		}
+
+		Expect(spec.ZoneReplicas(3)).To(Equal([]DataStoreZoneReplicas{
+			{Zone: "zone-a", Endpoints: []string{"etcd-0:2379", "etcd-2:2379"}, Replicas: 2},
+			{Zone: "zone-b", Endpoints: []string{"etcd-1:2379"}, Replicas: 1},
+		}))
+		Expect(spec.ZoneReplicas(2)).To(Equal([]DataStoreZoneReplicas{
+			{Zone: "zone-a", Endpoints: []string{"etcd-0:2379", "etcd-2:2379"}, Replicas: 1},
+			{Zone: "zone-b", Endpoints: []string{"etcd-1:2379"}, Replicas: 1},
+		}))
+	})
+})
+
+var _ = Describe("DataStore retained tenants", func() {
+	now := time.Now().Truncate(time.Second)
+
+	It("replaces the record of the same Tenant Control Plane", func() {
+		status := DataStoreStatus{}
+
+		status.RetainTenant(DataStoreRetainedTenant{TenantControlPlane: "default/tenant-00", Schema: "default_tenant_00", ExpiresAt: metav1.NewTime(now.Add(time.Hour))})
+		status.RetainTenant(DataStoreRetainedTenant{TenantControlPlane: "default/tenant-01", Schema: "default_tenant_01", ExpiresAt: metav1.NewTime(now.Add(2 * time.Hour))})
+		status.RetainTenant(DataStoreRetainedTenant{TenantControlPlane: "default/tenant-00", Schema: "default_tenant_00", ExpiresAt: metav1.NewTime(now.Add(3 * time.Hour))})
+
+		Expect(status.Retained).To(HaveLen(2))
+		Expect(status.Retained[0].ExpiresAt.Time).To(Equal(now.Add(3 * time.Hour)))
+	})
+
+	It("returns the earliest expiration", func() {
+		_, ok := DataStoreStatus{}.NextRetentionExpiry()
+		Expect(ok).To(BeFalse())
+
+		next, ok := DataStoreStatus{Retained: []DataStoreRetainedTenant{
+			{TenantControlPlane: "default/tenant-00", ExpiresAt: metav1.NewTime(now.Add(2 * time.Hour))},
+			{TenantControlPlane: "default/tenant-01", ExpiresAt: metav1.NewTime(now.Add(time.Hour))},
+		}}.NextRetentionExpiry()
+		Expect(ok).To(BeTrue())
+		Expect(next).To(Equal(now.Add(time.Hour)))
+	})
+})
+
+var _ = Describe("DataStore provisioning", func() {
+	It("is not provisioning without a provisioner", func() {
+		Expect((&DataStore{}).IsProvisioning()).To(BeFalse())
+	})
+
+	It("is provisioning until the backend is reported as ready", func() {
+		ds := &DataStore{Spec: DataStoreSpec{Provisioner: &DataStoreProvisionerSpec{Name: DataStoreProvisionerEtcdDruid, Namespace: "etcd-system"}}}
+		Expect(ds.IsProvisioning()).To(BeTrue())
+
+		ds.SetProvisioningConditions("the Etcd resource is not ready")
+		Expect(ds.IsProvisioning()).To(BeTrue())
+
+		ds.SetStandardConditions(nil)
+		Expect(ds.IsProvisioning()).To(BeFalse())
+	})
+})
+
+var _ = Describe("DataStore tenant prefix strategy", func() {
+	It("returns the default strategy of the driver", func() {
+		Expect(DataStoreSpec{Driver: EtcdDriver}.GetTenantPrefixStrategy()).To(Equal(TenantPrefixStrategyKeyPrefix))
+		Expect(DataStoreSpec{Driver: KineNatsDriver}.GetTenantPrefixStrategy()).To(Equal(TenantPrefixStrategyBucket))
+		Expect(DataStoreSpec{Driver: KineMySQLDriver}.GetTenantPrefixStrategy()).To(Equal(TenantPrefixStrategyDatabase))
+		Expect(DataStoreSpec{Driver: KinePostgreSQLDriver}.GetTenantPrefixStrategy()).To(Equal(TenantPrefixStrategyDatabase))
+	})
+
+	It("returns the declared strategy, and the shared database", func() {
+		spec := DataStoreSpec{Driver: KinePostgreSQLDriver, TenantPrefixStrategy: TenantPrefixStrategySchema}
+
+		Expect(spec.GetTenantPrefixStrategy()).To(Equal(TenantPrefixStrategySchema))
+		Expect(spec.GetSharedDatabase()).To(Equal(DefaultSharedDatabase))
+
+		spec.SharedDatabase = "tenants"
+		Expect(spec.GetSharedDatabase()).To(Equal("tenants"))
+	})
+})
+
+var _ = Describe("DataStore schema versions", func() {
+	It("records the schema version of each Tenant Control Plane", func() {
+		status := DataStoreStatus{}
+
+		status.SetSchemaVersion(DataStoreTenantSchemaVersion{TenantControlPlane: "default/tenant-00", Version: "kine:v0.13.0"})
+		status.SetSchemaVersion(DataStoreTenantSchemaVersion{TenantControlPlane: "default/tenant-01", Version: "kine:v0.13.0", TargetVersion: "kine:v0.14.0"})
+		status.SetSchemaVersion(DataStoreTenantSchemaVersion{TenantControlPlane: "default/tenant-00", Version: "kine:v0.13.0", TargetVersion: "kine:v0.14.0"})
+
+		Expect(status.SchemaVersions).To(HaveLen(2))
+		Expect(status.SchemaMigrationsInFlight()).To(Equal(2))
+
+		version, ok := status.SchemaVersion("default/tenant-01")
+		Expect(ok).To(BeTrue())
+		Expect(version.TargetVersion).To(Equal("kine:v0.14.0"))
+
+		_, ok = status.SchemaVersion("default/tenant-02")
+		Expect(ok).To(BeFalse())
+	})
+})
@@ -6,6 +6,7 @@ package v1alpha1
 import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 //+kubebuilder:validation:Enum=etcd;MySQL;PostgreSQL;NATS
@@ -32,6 +33,14 @@ type Endpoints []string
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\" && has(self.basicAuth)) ? ((has(self.basicAuth.username.secretReference) || has(self.basicAuth.username.content))) : true", message="When driver is not etcd and basicAuth exists, username must have secretReference or content"
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\" && has(self.basicAuth)) ? ((has(self.basicAuth.password.secretReference) || has(self.basicAuth.password.content))) : true", message="When driver is not etcd and basicAuth exists, password must have secretReference or content"
 // +kubebuilder:validation:XValidation:rule="(self.driver != \"etcd\") ? (has(self.tlsConfig) || has(self.basicAuth)) : true", message="When driver is not etcd, either tlsConfig or basicAuth must be provided"
+// +kubebuilder:validation:XValidation:rule="!has(self.topology) || self.topology.zones.all(z, z.endpoints.all(e, e in self.endpoints))", message="the topology zones must reference the DataStore endpoints"
+// +kubebuilder:validation:XValidation:rule="!has(self.provisioner) || (self.provisioner.name == 'etcd-druid' ? self.driver == 'etcd' : self.driver in ['MySQL', 'PostgreSQL'])", message="the provisioner doesn't support the DataStore driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.tenantPrefixStrategy) || (self.driver == 'etcd' ? self.tenantPrefixStrategy == 'KeyPrefix' : self.driver == 'NATS' ? self.tenantPrefixStrategy == 'Bucket' : self.driver == 'MySQL' ? self.tenantPrefixStrategy == 'Database' : self.tenantPrefixStrategy in ['Database', 'Schema'])", message="the tenant prefix strategy is not supported by the DataStore driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.sharedDatabase) || (has(self.tenantPrefixStrategy) && self.tenantPrefixStrategy == 'Schema')", message="the shared database requires the Schema tenant prefix strategy"
+// +kubebuilder:validation:XValidation:rule="(has(self.tenantPrefixStrategy) ? self.tenantPrefixStrategy : \"\") == (has(oldSelf.tenantPrefixStrategy) ? oldSelf.tenantPrefixStrategy : \"\")", message="the tenant prefix strategy is immutable"
+// +kubebuilder:validation:XValidation:rule="(has(self.sharedDatabase) ? self.sharedDatabase : \"\") == (has(oldSelf.sharedDatabase) ? oldSelf.sharedDatabase : \"\")", message="the shared database is immutable"
+// +kubebuilder:validation:XValidation:rule="!has(self.schemaMigration) || self.driver != 'etcd'", message="the schema migration batching requires a kine driver"
+// +kubebuilder:validation:XValidation:rule="!has(self.slowQueries) || self.driver in ['MySQL', 'PostgreSQL']", message="the slow queries reporting requires a SQL driver"
 type DataStoreSpec struct {
 	// The driver to use to connect to the shared datastore.
 	Driver Driver `json:"driver"`
@@ -44,6 +53,115 @@ type DataStoreSpec struct {
 	// Defines the TLS/SSL configuration required to connect to the data store in a secure way.
 	// This value is optional.
 	TLSConfig *TLSConfig `json:"tlsConfig,omitempty"`
+	// Topology maps the endpoints to the zones of the management cluster, such as for a DataStore spanning zones:
+	// the Tenant Control Plane replicas are spread across the zones proportionally to their endpoints,
+	// reducing the cross-zone latency of the API Server, and of the kine sidecar containers.
+	// This value is optional.
+	Topology *DataStoreTopology `json:"topology,omitempty"`
+	// Provisioner requests the DataStore backend from an external operator, such as etcd-druid, or the Aiven one:
+	// the DataStore is not used by the Tenant Control Planes until the requested resource is reported as ready.
+	// The endpoints, and the credentials, must reference the ones of the requested resource.
+	// This value is optional.
+	Provisioner *DataStoreProvisionerSpec `json:"provisioner,omitempty"`
+	// TenantPrefixStrategy defines how the data of the Tenant Control Planes is namespaced in the DataStore:
+	// a database per tenant (MySQL, PostgreSQL), a schema per tenant in a shared database (PostgreSQL),
+	// a key prefix (etcd), or a bucket (NATS).
+	// When not set, the default of the driver is applied: Database for the SQL drivers, KeyPrefix for etcd, and Bucket for NATS.
+	// The strategy is immutable: the Tenant Control Planes are moved to another strategy by migrating them
+	// to a DataStore using it.
+	TenantPrefixStrategy TenantPrefixStrategy `json:"tenantPrefixStrategy,omitempty"`
+	// SharedDatabase is the database hosting the tenant schemas with the Schema strategy, created if missing.
+	// When not set, the kamaji database is used.
+	//+kubebuilder:validation:MinLength=1
+	SharedDatabase string `json:"sharedDatabase,omitempty"`
+	// SchemaMigration batches the kine schema migrations of the Tenant Control Planes upon a kine image change, such as a Kamaji upgrade:
+	// the Tenant Control Planes exceeding the concurrent migrations wait for a free slot before rolling out the new kine image,
+	// preventing a stampede of schema migrations on the SQL backend.
+	// This value is optional.
+	SchemaMigration *DataStoreSchemaMigrationSpec `json:"schemaMigration,omitempty"`
+	// SlowQueries enables the reporting of the kine slow queries for the SQL drivers:
+	// the queries exceeding the threshold are collected from the kine logs, and aggregated in the Tenant Control Plane status,
+	// locating the tenants producing pathological list, and watch, patterns.
+	// This value is optional.
+	SlowQueries *DataStoreSlowQueriesSpec `json:"slowQueries,omitempty"`
+}
+
+// DataStoreSlowQueriesSpec defines the reporting of the kine slow queries.
+type DataStoreSlowQueriesSpec struct {
+	// Threshold is the duration above which a query is logged by kine as a slow one.
+	//+kubebuilder:default="1s"
+	Threshold metav1.Duration `json:"threshold,omitempty"`
+	// Interval is the period of the collection of the kine logs.
+	//+kubebuilder:default="1m"
+	Interval metav1.Duration `json:"interval,omitempty"`
+}
+
+// DataStoreSchemaMigrationSpec defines the batching of the kine schema migrations.
+type DataStoreSchemaMigrationSpec struct {
+	// MaxConcurrent is the number of Tenant Control Planes allowed to roll out a new kine image at the same time.
+	//+kubebuilder:default=5
+	//+kubebuilder:validation:Minimum=1
+	MaxConcurrent int32 `json:"maxConcurrent,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Database;Schema;KeyPrefix;Bucket
+
+// TenantPrefixStrategy is the way the data of the Tenant Control Planes is namespaced in a DataStore.
+type TenantPrefixStrategy string
+
+const (
+	// TenantPrefixStrategyDatabase stores each tenant in its own database, for the MySQL, and PostgreSQL drivers.
+	TenantPrefixStrategyDatabase TenantPrefixStrategy = "Database"
+	// TenantPrefixStrategySchema stores each tenant in its own schema of a shared database, for the PostgreSQL driver.
+	TenantPrefixStrategySchema TenantPrefixStrategy = "Schema"
+	// TenantPrefixStrategyKeyPrefix stores each tenant under its own key prefix, for the etcd driver.
+	TenantPrefixStrategyKeyPrefix TenantPrefixStrategy = "KeyPrefix"
+	// TenantPrefixStrategyBucket stores each tenant in its own bucket, for the NATS driver.
+	TenantPrefixStrategyBucket TenantPrefixStrategy = "Bucket"
+)
+
+// DefaultSharedDatabase is the database hosting the tenant schemas when the shared one is not declared.
+const DefaultSharedDatabase = "kamaji"
+
+// +kubebuilder:validation:Enum=etcd-druid;aiven
+
+// DataStoreProvisionerName is the name of the external operator provisioning a DataStore backend.
+type DataStoreProvisionerName string
+
+const (
+	// DataStoreProvisionerEtcdDruid requests an Etcd resource, for the etcd driver.
+	DataStoreProvisionerEtcdDruid DataStoreProvisionerName = "etcd-druid"
+	// DataStoreProvisionerAiven requests a PostgreSQL, or a MySQL, resource, for the matching driver.
+	DataStoreProvisionerAiven DataStoreProvisionerName = "aiven"
+)
+
+// DataStoreProvisionerSpec defines the resource requested to an external operator, named after the DataStore,
+// and owned by it: the resource is deleted along with the DataStore.
+type DataStoreProvisionerSpec struct {
+	Name DataStoreProvisionerName `json:"name"`
+	// Namespace of the requested resource.
+	//+kubebuilder:validation:MinLength=1
+	Namespace string `json:"namespace"`
+	// Spec of the requested resource, according to the API of the external operator.
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Spec *runtime.RawExtension `json:"spec,omitempty"`
+}
+
+// DataStoreTopology defines the zones of the DataStore endpoints.
+type DataStoreTopology struct {
+	//+kubebuilder:validation:MinItems=1
+	//+listType=map
+	//+listMapKey=name
+	Zones []DataStoreZone `json:"zones"`
+}
+
+// DataStoreZone defines the endpoints hosted in a zone.
+type DataStoreZone struct {
+	// Name of the zone, matching the topology.kubernetes.io/zone label of the management cluster nodes.
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	//+kubebuilder:validation:MinItems=1
+	Endpoints []string `json:"endpoints"`
 }
 
 // TLSConfig contains the information used to connect to the data store using a secured connection.
@@ -88,16 +206,65 @@ type SecretReference struct {
 	KeyPath secretReferKeyPath `json:"keyPath"`
 }
 
+// DataStoreRetainedTenant is the DataStore contents of a deleted Tenant Control Plane,
+// retained until the expiration of its retainOnDelete window.
+type DataStoreRetainedTenant struct {
+	// TenantControlPlane is the namespaced name of the deleted Tenant Control Plane.
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// Schema is the database name (for relational DataStores), or the key prefix (for etcd), of the retained contents.
+	Schema string `json:"schema"`
+	// User is the DataStore user of the deleted Tenant Control Plane.
+	User string `json:"user,omitempty"`
+	// ConfigSecretName is the name of the retained Secret holding the DataStore credentials,
+	// in the namespace of the deleted Tenant Control Plane.
+	ConfigSecretName string `json:"configSecretName,omitempty"`
+	// ExpiresAt is the time the retained contents are purged.
+	ExpiresAt metav1.Time `json:"expiresAt"`
+}
+
+// DataStoreTenantSchemaVersion is the kine schema version of a Tenant Control Plane, as the kine image migrating it.
+type DataStoreTenantSchemaVersion struct {
+	// TenantControlPlane is the namespaced name of the Tenant Control Plane.
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// Version is the kine image which migrated the schema last.
+	Version string `json:"version"`
+	// TargetVersion is the kine image being rolled out:
+	// the migration holds a slot of the concurrent ones until the rollout is completed.
+	TargetVersion string `json:"targetVersion,omitempty"`
+	// LastTransitionTime is the last time the schema version, or the target one, changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime,omitempty"`
+}
+
 // DataStoreStatus defines the observed state of DataStore.
 type DataStoreStatus struct {
 	// List of the Tenant Control Planes, namespaced named, using this data store.
 	UsedBy []string `json:"usedBy,omitempty"`
+	// Retained lists the contents of the deleted Tenant Control Planes, pending their purge.
+	// +listType=map
+	// +listMapKey=tenantControlPlane
+	// +optional
+	Retained []DataStoreRetainedTenant `json:"retained,omitempty"`
+	// ObservedGeneration is the latest generation of the DataStore reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Conditions contains the latest observations of the DataStore state,
+	// such as the Ready, Progressing, and Degraded ones.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// SchemaVersions tracks the kine schema version of each Tenant Control Plane,
+	// along with the in-flight migrations, when the schema migrations are batched.
+	// +listType=map
+	// +listMapKey=tenantControlPlane
+	// +optional
+	SchemaVersions []DataStoreTenantSchemaVersion `json:"schemaVersions,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:scope=Cluster
 //+kubebuilder:printcolumn:name="Driver",type="string",JSONPath=".spec.driver",description="Kamaji data store driver"
+//+kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description="Ready condition status"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 //+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
 
@@ -0,0 +1,180 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+)
+
+type ImageProfileComponent string
+
+const (
+	ImageProfileAPIServer          ImageProfileComponent = "apiServer"
+	ImageProfileControllerManager  ImageProfileComponent = "controllerManager"
+	ImageProfileScheduler          ImageProfileComponent = "scheduler"
+	ImageProfileCoreDNS            ImageProfileComponent = "coreDNS"
+	ImageProfileKubeProxy          ImageProfileComponent = "kubeProxy"
+	ImageProfileKonnectivityServer ImageProfileComponent = "konnectivityServer"
+	ImageProfileKonnectivityAgent  ImageProfileComponent = "konnectivityAgent"
+	ImageProfileKine               ImageProfileComponent = "kine"
+)
+
+func (in ImageProfileComponents) get(component ImageProfileComponent) *ComponentImage {
+	switch component {
+	case ImageProfileAPIServer:
+		return in.APIServer
+	case ImageProfileControllerManager:
+		return in.ControllerManager
+	case ImageProfileScheduler:
+		return in.Scheduler
+	case ImageProfileCoreDNS:
+		return in.CoreDNS
+	case ImageProfileKubeProxy:
+		return in.KubeProxy
+	case ImageProfileKonnectivityServer:
+		return in.KonnectivityServer
+	case ImageProfileKonnectivityAgent:
+		return in.KonnectivityAgent
+	case ImageProfileKine:
+		return in.Kine
+	default:
+		return nil
+	}
+}
+
+// WithFIPSComponents returns a copy of the profile whose component overrides are replaced by the FIPS ones, when declared:
+// a nil profile is returned as is.
+func (in *ImageProfile) WithFIPSComponents() *ImageProfile {
+	if in == nil {
+		return nil
+	}
+
+	out := in.DeepCopy()
+
+	components, fips := &out.Spec.Components, out.Spec.FIPSComponents
+
+	components.APIServer = fipsOverride(fips.APIServer, components.APIServer)
+	components.ControllerManager = fipsOverride(fips.ControllerManager, components.ControllerManager)
+	components.Scheduler = fipsOverride(fips.Scheduler, components.Scheduler)
+	components.CoreDNS = fipsOverride(fips.CoreDNS, components.CoreDNS)
+	components.KubeProxy = fipsOverride(fips.KubeProxy, components.KubeProxy)
+	components.KonnectivityServer = fipsOverride(fips.KonnectivityServer, components.KonnectivityServer)
+	components.KonnectivityAgent = fipsOverride(fips.KonnectivityAgent, components.KonnectivityAgent)
+	components.Kine = fipsOverride(fips.Kine, components.Kine)
+
+	return out
+}
+
+func fipsOverride(fips, override *ComponentImage) *ComponentImage {
+	if fips != nil {
+		return fips
+	}
+
+	return override
+}
+
+// HasFIPSImage returns true when the profile declares the FIPS-validated image of the given component.
+func (in *ImageProfile) HasFIPSImage(component ImageProfileComponent) bool {
+	if in == nil {
+		return false
+	}
+
+	override := in.Spec.FIPSComponents.get(component)
+
+	return override != nil && len(override.Repository) > 0
+}
+
+// Resolve returns the image reference for the given component, applying the profile overrides:
+// a nil profile returns the image as is.
+func (in *ImageProfile) Resolve(component ImageProfileComponent, image string) string {
+	if in == nil || len(image) == 0 {
+		return image
+	}
+
+	repository, tag, digest := splitImageReference(image)
+
+	override := in.Spec.Components.get(component)
+
+	switch {
+	case override != nil && len(override.Repository) > 0:
+		repository = override.Repository
+	case len(in.Spec.Registry) > 0:
+		repository = replaceImageRegistry(repository, in.Spec.Registry)
+	}
+
+	if override != nil && len(tag) > 0 {
+		if pinned, ok := override.Digests[tag]; ok {
+			digest = pinned
+		}
+	}
+
+	image = repository
+
+	if len(tag) > 0 {
+		image += ":" + tag
+	}
+
+	if len(digest) > 0 {
+		image += "@" + digest
+	}
+
+	return image
+}
+
+// RequiresDigests returns true when the component images must be pinned to their digests,
+// since signatures are verified against the manifest digest.
+func (in *ImageProfile) RequiresDigests() bool {
+	return in != nil && (in.Spec.ResolveDigests || in.Spec.Verification != nil)
+}
+
+// PinnedImage returns the image reference pinned to the digest resolved for the given component:
+// the image is returned as is when not resolved, such as upon a version change not yet reconciled.
+func (in *ImagesStatus) PinnedImage(component ImageProfileComponent, image string) string {
+	if in == nil {
+		return image
+	}
+
+	for _, status := range in.Components {
+		if status.Component != component || status.Image != image || len(status.Digest) == 0 {
+			continue
+		}
+
+		repository, tag, _ := splitImageReference(image)
+		if len(tag) > 0 {
+			repository += ":" + tag
+		}
+
+		return repository + "@" + status.Digest
+	}
+
+	return image
+}
+
+// splitImageReference splits the image reference in repository, tag, and digest.
+func splitImageReference(image string) (repository, tag, digest string) {
+	repository = image
+
+	if index := strings.Index(repository, "@"); index >= 0 {
+		repository, digest = repository[:index], repository[index+1:]
+	}
+	// The tag separator must follow the last path separator, since the registry could declare a port.
+	if index := strings.LastIndex(repository, ":"); index > strings.LastIndex(repository, "/") {
+		repository, tag = repository[:index], repository[index+1:]
+	}
+
+	return repository, tag, digest
+}
+
+// replaceImageRegistry replaces the registry of the given repository,
+// the ones without an explicit registry, such as the Docker Hub ones, are prefixed.
+func replaceImageRegistry(repository, registry string) string {
+	registry = strings.TrimSuffix(registry, "/")
+
+	parts := strings.SplitN(repository, "/", 2)
+	if len(parts) == 2 && (strings.ContainsAny(parts[0], ".:") || parts[0] == "localhost") {
+		return registry + "/" + parts[1]
+	}
+
+	return registry + "/" + repository
+}
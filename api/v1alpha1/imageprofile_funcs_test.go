@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ImageProfile", func() {
+	var profile *ImageProfile
+
+	BeforeEach(func() {
+		profile = &ImageProfile{
+			Spec: ImageProfileSpec{
+				Registry: "mirror.example.com:5000/kubernetes",
+				Components: ImageProfileComponents{
+					APIServer: &ComponentImage{
+						Repository: "mirror.example.com/custom/kube-apiserver",
+						Digests:    map[string]string{"v1.33.0": "sha256:abc"},
+					},
+				},
+			},
+		}
+	})
+
+	It("returns the image as is when no profile is referenced", func() {
+		var nilProfile *ImageProfile
+
+		Expect(nilProfile.Resolve(ImageProfileKine, "rancher/kine:v0.11.10-amd64")).To(Equal("rancher/kine:v0.11.10-amd64"))
+	})
+
+	It("replaces the registry of the component images", func() {
+		Expect(profile.Resolve(ImageProfileCoreDNS, "registry.k8s.io/coredns/coredns:v1.12.0")).To(Equal("mirror.example.com:5000/kubernetes/coredns/coredns:v1.12.0"))
+	})
+
+	It("prefixes the images with no explicit registry", func() {
+		Expect(profile.Resolve(ImageProfileKine, "rancher/kine:v0.11.10-amd64")).To(Equal("mirror.example.com:5000/kubernetes/rancher/kine:v0.11.10-amd64"))
+	})
+
+	It("uses the component repository, and pins the matching digest", func() {
+		Expect(profile.Resolve(ImageProfileAPIServer, "registry.k8s.io/kube-apiserver:v1.33.0")).To(Equal("mirror.example.com/custom/kube-apiserver:v1.33.0@sha256:abc"))
+		Expect(profile.Resolve(ImageProfileAPIServer, "registry.k8s.io/kube-apiserver:v1.32.0")).To(Equal("mirror.example.com/custom/kube-apiserver:v1.32.0"))
+	})
+
+	It("pins the resolved images to their digests", func() {
+		status := &ImagesStatus{
+			Components: []ComponentImageStatus{
+				{Component: ImageProfileAPIServer, Image: "registry.k8s.io/kube-apiserver:v1.33.0", Digest: "sha256:abc"},
+			},
+		}
+
+		Expect(status.PinnedImage(ImageProfileAPIServer, "registry.k8s.io/kube-apiserver:v1.33.0")).To(Equal("registry.k8s.io/kube-apiserver:v1.33.0@sha256:abc"))
+		Expect(status.PinnedImage(ImageProfileAPIServer, "registry.k8s.io/kube-apiserver:v1.34.0")).To(Equal("registry.k8s.io/kube-apiserver:v1.34.0"))
+		Expect(status.PinnedImage(ImageProfileScheduler, "registry.k8s.io/kube-apiserver:v1.33.0")).To(Equal("registry.k8s.io/kube-apiserver:v1.33.0"))
+	})
+
+	It("prefers the FIPS-validated component images", func() {
+		profile.Spec.FIPSComponents = ImageProfileComponents{
+			Scheduler: &ComponentImage{Repository: "fips.example.com/kube-scheduler"},
+		}
+
+		fipsProfile := profile.WithFIPSComponents()
+
+		Expect(fipsProfile.Resolve(ImageProfileScheduler, "registry.k8s.io/kube-scheduler:v1.33.0")).To(Equal("fips.example.com/kube-scheduler:v1.33.0"))
+		Expect(fipsProfile.Resolve(ImageProfileAPIServer, "registry.k8s.io/kube-apiserver:v1.33.0")).To(Equal("mirror.example.com/custom/kube-apiserver:v1.33.0@sha256:abc"))
+		Expect(fipsProfile.HasFIPSImage(ImageProfileScheduler)).To(BeTrue())
+		Expect(fipsProfile.HasFIPSImage(ImageProfileAPIServer)).To(BeFalse())
+		Expect(profile.Spec.Components.Scheduler).To(BeNil())
+	})
+})
@@ -0,0 +1,113 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ImageProfileSpec defines the desired state of ImageProfile.
+type ImageProfileSpec struct {
+	// Registry replaces the registry of all the component images, such as registry.k8s.io,
+	// pointing to a mirror registry: the image path, and tag, are left untouched.
+	// The component specific repositories take precedence.
+	Registry string `json:"registry,omitempty"`
+	// Components allows to override the image of each Tenant Control Plane component.
+	Components ImageProfileComponents `json:"components,omitempty"`
+	// FIPSComponents allows to override the image of each component with its FIPS-validated build,
+	// taking precedence over the Components overrides for the Tenant Control Planes running in FIPS mode.
+	FIPSComponents ImageProfileComponents `json:"fipsComponents,omitempty"`
+	// ResolveDigests resolves the tags of the Control Plane component images to their digests at reconciliation time:
+	// the components are rolled out using the pinned references, preventing a tag mutation from changing the running images.
+	// The registry credentials are taken from the Kamaji pod environment, such as the cloud provider workload identity.
+	ResolveDigests bool `json:"resolveDigests,omitempty"`
+	// Verification enforces the cosign signature verification of the Control Plane component images:
+	// the unverified images are not rolled out, and the digests are resolved regardless of the ResolveDigests value.
+	Verification *ImageVerification `json:"verification,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="has(self.publicKeys) || has(self.keyless)",message="at least one of publicKeys or keyless must be declared"
+
+// ImageVerification defines the trusted signers of the component images:
+// an image is verified when signed by any of them.
+type ImageVerification struct {
+	// PublicKeys contains the PEM encoded cosign public keys, such as the ones generated with cosign generate-key-pair.
+	PublicKeys []ContentRef `json:"publicKeys,omitempty"`
+	// Keyless contains the identities allowed to sign the images using the Sigstore keyless flow.
+	Keyless []KeylessIdentity `json:"keyless,omitempty"`
+}
+
+// KeylessIdentity defines an identity trusted to sign the images with a short-lived certificate issued by Fulcio:
+// the signature must be recorded in the Rekor transparency log, proving it has been created while the certificate was valid.
+type KeylessIdentity struct {
+	//+kubebuilder:validation:MinLength=1
+	// Issuer is the OIDC issuer of the signing identity, such as https://token.actions.githubusercontent.com.
+	Issuer string `json:"issuer"`
+	//+kubebuilder:validation:MinLength=1
+	// Subject is the signing identity, matching the certificate e-mail or URI subject alternative name,
+	// such as https://github.com/clastix/kamaji/.github/workflows/release.yml@refs/heads/master.
+	Subject string `json:"subject"`
+	// Roots contains the PEM encoded Fulcio root and intermediate certificates.
+	Roots ContentRef `json:"roots"`
+	// TransparencyLogPublicKey contains the PEM encoded Rekor public key,
+	// used to verify the signed entry timestamp bundled with the signature.
+	TransparencyLogPublicKey ContentRef `json:"transparencyLogPublicKey"`
+}
+
+// ImageProfileComponents defines the image overrides for each Tenant Control Plane component.
+type ImageProfileComponents struct {
+	APIServer          *ComponentImage `json:"apiServer,omitempty"`
+	ControllerManager  *ComponentImage `json:"controllerManager,omitempty"`
+	Scheduler          *ComponentImage `json:"scheduler,omitempty"`
+	CoreDNS            *ComponentImage `json:"coreDNS,omitempty"`
+	KubeProxy          *ComponentImage `json:"kubeProxy,omitempty"`
+	KonnectivityServer *ComponentImage `json:"konnectivityServer,omitempty"`
+	KonnectivityAgent  *ComponentImage `json:"konnectivityAgent,omitempty"`
+	Kine               *ComponentImage `json:"kine,omitempty"`
+}
+
+// ComponentImage defines the image override for a single component.
+type ComponentImage struct {
+	// Repository is the full image repository, without tag, replacing the default one,
+	// such as mirror.example.com/kubernetes/kube-apiserver.
+	Repository string `json:"repository,omitempty"`
+	// Digests pins the image tags to the given digests, such as v1.33.0: sha256:abc.
+	// The digest is appended to the image reference when its tag is matching, the tag is used as is otherwise.
+	Digests map[string]string `json:"digests,omitempty"`
+}
+
+// ImageProfileStatus defines the observed state of ImageProfile.
+type ImageProfileStatus struct {
+	// List of the Tenant Control Planes, namespaced named, using this image profile.
+	UsedBy []string `json:"usedBy,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,categories=kamaji
+//+kubebuilder:printcolumn:name="Registry",type="string",JSONPath=".spec.registry",description="Mirror registry"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// ImageProfile is the Schema for the imageprofiles API:
+// it maps the Tenant Control Plane component images to mirror registries, and digests.
+type ImageProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ImageProfileSpec   `json:"spec,omitempty"`
+	Status ImageProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ImageProfileList contains a list of ImageProfile.
+type ImageProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ImageProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ImageProfile{}, &ImageProfileList{})
+}
@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	CertificateRevocationTenantControlPlaneKey = "spec.tenantControlPlane"
+)
+
+type CertificateRevocationTenantControlPlane struct{}
+
+func (c *CertificateRevocationTenantControlPlane) Object() client.Object {
+	return &CertificateRevocation{}
+}
+
+func (c *CertificateRevocationTenantControlPlane) Field() string {
+	return CertificateRevocationTenantControlPlaneKey
+}
+
+func (c *CertificateRevocationTenantControlPlane) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		revocation := object.(*CertificateRevocation) //nolint:forcetypeassert
+
+		return []string{revocation.Spec.TenantControlPlane}
+	}
+}
+
+func (c *CertificateRevocationTenantControlPlane) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, c.Object(), c.Field(), c.ExtractValue())
+}
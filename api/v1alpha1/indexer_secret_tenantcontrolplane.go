@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecretTenantControlPlaneKey = "tenantControlPlane"
+)
+
+// SecretTenantControlPlane indexes the Secrets by the namespaced name of the Tenant Control Plane controlling them,
+// allowing to list the Secrets generated for a given tenant.
+type SecretTenantControlPlane struct{}
+
+func (s *SecretTenantControlPlane) Object() client.Object {
+	return &corev1.Secret{}
+}
+
+func (s *SecretTenantControlPlane) Field() string {
+	return SecretTenantControlPlaneKey
+}
+
+func (s *SecretTenantControlPlane) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp, ok := OwningTenantControlPlane(object)
+		if !ok {
+			return nil
+		}
+
+		return []string{tcp.String()}
+	}
+}
+
+func (s *SecretTenantControlPlane) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, s.Object(), s.Field(), s.ExtractValue())
+}
+
+// OwningTenantControlPlane returns the namespaced name of the Tenant Control Plane controlling the given object,
+// false if it's not controlled by a Tenant Control Plane.
+func OwningTenantControlPlane(object client.Object) (k8stypes.NamespacedName, bool) {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil || owner.Kind != "TenantControlPlane" || owner.APIVersion != GroupVersion.String() {
+		return k8stypes.NamespacedName{}, false
+	}
+
+	return k8stypes.NamespacedName{Namespace: object.GetNamespace(), Name: owner.Name}, true
+}
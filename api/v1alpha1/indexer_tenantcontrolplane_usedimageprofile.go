@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneUsedImageProfileKey = "spec.imageProfile"
+)
+
+type TenantControlPlaneImageProfile struct{}
+
+func (t *TenantControlPlaneImageProfile) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneImageProfile) Field() string {
+	return TenantControlPlaneUsedImageProfileKey
+}
+
+func (t *TenantControlPlaneImageProfile) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return []string{tcp.Spec.ImageProfile}
+	}
+}
+
+func (t *TenantControlPlaneImageProfile) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
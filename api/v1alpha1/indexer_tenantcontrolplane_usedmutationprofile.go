@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneUsedMutationProfileKey = "spec.controlPlane.mutators"
+)
+
+type TenantControlPlaneMutationProfile struct{}
+
+func (t *TenantControlPlaneMutationProfile) Object() client.Object {
+	return &TenantControlPlane{}
+}
+
+func (t *TenantControlPlaneMutationProfile) Field() string {
+	return TenantControlPlaneUsedMutationProfileKey
+}
+
+func (t *TenantControlPlaneMutationProfile) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		tcp := object.(*TenantControlPlane) //nolint:forcetypeassert
+
+		return tcp.Spec.ControlPlane.Mutators
+	}
+}
+
+func (t *TenantControlPlaneMutationProfile) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, t.Object(), t.Field(), t.ExtractValue())
+}
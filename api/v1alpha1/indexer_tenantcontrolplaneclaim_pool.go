@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"context"
+
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	TenantControlPlaneClaimPoolKey = "spec.poolName"
+)
+
+type TenantControlPlaneClaimPool struct{}
+
+func (c *TenantControlPlaneClaimPool) Object() client.Object {
+	return &TenantControlPlaneClaim{}
+}
+
+func (c *TenantControlPlaneClaimPool) Field() string {
+	return TenantControlPlaneClaimPoolKey
+}
+
+func (c *TenantControlPlaneClaimPool) ExtractValue() client.IndexerFunc {
+	return func(object client.Object) []string {
+		claim := object.(*TenantControlPlaneClaim) //nolint:forcetypeassert
+
+		return []string{claim.Spec.PoolName}
+	}
+}
+
+func (c *TenantControlPlaneClaimPool) SetupWithManager(ctx context.Context, mgr controllerruntime.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(ctx, c.Object(), c.Field(), c.ExtractValue())
+}
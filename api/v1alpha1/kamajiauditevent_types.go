@@ -0,0 +1,80 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=SecretRotation;DeploymentRollout;MigrationStarted;MigrationFinished;Deletion
+type AuditAction string
+
+const (
+	// AuditActionSecretRotation is recorded when a certificate, or a kubeconfig, is regenerated.
+	AuditActionSecretRotation AuditAction = "SecretRotation"
+	// AuditActionDeploymentRollout is recorded when the Control Plane Deployment is changed, rolling out its pods.
+	AuditActionDeploymentRollout AuditAction = "DeploymentRollout"
+	// AuditActionMigrationStarted is recorded when the migration to another DataStore is started.
+	AuditActionMigrationStarted AuditAction = "MigrationStarted"
+	// AuditActionMigrationFinished is recorded when the migration to another DataStore is completed.
+	AuditActionMigrationFinished AuditAction = "MigrationFinished"
+	// AuditActionDeletion is recorded when the Tenant Control Plane resources, and its DataStore contents, are deleted.
+	AuditActionDeletion AuditAction = "Deletion"
+)
+
+// AuditObjectReference references an object affected by an audited action.
+type AuditObjectReference struct {
+	APIVersion string `json:"apiVersion,omitempty"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// KamajiAuditEventSpec defines the audited action.
+type KamajiAuditEventSpec struct {
+	Action AuditAction `json:"action"`
+	// Actor is the identity performing the action, the Kamaji service account.
+	Actor string `json:"actor"`
+	// Reason describes why the action has been performed.
+	Reason string `json:"reason"`
+	// TenantControlPlane is the name of the Tenant Control Plane, in the same Namespace, the action has been performed for.
+	TenantControlPlane string `json:"tenantControlPlane"`
+	// Objects are the objects affected by the action.
+	Objects []AuditObjectReference `json:"objects,omitempty"`
+	// Timestamp is the time the action has been performed.
+	Timestamp metav1.Time `json:"timestamp"`
+	// KamajiVersion is the version of the Kamaji instance performing the action.
+	KamajiVersion string `json:"kamajiVersion,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Namespaced,categories=kamaji,shortName=kae
+//+kubebuilder:printcolumn:name="Tenant",type="string",JSONPath=".spec.tenantControlPlane",description="Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action",description="Audited action"
+//+kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".spec.reason",description="Reason",priority=1
+//+kubebuilder:printcolumn:name="Timestamp",type="date",JSONPath=".spec.timestamp",description="Time of the action"
+
+// KamajiAuditEvent is the Schema for the kamajiauditevents API:
+// it records an administrative action performed by Kamaji on a Tenant Control Plane, such as a secret rotation.
+// The events are append-only, their specification cannot be changed once created.
+type KamajiAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the audit events are immutable"
+	Spec KamajiAuditEventSpec `json:"spec"`
+}
+
+//+kubebuilder:object:root=true
+
+// KamajiAuditEventList contains a list of KamajiAuditEvent.
+type KamajiAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KamajiAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KamajiAuditEvent{}, &KamajiAuditEventList{})
+}
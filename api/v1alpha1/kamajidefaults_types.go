@@ -0,0 +1,116 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// KamajiDefaultsName is the name of the singleton KamajiDefaults object taken into account by the mutating webhook.
+const KamajiDefaultsName = "default"
+
+const (
+	// ControlPlaneQuotaReplicasAnnotation overrides, on a namespace, the maximum replicas of the control plane quota.
+	ControlPlaneQuotaReplicasAnnotation = "quota.kamaji.clastix.io/replicas"
+	// ControlPlaneQuotaCPUAnnotation overrides, on a namespace, the maximum CPU of the control plane quota, such as 4, or 4000m.
+	ControlPlaneQuotaCPUAnnotation = "quota.kamaji.clastix.io/cpu"
+	// ControlPlaneQuotaMemoryAnnotation overrides, on a namespace, the maximum memory of the control plane quota, such as 8Gi.
+	ControlPlaneQuotaMemoryAnnotation = "quota.kamaji.clastix.io/memory"
+)
+
+// KamajiDefaultsSpec defines the values applied to the new TenantControlPlane objects, when not declared.
+type KamajiDefaultsSpec struct {
+	// DataStore is the default DataStore, it takes precedence over the one provided with the Kamaji --datastore flag.
+	DataStore string `json:"dataStore,omitempty"`
+	// KubernetesVersion is the default Kubernetes version of the Tenant Control Plane.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+	// ImageProfile is the default ImageProfile used to override the component images.
+	ImageProfile string `json:"imageProfile,omitempty"`
+	// ServiceCIDR is the default CIDR for Kubernetes Services: if empty, defaulted to 10.96.0.0/16.
+	ServiceCIDR string `json:"serviceCidr,omitempty"`
+	// PodCIDR is the default CIDR for Kubernetes Pods: if empty, defaulted to 10.244.0.0/16.
+	PodCIDR string `json:"podCidr,omitempty"`
+	// Addons are applied as a whole to the Tenant Control Planes which are not declaring any addon.
+	Addons *AddonsSpec `json:"addons,omitempty"`
+	// TenantNamespace enables the management of the namespaces hosting the Tenant Control Planes,
+	// enforcing the same labels, quota, and limits for every tenant.
+	TenantNamespace *TenantNamespaceSpec `json:"tenantNamespace,omitempty"`
+	// ControlPlaneQuota limits the footprint of the Tenant Control Planes of each namespace, rejecting at admission
+	// the ones exceeding it: the namespaces can override the limits with the quota.kamaji.clastix.io annotations.
+	ControlPlaneQuota *ControlPlaneQuotaSpec `json:"controlPlaneQuota,omitempty"`
+}
+
+// ControlPlaneQuotaSpec defines the maximum footprint of the Tenant Control Planes of a namespace, the unset fields are not limited.
+type ControlPlaneQuotaSpec struct {
+	//+kubebuilder:validation:Minimum=0
+	// Replicas is the maximum number of Control Plane pods, including the controller-manager, and scheduler, ones of the Split topology.
+	Replicas *int32 `json:"replicas,omitempty"`
+	// CPU is the maximum CPU requested by the Control Plane pods: the limit is accounted when a container declares no request.
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+	// Memory is the maximum memory requested by the Control Plane pods: the limit is accounted when a container declares no request.
+	Memory *resource.Quantity `json:"memory,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=privileged;baseline;restricted
+type PodSecurityLevel string
+
+const (
+	PodSecurityLevelPrivileged PodSecurityLevel = "privileged"
+	PodSecurityLevelBaseline   PodSecurityLevel = "baseline"
+	PodSecurityLevelRestricted PodSecurityLevel = "restricted"
+)
+
+// TenantNamespaceSpec defines the policies applied by Kamaji to the namespaces hosting the Tenant Control Planes:
+// the namespace must exist before the Tenant Control Plane creation.
+type TenantNamespaceSpec struct {
+	// Labels are merged with the ones of the namespace.
+	Labels map[string]string `json:"labels,omitempty"`
+	// Annotations are merged with the ones of the namespace.
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// PodSecurity sets the Pod Security Admission labels of the namespace:
+	// the enforced level must admit the Tenant Control Plane pods.
+	PodSecurity *PodSecuritySpec `json:"podSecurity,omitempty"`
+	// ResourceQuota is applied to the namespace with a ResourceQuota named kamaji-tenant.
+	ResourceQuota *corev1.ResourceQuotaSpec `json:"resourceQuota,omitempty"`
+	// LimitRange is applied to the namespace with a LimitRange named kamaji-tenant.
+	LimitRange *corev1.LimitRangeSpec `json:"limitRange,omitempty"`
+}
+
+// PodSecuritySpec defines the Pod Security Admission levels of a namespace.
+type PodSecuritySpec struct {
+	Enforce PodSecurityLevel `json:"enforce,omitempty"`
+	Audit   PodSecurityLevel `json:"audit,omitempty"`
+	Warn    PodSecurityLevel `json:"warn,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,categories=kamaji
+//+kubebuilder:validation:XValidation:rule="self.metadata.name == 'default'",message="the KamajiDefaults object must be named default"
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.kubernetesVersion",description="Default Kubernetes version"
+//+kubebuilder:printcolumn:name="Datastore",type="string",JSONPath=".spec.dataStore",description="Default DataStore"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// KamajiDefaults is the Schema for the kamajidefaults API:
+// the singleton object named default provides the values the mutating webhook applies to new TenantControlPlane objects.
+type KamajiDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KamajiDefaultsSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KamajiDefaultsList contains a list of KamajiDefaults.
+type KamajiDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KamajiDefaults `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KamajiDefaults{}, &KamajiDefaultsList{})
+}
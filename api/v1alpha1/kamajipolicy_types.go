@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.allowedVersions) || has(self.allowedDataStores) || has(self.requiredLabels)",message="at least one of allowedVersions, allowedDataStores, or requiredLabels must be declared"
+
+// KamajiPolicySpec defines the organization policies enforced on the TenantControlPlane objects.
+type KamajiPolicySpec struct {
+	// NamespaceSelector restricts the policy to the TenantControlPlane objects of the matching namespaces:
+	// all the namespaces are matched if empty, allowing to declare, for instance, the DataStore objects allowed per team.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	//+kubebuilder:validation:MinItems=1
+	// AllowedVersions are the Kubernetes versions allowed for the Tenant Control Planes:
+	// a version without the patch, such as v1.32, allows all its patch versions.
+	AllowedVersions []string `json:"allowedVersions,omitempty"`
+	//+kubebuilder:validation:MinItems=1
+	// AllowedDataStores are the DataStore objects the Tenant Control Planes are allowed to use.
+	AllowedDataStores []string `json:"allowedDataStores,omitempty"`
+	//+kubebuilder:validation:MinItems=1
+	// RequiredLabels are the label keys the Tenant Control Planes must declare.
+	RequiredLabels []string `json:"requiredLabels,omitempty"`
+	//+kubebuilder:default={Deny}
+	//+kubebuilder:validation:MinItems=1
+	// ValidationActions define how the policy violations are enforced, such as Deny, Warn, or Audit.
+	ValidationActions []admissionregistrationv1.ValidationAction `json:"validationActions,omitempty"`
+}
+
+// KamajiPolicyStatus defines the observed state of KamajiPolicy.
+type KamajiPolicyStatus struct {
+	// ValidatingAdmissionPolicy is the name of the ValidatingAdmissionPolicy, and of its binding, enforcing the policy.
+	ValidatingAdmissionPolicy string `json:"validatingAdmissionPolicy,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,categories=kamaji
+//+kubebuilder:printcolumn:name="Policy",type="string",JSONPath=".status.validatingAdmissionPolicy",description="ValidatingAdmissionPolicy enforcing the policy"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// KamajiPolicy is the Schema for the kamajipolicies API:
+// it's enforced on the TenantControlPlane objects by a ValidatingAdmissionPolicy managed by Kamaji, complementing the built-in webhook.
+type KamajiPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KamajiPolicySpec   `json:"spec,omitempty"`
+	Status KamajiPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KamajiPolicyList contains a list of KamajiPolicy.
+type KamajiPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KamajiPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KamajiPolicy{}, &KamajiPolicyList{})
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	"strings"
+)
+
+// supportSeverity sorts the support statuses, the most restrictive one taking precedence among the merged catalogs.
+var supportSeverity = map[KubernetesVersionSupport]int{
+	KubernetesVersionSupported:   0,
+	KubernetesVersionDeprecated:  1,
+	KubernetesVersionUnsupported: 2,
+}
+
+// GetSupport returns the support status of the entry, Supported if not set.
+func (in *KubernetesVersionEntry) GetSupport() KubernetesVersionSupport {
+	if len(in.Support) == 0 {
+		return KubernetesVersionSupported
+	}
+
+	return in.Support
+}
+
+// Lookup returns the entry of the given Kubernetes version among the merged catalogs, nil if not listed:
+// the entries declaring the patch version take precedence over the minor ones,
+// and the most restrictive support status wins when a version is declared by several catalogs.
+func (in *KubernetesVersionCatalogList) Lookup(kubernetesVersion string) *KubernetesVersionEntry {
+	desired := strings.TrimPrefix(kubernetesVersion, "v")
+
+	var (
+		found      *KubernetesVersionEntry
+		foundExact bool
+	)
+
+	for i := range in.Items {
+		for j := range in.Items[i].Spec.Versions {
+			entry := &in.Items[i].Spec.Versions[j]
+			version := strings.TrimPrefix(entry.Version, "v")
+
+			exact := version == desired
+			if !exact && (strings.Count(version, ".") != 1 || !strings.HasPrefix(desired, version+".")) {
+				continue
+			}
+
+			switch {
+			case found == nil, exact && !foundExact:
+				found, foundExact = entry, exact
+			case exact == foundExact && supportSeverity[entry.GetSupport()] > supportSeverity[found.GetSupport()]:
+				found = entry
+			}
+		}
+	}
+
+	return found
+}
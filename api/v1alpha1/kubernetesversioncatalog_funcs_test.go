@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KubernetesVersionCatalog", func() {
+	var catalogs *KubernetesVersionCatalogList
+
+	BeforeEach(func() {
+		catalogs = &KubernetesVersionCatalogList{
+			Items: []KubernetesVersionCatalog{
+				{
+					Spec: KubernetesVersionCatalogSpec{
+						Versions: []KubernetesVersionEntry{
+							{Version: "v1.32", Support: KubernetesVersionDeprecated},
+							{Version: "v1.33"},
+							{Version: "v1.33.0", Support: KubernetesVersionUnsupported},
+						},
+					},
+				},
+				{
+					Spec: KubernetesVersionCatalogSpec{
+						Versions: []KubernetesVersionEntry{
+							{Version: "1.33", Components: KubernetesVersionComponents{CoreDNS: "registry.k8s.io/coredns/coredns:v1.12.0"}},
+							{Version: "v1.32", Support: KubernetesVersionUnsupported},
+						},
+					},
+				},
+			},
+		}
+	})
+
+	It("returns nil for the versions not listed", func() {
+		Expect(catalogs.Lookup("v1.31.4")).To(BeNil())
+		Expect(catalogs.Lookup("v1.3.0")).To(BeNil())
+	})
+
+	It("matches the patch versions with the minor entries", func() {
+		entry := catalogs.Lookup("v1.33.2")
+		Expect(entry).ToNot(BeNil())
+		Expect(entry.GetSupport()).To(Equal(KubernetesVersionSupported))
+	})
+
+	It("prefers the entries declaring the patch version", func() {
+		Expect(catalogs.Lookup("1.33.0").GetSupport()).To(Equal(KubernetesVersionUnsupported))
+	})
+
+	It("picks the most restrictive support status among the catalogs", func() {
+		Expect(catalogs.Lookup("v1.32.5").GetSupport()).To(Equal(KubernetesVersionUnsupported))
+	})
+})
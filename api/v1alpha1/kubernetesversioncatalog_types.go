@@ -0,0 +1,85 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Supported;Deprecated;Unsupported
+type KubernetesVersionSupport string
+
+var (
+	// KubernetesVersionSupported allows creating, and upgrading to, the Tenant Control Planes with the given version.
+	KubernetesVersionSupported KubernetesVersionSupport = "Supported"
+	// KubernetesVersionDeprecated prevents the creation of new Tenant Control Planes with the given version,
+	// still allowing the upgrades to it, such as the sequential minor upgrades towards a supported version.
+	KubernetesVersionDeprecated KubernetesVersionSupport = "Deprecated"
+	// KubernetesVersionUnsupported prevents the creation of, and the upgrades to, the Tenant Control Planes with the given version:
+	// the existing ones are left untouched.
+	KubernetesVersionUnsupported KubernetesVersionSupport = "Unsupported"
+)
+
+// KubernetesVersionCatalogSpec defines the supported Kubernetes versions, along with the compatible component images.
+type KubernetesVersionCatalogSpec struct {
+	//+kubebuilder:validation:MinItems=1
+	// Versions is the list of the Kubernetes versions known by the catalog.
+	Versions []KubernetesVersionEntry `json:"versions"`
+}
+
+// KubernetesVersionEntry defines the support status of a Kubernetes version, and its compatible component images.
+type KubernetesVersionEntry struct {
+	//+kubebuilder:validation:Pattern=`^v?[0-9]+\.[0-9]+(\.[0-9]+)?$`
+	// Version is the Kubernetes version, such as v1.33.1:
+	// a version without the patch, such as v1.33, matches all its patch versions, unless declared explicitly.
+	Version string `json:"version"`
+	//+kubebuilder:default=Supported
+	// Support defines whether the version can be used by the Tenant Control Planes.
+	// Supported (default) allows it, Deprecated rejects the new Tenant Control Planes using it, still allowing the upgrades,
+	// Unsupported rejects both the new Tenant Control Planes, and the upgrades.
+	Support KubernetesVersionSupport `json:"support,omitempty"`
+	// Components are the component images compatible with the Kubernetes version.
+	Components KubernetesVersionComponents `json:"components,omitempty"`
+}
+
+// KubernetesVersionComponents defines the images, along with their tag, of the components compatible with a Kubernetes version.
+type KubernetesVersionComponents struct {
+	// CoreDNS image, such as registry.k8s.io/coredns/coredns:v1.12.0.
+	CoreDNS string `json:"coreDNS,omitempty"`
+	// KubeProxy image, such as registry.k8s.io/kube-proxy:v1.33.0: it's meaningful for the entries declaring the patch version.
+	KubeProxy string `json:"kubeProxy,omitempty"`
+	// KonnectivityServer image, such as registry.k8s.io/kas-network-proxy/proxy-server:v0.33.0.
+	KonnectivityServer string `json:"konnectivityServer,omitempty"`
+	// KonnectivityAgent image, such as registry.k8s.io/kas-network-proxy/proxy-agent:v0.33.0.
+	KonnectivityAgent string `json:"konnectivityAgent,omitempty"`
+	// Kine image, such as rancher/kine:v0.13.14-amd64.
+	Kine string `json:"kine,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:scope=Cluster,categories=kamaji,shortName=kvc
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// KubernetesVersionCatalog is the Schema for the kubernetesversioncatalogs API:
+// it maps the Kubernetes versions to their support status, and to the compatible component images.
+// The catalogs are merged, and consulted by the validation webhook upon the TenantControlPlane creations, and upgrades.
+type KubernetesVersionCatalog struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec KubernetesVersionCatalogSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// KubernetesVersionCatalogList contains a list of KubernetesVersionCatalog.
+type KubernetesVersionCatalogList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KubernetesVersionCatalog `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&KubernetesVersionCatalog{}, &KubernetesVersionCatalogList{})
+}
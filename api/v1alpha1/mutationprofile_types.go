@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Deployment;Service;ConfigMap
+
+// MutationTargetKind is the kind of the rendered Tenant Control Plane objects which can be mutated.
+type MutationTargetKind string
+
+const (
+	MutationTargetDeployment MutationTargetKind = "Deployment"
+	MutationTargetService    MutationTargetKind = "Service"
+	MutationTargetConfigMap  MutationTargetKind = "ConfigMap"
+)
+
+// +kubebuilder:validation:Enum=StrategicMerge;JSON
+
+// MutationPatchType is the type of the patch applied to the rendered objects.
+type MutationPatchType string
+
+const (
+	MutationPatchStrategicMerge MutationPatchType = "StrategicMerge"
+	MutationPatchJSON           MutationPatchType = "JSON"
+)
+
+// +kubebuilder:validation:Enum=Fail;Ignore
+
+// MutationFailurePolicy defines how the errors of the mutation webhook are handled.
+type MutationFailurePolicy string
+
+const (
+	MutationFailurePolicyFail   MutationFailurePolicy = "Fail"
+	MutationFailurePolicyIgnore MutationFailurePolicy = "Ignore"
+)
+
+// +kubebuilder:validation:XValidation:rule="has(self.patches) || has(self.webhook)",message="at least one of patches or webhook must be declared"
+
+// MutationProfileSpec defines the desired state of MutationProfile.
+type MutationProfileSpec struct {
+	// Patches are applied in-process to the matching objects, in the declared order.
+	Patches []MutationPatch `json:"patches,omitempty"`
+	// Webhook is called with each matching object once the patches are applied, returning the mutated object.
+	Webhook *MutationWebhook `json:"webhook,omitempty"`
+}
+
+// MutationTarget selects the rendered objects a mutation is applied to.
+type MutationTarget struct {
+	Kind MutationTargetKind `json:"kind"`
+	// Name is a glob pattern matching the object name, such as *-scheduler-configuration:
+	// all the objects of the given kind are matched if empty.
+	Name string `json:"name,omitempty"`
+}
+
+// MutationPatch defines a patch applied to the rendered objects, such as injecting a sidecar container.
+type MutationPatch struct {
+	Target MutationTarget `json:"target"`
+	//+kubebuilder:default=StrategicMerge
+	Type MutationPatchType `json:"type,omitempty"`
+	//+kubebuilder:validation:MinLength=1
+	// Patch is the YAML, or JSON, encoded patch: a JSON patch must be expressed as a list of RFC 6902 operations.
+	Patch string `json:"patch"`
+}
+
+// MutationWebhook defines the external endpoint mutating the rendered objects:
+// it receives a POST request with the Tenant Control Plane reference, and the object, replying with the mutated object,
+// or with no content when no changes are required.
+type MutationWebhook struct {
+	//+kubebuilder:validation:Pattern=`^https://`
+	URL string `json:"url"`
+	// CABundle is the PEM encoded CA used to verify the webhook serving certificate,
+	// the system trust store is used if empty.
+	CABundle []byte `json:"caBundle,omitempty"`
+	//+kubebuilder:default="10s"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
+	// Kinds restricts the objects sent to the webhook: all the supported kinds are sent if empty.
+	Kinds []MutationTargetKind `json:"kinds,omitempty"`
+	//+kubebuilder:default=Fail
+	// FailurePolicy defines whether the webhook errors block the reconciliation, or are ignored leaving the object unchanged.
+	FailurePolicy MutationFailurePolicy `json:"failurePolicy,omitempty"`
+}
+
+// MutationProfileStatus defines the observed state of MutationProfile.
+type MutationProfileStatus struct {
+	// List of the Tenant Control Planes, namespaced named, using this mutation profile.
+	UsedBy []string `json:"usedBy,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,categories=kamaji
+//+kubebuilder:printcolumn:name="Webhook",type="string",JSONPath=".spec.webhook.url",description="Mutation webhook URL"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// MutationProfile is the Schema for the mutationprofiles API:
+// it mutates the rendered Tenant Control Plane Deployment, Service, and ConfigMap objects right before they're applied.
+type MutationProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MutationProfileSpec   `json:"spec,omitempty"`
+	Status MutationProfileStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// MutationProfileList contains a list of MutationProfile.
+type MutationProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MutationProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&MutationProfile{}, &MutationProfileList{})
+}
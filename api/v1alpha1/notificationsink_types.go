@@ -0,0 +1,116 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +kubebuilder:validation:Enum=Webhook;Slack;PagerDuty
+type NotificationSinkType string
+
+const (
+	NotificationSinkTypeWebhook   NotificationSinkType = "Webhook"
+	NotificationSinkTypeSlack     NotificationSinkType = "Slack"
+	NotificationSinkTypePagerDuty NotificationSinkType = "PagerDuty"
+)
+
+// +kubebuilder:validation:Enum=TenantReady;CertificateExpiring;MigrationFinished;SootCrashLooping
+type NotificationEvent string
+
+const (
+	// NotificationEventTenantReady is sent when a Tenant Control Plane becomes ready, such as upon its provisioning, or an upgrade.
+	NotificationEventTenantReady NotificationEvent = "TenantReady"
+	// NotificationEventCertificateExpiring is sent when a certificate is about to expire, triggering its rotation.
+	NotificationEventCertificateExpiring NotificationEvent = "CertificateExpiring"
+	// NotificationEventMigrationFinished is sent when a Tenant Control Plane is ready after the migration to another DataStore.
+	NotificationEventMigrationFinished NotificationEvent = "MigrationFinished"
+	// NotificationEventSootCrashLooping is sent when the soot manager of a Tenant Control Plane repeatedly fails.
+	NotificationEventSootCrashLooping NotificationEvent = "SootCrashLooping"
+)
+
+// +kubebuilder:validation:XValidation:rule="self.type == 'PagerDuty' || has(self.url) || has(self.urlSecretRef)",message="url, or urlSecretRef, is required for the Webhook, and Slack, sinks"
+// +kubebuilder:validation:XValidation:rule="self.type != 'PagerDuty' || has(self.routingKeySecretRef)",message="routingKeySecretRef is required for the PagerDuty sinks"
+
+// NotificationSinkSpec defines the desired state of NotificationSink.
+type NotificationSinkSpec struct {
+	//+kubebuilder:default=Webhook
+	// Type defines the receiver, determining the default payload: the Webhook one receives the JSON encoded notification,
+	// the Slack one an incoming webhook message, and the PagerDuty one an Events API v2 alert.
+	Type NotificationSinkType `json:"type,omitempty"`
+	// URL is the endpoint receiving the notifications with a POST request.
+	// It defaults to the Events API v2 endpoint for the PagerDuty sinks.
+	URL string `json:"url,omitempty"`
+	// URLSecretRef references the Secret key containing the URL, such as the Slack incoming webhook ones embedding a token:
+	// it has precedence over the URL value.
+	URLSecretRef *SecretReference `json:"urlSecretRef,omitempty"`
+	// RoutingKeySecretRef references the Secret key containing the PagerDuty integration key.
+	RoutingKeySecretRef *SecretReference `json:"routingKeySecretRef,omitempty"`
+	// Headers are added to the requests, such as the authorization ones.
+	Headers map[string]string `json:"headers,omitempty"`
+	// Template is the Go template rendering the request body, replacing the default one of the sink type.
+	// It's executed with the notification fields: .Event, .Severity, .Message, .Time, .TenantControlPlane.Name,
+	// .TenantControlPlane.Namespace, and .RoutingKey, along with the json function quoting the given value.
+	Template string `json:"template,omitempty"`
+	// Events are the notified events, all of them if empty.
+	Events []NotificationEvent `json:"events,omitempty"`
+	// Namespaces restricts the notifications to the Tenant Control Planes of the given Namespaces, all of them if empty.
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Selector restricts the notifications to the Tenant Control Planes matching the given labels, all of them if empty.
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+	//+kubebuilder:default="15m"
+	// Throttle is the minimum interval between the notifications of the same event for the same Tenant Control Plane,
+	// preventing the repeated ones, such as the crash loops, from flooding the receiver.
+	Throttle metav1.Duration `json:"throttle,omitempty"`
+}
+
+// NotificationDeliveryStatus reports the outcome of a delivery.
+type NotificationDeliveryStatus struct {
+	Event NotificationEvent `json:"event"`
+	// TenantControlPlane is the namespaced name of the notified Tenant Control Plane.
+	TenantControlPlane string      `json:"tenantControlPlane"`
+	Time               metav1.Time `json:"time"`
+	Succeeded          bool        `json:"succeeded"`
+	// Error reports the delivery failure, such as the receiver response status.
+	Error string `json:"error,omitempty"`
+}
+
+// NotificationSinkStatus defines the observed state of NotificationSink.
+type NotificationSinkStatus struct {
+	// LastDelivery reports the outcome of the last delivered notification.
+	LastDelivery *NotificationDeliveryStatus `json:"lastDelivery,omitempty"`
+	// LastFailure reports the last failed delivery, retained once the sink recovers.
+	LastFailure *NotificationDeliveryStatus `json:"lastFailure,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster,categories=kamaji
+//+kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="Receiver type"
+//+kubebuilder:printcolumn:name="Last Event",type="string",JSONPath=".status.lastDelivery.event",description="Last delivered event"
+//+kubebuilder:printcolumn:name="Succeeded",type="boolean",JSONPath=".status.lastDelivery.succeeded",description="Last delivery outcome"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// NotificationSink is the Schema for the notificationsinks API:
+// it delivers the selected lifecycle events of the Tenant Control Planes to an external receiver, such as a webhook, Slack, or PagerDuty.
+type NotificationSink struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NotificationSinkSpec   `json:"spec,omitempty"`
+	Status NotificationSinkStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// NotificationSinkList contains a list of NotificationSink.
+type NotificationSinkList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NotificationSink `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&NotificationSink{}, &NotificationSinkList{})
+}
@@ -7,4 +7,41 @@ const (
 	// PausedReconciliationAnnotation is an annotation that can be applied to
 	// Tenant Control Plane objects to prevent the controller from processing such a resource.
 	PausedReconciliationAnnotation = "kamaji.clastix.io/paused"
+	// DeletionProtectionAnnotation, when set to true, requires the explicit confirmation of the Tenant Control Plane deletion
+	// before wiping its DataStore contents.
+	DeletionProtectionAnnotation = "kamaji.clastix.io/deletion-protection"
+	// DeletionConfirmationAnnotation confirms the deletion of a protected Tenant Control Plane, its value must be the Tenant Control Plane name.
+	DeletionConfirmationAnnotation = "kamaji.clastix.io/confirm-deletion"
+	// OperatorUpgradeConfirmationAnnotation confirms the changes rendered by a new Kamaji version restarting the Control Plane pods,
+	// with the staged operator upgrades enabled: its value must be the Kamaji version.
+	OperatorUpgradeConfirmationAnnotation = "kamaji.clastix.io/confirm-operator-upgrade"
+	// RollbackToAnnotation restores the Tenant Control Plane specification of the given revision, such as 3:
+	// the annotation is removed once processed.
+	RollbackToAnnotation = "kamaji.clastix.io/rollback-to"
+	// ForceDowngradeAnnotation allows downgrading the Tenant Control Plane to the previous Kubernetes minor version,
+	// its value must be the desired version: the downgrade requires a fresh backup, declared with the DowngradeBackupAnnotation.
+	ForceDowngradeAnnotation = "kamaji.clastix.io/force-downgrade"
+	// DowngradeBackupAnnotation references the backup of the Tenant Control Plane DataStore taken before the downgrade,
+	// such as the Velero Backup name, along with the DowngradeBackupTimestampAnnotation.
+	DowngradeBackupAnnotation = "kamaji.clastix.io/downgrade-backup"
+	// DowngradeBackupTimestampAnnotation is the RFC 3339 completion time of the backup referenced by the DowngradeBackupAnnotation.
+	DowngradeBackupTimestampAnnotation = "kamaji.clastix.io/downgrade-backup-timestamp"
+	// ClientQPSAnnotation overrides the queries per second of the clients used by Kamaji to interact with the Tenant Cluster,
+	// such as the soot manager ones, allowing to preserve a small Tenant Control Plane API Server.
+	ClientQPSAnnotation = "kamaji.clastix.io/client-qps"
+	// ClientBurstAnnotation overrides the burst of the clients used by Kamaji to interact with the Tenant Cluster.
+	ClientBurstAnnotation = "kamaji.clastix.io/client-burst"
+	// MaintenanceAcknowledgedAnnotation is applied by the Tenant Cluster automation to the maintenance ConfigMap,
+	// acknowledging the notified operation: its value must be the ID of the operation.
+	MaintenanceAcknowledgedAnnotation = "kamaji.clastix.io/maintenance-acknowledged"
+	// SupportBundleAnnotation requests the collection of the Tenant Control Plane support bundle,
+	// stored in the <name>-support-bundle Secret: the annotation is removed once processed.
+	SupportBundleAnnotation = "kamaji.clastix.io/support-bundle"
+	// ClonedFromAnnotation is applied by kamajictl to the cloned Tenant Control Plane,
+	// its value is the namespaced name of the source one.
+	ClonedFromAnnotation = "kamaji.clastix.io/cloned-from"
+	// MigrationUnfreezeAnnotation is the break-glass procedure removing the webhook freezing the Tenant Cluster
+	// during a DataStore migration, such as when it's stuck: the writes performed meanwhile may be lost.
+	// The annotation must be removed once the migration has been fixed.
+	MigrationUnfreezeAnnotation = "kamaji.clastix.io/migration-unfreeze"
 )
@@ -0,0 +1,8 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+// Hub marks the v1alpha1 TenantControlPlane as the conversion hub, being the storage version:
+// the other versions are converted from, and to, it.
+func (*TenantControlPlane) Hub() {}
@@ -7,11 +7,14 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
@@ -37,6 +40,118 @@ func (in *TenantControlPlane) AssignedControlPlaneAddress() (string, int32, erro
 	return address, int32(port), nil
 }
 
+// GetDeletionPolicy returns the deletion policy of the Tenant Control Plane, Delete by default.
+func (in *TenantControlPlane) GetDeletionPolicy() DeletionPolicy {
+	if len(in.Spec.DeletionPolicy) == 0 {
+		return DeletionPolicyDelete
+	}
+
+	return in.Spec.DeletionPolicy
+}
+
+// IsDeletionConfirmed returns false when the Tenant Control Plane is protected, and its DataStore contents would be deleted,
+// until the deletion is confirmed by annotating it with its own name.
+func (in *TenantControlPlane) IsDeletionConfirmed() bool {
+	if in.GetDeletionPolicy() != DeletionPolicyDelete {
+		return true
+	}
+
+	if protected, _ := strconv.ParseBool(in.GetAnnotations()[DeletionProtectionAnnotation]); !protected {
+		return true
+	}
+
+	return in.GetAnnotations()[DeletionConfirmationAnnotation] == in.GetName()
+}
+
+// AdoptsDataStore returns true when the Tenant Control Plane re-adopts the state stored in an existing DataStore schema.
+func (in *TenantControlPlane) AdoptsDataStore() bool {
+	return in.Spec.DataStoreLifecycle != nil && in.Spec.DataStoreLifecycle.AdoptExisting
+}
+
+// IsFIPS returns true when the Tenant Control Plane runs in FIPS mode.
+func (in *TenantControlPlane) IsFIPS() bool {
+	return in.Spec.Compliance != nil && in.Spec.Compliance.FIPS
+}
+
+// HasSplitTopology returns true when the controller-manager, and the scheduler, run in dedicated Deployments.
+func (in *TenantControlPlane) HasSplitTopology() bool {
+	return in.Spec.ControlPlane.Deployment.ComponentTopology == ComponentTopologySplit
+}
+
+// TargetCluster returns the remote cluster running the Control Plane pods, nil when they run in the management cluster.
+func (in *TenantControlPlane) TargetCluster() *TargetClusterSpec {
+	return in.Spec.ControlPlane.Deployment.TargetCluster
+}
+
+// HostNetwork returns the host network mode of the Control Plane pods, nil when they run in the pod network.
+func (in *TenantControlPlane) HostNetwork() *HostNetworkSpec {
+	return in.Spec.ControlPlane.Deployment.HostNetwork
+}
+
+// APIServerPort returns the port the kube-apiserver is listening to:
+// the allocated host port in the host network mode, the declared one otherwise.
+func (in *TenantControlPlane) APIServerPort() int32 {
+	if in.HostNetwork() != nil && in.Status.Kubernetes.HostNetwork != nil {
+		return in.Status.Kubernetes.HostNetwork.APIServerPort
+	}
+
+	return in.Spec.NetworkProfile.Port
+}
+
+// SplitComponent returns the Deployment settings of the given component with the Split topology, nil if not declared.
+func (in *TenantControlPlane) SplitComponent(component SplitComponentName) *SplitComponentSpec {
+	components := in.Spec.ControlPlane.Deployment.Components
+	if components == nil {
+		return nil
+	}
+
+	switch component {
+	case SplitComponentControllerManager:
+		return components.ControllerManager
+	case SplitComponentScheduler:
+		return components.Scheduler
+	default:
+		return nil
+	}
+}
+
+// RunningKubernetesVersion returns the Kubernetes version the Control Plane has been rolled out with,
+// falling back to the desired one upon provisioning: the node addons must not be newer than the API Server.
+func (in *TenantControlPlane) RunningKubernetesVersion() string {
+	if len(in.Status.Kubernetes.Version.Version) > 0 {
+		return in.Status.Kubernetes.Version.Version
+	}
+
+	return in.Spec.Kubernetes.Version
+}
+
+// DedicatedDataStoreName returns the name of the DataStore generated for the dedicated etcd cluster,
+// or an empty string when the Tenant Control Plane is backed by a shared DataStore.
+// Since the DataStore is cluster-scoped, the name is derived from the Tenant Control Plane UID.
+func (in *TenantControlPlane) DedicatedDataStoreName() string {
+	if in.Spec.DedicatedDataStore == nil {
+		return ""
+	}
+
+	return "dedicated-" + string(in.GetUID())
+}
+
+// SkippedKubeadmPhases returns the sorted kubeadm phases which must not be performed against the Tenant Cluster:
+// the declared ones, along with the bootstrap ones of an adopted DataStore state.
+func (in *TenantControlPlane) SkippedKubeadmPhases() []KubeadmPhaseName {
+	phases := sets.New[KubeadmPhaseName]()
+
+	if in.Spec.Bootstrap != nil {
+		phases.Insert(in.Spec.Bootstrap.SkipPhases...)
+	}
+
+	if in.AdoptsDataStore() {
+		phases.Insert(KubeadmPhaseBootstrapToken, KubeadmPhaseClusterAdminRBAC)
+	}
+
+	return sets.List(phases)
+}
+
 // DeclaredControlPlaneAddress returns the desired Tenant Control Plane address.
 // In case of dynamic allocation, e.g. using a Load Balancer, it queries the API Server looking for the allocated IP.
 // When an IP has not been yet assigned, or it is expected, an error is returned.
@@ -95,3 +210,289 @@ func getLoadBalancerAddress(ingress []corev1.LoadBalancerIngress) (string, error
 
 	return "", kamajierrors.MissingValidIPError{}
 }
+
+// GetPhase computes the phase of the Tenant Control Plane according to its status:
+// during the provisioning, the completion of certificates and DataStore setup is reported,
+// otherwise the Kubernetes version status is used.
+func (in *TenantControlPlane) GetPhase() TenantControlPlanePhase {
+	status := VersionProvisioning
+	if in.Status.Kubernetes.Version.Status != nil {
+		status = *in.Status.Kubernetes.Version.Status
+	}
+
+	switch status {
+	case VersionSleeping:
+		return PhaseSleeping
+	case VersionMigrating:
+		return PhaseMigrating
+	case VersionUpgrading:
+		return PhaseUpgrading
+	case VersionReady:
+		return PhaseReady
+	case VersionNotReady:
+		return PhaseNotReady
+	}
+
+	certificates := in.Status.Certificates
+
+	switch {
+	case len(in.Status.Storage.Setup.Checksum) > 0:
+		return PhaseDatastoreReady
+	case len(certificates.CA.SecretName) > 0 && len(certificates.APIServer.SecretName) > 0 && len(certificates.SA.SecretName) > 0:
+		return PhaseCertificatesReady
+	default:
+		return PhaseProvisioning
+	}
+}
+
+// GetDriftDetection returns the drift detection mode, defaulting to Enabled when no sync policy is declared.
+func (in AddonApplyTrait) GetDriftDetection() AddonDriftDetection {
+	if in.SyncPolicy == nil || len(in.SyncPolicy.DriftDetection) == 0 {
+		return AddonDriftDetectionEnabled
+	}
+
+	return in.SyncPolicy.DriftDetection
+}
+
+// GetReconcileInterval returns the re-sync period of the addon, zero when no periodic re-sync is required.
+func (in AddonApplyTrait) GetReconcileInterval() time.Duration {
+	if in.SyncPolicy == nil || in.SyncPolicy.ReconcileInterval == nil {
+		return 0
+	}
+
+	return in.SyncPolicy.ReconcileInterval.Duration
+}
+
+// PoolConfig returns the kubelet configuration of the given pool, its fields overriding the ones of the Tenant Control Plane.
+func (in KubeletSpec) PoolConfig(pool KubeletPoolSpec) *KubeletConfigSpec {
+	if in.Config == nil && pool.Config == nil {
+		return nil
+	}
+
+	config := &KubeletConfigSpec{}
+	if in.Config != nil {
+		config = in.Config.DeepCopy()
+	}
+
+	if pool.Config == nil {
+		return config
+	}
+
+	if pool.Config.MaxPods != nil {
+		config.MaxPods = pool.Config.MaxPods
+	}
+
+	if pool.Config.SerializeImagePulls != nil {
+		config.SerializeImagePulls = pool.Config.SerializeImagePulls
+	}
+
+	if pool.Config.MaxParallelImagePulls != nil {
+		config.MaxParallelImagePulls = pool.Config.MaxParallelImagePulls
+	}
+
+	if len(pool.Config.EvictionHard) > 0 {
+		config.EvictionHard = pool.Config.EvictionHard
+	}
+
+	if len(pool.Config.EvictionSoft) > 0 {
+		config.EvictionSoft = pool.Config.EvictionSoft
+	}
+
+	if len(pool.Config.EvictionSoftGracePeriod) > 0 {
+		config.EvictionSoftGracePeriod = pool.Config.EvictionSoftGracePeriod
+	}
+
+	return config
+}
+
+// GracefulShutdown returns the graceful shutdown settings of the API server, nil if not declared.
+func (in KubernetesSpec) GracefulShutdown() *APIServerGracefulShutdownSpec {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.GracefulShutdown
+}
+
+// NodeConnectivity returns the declared API server node connectivity, if any.
+func (in KubernetesSpec) NodeConnectivity() *APIServerNodeConnectivitySpec {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.NodeConnectivity
+}
+
+// DefaultServiceAccountIssuer is the issuer of the service account tokens when not declared.
+const DefaultServiceAccountIssuer = "https://kubernetes.default.svc.cluster.local"
+
+// ServiceAccountIssuers returns the issuers of the service account tokens accepted by the API server,
+// the first one being used to issue the tokens.
+func (in KubernetesSpec) ServiceAccountIssuers() []string {
+	issuers := []string{DefaultServiceAccountIssuer}
+
+	if in.APIServer == nil {
+		return issuers
+	}
+
+	if len(in.APIServer.ServiceAccountIssuer) > 0 {
+		issuers[0] = in.APIServer.ServiceAccountIssuer
+	}
+
+	for _, issuer := range in.APIServer.AdditionalServiceAccountIssuers {
+		if !slices.Contains(issuers, issuer) {
+			issuers = append(issuers, issuer)
+		}
+	}
+
+	return issuers
+}
+
+// APIAudiences returns the declared API audiences, if any.
+func (in KubernetesSpec) APIAudiences() []string {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.APIAudiences
+}
+
+// OIDCDiscovery returns the declared publication of the API server OpenID discovery documents, if any.
+func (in KubernetesSpec) OIDCDiscovery() *APIServerOIDCDiscoverySpec {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.OIDCDiscovery
+}
+
+// APIServerTLS returns the declared TLS policy of the API server, if any.
+func (in KubernetesSpec) APIServerTLS() *APIServerTLSSpec {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.TLS
+}
+
+// APIServerStorage returns the watch cache, and the compaction, settings of the API server, if any.
+func (in KubernetesSpec) APIServerStorage() *APIServerStorageSpec {
+	if in.APIServer == nil {
+		return nil
+	}
+
+	return in.APIServer.Storage
+}
+
+// JWKSURI returns the public URL of the JSON Web Key Set serving the service account token signing keys.
+func (in *APIServerOIDCDiscoverySpec) JWKSURI() string {
+	return "https://" + in.Hostname + "/openid/v1/jwks"
+}
+
+// GetTerminationGracePeriodSeconds returns the declared termination grace period, or the one covering the drain,
+// the shutdown delay, and the 60 seconds of the API server default request timeout.
+func (in *APIServerGracefulShutdownSpec) GetTerminationGracePeriodSeconds() int64 {
+	if in.TerminationGracePeriodSeconds != nil {
+		return *in.TerminationGracePeriodSeconds
+	}
+
+	return int64(in.DrainSeconds) + int64(in.ShutdownDelaySeconds) + 60
+}
+
+// APIServerFeatureGates returns the feature gates of the API Server, the component specific ones overriding the global ones.
+func (in KubernetesSpec) APIServerFeatureGates() map[string]bool {
+	return in.mergeFeatureGates(in.ComponentFeatureGates.APIServer)
+}
+
+// ControllerManagerFeatureGates returns the feature gates of the Controller Manager, the component specific ones overriding the global ones.
+func (in KubernetesSpec) ControllerManagerFeatureGates() map[string]bool {
+	return in.mergeFeatureGates(in.ComponentFeatureGates.ControllerManager)
+}
+
+// SchedulerFeatureGates returns the feature gates of the Scheduler, the component specific ones overriding the global ones.
+func (in KubernetesSpec) SchedulerFeatureGates() map[string]bool {
+	return in.mergeFeatureGates(in.ComponentFeatureGates.Scheduler)
+}
+
+// KubeletFeatureGates returns the feature gates of the kubelet, the component specific ones overriding the global ones.
+func (in KubernetesSpec) KubeletFeatureGates() map[string]bool {
+	return in.mergeFeatureGates(in.ComponentFeatureGates.Kubelet)
+}
+
+func (in KubernetesSpec) mergeFeatureGates(overrides map[string]bool) map[string]bool {
+	if len(in.FeatureGates) == 0 && len(overrides) == 0 {
+		return nil
+	}
+
+	gates := make(map[string]bool, len(in.FeatureGates)+len(overrides))
+
+	for _, m := range []map[string]bool{in.FeatureGates, overrides} {
+		for gate, enabled := range m {
+			gates[gate] = enabled
+		}
+	}
+
+	return gates
+}
+
+// GetEgressSelections returns the traffic types proxied through Konnectivity, defaulting to the cluster one.
+func (in *KonnectivitySpec) GetEgressSelections() []KonnectivityEgressSelection {
+	if in.EgressSelector == nil || len(in.EgressSelector.Selections) == 0 {
+		return []KonnectivityEgressSelection{KonnectivityEgressSelectionCluster}
+	}
+
+	return in.EgressSelector.Selections
+}
+
+// HasDeploymentPlacement returns true when the Konnectivity server runs in a dedicated Deployment.
+func (in *KonnectivitySpec) HasDeploymentPlacement() bool {
+	return in.KonnectivityServerSpec.Placement == KonnectivityServerPlacementDeployment
+}
+
+// KonnectivityServerReplicas returns the replicas of the Konnectivity servers, used by the agents to connect to each of them:
+// the declared ones with the Deployment placement, the kube-apiserver ones otherwise.
+func (in *TenantControlPlane) KonnectivityServerReplicas() int32 {
+	konnectivity := in.Spec.Addons.Konnectivity
+	if konnectivity != nil && konnectivity.HasDeploymentPlacement() {
+		if deployment := konnectivity.KonnectivityServerSpec.Deployment; deployment != nil && deployment.Replicas != nil {
+			return *deployment.Replicas
+		}
+
+		return 2
+	}
+
+	if replicas := in.Spec.ControlPlane.Deployment.Replicas; replicas != nil {
+		return *replicas
+	}
+
+	return 1
+}
+
+// KonnectivityServerAddress returns the address the agents are connecting to: with the Deployment placement,
+// the declared one, or the load balancer address of the Konnectivity server Service, falling back to the Tenant Control Plane one.
+func (in *TenantControlPlane) KonnectivityServerAddress() (string, error) {
+	konnectivity := in.Spec.Addons.Konnectivity
+	if konnectivity == nil || !konnectivity.HasDeploymentPlacement() {
+		address, _, err := in.AssignedControlPlaneAddress()
+
+		return address, err
+	}
+
+	if deployment := konnectivity.KonnectivityServerSpec.Deployment; deployment != nil && len(deployment.Address) > 0 {
+		return deployment.Address, nil
+	}
+
+	for _, ingress := range in.Status.Addons.Konnectivity.Service.LoadBalancer.Ingress {
+		if len(ingress.IP) > 0 {
+			return ingress.IP, nil
+		}
+
+		if len(ingress.Hostname) > 0 {
+			return ingress.Hostname, nil
+		}
+	}
+
+	address, _, err := in.AssignedControlPlaneAddress()
+
+	return address, err
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("TenantControlPlane deletion", func() {
+	var tcp *TenantControlPlane
+
+	BeforeEach(func() {
+		tcp = &TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "tenant-00",
+				Namespace:   "default",
+				Annotations: map[string]string{DeletionProtectionAnnotation: "true"},
+			},
+		}
+	})
+
+	It("defaults to the Delete policy", func() {
+		Expect(tcp.GetDeletionPolicy()).To(Equal(DeletionPolicyDelete))
+	})
+
+	It("requires the confirmation of the protected instances", func() {
+		Expect(tcp.IsDeletionConfirmed()).To(BeFalse())
+
+		tcp.Annotations[DeletionConfirmationAnnotation] = "tenant-01"
+		Expect(tcp.IsDeletionConfirmed()).To(BeFalse())
+
+		tcp.Annotations[DeletionConfirmationAnnotation] = "tenant-00"
+		Expect(tcp.IsDeletionConfirmed()).To(BeTrue())
+	})
+
+	It("doesn't require the confirmation when the DataStore contents are retained", func() {
+		tcp.Spec.DeletionPolicy = DeletionPolicyRetain
+		Expect(tcp.IsDeletionConfirmed()).To(BeTrue())
+	})
+
+	It("doesn't require the confirmation of the unprotected instances", func() {
+		tcp.Annotations = nil
+		Expect(tcp.IsDeletionConfirmed()).To(BeTrue())
+	})
+})
+
+var _ = Describe("TenantControlPlane DataStore adoption", func() {
+	It("doesn't adopt the DataStore by default", func() {
+		Expect((&TenantControlPlane{}).AdoptsDataStore()).To(BeFalse())
+	})
+
+	It("adopts the DataStore when declared", func() {
+		tcp := &TenantControlPlane{Spec: TenantControlPlaneSpec{DataStoreLifecycle: &DataStoreLifecycleSpec{AdoptExisting: true}}}
+		Expect(tcp.AdoptsDataStore()).To(BeTrue())
+	})
+})
+
+var _ = Describe("TenantControlPlane dedicated DataStore", func() {
+	It("has no dedicated DataStore by default", func() {
+		Expect((&TenantControlPlane{}).DedicatedDataStoreName()).To(BeEmpty())
+	})
+
+	It("derives the dedicated DataStore name from the UID", func() {
+		tcp := &TenantControlPlane{Spec: TenantControlPlaneSpec{DedicatedDataStore: &DedicatedDataStoreSpec{}}}
+		tcp.SetUID("0a8d7f2e-4a16-4c5b-9f6d-2b1c8a4e7d90")
+		Expect(tcp.DedicatedDataStoreName()).To(Equal("dedicated-0a8d7f2e-4a16-4c5b-9f6d-2b1c8a4e7d90"))
+	})
+})
+
+var _ = Describe("TenantControlPlane kubeadm phases", func() {
+	It("performs all the phases by default", func() {
+		Expect((&TenantControlPlane{}).SkippedKubeadmPhases()).To(BeEmpty())
+	})
+
+	It("skips the declared, and the bootstrap phases of an adopted DataStore", func() {
+		tcp := &TenantControlPlane{
+			Spec: TenantControlPlaneSpec{
+				DataStoreLifecycle: &DataStoreLifecycleSpec{AdoptExisting: true},
+				Bootstrap:          &BootstrapSpec{SkipPhases: []KubeadmPhaseName{KubeadmPhaseUploadConfigKubelet, KubeadmPhaseBootstrapToken}},
+			},
+		}
+
+		Expect(tcp.SkippedKubeadmPhases()).To(Equal([]KubeadmPhaseName{KubeadmPhaseBootstrapToken, KubeadmPhaseClusterAdminRBAC, KubeadmPhaseUploadConfigKubelet}))
+	})
+})
@@ -53,6 +53,20 @@ type CertificatesStatus struct {
 	FrontProxyClient       CertificatePrivateKeyPairStatus `json:"frontProxyClient,omitempty"`
 	SA                     PublicKeyPrivateKeyPairStatus   `json:"sa,omitempty"`
 	ETCD                   *ETCDCertificatesStatus         `json:"etcd,omitempty"`
+	// CRL contains the status of the Certificate Revocation List published for the certificates issued by the Tenant CA.
+	CRL *CertificateRevocationListStatus `json:"crl,omitempty"`
+}
+
+// CertificateRevocationListStatus defines the observed state of the Tenant CA Certificate Revocation List.
+type CertificateRevocationListStatus struct {
+	// SecretName is the name of the Secret containing the PEM encoded CRL.
+	SecretName string `json:"secretName,omitempty"`
+	// Checksum of the revoked serial numbers.
+	Checksum string `json:"checksum,omitempty"`
+	// Number is the monotonically increasing CRL number.
+	Number     int64       `json:"number,omitempty"`
+	NextUpdate metav1.Time `json:"nextUpdate,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 type DataStoreCertificateStatus struct {
@@ -80,6 +94,43 @@ type StorageStatus struct {
 	Config        DataStoreConfigStatus      `json:"config,omitempty"`
 	Setup         DataStoreSetupStatus       `json:"setup,omitempty"`
 	Certificate   DataStoreCertificateStatus `json:"certificate,omitempty"`
+	// Dedicated reports the etcd cluster provisioned for the Tenant Control Plane, when using a dedicated DataStore.
+	Dedicated *DedicatedDataStoreStatus `json:"dedicated,omitempty"`
+	// SlowQueries aggregates the kine slow queries, when enabled by the DataStore.
+	SlowQueries *SlowQueriesStatus `json:"slowQueries,omitempty"`
+}
+
+// SlowQueriesStatus aggregates the slow queries logged by the kine containers of the Tenant Control Plane.
+type SlowQueriesStatus struct {
+	// Count is the number of the slow queries collected since the reporting has been enabled.
+	Count int64 `json:"count,omitempty"`
+	// TopQueries are the slowest normalized queries, ordered by their maximum duration.
+	TopQueries []SlowQueryStatus `json:"topQueries,omitempty"`
+}
+
+// SlowQueryStatus reports the occurrences of a normalized slow query.
+type SlowQueryStatus struct {
+	// Query is the SQL statement, whitespace normalized, and truncated.
+	Query string `json:"query"`
+	// Count is the number of the occurrences of the query.
+	Count int64 `json:"count"`
+	// MaxDuration is the longest duration of the query.
+	MaxDuration metav1.Duration `json:"maxDuration"`
+	// LastSeen is the time the query has been started the last time.
+	LastSeen metav1.Time `json:"lastSeen"`
+}
+
+// DedicatedDataStoreStatus reports the members, and the snapshots, of the dedicated etcd cluster.
+type DedicatedDataStoreStatus struct {
+	// Members are the names of the etcd voting members: once populated, the cluster has been bootstrapped,
+	// and the further members join the existing cluster.
+	Members []string `json:"members,omitempty"`
+	// Learners are the names of the members joining the cluster, not yet promoted to voting members.
+	Learners []string `json:"learners,omitempty"`
+	// LastSnapshot is the name of the persistent volume claim holding the latest snapshot.
+	LastSnapshot string `json:"lastSnapshot,omitempty"`
+	// LastSnapshotTime is the time the latest snapshot has been started.
+	LastSnapshotTime metav1.Time `json:"lastSnapshotTime,omitempty"`
 }
 
 // KubeconfigStatus contains information about the generated kubeconfig.
@@ -94,6 +145,9 @@ type KubeconfigsStatus struct {
 	Admin             KubeconfigStatus `json:"admin,omitempty"`
 	ControllerManager KubeconfigStatus `json:"controllerManager,omitempty"`
 	Scheduler         KubeconfigStatus `json:"scheduler,omitempty"`
+	// Soot contains the status of the scoped kubeconfig used by Kamaji to reconcile the Tenant Cluster resources,
+	// populated when running in least-privilege mode.
+	Soot KubeconfigStatus `json:"soot,omitempty"`
 }
 
 // KubeadmConfigStatus contains the status of the configuration required by kubeadm.
@@ -104,6 +158,39 @@ type KubeadmConfigStatus struct {
 	Checksum string `json:"checksum,omitempty"`
 }
 
+// SchedulerConfigurationStatus contains the status of the ConfigMap storing the scheduler configuration.
+type SchedulerConfigurationStatus struct {
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// APIVersion of the rendered KubeSchedulerConfiguration, according to the Tenant Control Plane version.
+	APIVersion string      `json:"apiVersion,omitempty"`
+	Checksum   string      `json:"checksum,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// APIServerTracingStatus contains the status of the ConfigMap storing the API server tracing configuration.
+type APIServerTracingStatus struct {
+	ConfigMapName string      `json:"configMapName,omitempty"`
+	Checksum      string      `json:"checksum,omitempty"`
+	LastUpdate    metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// APIServerNodeConnectivityStatus contains the status of the ConfigMap storing the API server egress selection
+// of the HTTP CONNECT proxy reaching the kubelets.
+type APIServerNodeConnectivityStatus struct {
+	ConfigMapName string      `json:"configMapName,omitempty"`
+	Checksum      string      `json:"checksum,omitempty"`
+	LastUpdate    metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// APIServerOIDCDiscoveryStatus contains the status of the Ingress publishing the API server OpenID discovery documents.
+type APIServerOIDCDiscoveryStatus struct {
+	IngressName string `json:"ingressName,omitempty"`
+	// JWKSURI is the public URL of the JSON Web Key Set, advertised by the OpenID discovery document.
+	JWKSURI    string      `json:"jwksURI,omitempty"`
+	Checksum   string      `json:"checksum,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
 // KubeadmPhaseStatus contains the status of a kubeadm phase action.
 type KubeadmPhaseStatus struct {
 	Checksum   string      `json:"checksum,omitempty"`
@@ -113,6 +200,28 @@ type KubeadmPhaseStatus struct {
 // KubeadmPhasesStatus contains the status of the different kubeadm phases action.
 type KubeadmPhasesStatus struct {
 	BootstrapToken KubeadmPhaseStatus `json:"bootstrapToken"`
+	// RBACProfiles contains the status of the RBAC profiles granted in the Tenant Cluster.
+	RBACProfiles RBACProfilesStatus `json:"rbacProfiles,omitempty"`
+	// SignedDiscovery contains the status of the discovery tokens signing the cluster-info ConfigMap.
+	SignedDiscovery *SignedDiscoveryStatus `json:"signedDiscovery,omitempty"`
+	// OIDCDiscovery contains the status of the binding granting the anonymous access to the OpenID discovery documents.
+	OIDCDiscovery *ExternalKubernetesObjectStatus `json:"oidcDiscovery,omitempty"`
+}
+
+// SignedDiscoveryStatus defines the observed state of the signed discovery.
+type SignedDiscoveryStatus struct {
+	// SecretName is the name of the Secret containing the current discovery token, and the CA public key hash.
+	SecretName string `json:"secretName,omitempty"`
+	// TokenID is the identifier of the current discovery token.
+	TokenID      string      `json:"tokenID,omitempty"`
+	LastRotation metav1.Time `json:"lastRotation,omitempty"`
+}
+
+// RBACProfilesStatus defines the observed state of the RBAC profiles granted in the Tenant Cluster.
+type RBACProfilesStatus struct {
+	// Profiles are the names of the RBAC profiles granted by Kamaji.
+	Profiles   []string    `json:"profiles,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
 }
 
 type ExternalKubernetesObjectStatus struct {
@@ -126,6 +235,9 @@ type KonnectivityAgentStatus struct {
 	ExternalKubernetesObjectStatus `json:",inline"`
 
 	Mode KonnectivityAgentMode `json:"mode,omitempty"`
+	// Remote reports if the agent is deployed in the remote cluster, rather than in the Tenant Cluster.
+	Remote              bool `json:"remote,omitempty"`
+	AddonWorkloadStatus `json:",inline"`
 }
 
 // KonnectivityStatus defines the status of Konnectivity as Addon.
@@ -138,6 +250,24 @@ type KonnectivityStatus struct {
 	ClusterRoleBinding ExternalKubernetesObjectStatus  `json:"clusterrolebinding,omitempty"`
 	Agent              KonnectivityAgentStatus         `json:"agent,omitempty"`
 	Service            KubernetesServiceStatus         `json:"service,omitempty"`
+	Health             KonnectivityHealthStatus        `json:"health,omitempty"`
+	// ServerCertificate is the certificate of the Konnectivity servers with the Deployment placement,
+	// issued for the addresses the kube-apiserver, and the agents, are connecting to.
+	ServerCertificate CertificatePrivateKeyPairStatus `json:"serverCertificate,omitempty"`
+}
+
+// KonnectivityHealthStatus reports the agents connected to the Konnectivity servers, as of the latest probe.
+type KonnectivityHealthStatus struct {
+	// ConnectedAgents is the lowest number of agents connected to a Konnectivity server.
+	ConnectedAgents int32 `json:"connectedAgents,omitempty"`
+	// ExpectedAgents is the number of agents scheduled by the DaemonSet, or the replicas of the Deployment.
+	ExpectedAgents int32 `json:"expectedAgents,omitempty"`
+	// Nodes is the number of nodes of the Tenant Cluster.
+	Nodes int32 `json:"nodes,omitempty"`
+	// LastProbe is the time of the latest probe of the Konnectivity servers.
+	LastProbe metav1.Time `json:"lastProbe,omitempty"`
+	// LastRestart is the time of the latest restart of the agents, triggered by Kamaji.
+	LastRestart metav1.Time `json:"lastRestart,omitempty"`
 }
 
 type KonnectivityConfigMap struct {
@@ -147,8 +277,23 @@ type KonnectivityConfigMap struct {
 
 // AddonStatus defines the observed state of an Addon.
 type AddonStatus struct {
-	Enabled    bool        `json:"enabled"`
-	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+	Enabled             bool        `json:"enabled"`
+	LastUpdate          metav1.Time `json:"lastUpdate,omitempty"`
+	AddonWorkloadStatus `json:",inline"`
+}
+
+// AddonWorkloadStatus defines the observed state of the workload deployed by an Addon in the Tenant Cluster,
+// allowing to detect the Tenant Control Planes running outdated addon images.
+type AddonWorkloadStatus struct {
+	// Image is the container image of the addon workload.
+	Image string `json:"image,omitempty"`
+	// Version is the tag of the addon image.
+	Version string `json:"version,omitempty"`
+	// Conditions contain the Ready condition of the addon workload,
+	// reporting if all the replicas are updated, and available.
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 // AddonsStatus defines the observed state of the different Addons.
@@ -156,6 +301,86 @@ type AddonsStatus struct {
 	CoreDNS      AddonStatus        `json:"coreDNS,omitempty"`
 	KubeProxy    AddonStatus        `json:"kubeProxy,omitempty"`
 	Konnectivity KonnectivityStatus `json:"konnectivity,omitempty"`
+	FrontProxy   AddonStatus        `json:"frontProxy,omitempty"`
+	WireGuard    WireGuardStatus    `json:"wireGuard,omitempty"`
+	FlowControl  FlowControlStatus  `json:"flowControl,omitempty"`
+}
+
+// FlowControlStatus defines the observed state of the API Priority and Fairness objects managed in the Tenant Cluster.
+type FlowControlStatus struct {
+	Enabled bool `json:"enabled"`
+	// PriorityLevels are the names of the PriorityLevelConfiguration objects managed by Kamaji.
+	PriorityLevels []string `json:"priorityLevels,omitempty"`
+	// FlowSchemas are the names of the FlowSchema objects managed by Kamaji.
+	FlowSchemas []string    `json:"flowSchemas,omitempty"`
+	LastUpdate  metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// WireGuardStatus defines the status of the WireGuard tunnel between the Tenant Control Plane, and the worker nodes.
+type WireGuardStatus struct {
+	Enabled bool `json:"enabled"`
+	// SecretName is the Secret containing the Tenant Control Plane key pair, and the WireGuard configuration.
+	SecretName string `json:"secretName,omitempty"`
+	// PublicKey of the Tenant Control Plane WireGuard interface.
+	PublicKey string `json:"publicKey,omitempty"`
+	// Peers contains the worker nodes connected to the Tenant Control Plane.
+	Peers      []WireGuardPeerStatus `json:"peers,omitempty"`
+	LastUpdate metav1.Time           `json:"lastUpdate,omitempty"`
+}
+
+// WireGuardPeerStatus defines a worker node connected through the WireGuard tunnel.
+type WireGuardPeerStatus struct {
+	// Node is the name of the worker node.
+	Node string `json:"node"`
+	// Address is the overlay network address assigned to the node.
+	Address string `json:"address"`
+	// PublicKey of the node WireGuard interface.
+	PublicKey string `json:"publicKey"`
+	// AllowedIPs are routed through the node, such as its internal IP, and the Pod CIDRs.
+	AllowedIPs []string `json:"allowedIPs,omitempty"`
+}
+
+// TTLStatus defines the lifetime of a Tenant Control Plane with a time-to-live.
+type TTLStatus struct {
+	// ReadyTime is the time the Tenant Control Plane became ready for the first time.
+	ReadyTime *metav1.Time `json:"readyTime,omitempty"`
+	// LastUseTime is the latest use of the Tenant Cluster sampled by Kamaji.
+	LastUseTime *metav1.Time `json:"lastUseTime,omitempty"`
+	// ExpirationTime is the time the Tenant Control Plane is deleted at.
+	ExpirationTime *metav1.Time `json:"expirationTime,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=CertificateAuthorityRotation;Upgrade
+type MaintenanceOperation string
+
+const (
+	MaintenanceOperationCARotation MaintenanceOperation = "CertificateAuthorityRotation"
+	MaintenanceOperationUpgrade    MaintenanceOperation = "Upgrade"
+)
+
+// +kubebuilder:validation:Enum=Pending;Acknowledged;TimedOut
+type MaintenanceOutcome string
+
+const (
+	MaintenanceOutcomePending      MaintenanceOutcome = "Pending"
+	MaintenanceOutcomeAcknowledged MaintenanceOutcome = "Acknowledged"
+	MaintenanceOutcomeTimedOut     MaintenanceOutcome = "TimedOut"
+)
+
+// MaintenanceStatus defines the notification of a disruptive operation in the Tenant Cluster.
+type MaintenanceStatus struct {
+	// ID identifies the notified operation, expected as the value of the acknowledgment annotation.
+	ID        string               `json:"id"`
+	Operation MaintenanceOperation `json:"operation"`
+	// Description of the operation, such as the upgrade versions.
+	Description string `json:"description,omitempty"`
+	// Outcome of the notification: the operation is held back while Pending.
+	Outcome    MaintenanceOutcome `json:"outcome"`
+	NotifiedAt metav1.Time        `json:"notifiedAt"`
+	// ConfigMapName is the name of the ConfigMap announcing the operation in the Tenant Cluster.
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// ConfigMapNamespace is the namespace of the ConfigMap announcing the operation in the Tenant Cluster.
+	ConfigMapNamespace string `json:"configMapNamespace,omitempty"`
 }
 
 // TenantControlPlaneStatus defines the observed state of TenantControlPlane.
@@ -177,8 +402,154 @@ type TenantControlPlaneStatus struct {
 	ControlPlaneEndpoint string `json:"controlPlaneEndpoint,omitempty"`
 	// Addons contains the status of the different Addons
 	Addons AddonsStatus `json:"addons,omitempty"`
+	// SchedulerConfiguration contains the status of the scheduler configuration, if declared.
+	SchedulerConfiguration *SchedulerConfigurationStatus `json:"schedulerConfiguration,omitempty"`
+	// APIServerTracing contains the status of the API server tracing configuration, if declared.
+	APIServerTracing *APIServerTracingStatus `json:"apiServerTracing,omitempty"`
+	// APIServerNodeConnectivity contains the status of the API server HTTP CONNECT proxy configuration, if declared.
+	APIServerNodeConnectivity *APIServerNodeConnectivityStatus `json:"apiServerNodeConnectivity,omitempty"`
+	// APIServerOIDCDiscovery contains the status of the Ingress publishing the API server OpenID discovery documents, if declared.
+	APIServerOIDCDiscovery *APIServerOIDCDiscoveryStatus `json:"apiServerOIDCDiscovery,omitempty"`
+	// Images contains the resolved digests of the Control Plane component images,
+	// populated when the referenced Image Profile requires digest pinning, or signature verification.
+	Images *ImagesStatus `json:"images,omitempty"`
+	// SecretsBackend contains the status of the credentials written to the external secrets backend, if declared.
+	SecretsBackend *SecretsBackendStatus `json:"secretsBackend,omitempty"`
+	// ObservedGeneration is the latest generation of the Tenant Control Plane fully reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// Revisions contains the history of the applied Tenant Control Plane specifications, if enabled.
+	Revisions *RevisionsStatus `json:"revisions,omitempty"`
+	// OperatorVersion is the Kamaji version which has fully reconciled the Tenant Control Plane last.
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+	// TTL contains the lifetime of the Tenant Control Plane, if a time-to-live is declared.
+	TTL *TTLStatus `json:"ttl,omitempty"`
+	// Maintenance contains the notification of the disruptive operation in progress, if any.
+	Maintenance *MaintenanceStatus `json:"maintenance,omitempty"`
+	// Conditions contains the latest observations of the Tenant Control Plane state,
+	// such as the Ready, Progressing, and Degraded ones.
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	//+kubebuilder:default=Provisioning
+	// Phase summarises the lifecycle of the Tenant Control Plane, from the provisioning of its requirements,
+	// such as certificates and DataStore, up to the ready state.
+	Phase TenantControlPlanePhase `json:"phase,omitempty"`
+	// PhaseMessage contains a human-readable message describing the current phase, such as the error causing the Failed one.
+	PhaseMessage string `json:"phaseMessage,omitempty"`
 }
 
+const (
+	// ConditionImagesVerified reports the outcome of the signature verification of the Control Plane component images.
+	ConditionImagesVerified = "ImagesVerified"
+
+	ReasonImagesVerified          = "Verified"
+	ReasonImageVerificationFailed = "ImageVerificationFailed"
+
+	// ConditionKonnectivityDegraded reports if agents are disconnected from the Konnectivity servers,
+	// it's set only when the Konnectivity health check is enabled.
+	ConditionKonnectivityDegraded = "KonnectivityDegraded"
+
+	// ReasonWorkloadAvailable and ReasonWorkloadProgressing are the reasons of the addon workload Ready condition.
+	ReasonWorkloadAvailable   = "Available"
+	ReasonWorkloadProgressing = "Progressing"
+
+	ReasonAgentsConnected    = "AgentsConnected"
+	ReasonAgentsDisconnected = "AgentsDisconnected"
+	ReasonProbeFailed        = "ProbeFailed"
+
+	// ConditionKonnectivityCertificatesRotating reports the rotation of the Konnectivity server certificate,
+	// from its issuing up to the reload by the running servers, which are not restarted.
+	ConditionKonnectivityCertificatesRotating = "KonnectivityCertificatesRotating"
+
+	ReasonCertificatesPropagating = "CertificatesPropagating"
+	ReasonCertificatesRotated     = "CertificatesRotated"
+
+	// ConditionDataStoreConnectionHealthy reports if the Tenant Control Plane API Server is reaching its DataStore,
+	// as of the latest probe of the etcd readiness check.
+	ConditionDataStoreConnectionHealthy = "DataStoreConnectionHealthy"
+
+	ReasonDataStoreReachable   = "Reachable"
+	ReasonDataStoreUnreachable = "Unreachable"
+
+	// ConditionOperatorUpgradePending reports the changes rendered by a new Kamaji version which would restart the Control Plane pods,
+	// waiting for the confirmation with the staged operator upgrades enabled.
+	ConditionOperatorUpgradePending = "OperatorUpgradePending"
+
+	ReasonRolloutConfirmationRequired = "RolloutConfirmationRequired"
+	ReasonOperatorUpgradeApplied      = "Applied"
+
+	// ConditionKineSchemaMigrationPending reports the rollout of a new kine image held back by the DataStore schema migration batching,
+	// waiting for a slot of the concurrent migrations.
+	ConditionKineSchemaMigrationPending = "KineSchemaMigrationPending"
+
+	ReasonKineSchemaMigrationQueued  = "Queued"
+	ReasonKineSchemaMigrationStarted = "Started"
+
+	// ConditionDrifted reports the objects of the Tenant Control Plane differing from the ones rendered by Kamaji,
+	// as of the latest run of the drift detection: the detailed diff is stored in the <name>-drift-report ConfigMap.
+	ConditionDrifted = "Drifted"
+
+	ReasonDriftDetected = "DriftDetected"
+	ReasonInSync        = "InSync"
+
+	// ConditionFIPSCompliant reports if the Tenant Control Plane running in FIPS mode complies with it,
+	// listing the gaps otherwise, such as the components missing a FIPS-validated image.
+	ConditionFIPSCompliant = "FIPSCompliant"
+
+	ReasonFIPSCompliant    = "Compliant"
+	ReasonFIPSNonCompliant = "NonCompliant"
+)
+
+// RevisionsStatus contains the history of the applied Tenant Control Plane specifications.
+type RevisionsStatus struct {
+	// ConfigMapName is the ConfigMap storing the specification of each revision, keyed by the revision number.
+	ConfigMapName string `json:"configMapName,omitempty"`
+	// Revision is the number of the latest applied revision.
+	Revision   int64       `json:"revision,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// SecretsBackendStatus contains the generated credentials written to the external secrets backend.
+type SecretsBackendStatus struct {
+	// Secrets is the list of the Secret names written to the backend.
+	Secrets    []string    `json:"secrets,omitempty"`
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// ImagesStatus contains the Control Plane component images pinned to their digests.
+type ImagesStatus struct {
+	// Checksum of the Image Profile specification used to resolve the images.
+	Checksum   string                 `json:"checksum,omitempty"`
+	Components []ComponentImageStatus `json:"components,omitempty"`
+	LastUpdate metav1.Time            `json:"lastUpdate,omitempty"`
+}
+
+type ComponentImageStatus struct {
+	Component ImageProfileComponent `json:"component"`
+	// Image is the image reference, resolved according to the Image Profile.
+	Image string `json:"image"`
+	// Digest is the manifest digest the image reference has been resolved to.
+	Digest string `json:"digest"`
+	// Verified reports whether the image signature has been verified.
+	Verified bool `json:"verified,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Provisioning;CertificatesReady;DatastoreReady;Migrating;Upgrading;Ready;NotReady;Sleeping;Failed
+type TenantControlPlanePhase string
+
+var (
+	PhaseProvisioning      TenantControlPlanePhase = "Provisioning"
+	PhaseCertificatesReady TenantControlPlanePhase = "CertificatesReady"
+	PhaseDatastoreReady    TenantControlPlanePhase = "DatastoreReady"
+	PhaseMigrating         TenantControlPlanePhase = "Migrating"
+	PhaseUpgrading         TenantControlPlanePhase = "Upgrading"
+	PhaseReady             TenantControlPlanePhase = "Ready"
+	PhaseNotReady          TenantControlPlanePhase = "NotReady"
+	PhaseSleeping          TenantControlPlanePhase = "Sleeping"
+	PhaseFailed            TenantControlPlanePhase = "Failed"
+)
+
 // KubernetesStatus defines the status of the resources deployed in the management cluster,
 // such as Deployment and Service.
 type KubernetesStatus struct {
@@ -187,6 +558,53 @@ type KubernetesStatus struct {
 	Deployment KubernetesDeploymentStatus `json:"deployment,omitempty"`
 	Service    KubernetesServiceStatus    `json:"service,omitempty"`
 	Ingress    *KubernetesIngressStatus   `json:"ingress,omitempty"`
+	// Nodes contains the aggregated health of the Tenant Cluster worker nodes, reported by the soot manager.
+	Nodes *KubernetesNodesStatus `json:"nodes,omitempty"`
+	// HostNetwork contains the host ports allocated to the Control Plane components, and the addresses of the eligible nodes,
+	// when the Control Plane pods are running in the host network namespace.
+	HostNetwork *KubernetesHostNetworkStatus `json:"hostNetwork,omitempty"`
+}
+
+// KubernetesHostNetworkStatus contains the host network allocations of the Tenant Control Plane.
+type KubernetesHostNetworkStatus struct {
+	// APIServerPort is the host port the kube-apiserver is listening to, advertised in the Control Plane endpoint.
+	APIServerPort int32 `json:"apiServerPort"`
+	// ControllerManagerPort is the host port of the kube-controller-manager secure serving.
+	ControllerManagerPort int32 `json:"controllerManagerPort"`
+	// SchedulerPort is the host port of the kube-scheduler secure serving.
+	SchedulerPort int32 `json:"schedulerPort"`
+	// KineMetricsPort is the host port of the kine metrics endpoint.
+	KineMetricsPort int32 `json:"kineMetricsPort"`
+	// NodeAddresses are the addresses of the nodes eligible to run the Control Plane pods,
+	// added to the API Server certificate SANs.
+	NodeAddresses []string `json:"nodeAddresses,omitempty"`
+	// LastUpdate is the last time the allocations, or the node addresses, have changed.
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// KubernetesNodesStatus contains the aggregated health of the Tenant Cluster worker nodes.
+type KubernetesNodesStatus struct {
+	// Total is the number of the registered nodes.
+	Total int32 `json:"total"`
+	// Ready is the number of the nodes reporting the Ready condition.
+	Ready int32 `json:"ready"`
+	// Versions is the number of the nodes per kubelet version.
+	//+listType=map
+	//+listMapKey=version
+	Versions []KubernetesNodesVersion `json:"versions,omitempty"`
+	// StaleHeartbeatsCount is the number of the nodes whose lease has not been renewed within the node monitor grace period.
+	StaleHeartbeatsCount int32 `json:"staleHeartbeatsCount,omitempty"`
+	// StaleHeartbeats are the names of the nodes whose lease has not been renewed within the node monitor grace period,
+	// limited to the first ones in alphabetical order.
+	StaleHeartbeats []string `json:"staleHeartbeats,omitempty"`
+	// LastUpdate is the last time the aggregated health has changed.
+	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+}
+
+// KubernetesNodesVersion contains the number of the nodes running a kubelet version.
+type KubernetesNodesVersion struct {
+	Version string `json:"version"`
+	Count   int32  `json:"count"`
 }
 
 // +kubebuilder:validation:Enum=Provisioning;CertificateAuthorityRotating;Upgrading;Migrating;Ready;NotReady;Sleeping
@@ -208,6 +626,22 @@ type KubernetesVersion struct {
 	//+kubebuilder:default=Provisioning
 	// Status returns the current status of the Kubernetes version, such as its provisioning state, or completed upgrade.
 	Status *KubernetesVersionStatus `json:"status,omitempty"`
+	// Downgrade records the last forced downgrade to a previous Kubernetes minor version.
+	Downgrade *KubernetesVersionDowngrade `json:"downgrade,omitempty"`
+}
+
+// KubernetesVersionDowngrade records a forced downgrade, along with the backup declared before performing it.
+type KubernetesVersionDowngrade struct {
+	// From is the Kubernetes version running before the downgrade.
+	From string `json:"from"`
+	// To is the Kubernetes version the Tenant Control Plane has been downgraded to.
+	To string `json:"to"`
+	// BackupReference is the backup of the DataStore declared with the kamaji.clastix.io/downgrade-backup annotation.
+	BackupReference string `json:"backupReference"`
+	// BackupTimestamp is the completion time of the declared backup.
+	BackupTimestamp metav1.Time `json:"backupTimestamp,omitempty"`
+	// ApprovedAt is the time the downgrade has been started.
+	ApprovedAt metav1.Time `json:"approvedAt"`
 }
 
 // KubernetesDeploymentStatus defines the status for the Tenant Control Plane Deployment in the management cluster.
@@ -221,6 +655,15 @@ type KubernetesDeploymentStatus struct {
 	Namespace string `json:"namespace"`
 	// Last time when deployment was updated
 	LastUpdate metav1.Time `json:"lastUpdate,omitempty"`
+	// Zones reports the replicas expected in each zone of the DataStore endpoints, when its topology is declared.
+	Zones []DataStoreZoneReplicas `json:"zones,omitempty"`
+}
+
+// DataStoreZoneReplicas maps the Tenant Control Plane replicas to a zone of the DataStore endpoints.
+type DataStoreZoneReplicas struct {
+	Zone      string   `json:"zone"`
+	Endpoints []string `json:"endpoints"`
+	Replicas  int32    `json:"replicas"`
 }
 
 // KubernetesServiceStatus defines the status for the Tenant Control Plane Service in the management cluster.
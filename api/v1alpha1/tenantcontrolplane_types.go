@@ -6,7 +6,12 @@ package v1alpha1
 import (
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // NetworkProfileSpec defines the desired state of NetworkProfile.
@@ -40,12 +45,12 @@ type NetworkProfileSpec struct {
 	Port int32 `json:"port,omitempty"`
 	// CertSANs sets extra Subject Alternative Names (SANs) for the API Server signing certificate.
 	// Use this field to add additional hostnames when exposing the Tenant Control Plane with third solutions.
+	// The entries can be templated with the .Name, .Namespace, .Address, .LoadBalancerIP, and .LoadBalancerHostname values,
+	// such as {{ .Name }}.tenants.example.com: they're resolved upon each reconciliation, skipping the ones referring to values not yet assigned.
 	CertSANs []string `json:"certSANs,omitempty"`
-	// CIDR for Kubernetes Services: if empty, defaulted to 10.96.0.0/16.
-	//+kubebuilder:default="10.96.0.0/16"
+	// CIDR for Kubernetes Services: if empty, defaulted to the KamajiDefaults one, or to 10.96.0.0/16.
 	ServiceCIDR string `json:"serviceCidr,omitempty"`
-	// CIDR for Kubernetes Pods: if empty, defaulted to 10.244.0.0/16.
-	//+kubebuilder:default="10.244.0.0/16"
+	// CIDR for Kubernetes Pods: if empty, defaulted to the KamajiDefaults one, or to 10.244.0.0/16.
 	PodCIDR string `json:"podCidr,omitempty"`
 	// The DNS Service for internal resolution, it must match the Service CIDR.
 	// In case of an empty value, it is automatically computed according to the Service CIDR, e.g.:
@@ -75,18 +80,364 @@ type KubeletSpec struct {
 	// CGroupFS defines the cgroup driver for Kubelet
 	// https://kubernetes.io/docs/tasks/administer-cluster/kubeadm/configure-cgroup-driver/
 	CGroupFS CGroupDriver `json:"cgroupfs,omitempty"`
+	// ServerCertificateRotation enables the kubelet serving certificates signed by the Tenant Cluster CA,
+	// setting serverTLSBootstrap in the kubelet configuration: the kubelet serving CSRs matching the identity
+	// of the requesting node are approved by Kamaji, allowing to verify the kubelet serving certificates.
+	ServerCertificateRotation *KubeletServerCertificateRotationSpec `json:"serverCertificateRotation,omitempty"`
+	// Config defines the fields of the kubelet configuration shared with the worker nodes,
+	// rendered to the kubelet-config ConfigMap of the Tenant Cluster.
+	Config *KubeletConfigSpec `json:"config,omitempty"`
+	// Pools define named variants of the kubelet configuration, such as for GPU, or edge, nodes:
+	// each one is uploaded to the kubelet-config-<name> ConfigMap, readable by the joining nodes.
+	//+listType=map
+	//+listMapKey=name
+	Pools []KubeletPoolSpec `json:"pools,omitempty"`
+}
+
+// KubeletPoolSpec defines a variant of the kubelet configuration, overriding the one of the Tenant Control Plane.
+type KubeletPoolSpec struct {
+	// Name of the pool, the configuration is uploaded to the kubelet-config-<name> ConfigMap.
+	//+kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	//+kubebuilder:validation:MaxLength=48
+	Name string `json:"name"`
+	// CGroupFS overrides the cgroup driver declared for the Tenant Control Plane.
+	CGroupFS CGroupDriver `json:"cgroupfs,omitempty"`
+	// Config overrides the fields of the kubelet configuration declared for the Tenant Control Plane.
+	Config *KubeletConfigSpec `json:"config,omitempty"`
+}
+
+// KubeletConfigSpec defines the fields of the KubeletConfiguration managed by Kamaji:
+// the kubelet feature gates are declared with the componentFeatureGates.kubelet field.
+// +kubebuilder:validation:XValidation:rule="!has(self.maxParallelImagePulls) || (has(self.serializeImagePulls) && !self.serializeImagePulls)",message="maxParallelImagePulls requires serializeImagePulls to be false"
+type KubeletConfigSpec struct {
+	// MaxPods is the number of pods that can run on each node.
+	//+kubebuilder:validation:Minimum=1
+	MaxPods *int32 `json:"maxPods,omitempty"`
+	// SerializeImagePulls pulls one image at a time, it's enabled by default.
+	SerializeImagePulls *bool `json:"serializeImagePulls,omitempty"`
+	// MaxParallelImagePulls is the number of images pulled in parallel, when the pulls are not serialized.
+	//+kubebuilder:validation:Minimum=1
+	MaxParallelImagePulls *int32 `json:"maxParallelImagePulls,omitempty"`
+	// EvictionHard maps the eviction signals to the thresholds triggering the eviction of the pods, such as memory.available: 100Mi,
+	// replacing the kubelet default ones.
+	//+kubebuilder:validation:XValidation:rule="self.all(signal, signal in ['memory.available', 'nodefs.available', 'nodefs.inodesFree', 'imagefs.available', 'imagefs.inodesFree', 'containerfs.available', 'containerfs.inodesFree', 'pid.available'])",message="unknown eviction signal"
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+	// EvictionSoft maps the eviction signals to the thresholds triggering the eviction of the pods,
+	// once exceeded for the grace period declared in evictionSoftGracePeriod.
+	//+kubebuilder:validation:XValidation:rule="self.all(signal, signal in ['memory.available', 'nodefs.available', 'nodefs.inodesFree', 'imagefs.available', 'imagefs.inodesFree', 'containerfs.available', 'containerfs.inodesFree', 'pid.available'])",message="unknown eviction signal"
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+	// EvictionSoftGracePeriod maps the eviction signals to the grace period of the soft eviction thresholds, such as memory.available: 1m30s.
+	//+kubebuilder:validation:XValidation:rule="self.all(signal, signal in ['memory.available', 'nodefs.available', 'nodefs.inodesFree', 'imagefs.available', 'imagefs.inodesFree', 'containerfs.available', 'containerfs.inodesFree', 'pid.available'])",message="unknown eviction signal"
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+}
+
+// KubeletServerCertificateRotationSpec defines the approval of the kubelet serving CSRs.
+type KubeletServerCertificateRotationSpec struct {
+	// VerificationHook is called for each kubelet serving CSR matching the identity of the requesting node,
+	// before approving it.
+	VerificationHook *KubeletServingCSRVerificationHook `json:"verificationHook,omitempty"`
+}
+
+// KubeletServingCSRVerificationHook is an HTTP endpoint receiving a POST request for each kubelet serving CSR:
+// a 2xx status code approves the CSR, a 4xx one denies it, the CSR is submitted again upon any other outcome.
+type KubeletServingCSRVerificationHook struct {
+	//+kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// CABundle is the PEM encoded CA bundle used to verify the hook certificate:
+	// if empty, the system trust roots are used.
+	CABundle []byte `json:"caBundle,omitempty"`
+	// Timeout of the hook requests.
+	//+kubebuilder:default="10s"
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 // KubernetesSpec defines the desired state of Kubernetes.
 type KubernetesSpec struct {
-	// Kubernetes Version for the tenant control plane
-	Version string      `json:"version"`
+	// Kubernetes Version for the tenant control plane:
+	// if empty, defaulted to the KamajiDefaults one upon creation.
+	Version string      `json:"version,omitempty"`
 	Kubelet KubeletSpec `json:"kubelet"`
 
 	// List of enabled Admission Controllers for the Tenant cluster.
 	// Full reference available here: https://kubernetes.io/docs/reference/access-authn-authz/admission-controllers
 	//+kubebuilder:default=CertificateApproval;CertificateSigning;CertificateSubjectRestriction;DefaultIngressClass;DefaultStorageClass;DefaultTolerationSeconds;LimitRanger;MutatingAdmissionWebhook;NamespaceLifecycle;PersistentVolumeClaimResize;Priority;ResourceQuota;RuntimeClass;ServiceAccount;StorageObjectInUseProtection;TaintNodesByCondition;ValidatingAdmissionWebhook
 	AdmissionControllers AdmissionControllers `json:"admissionControllers,omitempty"`
+	// FeatureGates enables, or disables, the given Kubernetes feature gates for all the Tenant Control Plane components,
+	// and for the kubelet configuration shared with the worker nodes.
+	// The gates are validated against the ones known by the Kubernetes version of the Tenant Control Plane:
+	// the feature gates declared using the extra args take precedence.
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+	// ComponentFeatureGates defines the feature gates for a specific component, overriding the global ones.
+	ComponentFeatureGates ComponentFeatureGates `json:"componentFeatureGates,omitempty"`
+	// Scheduler defines the configuration of the Tenant Control Plane scheduler.
+	Scheduler *SchedulerSpec `json:"scheduler,omitempty"`
+	// ControllerManager defines the configuration of the Tenant Control Plane controller manager.
+	ControllerManager *ControllerManagerSpec `json:"controllerManager,omitempty"`
+	// APIServer defines the configuration of the Tenant Control Plane API server.
+	APIServer *APIServerSpec `json:"apiServer,omitempty"`
+}
+
+// APIServerSpec defines the configuration of the kube-apiserver component.
+type APIServerSpec struct {
+	// Tracing enables the OpenTelemetry tracing of the API server requests,
+	// exporting the spans to the given OTLP collector.
+	Tracing *APIServerTracingSpec `json:"tracing,omitempty"`
+	// MaxRequestsInflight is the maximum number of non-mutating requests in flight at a given time,
+	// rendered as the --max-requests-inflight flag: it's ignored when API Priority and Fairness is enabled,
+	// contributing to the total server concurrency limit along with the mutating ones.
+	//+kubebuilder:validation:Minimum=0
+	MaxRequestsInflight *int32 `json:"maxRequestsInflight,omitempty"`
+	// MaxMutatingRequestsInflight is the maximum number of mutating requests in flight at a given time,
+	// rendered as the --max-mutating-requests-inflight flag.
+	//+kubebuilder:validation:Minimum=0
+	MaxMutatingRequestsInflight *int32 `json:"maxMutatingRequestsInflight,omitempty"`
+	// FlowControl declares the API Priority and Fairness objects installed in the Tenant Cluster,
+	// allowing to isolate, or to throttle, the noisy clients of the tenant.
+	FlowControl *APIServerFlowControlSpec `json:"flowControl,omitempty"`
+	// EgressPolicy restricts the destinations the Tenant Control Plane pods can connect to by means of a NetworkPolicy,
+	// reducing the blast radius of a compromised API server: the management cluster CNI must enforce the NetworkPolicy objects.
+	EgressPolicy *APIServerEgressPolicySpec `json:"egressPolicy,omitempty"`
+	// GracefulShutdown drains the API server connections upon the rollouts, and the scale downs,
+	// preventing the multi-replica Tenant Control Planes to drop the in-flight requests.
+	GracefulShutdown *APIServerGracefulShutdownSpec `json:"gracefulShutdown,omitempty"`
+	// NodeConnectivity declares how the API server reaches the kubelets, serving the logs, exec, and port-forward requests,
+	// when neither Konnectivity, nor WireGuard, are enabled.
+	NodeConnectivity *APIServerNodeConnectivitySpec `json:"nodeConnectivity,omitempty"`
+	// ServiceAccountIssuer is the identifier of the service account tokens issuer, rendered as the first --service-account-issuer flag,
+	// such as the URL serving the OpenID discovery document for the workload identity federation.
+	// If empty, defaulted to https://kubernetes.default.svc.cluster.local.
+	// Changing it invalidates the issued tokens: the previous issuer must be kept in the additional ones until the tokens are refreshed.
+	//+kubebuilder:validation:MinLength=1
+	ServiceAccountIssuer string `json:"serviceAccountIssuer,omitempty"`
+	// AdditionalServiceAccountIssuers are the issuers of the accepted service account tokens besides the main one,
+	// rendered as the following --service-account-issuer flags, allowing the tokens of a previous issuer to be validated.
+	//+listType=set
+	AdditionalServiceAccountIssuers []string `json:"additionalServiceAccountIssuers,omitempty"`
+	// APIAudiences are the identifiers of the API, rendered as the --api-audiences flag:
+	// the service account tokens must be issued for at least one of them. If empty, the service account issuer is the only audience.
+	//+listType=set
+	APIAudiences []string `json:"apiAudiences,omitempty"`
+	// OIDCDiscovery publishes the OpenID discovery document, and the JWKS, of the service account issuer
+	// through an Ingress managed by Kamaji, allowing the tenant service accounts to federate into the cloud IAM
+	// without exposing the whole API server: the service account issuer must be https://<hostname>.
+	OIDCDiscovery *APIServerOIDCDiscoverySpec `json:"oidcDiscovery,omitempty"`
+	// TLS defines the TLS, and the HTTP/2, policy of the API server serving endpoint,
+	// for the organizations with strict TLS baselines: the omitted settings are left to the API server defaults.
+	TLS *APIServerTLSSpec `json:"tls,omitempty"`
+	// Storage tunes the watch cache of the API server, and the compaction of the DataStore revisions,
+	// since the large Tenant Control Planes require a different tuning than the small ones.
+	Storage *APIServerStorageSpec `json:"storage,omitempty"`
+}
+
+// APIServerStorageSpec defines the watch cache, and the compaction, settings of the API server.
+// +kubebuilder:validation:XValidation:rule="!has(self.watchCache) || self.watchCache || (!has(self.defaultWatchCacheSize) && !has(self.watchCacheSizes))",message="the watch cache sizes cannot be set when the watch cache is disabled"
+type APIServerStorageSpec struct {
+	// WatchCache enables the watch cache of the API server, rendered as the --watch-cache flag.
+	// If empty, the watch cache is enabled.
+	WatchCache *bool `json:"watchCache,omitempty"`
+	// DefaultWatchCacheSize is the watch cache size of the resources without an override, rendered as the --default-watch-cache-size flag:
+	// a zero size disables the watch cache of these resources.
+	//+kubebuilder:validation:Minimum=0
+	DefaultWatchCacheSize *int32 `json:"defaultWatchCacheSize,omitempty"`
+	// WatchCacheSizes are the per-resource overrides of the watch cache size, rendered as the --watch-cache-sizes flag.
+	// Since Kubernetes v1.19 the watch cache is sized dynamically, and a zero size, disabling the watch cache of the resource,
+	// is the only value honoured by the API server.
+	//+listType=map
+	//+listMapKey=resource
+	WatchCacheSizes []APIServerWatchCacheSize `json:"watchCacheSizes,omitempty"`
+	// EtcdCompactionInterval is the interval of the compaction requests issued by the API server, rendered as the --etcd-compaction-interval flag:
+	// a zero interval disables them. With the kine drivers, the compaction is performed by kine, rendering the --compact-interval flag.
+	EtcdCompactionInterval *metav1.Duration `json:"etcdCompactionInterval,omitempty"`
+}
+
+// APIServerWatchCacheSize defines the watch cache size of a resource.
+type APIServerWatchCacheSize struct {
+	// Resource is the lowercase plural name of the resource, followed by its API group for the non-core ones, such as pods, or deployments.apps.
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	Resource string `json:"resource"`
+	// Size is the watch cache size of the resource.
+	//+kubebuilder:validation:Minimum=0
+	Size int32 `json:"size"`
+}
+
+// APIServerTLSSpec defines the TLS, and the HTTP/2, policy of the API server.
+// +kubebuilder:validation:XValidation:rule="!has(self.http2MaxStreamsPerConnection) || !has(self.disableHTTP2) || !self.disableHTTP2",message="the HTTP/2 streams limit cannot be set when HTTP/2 is disabled"
+type APIServerTLSSpec struct {
+	// MinVersion is the minimum TLS version accepted by the API server, rendered as the --tls-min-version flag.
+	//+kubebuilder:validation:Enum=VersionTLS12;VersionTLS13
+	MinVersion string `json:"minVersion,omitempty"`
+	// CipherSuites are the IANA names of the cipher suites accepted by the API server, rendered as the --tls-cipher-suites flag:
+	// they only apply to TLS 1.2, since the TLS 1.3 ones are not configurable.
+	//+listType=set
+	CipherSuites []string `json:"cipherSuites,omitempty"`
+	// DisableHTTP2 serves the API server requests using HTTP/1.1 only, rendered as the --disable-http2-serving flag,
+	// available since Kubernetes v1.31.
+	DisableHTTP2 bool `json:"disableHTTP2,omitempty"`
+	// HTTP2MaxStreamsPerConnection is the limit of the HTTP/2 concurrent streams per connection,
+	// rendered as the --http2-max-streams-per-connection flag.
+	//+kubebuilder:validation:Minimum=1
+	HTTP2MaxStreamsPerConnection *int32 `json:"http2MaxStreamsPerConnection,omitempty"`
+}
+
+// APIServerOIDCDiscoverySpec defines the Ingress publishing the /.well-known/openid-configuration, and the /openid/v1/jwks, paths
+// of the API server: the anonymous requests to these paths are granted in the Tenant Cluster.
+type APIServerOIDCDiscoverySpec struct {
+	AdditionalMetadata AdditionalMetadata `json:"additionalMetadata,omitempty"`
+	IngressClassName   string             `json:"ingressClassName,omitempty"`
+	// Hostname is the public host serving the discovery documents, used as the Ingress host.
+	//+kubebuilder:validation:MinLength=1
+	Hostname string `json:"hostname"`
+	// TLSSecretName is the name of the Secret containing the certificate of the hostname, such as the one issued by cert-manager:
+	// if empty, the TLS is terminated according to the Ingress Controller defaults.
+	TLSSecretName string `json:"tlsSecretName,omitempty"`
+}
+
+// APIServerNodeConnectivitySpec defines the connections of the API server to the kubelets without the node connectivity addons:
+// the kubelet addresses are selected according to the preferredAddressTypes of the kubelet spec.
+type APIServerNodeConnectivitySpec struct {
+	// NodeCIDRs are the worker nodes networks routable from the management cluster, reached directly by the API server:
+	// the connections to the kubelet port are allowed by the egress policy, if declared.
+	NodeCIDRs []string `json:"nodeCidrs,omitempty"`
+	// HTTPConnectProxy is the URL of the HTTP CONNECT proxy tunnelling the API server connections to the kubelets,
+	// such as one running on a bastion host of the worker nodes network, replacing the SSH tunnels removed from Kubernetes.
+	// It's rendered as the cluster egress selection of the API server, and it's mutually exclusive with Konnectivity, and WireGuard.
+	//+kubebuilder:validation:Pattern=`^http://[^/]+/?$`
+	HTTPConnectProxy string `json:"httpConnectProxy,omitempty"`
+}
+
+// APIServerGracefulShutdownSpec defines how a terminating API server is removed from the Service endpoints:
+// the pod keeps serving until the EndpointSlices, the kube-proxy rules, and the load balancers, are no longer routing requests to it.
+type APIServerGracefulShutdownSpec struct {
+	// DrainSeconds is the time the API server keeps serving once the pod is terminating, before receiving the termination signal,
+	// rendered as a preStop sleep hook: the Service endpoints are updated meanwhile. It's also used as the minReadySeconds
+	// of the Deployment, letting the new pods to be routed before the old ones are terminated.
+	//+kubebuilder:default=15
+	//+kubebuilder:validation:Minimum=0
+	DrainSeconds int32 `json:"drainSeconds,omitempty"`
+	// ShutdownDelaySeconds is the time the API server keeps serving once signalled, with the readiness endpoint failing,
+	// rendered as the --shutdown-delay-duration flag: the new requests are answered with a retriable 429 status code.
+	//+kubebuilder:default=5
+	//+kubebuilder:validation:Minimum=0
+	ShutdownDelaySeconds int32 `json:"shutdownDelaySeconds,omitempty"`
+	// TerminationGracePeriodSeconds of the Tenant Control Plane pods, defaulting to the sum of the drain, and the shutdown delay,
+	// along with the 60 seconds the API server waits for the in-flight requests to complete.
+	//+kubebuilder:validation:Minimum=1
+	TerminationGracePeriodSeconds *int64 `json:"terminationGracePeriodSeconds,omitempty"`
+}
+
+// APIServerEgressPolicySpec defines the destinations allowed for the Tenant Control Plane pods, along with the DNS resolution:
+// the API server, and the sidecar containers, such as kine, share the pod network, thus the same egress policy.
+type APIServerEgressPolicySpec struct {
+	// DataStoreCIDRs are the networks hosting the DataStore, the connections are allowed on the ports of its endpoints:
+	// if empty, the endpoints ports are allowed towards any destination.
+	DataStoreCIDRs []string `json:"dataStoreCidrs,omitempty"`
+	// OIDCIssuerCIDRs are the networks hosting the OIDC issuer, the connections are allowed on the 443 port.
+	OIDCIssuerCIDRs []string `json:"oidcIssuerCidrs,omitempty"`
+	// WebhookCIDRs are the networks hosting the admission, authentication, and authorization webhooks,
+	// the connections are allowed on any port.
+	WebhookCIDRs []string `json:"webhookCidrs,omitempty"`
+	// ExtraRules are appended to the generated ones, such as the rule allowing the connections to the kubelets,
+	// required when Konnectivity is not enabled.
+	ExtraRules []networkingv1.NetworkPolicyEgressRule `json:"extraRules,omitempty"`
+}
+
+// APIServerFlowControlSpec defines the FlowSchema, and PriorityLevelConfiguration, objects managed by Kamaji in the Tenant Cluster:
+// the objects removed from the spec are deleted, the ones not managed by Kamaji, such as the mandatory and suggested ones, are left untouched.
+type APIServerFlowControlSpec struct {
+	// PriorityLevels are the PriorityLevelConfiguration objects installed in the Tenant Cluster.
+	//+listType=map
+	//+listMapKey=name
+	PriorityLevels []FlowControlPriorityLevel `json:"priorityLevels,omitempty"`
+	// FlowSchemas are the FlowSchema objects installed in the Tenant Cluster.
+	//+listType=map
+	//+listMapKey=name
+	FlowSchemas []FlowControlFlowSchema `json:"flowSchemas,omitempty"`
+
+	AddonApplyTrait `json:",inline"`
+}
+
+// FlowControlPriorityLevel defines a PriorityLevelConfiguration object of the Tenant Cluster.
+type FlowControlPriorityLevel struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string                                       `json:"name"`
+	Spec flowcontrolv1.PriorityLevelConfigurationSpec `json:"spec"`
+}
+
+// FlowControlFlowSchema defines a FlowSchema object of the Tenant Cluster.
+type FlowControlFlowSchema struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string                       `json:"name"`
+	Spec flowcontrolv1.FlowSchemaSpec `json:"spec"`
+}
+
+// APIServerTracingSpec defines the TracingConfiguration of the API server, supported since Kubernetes v1.27.
+type APIServerTracingSpec struct {
+	// Endpoint of the OTLP gRPC collector the API server reports the traces to, such as otel-collector.observability.svc:4317:
+	// the connection is insecure, since TLS is not supported by the API server.
+	//+kubebuilder:validation:MinLength=1
+	Endpoint string `json:"endpoint"`
+	// SamplingRatePerMillion is the number of samples to collect per million spans:
+	// when not specified, the API server respects the sampling rate of the parent span, but otherwise never samples.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=1000000
+	SamplingRatePerMillion *int32 `json:"samplingRatePerMillion,omitempty"`
+}
+
+// ControllerManagerSpec defines the configuration of the kube-controller-manager component.
+type ControllerManagerSpec struct {
+	// CloudProvider configures the controller manager to work along with an external cloud controller manager,
+	// running in the Tenant Cluster, or in the Management Cluster: the cloud-specific control loops are delegated to it.
+	CloudProvider *CloudProviderSpec `json:"cloudProvider,omitempty"`
+}
+
+//+kubebuilder:validation:XValidation:rule="!has(self.nodeCIDRMaskSize) || (!has(self.nodeCIDRMaskSizeIPv4) && !has(self.nodeCIDRMaskSizeIPv6))",message="nodeCIDRMaskSize cannot be used along with the IPv4, or IPv6, specific mask sizes"
+
+// CloudProviderSpec defines the controller manager settings required by the external cloud controller managers.
+type CloudProviderSpec struct {
+	// Name of the cloud provider: since the in-tree cloud providers have been removed,
+	// the only supported value is external, delegating the cloud control loops to the cloud controller manager.
+	//+kubebuilder:default=external
+	//+kubebuilder:validation:Enum=external
+	Name string `json:"name,omitempty"`
+	// ClusterName is the instance prefix of the cluster, used by the cloud provider to tag the infrastructure resources.
+	// When not specified, the Tenant Control Plane name is used.
+	ClusterName string `json:"clusterName,omitempty"`
+	// ConfigureCloudRoutes defines if the CIDRs allocated to the nodes must be configured on the cloud provider.
+	ConfigureCloudRoutes *bool `json:"configureCloudRoutes,omitempty"`
+	// AllocateNodeCIDRs defines if the node IPAM controller must allocate the Pod CIDRs to the nodes:
+	// it must be disabled when the CIDRs are allocated by the cloud controller manager.
+	//+kubebuilder:default=true
+	AllocateNodeCIDRs *bool `json:"allocateNodeCIDRs,omitempty"`
+	// NodeCIDRMaskSize is the mask size of the Pod CIDR allocated to each node, in single-stack clusters.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=128
+	NodeCIDRMaskSize *int32 `json:"nodeCIDRMaskSize,omitempty"`
+	// NodeCIDRMaskSizeIPv4 is the mask size of the IPv4 Pod CIDR allocated to each node, in dual-stack clusters.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=32
+	NodeCIDRMaskSizeIPv4 *int32 `json:"nodeCIDRMaskSizeIPv4,omitempty"`
+	// NodeCIDRMaskSizeIPv6 is the mask size of the IPv6 Pod CIDR allocated to each node, in dual-stack clusters.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=128
+	NodeCIDRMaskSizeIPv6 *int32 `json:"nodeCIDRMaskSizeIPv6,omitempty"`
+}
+
+// SchedulerSpec defines the configuration of the kube-scheduler component.
+type SchedulerSpec struct {
+	// Config is the KubeSchedulerConfiguration object used by the scheduler, such as for profiles, plugins, and score weights.
+	// It's converted to the API version supported by the Tenant Control Plane version, also upon upgrades:
+	// the client connection is managed by Kamaji, and the declared one is ignored.
+	// Only the kubescheduler.config.k8s.io/v1 API is supported, hence it requires Kubernetes v1.25.0, or greater.
+	//+kubebuilder:pruning:PreserveUnknownFields
+	Config *runtime.RawExtension `json:"config,omitempty"`
+}
+
+// ComponentFeatureGates defines the feature gates of each Tenant Control Plane component.
+type ComponentFeatureGates struct {
+	APIServer         map[string]bool `json:"apiServer,omitempty"`
+	ControllerManager map[string]bool `json:"controllerManager,omitempty"`
+	Scheduler         map[string]bool `json:"scheduler,omitempty"`
+	Kubelet           map[string]bool `json:"kubelet,omitempty"`
 }
 
 // AdditionalMetadata defines which additional metadata, such as labels and annotations, must be attached to the created resource.
@@ -104,6 +455,11 @@ type ControlPlane struct {
 	Service ServiceSpec `json:"service"`
 	// Defining the options for an Optional Ingress which will expose API Server of the Tenant Control Plane
 	Ingress *IngressSpec `json:"ingress,omitempty"`
+	//+listType=set
+	// Mutators references the cluster-scoped MutationProfile objects applied, in the declared order,
+	// to the rendered Deployment, Service, and ConfigMap objects right before they're applied,
+	// such as to inject a corporate sidecar container.
+	Mutators []string `json:"mutators,omitempty"`
 }
 
 // IngressSpec defines the options for the ingress which will expose API Server of the Tenant Control Plane.
@@ -124,6 +480,99 @@ type ControlPlaneComponentsResources struct {
 	Kine *corev1.ResourceRequirements `json:"kine,omitempty"`
 }
 
+// ControlPlaneComponentsProbes defines the probes of each component of the Control Plane.
+type ControlPlaneComponentsProbes struct {
+	APIServer         *ComponentProbes `json:"apiServer,omitempty"`
+	ControllerManager *ComponentProbes `json:"controllerManager,omitempty"`
+	Scheduler         *ComponentProbes `json:"scheduler,omitempty"`
+}
+
+// ComponentProbes defines the liveness, readiness, and startup probes of a Control Plane component.
+type ComponentProbes struct {
+	Liveness *ProbeSpec `json:"liveness,omitempty"`
+	// Readiness is available only for the kube-apiserver, the other components have no readiness probe.
+	Readiness *ProbeSpec `json:"readiness,omitempty"`
+	Startup   *ProbeSpec `json:"startup,omitempty"`
+}
+
+// ProbeSpec defines the timings, and the thresholds, of a probe: the unset fields keep the Kamaji defaults.
+type ProbeSpec struct {
+	//+kubebuilder:validation:Minimum=0
+	InitialDelaySeconds *int32 `json:"initialDelaySeconds,omitempty"`
+	//+kubebuilder:validation:Minimum=1
+	TimeoutSeconds *int32 `json:"timeoutSeconds,omitempty"`
+	//+kubebuilder:validation:Minimum=1
+	PeriodSeconds *int32 `json:"periodSeconds,omitempty"`
+	//+kubebuilder:validation:Minimum=1
+	FailureThreshold *int32 `json:"failureThreshold,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Monolithic;Split
+type ComponentTopology string
+
+var (
+	// ComponentTopologyMonolithic runs all the Control Plane components in the same pod.
+	ComponentTopologyMonolithic ComponentTopology = "Monolithic"
+	// ComponentTopologySplit runs the controller-manager, and the scheduler, in dedicated Deployments.
+	ComponentTopologySplit ComponentTopology = "Split"
+)
+
+// +kubebuilder:validation:Enum=Restricted;Baseline;Custom
+type SecurityProfile string
+
+var (
+	// SecurityProfileRestricted complies with the restricted Pod Security Standard: the containers run as a non-root user,
+	// with a read-only root filesystem, the RuntimeDefault seccomp profile, no privilege escalation, and all the capabilities dropped.
+	SecurityProfileRestricted SecurityProfile = "Restricted"
+	// SecurityProfileBaseline hardens the containers with the RuntimeDefault seccomp profile, no privilege escalation,
+	// and all the capabilities dropped, leaving the user, and the root filesystem, untouched.
+	SecurityProfileBaseline SecurityProfile = "Baseline"
+	// SecurityProfileCustom applies the declared security contexts.
+	SecurityProfileCustom SecurityProfile = "Custom"
+)
+
+// ControlPlaneSecurityContext defines the security contexts applied with the Custom security profile.
+type ControlPlaneSecurityContext struct {
+	// Pod is the security context of the Control Plane pods, such as the AppArmor, and the seccomp, profiles.
+	Pod *corev1.PodSecurityContext `json:"pod,omitempty"`
+	// Container is the security context of each Control Plane container managed by Kamaji.
+	Container *corev1.SecurityContext `json:"container,omitempty"`
+}
+
+// SplitComponentName is the name of a Control Plane component running in a dedicated Deployment with the Split topology.
+type SplitComponentName string
+
+const (
+	SplitComponentControllerManager SplitComponentName = "controller-manager"
+	SplitComponentScheduler         SplitComponentName = "scheduler"
+)
+
+// SplitComponentsSpec defines the Deployments of the controller-manager, and the scheduler, with the Split topology.
+type SplitComponentsSpec struct {
+	ControllerManager *SplitComponentSpec `json:"controllerManager,omitempty"`
+	Scheduler         *SplitComponentSpec `json:"scheduler,omitempty"`
+}
+
+// SplitComponentSpec defines the replicas, and the disruption budget, of a Control Plane component Deployment.
+type SplitComponentSpec struct {
+	// Replicas of the component Deployment, defaulting to the replicas of the Control Plane Deployment.
+	// The components are using the leader election, a single replica is active at a time.
+	//+kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+	// PodDisruptionBudget is created for the component Deployment when declared.
+	PodDisruptionBudget *PodDisruptionBudgetSpec `json:"podDisruptionBudget,omitempty"`
+}
+
+// PodDisruptionBudgetSpec defines the disruptions allowed for the pods of a Control Plane component:
+// only one of the fields can be declared.
+//
+// +kubebuilder:validation:XValidation:rule="!(has(self.minAvailable) && has(self.maxUnavailable))",message="minAvailable and maxUnavailable are mutually exclusive"
+type PodDisruptionBudgetSpec struct {
+	MinAvailable   *intstr.IntOrString `json:"minAvailable,omitempty"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// +kubebuilder:validation:XValidation:rule="(has(self.securityProfile) && self.securityProfile == 'Custom') == has(self.securityContext)",message="the securityContext must be declared with the Custom securityProfile only"
 type DeploymentSpec struct {
 	// RegistrySettings allows to override the default images for the given Tenant Control Plane instance.
 	// It could be used to point to a different container registry rather than the public one.
@@ -131,6 +580,17 @@ type DeploymentSpec struct {
 	RegistrySettings RegistrySettings `json:"registrySettings,omitempty"`
 	//+kubebuilder:default=2
 	Replicas *int32 `json:"replicas,omitempty"`
+	// ComponentTopology defines how the Control Plane components are deployed.
+	// Monolithic (default) runs the kube-apiserver, the controller-manager, and the scheduler in the same pod:
+	// any change to one of them rolls out all the components.
+	// Split runs the controller-manager, and the scheduler, in the <name>-controller-manager, and <name>-scheduler, Deployments,
+	// connecting to the kube-apiserver through the Service: a change to the kube-apiserver flags rolls out only its pods,
+	// and vice versa, reducing the API downtime of the single-replica Tenant Control Planes.
+	//+kubebuilder:default=Monolithic
+	ComponentTopology ComponentTopology `json:"componentTopology,omitempty"`
+	// Components defines the replicas, and the disruption budgets, of the controller-manager, and the scheduler, Deployments
+	// with the Split topology: the kube-apiserver replicas are the ones of the Control Plane Deployment.
+	Components *SplitComponentsSpec `json:"components,omitempty"`
 	// NodeSelector is a selector which must be true for the pod to fit on a node.
 	// Selector which must match a node's labels for the pod to be scheduled on that node.
 	// More info: https://kubernetes.io/docs/concepts/configuration/assign-pod-node/
@@ -159,6 +619,9 @@ type DeploymentSpec struct {
 	// Resources defines the amount of memory and CPU to allocate to each component of the Control Plane
 	// (kube-apiserver, controller-manager, and scheduler).
 	Resources *ControlPlaneComponentsResources `json:"resources,omitempty"`
+	// Probes customizes the timings, and the thresholds, of the probes of each component of the Control Plane,
+	// such as relaxing them on overloaded management nodes, preventing restart storms.
+	Probes *ControlPlaneComponentsProbes `json:"probes,omitempty"`
 	// ExtraArgs allows adding additional arguments to the Control Plane components,
 	// such as kube-apiserver, controller-manager, and scheduler. WARNING - This option
 	// can override existing parameters and cause components to misbehave in unxpected ways.
@@ -178,6 +641,78 @@ type DeploymentSpec struct {
 	//+kubebuilder:default="default"
 	// ServiceAccountName allows to specify the service account to be mounted to the pods of the Control plane deployment
 	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+	// Proxy defines the egress proxy used by the Control Plane components,
+	// such as to reach OIDC issuers, or webhooks, outside the Management Cluster network.
+	Proxy *ProxySpec `json:"proxy,omitempty"`
+	// TrustedCAs are additional CA bundles trusted by the Control Plane components, along with the system ones.
+	// Changes to the referenced bundles are rolled out upon the next reconciliation of the Tenant Control Plane.
+	TrustedCAs []TrustedCASource `json:"trustedCAs,omitempty"`
+	// TargetCluster runs the Control Plane pods in a remote cluster, rather than the one hosting Kamaji, and the Tenant Control Plane:
+	// the Deployments, the Services, the PodDisruptionBudgets, the Ingresses, and the NetworkPolicies, are created in the
+	// namespace of the remote cluster named as the Tenant Control Plane one, which must exist.
+	// The Secrets, and the ConfigMaps, mounted by the Control Plane pods are mirrored from the Tenant Control Plane namespace.
+	// It cannot be changed once set, since the existing Control Plane would be orphaned.
+	TargetCluster *TargetClusterSpec `json:"targetCluster,omitempty"`
+	// HostNetwork runs the Control Plane pods in the host network namespace, for the clusters without LoadBalancers
+	// where the node addresses are directly routable from the worker nodes: the Control Plane is exposed both through the Service,
+	// and the node addresses, added to the API Server certificate SANs.
+	// Each Tenant Control Plane is allocated a block of host ports, preventing the conflicts with the other ones on the same node,
+	// and the Control Plane endpoint is advertised with the allocated API Server port.
+	// The Control Plane address must be declared, such as a virtual IP, or a DNS name, resolving to the nodes.
+	HostNetwork *HostNetworkSpec `json:"hostNetwork,omitempty"`
+	// SecurityProfile hardens the security context of the Control Plane containers managed by Kamaji,
+	// such as the kube-apiserver, the kine, and the Konnectivity server ones, in all the Control Plane Deployments:
+	// the additional containers, and the WireGuard sidecar requiring the NET_ADMIN capability, are left untouched.
+	// The profile is verified against the Pod Security Admission level enforced by the Tenant Control Plane namespace.
+	// When empty, the security contexts are left to the container runtime defaults.
+	SecurityProfile SecurityProfile `json:"securityProfile,omitempty"`
+	// SecurityContext defines the security contexts applied with the Custom security profile.
+	SecurityContext *ControlPlaneSecurityContext `json:"securityContext,omitempty"`
+}
+
+// HostNetworkSpec defines the host network mode of the Control Plane pods.
+type HostNetworkSpec struct {
+	// PortRange is the range of the host ports allocated to the Control Plane components,
+	// such as the kube-apiserver, and the kube-controller-manager, and the kube-scheduler, secure serving.
+	//+kubebuilder:default={from:40000,to:49999}
+	PortRange HostPortRange `json:"portRange,omitempty"`
+}
+
+// HostPortRange defines a range of host ports, bounds included.
+// +kubebuilder:validation:XValidation:rule="self.to >= self.from",message="the range upper bound must be greater than, or equal to, the lower one"
+type HostPortRange struct {
+	//+kubebuilder:validation:Minimum=1024
+	//+kubebuilder:validation:Maximum=65535
+	From int32 `json:"from"`
+	//+kubebuilder:validation:Minimum=1024
+	//+kubebuilder:validation:Maximum=65535
+	To int32 `json:"to"`
+}
+
+// TargetClusterSpec defines the remote cluster running the Control Plane pods.
+type TargetClusterSpec struct {
+	// KubeconfigSecretRef references the Secret, in the Tenant Control Plane namespace, containing the kubeconfig of the remote cluster.
+	KubeconfigSecretRef KubeconfigSecretReference `json:"kubeconfigSecretRef"`
+}
+
+// ProxySpec defines the proxy environment variables injected into the Control Plane containers.
+type ProxySpec struct {
+	// HTTPProxy is the proxy used for the HTTP requests, injected as HTTP_PROXY.
+	HTTPProxy string `json:"httpProxy,omitempty"`
+	// HTTPSProxy is the proxy used for the HTTPS requests, injected as HTTPS_PROXY.
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+	// NoProxy is the list of hosts, domains, and CIDRs, which must be reached without proxy, injected as NO_PROXY.
+	// The loopback addresses, the Tenant Cluster Service and Pod CIDRs, the cluster domain,
+	// and the DataStore endpoints are always added.
+	NoProxy []string `json:"noProxy,omitempty"`
+}
+
+// TrustedCASource references a PEM encoded CA bundle, stored in a ConfigMap, or in a Secret,
+// of the Tenant Control Plane namespace.
+// +kubebuilder:validation:XValidation:rule="has(self.configMap) != has(self.secret)",message="exactly one of configMap, or secret, must be specified"
+type TrustedCASource struct {
+	ConfigMap *corev1.ConfigMapKeySelector `json:"configMap,omitempty"`
+	Secret    *corev1.SecretKeySelector    `json:"secret,omitempty"`
 }
 
 // AdditionalVolumeMounts allows mounting additional volumes to the Control Plane components.
@@ -205,6 +740,69 @@ type ServiceSpec struct {
 // AddonSpec defines the spec for every addon.
 type AddonSpec struct {
 	ImageOverrideTrait `json:",inline"`
+	AddonApplyTrait    `json:",inline"`
+	// VersionPolicy defines how the addon version is handled upon the Tenant Control Plane upgrades.
+	// FollowControlPlane (default) bumps the image to the version matching the running Kubernetes one,
+	// according to the kubeadm mapping: Manual keeps the version installed in the Tenant Cluster.
+	// The imageTag pins the version regardless of the policy.
+	//+kubebuilder:default=FollowControlPlane
+	VersionPolicy AddonVersionPolicy `json:"versionPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=FollowControlPlane;Manual
+type AddonVersionPolicy string
+
+var (
+	// AddonVersionPolicyFollowControlPlane makes Kamaji upgrade the addon along with the Tenant Control Plane.
+	AddonVersionPolicyFollowControlPlane AddonVersionPolicy = "FollowControlPlane"
+	// AddonVersionPolicyManual makes Kamaji keep the addon version installed in the Tenant Cluster.
+	AddonVersionPolicyManual AddonVersionPolicy = "Manual"
+)
+
+// +kubebuilder:validation:Enum=Force;IgnoreUserFields
+type AddonConflictPolicy string
+
+var (
+	// AddonConflictPolicyForce makes Kamaji take over the ownership of the fields changed by other actors.
+	AddonConflictPolicyForce AddonConflictPolicy = "Force"
+	// AddonConflictPolicyIgnoreUserFields makes Kamaji leave the fields owned by other actors untouched.
+	AddonConflictPolicyIgnoreUserFields AddonConflictPolicy = "IgnoreUserFields"
+)
+
+type AddonApplyTrait struct {
+	// ConflictPolicy defines how the conflicts are handled when applying the addon resources in the Tenant Cluster,
+	// using the server-side apply strategy with the Kamaji field manager.
+	// Force (default) overwrites the fields changed by other actors, such as users, or GitOps tools:
+	// IgnoreUserFields leaves them untouched, enforcing only the ones owned by Kamaji.
+	//+kubebuilder:default=Force
+	ConflictPolicy AddonConflictPolicy `json:"conflictPolicy,omitempty"`
+	// SyncPolicy defines how the addon resources are kept in sync in the Tenant Cluster.
+	SyncPolicy *AddonSyncPolicy `json:"syncPolicy,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Enabled;WarnOnly;Disabled
+type AddonDriftDetection string
+
+var (
+	// AddonDriftDetectionEnabled makes Kamaji revert any drift of the addon resources.
+	AddonDriftDetectionEnabled AddonDriftDetection = "Enabled"
+	// AddonDriftDetectionWarnOnly makes Kamaji report the drift of the addon resources, without reverting it.
+	AddonDriftDetectionWarnOnly AddonDriftDetection = "WarnOnly"
+	// AddonDriftDetectionDisabled makes Kamaji install the addon resources only once, when missing.
+	AddonDriftDetectionDisabled AddonDriftDetection = "Disabled"
+)
+
+type AddonSyncPolicy struct {
+	// ReconcileInterval defines the period the addon resources are periodically re-synced in the Tenant Cluster,
+	// besides the changes notified by the watched resources.
+	// When empty, the addon is reconciled only upon events.
+	ReconcileInterval *metav1.Duration `json:"reconcileInterval,omitempty"`
+	// DriftDetection defines how the changes to the addon resources performed by other actors are handled.
+	// Enabled (default) reverts them, enforcing the desired state:
+	// WarnOnly reports them in the Kamaji logs without applying any change,
+	// Disabled installs the resources only once, when they're missing.
+	//+kubebuilder:default=Enabled
+	DriftDetection AddonDriftDetection `json:"driftDetection,omitempty"`
 }
 
 type ImageOverrideTrait struct {
@@ -222,6 +820,8 @@ type ImageOverrideTrait struct {
 // unxpected ways. Only modify if you know what you are doing.
 type ExtraArgs []string
 
+//+kubebuilder:validation:XValidation:rule="!has(self.deployment) || self.placement == 'Deployment'",message="deployment is allowed only when placement is Deployment"
+
 type KonnectivityServerSpec struct {
 	// The port which Konnectivity server is listening to.
 	Port int32 `json:"port"`
@@ -234,6 +834,35 @@ type KonnectivityServerSpec struct {
 	// Resources define the amount of CPU and memory to allocate to the Konnectivity server.
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 	ExtraArgs ExtraArgs                    `json:"extraArgs,omitempty"`
+	// Placement defines where the Konnectivity server runs: as a sidecar of the kube-apiserver pods (default),
+	// or in a dedicated Deployment, scaled independently, and exposed to the agents by a dedicated Service.
+	//+kubebuilder:default="Sidecar"
+	//+kubebuilder:validation:Enum=Sidecar;Deployment
+	Placement KonnectivityServerPlacement `json:"placement,omitempty"`
+	// Deployment defines the dedicated Konnectivity server Deployment, when the placement is Deployment.
+	Deployment *KonnectivityServerDeploymentSpec `json:"deployment,omitempty"`
+}
+
+type KonnectivityServerPlacement string
+
+const (
+	// KonnectivityServerPlacementSidecar runs the Konnectivity server as a sidecar container of the kube-apiserver pods,
+	// which are connected to it through a Unix domain socket.
+	KonnectivityServerPlacementSidecar KonnectivityServerPlacement = "Sidecar"
+	// KonnectivityServerPlacementDeployment runs the Konnectivity server in a dedicated Deployment:
+	// the kube-apiserver pods are connected to it through its Service, using mutual TLS.
+	KonnectivityServerPlacementDeployment KonnectivityServerPlacement = "Deployment"
+)
+
+// KonnectivityServerDeploymentSpec defines the dedicated Konnectivity server Deployment.
+type KonnectivityServerDeploymentSpec struct {
+	// Replicas of the Konnectivity server Deployment, independent of the kube-apiserver ones.
+	//+kubebuilder:default=2
+	//+kubebuilder:validation:Minimum=1
+	Replicas *int32 `json:"replicas,omitempty"`
+	// Address advertised to the agents to reach the Konnectivity servers: if not declared, the load balancer address
+	// of the Konnectivity server Service is used, falling back to the Tenant Control Plane one.
+	Address string `json:"address,omitempty"`
 }
 
 type KonnectivityAgentMode string
@@ -265,6 +894,29 @@ type KonnectivityAgentSpec struct {
 	// Must be 0 if Mode is DaemonSet.
 	//+kubebuilder:validation:Optional
 	Replicas int32 `json:"replicas,omitempty"`
+	// RemoteCluster deploys the agent in the cluster hosting the worker nodes, when it's not the Tenant Cluster,
+	// such as when the nodes are managed by a separate cluster: the agent authenticates with a token issued by the Tenant Cluster.
+	RemoteCluster *KonnectivityAgentRemoteClusterSpec `json:"remoteCluster,omitempty"`
+	// MaxUnavailable is the number, or the percentage, of agents rolled out at once, such as upon an upgrade,
+	// or a restart triggered by the health check: the tunnels of the remaining agents are kept up meanwhile.
+	// If not declared, the Kubernetes defaults of the DaemonSet, or the Deployment, are applied.
+	MaxUnavailable  *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+	AddonApplyTrait `json:",inline"`
+}
+
+// KonnectivityAgentRemoteClusterSpec defines the cluster the Konnectivity agent is deployed to.
+type KonnectivityAgentRemoteClusterSpec struct {
+	// KubeconfigSecretRef references the Secret, in the Tenant Control Plane namespace, containing the kubeconfig of the remote cluster.
+	KubeconfigSecretRef KubeconfigSecretReference `json:"kubeconfigSecretRef"`
+}
+
+// KubeconfigSecretReference references a kubeconfig stored in a Secret of the Tenant Control Plane namespace.
+type KubeconfigSecretReference struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Key of the Secret containing the kubeconfig.
+	//+kubebuilder:default=kubeconfig
+	Key string `json:"key,omitempty"`
 }
 
 // KonnectivitySpec defines the spec for Konnectivity.
@@ -273,27 +925,320 @@ type KonnectivitySpec struct {
 	KonnectivityServerSpec KonnectivityServerSpec `json:"server,omitempty"`
 	//+kubebuilder:default={version:"v0.28.6",image:"registry.k8s.io/kas-network-proxy/proxy-agent",mode:"DaemonSet"}
 	KonnectivityAgentSpec KonnectivityAgentSpec `json:"agent,omitempty"`
+	// EgressSelector defines the traffic types proxied through Konnectivity by the API Server:
+	// if not declared, only the cluster traffic is proxied.
+	EgressSelector *KonnectivityEgressSelectorSpec `json:"egressSelector,omitempty"`
+	// HealthCheck enables the monitoring of the agents connected to the Konnectivity servers,
+	// reported with the KonnectivityDegraded condition: the admin endpoint of the servers is exposed on the Pod address.
+	HealthCheck *KonnectivityHealthCheckSpec `json:"healthCheck,omitempty"`
+}
+
+// KonnectivityHealthCheckSpec defines how the agents connected to the Konnectivity servers are monitored, and recovered.
+type KonnectivityHealthCheckSpec struct {
+	// Interval between the probes of the Konnectivity servers.
+	//+kubebuilder:default="1m"
+	Interval metav1.Duration `json:"interval,omitempty"`
+	// GracePeriod is the time the agents are allowed to be disconnected before being restarted.
+	//+kubebuilder:default="5m"
+	GracePeriod metav1.Duration `json:"gracePeriod,omitempty"`
+	// RestartAgents enables the rollout restart of the agents, once disconnected for longer than the grace period:
+	// the agents are restarted at most once per grace period.
+	//+kubebuilder:default=true
+	RestartAgents bool `json:"restartAgents,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=cluster;controlplane;etcd
+type KonnectivityEgressSelection string
+
+const (
+	// KonnectivityEgressSelectionCluster is the traffic towards the nodes, pods, and services of the Tenant Cluster.
+	KonnectivityEgressSelectionCluster KonnectivityEgressSelection = "cluster"
+	// KonnectivityEgressSelectionControlPlane is the traffic towards the control plane, formerly named master.
+	KonnectivityEgressSelectionControlPlane KonnectivityEgressSelection = "controlplane"
+	// KonnectivityEgressSelectionEtcd is the traffic towards the etcd DataStore.
+	KonnectivityEgressSelectionEtcd KonnectivityEgressSelection = "etcd"
+)
+
+// KonnectivityEgressSelectorSpec defines the traffic types routed through Konnectivity:
+// the ones not listed are established directly from the API Server, such as the ones towards the webhooks
+// living in the management cluster network.
+type KonnectivityEgressSelectorSpec struct {
+	//+kubebuilder:validation:MinItems=1
+	//+listType=set
+	Selections []KonnectivityEgressSelection `json:"selections"`
+}
+
+// WireGuardSpec defines the spec for the WireGuard node connectivity addon,
+// an alternative to Konnectivity for the environments where it can't be used.
+type WireGuardSpec struct {
+	// Image providing the wg, and wg-quick, binaries, along with a POSIX shell:
+	// it is used by the Tenant Control Plane sidecar container, and by the node agents.
+	//+kubebuilder:validation:MinLength=1
+	Image string `json:"image"`
+	// Port exposed by the Tenant Control Plane Service for the WireGuard tunnel, using the UDP protocol.
+	//+kubebuilder:default=51820
+	Port int32 `json:"port,omitempty"`
+	// CIDR of the overlay network: the first address is assigned to the Tenant Control Plane,
+	// the following ones to the worker nodes.
+	//+kubebuilder:default="10.250.0.0/16"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the WireGuard CIDR is not supported"
+	CIDR string `json:"cidr,omitempty"`
+	// Tolerations for the node agents.
+	//+kubebuilder:default={{operator: "Exists"}}
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	AddonApplyTrait `json:",inline"`
 }
 
 // AddonsSpec defines the enabled addons and their features.
+// +kubebuilder:validation:XValidation:rule="!(has(self.konnectivity) && has(self.wireGuard))",message="konnectivity and wireGuard are mutually exclusive"
 type AddonsSpec struct {
 	// Enables the DNS addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `coredns`.
 	CoreDNS *AddonSpec `json:"coreDNS,omitempty"`
 	// Enables the Konnectivity addon in the Tenant Cluster, required if the worker nodes are in a different network.
 	Konnectivity *KonnectivitySpec `json:"konnectivity,omitempty"`
+	// Enables the WireGuard addon in the Tenant Cluster, connecting the Tenant Control Plane to the worker nodes
+	// with a WireGuard tunnel: it is mutually exclusive with Konnectivity.
+	WireGuard *WireGuardSpec `json:"wireGuard,omitempty"`
 	// Enables the kube-proxy addon in the Tenant Cluster.
 	// The registry and the tag are configurable, the image is hard-coded to `kube-proxy`.
 	KubeProxy *AddonSpec `json:"kubeProxy,omitempty"`
+	// Enables the front-proxy addon in the Tenant Cluster, required to run extension API servers.
+	// The request header client CA, along with the front-proxy client certificate, are published in the
+	// kube-system/kamaji-front-proxy ConfigMap, and the aggregator routes the requests to the extension API servers
+	// endpoints: Konnectivity is required to reach them from the Tenant Control Plane.
+	FrontProxy *FrontProxySpec `json:"frontProxy,omitempty"`
+}
+
+// FrontProxySpec defines the spec for the front-proxy addon.
+type FrontProxySpec struct {
+	AddonApplyTrait `json:",inline"`
 }
 
 // TenantControlPlaneSpec defines the desired state of TenantControlPlane.
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.dataStore) || has(self.dataStore)", message="unsetting the dataStore is not supported"
 // +kubebuilder:validation:XValidation:rule="!has(oldSelf.dataStoreSchema) || has(self.dataStoreSchema)", message="unsetting the dataStoreSchema is not supported"
+// +kubebuilder:validation:XValidation:rule="has(oldSelf.dedicatedDataStore) == has(self.dedicatedDataStore)", message="switching between a dedicated, and a shared, DataStore is not supported"
+// +kubebuilder:validation:XValidation:rule="!has(self.dedicatedDataStore) || !has(self.dataStoreLifecycle)", message="the DataStore lifecycle is not supported with a dedicated DataStore"
 // +kubebuilder:validation:XValidation:rule="!has(self.networkProfile.loadBalancerSourceRanges) || (size(self.networkProfile.loadBalancerSourceRanges) == 0 || self.controlPlane.service.serviceType == 'LoadBalancer')", message="LoadBalancer source ranges are supported only with LoadBalancer service type"
 // +kubebuilder:validation:XValidation:rule="!has(self.networkProfile.loadBalancerClass) || self.controlPlane.service.serviceType == 'LoadBalancer'", message="LoadBalancerClass is supported only with LoadBalancer service type"
 // +kubebuilder:validation:XValidation:rule="self.controlPlane.service.serviceType != 'LoadBalancer' || (oldSelf.controlPlane.service.serviceType != 'LoadBalancer' && self.controlPlane.service.serviceType == 'LoadBalancer') || has(self.networkProfile.loadBalancerClass) == has(oldSelf.networkProfile.loadBalancerClass)",message="LoadBalancerClass cannot be set or unset at runtime"
 
+// +kubebuilder:validation:Enum=SecretStore;ClusterSecretStore
+
+// ExternalSecretStoreKind is the kind of the External Secrets Operator store the credentials are pushed to.
+type ExternalSecretStoreKind string
+
+const (
+	ExternalSecretStoreKindNamespaced ExternalSecretStoreKind = "SecretStore"
+	ExternalSecretStoreKindCluster    ExternalSecretStoreKind = "ClusterSecretStore"
+)
+
+// +kubebuilder:validation:Enum=Delete;None
+
+// ExternalSecretsDeletionPolicy defines whether the pushed credentials are deleted from the external store along with the Tenant Control Plane.
+type ExternalSecretsDeletionPolicy string
+
+const (
+	ExternalSecretsDeletionPolicyDelete ExternalSecretsDeletionPolicy = "Delete"
+	ExternalSecretsDeletionPolicyNone   ExternalSecretsDeletionPolicy = "None"
+)
+
+// ExternalSecretStoreReference references the External Secrets Operator store, such as a Vault, or an AWS Secrets Manager, one.
+type ExternalSecretStoreReference struct {
+	//+kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	//+kubebuilder:default=ClusterSecretStore
+	Kind ExternalSecretStoreKind `json:"kind,omitempty"`
+}
+
+// ExternalSecretsBackend pushes the generated credentials to an external store,
+// by means of the PushSecret objects of the External Secrets Operator.
+type ExternalSecretsBackend struct {
+	SecretStoreRef ExternalSecretStoreReference `json:"secretStoreRef"`
+	//+kubebuilder:default="kamaji"
+	// RemoteKeyPrefix is prepended to the remote keys, which are in the form <prefix>/<namespace>/<secret-name>.
+	RemoteKeyPrefix string `json:"remoteKeyPrefix,omitempty"`
+	//+kubebuilder:default="1h"
+	// RefreshInterval is the interval the External Secrets Operator pushes again the credentials.
+	RefreshInterval metav1.Duration `json:"refreshInterval,omitempty"`
+	//+kubebuilder:default=None
+	DeletionPolicy ExternalSecretsDeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SecretsBackendSpec defines the backend storing the generated credentials, such as the PKI, and the kubeconfigs,
+// besides the Kubernetes Secrets mounted by the Control Plane pods.
+type SecretsBackendSpec struct {
+	ExternalSecrets *ExternalSecretsBackend `json:"externalSecrets,omitempty"`
+}
+
+// DataStoreLifecycleSpec defines the lifecycle of the DataStore contents of the Tenant Control Plane.
+type DataStoreLifecycleSpec struct {
+	// RetainOnDelete delays the wipe of the DataStore contents upon the deletion with the Delete policy,
+	// such as 168h to retain them for a week: the Tenant Control Plane can be undeleted within the window,
+	// by creating it again with the same name, and schema.
+	// Once expired, the DataStore contents are purged by the Kamaji janitor.
+	RetainOnDelete *metav1.Duration `json:"retainOnDelete,omitempty"`
+	// AdoptExisting re-adopts the state stored in the existing DataStore schema, such as the one retained upon
+	// the deletion of a previous Tenant Control Plane, rather than creating an empty one: the bootstrap phases
+	// already performed on the stored state, such as the bootstrap token, and the cluster-admin RBAC ones, are skipped.
+	// The Certificate Authorities, and the DataStore credentials, are reused when their Secrets are still present.
+	AdoptExisting bool `json:"adoptExisting,omitempty"`
+}
+
+// DedicatedDataStoreSpec defines the etcd cluster provisioned by Kamaji in the Tenant Control Plane namespace,
+// backing the given Tenant Control Plane only, rather than a shared DataStore.
+type DedicatedDataStoreSpec struct {
+	//+kubebuilder:default=1
+	//+kubebuilder:validation:Enum=1;3
+	// Replicas is the number of the etcd members: upon resizing, the members are added, or removed, one at a time,
+	// and the new ones join the cluster as learners, until promoted once in sync with the leader.
+	Replicas int32 `json:"replicas,omitempty"`
+	//+kubebuilder:default="quay.io/coreos/etcd:v3.5.21"
+	// Image is the etcd container image, which must provide the etcdctl binary, used to take the snapshots.
+	Image string `json:"image,omitempty"`
+	//+kubebuilder:default="8Gi"
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the storageSize is not supported"
+	// StorageSize is the size of the persistent volume of each member.
+	StorageSize resource.Quantity `json:"storageSize,omitempty"`
+	//+kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the storageClassName is not supported"
+	// StorageClassName is the StorageClass of the members, and of the snapshots, persistent volumes:
+	// when empty, the default StorageClass is used.
+	StorageClassName *string `json:"storageClassName,omitempty"`
+	// Resources of the etcd containers.
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// Snapshots enables the periodic snapshots of the etcd cluster, each one stored in its own persistent volume.
+	Snapshots *DedicatedDataStoreSnapshotsSpec `json:"snapshots,omitempty"`
+}
+
+// DedicatedDataStoreSnapshotsSpec defines the periodic snapshots of the dedicated etcd cluster.
+type DedicatedDataStoreSnapshotsSpec struct {
+	//+kubebuilder:default="24h"
+	// Interval between two snapshots.
+	Interval metav1.Duration `json:"interval,omitempty"`
+	//+kubebuilder:default=3
+	//+kubebuilder:validation:Minimum=1
+	// Retain is the number of the latest snapshots kept: the older ones are deleted, along with their persistent volumes.
+	Retain int32 `json:"retain,omitempty"`
+	//+kubebuilder:default="8Gi"
+	// StorageSize is the size of the persistent volume of each snapshot.
+	StorageSize resource.Quantity `json:"storageSize,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=UploadConfigKubeadm;UploadConfigKubelet;BootstrapToken;ClusterAdminRBAC
+
+// KubeadmPhaseName is the name of a kubeadm phase performed by Kamaji against the Tenant Cluster.
+type KubeadmPhaseName string
+
+const (
+	KubeadmPhaseUploadConfigKubeadm KubeadmPhaseName = "UploadConfigKubeadm"
+	KubeadmPhaseUploadConfigKubelet KubeadmPhaseName = "UploadConfigKubelet"
+	KubeadmPhaseBootstrapToken      KubeadmPhaseName = "BootstrapToken"
+	KubeadmPhaseClusterAdminRBAC    KubeadmPhaseName = "ClusterAdminRBAC"
+)
+
+// +kubebuilder:validation:Enum=ClusterAdmin;View;Edit;NamespaceAdmin
+
+// RBACProfileName is the name of a curated set of permissions granted in the Tenant Cluster.
+type RBACProfileName string
+
+const (
+	// RBACProfileClusterAdmin binds the cluster-admin ClusterRole cluster-wide.
+	RBACProfileClusterAdmin RBACProfileName = "ClusterAdmin"
+	// RBACProfileView binds the view ClusterRole cluster-wide.
+	RBACProfileView RBACProfileName = "View"
+	// RBACProfileEdit binds the edit ClusterRole cluster-wide.
+	RBACProfileEdit RBACProfileName = "Edit"
+	// RBACProfileNamespaceAdmin binds the admin ClusterRole in the given namespaces.
+	RBACProfileNamespaceAdmin RBACProfileName = "NamespaceAdmin"
+)
+
+// RBACProfile grants a curated set of permissions in the Tenant Cluster to the given groups,
+// such as the ones asserted by an OIDC identity provider.
+// +kubebuilder:validation:XValidation:rule="self.profile != 'NamespaceAdmin' || (has(self.namespaces) && size(self.namespaces) > 0)",message="the NamespaceAdmin profile requires at least a namespace"
+// +kubebuilder:validation:XValidation:rule="self.profile == 'NamespaceAdmin' || !has(self.namespaces) || size(self.namespaces) == 0",message="the namespaces are supported only by the NamespaceAdmin profile"
+type RBACProfile struct {
+	// Name of the profile, used to name the bindings in the Tenant Cluster.
+	//+kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	//+kubebuilder:validation:MaxLength=63
+	Name    string          `json:"name"`
+	Profile RBACProfileName `json:"profile"`
+	// Groups are bound to the profile permissions, including the prefix of the OIDC groups, if any, such as oidc:developers.
+	//+kubebuilder:validation:MinItems=1
+	Groups []string `json:"groups"`
+	// Namespaces where the NamespaceAdmin profile is granted, created when missing.
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// BootstrapSpec defines the kubeadm phases performed against the Tenant Cluster.
+type BootstrapSpec struct {
+	// SkipPhases lists the kubeadm phases Kamaji must not perform, such as the bootstrap token one,
+	// when the worker nodes are joined by other means, like the Cluster API bootstrap providers.
+	// +listType=set
+	SkipPhases []KubeadmPhaseName `json:"skipPhases,omitempty"`
+	// RBACProfiles are the curated sets of permissions granted in the Tenant Cluster, besides the kubeadm cluster-admin binding.
+	// +listType=map
+	// +listMapKey=name
+	RBACProfiles []RBACProfile `json:"rbacProfiles,omitempty"`
+	// ClusterInfo customizes the kube-public/cluster-info ConfigMap used by the nodes for the discovery of the Tenant Control Plane.
+	ClusterInfo *ClusterInfoSpec `json:"clusterInfo,omitempty"`
+}
+
+// ClusterInfoSpec defines the contents of the kube-public/cluster-info ConfigMap, and its signed discovery.
+type ClusterInfoSpec struct {
+	// Endpoints are the API Server URLs advertised to the nodes, overriding the Tenant Control Plane one:
+	// the first one is used by kubeadm for the discovery, the others are published as the endpoint-N clusters.
+	// +kubebuilder:validation:MaxItems=8
+	// +listType=set
+	Endpoints []ClusterInfoEndpoint `json:"endpoints,omitempty"`
+	// CABundle references the PEM encoded certificates appended to the Tenant CA,
+	// such as the intermediate ones of a chain, or the CA of a load balancer terminating the TLS connections.
+	CABundle *TrustedCASource `json:"caBundle,omitempty"`
+	// SignedDiscovery enables the rotation of the signing-only bootstrap tokens, whose JWS signatures of the
+	// cluster-info ConfigMap let the nodes verify it: the current token, and the CA public key hash,
+	// are published in the Secret referenced by the status.
+	SignedDiscovery *SignedDiscoverySpec `json:"signedDiscovery,omitempty"`
+}
+
+// +kubebuilder:validation:Pattern=`^https://[^/]+/?$`
+
+// ClusterInfoEndpoint is the HTTPS URL of an API Server endpoint.
+type ClusterInfoEndpoint string
+
+// SignedDiscoverySpec defines the rotation of the discovery tokens.
+// +kubebuilder:validation:XValidation:rule="!has(self.rotationPeriod) || duration(self.rotationPeriod) >= duration('10m')",message="rotationPeriod must be at least 10m"
+type SignedDiscoverySpec struct {
+	//+kubebuilder:default="24h"
+	// RotationPeriod is the interval between the issuing of the discovery tokens:
+	// each token expires after two periods, leaving the time to the nodes to pick up the new one.
+	RotationPeriod metav1.Duration `json:"rotationPeriod,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Delete;Retain;Orphan
+
+// NamingSpec defines the names of the objects generated for the Tenant Control Plane.
+type NamingSpec struct {
+	//+kubebuilder:default="{{ .Name }}"
+	// Template is the Go template rendering the base name of the generated objects, such as hcp-{{ .Name }}:
+	// the Deployment, and the Services, are named after it, while the names of the other objects, such as the Secrets, are prefixed by it.
+	// The .Name, and .Namespace, values refer to the Tenant Control Plane, and the rendered name must be a DNS-1035 label.
+	Template string `json:"template,omitempty"`
+}
+
+// DeletionPolicy defines what happens to the Tenant Control Plane state upon its deletion.
+type DeletionPolicy string
+
+const (
+	// DeletionPolicyDelete wipes the DataStore contents, and deletes all the Tenant Control Plane objects.
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyRetain keeps the DataStore contents, deleting all the Tenant Control Plane objects.
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+	// DeletionPolicyOrphan keeps the DataStore contents, and the generated Secrets, such as the PKI, and the kubeconfigs,
+	// which are no longer owned by the Tenant Control Plane.
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+)
+
 type TenantControlPlaneSpec struct {
 	// DataStore specifies the DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
 	// When Kamaji runs with the default DataStore flag, all empty values will inherit the default value.
@@ -307,25 +1252,87 @@ type TenantControlPlaneSpec struct {
 	// to the user to avoid clashes between different TenantControlPlanes. If not set upon creation, Kamaji will default the
 	// DataStoreSchema by concatenating the namespace and name of the TenantControlPlane.
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the dataStoreSchema is not supported"
-	DataStoreSchema string       `json:"dataStoreSchema,omitempty"`
-	ControlPlane    ControlPlane `json:"controlPlane"`
+	DataStoreSchema string `json:"dataStoreSchema,omitempty"`
+	// DataStoreLifecycle defines the lifecycle of the DataStore contents, such as their retention upon the deletion.
+	DataStoreLifecycle *DataStoreLifecycleSpec `json:"dataStoreLifecycle,omitempty"`
+	// DedicatedDataStore provisions an etcd cluster in the Tenant Control Plane namespace, backing the given Tenant Control Plane only:
+	// Kamaji manages its lifecycle, such as the members replacement, the resizing, and the snapshots.
+	// It cannot be set along with a shared DataStore, nor set or unset at runtime.
+	DedicatedDataStore *DedicatedDataStoreSpec `json:"dedicatedDataStore,omitempty"`
+	// ImageProfile specifies the cluster-scoped ImageProfile used to override the component images,
+	// such as pointing to mirror registries, or pinning digests, for air-gapped environments.
+	ImageProfile string `json:"imageProfile,omitempty"`
+	// SecretsBackend specifies the external store the generated credentials are written to, such as Vault, or AWS Secrets Manager:
+	// when empty, the credentials are kept in the Kubernetes Secrets only.
+	SecretsBackend *SecretsBackendSpec `json:"secretsBackend,omitempty"`
+	// Naming customizes the names of the generated objects, such as the Deployment, the Service, and the Secrets,
+	// complying with naming conventions, or with the names used by other hosted control plane tools upon migrations.
+	// When empty, the objects are named after the Tenant Control Plane. It cannot be changed once the Tenant Control Plane is created.
+	Naming *NamingSpec `json:"naming,omitempty"`
+	//+kubebuilder:default=Delete
+	// DeletionPolicy defines what happens to the DataStore contents, and to the generated Secrets, upon the Tenant Control Plane deletion.
+	// With the Delete policy, the kamaji.clastix.io/deletion-protection=true annotation requires the deletion to be confirmed
+	// with the kamaji.clastix.io/confirm-deletion annotation, set to the Tenant Control Plane name.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+	ControlPlane   ControlPlane   `json:"controlPlane"`
 	// Kubernetes specification for tenant control plane
 	Kubernetes KubernetesSpec `json:"kubernetes"`
 	// NetworkProfile specifies how the network is
 	NetworkProfile NetworkProfileSpec `json:"networkProfile,omitempty"`
 	// Addons contain which addons are enabled
 	Addons AddonsSpec `json:"addons,omitempty"`
+	// Bootstrap defines the kubeadm phases performed against the Tenant Cluster.
+	Bootstrap *BootstrapSpec `json:"bootstrap,omitempty"`
+	//+kubebuilder:validation:Minimum=60
+	// TTLSecondsAfterReady deletes the Tenant Control Plane, along with its DataStore contents according to the deletion policy,
+	// once the given seconds have elapsed since it became ready for the first time: meant for the short-lived test tenants.
+	TTLSecondsAfterReady *int32 `json:"ttlSecondsAfterReady,omitempty"`
+	//+kubebuilder:validation:Minimum=300
+	// TTLSecondsAfterLastUse deletes the Tenant Control Plane once the given seconds have elapsed since its last use,
+	// as sampled by Kamaji in the Tenant Cluster, such as the node heartbeats, and the events of the workloads.
+	TTLSecondsAfterLastUse *int32 `json:"ttlSecondsAfterLastUse,omitempty"`
+	// Maintenance notifies the Tenant Cluster workloads before the disruptive operations of the Tenant Control Plane,
+	// such as the Certificate Authority rotation, and the minor version upgrades, letting the in-tenant automation quiesce.
+	Maintenance *MaintenanceSpec `json:"maintenance,omitempty"`
+	// Compliance defines the regulatory requirements the Tenant Control Plane must comply with, such as FIPS 140.
+	Compliance *ComplianceSpec `json:"compliance,omitempty"`
+}
+
+// ComplianceSpec defines the regulatory requirements of the Tenant Control Plane.
+type ComplianceSpec struct {
+	// FIPS runs the Tenant Control Plane in FIPS mode: the FIPS-validated component images are selected from the ImageProfile,
+	// the API Server serves the FIPS-approved cipher suites only, and the generated PKI uses 3072 bits RSA keys.
+	// The compliance gaps, such as Kamaji not running in FIPS mode, are reported with the FIPSCompliant condition.
+	FIPS bool `json:"fips,omitempty"`
+}
+
+// MaintenanceSpec defines the notification of the disruptive operations in the Tenant Cluster:
+// the operation is held back until the notification ConfigMap is acknowledged, or the timeout expires.
+type MaintenanceSpec struct {
+	//+kubebuilder:default="kamaji-maintenance"
+	// ConfigMapName is the name of the ConfigMap announcing the pending operation in the Tenant Cluster.
+	ConfigMapName string `json:"configMapName,omitempty"`
+	//+kubebuilder:default="kube-system"
+	// Namespace of the Tenant Cluster where the ConfigMap, and the Event, are created: it must exist.
+	Namespace string `json:"namespace,omitempty"`
+	//+kubebuilder:default="10m"
+	// Timeout after which the operation proceeds, although the notification has not been acknowledged.
+	Timeout metav1.Duration `json:"timeout,omitempty"`
 }
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:subresource:scale:specpath=.spec.controlPlane.deployment.replicas,statuspath=.status.kubernetesResources.deployment.replicas,selectorpath=.status.kubernetesResources.deployment.selector
 //+kubebuilder:resource:categories=kamaji,shortName=tcp
+//+kubebuilder:storageversion
 //+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.kubernetes.version",description="Kubernetes version"
 //+kubebuilder:printcolumn:name="Installed Version",type="string",JSONPath=".status.kubernetesResources.version.version",description="The actual installed Kubernetes version from status"
 //+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.kubernetesResources.version.status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Lifecycle phase of the Tenant Control Plane"
 //+kubebuilder:printcolumn:name="Control-Plane endpoint",type="string",JSONPath=".status.controlPlaneEndpoint",description="Tenant Control Plane Endpoint (API server)"
 //+kubebuilder:printcolumn:name="Kubeconfig",type="string",JSONPath=".status.kubeconfig.admin.secretName",description="Secret which contains admin kubeconfig"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.phaseMessage",description="Message describing the current phase",priority=1
+//+kubebuilder:printcolumn:name="Ready Nodes",type="integer",JSONPath=".status.kubernetesResources.nodes.ready",description="Number of the ready worker nodes",priority=1
 //+kubebuilder:printcolumn:name="Datastore",type="string",JSONPath=".status.storage.dataStoreName",description="DataStore actually used"
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 //+kubebuilder:metadata:annotations={"cert-manager.io/inject-ca-from=kamaji-system/kamaji-serving-cert"}
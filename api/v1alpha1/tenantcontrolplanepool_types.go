@@ -0,0 +1,130 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TenantControlPlanePoolLabel is the label of the Tenant Control Planes provisioned by a pool, its value is the pool name.
+	TenantControlPlanePoolLabel = "kamaji.clastix.io/pool"
+	// TenantControlPlaneClaimLabel is the label of the pooled Tenant Control Planes bound to a claim, its value is the claim name.
+	TenantControlPlaneClaimLabel = "kamaji.clastix.io/claim"
+	// TenantControlPlanePoolTemplateChecksumAnnotation is the checksum of the pool template a Tenant Control Plane has been provisioned with:
+	// the unclaimed Tenant Control Planes provisioned with an outdated template are replaced.
+	TenantControlPlanePoolTemplateChecksumAnnotation = "kamaji.clastix.io/pool-template-checksum"
+)
+
+// TenantControlPlaneTemplate is the template of the Tenant Control Planes provisioned by a pool.
+type TenantControlPlaneTemplate struct {
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	//+kubebuilder:validation:Schemaless
+	//+kubebuilder:validation:Type=object
+	//+kubebuilder:pruning:PreserveUnknownFields
+	// Spec is the specification of the pooled Tenant Control Planes: it's validated upon their creation,
+	// and the rejected ones are reported in the pool status.
+	Spec TenantControlPlaneSpec `json:"spec"`
+}
+
+// TenantControlPlanePoolSpec defines the desired state of TenantControlPlanePool.
+type TenantControlPlanePoolSpec struct {
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=100
+	// Size is the number of unclaimed Tenant Control Planes kept provisioned, ready to be claimed:
+	// a claimed Tenant Control Plane is replaced with a new one.
+	Size     int32                      `json:"size"`
+	Template TenantControlPlaneTemplate `json:"template"`
+}
+
+// TenantControlPlanePoolStatus defines the observed state of TenantControlPlanePool.
+type TenantControlPlanePoolStatus struct {
+	// Available is the number of unclaimed Tenant Control Planes ready to be claimed.
+	Available int32 `json:"available"`
+	// Provisioning is the number of unclaimed Tenant Control Planes not yet ready.
+	Provisioning int32 `json:"provisioning"`
+	// Claimed is the number of Tenant Control Planes bound to a claim.
+	Claimed int32 `json:"claimed"`
+	// Pending is the number of claims waiting for an available Tenant Control Plane.
+	Pending int32 `json:"pending"`
+	// Error reports why the pooled Tenant Control Planes cannot be provisioned, such as for a rejected template.
+	Error string `json:"error,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories=kamaji,shortName=tcppool
+//+kubebuilder:printcolumn:name="Size",type="integer",JSONPath=".spec.size",description="Desired unclaimed Tenant Control Planes"
+//+kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.available",description="Unclaimed ready Tenant Control Planes"
+//+kubebuilder:printcolumn:name="Claimed",type="integer",JSONPath=".status.claimed",description="Claimed Tenant Control Planes"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// TenantControlPlanePool is the Schema for the tenantcontrolplanepools API:
+// it keeps a number of pre-provisioned Tenant Control Planes, bound in seconds to the TenantControlPlaneClaim objects.
+type TenantControlPlanePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlanePoolSpec   `json:"spec,omitempty"`
+	Status TenantControlPlanePoolStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlanePoolList contains a list of TenantControlPlanePool.
+type TenantControlPlanePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlanePool `json:"items"`
+}
+
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="the claim is immutable"
+
+// TenantControlPlaneClaimSpec defines the pool a Tenant Control Plane is claimed from.
+type TenantControlPlaneClaimSpec struct {
+	//+kubebuilder:validation:MinLength=1
+	// PoolName is the name of the TenantControlPlanePool, in the same namespace, providing the Tenant Control Plane.
+	PoolName string `json:"poolName"`
+}
+
+// TenantControlPlaneClaimStatus defines the observed state of TenantControlPlaneClaim.
+type TenantControlPlaneClaimStatus struct {
+	// TenantControlPlane is the name of the Tenant Control Plane bound to the claim.
+	TenantControlPlane string `json:"tenantControlPlane,omitempty"`
+	// KubeconfigSecret is the name of the Secret containing the admin kubeconfig of the bound Tenant Control Plane.
+	KubeconfigSecret string      `json:"kubeconfigSecret,omitempty"`
+	BoundAt          metav1.Time `json:"boundAt,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Namespaced,categories=kamaji,shortName=tcpclaim
+//+kubebuilder:printcolumn:name="Pool",type="string",JSONPath=".spec.poolName",description="TenantControlPlanePool"
+//+kubebuilder:printcolumn:name="Tenant Control Plane",type="string",JSONPath=".status.tenantControlPlane",description="Bound Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Kubeconfig",type="string",JSONPath=".status.kubeconfigSecret",description="Admin kubeconfig Secret"
+//+kubebuilder:printcolumn:name="Bound",type="date",JSONPath=".status.boundAt",description="Binding time"
+
+// TenantControlPlaneClaim is the Schema for the tenantcontrolplaneclaims API:
+// it binds an available Tenant Control Plane of a pool, which is deleted along with the claim.
+type TenantControlPlaneClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneClaimSpec   `json:"spec,omitempty"`
+	Status TenantControlPlaneClaimStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlaneClaimList contains a list of TenantControlPlaneClaim.
+type TenantControlPlaneClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlaneClaim `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantControlPlanePool{}, &TenantControlPlanePoolList{}, &TenantControlPlaneClaim{}, &TenantControlPlaneClaimList{})
+}
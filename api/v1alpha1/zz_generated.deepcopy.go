@@ -8,8 +8,12 @@
 package v1alpha1
 
 import (
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/api/core/v1"
-	runtime "k8s.io/apimachinery/pkg/runtime"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -29,467 +33,3776 @@ func (in *APIServerCertificatesStatus) DeepCopy() *APIServerCertificatesStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AdditionalMetadata) DeepCopyInto(out *AdditionalMetadata) {
+func (in *APIServerEgressPolicySpec) DeepCopyInto(out *APIServerEgressPolicySpec) {
 	*out = *in
-	if in.Labels != nil {
-		in, out := &in.Labels, &out.Labels
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.DataStoreCIDRs != nil {
+		in, out := &in.DataStoreCIDRs, &out.DataStoreCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Annotations != nil {
-		in, out := &in.Annotations, &out.Annotations
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
+	if in.OIDCIssuerCIDRs != nil {
+		in, out := &in.OIDCIssuerCIDRs, &out.OIDCIssuerCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookCIDRs != nil {
+		in, out := &in.WebhookCIDRs, &out.WebhookCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExtraRules != nil {
+		in, out := &in.ExtraRules, &out.ExtraRules
+		*out = make([]networkingv1.NetworkPolicyEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalMetadata.
-func (in *AdditionalMetadata) DeepCopy() *AdditionalMetadata {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerEgressPolicySpec.
+func (in *APIServerEgressPolicySpec) DeepCopy() *APIServerEgressPolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AdditionalMetadata)
+	out := new(APIServerEgressPolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AdditionalVolumeMounts) DeepCopyInto(out *AdditionalVolumeMounts) {
+func (in *APIServerFlowControlSpec) DeepCopyInto(out *APIServerFlowControlSpec) {
 	*out = *in
-	if in.APIServer != nil {
-		in, out := &in.APIServer, &out.APIServer
-		*out = make([]v1.VolumeMount, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ControllerManager != nil {
-		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = make([]v1.VolumeMount, len(*in))
+	if in.PriorityLevels != nil {
+		in, out := &in.PriorityLevels, &out.PriorityLevels
+		*out = make([]FlowControlPriorityLevel, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.Scheduler != nil {
-		in, out := &in.Scheduler, &out.Scheduler
-		*out = make([]v1.VolumeMount, len(*in))
+	if in.FlowSchemas != nil {
+		in, out := &in.FlowSchemas, &out.FlowSchemas
+		*out = make([]FlowControlFlowSchema, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	in.AddonApplyTrait.DeepCopyInto(&out.AddonApplyTrait)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalVolumeMounts.
-func (in *AdditionalVolumeMounts) DeepCopy() *AdditionalVolumeMounts {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerFlowControlSpec.
+func (in *APIServerFlowControlSpec) DeepCopy() *APIServerFlowControlSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AdditionalVolumeMounts)
+	out := new(APIServerFlowControlSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
+func (in *APIServerGracefulShutdownSpec) DeepCopyInto(out *APIServerGracefulShutdownSpec) {
 	*out = *in
-	out.ImageOverrideTrait = in.ImageOverrideTrait
+	if in.TerminationGracePeriodSeconds != nil {
+		in, out := &in.TerminationGracePeriodSeconds, &out.TerminationGracePeriodSeconds
+		*out = new(int64)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
-func (in *AddonSpec) DeepCopy() *AddonSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerGracefulShutdownSpec.
+func (in *APIServerGracefulShutdownSpec) DeepCopy() *APIServerGracefulShutdownSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonSpec)
+	out := new(APIServerGracefulShutdownSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
+func (in *APIServerNodeConnectivitySpec) DeepCopyInto(out *APIServerNodeConnectivitySpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.NodeCIDRs != nil {
+		in, out := &in.NodeCIDRs, &out.NodeCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
-func (in *AddonStatus) DeepCopy() *AddonStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerNodeConnectivitySpec.
+func (in *APIServerNodeConnectivitySpec) DeepCopy() *APIServerNodeConnectivitySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonStatus)
+	out := new(APIServerNodeConnectivitySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
+func (in *APIServerNodeConnectivityStatus) DeepCopyInto(out *APIServerNodeConnectivityStatus) {
 	*out = *in
-	if in.CoreDNS != nil {
-		in, out := &in.CoreDNS, &out.CoreDNS
-		*out = new(AddonSpec)
-		**out = **in
-	}
-	if in.Konnectivity != nil {
-		in, out := &in.Konnectivity, &out.Konnectivity
-		*out = new(KonnectivitySpec)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.KubeProxy != nil {
-		in, out := &in.KubeProxy, &out.KubeProxy
-		*out = new(AddonSpec)
-		**out = **in
-	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsSpec.
-func (in *AddonsSpec) DeepCopy() *AddonsSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerNodeConnectivityStatus.
+func (in *APIServerNodeConnectivityStatus) DeepCopy() *APIServerNodeConnectivityStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonsSpec)
+	out := new(APIServerNodeConnectivityStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AddonsStatus) DeepCopyInto(out *AddonsStatus) {
+func (in *APIServerOIDCDiscoverySpec) DeepCopyInto(out *APIServerOIDCDiscoverySpec) {
 	*out = *in
-	in.CoreDNS.DeepCopyInto(&out.CoreDNS)
-	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
-	in.Konnectivity.DeepCopyInto(&out.Konnectivity)
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsStatus.
-func (in *AddonsStatus) DeepCopy() *AddonsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerOIDCDiscoverySpec.
+func (in *APIServerOIDCDiscoverySpec) DeepCopy() *APIServerOIDCDiscoverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(AddonsStatus)
+	out := new(APIServerOIDCDiscoverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in AdmissionControllers) DeepCopyInto(out *AdmissionControllers) {
-	{
-		in := &in
-		*out = make(AdmissionControllers, len(*in))
-		copy(*out, *in)
-	}
+func (in *APIServerOIDCDiscoveryStatus) DeepCopyInto(out *APIServerOIDCDiscoveryStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllers.
-func (in AdmissionControllers) DeepCopy() AdmissionControllers {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerOIDCDiscoveryStatus.
+func (in *APIServerOIDCDiscoveryStatus) DeepCopy() *APIServerOIDCDiscoveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(AdmissionControllers)
+	out := new(APIServerOIDCDiscoveryStatus)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+func (in *APIServerSpec) DeepCopyInto(out *APIServerSpec) {
 	*out = *in
-	in.Username.DeepCopyInto(&out.Username)
-	in.Password.DeepCopyInto(&out.Password)
+	if in.Tracing != nil {
+		in, out := &in.Tracing, &out.Tracing
+		*out = new(APIServerTracingSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxRequestsInflight != nil {
+		in, out := &in.MaxRequestsInflight, &out.MaxRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxMutatingRequestsInflight != nil {
+		in, out := &in.MaxMutatingRequestsInflight, &out.MaxMutatingRequestsInflight
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FlowControl != nil {
+		in, out := &in.FlowControl, &out.FlowControl
+		*out = new(APIServerFlowControlSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.EgressPolicy != nil {
+		in, out := &in.EgressPolicy, &out.EgressPolicy
+		*out = new(APIServerEgressPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GracefulShutdown != nil {
+		in, out := &in.GracefulShutdown, &out.GracefulShutdown
+		*out = new(APIServerGracefulShutdownSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeConnectivity != nil {
+		in, out := &in.NodeConnectivity, &out.NodeConnectivity
+		*out = new(APIServerNodeConnectivitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalServiceAccountIssuers != nil {
+		in, out := &in.AdditionalServiceAccountIssuers, &out.AdditionalServiceAccountIssuers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.APIAudiences != nil {
+		in, out := &in.APIAudiences, &out.APIAudiences
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OIDCDiscovery != nil {
+		in, out := &in.OIDCDiscovery, &out.OIDCDiscovery
+		*out = new(APIServerOIDCDiscoverySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(APIServerTLSSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		*out = new(APIServerStorageSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
-func (in *BasicAuth) DeepCopy() *BasicAuth {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerSpec.
+func (in *APIServerSpec) DeepCopy() *APIServerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(BasicAuth)
+	out := new(APIServerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertKeyPair) DeepCopyInto(out *CertKeyPair) {
+func (in *APIServerStorageSpec) DeepCopyInto(out *APIServerStorageSpec) {
 	*out = *in
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	if in.PrivateKey != nil {
-		in, out := &in.PrivateKey, &out.PrivateKey
-		*out = new(ContentRef)
-		(*in).DeepCopyInto(*out)
+	if in.WatchCache != nil {
+		in, out := &in.WatchCache, &out.WatchCache
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DefaultWatchCacheSize != nil {
+		in, out := &in.DefaultWatchCacheSize, &out.DefaultWatchCacheSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WatchCacheSizes != nil {
+		in, out := &in.WatchCacheSizes, &out.WatchCacheSizes
+		*out = make([]APIServerWatchCacheSize, len(*in))
+		copy(*out, *in)
+	}
+	if in.EtcdCompactionInterval != nil {
+		in, out := &in.EtcdCompactionInterval, &out.EtcdCompactionInterval
+		*out = new(metav1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertKeyPair.
-func (in *CertKeyPair) DeepCopy() *CertKeyPair {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerStorageSpec.
+func (in *APIServerStorageSpec) DeepCopy() *APIServerStorageSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CertKeyPair)
+	out := new(APIServerStorageSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatePrivateKeyPairStatus) DeepCopyInto(out *CertificatePrivateKeyPairStatus) {
+func (in *APIServerTLSSpec) DeepCopyInto(out *APIServerTLSSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.CipherSuites != nil {
+		in, out := &in.CipherSuites, &out.CipherSuites
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HTTP2MaxStreamsPerConnection != nil {
+		in, out := &in.HTTP2MaxStreamsPerConnection, &out.HTTP2MaxStreamsPerConnection
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyPairStatus.
-func (in *CertificatePrivateKeyPairStatus) DeepCopy() *CertificatePrivateKeyPairStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerTLSSpec.
+func (in *APIServerTLSSpec) DeepCopy() *APIServerTLSSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatePrivateKeyPairStatus)
+	out := new(APIServerTLSSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+func (in *APIServerTracingSpec) DeepCopyInto(out *APIServerTracingSpec) {
 	*out = *in
-	in.CA.DeepCopyInto(&out.CA)
-	in.APIServer.DeepCopyInto(&out.APIServer)
-	in.APIServerKubeletClient.DeepCopyInto(&out.APIServerKubeletClient)
-	in.FrontProxyCA.DeepCopyInto(&out.FrontProxyCA)
-	in.FrontProxyClient.DeepCopyInto(&out.FrontProxyClient)
-	in.SA.DeepCopyInto(&out.SA)
-	if in.ETCD != nil {
-		in, out := &in.ETCD, &out.ETCD
-		*out = new(ETCDCertificatesStatus)
-		(*in).DeepCopyInto(*out)
+	if in.SamplingRatePerMillion != nil {
+		in, out := &in.SamplingRatePerMillion, &out.SamplingRatePerMillion
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
-func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerTracingSpec.
+func (in *APIServerTracingSpec) DeepCopy() *APIServerTracingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CertificatesStatus)
+	out := new(APIServerTracingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
+func (in *APIServerTracingStatus) DeepCopyInto(out *APIServerTracingStatus) {
 	*out = *in
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
-func (in *ClientCertificate) DeepCopy() *ClientCertificate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerTracingStatus.
+func (in *APIServerTracingStatus) DeepCopy() *APIServerTracingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ClientCertificate)
+	out := new(APIServerTracingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ContentRef) DeepCopyInto(out *ContentRef) {
+func (in *APIServerWatchCacheSize) DeepCopyInto(out *APIServerWatchCacheSize) {
 	*out = *in
-	if in.Content != nil {
-		in, out := &in.Content, &out.Content
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
-	}
-	if in.SecretRef != nil {
-		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(SecretReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentRef.
-func (in *ContentRef) DeepCopy() *ContentRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIServerWatchCacheSize.
+func (in *APIServerWatchCacheSize) DeepCopy() *APIServerWatchCacheSize {
 	if in == nil {
 		return nil
 	}
-	out := new(ContentRef)
+	out := new(APIServerWatchCacheSize)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+func (in *AdditionalMetadata) DeepCopyInto(out *AdditionalMetadata) {
 	*out = *in
-	in.Deployment.DeepCopyInto(&out.Deployment)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(IngressSpec)
-		(*in).DeepCopyInto(*out)
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
-func (in *ControlPlane) DeepCopy() *ControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalMetadata.
+func (in *AdditionalMetadata) DeepCopy() *AdditionalMetadata {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlane)
+	out := new(AdditionalMetadata)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneComponentsResources) DeepCopyInto(out *ControlPlaneComponentsResources) {
+func (in *AdditionalVolumeMounts) DeepCopyInto(out *AdditionalVolumeMounts) {
 	*out = *in
 	if in.APIServer != nil {
 		in, out := &in.APIServer, &out.APIServer
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ControllerManager != nil {
 		in, out := &in.ControllerManager, &out.ControllerManager
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Scheduler != nil {
 		in, out := &in.Scheduler, &out.Scheduler
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Kine != nil {
-		in, out := &in.Kine, &out.Kine
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+		*out = make([]v1.VolumeMount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentsResources.
-func (in *ControlPlaneComponentsResources) DeepCopy() *ControlPlaneComponentsResources {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalVolumeMounts.
+func (in *AdditionalVolumeMounts) DeepCopy() *AdditionalVolumeMounts {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneComponentsResources)
+	out := new(AdditionalVolumeMounts)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneExtraArgs) DeepCopyInto(out *ControlPlaneExtraArgs) {
+func (in *AddonApplyTrait) DeepCopyInto(out *AddonApplyTrait) {
 	*out = *in
+	if in.SyncPolicy != nil {
+		in, out := &in.SyncPolicy, &out.SyncPolicy
+		*out = new(AddonSyncPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonApplyTrait.
+func (in *AddonApplyTrait) DeepCopy() *AddonApplyTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonApplyTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSpec) DeepCopyInto(out *AddonSpec) {
+	*out = *in
+	out.ImageOverrideTrait = in.ImageOverrideTrait
+	in.AddonApplyTrait.DeepCopyInto(&out.AddonApplyTrait)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSpec.
+func (in *AddonSpec) DeepCopy() *AddonSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonStatus) DeepCopyInto(out *AddonStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.AddonWorkloadStatus.DeepCopyInto(&out.AddonWorkloadStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonStatus.
+func (in *AddonStatus) DeepCopy() *AddonStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonSyncPolicy) DeepCopyInto(out *AddonSyncPolicy) {
+	*out = *in
+	if in.ReconcileInterval != nil {
+		in, out := &in.ReconcileInterval, &out.ReconcileInterval
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonSyncPolicy.
+func (in *AddonSyncPolicy) DeepCopy() *AddonSyncPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonSyncPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonWorkloadStatus) DeepCopyInto(out *AddonWorkloadStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonWorkloadStatus.
+func (in *AddonWorkloadStatus) DeepCopy() *AddonWorkloadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonWorkloadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonsSpec) DeepCopyInto(out *AddonsSpec) {
+	*out = *in
+	if in.CoreDNS != nil {
+		in, out := &in.CoreDNS, &out.CoreDNS
+		*out = new(AddonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Konnectivity != nil {
+		in, out := &in.Konnectivity, &out.Konnectivity
+		*out = new(KonnectivitySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WireGuard != nil {
+		in, out := &in.WireGuard, &out.WireGuard
+		*out = new(WireGuardSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeProxy != nil {
+		in, out := &in.KubeProxy, &out.KubeProxy
+		*out = new(AddonSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FrontProxy != nil {
+		in, out := &in.FrontProxy, &out.FrontProxy
+		*out = new(FrontProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsSpec.
+func (in *AddonsSpec) DeepCopy() *AddonsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AddonsStatus) DeepCopyInto(out *AddonsStatus) {
+	*out = *in
+	in.CoreDNS.DeepCopyInto(&out.CoreDNS)
+	in.KubeProxy.DeepCopyInto(&out.KubeProxy)
+	in.Konnectivity.DeepCopyInto(&out.Konnectivity)
+	in.FrontProxy.DeepCopyInto(&out.FrontProxy)
+	in.WireGuard.DeepCopyInto(&out.WireGuard)
+	in.FlowControl.DeepCopyInto(&out.FlowControl)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AddonsStatus.
+func (in *AddonsStatus) DeepCopy() *AddonsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(AddonsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in AdmissionControllers) DeepCopyInto(out *AdmissionControllers) {
+	{
+		in := &in
+		*out = make(AdmissionControllers, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdmissionControllers.
+func (in AdmissionControllers) DeepCopy() AdmissionControllers {
+	if in == nil {
+		return nil
+	}
+	out := new(AdmissionControllers)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AuditObjectReference) DeepCopyInto(out *AuditObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditObjectReference.
+func (in *AuditObjectReference) DeepCopy() *AuditObjectReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AuditObjectReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BasicAuth) DeepCopyInto(out *BasicAuth) {
+	*out = *in
+	in.Username.DeepCopyInto(&out.Username)
+	in.Password.DeepCopyInto(&out.Password)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BasicAuth.
+func (in *BasicAuth) DeepCopy() *BasicAuth {
+	if in == nil {
+		return nil
+	}
+	out := new(BasicAuth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BootstrapSpec) DeepCopyInto(out *BootstrapSpec) {
+	*out = *in
+	if in.SkipPhases != nil {
+		in, out := &in.SkipPhases, &out.SkipPhases
+		*out = make([]KubeadmPhaseName, len(*in))
+		copy(*out, *in)
+	}
+	if in.RBACProfiles != nil {
+		in, out := &in.RBACProfiles, &out.RBACProfiles
+		*out = make([]RBACProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClusterInfo != nil {
+		in, out := &in.ClusterInfo, &out.ClusterInfo
+		*out = new(ClusterInfoSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BootstrapSpec.
+func (in *BootstrapSpec) DeepCopy() *BootstrapSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BootstrapSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertKeyPair) DeepCopyInto(out *CertKeyPair) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.PrivateKey != nil {
+		in, out := &in.PrivateKey, &out.PrivateKey
+		*out = new(ContentRef)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertKeyPair.
+func (in *CertKeyPair) DeepCopy() *CertKeyPair {
+	if in == nil {
+		return nil
+	}
+	out := new(CertKeyPair)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatePrivateKeyPairStatus) DeepCopyInto(out *CertificatePrivateKeyPairStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatePrivateKeyPairStatus.
+func (in *CertificatePrivateKeyPairStatus) DeepCopy() *CertificatePrivateKeyPairStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatePrivateKeyPairStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocation) DeepCopyInto(out *CertificateRevocation) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocation.
+func (in *CertificateRevocation) DeepCopy() *CertificateRevocation {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRevocation) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocationList) DeepCopyInto(out *CertificateRevocationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CertificateRevocation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocationList.
+func (in *CertificateRevocationList) DeepCopy() *CertificateRevocationList {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CertificateRevocationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocationListStatus) DeepCopyInto(out *CertificateRevocationListStatus) {
+	*out = *in
+	in.NextUpdate.DeepCopyInto(&out.NextUpdate)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocationListStatus.
+func (in *CertificateRevocationListStatus) DeepCopy() *CertificateRevocationListStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocationListStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocationSpec) DeepCopyInto(out *CertificateRevocationSpec) {
+	*out = *in
+	if in.KubeconfigSecret != nil {
+		in, out := &in.KubeconfigSecret, &out.KubeconfigSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocationSpec.
+func (in *CertificateRevocationSpec) DeepCopy() *CertificateRevocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocationStatus) DeepCopyInto(out *CertificateRevocationStatus) {
+	*out = *in
+	in.RevokedAt.DeepCopyInto(&out.RevokedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocationStatus.
+func (in *CertificateRevocationStatus) DeepCopy() *CertificateRevocationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRevocationTenantControlPlane) DeepCopyInto(out *CertificateRevocationTenantControlPlane) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRevocationTenantControlPlane.
+func (in *CertificateRevocationTenantControlPlane) DeepCopy() *CertificateRevocationTenantControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificateRevocationTenantControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificatesStatus) DeepCopyInto(out *CertificatesStatus) {
+	*out = *in
+	in.CA.DeepCopyInto(&out.CA)
+	in.APIServer.DeepCopyInto(&out.APIServer)
+	in.APIServerKubeletClient.DeepCopyInto(&out.APIServerKubeletClient)
+	in.FrontProxyCA.DeepCopyInto(&out.FrontProxyCA)
+	in.FrontProxyClient.DeepCopyInto(&out.FrontProxyClient)
+	in.SA.DeepCopyInto(&out.SA)
+	if in.ETCD != nil {
+		in, out := &in.ETCD, &out.ETCD
+		*out = new(ETCDCertificatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CertificateRevocationListStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificatesStatus.
+func (in *CertificatesStatus) DeepCopy() *CertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClientCertificate) DeepCopyInto(out *ClientCertificate) {
+	*out = *in
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClientCertificate.
+func (in *ClientCertificate) DeepCopy() *ClientCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(ClientCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CloudProviderSpec) DeepCopyInto(out *CloudProviderSpec) {
+	*out = *in
+	if in.ConfigureCloudRoutes != nil {
+		in, out := &in.ConfigureCloudRoutes, &out.ConfigureCloudRoutes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllocateNodeCIDRs != nil {
+		in, out := &in.AllocateNodeCIDRs, &out.AllocateNodeCIDRs
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NodeCIDRMaskSize != nil {
+		in, out := &in.NodeCIDRMaskSize, &out.NodeCIDRMaskSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeCIDRMaskSizeIPv4 != nil {
+		in, out := &in.NodeCIDRMaskSizeIPv4, &out.NodeCIDRMaskSizeIPv4
+		*out = new(int32)
+		**out = **in
+	}
+	if in.NodeCIDRMaskSizeIPv6 != nil {
+		in, out := &in.NodeCIDRMaskSizeIPv6, &out.NodeCIDRMaskSizeIPv6
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudProviderSpec.
+func (in *CloudProviderSpec) DeepCopy() *CloudProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CloudProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterInfoSpec) DeepCopyInto(out *ClusterInfoSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]ClusterInfoEndpoint, len(*in))
+		copy(*out, *in)
+	}
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(TrustedCASource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SignedDiscovery != nil {
+		in, out := &in.SignedDiscovery, &out.SignedDiscovery
+		*out = new(SignedDiscoverySpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterInfoSpec.
+func (in *ClusterInfoSpec) DeepCopy() *ClusterInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComplianceSpec) DeepCopyInto(out *ComplianceSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComplianceSpec.
+func (in *ComplianceSpec) DeepCopy() *ComplianceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComplianceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentFeatureGates) DeepCopyInto(out *ComponentFeatureGates) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Kubelet != nil {
+		in, out := &in.Kubelet, &out.Kubelet
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentFeatureGates.
+func (in *ComponentFeatureGates) DeepCopy() *ComponentFeatureGates {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentFeatureGates)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentImage) DeepCopyInto(out *ComponentImage) {
+	*out = *in
+	if in.Digests != nil {
+		in, out := &in.Digests, &out.Digests
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentImage.
+func (in *ComponentImage) DeepCopy() *ComponentImage {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentImage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentImageStatus) DeepCopyInto(out *ComponentImageStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentImageStatus.
+func (in *ComponentImageStatus) DeepCopy() *ComponentImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentProbes) DeepCopyInto(out *ComponentProbes) {
+	*out = *in
+	if in.Liveness != nil {
+		in, out := &in.Liveness, &out.Liveness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Readiness != nil {
+		in, out := &in.Readiness, &out.Readiness
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Startup != nil {
+		in, out := &in.Startup, &out.Startup
+		*out = new(ProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentProbes.
+func (in *ComponentProbes) DeepCopy() *ComponentProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContentRef) DeepCopyInto(out *ContentRef) {
+	*out = *in
+	if in.Content != nil {
+		in, out := &in.Content, &out.Content
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContentRef.
+func (in *ContentRef) DeepCopy() *ContentRef {
+	if in == nil {
+		return nil
+	}
+	out := new(ContentRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+	*out = *in
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(IngressSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Mutators != nil {
+		in, out := &in.Mutators, &out.Mutators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
+func (in *ControlPlane) DeepCopy() *ControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentsProbes) DeepCopyInto(out *ControlPlaneComponentsProbes) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(ComponentProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ComponentProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(ComponentProbes)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentsProbes.
+func (in *ControlPlaneComponentsProbes) DeepCopy() *ControlPlaneComponentsProbes {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentsProbes)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneComponentsResources) DeepCopyInto(out *ControlPlaneComponentsResources) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneComponentsResources.
+func (in *ControlPlaneComponentsResources) DeepCopy() *ControlPlaneComponentsResources {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneComponentsResources)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneExtraArgs) DeepCopyInto(out *ControlPlaneExtraArgs) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneExtraArgs.
+func (in *ControlPlaneExtraArgs) DeepCopy() *ControlPlaneExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneExtraArgs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneQuotaSpec) DeepCopyInto(out *ControlPlaneQuotaSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneQuotaSpec.
+func (in *ControlPlaneQuotaSpec) DeepCopy() *ControlPlaneQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneSecurityContext) DeepCopyInto(out *ControlPlaneSecurityContext) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(v1.PodSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(v1.SecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneSecurityContext.
+func (in *ControlPlaneSecurityContext) DeepCopy() *ControlPlaneSecurityContext {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneSecurityContext)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControllerManagerSpec) DeepCopyInto(out *ControllerManagerSpec) {
+	*out = *in
+	if in.CloudProvider != nil {
+		in, out := &in.CloudProvider, &out.CloudProvider
+		*out = new(CloudProviderSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerManagerSpec.
+func (in *ControllerManagerSpec) DeepCopy() *ControllerManagerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControllerManagerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStore) DeepCopyInto(out *DataStore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStore.
+func (in *DataStore) DeepCopy() *DataStore {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreCertificateStatus) DeepCopyInto(out *DataStoreCertificateStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreCertificateStatus.
+func (in *DataStoreCertificateStatus) DeepCopy() *DataStoreCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreConfigStatus) DeepCopyInto(out *DataStoreConfigStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreConfigStatus.
+func (in *DataStoreConfigStatus) DeepCopy() *DataStoreConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreLifecycleSpec) DeepCopyInto(out *DataStoreLifecycleSpec) {
+	*out = *in
+	if in.RetainOnDelete != nil {
+		in, out := &in.RetainOnDelete, &out.RetainOnDelete
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreLifecycleSpec.
+func (in *DataStoreLifecycleSpec) DeepCopy() *DataStoreLifecycleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreLifecycleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DataStore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
+func (in *DataStoreList) DeepCopy() *DataStoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DataStoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreProvisionerSpec) DeepCopyInto(out *DataStoreProvisionerSpec) {
+	*out = *in
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreProvisionerSpec.
+func (in *DataStoreProvisionerSpec) DeepCopy() *DataStoreProvisionerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreProvisionerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreRetainedTenant) DeepCopyInto(out *DataStoreRetainedTenant) {
+	*out = *in
+	in.ExpiresAt.DeepCopyInto(&out.ExpiresAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreRetainedTenant.
+func (in *DataStoreRetainedTenant) DeepCopy() *DataStoreRetainedTenant {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreRetainedTenant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSchemaMigrationSpec) DeepCopyInto(out *DataStoreSchemaMigrationSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSchemaMigrationSpec.
+func (in *DataStoreSchemaMigrationSpec) DeepCopy() *DataStoreSchemaMigrationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSchemaMigrationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
+func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSetupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSlowQueriesSpec) DeepCopyInto(out *DataStoreSlowQueriesSpec) {
+	*out = *in
+	out.Threshold = in.Threshold
+	out.Interval = in.Interval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSlowQueriesSpec.
+func (in *DataStoreSlowQueriesSpec) DeepCopy() *DataStoreSlowQueriesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSlowQueriesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make(Endpoints, len(*in))
+		copy(*out, *in)
+	}
+	if in.BasicAuth != nil {
+		in, out := &in.BasicAuth, &out.BasicAuth
+		*out = new(BasicAuth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TLSConfig != nil {
+		in, out := &in.TLSConfig, &out.TLSConfig
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Topology != nil {
+		in, out := &in.Topology, &out.Topology
+		*out = new(DataStoreTopology)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Provisioner != nil {
+		in, out := &in.Provisioner, &out.Provisioner
+		*out = new(DataStoreProvisionerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SchemaMigration != nil {
+		in, out := &in.SchemaMigration, &out.SchemaMigration
+		*out = new(DataStoreSchemaMigrationSpec)
+		**out = **in
+	}
+	if in.SlowQueries != nil {
+		in, out := &in.SlowQueries, &out.SlowQueries
+		*out = new(DataStoreSlowQueriesSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
+func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Retained != nil {
+		in, out := &in.Retained, &out.Retained
+		*out = make([]DataStoreRetainedTenant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.SchemaVersions != nil {
+		in, out := &in.SchemaVersions, &out.SchemaVersions
+		*out = make([]DataStoreTenantSchemaVersion, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
+func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreTenantSchemaVersion) DeepCopyInto(out *DataStoreTenantSchemaVersion) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreTenantSchemaVersion.
+func (in *DataStoreTenantSchemaVersion) DeepCopy() *DataStoreTenantSchemaVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreTenantSchemaVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreTopology) DeepCopyInto(out *DataStoreTopology) {
+	*out = *in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]DataStoreZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreTopology.
+func (in *DataStoreTopology) DeepCopy() *DataStoreTopology {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreTopology)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreZone) DeepCopyInto(out *DataStoreZone) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreZone.
+func (in *DataStoreZone) DeepCopy() *DataStoreZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataStoreZoneReplicas) DeepCopyInto(out *DataStoreZoneReplicas) {
+	*out = *in
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreZoneReplicas.
+func (in *DataStoreZoneReplicas) DeepCopy() *DataStoreZoneReplicas {
+	if in == nil {
+		return nil
+	}
+	out := new(DataStoreZoneReplicas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatastoreUsedSecret) DeepCopyInto(out *DatastoreUsedSecret) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreUsedSecret.
+func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
+	if in == nil {
+		return nil
+	}
+	out := new(DatastoreUsedSecret)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedDataStoreSnapshotsSpec) DeepCopyInto(out *DedicatedDataStoreSnapshotsSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	out.StorageSize = in.StorageSize.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedDataStoreSnapshotsSpec.
+func (in *DedicatedDataStoreSnapshotsSpec) DeepCopy() *DedicatedDataStoreSnapshotsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedDataStoreSnapshotsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedDataStoreSpec) DeepCopyInto(out *DedicatedDataStoreSpec) {
+	*out = *in
+	out.StorageSize = in.StorageSize.DeepCopy()
+	if in.StorageClassName != nil {
+		in, out := &in.StorageClassName, &out.StorageClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Snapshots != nil {
+		in, out := &in.Snapshots, &out.Snapshots
+		*out = new(DedicatedDataStoreSnapshotsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedDataStoreSpec.
+func (in *DedicatedDataStoreSpec) DeepCopy() *DedicatedDataStoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedDataStoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DedicatedDataStoreStatus) DeepCopyInto(out *DedicatedDataStoreStatus) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Learners != nil {
+		in, out := &in.Learners, &out.Learners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastSnapshotTime.DeepCopyInto(&out.LastSnapshotTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DedicatedDataStoreStatus.
+func (in *DedicatedDataStoreStatus) DeepCopy() *DedicatedDataStoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DedicatedDataStoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+	*out = *in
+	out.RegistrySettings = in.RegistrySettings
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = new(SplitComponentsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Strategy.DeepCopyInto(&out.Strategy)
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(v1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]v1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(ControlPlaneComponentsResources)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(ControlPlaneComponentsProbes)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = new(ControlPlaneExtraArgs)
+		(*in).DeepCopyInto(*out)
+	}
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	in.PodAdditionalMetadata.DeepCopyInto(&out.PodAdditionalMetadata)
+	if in.AdditionalInitContainers != nil {
+		in, out := &in.AdditionalInitContainers, &out.AdditionalInitContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalContainers != nil {
+		in, out := &in.AdditionalContainers, &out.AdditionalContainers
+		*out = make([]v1.Container, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalVolumes != nil {
+		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
+		*out = make([]v1.Volume, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.AdditionalVolumeMounts != nil {
+		in, out := &in.AdditionalVolumeMounts, &out.AdditionalVolumeMounts
+		*out = new(AdditionalVolumeMounts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrustedCAs != nil {
+		in, out := &in.TrustedCAs, &out.TrustedCAs
+		*out = make([]TrustedCASource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TargetCluster != nil {
+		in, out := &in.TargetCluster, &out.TargetCluster
+		*out = new(TargetClusterSpec)
+		**out = **in
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(HostNetworkSpec)
+		**out = **in
+	}
+	if in.SecurityContext != nil {
+		in, out := &in.SecurityContext, &out.SecurityContext
+		*out = new(ControlPlaneSecurityContext)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSpec.
+func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDCertificateStatus) DeepCopyInto(out *ETCDCertificateStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificateStatus.
+func (in *ETCDCertificateStatus) DeepCopy() *ETCDCertificateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDCertificateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ETCDCertificatesStatus) DeepCopyInto(out *ETCDCertificatesStatus) {
+	*out = *in
+	in.APIServer.DeepCopyInto(&out.APIServer)
+	in.CA.DeepCopyInto(&out.CA)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificatesStatus.
+func (in *ETCDCertificatesStatus) DeepCopy() *ETCDCertificatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ETCDCertificatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Endpoints) DeepCopyInto(out *Endpoints) {
+	{
+		in := &in
+		*out = make(Endpoints, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoints.
+func (in Endpoints) DeepCopy() Endpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(Endpoints)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
+func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalKubernetesObjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretStoreReference) DeepCopyInto(out *ExternalSecretStoreReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretStoreReference.
+func (in *ExternalSecretStoreReference) DeepCopy() *ExternalSecretStoreReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretStoreReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExternalSecretsBackend) DeepCopyInto(out *ExternalSecretsBackend) {
+	*out = *in
+	out.SecretStoreRef = in.SecretStoreRef
+	out.RefreshInterval = in.RefreshInterval
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretsBackend.
+func (in *ExternalSecretsBackend) DeepCopy() *ExternalSecretsBackend {
+	if in == nil {
+		return nil
+	}
+	out := new(ExternalSecretsBackend)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
+	{
+		in := &in
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
+func (in ExtraArgs) DeepCopy() ExtraArgs {
+	if in == nil {
+		return nil
+	}
+	out := new(ExtraArgs)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowControlFlowSchema) DeepCopyInto(out *FlowControlFlowSchema) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowControlFlowSchema.
+func (in *FlowControlFlowSchema) DeepCopy() *FlowControlFlowSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowControlFlowSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowControlPriorityLevel) DeepCopyInto(out *FlowControlPriorityLevel) {
+	*out = *in
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowControlPriorityLevel.
+func (in *FlowControlPriorityLevel) DeepCopy() *FlowControlPriorityLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowControlPriorityLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FlowControlStatus) DeepCopyInto(out *FlowControlStatus) {
+	*out = *in
+	if in.PriorityLevels != nil {
+		in, out := &in.PriorityLevels, &out.PriorityLevels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FlowSchemas != nil {
+		in, out := &in.FlowSchemas, &out.FlowSchemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FlowControlStatus.
+func (in *FlowControlStatus) DeepCopy() *FlowControlStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FlowControlStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrontProxySpec) DeepCopyInto(out *FrontProxySpec) {
+	*out = *in
+	in.AddonApplyTrait.DeepCopyInto(&out.AddonApplyTrait)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrontProxySpec.
+func (in *FrontProxySpec) DeepCopy() *FrontProxySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrontProxySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostNetworkSpec) DeepCopyInto(out *HostNetworkSpec) {
+	*out = *in
+	out.PortRange = in.PortRange
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostNetworkSpec.
+func (in *HostNetworkSpec) DeepCopy() *HostNetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostNetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostPortRange) DeepCopyInto(out *HostPortRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostPortRange.
+func (in *HostPortRange) DeepCopy() *HostPortRange {
+	if in == nil {
+		return nil
+	}
+	out := new(HostPortRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
+func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageOverrideTrait)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfile) DeepCopyInto(out *ImageProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfile.
+func (in *ImageProfile) DeepCopy() *ImageProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfileComponents) DeepCopyInto(out *ImageProfileComponents) {
+	*out = *in
+	if in.APIServer != nil {
+		in, out := &in.APIServer, &out.APIServer
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CoreDNS != nil {
+		in, out := &in.CoreDNS, &out.CoreDNS
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeProxy != nil {
+		in, out := &in.KubeProxy, &out.KubeProxy
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KonnectivityServer != nil {
+		in, out := &in.KonnectivityServer, &out.KonnectivityServer
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KonnectivityAgent != nil {
+		in, out := &in.KonnectivityAgent, &out.KonnectivityAgent
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Kine != nil {
+		in, out := &in.Kine, &out.Kine
+		*out = new(ComponentImage)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfileComponents.
+func (in *ImageProfileComponents) DeepCopy() *ImageProfileComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfileComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfileList) DeepCopyInto(out *ImageProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ImageProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfileList.
+func (in *ImageProfileList) DeepCopy() *ImageProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ImageProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfileSpec) DeepCopyInto(out *ImageProfileSpec) {
+	*out = *in
+	in.Components.DeepCopyInto(&out.Components)
+	in.FIPSComponents.DeepCopyInto(&out.FIPSComponents)
+	if in.Verification != nil {
+		in, out := &in.Verification, &out.Verification
+		*out = new(ImageVerification)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfileSpec.
+func (in *ImageProfileSpec) DeepCopy() *ImageProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageProfileStatus) DeepCopyInto(out *ImageProfileStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageProfileStatus.
+func (in *ImageProfileStatus) DeepCopy() *ImageProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageVerification) DeepCopyInto(out *ImageVerification) {
+	*out = *in
+	if in.PublicKeys != nil {
+		in, out := &in.PublicKeys, &out.PublicKeys
+		*out = make([]ContentRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Keyless != nil {
+		in, out := &in.Keyless, &out.Keyless
+		*out = make([]KeylessIdentity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageVerification.
+func (in *ImageVerification) DeepCopy() *ImageVerification {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageVerification)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImagesStatus) DeepCopyInto(out *ImagesStatus) {
+	*out = *in
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentImageStatus, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImagesStatus.
+func (in *ImagesStatus) DeepCopy() *ImagesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ImagesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+	*out = *in
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
+func (in *IngressSpec) DeepCopy() *IngressSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(IngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiAuditEvent) DeepCopyInto(out *KamajiAuditEvent) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiAuditEvent.
+func (in *KamajiAuditEvent) DeepCopy() *KamajiAuditEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiAuditEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiAuditEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiAuditEventList) DeepCopyInto(out *KamajiAuditEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KamajiAuditEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiAuditEventList.
+func (in *KamajiAuditEventList) DeepCopy() *KamajiAuditEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiAuditEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiAuditEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiAuditEventSpec) DeepCopyInto(out *KamajiAuditEventSpec) {
+	*out = *in
+	if in.Objects != nil {
+		in, out := &in.Objects, &out.Objects
+		*out = make([]AuditObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiAuditEventSpec.
+func (in *KamajiAuditEventSpec) DeepCopy() *KamajiAuditEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiAuditEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiDefaults) DeepCopyInto(out *KamajiDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiDefaults.
+func (in *KamajiDefaults) DeepCopy() *KamajiDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiDefaultsList) DeepCopyInto(out *KamajiDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KamajiDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiDefaultsList.
+func (in *KamajiDefaultsList) DeepCopy() *KamajiDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiDefaultsSpec) DeepCopyInto(out *KamajiDefaultsSpec) {
+	*out = *in
+	if in.Addons != nil {
+		in, out := &in.Addons, &out.Addons
+		*out = new(AddonsSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TenantNamespace != nil {
+		in, out := &in.TenantNamespace, &out.TenantNamespace
+		*out = new(TenantNamespaceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControlPlaneQuota != nil {
+		in, out := &in.ControlPlaneQuota, &out.ControlPlaneQuota
+		*out = new(ControlPlaneQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiDefaultsSpec.
+func (in *KamajiDefaultsSpec) DeepCopy() *KamajiDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiPolicy) DeepCopyInto(out *KamajiPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiPolicy.
+func (in *KamajiPolicy) DeepCopy() *KamajiPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiPolicyList) DeepCopyInto(out *KamajiPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KamajiPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiPolicyList.
+func (in *KamajiPolicyList) DeepCopy() *KamajiPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KamajiPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiPolicySpec) DeepCopyInto(out *KamajiPolicySpec) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedVersions != nil {
+		in, out := &in.AllowedVersions, &out.AllowedVersions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedDataStores != nil {
+		in, out := &in.AllowedDataStores, &out.AllowedDataStores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredLabels != nil {
+		in, out := &in.RequiredLabels, &out.RequiredLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValidationActions != nil {
+		in, out := &in.ValidationActions, &out.ValidationActions
+		*out = make([]admissionregistrationv1.ValidationAction, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiPolicySpec.
+func (in *KamajiPolicySpec) DeepCopy() *KamajiPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KamajiPolicyStatus) DeepCopyInto(out *KamajiPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KamajiPolicyStatus.
+func (in *KamajiPolicyStatus) DeepCopy() *KamajiPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KamajiPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeylessIdentity) DeepCopyInto(out *KeylessIdentity) {
+	*out = *in
+	in.Roots.DeepCopyInto(&out.Roots)
+	in.TransparencyLogPublicKey.DeepCopyInto(&out.TransparencyLogPublicKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeylessIdentity.
+func (in *KeylessIdentity) DeepCopy() *KeylessIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(KeylessIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentRemoteClusterSpec) DeepCopyInto(out *KonnectivityAgentRemoteClusterSpec) {
+	*out = *in
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentRemoteClusterSpec.
+func (in *KonnectivityAgentRemoteClusterSpec) DeepCopy() *KonnectivityAgentRemoteClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentRemoteClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.RemoteCluster != nil {
+		in, out := &in.RemoteCluster, &out.RemoteCluster
+		*out = new(KonnectivityAgentRemoteClusterSpec)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	in.AddonApplyTrait.DeepCopyInto(&out.AddonApplyTrait)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
+func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityAgentStatus) DeepCopyInto(out *KonnectivityAgentStatus) {
+	*out = *in
+	in.ExternalKubernetesObjectStatus.DeepCopyInto(&out.ExternalKubernetesObjectStatus)
+	in.AddonWorkloadStatus.DeepCopyInto(&out.AddonWorkloadStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentStatus.
+func (in *KonnectivityAgentStatus) DeepCopy() *KonnectivityAgentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityAgentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
+func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityEgressSelectorSpec) DeepCopyInto(out *KonnectivityEgressSelectorSpec) {
+	*out = *in
+	if in.Selections != nil {
+		in, out := &in.Selections, &out.Selections
+		*out = make([]KonnectivityEgressSelection, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityEgressSelectorSpec.
+func (in *KonnectivityEgressSelectorSpec) DeepCopy() *KonnectivityEgressSelectorSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityEgressSelectorSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityHealthCheckSpec) DeepCopyInto(out *KonnectivityHealthCheckSpec) {
+	*out = *in
+	out.Interval = in.Interval
+	out.GracePeriod = in.GracePeriod
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityHealthCheckSpec.
+func (in *KonnectivityHealthCheckSpec) DeepCopy() *KonnectivityHealthCheckSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityHealthCheckSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityHealthStatus) DeepCopyInto(out *KonnectivityHealthStatus) {
+	*out = *in
+	in.LastProbe.DeepCopyInto(&out.LastProbe)
+	in.LastRestart.DeepCopyInto(&out.LastRestart)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityHealthStatus.
+func (in *KonnectivityHealthStatus) DeepCopy() *KonnectivityHealthStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityHealthStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerDeploymentSpec) DeepCopyInto(out *KonnectivityServerDeploymentSpec) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerDeploymentSpec.
+func (in *KonnectivityServerDeploymentSpec) DeepCopy() *KonnectivityServerDeploymentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerDeploymentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraArgs != nil {
+		in, out := &in.ExtraArgs, &out.ExtraArgs
+		*out = make(ExtraArgs, len(*in))
+		copy(*out, *in)
+	}
+	if in.Deployment != nil {
+		in, out := &in.Deployment, &out.Deployment
+		*out = new(KonnectivityServerDeploymentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
+func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+	*out = *in
+	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
+	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
+	if in.EgressSelector != nil {
+		in, out := &in.EgressSelector, &out.EgressSelector
+		*out = new(KonnectivityEgressSelectorSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(KonnectivityHealthCheckSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
+func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+	*out = *in
+	out.ConfigMap = in.ConfigMap
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
+	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
+	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
+	in.Agent.DeepCopyInto(&out.Agent)
+	in.Service.DeepCopyInto(&out.Service)
+	in.Health.DeepCopyInto(&out.Health)
+	in.ServerCertificate.DeepCopyInto(&out.ServerCertificate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
+func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KonnectivityStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
+func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
+func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+	*out = *in
+	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
+	in.RBACProfiles.DeepCopyInto(&out.RBACProfiles)
+	if in.SignedDiscovery != nil {
+		in, out := &in.SignedDiscovery, &out.SignedDiscovery
+		*out = new(SignedDiscoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OIDCDiscovery != nil {
+		in, out := &in.OIDCDiscovery, &out.OIDCDiscovery
+		*out = new(ExternalKubernetesObjectStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
+func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmPhasesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigSecretReference) DeepCopyInto(out *KubeconfigSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigSecretReference.
+func (in *KubeconfigSecretReference) DeepCopy() *KubeconfigSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
+func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+	*out = *in
+	in.Admin.DeepCopyInto(&out.Admin)
+	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
+	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	in.Soot.DeepCopyInto(&out.Soot)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
+func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeconfigsStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletConfigSpec) DeepCopyInto(out *KubeletConfigSpec) {
+	*out = *in
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SerializeImagePulls != nil {
+		in, out := &in.SerializeImagePulls, &out.SerializeImagePulls
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxParallelImagePulls != nil {
+		in, out := &in.MaxParallelImagePulls, &out.MaxParallelImagePulls
+		*out = new(int32)
+		**out = **in
+	}
+	if in.EvictionHard != nil {
+		in, out := &in.EvictionHard, &out.EvictionHard
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoft != nil {
+		in, out := &in.EvictionSoft, &out.EvictionSoft
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.EvictionSoftGracePeriod != nil {
+		in, out := &in.EvictionSoftGracePeriod, &out.EvictionSoftGracePeriod
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletConfigSpec.
+func (in *KubeletConfigSpec) DeepCopy() *KubeletConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletPoolSpec) DeepCopyInto(out *KubeletPoolSpec) {
+	*out = *in
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(KubeletConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletPoolSpec.
+func (in *KubeletPoolSpec) DeepCopy() *KubeletPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletServerCertificateRotationSpec) DeepCopyInto(out *KubeletServerCertificateRotationSpec) {
+	*out = *in
+	if in.VerificationHook != nil {
+		in, out := &in.VerificationHook, &out.VerificationHook
+		*out = new(KubeletServingCSRVerificationHook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletServerCertificateRotationSpec.
+func (in *KubeletServerCertificateRotationSpec) DeepCopy() *KubeletServerCertificateRotationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletServerCertificateRotationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletServingCSRVerificationHook) DeepCopyInto(out *KubeletServingCSRVerificationHook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletServingCSRVerificationHook.
+func (in *KubeletServingCSRVerificationHook) DeepCopy() *KubeletServingCSRVerificationHook {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletServingCSRVerificationHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
+	*out = *in
+	if in.PreferredAddressTypes != nil {
+		in, out := &in.PreferredAddressTypes, &out.PreferredAddressTypes
+		*out = make([]KubeletPreferredAddressType, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServerCertificateRotation != nil {
+		in, out := &in.ServerCertificateRotation, &out.ServerCertificateRotation
+		*out = new(KubeletServerCertificateRotationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(KubeletConfigSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]KubeletPoolSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
+func (in *KubeletSpec) DeepCopy() *KubeletSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeletSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
+	*out = *in
+	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]DataStoreZoneReplicas, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
+func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesDeploymentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesHostNetworkStatus) DeepCopyInto(out *KubernetesHostNetworkStatus) {
+	*out = *in
+	if in.NodeAddresses != nil {
+		in, out := &in.NodeAddresses, &out.NodeAddresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesHostNetworkStatus.
+func (in *KubernetesHostNetworkStatus) DeepCopy() *KubernetesHostNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesHostNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
+	*out = *in
+	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
+func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesIngressStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesNodesStatus) DeepCopyInto(out *KubernetesNodesStatus) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]KubernetesNodesVersion, len(*in))
+		copy(*out, *in)
+	}
+	if in.StaleHeartbeats != nil {
+		in, out := &in.StaleHeartbeats, &out.StaleHeartbeats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesNodesStatus.
+func (in *KubernetesNodesStatus) DeepCopy() *KubernetesNodesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesNodesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesNodesVersion) DeepCopyInto(out *KubernetesNodesVersion) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesNodesVersion.
+func (in *KubernetesNodesVersion) DeepCopy() *KubernetesNodesVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesNodesVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
+	*out = *in
+	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
+func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesServiceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+	*out = *in
+	in.Kubelet.DeepCopyInto(&out.Kubelet)
+	if in.AdmissionControllers != nil {
+		in, out := &in.AdmissionControllers, &out.AdmissionControllers
+		*out = make(AdmissionControllers, len(*in))
+		copy(*out, *in)
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.ComponentFeatureGates.DeepCopyInto(&out.ComponentFeatureGates)
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SchedulerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(ControllerManagerSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.APIServer != nil {
 		in, out := &in.APIServer, &out.APIServer
+		*out = new(APIServerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
+func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
+	*out = *in
+	in.Version.DeepCopyInto(&out.Version)
+	in.Deployment.DeepCopyInto(&out.Deployment)
+	in.Service.DeepCopyInto(&out.Service)
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(KubernetesIngressStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Nodes != nil {
+		in, out := &in.Nodes, &out.Nodes
+		*out = new(KubernetesNodesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostNetwork != nil {
+		in, out := &in.HostNetwork, &out.HostNetwork
+		*out = new(KubernetesHostNetworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
+func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
+	*out = *in
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(KubernetesVersionStatus)
+		**out = **in
+	}
+	if in.Downgrade != nil {
+		in, out := &in.Downgrade, &out.Downgrade
+		*out = new(KubernetesVersionDowngrade)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
+func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionCatalog) DeepCopyInto(out *KubernetesVersionCatalog) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionCatalog.
+func (in *KubernetesVersionCatalog) DeepCopy() *KubernetesVersionCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesVersionCatalog) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionCatalogList) DeepCopyInto(out *KubernetesVersionCatalogList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KubernetesVersionCatalog, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionCatalogList.
+func (in *KubernetesVersionCatalogList) DeepCopy() *KubernetesVersionCatalogList {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionCatalogList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KubernetesVersionCatalogList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionCatalogSpec) DeepCopyInto(out *KubernetesVersionCatalogSpec) {
+	*out = *in
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]KubernetesVersionEntry, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionCatalogSpec.
+func (in *KubernetesVersionCatalogSpec) DeepCopy() *KubernetesVersionCatalogSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionCatalogSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionComponents) DeepCopyInto(out *KubernetesVersionComponents) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionComponents.
+func (in *KubernetesVersionComponents) DeepCopy() *KubernetesVersionComponents {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionComponents)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionDowngrade) DeepCopyInto(out *KubernetesVersionDowngrade) {
+	*out = *in
+	in.BackupTimestamp.DeepCopyInto(&out.BackupTimestamp)
+	in.ApprovedAt.DeepCopyInto(&out.ApprovedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionDowngrade.
+func (in *KubernetesVersionDowngrade) DeepCopy() *KubernetesVersionDowngrade {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionDowngrade)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesVersionEntry) DeepCopyInto(out *KubernetesVersionEntry) {
+	*out = *in
+	out.Components = in.Components
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersionEntry.
+func (in *KubernetesVersionEntry) DeepCopy() *KubernetesVersionEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesVersionEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceSpec) DeepCopyInto(out *MaintenanceSpec) {
+	*out = *in
+	out.Timeout = in.Timeout
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceSpec.
+func (in *MaintenanceSpec) DeepCopy() *MaintenanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceStatus) DeepCopyInto(out *MaintenanceStatus) {
+	*out = *in
+	in.NotifiedAt.DeepCopyInto(&out.NotifiedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceStatus.
+func (in *MaintenanceStatus) DeepCopy() *MaintenanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationPatch) DeepCopyInto(out *MutationPatch) {
+	*out = *in
+	out.Target = in.Target
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationPatch.
+func (in *MutationPatch) DeepCopy() *MutationPatch {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationPatch)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationProfile) DeepCopyInto(out *MutationProfile) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationProfile.
+func (in *MutationProfile) DeepCopy() *MutationProfile {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationProfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MutationProfile) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationProfileList) DeepCopyInto(out *MutationProfileList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MutationProfile, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationProfileList.
+func (in *MutationProfileList) DeepCopy() *MutationProfileList {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationProfileList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MutationProfileList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationProfileSpec) DeepCopyInto(out *MutationProfileSpec) {
+	*out = *in
+	if in.Patches != nil {
+		in, out := &in.Patches, &out.Patches
+		*out = make([]MutationPatch, len(*in))
+		copy(*out, *in)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(MutationWebhook)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationProfileSpec.
+func (in *MutationProfileSpec) DeepCopy() *MutationProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationProfileStatus) DeepCopyInto(out *MutationProfileStatus) {
+	*out = *in
+	if in.UsedBy != nil {
+		in, out := &in.UsedBy, &out.UsedBy
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ControllerManager != nil {
-		in, out := &in.ControllerManager, &out.ControllerManager
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationProfileStatus.
+func (in *MutationProfileStatus) DeepCopy() *MutationProfileStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationProfileStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationTarget) DeepCopyInto(out *MutationTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationTarget.
+func (in *MutationTarget) DeepCopy() *MutationTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MutationWebhook) DeepCopyInto(out *MutationWebhook) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	out.Timeout = in.Timeout
+	if in.Kinds != nil {
+		in, out := &in.Kinds, &out.Kinds
+		*out = make([]MutationTargetKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MutationWebhook.
+func (in *MutationWebhook) DeepCopy() *MutationWebhook {
+	if in == nil {
+		return nil
+	}
+	out := new(MutationWebhook)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamingSpec) DeepCopyInto(out *NamingSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamingSpec.
+func (in *NamingSpec) DeepCopy() *NamingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NamingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
+	*out = *in
+	if in.LoadBalancerSourceRanges != nil {
+		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Scheduler != nil {
-		in, out := &in.Scheduler, &out.Scheduler
+	if in.LoadBalancerClass != nil {
+		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
+		*out = new(string)
+		**out = **in
+	}
+	if in.CertSANs != nil {
+		in, out := &in.CertSANs, &out.CertSANs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Kine != nil {
-		in, out := &in.Kine, &out.Kine
+	if in.DNSServiceIPs != nil {
+		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneExtraArgs.
-func (in *ControlPlaneExtraArgs) DeepCopy() *ControlPlaneExtraArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
+func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneExtraArgs)
+	out := new(NetworkProfileSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStore) DeepCopyInto(out *DataStore) {
+func (in *NotificationDeliveryStatus) DeepCopyInto(out *NotificationDeliveryStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	in.Time.DeepCopyInto(&out.Time)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStore.
-func (in *DataStore) DeepCopy() *DataStore {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDeliveryStatus.
+func (in *NotificationDeliveryStatus) DeepCopy() *NotificationDeliveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStore)
+	out := new(NotificationDeliveryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataStore) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreCertificateStatus) DeepCopyInto(out *DataStoreCertificateStatus) {
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreCertificateStatus.
-func (in *DataStoreCertificateStatus) DeepCopy() *DataStoreCertificateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreCertificateStatus)
+	out := new(NotificationSink)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreConfigStatus) DeepCopyInto(out *DataStoreConfigStatus) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreConfigStatus.
-func (in *DataStoreConfigStatus) DeepCopy() *DataStoreConfigStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationSink) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(DataStoreConfigStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreList) DeepCopyInto(out *DataStoreList) {
+func (in *NotificationSinkList) DeepCopyInto(out *NotificationSinkList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DataStore, len(*in))
+		*out = make([]NotificationSink, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreList.
-func (in *DataStoreList) DeepCopy() *DataStoreList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSinkList.
+func (in *NotificationSinkList) DeepCopy() *NotificationSinkList {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreList)
+	out := new(NotificationSinkList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DataStoreList) DeepCopyObject() runtime.Object {
+func (in *NotificationSinkList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -497,785 +3810,818 @@ func (in *DataStoreList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSetupStatus) DeepCopyInto(out *DataStoreSetupStatus) {
+func (in *NotificationSinkSpec) DeepCopyInto(out *NotificationSinkSpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.URLSecretRef != nil {
+		in, out := &in.URLSecretRef, &out.URLSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.RoutingKeySecretRef != nil {
+		in, out := &in.RoutingKeySecretRef, &out.RoutingKeySecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	out.Throttle = in.Throttle
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSetupStatus.
-func (in *DataStoreSetupStatus) DeepCopy() *DataStoreSetupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSinkSpec.
+func (in *NotificationSinkSpec) DeepCopy() *NotificationSinkSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSetupStatus)
+	out := new(NotificationSinkSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreSpec) DeepCopyInto(out *DataStoreSpec) {
+func (in *NotificationSinkStatus) DeepCopyInto(out *NotificationSinkStatus) {
 	*out = *in
-	if in.Endpoints != nil {
-		in, out := &in.Endpoints, &out.Endpoints
-		*out = make(Endpoints, len(*in))
-		copy(*out, *in)
-	}
-	if in.BasicAuth != nil {
-		in, out := &in.BasicAuth, &out.BasicAuth
-		*out = new(BasicAuth)
+	if in.LastDelivery != nil {
+		in, out := &in.LastDelivery, &out.LastDelivery
+		*out = new(NotificationDeliveryStatus)
 		(*in).DeepCopyInto(*out)
 	}
-	if in.TLSConfig != nil {
-		in, out := &in.TLSConfig, &out.TLSConfig
-		*out = new(TLSConfig)
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(NotificationDeliveryStatus)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreSpec.
-func (in *DataStoreSpec) DeepCopy() *DataStoreSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSinkStatus.
+func (in *NotificationSinkStatus) DeepCopy() *NotificationSinkStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreSpec)
+	out := new(NotificationSinkStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DataStoreStatus) DeepCopyInto(out *DataStoreStatus) {
+func (in *PodDisruptionBudgetSpec) DeepCopyInto(out *PodDisruptionBudgetSpec) {
 	*out = *in
-	if in.UsedBy != nil {
-		in, out := &in.UsedBy, &out.UsedBy
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataStoreStatus.
-func (in *DataStoreStatus) DeepCopy() *DataStoreStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDisruptionBudgetSpec.
+func (in *PodDisruptionBudgetSpec) DeepCopy() *PodDisruptionBudgetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DataStoreStatus)
+	out := new(PodDisruptionBudgetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatastoreUsedSecret) DeepCopyInto(out *DatastoreUsedSecret) {
+func (in *PodSecuritySpec) DeepCopyInto(out *PodSecuritySpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatastoreUsedSecret.
-func (in *DatastoreUsedSecret) DeepCopy() *DatastoreUsedSecret {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecuritySpec.
+func (in *PodSecuritySpec) DeepCopy() *PodSecuritySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DatastoreUsedSecret)
+	out := new(PodSecuritySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DeploymentSpec) DeepCopyInto(out *DeploymentSpec) {
+func (in *ProbeSpec) DeepCopyInto(out *ProbeSpec) {
 	*out = *in
-	out.RegistrySettings = in.RegistrySettings
-	if in.Replicas != nil {
-		in, out := &in.Replicas, &out.Replicas
+	if in.InitialDelaySeconds != nil {
+		in, out := &in.InitialDelaySeconds, &out.InitialDelaySeconds
 		*out = new(int32)
 		**out = **in
 	}
-	if in.NodeSelector != nil {
-		in, out := &in.NodeSelector, &out.NodeSelector
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	in.Strategy.DeepCopyInto(&out.Strategy)
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Affinity != nil {
-		in, out := &in.Affinity, &out.Affinity
-		*out = new(v1.Affinity)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.TopologySpreadConstraints != nil {
-		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
-		*out = make([]v1.TopologySpreadConstraint, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(ControlPlaneComponentsResources)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = new(ControlPlaneExtraArgs)
-		(*in).DeepCopyInto(*out)
-	}
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
-	in.PodAdditionalMetadata.DeepCopyInto(&out.PodAdditionalMetadata)
-	if in.AdditionalInitContainers != nil {
-		in, out := &in.AdditionalInitContainers, &out.AdditionalInitContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.AdditionalContainers != nil {
-		in, out := &in.AdditionalContainers, &out.AdditionalContainers
-		*out = make([]v1.Container, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int32)
+		**out = **in
 	}
-	if in.AdditionalVolumes != nil {
-		in, out := &in.AdditionalVolumes, &out.AdditionalVolumes
-		*out = make([]v1.Volume, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.PeriodSeconds != nil {
+		in, out := &in.PeriodSeconds, &out.PeriodSeconds
+		*out = new(int32)
+		**out = **in
 	}
-	if in.AdditionalVolumeMounts != nil {
-		in, out := &in.AdditionalVolumeMounts, &out.AdditionalVolumeMounts
-		*out = new(AdditionalVolumeMounts)
-		(*in).DeepCopyInto(*out)
+	if in.FailureThreshold != nil {
+		in, out := &in.FailureThreshold, &out.FailureThreshold
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DeploymentSpec.
-func (in *DeploymentSpec) DeepCopy() *DeploymentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProbeSpec.
+func (in *ProbeSpec) DeepCopy() *ProbeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DeploymentSpec)
+	out := new(ProbeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ETCDCertificateStatus) DeepCopyInto(out *ETCDCertificateStatus) {
+func (in *ProxySpec) DeepCopyInto(out *ProxySpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	if in.NoProxy != nil {
+		in, out := &in.NoProxy, &out.NoProxy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificateStatus.
-func (in *ETCDCertificateStatus) DeepCopy() *ETCDCertificateStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxySpec.
+func (in *ProxySpec) DeepCopy() *ProxySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ETCDCertificateStatus)
+	out := new(ProxySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ETCDCertificatesStatus) DeepCopyInto(out *ETCDCertificatesStatus) {
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
 	*out = *in
-	in.APIServer.DeepCopyInto(&out.APIServer)
-	in.CA.DeepCopyInto(&out.CA)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ETCDCertificatesStatus.
-func (in *ETCDCertificatesStatus) DeepCopy() *ETCDCertificatesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
+func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ETCDCertificatesStatus)
+	out := new(PublicKeyPrivateKeyPairStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Endpoints) DeepCopyInto(out *Endpoints) {
-	{
-		in := &in
-		*out = make(Endpoints, len(*in))
+func (in *RBACProfile) DeepCopyInto(out *RBACProfile) {
+	*out = *in
+	if in.Groups != nil {
+		in, out := &in.Groups, &out.Groups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Endpoints.
-func (in Endpoints) DeepCopy() Endpoints {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACProfile.
+func (in *RBACProfile) DeepCopy() *RBACProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(Endpoints)
+	out := new(RBACProfile)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ExternalKubernetesObjectStatus) DeepCopyInto(out *ExternalKubernetesObjectStatus) {
+func (in *RBACProfilesStatus) DeepCopyInto(out *RBACProfilesStatus) {
 	*out = *in
+	if in.Profiles != nil {
+		in, out := &in.Profiles, &out.Profiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalKubernetesObjectStatus.
-func (in *ExternalKubernetesObjectStatus) DeepCopy() *ExternalKubernetesObjectStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RBACProfilesStatus.
+func (in *RBACProfilesStatus) DeepCopy() *RBACProfilesStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ExternalKubernetesObjectStatus)
+	out := new(RBACProfilesStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in ExtraArgs) DeepCopyInto(out *ExtraArgs) {
-	{
-		in := &in
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
-	}
+func (in *RegistrySettings) DeepCopyInto(out *RegistrySettings) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExtraArgs.
-func (in ExtraArgs) DeepCopy() ExtraArgs {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrySettings.
+func (in *RegistrySettings) DeepCopy() *RegistrySettings {
 	if in == nil {
 		return nil
 	}
-	out := new(ExtraArgs)
+	out := new(RegistrySettings)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ImageOverrideTrait) DeepCopyInto(out *ImageOverrideTrait) {
+func (in *RevisionsStatus) DeepCopyInto(out *RevisionsStatus) {
 	*out = *in
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageOverrideTrait.
-func (in *ImageOverrideTrait) DeepCopy() *ImageOverrideTrait {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RevisionsStatus.
+func (in *RevisionsStatus) DeepCopy() *RevisionsStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ImageOverrideTrait)
+	out := new(RevisionsStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *IngressSpec) DeepCopyInto(out *IngressSpec) {
+func (in *SchedulerConfigurationStatus) DeepCopyInto(out *SchedulerConfigurationStatus) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IngressSpec.
-func (in *IngressSpec) DeepCopy() *IngressSpec {
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerConfigurationStatus.
+func (in *SchedulerConfigurationStatus) DeepCopy() *SchedulerConfigurationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(IngressSpec)
+	out := new(SchedulerConfigurationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityAgentSpec) DeepCopyInto(out *KonnectivityAgentSpec) {
+func (in *SchedulerSpec) DeepCopyInto(out *SchedulerSpec) {
 	*out = *in
-	if in.Tolerations != nil {
-		in, out := &in.Tolerations, &out.Tolerations
-		*out = make([]v1.Toleration, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentSpec.
-func (in *KonnectivityAgentSpec) DeepCopy() *KonnectivityAgentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulerSpec.
+func (in *SchedulerSpec) DeepCopy() *SchedulerSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityAgentSpec)
+	out := new(SchedulerSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityAgentStatus) DeepCopyInto(out *KonnectivityAgentStatus) {
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
-	in.ExternalKubernetesObjectStatus.DeepCopyInto(&out.ExternalKubernetesObjectStatus)
+	out.SecretReference = in.SecretReference
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityAgentStatus.
-func (in *KonnectivityAgentStatus) DeepCopy() *KonnectivityAgentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityAgentStatus)
+	out := new(SecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityConfigMap) DeepCopyInto(out *KonnectivityConfigMap) {
+func (in *SecretTenantControlPlane) DeepCopyInto(out *SecretTenantControlPlane) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityConfigMap.
-func (in *KonnectivityConfigMap) DeepCopy() *KonnectivityConfigMap {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretTenantControlPlane.
+func (in *SecretTenantControlPlane) DeepCopy() *SecretTenantControlPlane {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityConfigMap)
+	out := new(SecretTenantControlPlane)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityServerSpec) DeepCopyInto(out *KonnectivityServerSpec) {
+func (in *SecretsBackendSpec) DeepCopyInto(out *SecretsBackendSpec) {
 	*out = *in
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(v1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ExtraArgs != nil {
-		in, out := &in.ExtraArgs, &out.ExtraArgs
-		*out = make(ExtraArgs, len(*in))
-		copy(*out, *in)
+	if in.ExternalSecrets != nil {
+		in, out := &in.ExternalSecrets, &out.ExternalSecrets
+		*out = new(ExternalSecretsBackend)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityServerSpec.
-func (in *KonnectivityServerSpec) DeepCopy() *KonnectivityServerSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackendSpec.
+func (in *SecretsBackendSpec) DeepCopy() *SecretsBackendSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityServerSpec)
+	out := new(SecretsBackendSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivitySpec) DeepCopyInto(out *KonnectivitySpec) {
+func (in *SecretsBackendStatus) DeepCopyInto(out *SecretsBackendStatus) {
 	*out = *in
-	in.KonnectivityServerSpec.DeepCopyInto(&out.KonnectivityServerSpec)
-	in.KonnectivityAgentSpec.DeepCopyInto(&out.KonnectivityAgentSpec)
+	if in.Secrets != nil {
+		in, out := &in.Secrets, &out.Secrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivitySpec.
-func (in *KonnectivitySpec) DeepCopy() *KonnectivitySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretsBackendStatus.
+func (in *SecretsBackendStatus) DeepCopy() *SecretsBackendStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivitySpec)
+	out := new(SecretsBackendStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KonnectivityStatus) DeepCopyInto(out *KonnectivityStatus) {
+func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
 	*out = *in
-	out.ConfigMap = in.ConfigMap
-	in.Certificate.DeepCopyInto(&out.Certificate)
-	in.Kubeconfig.DeepCopyInto(&out.Kubeconfig)
-	in.ServiceAccount.DeepCopyInto(&out.ServiceAccount)
-	in.ClusterRoleBinding.DeepCopyInto(&out.ClusterRoleBinding)
-	in.Agent.DeepCopyInto(&out.Agent)
-	in.Service.DeepCopyInto(&out.Service)
+	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KonnectivityStatus.
-func (in *KonnectivityStatus) DeepCopy() *KonnectivityStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
+func (in *ServiceSpec) DeepCopy() *ServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KonnectivityStatus)
+	out := new(ServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmConfigStatus) DeepCopyInto(out *KubeadmConfigStatus) {
+func (in *SignedDiscoverySpec) DeepCopyInto(out *SignedDiscoverySpec) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.RotationPeriod = in.RotationPeriod
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmConfigStatus.
-func (in *KubeadmConfigStatus) DeepCopy() *KubeadmConfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedDiscoverySpec.
+func (in *SignedDiscoverySpec) DeepCopy() *SignedDiscoverySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmConfigStatus)
+	out := new(SignedDiscoverySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhaseStatus) DeepCopyInto(out *KubeadmPhaseStatus) {
+func (in *SignedDiscoveryStatus) DeepCopyInto(out *SignedDiscoveryStatus) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	in.LastRotation.DeepCopyInto(&out.LastRotation)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhaseStatus.
-func (in *KubeadmPhaseStatus) DeepCopy() *KubeadmPhaseStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SignedDiscoveryStatus.
+func (in *SignedDiscoveryStatus) DeepCopy() *SignedDiscoveryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmPhaseStatus)
+	out := new(SignedDiscoveryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeadmPhasesStatus) DeepCopyInto(out *KubeadmPhasesStatus) {
+func (in *SlowQueriesStatus) DeepCopyInto(out *SlowQueriesStatus) {
 	*out = *in
-	in.BootstrapToken.DeepCopyInto(&out.BootstrapToken)
+	if in.TopQueries != nil {
+		in, out := &in.TopQueries, &out.TopQueries
+		*out = make([]SlowQueryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmPhasesStatus.
-func (in *KubeadmPhasesStatus) DeepCopy() *KubeadmPhasesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlowQueriesStatus.
+func (in *SlowQueriesStatus) DeepCopy() *SlowQueriesStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeadmPhasesStatus)
+	out := new(SlowQueriesStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigStatus) DeepCopyInto(out *KubeconfigStatus) {
+func (in *SlowQueryStatus) DeepCopyInto(out *SlowQueryStatus) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.MaxDuration = in.MaxDuration
+	in.LastSeen.DeepCopyInto(&out.LastSeen)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigStatus.
-func (in *KubeconfigStatus) DeepCopy() *KubeconfigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SlowQueryStatus.
+func (in *SlowQueryStatus) DeepCopy() *SlowQueryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigStatus)
+	out := new(SlowQueryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeconfigsStatus) DeepCopyInto(out *KubeconfigsStatus) {
+func (in *SplitComponentSpec) DeepCopyInto(out *SplitComponentSpec) {
 	*out = *in
-	in.Admin.DeepCopyInto(&out.Admin)
-	in.ControllerManager.DeepCopyInto(&out.ControllerManager)
-	in.Scheduler.DeepCopyInto(&out.Scheduler)
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodDisruptionBudget != nil {
+		in, out := &in.PodDisruptionBudget, &out.PodDisruptionBudget
+		*out = new(PodDisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeconfigsStatus.
-func (in *KubeconfigsStatus) DeepCopy() *KubeconfigsStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitComponentSpec.
+func (in *SplitComponentSpec) DeepCopy() *SplitComponentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeconfigsStatus)
+	out := new(SplitComponentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubeletSpec) DeepCopyInto(out *KubeletSpec) {
+func (in *SplitComponentsSpec) DeepCopyInto(out *SplitComponentsSpec) {
 	*out = *in
-	if in.PreferredAddressTypes != nil {
-		in, out := &in.PreferredAddressTypes, &out.PreferredAddressTypes
-		*out = make([]KubeletPreferredAddressType, len(*in))
-		copy(*out, *in)
+	if in.ControllerManager != nil {
+		in, out := &in.ControllerManager, &out.ControllerManager
+		*out = new(SplitComponentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Scheduler != nil {
+		in, out := &in.Scheduler, &out.Scheduler
+		*out = new(SplitComponentSpec)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeletSpec.
-func (in *KubeletSpec) DeepCopy() *KubeletSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SplitComponentsSpec.
+func (in *SplitComponentsSpec) DeepCopy() *SplitComponentsSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubeletSpec)
+	out := new(SplitComponentsSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesDeploymentStatus) DeepCopyInto(out *KubernetesDeploymentStatus) {
+func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
 	*out = *in
-	in.DeploymentStatus.DeepCopyInto(&out.DeploymentStatus)
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+	out.Config = in.Config
+	in.Setup.DeepCopyInto(&out.Setup)
+	in.Certificate.DeepCopyInto(&out.Certificate)
+	if in.Dedicated != nil {
+		in, out := &in.Dedicated, &out.Dedicated
+		*out = new(DedicatedDataStoreStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SlowQueries != nil {
+		in, out := &in.SlowQueries, &out.SlowQueries
+		*out = new(SlowQueriesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesDeploymentStatus.
-func (in *KubernetesDeploymentStatus) DeepCopy() *KubernetesDeploymentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
+func (in *StorageStatus) DeepCopy() *StorageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesDeploymentStatus)
+	out := new(StorageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesIngressStatus) DeepCopyInto(out *KubernetesIngressStatus) {
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
 	*out = *in
-	in.IngressStatus.DeepCopyInto(&out.IngressStatus)
+	in.CertificateAuthority.DeepCopyInto(&out.CertificateAuthority)
+	if in.ClientCertificate != nil {
+		in, out := &in.ClientCertificate, &out.ClientCertificate
+		*out = new(ClientCertificate)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesIngressStatus.
-func (in *KubernetesIngressStatus) DeepCopy() *KubernetesIngressStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesIngressStatus)
+	out := new(TLSConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesServiceStatus) DeepCopyInto(out *KubernetesServiceStatus) {
+func (in *TTLStatus) DeepCopyInto(out *TTLStatus) {
 	*out = *in
-	in.ServiceStatus.DeepCopyInto(&out.ServiceStatus)
+	if in.ReadyTime != nil {
+		in, out := &in.ReadyTime, &out.ReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastUseTime != nil {
+		in, out := &in.LastUseTime, &out.LastUseTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationTime != nil {
+		in, out := &in.ExpirationTime, &out.ExpirationTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesServiceStatus.
-func (in *KubernetesServiceStatus) DeepCopy() *KubernetesServiceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TTLStatus.
+func (in *TTLStatus) DeepCopy() *TTLStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesServiceStatus)
+	out := new(TTLStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+func (in *TargetClusterSpec) DeepCopyInto(out *TargetClusterSpec) {
 	*out = *in
-	in.Kubelet.DeepCopyInto(&out.Kubelet)
-	if in.AdmissionControllers != nil {
-		in, out := &in.AdmissionControllers, &out.AdmissionControllers
-		*out = make(AdmissionControllers, len(*in))
-		copy(*out, *in)
-	}
+	out.KubeconfigSecretRef = in.KubeconfigSecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
-func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetClusterSpec.
+func (in *TargetClusterSpec) DeepCopy() *TargetClusterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesSpec)
+	out := new(TargetClusterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesStatus) DeepCopyInto(out *KubernetesStatus) {
+func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
 	*out = *in
-	in.Version.DeepCopyInto(&out.Version)
-	in.Deployment.DeepCopyInto(&out.Deployment)
-	in.Service.DeepCopyInto(&out.Service)
-	if in.Ingress != nil {
-		in, out := &in.Ingress, &out.Ingress
-		*out = new(KubernetesIngressStatus)
-		(*in).DeepCopyInto(*out)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesStatus.
-func (in *KubernetesStatus) DeepCopy() *KubernetesStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlane.
+func (in *TenantControlPlane) DeepCopy() *TenantControlPlane {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesStatus)
+	out := new(TenantControlPlane)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesVersion) DeepCopyInto(out *KubernetesVersion) {
+func (in *TenantControlPlaneClaim) DeepCopyInto(out *TenantControlPlaneClaim) {
 	*out = *in
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(KubernetesVersionStatus)
-		**out = **in
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesVersion.
-func (in *KubernetesVersion) DeepCopy() *KubernetesVersion {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClaim.
+func (in *TenantControlPlaneClaim) DeepCopy() *TenantControlPlaneClaim {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesVersion)
+	out := new(TenantControlPlaneClaim)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneClaim) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkProfileSpec) DeepCopyInto(out *NetworkProfileSpec) {
+func (in *TenantControlPlaneClaimList) DeepCopyInto(out *TenantControlPlaneClaimList) {
 	*out = *in
-	if in.LoadBalancerSourceRanges != nil {
-		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.LoadBalancerClass != nil {
-		in, out := &in.LoadBalancerClass, &out.LoadBalancerClass
-		*out = new(string)
-		**out = **in
-	}
-	if in.CertSANs != nil {
-		in, out := &in.CertSANs, &out.CertSANs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.DNSServiceIPs != nil {
-		in, out := &in.DNSServiceIPs, &out.DNSServiceIPs
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlaneClaim, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkProfileSpec.
-func (in *NetworkProfileSpec) DeepCopy() *NetworkProfileSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClaimList.
+func (in *TenantControlPlaneClaimList) DeepCopy() *TenantControlPlaneClaimList {
 	if in == nil {
 		return nil
 	}
-	out := new(NetworkProfileSpec)
+	out := new(TenantControlPlaneClaimList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneClaimList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopyInto(out *PublicKeyPrivateKeyPairStatus) {
+func (in *TenantControlPlaneClaimPool) DeepCopyInto(out *TenantControlPlaneClaimPool) {
 	*out = *in
-	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublicKeyPrivateKeyPairStatus.
-func (in *PublicKeyPrivateKeyPairStatus) DeepCopy() *PublicKeyPrivateKeyPairStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClaimPool.
+func (in *TenantControlPlaneClaimPool) DeepCopy() *TenantControlPlaneClaimPool {
 	if in == nil {
 		return nil
 	}
-	out := new(PublicKeyPrivateKeyPairStatus)
+	out := new(TenantControlPlaneClaimPool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RegistrySettings) DeepCopyInto(out *RegistrySettings) {
+func (in *TenantControlPlaneClaimSpec) DeepCopyInto(out *TenantControlPlaneClaimSpec) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistrySettings.
-func (in *RegistrySettings) DeepCopy() *RegistrySettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClaimSpec.
+func (in *TenantControlPlaneClaimSpec) DeepCopy() *TenantControlPlaneClaimSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RegistrySettings)
+	out := new(TenantControlPlaneClaimSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+func (in *TenantControlPlaneClaimStatus) DeepCopyInto(out *TenantControlPlaneClaimStatus) {
 	*out = *in
-	out.SecretReference = in.SecretReference
+	in.BoundAt.DeepCopyInto(&out.BoundAt)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneClaimStatus.
+func (in *TenantControlPlaneClaimStatus) DeepCopy() *TenantControlPlaneClaimStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(TenantControlPlaneClaimStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceSpec) DeepCopyInto(out *ServiceSpec) {
+func (in *TenantControlPlaneImageProfile) DeepCopyInto(out *TenantControlPlaneImageProfile) {
 	*out = *in
-	in.AdditionalMetadata.DeepCopyInto(&out.AdditionalMetadata)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceSpec.
-func (in *ServiceSpec) DeepCopy() *ServiceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneImageProfile.
+func (in *TenantControlPlaneImageProfile) DeepCopy() *TenantControlPlaneImageProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceSpec)
+	out := new(TenantControlPlaneImageProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *StorageStatus) DeepCopyInto(out *StorageStatus) {
+func (in *TenantControlPlaneList) DeepCopyInto(out *TenantControlPlaneList) {
 	*out = *in
-	out.Config = in.Config
-	in.Setup.DeepCopyInto(&out.Setup)
-	in.Certificate.DeepCopyInto(&out.Certificate)
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageStatus.
-func (in *StorageStatus) DeepCopy() *StorageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneList.
+func (in *TenantControlPlaneList) DeepCopy() *TenantControlPlaneList {
 	if in == nil {
 		return nil
 	}
-	out := new(StorageStatus)
+	out := new(TenantControlPlaneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+func (in *TenantControlPlaneMutationProfile) DeepCopyInto(out *TenantControlPlaneMutationProfile) {
 	*out = *in
-	in.CertificateAuthority.DeepCopyInto(&out.CertificateAuthority)
-	if in.ClientCertificate != nil {
-		in, out := &in.ClientCertificate, &out.ClientCertificate
-		*out = new(ClientCertificate)
-		(*in).DeepCopyInto(*out)
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
-func (in *TLSConfig) DeepCopy() *TLSConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneMutationProfile.
+func (in *TenantControlPlaneMutationProfile) DeepCopy() *TenantControlPlaneMutationProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(TLSConfig)
+	out := new(TenantControlPlaneMutationProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
+func (in *TenantControlPlanePool) DeepCopyInto(out *TenantControlPlanePool) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	out.Status = in.Status
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlane.
-func (in *TenantControlPlane) DeepCopy() *TenantControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlanePool.
+func (in *TenantControlPlanePool) DeepCopy() *TenantControlPlanePool {
 	if in == nil {
 		return nil
 	}
-	out := new(TenantControlPlane)
+	out := new(TenantControlPlanePool)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
+func (in *TenantControlPlanePool) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1283,44 +4629,120 @@ func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TenantControlPlaneList) DeepCopyInto(out *TenantControlPlaneList) {
+func (in *TenantControlPlanePoolList) DeepCopyInto(out *TenantControlPlanePoolList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]TenantControlPlane, len(*in))
+		*out = make([]TenantControlPlanePool, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneList.
-func (in *TenantControlPlaneList) DeepCopy() *TenantControlPlaneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlanePoolList.
+func (in *TenantControlPlanePoolList) DeepCopy() *TenantControlPlanePoolList {
 	if in == nil {
 		return nil
 	}
-	out := new(TenantControlPlaneList)
+	out := new(TenantControlPlanePoolList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
+func (in *TenantControlPlanePoolList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlanePoolSpec) DeepCopyInto(out *TenantControlPlanePoolSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlanePoolSpec.
+func (in *TenantControlPlanePoolSpec) DeepCopy() *TenantControlPlanePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlanePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlanePoolStatus) DeepCopyInto(out *TenantControlPlanePoolStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlanePoolStatus.
+func (in *TenantControlPlanePoolStatus) DeepCopy() *TenantControlPlanePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlanePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
 	*out = *in
+	if in.DataStoreLifecycle != nil {
+		in, out := &in.DataStoreLifecycle, &out.DataStoreLifecycle
+		*out = new(DataStoreLifecycleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DedicatedDataStore != nil {
+		in, out := &in.DedicatedDataStore, &out.DedicatedDataStore
+		*out = new(DedicatedDataStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretsBackend != nil {
+		in, out := &in.SecretsBackend, &out.SecretsBackend
+		*out = new(SecretsBackendSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(NamingSpec)
+		**out = **in
+	}
 	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
 	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
 	in.NetworkProfile.DeepCopyInto(&out.NetworkProfile)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(BootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterReady != nil {
+		in, out := &in.TTLSecondsAfterReady, &out.TTLSecondsAfterReady
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterLastUse != nil {
+		in, out := &in.TTLSecondsAfterLastUse, &out.TTLSecondsAfterLastUse
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceSpec)
+		**out = **in
+	}
+	if in.Compliance != nil {
+		in, out := &in.Compliance, &out.Compliance
+		*out = new(ComplianceSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneSpec.
@@ -1343,6 +4765,58 @@ func (in *TenantControlPlaneStatus) DeepCopyInto(out *TenantControlPlaneStatus)
 	in.KubeadmConfig.DeepCopyInto(&out.KubeadmConfig)
 	in.KubeadmPhase.DeepCopyInto(&out.KubeadmPhase)
 	in.Addons.DeepCopyInto(&out.Addons)
+	if in.SchedulerConfiguration != nil {
+		in, out := &in.SchedulerConfiguration, &out.SchedulerConfiguration
+		*out = new(SchedulerConfigurationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerTracing != nil {
+		in, out := &in.APIServerTracing, &out.APIServerTracing
+		*out = new(APIServerTracingStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerNodeConnectivity != nil {
+		in, out := &in.APIServerNodeConnectivity, &out.APIServerNodeConnectivity
+		*out = new(APIServerNodeConnectivityStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.APIServerOIDCDiscovery != nil {
+		in, out := &in.APIServerOIDCDiscovery, &out.APIServerOIDCDiscovery
+		*out = new(APIServerOIDCDiscoveryStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = new(ImagesStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SecretsBackend != nil {
+		in, out := &in.SecretsBackend, &out.SecretsBackend
+		*out = new(SecretsBackendStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Revisions != nil {
+		in, out := &in.Revisions, &out.Revisions
+		*out = new(RevisionsStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(TTLStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(MaintenanceStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneStatus.
@@ -1369,3 +4843,168 @@ func (in *TenantControlPlaneStatusDataStore) DeepCopy() *TenantControlPlaneStatu
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneTemplate) DeepCopyInto(out *TenantControlPlaneTemplate) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneTemplate.
+func (in *TenantControlPlaneTemplate) DeepCopy() *TenantControlPlaneTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantNamespaceSpec) DeepCopyInto(out *TenantNamespaceSpec) {
+	*out = *in
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.PodSecurity != nil {
+		in, out := &in.PodSecurity, &out.PodSecurity
+		*out = new(PodSecuritySpec)
+		**out = **in
+	}
+	if in.ResourceQuota != nil {
+		in, out := &in.ResourceQuota, &out.ResourceQuota
+		*out = new(v1.ResourceQuotaSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LimitRange != nil {
+		in, out := &in.LimitRange, &out.LimitRange
+		*out = new(v1.LimitRangeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantNamespaceSpec.
+func (in *TenantNamespaceSpec) DeepCopy() *TenantNamespaceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantNamespaceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TrustedCASource) DeepCopyInto(out *TrustedCASource) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(v1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TrustedCASource.
+func (in *TrustedCASource) DeepCopy() *TrustedCASource {
+	if in == nil {
+		return nil
+	}
+	out := new(TrustedCASource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardPeerStatus) DeepCopyInto(out *WireGuardPeerStatus) {
+	*out = *in
+	if in.AllowedIPs != nil {
+		in, out := &in.AllowedIPs, &out.AllowedIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardPeerStatus.
+func (in *WireGuardPeerStatus) DeepCopy() *WireGuardPeerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardPeerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardSpec) DeepCopyInto(out *WireGuardSpec) {
+	*out = *in
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.AddonApplyTrait.DeepCopyInto(&out.AddonApplyTrait)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardSpec.
+func (in *WireGuardSpec) DeepCopy() *WireGuardSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WireGuardStatus) DeepCopyInto(out *WireGuardStatus) {
+	*out = *in
+	if in.Peers != nil {
+		in, out := &in.Peers, &out.Peers
+		*out = make([]WireGuardPeerStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.LastUpdate.DeepCopyInto(&out.LastUpdate)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WireGuardStatus.
+func (in *WireGuardStatus) DeepCopy() *WireGuardStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(WireGuardStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	"fmt"
+
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// ConvertTo converts the TenantControlPlane to the v1alpha1 Hub version, the storage one.
+func (in *TenantControlPlane) ConvertTo(dstRaw conversion.Hub) error {
+	dst, ok := dstRaw.(*kamajiv1alpha1.TenantControlPlane)
+	if !ok {
+		return fmt.Errorf("unsupported conversion to %T", dstRaw)
+	}
+
+	dst.ObjectMeta = *in.ObjectMeta.DeepCopy()
+	dst.Spec = kamajiv1alpha1.TenantControlPlaneSpec{
+		DataStore:              in.Spec.Storage.DataStore,
+		DataStoreSchema:        in.Spec.Storage.Schema,
+		DataStoreLifecycle:     in.Spec.Storage.Lifecycle.DeepCopy(),
+		DedicatedDataStore:     in.Spec.Storage.Dedicated.DeepCopy(),
+		ImageProfile:           in.Spec.ImageProfile,
+		SecretsBackend:         in.Spec.SecretsBackend.DeepCopy(),
+		Naming:                 in.Spec.Naming.DeepCopy(),
+		DeletionPolicy:         in.Spec.DeletionPolicy,
+		ControlPlane:           *in.Spec.ControlPlane.DeepCopy(),
+		Kubernetes:             *in.Spec.Kubernetes.DeepCopy(),
+		NetworkProfile:         *in.Spec.Network.DeepCopy(),
+		Addons:                 *in.Spec.Addons.DeepCopy(),
+		Bootstrap:              in.Spec.Bootstrap.DeepCopy(),
+		TTLSecondsAfterReady:   in.Spec.TTLSecondsAfterReady,
+		TTLSecondsAfterLastUse: in.Spec.TTLSecondsAfterLastUse,
+		Maintenance:            in.Spec.Maintenance.DeepCopy(),
+		Compliance:             in.Spec.Compliance.DeepCopy(),
+	}
+	dst.Status = *in.Status.DeepCopy()
+
+	return nil
+}
+
+// ConvertFrom converts the v1alpha1 Hub version to the TenantControlPlane.
+func (in *TenantControlPlane) ConvertFrom(srcRaw conversion.Hub) error {
+	src, ok := srcRaw.(*kamajiv1alpha1.TenantControlPlane)
+	if !ok {
+		return fmt.Errorf("unsupported conversion from %T", srcRaw)
+	}
+
+	in.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	in.Spec = TenantControlPlaneSpec{
+		Kubernetes: *src.Spec.Kubernetes.DeepCopy(),
+		Storage: StorageSpec{
+			DataStore: src.Spec.DataStore,
+			Schema:    src.Spec.DataStoreSchema,
+			Lifecycle: src.Spec.DataStoreLifecycle.DeepCopy(),
+			Dedicated: src.Spec.DedicatedDataStore.DeepCopy(),
+		},
+		ImageProfile:           src.Spec.ImageProfile,
+		SecretsBackend:         src.Spec.SecretsBackend.DeepCopy(),
+		Naming:                 src.Spec.Naming.DeepCopy(),
+		DeletionPolicy:         src.Spec.DeletionPolicy,
+		ControlPlane:           *src.Spec.ControlPlane.DeepCopy(),
+		Network:                *src.Spec.NetworkProfile.DeepCopy(),
+		Addons:                 *src.Spec.Addons.DeepCopy(),
+		Bootstrap:              src.Spec.Bootstrap.DeepCopy(),
+		TTLSecondsAfterReady:   src.Spec.TTLSecondsAfterReady,
+		TTLSecondsAfterLastUse: src.Spec.TTLSecondsAfterLastUse,
+		Maintenance:            src.Spec.Maintenance.DeepCopy(),
+		Compliance:             src.Spec.Compliance.DeepCopy(),
+	}
+	in.Status = *src.Status.DeepCopy()
+
+	return nil
+}
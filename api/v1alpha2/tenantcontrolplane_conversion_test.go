@@ -0,0 +1,142 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajiv1alpha2 "github.com/clastix/kamaji/api/v1alpha2"
+)
+
+var _ = Describe("TenantControlPlane conversion", func() {
+	hub := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tenant-00",
+			Namespace: "default",
+			Labels:    map[string]string{"tenant.clastix.io": "tenant-00"},
+		},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			DataStore:       "default",
+			DataStoreSchema: "default_tenant_00",
+			DataStoreLifecycle: &kamajiv1alpha1.DataStoreLifecycleSpec{
+				RetainOnDelete: &metav1.Duration{Duration: 168 * time.Hour},
+			},
+			DedicatedDataStore: &kamajiv1alpha1.DedicatedDataStoreSpec{
+				Replicas:  3,
+				Snapshots: &kamajiv1alpha1.DedicatedDataStoreSnapshotsSpec{Interval: metav1.Duration{Duration: 24 * time.Hour}, Retain: 3},
+			},
+			ImageProfile:   "air-gapped",
+			DeletionPolicy: kamajiv1alpha1.DeletionPolicyRetain,
+			SecretsBackend: &kamajiv1alpha1.SecretsBackendSpec{
+				ExternalSecrets: &kamajiv1alpha1.ExternalSecretsBackend{
+					SecretStoreRef: kamajiv1alpha1.ExternalSecretStoreReference{Name: "vault", Kind: kamajiv1alpha1.ExternalSecretStoreKindCluster},
+				},
+			},
+			ControlPlane: kamajiv1alpha1.ControlPlane{
+				Deployment: kamajiv1alpha1.DeploymentSpec{Replicas: ptr.To(int32(2))},
+				Service:    kamajiv1alpha1.ServiceSpec{ServiceType: kamajiv1alpha1.ServiceTypeLoadBalancer},
+			},
+			Kubernetes: kamajiv1alpha1.KubernetesSpec{
+				Version: "v1.33.0",
+				Kubelet: kamajiv1alpha1.KubeletSpec{CGroupFS: "systemd"},
+			},
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{
+				Address:                  "172.18.0.100",
+				Port:                     6443,
+				ServiceCIDR:              "10.96.0.0/16",
+				PodCIDR:                  "10.244.0.0/16",
+				DNSServiceIPs:            []string{"10.96.0.10"},
+				LoadBalancerSourceRanges: []string{"10.0.0.0/8"},
+			},
+			Addons: kamajiv1alpha1.AddonsSpec{
+				CoreDNS:   &kamajiv1alpha1.AddonSpec{},
+				KubeProxy: &kamajiv1alpha1.AddonSpec{},
+			},
+			Bootstrap: &kamajiv1alpha1.BootstrapSpec{
+				SkipPhases: []kamajiv1alpha1.KubeadmPhaseName{kamajiv1alpha1.KubeadmPhaseBootstrapToken},
+			},
+			TTLSecondsAfterLastUse: ptr.To(int32(3600)),
+			Maintenance:            &kamajiv1alpha1.MaintenanceSpec{ConfigMapName: "kamaji-maintenance", Namespace: "kube-system"},
+			Compliance:             &kamajiv1alpha1.ComplianceSpec{FIPS: true},
+		},
+		Status: kamajiv1alpha1.TenantControlPlaneStatus{
+			ControlPlaneEndpoint: "172.18.0.100:6443",
+			Storage:              kamajiv1alpha1.StorageStatus{DataStoreName: "default"},
+		},
+	}
+
+	It("should group the storage, and the network settings", func() {
+		spoke := &kamajiv1alpha2.TenantControlPlane{}
+		Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+
+		Expect(spoke.ObjectMeta).To(Equal(hub.ObjectMeta))
+		Expect(spoke.Spec.Storage).To(Equal(kamajiv1alpha2.StorageSpec{DataStore: "default", Schema: "default_tenant_00", Lifecycle: hub.Spec.DataStoreLifecycle, Dedicated: hub.Spec.DedicatedDataStore}))
+		Expect(spoke.Spec.Network).To(Equal(hub.Spec.NetworkProfile))
+		Expect(spoke.Spec.ControlPlane).To(Equal(hub.Spec.ControlPlane))
+		Expect(spoke.Spec.Addons).To(Equal(hub.Spec.Addons))
+		Expect(spoke.Spec.Bootstrap).To(Equal(hub.Spec.Bootstrap))
+		Expect(spoke.Spec.SecretsBackend).To(Equal(hub.Spec.SecretsBackend))
+		Expect(spoke.Spec.DeletionPolicy).To(Equal(kamajiv1alpha1.DeletionPolicyRetain))
+		Expect(spoke.Spec.TTLSecondsAfterLastUse).To(Equal(ptr.To(int32(3600))))
+		Expect(spoke.Spec.Maintenance).To(Equal(hub.Spec.Maintenance))
+		Expect(spoke.Spec.Compliance).To(Equal(hub.Spec.Compliance))
+		Expect(spoke.Status).To(Equal(hub.Status))
+	})
+
+	It("should not share the maintenance settings with the converted object", func() {
+		source := hub.DeepCopy()
+
+		spoke := &kamajiv1alpha2.TenantControlPlane{}
+		Expect(spoke.ConvertFrom(source)).To(Succeed())
+		Expect(spoke.Spec.Maintenance).ToNot(BeIdenticalTo(source.Spec.Maintenance))
+
+		converted := &kamajiv1alpha1.TenantControlPlane{}
+		Expect(spoke.ConvertTo(converted)).To(Succeed())
+		Expect(converted.Spec.Maintenance).ToNot(BeIdenticalTo(spoke.Spec.Maintenance))
+	})
+
+	It("should round-trip from the hub version", func() {
+		spoke := &kamajiv1alpha2.TenantControlPlane{}
+		Expect(spoke.ConvertFrom(hub.DeepCopy())).To(Succeed())
+
+		converted := &kamajiv1alpha1.TenantControlPlane{}
+		Expect(spoke.ConvertTo(converted)).To(Succeed())
+		Expect(converted).To(Equal(hub))
+	})
+
+	It("should round-trip from the v1alpha2 version", func() {
+		spoke := &kamajiv1alpha2.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-01", Namespace: "default"},
+			Spec: kamajiv1alpha2.TenantControlPlaneSpec{
+				Kubernetes: kamajiv1alpha1.KubernetesSpec{Version: "v1.33.0"},
+				Storage:    kamajiv1alpha2.StorageSpec{DataStore: "etcd"},
+				ControlPlane: kamajiv1alpha1.ControlPlane{
+					Service: kamajiv1alpha1.ServiceSpec{ServiceType: kamajiv1alpha1.ServiceTypeClusterIP},
+					Deployment: kamajiv1alpha1.DeploymentSpec{
+						Tolerations: []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpExists}},
+					},
+				},
+				Network:    kamajiv1alpha1.NetworkProfileSpec{Port: 6443, CertSANs: []string{"tenant-01.clastix.io"}},
+				Compliance: &kamajiv1alpha1.ComplianceSpec{FIPS: true},
+			},
+		}
+
+		hub := &kamajiv1alpha1.TenantControlPlane{}
+		Expect(spoke.ConvertTo(hub)).To(Succeed())
+		Expect(hub.Spec.DataStore).To(Equal("etcd"))
+		Expect(hub.Spec.NetworkProfile.CertSANs).To(ConsistOf("tenant-01.clastix.io"))
+		Expect(hub.IsFIPS()).To(BeTrue())
+
+		converted := &kamajiv1alpha2.TenantControlPlane{}
+		Expect(converted.ConvertFrom(hub)).To(Succeed())
+		Expect(converted).To(Equal(spoke))
+	})
+})
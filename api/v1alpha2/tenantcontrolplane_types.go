@@ -0,0 +1,110 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha2
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// StorageSpec groups the settings of the DataStore backing the Tenant Control Plane.
+type StorageSpec struct {
+	// DataStore specifies the DataStore that should be used to store the Kubernetes data for the given Tenant Control Plane.
+	// When Kamaji runs with the default DataStore flag, all empty values will inherit the default value.
+	//
+	// Migration from one DataStore to another backed by the same Driver is possible. See: https://kamaji.clastix.io/guides/datastore-migration/
+	// Migration from one DataStore to another backed by a different Driver is not supported.
+	DataStore string `json:"dataStore,omitempty"`
+	// Schema allows to specify the name of the database (for relational DataStores) or the key prefix (for etcd):
+	// if not set upon creation, Kamaji will default it by concatenating the namespace and name of the TenantControlPlane.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="changing the schema is not supported"
+	Schema string `json:"schema,omitempty"`
+	// Lifecycle defines the lifecycle of the DataStore contents, such as their retention upon the deletion.
+	Lifecycle *kamajiv1alpha1.DataStoreLifecycleSpec `json:"lifecycle,omitempty"`
+	// Dedicated provisions an etcd cluster in the Tenant Control Plane namespace, backing the given Tenant Control Plane only:
+	// it cannot be set along with a shared DataStore, nor set or unset at runtime.
+	Dedicated *kamajiv1alpha1.DedicatedDataStoreSpec `json:"dedicated,omitempty"`
+}
+
+// TenantControlPlaneSpec defines the desired state of TenantControlPlane:
+// compared to v1alpha1, the settings are grouped by concern, such as the storage, and the network ones.
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.storage) || !has(oldSelf.storage.dataStore) || (has(self.storage) && has(self.storage.dataStore))", message="unsetting the dataStore is not supported"
+// +kubebuilder:validation:XValidation:rule="!has(oldSelf.storage) || !has(oldSelf.storage.schema) || (has(self.storage) && has(self.storage.schema))", message="unsetting the schema is not supported"
+// +kubebuilder:validation:XValidation:rule="(has(oldSelf.storage) && has(oldSelf.storage.dedicated)) == (has(self.storage) && has(self.storage.dedicated))", message="switching between a dedicated, and a shared, DataStore is not supported"
+// +kubebuilder:validation:XValidation:rule="!has(self.storage) || !has(self.storage.dedicated) || !has(self.storage.lifecycle)", message="the DataStore lifecycle is not supported with a dedicated DataStore"
+// +kubebuilder:validation:XValidation:rule="!has(self.network.loadBalancerSourceRanges) || (size(self.network.loadBalancerSourceRanges) == 0 || self.controlPlane.service.serviceType == 'LoadBalancer')", message="LoadBalancer source ranges are supported only with LoadBalancer service type"
+// +kubebuilder:validation:XValidation:rule="!has(self.network.loadBalancerClass) || self.controlPlane.service.serviceType == 'LoadBalancer'", message="LoadBalancerClass is supported only with LoadBalancer service type"
+// +kubebuilder:validation:XValidation:rule="self.controlPlane.service.serviceType != 'LoadBalancer' || (oldSelf.controlPlane.service.serviceType != 'LoadBalancer' && self.controlPlane.service.serviceType == 'LoadBalancer') || has(self.network.loadBalancerClass) == has(oldSelf.network.loadBalancerClass)",message="LoadBalancerClass cannot be set or unset at runtime"
+type TenantControlPlaneSpec struct {
+	// Kubernetes specification for tenant control plane
+	Kubernetes kamajiv1alpha1.KubernetesSpec `json:"kubernetes"`
+	// Storage groups the DataStore settings.
+	Storage StorageSpec `json:"storage,omitempty"`
+	// ImageProfile specifies the cluster-scoped ImageProfile used to override the component images,
+	// such as pointing to mirror registries, or pinning digests, for air-gapped environments.
+	ImageProfile string `json:"imageProfile,omitempty"`
+	// SecretsBackend specifies the external store the generated credentials are written to, such as Vault, or AWS Secrets Manager.
+	SecretsBackend *kamajiv1alpha1.SecretsBackendSpec `json:"secretsBackend,omitempty"`
+	// Naming customizes the names of the generated objects, such as the Deployment, the Service, and the Secrets.
+	Naming *kamajiv1alpha1.NamingSpec `json:"naming,omitempty"`
+	//+kubebuilder:default=Delete
+	// DeletionPolicy defines what happens to the DataStore contents, and to the generated Secrets, upon the Tenant Control Plane deletion.
+	DeletionPolicy kamajiv1alpha1.DeletionPolicy `json:"deletionPolicy,omitempty"`
+	// ControlPlane defines how the Tenant Control Plane components are deployed, and exposed.
+	ControlPlane kamajiv1alpha1.ControlPlane `json:"controlPlane"`
+	// Network specifies the networking of the Tenant Control Plane, and of the Tenant Cluster.
+	Network kamajiv1alpha1.NetworkProfileSpec `json:"network,omitempty"`
+	// Addons contain which addons are enabled
+	Addons kamajiv1alpha1.AddonsSpec `json:"addons,omitempty"`
+	// Bootstrap defines the kubeadm phases performed against the Tenant Cluster.
+	Bootstrap *kamajiv1alpha1.BootstrapSpec `json:"bootstrap,omitempty"`
+	//+kubebuilder:validation:Minimum=60
+	// TTLSecondsAfterReady deletes the Tenant Control Plane once the given seconds have elapsed since it became ready for the first time.
+	TTLSecondsAfterReady *int32 `json:"ttlSecondsAfterReady,omitempty"`
+	//+kubebuilder:validation:Minimum=300
+	// TTLSecondsAfterLastUse deletes the Tenant Control Plane once the given seconds have elapsed since its last use.
+	TTLSecondsAfterLastUse *int32 `json:"ttlSecondsAfterLastUse,omitempty"`
+	// Maintenance notifies the Tenant Cluster workloads before the disruptive operations of the Tenant Control Plane.
+	Maintenance *kamajiv1alpha1.MaintenanceSpec `json:"maintenance,omitempty"`
+	// Compliance defines the regulatory requirements the Tenant Control Plane must comply with, such as FIPS 140.
+	Compliance *kamajiv1alpha1.ComplianceSpec `json:"compliance,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:subresource:scale:specpath=.spec.controlPlane.deployment.replicas,statuspath=.status.kubernetesResources.deployment.replicas,selectorpath=.status.kubernetesResources.deployment.selector
+//+kubebuilder:resource:categories=kamaji,shortName=tcp
+//+kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.kubernetes.version",description="Kubernetes version"
+//+kubebuilder:printcolumn:name="Installed Version",type="string",JSONPath=".status.kubernetesResources.version.version",description="The actual installed Kubernetes version from status"
+//+kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.kubernetesResources.version.status",description="Status"
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Lifecycle phase of the Tenant Control Plane"
+//+kubebuilder:printcolumn:name="Control-Plane endpoint",type="string",JSONPath=".status.controlPlaneEndpoint",description="Tenant Control Plane Endpoint (API server)"
+//+kubebuilder:printcolumn:name="Kubeconfig",type="string",JSONPath=".status.kubeconfig.admin.secretName",description="Secret which contains admin kubeconfig"
+//+kubebuilder:printcolumn:name="Message",type="string",JSONPath=".status.phaseMessage",description="Message describing the current phase",priority=1
+//+kubebuilder:printcolumn:name="Ready Nodes",type="integer",JSONPath=".status.kubernetesResources.nodes.ready",description="Number of the ready worker nodes",priority=1
+//+kubebuilder:printcolumn:name="Datastore",type="string",JSONPath=".status.storage.dataStoreName",description="DataStore actually used"
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+
+// TenantControlPlane is the Schema for the tenantcontrolplanes API.
+type TenantControlPlane struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TenantControlPlaneSpec                  `json:"spec,omitempty"`
+	Status kamajiv1alpha1.TenantControlPlaneStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// TenantControlPlaneList contains a list of TenantControlPlane.
+type TenantControlPlaneList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TenantControlPlane `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&TenantControlPlane{}, &TenantControlPlaneList{})
+}
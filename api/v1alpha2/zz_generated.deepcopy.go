@@ -0,0 +1,152 @@
+//go:build !ignore_autogenerated
+
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Code generated by controller-gen. DO NOT EDIT.
+
+package v1alpha2
+
+import (
+	"github.com/clastix/kamaji/api/v1alpha1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+)
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StorageSpec) DeepCopyInto(out *StorageSpec) {
+	*out = *in
+	if in.Lifecycle != nil {
+		in, out := &in.Lifecycle, &out.Lifecycle
+		*out = new(v1alpha1.DataStoreLifecycleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Dedicated != nil {
+		in, out := &in.Dedicated, &out.Dedicated
+		*out = new(v1alpha1.DedicatedDataStoreSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StorageSpec.
+func (in *StorageSpec) DeepCopy() *StorageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(StorageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlane) DeepCopyInto(out *TenantControlPlane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlane.
+func (in *TenantControlPlane) DeepCopy() *TenantControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneList) DeepCopyInto(out *TenantControlPlaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TenantControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneList.
+func (in *TenantControlPlaneList) DeepCopy() *TenantControlPlaneList {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TenantControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TenantControlPlaneSpec) DeepCopyInto(out *TenantControlPlaneSpec) {
+	*out = *in
+	in.Kubernetes.DeepCopyInto(&out.Kubernetes)
+	in.Storage.DeepCopyInto(&out.Storage)
+	if in.SecretsBackend != nil {
+		in, out := &in.SecretsBackend, &out.SecretsBackend
+		*out = new(v1alpha1.SecretsBackendSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Naming != nil {
+		in, out := &in.Naming, &out.Naming
+		*out = new(v1alpha1.NamingSpec)
+		**out = **in
+	}
+	in.ControlPlane.DeepCopyInto(&out.ControlPlane)
+	in.Network.DeepCopyInto(&out.Network)
+	in.Addons.DeepCopyInto(&out.Addons)
+	if in.Bootstrap != nil {
+		in, out := &in.Bootstrap, &out.Bootstrap
+		*out = new(v1alpha1.BootstrapSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterReady != nil {
+		in, out := &in.TTLSecondsAfterReady, &out.TTLSecondsAfterReady
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSecondsAfterLastUse != nil {
+		in, out := &in.TTLSecondsAfterLastUse, &out.TTLSecondsAfterLastUse
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Maintenance != nil {
+		in, out := &in.Maintenance, &out.Maintenance
+		*out = new(v1alpha1.MaintenanceSpec)
+		**out = **in
+	}
+	if in.Compliance != nil {
+		in, out := &in.Compliance, &out.Compliance
+		*out = new(v1alpha1.ComplianceSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TenantControlPlaneSpec.
+func (in *TenantControlPlaneSpec) DeepCopy() *TenantControlPlaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TenantControlPlaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,353 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	etcdclient "go.etcd.io/etcd/client/v3"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// adoptionEtcdPrefix is the key prefix used by the kubeadm clusters.
+const adoptionEtcdPrefix = "/registry"
+
+// adoptionClusterConfiguration holds the fields of the kubeadm ClusterConfiguration required by the adoption.
+type adoptionClusterConfiguration struct {
+	ControlPlaneEndpoint string `json:"controlPlaneEndpoint"`
+	Networking           struct {
+		DNSDomain     string `json:"dnsDomain"`
+		PodSubnet     string `json:"podSubnet"`
+		ServiceSubnet string `json:"serviceSubnet"`
+	} `json:"networking"`
+	APIServer struct {
+		CertSANs []string `json:"certSANs"`
+	} `json:"apiServer"`
+}
+
+// adoptionSecret is a Secret holding the PKI of the existing cluster, named as the one generated by Kamaji:
+// the valid keys found upon the first reconciliation are adopted by the Tenant Control Plane.
+type adoptionSecret struct {
+	name  string
+	files map[string]string
+}
+
+var adoptionSecrets = []adoptionSecret{
+	{name: "ca", files: map[string]string{kubeadmconstants.CACertName: kubeadmconstants.CACertName, kubeadmconstants.CAKeyName: kubeadmconstants.CAKeyName}},
+	{name: "sa-certificate", files: map[string]string{kubeadmconstants.ServiceAccountPublicKeyName: kubeadmconstants.ServiceAccountPublicKeyName, kubeadmconstants.ServiceAccountPrivateKeyName: kubeadmconstants.ServiceAccountPrivateKeyName}},
+	{name: "front-proxy-ca-certificate", files: map[string]string{kubeadmconstants.FrontProxyCACertName: kubeadmconstants.FrontProxyCACertName, kubeadmconstants.FrontProxyCAKeyName: kubeadmconstants.FrontProxyCAKeyName}},
+}
+
+// newAdoptCmd imports the control plane of an existing kubeadm cluster as a TenantControlPlane: the Certificate Authorities,
+// and the Service Account keys, are stored in the Secrets adopted by Kamaji, keeping the trust with the worker nodes,
+// and the etcd data is copied to the DataStore schema adopted by the TenantControlPlane.
+func newAdoptCmd(opts *options) *cobra.Command {
+	var (
+		sourceKubeconfig string
+		pkiDir           string
+		etcdEndpoints    []string
+		dataStoreName    string
+		serviceType      string
+		address          string
+		verifyOnly       bool
+		timeout          time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "adopt TENANT_CONTROL_PLANE --source-kubeconfig KUBECONFIG --etcd-endpoints ENDPOINTS --datastore DATASTORE",
+		Short: "Adopt the control plane of an existing kubeadm cluster as a TenantControlPlane",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancelFn := context.WithTimeout(cmd.Context(), timeout)
+			defer cancelFn()
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			// Verification phase: no object is created, and no data is copied, until all the checks are passed.
+			pki, err := readAdoptionPKI(pkiDir)
+			if err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(out, "verified: the PKI in %s is valid\n", pkiDir)
+
+			sourceConfig, err := clientcmd.BuildConfigFromFlags("", sourceKubeconfig)
+			if err != nil {
+				return errors.Wrap(err, "cannot load the source kubeconfig")
+			}
+
+			if err = verifyAdoptionCA(sourceConfig.CAData, sourceConfig.CAFile, pki[kubeadmconstants.CACertName]); err != nil {
+				return err
+			}
+
+			sourceClient, err := kubernetes.NewForConfig(sourceConfig)
+			if err != nil {
+				return err
+			}
+
+			version, err := sourceClient.Discovery().ServerVersion()
+			if err != nil {
+				return errors.Wrap(err, "cannot reach the source API Server")
+			}
+
+			_, _ = fmt.Fprintf(out, "verified: the source API Server is running %s\n", version.GitVersion)
+
+			kubeadmConfig, err := sourceClient.CoreV1().ConfigMaps(metav1.NamespaceSystem).Get(ctx, kubeadmconstants.KubeadmConfigConfigMap, metav1.GetOptions{})
+			if err != nil {
+				return errors.Wrap(err, "cannot retrieve the kubeadm configuration of the source cluster")
+			}
+
+			var clusterConfig adoptionClusterConfiguration
+			if err = yaml.Unmarshal([]byte(kubeadmConfig.Data[kubeadmconstants.ClusterConfigurationConfigMapKey]), &clusterConfig); err != nil {
+				return errors.Wrap(err, "cannot decode the kubeadm ClusterConfiguration of the source cluster")
+			}
+
+			sourceEtcd, err := newAdoptionEtcdClient(etcdEndpoints, pki)
+			if err != nil {
+				return err
+			}
+			defer sourceEtcd.Close()
+
+			count, err := sourceEtcd.Get(ctx, adoptionEtcdPrefix+"/", etcdclient.WithPrefix(), etcdclient.WithCountOnly())
+			if err != nil {
+				return errors.Wrap(err, "cannot reach the source etcd cluster")
+			}
+
+			_, _ = fmt.Fprintf(out, "verified: the source etcd cluster stores %d keys\n", count.Count)
+
+			ds := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: dataStoreName}, ds); err != nil {
+				return err
+			}
+
+			if ds.Spec.Driver != kamajiv1alpha1.EtcdDriver {
+				return fmt.Errorf("the DataStore %s uses the %s driver, only the etcd one is supported", ds.GetName(), ds.Spec.Driver)
+			}
+
+			tcp := newAdoptedTenantControlPlane(args[0], opts.namespace, ds.GetName(), version.GitVersion, clusterConfig)
+			tcp.Spec.ControlPlane.Service.ServiceType = kamajiv1alpha1.ServiceType(serviceType)
+			tcp.Spec.NetworkProfile.Address = address
+
+			if err = client.Get(ctx, ctrlclient.ObjectKeyFromObject(tcp), &kamajiv1alpha1.TenantControlPlane{}); err == nil {
+				return fmt.Errorf("the TenantControlPlane %s/%s already exists", tcp.GetNamespace(), tcp.GetName())
+			} else if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			connection, err := datastore.NewStorageConnection(ctx, client, *ds)
+			if err != nil {
+				return err
+			}
+			defer connection.Close()
+
+			target := connection.(*datastore.EtcdClient) //nolint:forcetypeassert
+
+			empty, err := target.IsEmpty(ctx, tcp.Spec.DataStoreSchema)
+			if err != nil {
+				return errors.Wrap(err, "cannot check the target DataStore schema")
+			}
+
+			if !empty {
+				return fmt.Errorf("the schema %s of the DataStore %s is not empty", tcp.Spec.DataStoreSchema, ds.GetName())
+			}
+
+			_, _ = fmt.Fprintf(out, "verified: the schema %s of the DataStore %s is empty\n", tcp.Spec.DataStoreSchema, ds.GetName())
+
+			if verifyOnly {
+				return nil
+			}
+			// Cutover phase: the PKI Secrets are stored prior to the TenantControlPlane,
+			// guaranteeing they're available when the controller starts the reconciliation.
+			for _, secret := range adoptionSecrets {
+				obj := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      utilities.AddTenantPrefix(secret.name, tcp),
+						Namespace: tcp.GetNamespace(),
+					},
+					Data: map[string][]byte{},
+				}
+
+				for key, file := range secret.files {
+					obj.Data[key] = pki[file]
+				}
+
+				if err = client.Create(ctx, obj); err != nil {
+					return errors.Wrap(err, fmt.Sprintf("cannot create the Secret %s/%s", obj.GetNamespace(), obj.GetName()))
+				}
+
+				_, _ = fmt.Fprintf(out, "secret/%s imported\n", obj.GetName())
+			}
+
+			imported, err := target.Import(ctx, sourceEtcd, adoptionEtcdPrefix, tcp.Spec.DataStoreSchema)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("the etcd data import failed after %d keys", imported))
+			}
+
+			_, _ = fmt.Fprintf(out, "%d keys imported to the schema %s\n", imported, tcp.Spec.DataStoreSchema)
+
+			if err = client.Create(ctx, tcp); err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(out, "tenantcontrolplane/%s created\n", tcp.GetName())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&sourceKubeconfig, "source-kubeconfig", "", "Path of the admin kubeconfig of the cluster to adopt")
+	cmd.Flags().StringVar(&pkiDir, "pki-dir", kubeadmconstants.KubernetesDir+"/pki", "Path of the PKI directory of the cluster to adopt, as generated by kubeadm")
+	cmd.Flags().StringSliceVar(&etcdEndpoints, "etcd-endpoints", nil, "Endpoints of the etcd cluster of the cluster to adopt (e.g.: 10.0.0.10:2379)")
+	cmd.Flags().StringVar(&dataStoreName, "datastore", "", "Name of the DataStore the etcd data is copied to, it must use the etcd driver")
+	cmd.Flags().StringVar(&serviceType, "service-type", string(corev1.ServiceTypeLoadBalancer), "Service type exposing the TenantControlPlane")
+	cmd.Flags().StringVar(&address, "address", "", "Address of the TenantControlPlane, such as the existing control plane endpoint, keeping the worker nodes configuration")
+	cmd.Flags().BoolVar(&verifyOnly, "verify-only", false, "Perform the verification phase only, without importing the PKI, and the data")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Amount of time for the adoption to complete")
+
+	_ = cmd.MarkFlagRequired("source-kubeconfig")
+	_ = cmd.MarkFlagRequired("etcd-endpoints")
+	_ = cmd.MarkFlagRequired("datastore")
+
+	return cmd
+}
+
+// newAdoptedTenantControlPlane returns the TenantControlPlane adopting the existing cluster,
+// matching its version, and networking, and serving its certificate SANs.
+func newAdoptedTenantControlPlane(name, namespace, dataStore, version string, config adoptionClusterConfiguration) *kamajiv1alpha1.TenantControlPlane {
+	certSANs := config.APIServer.CertSANs
+
+	if host, _, err := net.SplitHostPort(config.ControlPlaneEndpoint); err == nil {
+		certSANs = append(certSANs, host)
+	} else if len(config.ControlPlaneEndpoint) > 0 {
+		certSANs = append(certSANs, config.ControlPlaneEndpoint)
+	}
+
+	return &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			DataStore:          dataStore,
+			DataStoreSchema:    strings.ReplaceAll(fmt.Sprintf("%s_%s", namespace, name), "-", "_"),
+			DataStoreLifecycle: &kamajiv1alpha1.DataStoreLifecycleSpec{AdoptExisting: true},
+			Kubernetes: kamajiv1alpha1.KubernetesSpec{
+				Version: version,
+			},
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{
+				ClusterDomain: config.Networking.DNSDomain,
+				PodCIDR:       config.Networking.PodSubnet,
+				ServiceCIDR:   config.Networking.ServiceSubnet,
+				CertSANs:      certSANs,
+			},
+		},
+	}
+}
+
+// readAdoptionPKI reads the kubeadm PKI files required by the adoption, checking the validity of the key pairs.
+func readAdoptionPKI(dir string) (map[string][]byte, error) {
+	files := []string{
+		kubeadmconstants.CACertName, kubeadmconstants.CAKeyName,
+		kubeadmconstants.ServiceAccountPublicKeyName, kubeadmconstants.ServiceAccountPrivateKeyName,
+		kubeadmconstants.FrontProxyCACertName, kubeadmconstants.FrontProxyCAKeyName,
+		kubeadmconstants.EtcdCACertName,
+		kubeadmconstants.APIServerEtcdClientCertName, kubeadmconstants.APIServerEtcdClientKeyName,
+	}
+
+	pki := make(map[string][]byte, len(files))
+
+	for _, file := range files {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot read the PKI file %s", file))
+		}
+
+		pki[file] = content
+	}
+
+	for cert, key := range map[string]string{
+		kubeadmconstants.CACertName:                  kubeadmconstants.CAKeyName,
+		kubeadmconstants.FrontProxyCACertName:        kubeadmconstants.FrontProxyCAKeyName,
+		kubeadmconstants.APIServerEtcdClientCertName: kubeadmconstants.APIServerEtcdClientKeyName,
+	} {
+		if valid, err := crypto.CheckCertificateAndPrivateKeyPairValidity(pki[cert], pki[key]); !valid {
+			return nil, fmt.Errorf("the %s certificate, and the %s private key, are not a valid pair: %v", cert, key, err)
+		}
+	}
+
+	if valid, err := crypto.CheckPublicAndPrivateKeyValidity(pki[kubeadmconstants.ServiceAccountPublicKeyName], pki[kubeadmconstants.ServiceAccountPrivateKeyName]); !valid {
+		return nil, fmt.Errorf("the Service Account keys are not a valid pair: %v", err)
+	}
+
+	return pki, nil
+}
+
+// verifyAdoptionCA checks the source kubeconfig trusts the Certificate Authority found in the PKI directory.
+func verifyAdoptionCA(caData []byte, caFile string, ca []byte) error {
+	if len(caData) == 0 && len(caFile) > 0 {
+		content, err := os.ReadFile(caFile)
+		if err != nil {
+			return errors.Wrap(err, "cannot read the Certificate Authority of the source kubeconfig")
+		}
+
+		caData = content
+	}
+
+	if !bytes.Equal(bytes.TrimSpace(caData), bytes.TrimSpace(ca)) {
+		return fmt.Errorf("the Certificate Authority of the source kubeconfig doesn't match the %s one", kubeadmconstants.CACertName)
+	}
+
+	return nil
+}
+
+// newAdoptionEtcdClient returns the client of the source etcd cluster, authenticated with the API Server etcd client certificate.
+func newAdoptionEtcdClient(endpoints []string, pki map[string][]byte) (*etcdclient.Client, error) {
+	certificate, err := tls.X509KeyPair(pki[kubeadmconstants.APIServerEtcdClientCertName], pki[kubeadmconstants.APIServerEtcdClientKeyName])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load the API Server etcd client certificate")
+	}
+
+	rootCAs := x509.NewCertPool()
+	if !rootCAs.AppendCertsFromPEM(pki[kubeadmconstants.EtcdCACertName]) {
+		return nil, fmt.Errorf("cannot load the etcd Certificate Authority")
+	}
+
+	return etcdclient.New(etcdclient.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 10 * time.Second,
+		TLS: &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			RootCAs:      rootCAs,
+			MinVersion:   tls.VersionTLS12,
+		},
+	})
+}
@@ -0,0 +1,209 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// newBackupCmd exports the TenantControlPlane along with the Secret objects it owns, such as the Certificate Authorities,
+// the Service Account keys, and the DataStore credentials: restoring them before the TenantControlPlane lets Kamaji
+// adopt them, keeping the trust with the worker nodes, as long as the DataStore content is preserved.
+func newBackupCmd(opts *options) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "backup TENANT_CONTROL_PLANE",
+		Short: "Export a TenantControlPlane along with its Secret objects",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, tcp); err != nil {
+				return err
+			}
+
+			secretList := &corev1.SecretList{}
+			if err = client.List(cmd.Context(), secretList, ctrlclient.InNamespace(tcp.GetNamespace())); err != nil {
+				return err
+			}
+
+			buf := bytes.NewBuffer(nil)
+
+			for i := range secretList.Items {
+				secret := secretList.Items[i]
+
+				if !isOwnedBy(&secret, tcp) {
+					continue
+				}
+
+				if err = encodeBackupObject(buf, opts.scheme, &secret); err != nil {
+					return err
+				}
+			}
+
+			tcp.Status = kamajiv1alpha1.TenantControlPlaneStatus{}
+
+			if err = encodeBackupObject(buf, opts.scheme, tcp); err != nil {
+				return err
+			}
+
+			if output == "-" {
+				_, err = cmd.OutOrStdout().Write(buf.Bytes())
+
+				return err
+			}
+
+			return os.WriteFile(output, buf.Bytes(), 0o600)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "Path of the backup file, use - for printing it to stdout")
+
+	return cmd
+}
+
+func newRestoreCmd(opts *options) *cobra.Command {
+	var input string
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore a TenantControlPlane along with its Secret objects from a backup file",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			content, err := os.ReadFile(input)
+			if err != nil {
+				return err
+			}
+
+			objects, err := decodeBackupObjects(opts.scheme, content)
+			if err != nil {
+				return err
+			}
+			// Secret objects are stored prior to the TenantControlPlane one,
+			// guaranteeing they're available when the controller starts the reconciliation.
+			for _, obj := range objects {
+				if err = client.Create(cmd.Context(), obj); err != nil {
+					if !apierrors.IsAlreadyExists(err) {
+						return errors.Wrap(err, fmt.Sprintf("cannot restore %s/%s", obj.GetNamespace(), obj.GetName()))
+					}
+
+					_, _ = fmt.Fprintf(cmd.ErrOrStderr(), "%s/%s already exists, skipping\n", obj.GetNamespace(), obj.GetName())
+
+					continue
+				}
+
+				_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s/%s restored\n", obj.GetNamespace(), obj.GetName())
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&input, "file", "f", "", "Path of the backup file")
+
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func isOwnedBy(obj ctrlclient.Object, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == tcp.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func encodeBackupObject(w io.Writer, scheme *runtime.Scheme, obj ctrlclient.Object) error {
+	gvk, err := apiutil.GVKForObject(obj, scheme)
+	if err != nil {
+		return err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	// Server-populated fields must be removed, as well as the owner references
+	// since the TenantControlPlane UID is changing upon restoration.
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+	obj.SetOwnerReferences(nil)
+	obj.SetGeneration(0)
+
+	out, err := utilities.EncodeToYaml(obj)
+	if err != nil {
+		return err
+	}
+
+	if _, err = fmt.Fprintf(w, "---\n%s", out); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func decodeBackupObjects(scheme *runtime.Scheme, content []byte) ([]ctrlclient.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+	var objects []ctrlclient.Object
+
+	for {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot read YAML document")
+		}
+
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		obj, _, err := decoder.Decode(doc, nil, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot decode object")
+		}
+
+		clientObj, ok := obj.(ctrlclient.Object)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object %T", obj)
+		}
+
+		objects = append(objects, clientObj)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,176 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// newCloneCmd creates a TenantControlPlane copying the configuration, and the DataStore contents, of an existing one,
+// such as a staging copy of a production control plane: the PKI, and the endpoints, are regenerated by Kamaji,
+// since no Secret is copied, and the data is copied to the DataStore schema adopted by the clone.
+func newCloneCmd(opts *options) *cobra.Command {
+	var (
+		dataStoreName string
+		address       string
+		hostname      string
+		verifyOnly    bool
+		timeout       time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clone SOURCE_TENANT_CONTROL_PLANE TENANT_CONTROL_PLANE",
+		Short: "Create a TenantControlPlane copying the configuration, and the DataStore contents, of an existing one",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancelFn := context.WithTimeout(cmd.Context(), timeout)
+			defer cancelFn()
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			// Verification phase: no object is created, and no data is copied, until all the checks are passed.
+			source := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(ctx, types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, source); err != nil {
+				return err
+			}
+
+			sourceSchema := source.Status.Storage.Setup.Schema
+			if len(sourceSchema) == 0 {
+				return fmt.Errorf("the TenantControlPlane %s/%s has no DataStore schema yet", source.GetNamespace(), source.GetName())
+			}
+
+			sourceDS := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: source.Status.Storage.DataStoreName}, sourceDS); err != nil {
+				return err
+			}
+
+			if len(dataStoreName) == 0 {
+				if source.Spec.DedicatedDataStore != nil {
+					return fmt.Errorf("the TenantControlPlane %s/%s uses a dedicated DataStore, the target one must be specified", source.GetNamespace(), source.GetName())
+				}
+
+				dataStoreName = sourceDS.GetName()
+			}
+
+			targetDS := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(ctx, types.NamespacedName{Name: dataStoreName}, targetDS); err != nil {
+				return err
+			}
+
+			for _, ds := range []*kamajiv1alpha1.DataStore{sourceDS, targetDS} {
+				if ds.Spec.Driver != kamajiv1alpha1.EtcdDriver {
+					return fmt.Errorf("the DataStore %s uses the %s driver, only the etcd one is supported", ds.GetName(), ds.Spec.Driver)
+				}
+			}
+
+			tcp := newClonedTenantControlPlane(source, args[1], targetDS.GetName())
+			tcp.Spec.NetworkProfile.Address = address
+
+			if tcp.Spec.ControlPlane.Ingress != nil {
+				tcp.Spec.ControlPlane.Ingress.Hostname = hostname
+			}
+
+			if err = client.Get(ctx, ctrlclient.ObjectKeyFromObject(tcp), &kamajiv1alpha1.TenantControlPlane{}); err == nil {
+				return fmt.Errorf("the TenantControlPlane %s/%s already exists", tcp.GetNamespace(), tcp.GetName())
+			} else if !apierrors.IsNotFound(err) {
+				return err
+			}
+
+			sourceConnection, err := datastore.NewStorageConnection(ctx, client, *sourceDS)
+			if err != nil {
+				return err
+			}
+			defer sourceConnection.Close()
+
+			targetConnection, err := datastore.NewStorageConnection(ctx, client, *targetDS)
+			if err != nil {
+				return err
+			}
+			defer targetConnection.Close()
+
+			target := targetConnection.(*datastore.EtcdClient) //nolint:forcetypeassert
+
+			empty, err := target.IsEmpty(ctx, tcp.Spec.DataStoreSchema)
+			if err != nil {
+				return errors.Wrap(err, "cannot check the target DataStore schema")
+			}
+
+			if !empty {
+				return fmt.Errorf("the schema %s of the DataStore %s is not empty", tcp.Spec.DataStoreSchema, targetDS.GetName())
+			}
+
+			_, _ = fmt.Fprintf(out, "verified: the schema %s of the DataStore %s is empty\n", tcp.Spec.DataStoreSchema, targetDS.GetName())
+
+			if verifyOnly {
+				return nil
+			}
+			// Copy phase: the keys are read at a single revision, providing a consistent snapshot of the source schema.
+			copied, err := target.Import(ctx, sourceConnection.(*datastore.EtcdClient).Client, "/"+sourceSchema, tcp.Spec.DataStoreSchema) //nolint:forcetypeassert
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("the etcd data copy failed after %d keys", copied))
+			}
+
+			_, _ = fmt.Fprintf(out, "%d keys copied from the schema %s to the schema %s\n", copied, sourceSchema, tcp.Spec.DataStoreSchema)
+
+			if err = client.Create(ctx, tcp); err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(out, "tenantcontrolplane/%s created\n", tcp.GetName())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&dataStoreName, "datastore", "", "Name of the DataStore the data is copied to, it must use the etcd driver (default: the source one)")
+	cmd.Flags().StringVar(&address, "address", "", "Address of the cloned TenantControlPlane (default: assigned by the Service)")
+	cmd.Flags().StringVar(&hostname, "ingress-hostname", "", "Ingress hostname of the cloned TenantControlPlane, if exposed with an Ingress (default: generated by Kamaji)")
+	cmd.Flags().BoolVar(&verifyOnly, "verify-only", false, "Perform the verification phase only, without copying the data")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Amount of time for the clone to complete")
+
+	return cmd
+}
+
+// newClonedTenantControlPlane returns the TenantControlPlane copying the source specification,
+// adopting the DataStore schema holding the copied data: the source endpoints are not retained.
+func newClonedTenantControlPlane(source *kamajiv1alpha1.TenantControlPlane, name, dataStore string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   source.GetNamespace(),
+			Labels:      source.GetLabels(),
+			Annotations: map[string]string{kamajiv1alpha1.ClonedFromAnnotation: ctrlclient.ObjectKeyFromObject(source).String()},
+		},
+		Spec: *source.Spec.DeepCopy(),
+	}
+
+	tcp.Spec.DataStore = dataStore
+	tcp.Spec.DataStoreSchema = strings.ReplaceAll(fmt.Sprintf("%s_%s", tcp.GetNamespace(), tcp.GetName()), "-", "_")
+
+	if tcp.Spec.DataStoreLifecycle == nil {
+		tcp.Spec.DataStoreLifecycle = &kamajiv1alpha1.DataStoreLifecycleSpec{}
+	}
+
+	tcp.Spec.DataStoreLifecycle.AdoptExisting = true
+	tcp.Spec.DedicatedDataStore = nil
+
+	return tcp
+}
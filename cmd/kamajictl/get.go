@@ -0,0 +1,76 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func newGetCmd(opts *options) *cobra.Command {
+	var allNamespaces bool
+
+	cmd := &cobra.Command{
+		Use:     "get tcps",
+		Short:   "List the TenantControlPlane objects",
+		Aliases: []string{"list"},
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && args[0] != "tcps" && args[0] != "tcp" && args[0] != "tenantcontrolplanes" {
+				return fmt.Errorf("unsupported resource %s, only tcps can be listed", args[0])
+			}
+
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			var listOpts []ctrlclient.ListOption
+			if !allNamespaces {
+				listOpts = append(listOpts, ctrlclient.InNamespace(opts.namespace))
+			}
+
+			tcpList := &kamajiv1alpha1.TenantControlPlaneList{}
+			if err = client.List(cmd.Context(), tcpList, listOpts...); err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 3, ' ', 0)
+			defer w.Flush()
+
+			_, _ = fmt.Fprintln(w, "NAMESPACE\tNAME\tVERSION\tSTATUS\tPHASE\tENDPOINT\tDATASTORE\tAGE")
+
+			for _, tcp := range tcpList.Items {
+				var status string
+				if tcp.Status.Kubernetes.Version.Status != nil {
+					status = string(*tcp.Status.Kubernetes.Version.Status)
+				}
+
+				_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+					tcp.GetNamespace(),
+					tcp.GetName(),
+					tcp.Spec.Kubernetes.Version,
+					status,
+					tcp.Status.Phase,
+					tcp.Status.ControlPlaneEndpoint,
+					tcp.Status.Storage.DataStoreName,
+					duration.HumanDuration(time.Since(tcp.GetCreationTimestamp().Time)),
+				)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&allNamespaces, "all-namespaces", "A", false, "List the TenantControlPlane objects across all namespaces")
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+func newKubeconfigCmd(opts *options) *cobra.Command {
+	var key string
+
+	cmd := &cobra.Command{
+		Use:   "kubeconfig TENANT_CONTROL_PLANE",
+		Short: "Print the admin kubeconfig of a TenantControlPlane",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, tcp); err != nil {
+				return err
+			}
+
+			secretName := tcp.Status.KubeConfig.Admin.SecretName
+			if len(secretName) == 0 {
+				return fmt.Errorf("the admin kubeconfig of the TenantControlPlane %s has not been generated yet", tcp.GetName())
+			}
+
+			secret := &corev1.Secret{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: secretName}, secret); err != nil {
+				return err
+			}
+
+			kubeconfig, ok := secret.Data[key]
+			if !ok {
+				return fmt.Errorf("the key %s is missing from the Secret %s", key, secretName)
+			}
+
+			_, err = cmd.OutOrStdout().Write(kubeconfig)
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&key, "key", resources.AdminKubeConfigFileName, "Secret key containing the kubeconfig: use admin.svc for the in-cluster Service endpoint, or super-admin.conf for the super-admin credentials")
+
+	return cmd
+}
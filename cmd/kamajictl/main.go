@@ -0,0 +1,79 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"flag"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// options are shared across all the kamajictl commands.
+type options struct {
+	scheme    *runtime.Scheme
+	namespace string
+	// newClient overrides the management cluster client built from the kubeconfig, such as in tests.
+	newClient func() (ctrlclient.Client, error)
+}
+
+func (o *options) client() (ctrlclient.Client, error) {
+	if o.newClient != nil {
+		return o.newClient()
+	}
+
+	config, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return ctrlclient.New(config, ctrlclient.Options{Scheme: o.scheme})
+}
+
+func newOptions() *options {
+	opts := &options{scheme: runtime.NewScheme()}
+
+	utilruntime.Must(clientgoscheme.AddToScheme(opts.scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(opts.scheme))
+
+	return opts
+}
+
+func newRootCmd(opts *options) *cobra.Command {
+	root := &cobra.Command{
+		Use:          "kamajictl",
+		Short:        "Operate the lifecycle of Kamaji Tenant Control Planes.",
+		SilenceUsage: true,
+	}
+	root.PersistentFlags().StringVarP(&opts.namespace, "namespace", "n", "default", "Namespace of the TenantControlPlane objects")
+	// Exposing the kubeconfig flag registered by controller-runtime
+	root.PersistentFlags().AddGoFlagSet(flag.CommandLine)
+
+	root.AddCommand(
+		newGetCmd(opts),
+		newKubeconfigCmd(opts),
+		newMigrateCmd(opts),
+		newBackupCmd(opts),
+		newRestoreCmd(opts),
+		newAdoptCmd(opts),
+		newCloneCmd(opts),
+		newPKICmd(opts),
+		newSupportBundleCmd(opts),
+	)
+
+	return root
+}
+
+func main() {
+	if err := newRootCmd(newOptions()).Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,267 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+type result struct {
+	stdout string
+	stderr string
+	err    error
+}
+
+// execute runs the kamajictl command with the given arguments against the provided client.
+func execute(t *testing.T, client ctrlclient.Client, args ...string) result {
+	t.Helper()
+
+	opts := newOptions()
+	opts.newClient = func() (ctrlclient.Client, error) {
+		return client, nil
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := newRootCmd(opts)
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+	cmd.SetArgs(args)
+
+	err := cmd.ExecuteContext(context.Background())
+
+	return result{stdout: stdout.String(), stderr: stderr.String(), err: err}
+}
+
+func newFakeClient(objects ...ctrlclient.Object) ctrlclient.Client {
+	return fake.NewClientBuilder().WithScheme(newOptions().scheme).WithObjects(objects...).Build()
+}
+
+func newTenantControlPlane(namespace, name string) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			UID:       types.UID(namespace + "-" + name),
+		},
+	}
+	tcp.Spec.Kubernetes.Version = "v1.33.0"
+	tcp.Spec.DataStore = "etcd-bronze"
+	tcp.Status.Storage.DataStoreName = "etcd-bronze"
+	tcp.Status.Storage.Driver = string(kamajiv1alpha1.EtcdDriver)
+
+	return tcp
+}
+
+func newDataStore(name string, driver kamajiv1alpha1.Driver) *kamajiv1alpha1.DataStore {
+	return &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       kamajiv1alpha1.DataStoreSpec{Driver: driver},
+	}
+}
+
+func TestGetCmd(t *testing.T) {
+	client := newFakeClient(newTenantControlPlane("default", "tenant-00"), newTenantControlPlane("tenants", "tenant-01"))
+
+	res := execute(t, client, "get", "tcps")
+	if res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	if !strings.HasPrefix(res.stdout, "NAMESPACE") || !strings.Contains(res.stdout, "tenant-00") || strings.Contains(res.stdout, "tenant-01") {
+		t.Errorf("expected the TenantControlPlane objects of the default namespace only, got:\n%s", res.stdout)
+	}
+
+	if res = execute(t, client, "get", "tcps", "--all-namespaces"); res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	if !strings.Contains(res.stdout, "tenant-00") || !strings.Contains(res.stdout, "tenant-01") {
+		t.Errorf("expected the TenantControlPlane objects of all the namespaces, got:\n%s", res.stdout)
+	}
+
+	if res = execute(t, client, "get", "pods"); res.err == nil || !strings.Contains(res.err.Error(), "unsupported resource pods") {
+		t.Errorf("expected an unsupported resource error, got %v", res.err)
+	}
+}
+
+func TestKubeconfigCmd(t *testing.T) {
+	tcp := newTenantControlPlane("tenants", "tenant-00")
+	tcp.Status.KubeConfig.Admin.SecretName = "tenant-00-admin-kubeconfig"
+
+	pending := newTenantControlPlane("tenants", "tenant-01")
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00-admin-kubeconfig"},
+		Data: map[string][]byte{
+			"admin.conf": []byte("admin"),
+			"admin.svc":  []byte("service"),
+		},
+	}
+
+	client := newFakeClient(tcp, pending, secret)
+
+	testCases := []struct {
+		name     string
+		args     []string
+		expected string
+		err      string
+	}{
+		{
+			name:     "default key",
+			args:     []string{"kubeconfig", "tenant-00", "-n", "tenants"},
+			expected: "admin",
+		},
+		{
+			name:     "custom key",
+			args:     []string{"kubeconfig", "tenant-00", "-n", "tenants", "--key", "admin.svc"},
+			expected: "service",
+		},
+		{
+			name: "missing key",
+			args: []string{"kubeconfig", "tenant-00", "-n", "tenants", "--key", "super-admin.conf"},
+			err:  "the key super-admin.conf is missing",
+		},
+		{
+			name: "kubeconfig not generated",
+			args: []string{"kubeconfig", "tenant-01", "-n", "tenants"},
+			err:  "has not been generated yet",
+		},
+		{
+			name: "missing argument",
+			args: []string{"kubeconfig"},
+			err:  "accepts 1 arg(s)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			res := execute(t, client, tc.args...)
+			if len(tc.err) > 0 {
+				if res.err == nil || !strings.Contains(res.err.Error(), tc.err) {
+					t.Fatalf("expected the error %q, got %v", tc.err, res.err)
+				}
+
+				return
+			}
+
+			if res.err != nil {
+				t.Fatalf("unexpected error: %s", res.err)
+			}
+
+			if res.stdout != tc.expected {
+				t.Errorf("expected the kubeconfig %q, got %q", tc.expected, res.stdout)
+			}
+		})
+	}
+}
+
+func TestMigrateCmd(t *testing.T) {
+	client := newFakeClient(
+		newTenantControlPlane("default", "tenant-00"),
+		newDataStore("etcd-bronze", kamajiv1alpha1.EtcdDriver),
+		newDataStore("etcd-gold", kamajiv1alpha1.EtcdDriver),
+		newDataStore("postgresql", kamajiv1alpha1.KinePostgreSQLDriver),
+	)
+
+	if res := execute(t, client, "migrate", "tenant-00"); res.err == nil || !strings.Contains(res.err.Error(), `required flag(s) "to" not set`) {
+		t.Errorf("expected a required flag error, got %v", res.err)
+	}
+
+	if res := execute(t, client, "migrate", "tenant-00", "--to", "etcd-bronze"); res.err == nil || !strings.Contains(res.err.Error(), "is already using the DataStore etcd-bronze") {
+		t.Errorf("expected an error for the current DataStore, got %v", res.err)
+	}
+
+	if res := execute(t, client, "migrate", "tenant-00", "--to", "postgresql"); res.err == nil || !strings.Contains(res.err.Error(), "different driver is not supported") {
+		t.Errorf("expected an error for a different driver, got %v", res.err)
+	}
+
+	res := execute(t, client, "migrate", "tenant-00", "--to", "etcd-gold")
+	if res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	if expected := "tenantcontrolplane/tenant-00 migration to etcd-gold requested\n"; res.stdout != expected {
+		t.Errorf("expected the output %q, got %q", expected, res.stdout)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	if err := client.Get(context.Background(), types.NamespacedName{Namespace: "default", Name: "tenant-00"}, tcp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if tcp.Spec.DataStore != "etcd-gold" {
+		t.Errorf("expected the TenantControlPlane to reference the DataStore etcd-gold, got %s", tcp.Spec.DataStore)
+	}
+}
+
+func TestBackupRestoreCmd(t *testing.T) {
+	tcp := newTenantControlPlane("tenants", "tenant-00")
+
+	owned := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:       "tenants",
+			Name:            "tenant-00-ca",
+			OwnerReferences: []metav1.OwnerReference{{APIVersion: kamajiv1alpha1.GroupVersion.String(), Kind: "TenantControlPlane", Name: tcp.GetName(), UID: tcp.GetUID()}},
+		},
+		Data: map[string][]byte{"ca.crt": []byte("certificate")},
+	}
+	unrelated := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "unrelated"}}
+
+	backup := filepath.Join(t.TempDir(), "backup.yaml")
+
+	res := execute(t, newFakeClient(tcp, owned, unrelated), "backup", "tenant-00", "-n", "tenants", "-o", backup)
+	if res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	content, err := os.ReadFile(backup)
+	if err != nil {
+		t.Fatalf("cannot read the backup file: %s", err)
+	}
+
+	objects, err := decodeBackupObjects(newOptions().scheme, content)
+	if err != nil {
+		t.Fatalf("cannot decode the backup file: %s", err)
+	}
+
+	if len(objects) != 2 || objects[0].GetName() != "tenant-00-ca" || objects[1].GetName() != "tenant-00" {
+		t.Fatalf("expected the owned Secret to be exported before the TenantControlPlane, got %d objects", len(objects))
+	}
+
+	if len(objects[0].GetOwnerReferences()) > 0 || len(objects[1].GetUID()) > 0 {
+		t.Error("expected the owner references and the UID to be removed")
+	}
+
+	client := newFakeClient()
+
+	if res = execute(t, client, "restore", "-f", backup); res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	if expected := "tenants/tenant-00-ca restored\ntenants/tenant-00 restored\n"; res.stdout != expected {
+		t.Errorf("expected the output %q, got %q", expected, res.stdout)
+	}
+
+	if res = execute(t, client, "restore", "-f", backup); res.err != nil {
+		t.Fatalf("unexpected error: %s", res.err)
+	}
+
+	if !strings.Contains(res.stderr, "tenants/tenant-00 already exists, skipping") {
+		t.Errorf("expected the existing objects to be skipped, got %q", res.stderr)
+	}
+}
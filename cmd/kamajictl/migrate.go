@@ -0,0 +1,97 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func newMigrateCmd(opts *options) *cobra.Command {
+	var (
+		targetDataStore string
+		waitForMigrate  bool
+		timeout         time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate TENANT_CONTROL_PLANE --to DATASTORE",
+		Short: "Migrate the data of a TenantControlPlane to another DataStore",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, tcp); err != nil {
+				return err
+			}
+
+			ds := &kamajiv1alpha1.DataStore{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Name: targetDataStore}, ds); err != nil {
+				return err
+			}
+
+			if tcp.Status.Storage.DataStoreName == ds.GetName() {
+				return fmt.Errorf("the TenantControlPlane %s is already using the DataStore %s", tcp.GetName(), ds.GetName())
+			}
+
+			if tcp.Status.Storage.Driver != string(ds.Spec.Driver) {
+				return fmt.Errorf("migration between DataStore with different driver is not supported")
+			}
+			// The migration is orchestrated by the controller upon the DataStore change:
+			// the tenant API Server is frozen, and a Job copies the data to the target DataStore.
+			patch := ctrlclient.MergeFrom(tcp.DeepCopy())
+			tcp.Spec.DataStore = ds.GetName()
+
+			if err = client.Patch(cmd.Context(), tcp, patch); err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "tenantcontrolplane/%s migration to %s requested\n", tcp.GetName(), ds.GetName())
+
+			if !waitForMigrate {
+				return nil
+			}
+
+			ctx, cancelFn := context.WithTimeout(cmd.Context(), timeout)
+			defer cancelFn()
+
+			err = wait.PollUntilContextCancel(ctx, 5*time.Second, false, func(ctx context.Context) (bool, error) {
+				if err := client.Get(ctx, ctrlclient.ObjectKeyFromObject(tcp), tcp); err != nil {
+					return false, err
+				}
+
+				ready := tcp.Status.Kubernetes.Version.Status != nil && *tcp.Status.Kubernetes.Version.Status == kamajiv1alpha1.VersionReady
+
+				return tcp.Status.Storage.DataStoreName == ds.GetName() && ready, nil
+			})
+			if err != nil {
+				return fmt.Errorf("the TenantControlPlane migration didn't complete: %w", err)
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "tenantcontrolplane/%s migrated to %s\n", tcp.GetName(), ds.GetName())
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&targetDataStore, "to", "", "Name of the DataStore to which the TenantControlPlane will be migrated")
+	cmd.Flags().BoolVar(&waitForMigrate, "wait", false, "Wait for the migration to be completed")
+	cmd.Flags().DurationVar(&timeout, "timeout", 10*time.Minute, "Amount of time to wait for the migration completion")
+
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
@@ -0,0 +1,188 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// pkiBundleEntry maps a Secret key to the path of the file in the kubeadm layout, relative to the /etc/kubernetes directory.
+type pkiBundleEntry struct {
+	secretName string
+	key        string
+	path       string
+	// optional entries are skipped when missing, such as the DataStore certificates when TLS is not used.
+	optional bool
+}
+
+func pkiBundleEntries(tcp *kamajiv1alpha1.TenantControlPlane) []pkiBundleEntry {
+	certs, kubeconfigs := tcp.Status.Certificates, tcp.Status.KubeConfig
+
+	entries := []pkiBundleEntry{
+		{secretName: certs.CA.SecretName, key: kubeadmconstants.CACertName},
+		{secretName: certs.CA.SecretName, key: kubeadmconstants.CAKeyName},
+		{secretName: certs.APIServer.SecretName, key: kubeadmconstants.APIServerCertName},
+		{secretName: certs.APIServer.SecretName, key: kubeadmconstants.APIServerKeyName},
+		{secretName: certs.APIServerKubeletClient.SecretName, key: kubeadmconstants.APIServerKubeletClientCertName},
+		{secretName: certs.APIServerKubeletClient.SecretName, key: kubeadmconstants.APIServerKubeletClientKeyName},
+		{secretName: certs.FrontProxyCA.SecretName, key: kubeadmconstants.FrontProxyCACertName},
+		{secretName: certs.FrontProxyCA.SecretName, key: kubeadmconstants.FrontProxyCAKeyName},
+		{secretName: certs.FrontProxyClient.SecretName, key: kubeadmconstants.FrontProxyClientCertName},
+		{secretName: certs.FrontProxyClient.SecretName, key: kubeadmconstants.FrontProxyClientKeyName},
+		{secretName: certs.SA.SecretName, key: kubeadmconstants.ServiceAccountPublicKeyName},
+		{secretName: certs.SA.SecretName, key: kubeadmconstants.ServiceAccountPrivateKeyName},
+		{secretName: tcp.Status.Storage.Certificate.SecretName, key: "ca.crt", path: kubeadmconstants.EtcdCACertName, optional: true},
+		{secretName: tcp.Status.Storage.Certificate.SecretName, key: "server.crt", path: kubeadmconstants.APIServerEtcdClientCertName, optional: true},
+		{secretName: tcp.Status.Storage.Certificate.SecretName, key: "server.key", path: kubeadmconstants.APIServerEtcdClientKeyName, optional: true},
+	}
+	// The certificates, and the keys, are stored in the pki directory.
+	for i := range entries {
+		if len(entries[i].path) == 0 {
+			entries[i].path = entries[i].key
+		}
+
+		entries[i].path = path.Join("pki", entries[i].path)
+	}
+
+	return append(entries,
+		pkiBundleEntry{secretName: kubeconfigs.Admin.SecretName, key: kubeadmconstants.AdminKubeConfigFileName, path: kubeadmconstants.AdminKubeConfigFileName},
+		pkiBundleEntry{secretName: kubeconfigs.Admin.SecretName, key: kubeadmconstants.SuperAdminKubeConfigFileName, path: kubeadmconstants.SuperAdminKubeConfigFileName, optional: true},
+		pkiBundleEntry{secretName: kubeconfigs.ControllerManager.SecretName, key: kubeadmconstants.ControllerManagerKubeConfigFileName, path: kubeadmconstants.ControllerManagerKubeConfigFileName},
+		pkiBundleEntry{secretName: kubeconfigs.Scheduler.SecretName, key: kubeadmconstants.SchedulerKubeConfigFileName, path: kubeadmconstants.SchedulerKubeConfigFileName},
+	)
+}
+
+// newPKICmd exports the certificates, the keys, and the kubeconfigs of a TenantControlPlane as a tar.gz archive
+// using the kubeadm /etc/kubernetes layout, for the disaster recovery runbooks, and the tools expecting the PKI on disk.
+func newPKICmd(opts *options) *cobra.Command {
+	var (
+		output string
+		prefix string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "pki TENANT_CONTROL_PLANE",
+		Short: "Export the PKI, and the kubeconfigs, of a TenantControlPlane as a tar.gz archive with the kubeadm layout",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, tcp); err != nil {
+				return err
+			}
+
+			secrets := map[string]*corev1.Secret{}
+			files := map[string][]byte{}
+
+			var paths []string
+
+			for _, entry := range pkiBundleEntries(tcp) {
+				if len(entry.secretName) == 0 {
+					if entry.optional {
+						continue
+					}
+
+					return fmt.Errorf("the TenantControlPlane %s is not reporting the Secret holding %s, it may be still provisioning", tcp.GetName(), entry.key)
+				}
+
+				secret, ok := secrets[entry.secretName]
+				if !ok {
+					secret = &corev1.Secret{}
+					if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: entry.secretName}, secret); err != nil {
+						return errors.Wrap(err, fmt.Sprintf("cannot retrieve the Secret %s", entry.secretName))
+					}
+
+					secrets[entry.secretName] = secret
+				}
+
+				content, ok := secret.Data[entry.key]
+				if !ok {
+					if entry.optional {
+						continue
+					}
+
+					return fmt.Errorf("the Secret %s is missing the %s key", entry.secretName, entry.key)
+				}
+
+				files[entry.path] = content
+				paths = append(paths, entry.path)
+			}
+
+			w := cmd.OutOrStdout()
+
+			if output != "-" {
+				f, fErr := os.OpenFile(output, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+				if fErr != nil {
+					return fErr
+				}
+				defer f.Close()
+
+				w = f
+			}
+
+			return writePKIBundle(w, prefix, paths, files)
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "-", "Path of the tar.gz archive, use - for printing it to stdout")
+	cmd.Flags().StringVar(&prefix, "prefix", strings.TrimPrefix(kubeadmconstants.KubernetesDir, "/"), "Directory of the archive the files are stored in")
+
+	return cmd
+}
+
+// writePKIBundle writes the given files as a tar.gz archive, in the given order:
+// the private keys, and the kubeconfigs, are readable by the owner only.
+func writePKIBundle(w io.Writer, prefix string, paths []string, files map[string][]byte) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+
+	now := time.Now()
+
+	for _, name := range paths {
+		mode := int64(0o600)
+		if strings.HasSuffix(name, ".crt") || strings.HasSuffix(name, ".pub") {
+			mode = 0o644
+		}
+
+		header := &tar.Header{
+			Name:    path.Join(prefix, name),
+			Mode:    mode,
+			Size:    int64(len(files[name])),
+			ModTime: now,
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if _, err := tw.Write(files[name]); err != nil {
+			return err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+
+	return gw.Close()
+}
@@ -0,0 +1,89 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/supportbundle"
+)
+
+// newSupportBundleCmd collects the diagnostics of a TenantControlPlane, such as its conditions, events, manifests,
+// and the Control Plane and Kamaji logs, into an archive attached to the bug reports: the sensitive data is redacted.
+func newSupportBundleCmd(opts *options) *cobra.Command {
+	var (
+		output          string
+		kamajiNamespace string
+		kamajiSelector  string
+		logLines        int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "support-bundle TENANT_CONTROL_PLANE",
+		Short: "Collect the redacted diagnostics of a TenantControlPlane into an archive for bug reports",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := opts.client()
+			if err != nil {
+				return err
+			}
+
+			config, err := ctrl.GetConfig()
+			if err != nil {
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(config)
+			if err != nil {
+				return err
+			}
+
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			if err = client.Get(cmd.Context(), types.NamespacedName{Namespace: opts.namespace, Name: args[0]}, tcp); err != nil {
+				return err
+			}
+
+			if len(output) == 0 {
+				output = fmt.Sprintf("%s-%s-support-bundle.tar.gz", tcp.GetNamespace(), tcp.GetName())
+			}
+
+			file, err := os.OpenFile(output, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			collector := &supportbundle.Collector{
+				Reader:          client,
+				Scheme:          opts.scheme,
+				Clientset:       clientset,
+				KamajiNamespace: kamajiNamespace,
+				KamajiSelector:  kamajiSelector,
+				LogLines:        logLines,
+			}
+
+			if err = collector.Collect(cmd.Context(), tcp, file); err != nil {
+				return err
+			}
+
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "support bundle written to %s\n", output)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Path of the archive, defaulting to <namespace>-<name>-support-bundle.tar.gz")
+	cmd.Flags().StringVar(&kamajiNamespace, "kamaji-namespace", "kamaji-system", "Namespace of the Kamaji pods, use an empty value to skip their logs")
+	cmd.Flags().StringVar(&kamajiSelector, "kamaji-selector", supportbundle.DefaultKamajiSelector, "Label selector of the Kamaji pods")
+	cmd.Flags().Int64Var(&logLines, "log-lines", supportbundle.DefaultLogLines, "Number of the most recent log lines collected per container")
+
+	return cmd
+}
@@ -9,17 +9,23 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"path/filepath"
 	goRuntime "runtime"
 	"time"
 
 	telemetryclient "github.com/clastix/kamaji-telemetry/pkg/client"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
@@ -27,12 +33,21 @@ import (
 	ctrlwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/cmd/render"
 	cmdutils "github.com/clastix/kamaji/cmd/utils"
 	"github.com/clastix/kamaji/controllers"
 	"github.com/clastix/kamaji/controllers/soot"
+	controllerutils "github.com/clastix/kamaji/controllers/utils"
 	"github.com/clastix/kamaji/internal"
+	"github.com/clastix/kamaji/internal/audit"
 	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/console"
 	datastoreutils "github.com/clastix/kamaji/internal/datastore/utils"
+	"github.com/clastix/kamaji/internal/faultinjection"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/notifications"
+	"github.com/clastix/kamaji/internal/supportbundle"
+	"github.com/clastix/kamaji/internal/utilities"
 	"github.com/clastix/kamaji/internal/webhook"
 	"github.com/clastix/kamaji/internal/webhook/handlers"
 	"github.com/clastix/kamaji/internal/webhook/routes"
@@ -44,6 +59,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	var (
 		metricsBindAddress            string
 		healthProbeBindAddress        string
+		pprofBindAddress              string
 		leaderElect                   bool
 		tmpDirectory                  string
 		kineImage                     string
@@ -55,13 +71,48 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		managerServiceName            string
 		webhookCABundle               []byte
 		migrateJobImage               string
+		migrateWebhookFailurePolicy   string
+		migrateWatchdogLeaseDuration  time.Duration
 		maxConcurrentReconciles       int
+		usePriorityQueue              bool
+		resourcesConcurrency          int
+		requeueInitialDelay           time.Duration
+		requeueMaxDelay               time.Duration
+		requeueJitter                 float64
 		disableTelemetry              bool
 		certificateExpirationDeadline time.Duration
+		sootLeastPrivilege            bool
+		stagedUpgrades                bool
+		confirmUpgrades               bool
+		revisionHistoryLimit          int
+		watchNamespaces               []string
+		instanceSelector              string
+		shard                         string
+		shardLeaseDuration            time.Duration
+		orphansCollectorInterval      time.Duration
+		orphansCollectorDryRun        bool
+		scope                         cmdutils.Scope
+		tenantLogsDirectory           string
+		tenantLogsWebhookURL          string
+		admissionPolicies             bool
+		managementAPIQPS              float32
+		managementAPIBurst            int
+		tenantAPIQPS                  float32
+		tenantAPIBurst                int
+		consoleAPIBindAddress         string
+		consoleAPICertDir             string
+		auditEvents                   bool
+		auditWebhookURL               string
+		faultInjection                string
+		driftDetectionInterval        time.Duration
 
 		webhookCAPath string
 	)
 
+	opts := zap.Options{
+		Development: true,
+	}
+
 	ctx := ctrl.SetupSignalHandler()
 
 	cmd := &cobra.Command{
@@ -73,6 +124,26 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			// Avoid to pollute Kamaji stdout with useless details by the underlying klog implementations
 			klog.SetOutput(io.Discard)
 			klog.LogToStderr(false)
+			// The logger is set once the flags have been parsed, teeing the tenant entries to the requested sinks.
+			var tenantLogsLevel zapcore.LevelEnabler = zapcore.InfoLevel
+			if opts.Level != nil {
+				tenantLogsLevel = opts.Level
+			}
+
+			if tenantLogsDirectory != "" {
+				fileSink, sinkErr := logging.NewFileSink(tenantLogsDirectory)
+				if sinkErr != nil {
+					return sinkErr
+				}
+
+				opts.ZapOpts = append(opts.ZapOpts, logging.WithTenantSink(tenantLogsLevel, fileSink))
+			}
+
+			if tenantLogsWebhookURL != "" {
+				opts.ZapOpts = append(opts.ZapOpts, logging.WithTenantSink(tenantLogsLevel, logging.NewWebhookSink(ctx, tenantLogsWebhookURL, 1024)))
+			}
+
+			ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 			if err = cmdutils.CheckFlags(cmd.Flags(), []string{"kine-image", "migrate-image", "tmp-directory", "pod-namespace", "webhook-service-name", "serviceaccount-name", "webhook-ca-path"}...); err != nil {
 				return err
@@ -94,6 +165,42 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return fmt.Errorf("the controller reconcile timeout must be greater than zero")
 			}
 
+			if requeueInitialDelay <= 0 || requeueMaxDelay < requeueInitialDelay {
+				return fmt.Errorf("the requeue initial delay must be greater than zero, and lower than the maximum one")
+			}
+
+			if requeueJitter < 0 {
+				return fmt.Errorf("the requeue jitter cannot be negative")
+			}
+
+			if shardLeaseDuration < 3*time.Second {
+				return fmt.Errorf("the shard lease duration must be at least 3 seconds")
+			}
+
+			if orphansCollectorInterval < 0 {
+				return fmt.Errorf("the orphans collector interval cannot be negative")
+			}
+
+			if revisionHistoryLimit < 0 {
+				return fmt.Errorf("the revision history limit cannot be negative")
+			}
+
+			if policy := admissionregistrationv1.FailurePolicyType(migrateWebhookFailurePolicy); policy != admissionregistrationv1.Fail && policy != admissionregistrationv1.Ignore {
+				return fmt.Errorf("the migrate webhook failure policy must be either %s, or %s", admissionregistrationv1.Fail, admissionregistrationv1.Ignore)
+			}
+
+			if migrateWatchdogLeaseDuration != 0 && migrateWatchdogLeaseDuration < 15*time.Second {
+				return fmt.Errorf("the migrate watchdog lease duration must be at least 15 seconds")
+			}
+
+			if tenantAPIQPS <= 0 || tenantAPIBurst <= 0 {
+				return fmt.Errorf("the Tenant Cluster clients QPS, and burst, must be positive")
+			}
+
+			if scope, err = cmdutils.NewScope(watchNamespaces, instanceSelector, shard, managerNamespace); err != nil {
+				return err
+			}
+
 			return nil
 		},
 		RunE: func(*cobra.Command, []string) error {
@@ -106,6 +213,10 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			setupLog.Info(fmt.Sprintf("Go OS/Arch: %s/%s", goRuntime.GOOS, goRuntime.GOARCH))
 			setupLog.Info(fmt.Sprintf("Telemetry enabled: %t", !disableTelemetry))
 
+			if !scope.IsEmpty() {
+				setupLog.Info("Scoped instance", "namespaces", scope.Namespaces, "selector", instanceSelector, "shard", shard)
+			}
+
 			telemetryClient := telemetryclient.New(http.Client{Timeout: 5 * time.Second}, "https://telemetry.clastix.io")
 			if disableTelemetry {
 				telemetryClient = telemetryclient.NewNewOp()
@@ -120,17 +231,27 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					Port: 9443,
 				}),
 				HealthProbeBindAddress:  healthProbeBindAddress,
+				PprofBindAddress:        pprofBindAddress,
 				LeaderElection:          leaderElect,
 				LeaderElectionNamespace: managerNamespace,
-				LeaderElectionID:        "kamaji.clastix.io",
+				LeaderElectionID:        scope.LeaderElectionID("kamaji.clastix.io"),
 				NewCache: func(config *rest.Config, opts cache.Options) (cache.Cache, error) {
 					opts.SyncPeriod = &cacheResyncPeriod
+					scope.CacheOptions(&opts)
 
 					return cache.New(config, opts)
 				},
 			}
 
-			mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrlOpts)
+			restConfig := ctrl.GetConfigOrDie()
+			// The client-side rate limiting of the management cluster client is disabled by default, relying on the API Priority and Fairness.
+			if managementAPIQPS != 0 {
+				restConfig.QPS, restConfig.Burst = managementAPIQPS, managementAPIBurst
+			}
+
+			utilities.SetTenantClientRateLimits(tenantAPIQPS, tenantAPIBurst)
+
+			mgr, err := ctrl.NewManager(restConfig, ctrlOpts)
 			if err != nil {
 				setupLog.Error(err, "unable to start manager")
 
@@ -139,28 +260,138 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 			tcpChannel, certChannel := make(chan event.GenericEvent), make(chan event.GenericEvent)
 
+			backoff := controllerutils.NewBackoff(requeueInitialDelay, requeueMaxDelay, requeueJitter)
+
 			if err = (&controllers.DataStore{Client: mgr.GetClient(), TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "DataStore")
 
 				return err
 			}
 
+			if err = (&controllers.DataStoreJanitor{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DataStoreJanitor")
+
+				return err
+			}
+
+			if err = (&controllers.DedicatedDataStoreSnapshots{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "DedicatedDataStoreSnapshots")
+
+				return err
+			}
+
+			if err = (&controllers.ImageProfile{Client: mgr.GetClient(), TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "ImageProfile")
+
+				return err
+			}
+
+			if err = (&controllers.MutationProfile{Client: mgr.GetClient(), TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "MutationProfile")
+
+				return err
+			}
+
+			if err = (&controllers.CertificateRevocation{Client: mgr.GetClient(), TenantControlPlaneTrigger: tcpChannel}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "CertificateRevocation")
+
+				return err
+			}
+
+			clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+			if err != nil {
+				setupLog.Error(err, "unable to create the Kubernetes clientset")
+
+				return err
+			}
+
+			if err = (&controllers.SupportBundle{
+				Client: mgr.GetClient(),
+				Collector: &supportbundle.Collector{
+					Reader:          mgr.GetAPIReader(),
+					Scheme:          mgr.GetScheme(),
+					Clientset:       clientset,
+					KamajiNamespace: managerNamespace,
+				},
+				Recorder: mgr.GetEventRecorderFor("kamaji"),
+			}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "SupportBundle")
+
+				return err
+			}
+
+			if err = (&controllers.TenantControlPlanePool{Client: mgr.GetClient(), APIReader: mgr.GetAPIReader()}).SetupWithManager(mgr); err != nil {
+				setupLog.Error(err, "unable to create controller", "controller", "TenantControlPlanePool")
+
+				return err
+			}
+
+			if admissionPolicies {
+				if err = (&controllers.KamajiPolicy{Client: mgr.GetClient()}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "KamajiPolicy")
+
+					return err
+				}
+			}
+
+			faultInjector, err := faultinjection.Parse(faultInjection)
+			if err != nil {
+				setupLog.Error(err, "unable to parse the fault injection flag")
+
+				return err
+			}
+
+			if faultInjector != nil {
+				setupLog.Info("WARNING: fault injection is enabled, do not use it in production", "faults", faultInjector.String())
+			}
+
+			notifier := notifications.NewDispatcher(mgr.GetClient())
+			if err = mgr.Add(notifier); err != nil {
+				setupLog.Error(err, "unable to create the notifications dispatcher")
+
+				return err
+			}
+
+			var auditor *audit.Recorder
+			if auditEvents || len(auditWebhookURL) > 0 {
+				auditor = &audit.Recorder{
+					Client:        mgr.GetClient(),
+					Actor:         serviceaccount.MakeUsername(managerNamespace, managerServiceAccountName),
+					KamajiVersion: internal.GitTag,
+					Objects:       auditEvents,
+					WebhookURL:    auditWebhookURL,
+				}
+			}
+
 			reconciler := &controllers.TenantControlPlaneReconciler{
 				Client:    mgr.GetClient(),
 				APIReader: mgr.GetAPIReader(),
+				Recorder:  mgr.GetEventRecorderFor("kamaji"),
 				Config: controllers.TenantControlPlaneReconcilerConfig{
-					ReconcileTimeout:     controllerReconcileTimeout,
-					DefaultDataStoreName: datastore,
-					KineContainerImage:   kineImage,
-					TmpBaseDirectory:     tmpDirectory,
+					ReconcileTimeout:             controllerReconcileTimeout,
+					DefaultDataStoreName:         datastore,
+					KineContainerImage:           kineImage,
+					TmpBaseDirectory:             tmpDirectory,
+					SootLeastPrivilege:           sootLeastPrivilege,
+					KamajiVersion:                internal.GitTag,
+					StagedUpgrades:               stagedUpgrades,
+					ConfirmUpgrades:              confirmUpgrades,
+					ResourcesConcurrency:         resourcesConcurrency,
+					RevisionHistoryLimit:         revisionHistoryLimit,
+					MigrateWatchdogLeaseDuration: migrateWatchdogLeaseDuration,
 				},
 				CertificateChan:         certChannel,
+				Notifier:                notifier,
+				Auditor:                 auditor,
+				FaultInjector:           faultInjector,
 				TriggerChan:             tcpChannel,
 				KamajiNamespace:         managerNamespace,
 				KamajiServiceAccount:    managerServiceAccountName,
 				KamajiService:           managerServiceName,
 				KamajiMigrateImage:      migrateJobImage,
 				MaxConcurrentReconciles: maxConcurrentReconciles,
+				Backoff:                 backoff,
+				UsePriorityQueue:        usePriorityQueue,
 			}
 
 			if err = reconciler.SetupWithManager(mgr); err != nil {
@@ -169,6 +400,22 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if driftDetectionInterval > 0 {
+				renderConfig := reconciler.Config
+				renderConfig.TmpBaseDirectory = filepath.Join(tmpDirectory, "drift-detection")
+
+				if err = (&controllers.DriftDetection{
+					Client:   mgr.GetClient(),
+					Renderer: render.Renderer{Scheme: mgr.GetScheme(), Config: renderConfig, Reader: mgr.GetAPIReader()},
+					Recorder: mgr.GetEventRecorderFor("kamaji"),
+					Interval: driftDetectionInterval,
+				}).SetupWithManager(mgr); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "DriftDetection")
+
+					return err
+				}
+			}
+
 			k8sVersion, versionErr := cmdutils.KubernetesVersion(mgr.GetConfig())
 			if versionErr != nil {
 				setupLog.Error(err, "unable to get kubernetes version")
@@ -192,7 +439,55 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				}
 			}
 
-			if err = (&controllers.CertificateLifecycle{Channel: certChannel, Deadline: certificateExpirationDeadline}).SetupWithManager(mgr); err != nil {
+			if len(shard) > 0 {
+				if err = mgr.Add(&controllers.ShardingController{
+					Client:        mgr.GetClient(),
+					APIReader:     mgr.GetAPIReader(),
+					Shard:         shard,
+					Namespace:     managerNamespace,
+					Namespaces:    scope.Namespaces,
+					Selector:      scope.Selector,
+					LeaseDuration: shardLeaseDuration,
+				}); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "ShardingController")
+
+					return err
+				}
+			}
+
+			if orphansCollectorInterval > 0 {
+				if err = mgr.Add(&controllers.OrphansCollector{
+					Client:   mgr.GetClient(),
+					Interval: orphansCollectorInterval,
+					DryRun:   orphansCollectorDryRun,
+				}); err != nil {
+					setupLog.Error(err, "unable to create controller", "controller", "OrphansCollector")
+
+					return err
+				}
+			}
+
+			if consoleAPIBindAddress != "" {
+				watchClient, watchErr := client.NewWithWatch(mgr.GetConfig(), client.Options{Scheme: mgr.GetScheme(), Mapper: mgr.GetRESTMapper()})
+				if watchErr != nil {
+					setupLog.Error(watchErr, "unable to create the console API watch client")
+
+					return watchErr
+				}
+
+				if err = mgr.Add(&console.Server{
+					Client:      mgr.GetClient(),
+					WatchClient: watchClient,
+					BindAddress: consoleAPIBindAddress,
+					CertDir:     consoleAPICertDir,
+				}); err != nil {
+					setupLog.Error(err, "unable to create the console API server")
+
+					return err
+				}
+			}
+
+			if err = (&controllers.CertificateLifecycle{Channel: certChannel, Deadline: certificateExpirationDeadline, Notifier: notifier, FaultInjector: faultInjector}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "CertificateLifecycle")
 
 				return err
@@ -204,18 +499,49 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if err = (&kamajiv1alpha1.SecretTenantControlPlane{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "SecretTenantControlPlane")
+
+				return err
+			}
+
 			if err = (&kamajiv1alpha1.TenantControlPlaneStatusDataStore{}).SetupWithManager(ctx, mgr); err != nil {
 				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneStatusDataStore")
 
 				return err
 			}
 
+			if err = (&kamajiv1alpha1.TenantControlPlaneImageProfile{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneImageProfile")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.TenantControlPlaneMutationProfile{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneMutationProfile")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.CertificateRevocationTenantControlPlane{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "CertificateRevocationTenantControlPlane")
+
+				return err
+			}
+
+			if err = (&kamajiv1alpha1.TenantControlPlaneClaimPool{}).SetupWithManager(ctx, mgr); err != nil {
+				setupLog.Error(err, "unable to create indexer", "indexer", "TenantControlPlaneClaimPool")
+
+				return err
+			}
+
 			err = webhook.Register(mgr, map[routes.Route][]handlers.Handler{
 				routes.TenantControlPlaneMigrate{}: {
 					handlers.Freeze{},
 				},
 				routes.TenantControlPlaneDefaults{}: {
 					handlers.TenantControlPlaneDefaults{
+						Client:           mgr.GetClient(),
 						DefaultDatastore: datastore,
 					},
 				},
@@ -223,7 +549,10 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					handlers.TenantControlPlaneCertSANs{},
 					handlers.TenantControlPlaneName{},
 					handlers.TenantControlPlaneVersion{},
+					handlers.TenantControlPlaneVersionCatalog{Client: mgr.GetClient()},
 					handlers.TenantControlPlaneDataStore{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneImageProfile{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneMutationProfile{Client: mgr.GetClient()},
 					handlers.TenantControlPlaneDeployment{
 						Client: mgr.GetClient(),
 						DeploymentBuilder: controlplane.Deployment{
@@ -236,6 +565,18 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 					},
 					handlers.TenantControlPlaneServiceCIDR{},
 					handlers.TenantControlPlaneLoadBalancerSourceRanges{},
+					handlers.TenantControlPlaneEgressPolicy{},
+					handlers.TenantControlPlaneNodeConnectivity{},
+					handlers.TenantControlPlaneServiceAccountIssuer{},
+					handlers.TenantControlPlaneTLSPolicy{},
+					handlers.TenantControlPlaneSecurityProfile{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneQuota{Client: mgr.GetClient()},
+					handlers.TenantControlPlaneClientRateLimits{},
+					handlers.TenantControlPlaneNaming{},
+					handlers.TenantControlPlaneTargetCluster{},
+					handlers.TenantControlPlaneHostNetwork{},
+					handlers.TenantControlPlaneFeatureGates{},
+					handlers.TenantControlPlaneSchedulerConfiguration{},
 				},
 				routes.TenantControlPlaneTelemetry{}: {
 					handlers.TenantControlPlaneTelemetry{
@@ -251,6 +592,9 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				routes.DataStoreSecrets{}: {
 					handlers.DataStoreSecretValidation{Client: mgr.GetClient()},
 				},
+				routes.TenantControlPlaneSecrets{}: {
+					handlers.TenantControlPlaneSecrets{Username: serviceaccount.MakeUsername(managerNamespace, managerServiceAccountName)},
+				},
 			})
 			if err != nil {
 				setupLog.Error(err, "unable to create webhook")
@@ -259,10 +603,18 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 			}
 
 			if err = (&soot.Manager{
-				MigrateCABundle:         webhookCABundle,
-				MigrateServiceName:      managerServiceName,
-				MigrateServiceNamespace: managerNamespace,
-				AdminClient:             mgr.GetClient(),
+				MigrateCABundle:              webhookCABundle,
+				MigrateServiceName:           managerServiceName,
+				MigrateServiceNamespace:      managerNamespace,
+				AdminClient:                  mgr.GetClient(),
+				AdminClientset:               clientset,
+				APIReader:                    mgr.GetAPIReader(),
+				Backoff:                      backoff,
+				LeastPrivilege:               sootLeastPrivilege,
+				Notifier:                     notifier,
+				FaultInjector:                faultInjector,
+				MigrateWebhookFailurePolicy:  admissionregistrationv1.FailurePolicyType(migrateWebhookFailurePolicy),
+				MigrateWatchdogLeaseDuration: migrateWatchdogLeaseDuration,
 			}).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to set up soot manager")
 
@@ -293,21 +645,25 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 
 	// Setting zap logger
 	zapfs := flag.NewFlagSet("zap", flag.ExitOnError)
-	opts := zap.Options{
-		Development: true,
-	}
 	opts.BindFlags(zapfs)
 	cmd.Flags().AddGoFlagSet(zapfs)
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 	// Setting CLI flags
 	cmd.Flags().StringVar(&metricsBindAddress, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	cmd.Flags().StringVar(&healthProbeBindAddress, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	cmd.Flags().StringVar(&pprofBindAddress, "pprof-bind-address", "0", "The address the pprof endpoint binds to, the soot managers profiles are labelled with their TenantControlPlane: set to 0 to disable it.")
 	cmd.Flags().BoolVar(&leaderElect, "leader-elect", true, "Enable leader election for controller manager. Enabling this will ensure there is only one active controller manager.")
 	cmd.Flags().StringVar(&tmpDirectory, "tmp-directory", "/tmp/kamaji", "Directory which will be used to work with temporary files.")
 	cmd.Flags().StringVar(&kineImage, "kine-image", "rancher/kine:v0.11.10-amd64", "Container image along with tag to use for the Kine sidecar container (used only if etcd-storage-type is set to one of kine strategies).")
 	cmd.Flags().StringVar(&datastore, "datastore", "", "Optional, the default DataStore that should be used by Kamaji to setup the required storage of Tenant Control Planes with undeclared DataStore.")
+	cmd.Flags().StringVar(&migrateWebhookFailurePolicy, "migrate-webhook-failure-policy", string(admissionregistrationv1.Fail), "The failure policy of the webhook freezing the Tenant Clusters during the DataStore migrations, either Fail, or Ignore: with the latter, the writes are allowed when Kamaji cannot be reached, at the cost of losing them upon the migration completion.")
+	cmd.Flags().DurationVar(&migrateWatchdogLeaseDuration, "migrate-watchdog-lease-duration", 0, "Optional, the duration of the Lease renewed by the migration Job in the Tenant Cluster: the webhook freezing it is removed once the Lease expires, such as when the Job is not running anymore. Disabled when zero.")
 	cmd.Flags().StringVar(&migrateJobImage, "migrate-image", fmt.Sprintf("%s/clastix/kamaji:%s", internal.ContainerRepository, internal.GitTag), "Specify the container image to launch when a TenantControlPlane is migrated to a new datastore.")
 	cmd.Flags().IntVar(&maxConcurrentReconciles, "max-concurrent-tcp-reconciles", 1, "Specify the number of workers for the Tenant Control Plane controller (beware of CPU consumption)")
+	cmd.Flags().BoolVar(&usePriorityQueue, "tcp-priority-queue", false, "Prioritize the reconciliation of deleted, not-ready, and certificate rotating TenantControlPlane objects over the routine re-syncs.")
+	cmd.Flags().IntVar(&resourcesConcurrency, "tcp-resources-concurrency", 4, "Specify the number of independent resources, such as certificates and kubeconfigs, generated concurrently for each Tenant Control Plane.")
+	cmd.Flags().DurationVar(&requeueInitialDelay, "requeue-initial-delay", time.Second, "The delay of the TenantControlPlane reconciliations enqueued back while waiting for a condition, such as a resource being ready: it's doubled upon each attempt.")
+	cmd.Flags().DurationVar(&requeueMaxDelay, "requeue-max-delay", time.Second, "The maximum delay of the TenantControlPlane reconciliations enqueued back: increase it to reduce the API Server load, at the cost of a higher latency.")
+	cmd.Flags().Float64Var(&requeueJitter, "requeue-jitter", 0, "The jitter factor randomizing the delay of the TenantControlPlane reconciliations enqueued back, spreading the load of requests enqueued at the same time.")
 	cmd.Flags().StringVar(&managerNamespace, "pod-namespace", os.Getenv("POD_NAMESPACE"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceName, "webhook-service-name", "kamaji-webhook-service", "The Kamaji webhook server Service name which is used to get validation webhooks, required for the TenantControlPlane migration jobs.")
 	cmd.Flags().StringVar(&managerServiceAccountName, "serviceaccount-name", os.Getenv("SERVICE_ACCOUNT"), "The Kubernetes Namespace on which the Operator is running in, required for the TenantControlPlane migration jobs.")
@@ -316,6 +672,29 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().DurationVar(&cacheResyncPeriod, "cache-resync-period", 10*time.Hour, "The controller-runtime.Manager cache resync period.")
 	cmd.Flags().BoolVar(&disableTelemetry, "disable-telemetry", false, "Disable the analytics traces collection.")
 	cmd.Flags().DurationVar(&certificateExpirationDeadline, "certificate-expiration-deadline", 24*time.Hour, "Define the deadline upon certificate expiration to start the renewal process, cannot be less than a 24 hours.")
+	cmd.Flags().StringSliceVar(&watchNamespaces, "watch-namespaces", nil, "Optional, restrict the reconciled TenantControlPlane objects to the given Namespaces, along with the Kamaji one: all the Namespaces are watched if empty.")
+	cmd.Flags().StringVar(&instanceSelector, "instance-selector", "", "Optional, a label selector restricting the reconciled TenantControlPlane, and DataStore objects, allowing several Kamaji instances to run on the same cluster.")
+	cmd.Flags().StringVar(&shard, "shard", "", "Optional, the name of the shard served by the instance: the TenantControlPlane objects are assigned to the live shards using the kamaji.clastix.io/shard label.")
+	cmd.Flags().DurationVar(&orphansCollectorInterval, "orphans-collector-interval", 0, "The interval of the collection of the Kamaji-owned Secrets, Services, and Deployments no longer referenced by their TenantControlPlane: the collector is disabled if zero.")
+	cmd.Flags().BoolVar(&orphansCollectorDryRun, "orphans-collector-dry-run", false, "Report the orphaned objects found by the collector, along with the metrics, without deleting them.")
+	cmd.Flags().BoolVar(&admissionPolicies, "admission-policies", false, "Enforce the KamajiPolicy objects on the TenantControlPlane objects by means of ValidatingAdmissionPolicy objects, requiring Kubernetes v1.30, or greater, for the management cluster.")
+	cmd.Flags().StringVar(&tenantLogsDirectory, "tenant-logs-directory", "", "Optional, the directory where the log entries of each TenantControlPlane are appended to its own <namespace>_<name>.log file, besides the standard output.")
+	cmd.Flags().StringVar(&tenantLogsWebhookURL, "tenant-logs-webhook-url", "", "Optional, the URL receiving the JSON log entries of the TenantControlPlanes, with a POST request along with the X-Kamaji-Tenant header: entries are dropped when the receiver doesn't keep up.")
+	cmd.Flags().DurationVar(&shardLeaseDuration, "shard-lease-duration", 30*time.Second, "The duration after which a shard not renewing its Lease is considered gone, and its TenantControlPlane objects are assigned to the live ones.")
+	cmd.Flags().Float32Var(&managementAPIQPS, "management-api-qps", 0, "The queries per second of the management cluster client: the client-side rate limiting is disabled if zero, relying on the API Priority and Fairness of the management cluster.")
+	cmd.Flags().IntVar(&managementAPIBurst, "management-api-burst", 30, "The burst of the management cluster client, used only when the management-api-qps flag is set.")
+	cmd.Flags().Float32Var(&tenantAPIQPS, "tenant-api-qps", 5, "The queries per second of the clients interacting with the Tenant Clusters, such as the soot managers ones: it can be overridden per TenantControlPlane with the kamaji.clastix.io/client-qps annotation.")
+	cmd.Flags().IntVar(&tenantAPIBurst, "tenant-api-burst", 10, "The burst of the clients interacting with the Tenant Clusters: it can be overridden per TenantControlPlane with the kamaji.clastix.io/client-burst annotation.")
+	cmd.Flags().BoolVar(&sootLeastPrivilege, "soot-least-privilege", false, "Reconcile the Tenant Cluster resources, such as addons and kubeadm phases, using a dedicated user scoped to the required permissions rather than the admin one.")
+	cmd.Flags().BoolVar(&stagedUpgrades, "staged-upgrades", false, "Hold back the changes rendered by a new Kamaji version restarting the Control Plane pods of the existing TenantControlPlane objects, reporting them with events and conditions, until confirmed with the kamaji.clastix.io/confirm-operator-upgrade annotation set to the Kamaji version.")
+	cmd.Flags().BoolVar(&confirmUpgrades, "confirm-upgrades", false, "Confirm the changes rendered by a new Kamaji version for all the TenantControlPlane objects, used along with the staged-upgrades flag.")
+	cmd.Flags().StringVar(&consoleAPIBindAddress, "console-api-bind-address", "", "Optional, the address the read-only console API binds to, serving the TenantControlPlane summaries, kubeconfig, and events, to the user interfaces: it's disabled if empty.")
+	cmd.Flags().StringVar(&consoleAPICertDir, "console-api-cert-dir", "/tmp/k8s-webhook-server/serving-certs", "The directory containing the tls.crt, and tls.key, files of the console API serving certificate, defaulting to the webhook server one.")
+	cmd.Flags().BoolVar(&auditEvents, "audit-events", false, "Record the administrative actions performed on the TenantControlPlane objects, such as the secret rotations, the rollouts, the migrations, and the deletions, with KamajiAuditEvent objects.")
+	cmd.Flags().StringVar(&auditWebhookURL, "audit-webhook-url", "", "Optional, the URL receiving the JSON encoded audit events with a POST request, along with, or in place of, the KamajiAuditEvent objects.")
+	cmd.Flags().DurationVar(&driftDetectionInterval, "drift-detection-interval", 0, "Optional, the interval of the comparison between the Tenant Control Plane objects, including the tenant-side addon ones, and the ones rendered by Kamaji: the drift is reported by the Drifted condition, and in the <name>-drift-report ConfigMap, without reconciling it. Disabled when zero.")
+	cmd.Flags().StringVar(&faultInjection, "fault-injection", "", "Development only, the comma separated list of the faults injected to exercise the error handling paths, along with their probability, such as TenantAPIErrors=0.1,DataStoreTimeouts=0.05,CertificateExpiry=0.01.")
+	cmd.Flags().IntVar(&revisionHistoryLimit, "revision-history-limit", 10, "The number of the TenantControlPlane specification revisions retained for the rollbacks with the kamaji.clastix.io/rollback-to annotation, the history is disabled if set to 0.")
 
 	cobra.OnInitialize(func() {
 		viper.AutomaticEnv()
@@ -6,6 +6,7 @@ package migrate
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -17,6 +18,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/utilities"
 )
 
 func NewCmd(scheme *runtime.Scheme) *cobra.Command {
@@ -26,6 +28,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		targetDataStore       string
 		cleanupPriorMigration bool
 		timeout               time.Duration
+		watchdogLeaseDuration time.Duration
 	)
 
 	cmd := &cobra.Command{
@@ -59,6 +62,23 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 				return err
 			}
 
+			if watchdogLeaseDuration > 0 {
+				log.Info("starting the renewal of the migration watchdog Lease")
+
+				tenantClient, tErr := utilities.GetTenantAdminClient(ctx, client, tcp)
+				if tErr != nil {
+					return tErr
+				}
+
+				holder, _ := os.Hostname()
+				// The first renewal is synchronous, failing the migration if the Tenant Cluster cannot be reached.
+				if err = utilities.RenewMigrateWatchdog(ctx, tenantClient, holder, watchdogLeaseDuration); err != nil {
+					return fmt.Errorf("unable to renew the migration watchdog Lease: %w", err)
+				}
+
+				go renewWatchdog(ctx, tenantClient, holder, watchdogLeaseDuration)
+			}
+
 			log.Info("retrieving the TenantControlPlane used DataStore")
 
 			originDs := &kamajiv1alpha1.DataStore{}
@@ -127,9 +147,28 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 	cmd.Flags().StringVar(&targetDataStore, "target-datastore", "", "Name of the Datastore to which the TenantControlPlane will be migrated")
 	cmd.Flags().BoolVar(&cleanupPriorMigration, "cleanup-prior-migration", false, "When set to true, migration job will drop existing data in the target DataStore: useful to avoid stale data when migrating back and forth between DataStores.")
 	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Amount of time for the context timeout")
+	cmd.Flags().DurationVar(&watchdogLeaseDuration, "watchdog-lease-duration", 0, "When greater than zero, the duration of the migration watchdog Lease renewed in the Tenant Cluster during the migration: Kamaji unfreezes the Tenant Cluster once it expires.")
 
 	_ = cmd.MarkFlagRequired("tenant-control-plane")
 	_ = cmd.MarkFlagRequired("target-datastore")
 
 	return cmd
 }
+
+// renewWatchdog renews the migration watchdog Lease until the given context is done:
+// the failed renewals are retried, the Lease expires if none succeeds within its duration.
+func renewWatchdog(ctx context.Context, tenantClient ctrlclient.Client, holder string, duration time.Duration) {
+	ticker := time.NewTicker(duration / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := utilities.RenewMigrateWatchdog(ctx, tenantClient, holder, duration); err != nil {
+				ctrl.Log.Error(err, "unable to renew the migration watchdog Lease")
+			}
+		}
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+func NewCmd(scheme *runtime.Scheme) *cobra.Command {
+	// CLI flags
+	var (
+		tenantControlPlaneFile string
+		dataStoreFiles         []string
+		controlPlaneAddress    string
+		kineImage              string
+		tmpDirectory           string
+		showSecretData         bool
+		timeout                time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:          "render",
+		Short:        "Render the objects Kamaji would create for a TenantControlPlane, without applying them",
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, cancelFn := context.WithTimeout(context.Background(), timeout)
+			defer cancelFn()
+
+			log := ctrl.Log
+
+			tcp, err := decodeTenantControlPlane(scheme, tenantControlPlaneFile)
+			if err != nil {
+				return err
+			}
+
+			if len(tcp.GetNamespace()) == 0 {
+				tcp.SetNamespace(corev1.NamespaceDefault)
+			}
+
+			objects, err := decodeObjects(scheme, dataStoreFiles...)
+			if err != nil {
+				return err
+			}
+
+			ds, err := findDataStore(tcp, objects)
+			if err != nil {
+				return err
+			}
+
+			// OpenAPI defaults are applied by the API Server according to the CRD schema:
+			// the provided manifest must contain them, such as the output of a server-side dry-run creation.
+			if len(tcp.Spec.NetworkProfile.ClusterDomain) == 0 {
+				return fmt.Errorf("the TenantControlPlane manifest is missing the defaulted fields, generate it with `kubectl create --dry-run=server -o yaml`")
+			}
+
+			if err = applyDefaults(ctx, tcp, ds.GetName()); err != nil {
+				return err
+			}
+
+			// The KamajiDefaults object is not available offline, the version must be declared.
+			if len(tcp.Spec.Kubernetes.Version) == 0 {
+				return fmt.Errorf("the TenantControlPlane manifest doesn't declare the spec.kubernetes.version field")
+			}
+
+			if len(tcp.Spec.NetworkProfile.Address) == 0 {
+				if len(controlPlaneAddress) == 0 {
+					return fmt.Errorf("the TenantControlPlane doesn't declare the spec.networkProfile.address field, please provide it with the --control-plane-address flag")
+				}
+
+				tcp.Spec.NetworkProfile.Address = controlPlaneAddress
+			}
+
+			tmp, err := os.MkdirTemp(tmpDirectory, "kamaji-render-")
+			if err != nil {
+				return fmt.Errorf("cannot create temporary directory: %w", err)
+			}
+			defer os.RemoveAll(tmp)
+
+			log.Info("rendering the TenantControlPlane objects", "namespace", tcp.GetNamespace(), "name", tcp.GetName(), "datastore", ds.GetName())
+
+			renderer := Renderer{
+				Scheme: scheme,
+				Config: controllers.TenantControlPlaneReconcilerConfig{
+					KineContainerImage: kineImage,
+					TmpBaseDirectory:   tmp,
+				},
+				ShowSecretData: showSecretData,
+			}
+
+			bundle, err := renderer.Render(ctx, tcp, *ds, objects...)
+			if err != nil {
+				return err
+			}
+
+			_, err = cmd.OutOrStdout().Write(bundle)
+
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&tenantControlPlaneFile, "tenant-control-plane", "", "Path to the YAML manifest of the TenantControlPlane to render, use - for reading from stdin")
+	cmd.Flags().StringSliceVar(&dataStoreFiles, "datastore", nil, "Path to the YAML manifests of the DataStore, along with the Secret objects it references: it can be repeated, or contain multiple documents")
+	cmd.Flags().StringVar(&controlPlaneAddress, "control-plane-address", "", "Address used to render certificates and kubeconfigs when the TenantControlPlane doesn't declare one, since no Service can be provisioned")
+	cmd.Flags().StringVar(&kineImage, "kine-image", "rancher/kine:v0.11.10-amd64", "Container image along with tag to use for the Kine sidecar container (used only if etcd-storage-type is set to one of kine strategies).")
+	cmd.Flags().StringVar(&tmpDirectory, "tmp-directory", os.TempDir(), "Directory which will be used to work with temporary files.")
+	cmd.Flags().BoolVar(&showSecretData, "show-secret-data", false, "When set to true, the rendered Secret objects will contain their generated data: by default only metadata is printed.")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "Amount of time for the context timeout")
+
+	_ = cmd.MarkFlagRequired("tenant-control-plane")
+	_ = cmd.MarkFlagRequired("datastore")
+
+	return cmd
+}
+
+// applyDefaults mimics the mutating webhook upon the TenantControlPlane creation,
+// in order to render the same objects the API Server would persist.
+func applyDefaults(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, defaultDataStore string) error {
+	operations, err := handlers.TenantControlPlaneDefaults{DefaultDatastore: defaultDataStore}.OnCreate(tcp)(ctx, admission.Request{})
+	if err != nil {
+		return fmt.Errorf("cannot default the TenantControlPlane: %w", err)
+	}
+
+	if len(operations) == 0 {
+		return nil
+	}
+
+	original, err := json.Marshal(tcp)
+	if err != nil {
+		return err
+	}
+
+	rawPatch, err := json.Marshal(operations)
+	if err != nil {
+		return err
+	}
+
+	patch, err := jsonpatch.DecodePatch(rawPatch)
+	if err != nil {
+		return err
+	}
+
+	defaulted, err := patch.Apply(original)
+	if err != nil {
+		return fmt.Errorf("cannot apply defaults to the TenantControlPlane: %w", err)
+	}
+
+	return json.Unmarshal(defaulted, tcp)
+}
+
+func findDataStore(tcp *kamajiv1alpha1.TenantControlPlane, objects []runtime.Object) (*kamajiv1alpha1.DataStore, error) {
+	var dataStores []*kamajiv1alpha1.DataStore
+
+	for _, object := range objects {
+		if ds, ok := object.(*kamajiv1alpha1.DataStore); ok {
+			dataStores = append(dataStores, ds)
+		}
+	}
+
+	switch {
+	case len(dataStores) == 0:
+		return nil, fmt.Errorf("no DataStore object has been provided")
+	case len(tcp.Spec.DataStore) == 0 && len(dataStores) > 1:
+		return nil, fmt.Errorf("the TenantControlPlane doesn't reference a DataStore, and multiple ones have been provided")
+	case len(tcp.Spec.DataStore) == 0:
+		return dataStores[0], nil
+	}
+
+	for _, ds := range dataStores {
+		if ds.GetName() == tcp.Spec.DataStore {
+			return ds, nil
+		}
+	}
+
+	return nil, fmt.Errorf("the referenced DataStore %s has not been provided", tcp.Spec.DataStore)
+}
@@ -0,0 +1,306 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// maxRenderingPasses is the upper bound of pipeline iterations: resources can request to be enqueued back,
+// or depend on status fields populated by following ones, the same way the controller requeues the object.
+const maxRenderingPasses = 10
+
+// Renderer runs the resource pipeline of a TenantControlPlane against an in-memory client,
+// returning the resulting objects as a YAML bundle instead of applying them to the management cluster.
+type Renderer struct {
+	Scheme         *runtime.Scheme
+	Config         controllers.TenantControlPlaneReconcilerConfig
+	ShowSecretData bool
+	// Reader is used to retrieve the objects not found in the in-memory client, such as the DataStore Secrets:
+	// it's optional, and it must be set when rendering against the live objects.
+	Reader client.Reader
+}
+
+func (r Renderer) Render(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore, objects ...runtime.Object) ([]byte, error) {
+	c, provided, err := r.run(ctx, tenantControlPlane, dataStore, objects...)
+	if err != nil {
+		return nil, err
+	}
+
+	rendered, err := r.list(ctx, c, tenantControlPlane.GetNamespace())
+	if err != nil {
+		return nil, err
+	}
+
+	buf := bytes.NewBuffer(nil)
+
+	for _, obj := range rendered {
+		if provided.Has(client.ObjectKeyFromObject(obj)) {
+			continue
+		}
+
+		out, err := r.encode(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(out)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// RenderObjects returns the objects of the TenantControlPlane namespace resulting from the resource pipeline,
+// including the provided ones updated by the resources: it's used to detect the drift of the live objects.
+func (r Renderer) RenderObjects(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore, objects ...runtime.Object) ([]client.Object, error) {
+	c, _, err := r.run(ctx, tenantControlPlane, dataStore, objects...)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.list(ctx, c, tenantControlPlane.GetNamespace())
+}
+
+func (r Renderer) run(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore, objects ...runtime.Object) (client.Client, sets.Set[types.NamespacedName], error) {
+	tcp := tenantControlPlane.DeepCopy()
+
+	provided := sets.New[types.NamespacedName]()
+	// The live Tenant Control Plane UID is retained, since it's referenced by the owner references of the provided objects.
+	if len(tcp.GetUID()) == 0 {
+		tcp.SetUID(uuid.NewUUID())
+	}
+	clientObjects := []client.Object{tcp.DeepCopy()}
+
+	for _, object := range objects {
+		clientObject, ok := object.(client.Object)
+		if !ok {
+			continue
+		}
+
+		if len(clientObject.GetUID()) == 0 {
+			clientObject.SetUID(uuid.NewUUID())
+		}
+
+		provided.Insert(client.ObjectKeyFromObject(clientObject))
+		clientObjects = append(clientObjects, clientObject)
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(r.Scheme).
+		WithObjects(clientObjects...).
+		WithStatusSubresource(&kamajiv1alpha1.TenantControlPlane{}).
+		WithInterceptorFuncs(interceptor.Funcs{Create: generateUID, Get: r.get}).
+		Build()
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+		return nil, nil, errors.Wrap(err, "cannot retrieve the TenantControlPlane")
+	}
+
+	if err := r.reconcile(ctx, c, tcp, dataStore); err != nil {
+		return nil, nil, err
+	}
+
+	return c, provided, nil
+}
+
+// get falls back to the Reader for the objects not found in the in-memory client.
+func (r Renderer) get(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	err := c.Get(ctx, key, obj, opts...)
+	if r.Reader == nil || !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	return r.Reader.Get(ctx, key, obj, opts...)
+}
+
+// generateUID mimics the API Server behaviour, since resources rely on the UID to detect created objects.
+func generateUID(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	if len(obj.GetUID()) == 0 {
+		obj.SetUID(uuid.NewUUID())
+	}
+
+	return c.Create(ctx, obj, opts...)
+}
+
+func (r Renderer) reconcile(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore) error {
+	for range maxRenderingPasses {
+		converged := true
+
+		for _, resource := range controllers.GetRenderableResources(c, r.Config, *tcp, dataStore) {
+			result, err := resources.Handle(ctx, resource, tcp)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot render resource %s", resource.GetName()))
+			}
+
+			if result == controllerutil.OperationResultNone {
+				continue
+			}
+
+			// Status-only updates don't change the rendered objects,
+			// such as the Deployment never becoming ready since no Pod is going to be scheduled.
+			if result != controllerutil.OperationResultUpdatedStatusOnly {
+				converged = false
+			}
+
+			if err = utils.UpdateStatus(ctx, c, tcp, resource); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot update status for resource %s", resource.GetName()))
+			}
+
+			if result == resources.OperationResultEnqueueBack {
+				break
+			}
+		}
+
+		if converged {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("rendering didn't converge after %d passes", maxRenderingPasses)
+}
+
+func (r Renderer) list(ctx context.Context, c client.Client, namespace string) ([]client.Object, error) {
+	lists := []client.ObjectList{
+		&corev1.ServiceList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&appsv1.DeploymentList{},
+		&networkingv1.IngressList{},
+	}
+
+	var objects []client.Object
+
+	for _, list := range lists {
+		if err := c.List(ctx, list, client.InNamespace(namespace)); err != nil {
+			return nil, errors.Wrap(err, "cannot list rendered objects")
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			objects = append(objects, item.(client.Object)) //nolint:forcetypeassert
+		}
+	}
+
+	return objects, nil
+}
+
+func (r Renderer) encode(obj client.Object) ([]byte, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Scheme)
+	if err != nil {
+		return nil, err
+	}
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+	obj.SetManagedFields(nil)
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+
+	if secret, ok := obj.(*corev1.Secret); ok && !r.ShowSecretData {
+		secret.Data, secret.StringData = nil, nil
+	}
+
+	return utilities.EncodeToYaml(obj)
+}
+
+func decodeTenantControlPlane(scheme *runtime.Scheme, path string) (*kamajiv1alpha1.TenantControlPlane, error) {
+	objects, err := decodeObjects(scheme, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(objects) != 1 {
+		return nil, fmt.Errorf("expected a single object in %s, found %d", path, len(objects))
+	}
+
+	tcp, ok := objects[0].(*kamajiv1alpha1.TenantControlPlane)
+	if !ok {
+		return nil, fmt.Errorf("expected a TenantControlPlane object in %s", path)
+	}
+
+	return tcp, nil
+}
+
+func decodeObjects(scheme *runtime.Scheme, paths ...string) ([]runtime.Object, error) {
+	decoder := serializer.NewCodecFactory(scheme).UniversalDeserializer()
+
+	var objects []runtime.Object
+
+	for _, path := range paths {
+		var (
+			content []byte
+			err     error
+		)
+
+		switch path {
+		case "-":
+			content, err = io.ReadAll(os.Stdin)
+		default:
+			content, err = os.ReadFile(path)
+		}
+
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot read %s", path))
+		}
+
+		reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(content)))
+
+		for {
+			doc, rErr := reader.Read()
+			if errors.Is(rErr, io.EOF) {
+				break
+			}
+
+			if rErr != nil {
+				return nil, errors.Wrap(rErr, fmt.Sprintf("cannot read YAML document from %s", path))
+			}
+
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+
+			obj, _, dErr := decoder.Decode(doc, nil, nil)
+			if dErr != nil {
+				return nil, errors.Wrap(dErr, fmt.Sprintf("cannot decode object from %s", path))
+			}
+
+			objects = append(objects, obj)
+		}
+	}
+
+	return objects, nil
+}
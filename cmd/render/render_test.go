@@ -0,0 +1,289 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package render
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const (
+	tenantControlPlaneManifest = `apiVersion: kamaji.clastix.io/v1alpha1
+kind: TenantControlPlane
+metadata:
+  name: tenant-00
+  namespace: tenants
+spec:
+  dataStore: nats
+  controlPlane:
+    deployment:
+      replicas: 1
+    service:
+      serviceType: ClusterIP
+  kubernetes:
+    version: v1.33.0
+    kubelet:
+      cgroupfs: systemd
+  networkProfile:
+    port: 6443
+    clusterDomain: cluster.local
+    serviceCidr: 10.96.0.0/16
+    podCidr: 10.244.0.0/16
+    dnsServiceIPs:
+      - 10.96.0.10
+`
+	dataStoreManifest = `apiVersion: kamaji.clastix.io/v1alpha1
+kind: DataStore
+metadata:
+  name: nats
+spec:
+  driver: NATS
+  endpoints:
+    - nats.nats-system.svc:4222
+  basicAuth:
+    username:
+      content: YWRtaW4=
+    password:
+      secretReference:
+        name: nats-config
+        namespace: nats-system
+        keyPath: password
+---
+apiVersion: v1
+kind: Secret
+metadata:
+  name: nats-config
+  namespace: nats-system
+data:
+  password: cGFzc3dvcmQ=
+`
+)
+
+func testScheme() *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	return scheme
+}
+
+func writeManifest(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("cannot write the %s manifest: %s", name, err)
+	}
+
+	return path
+}
+
+func TestDecodeObjects(t *testing.T) {
+	scheme := testScheme()
+
+	objects, err := decodeObjects(scheme, writeManifest(t, "datastore.yaml", dataStoreManifest))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(objects) != 2 {
+		t.Fatalf("expected 2 objects, got %d", len(objects))
+	}
+
+	if _, ok := objects[0].(*kamajiv1alpha1.DataStore); !ok {
+		t.Errorf("expected a DataStore object, got %T", objects[0])
+	}
+
+	if _, ok := objects[1].(*corev1.Secret); !ok {
+		t.Errorf("expected a Secret object, got %T", objects[1])
+	}
+
+	if _, err = decodeObjects(scheme, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestDecodeTenantControlPlane(t *testing.T) {
+	scheme := testScheme()
+
+	tcp, err := decodeTenantControlPlane(scheme, writeManifest(t, "tcp.yaml", tenantControlPlaneManifest))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if tcp.GetName() != "tenant-00" || tcp.Spec.DataStore != "nats" {
+		t.Errorf("unexpected TenantControlPlane %s referencing the DataStore %s", tcp.GetName(), tcp.Spec.DataStore)
+	}
+
+	if _, err = decodeTenantControlPlane(scheme, writeManifest(t, "datastore.yaml", dataStoreManifest)); err == nil || !strings.Contains(err.Error(), "expected a single object") {
+		t.Errorf("expected an error for multiple objects, got %v", err)
+	}
+
+	dataStore, _, _ := strings.Cut(dataStoreManifest, "---")
+	if _, err = decodeTenantControlPlane(scheme, writeManifest(t, "datastore.yaml", dataStore)); err == nil || !strings.Contains(err.Error(), "expected a TenantControlPlane object") {
+		t.Errorf("expected an error for a non TenantControlPlane object, got %v", err)
+	}
+}
+
+func TestFindDataStore(t *testing.T) {
+	dataStore := func(name string) *kamajiv1alpha1.DataStore {
+		return &kamajiv1alpha1.DataStore{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	testCases := []struct {
+		name      string
+		reference string
+		objects   []runtime.Object
+		expected  string
+		err       string
+	}{
+		{
+			name:    "no DataStore",
+			objects: []runtime.Object{&corev1.Secret{}},
+			err:     "no DataStore object has been provided",
+		},
+		{
+			name:     "single DataStore without reference",
+			objects:  []runtime.Object{&corev1.Secret{}, dataStore("etcd")},
+			expected: "etcd",
+		},
+		{
+			name:    "multiple DataStores without reference",
+			objects: []runtime.Object{dataStore("etcd"), dataStore("nats")},
+			err:     "multiple ones have been provided",
+		},
+		{
+			name:      "referenced DataStore",
+			reference: "nats",
+			objects:   []runtime.Object{dataStore("etcd"), dataStore("nats")},
+			expected:  "nats",
+		},
+		{
+			name:      "missing referenced DataStore",
+			reference: "mysql",
+			objects:   []runtime.Object{dataStore("etcd"), dataStore("nats")},
+			err:       "the referenced DataStore mysql has not been provided",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.DataStore = tc.reference
+
+			ds, err := findDataStore(tcp, tc.objects)
+			if len(tc.err) > 0 {
+				if err == nil || !strings.Contains(err.Error(), tc.err) {
+					t.Fatalf("expected the error %q, got %v", tc.err, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if ds.GetName() != tc.expected {
+				t.Errorf("expected the DataStore %s, got %s", tc.expected, ds.GetName())
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	secret := func() *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "tenant-00-api-server-certificate",
+				Namespace:       "tenants",
+				UID:             "a7a8c6a8-0000-0000-0000-000000000000",
+				ResourceVersion: "42",
+			},
+			Data: map[string][]byte{"apiserver.key": []byte("private")},
+		}
+	}
+
+	out, err := Renderer{Scheme: testScheme()}.encode(secret())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, unexpected := range []string{"apiserver.key", "resourceVersion", "uid"} {
+		if bytes.Contains(out, []byte(unexpected)) {
+			t.Errorf("expected %s to be stripped from the rendered Secret:\n%s", unexpected, out)
+		}
+	}
+
+	if !bytes.Contains(out, []byte("kind: Secret")) {
+		t.Errorf("expected the rendered Secret to declare its kind:\n%s", out)
+	}
+
+	if out, err = (Renderer{Scheme: testScheme(), ShowSecretData: true}).encode(secret()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !bytes.Contains(out, []byte("apiserver.key")) {
+		t.Errorf("expected the rendered Secret to contain its data:\n%s", out)
+	}
+}
+
+func TestRenderCommand(t *testing.T) {
+	cmd := NewCmd(testScheme())
+
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{
+		"--tenant-control-plane", writeManifest(t, "tcp.yaml", tenantControlPlaneManifest),
+		"--datastore", writeManifest(t, "datastore.yaml", dataStoreManifest),
+		"--control-plane-address", "192.168.1.100",
+		"--tmp-directory", t.TempDir(),
+	})
+
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	objects, err := decodeObjects(testScheme(), writeManifest(t, "bundle.yaml", out.String()))
+	if err != nil {
+		t.Fatalf("cannot decode the rendered bundle: %s", err)
+	}
+
+	rendered := map[string]bool{}
+
+	for _, object := range objects {
+		obj, ok := object.(client.Object)
+		if !ok {
+			t.Fatalf("unexpected rendered object %T", object)
+		}
+
+		if obj.GetNamespace() != "tenants" {
+			t.Errorf("expected the provided object %s/%s to be skipped", obj.GetNamespace(), obj.GetName())
+		}
+
+		if secret, isSecret := obj.(*corev1.Secret); isSecret && len(secret.Data) > 0 {
+			t.Errorf("expected the Secret %s to be rendered without data", secret.GetName())
+		}
+
+		rendered[obj.GetObjectKind().GroupVersionKind().Kind] = true
+	}
+
+	for _, kind := range []string{"Deployment", "Service", "Secret"} {
+		if !rendered[kind] {
+			t.Errorf("expected a %s to be rendered", kind)
+		}
+	}
+}
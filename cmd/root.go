@@ -12,6 +12,7 @@ import (
 	appsv1 "k8s.io/kubernetes/pkg/apis/apps/v1"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajiv1alpha2 "github.com/clastix/kamaji/api/v1alpha2"
 )
 
 func NewCmd(scheme *runtime.Scheme) *cobra.Command {
@@ -21,6 +22,7 @@ func NewCmd(scheme *runtime.Scheme) *cobra.Command {
 		PersistentPreRun: func(*cobra.Command, []string) {
 			utilruntime.Must(clientgoscheme.AddToScheme(scheme))
 			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+			utilruntime.Must(kamajiv1alpha2.AddToScheme(scheme))
 			utilruntime.Must(appsv1.RegisterDefaults(scheme))
 		},
 	}
@@ -0,0 +1,123 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Scope restricts the TenantControlPlane, and DataStore objects reconciled by a Kamaji instance,
+// allowing several isolated instances to run on the same management cluster.
+type Scope struct {
+	Namespaces []string
+	Selector   labels.Selector
+	// Shard restricts the TenantControlPlane objects to the ones assigned to the given shard.
+	Shard string
+}
+
+// NewScope parses the watched namespaces, the instance label selector, and the shard:
+// the Kamaji namespace is always watched since it's hosting the DataStore Secrets.
+func NewScope(namespaces []string, selector, shard, managerNamespace string) (Scope, error) {
+	scope := Scope{Shard: shard}
+
+	if msgs := validation.IsValidLabelValue(shard); len(msgs) > 0 {
+		return Scope{}, fmt.Errorf("invalid shard name: %s", strings.Join(msgs, ", "))
+	}
+
+	if len(namespaces) > 0 {
+		scope.Namespaces = append(scope.Namespaces, namespaces...)
+		if !slices.Contains(scope.Namespaces, managerNamespace) {
+			scope.Namespaces = append(scope.Namespaces, managerNamespace)
+		}
+
+		slices.Sort(scope.Namespaces)
+	}
+
+	if len(selector) > 0 {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return Scope{}, errors.Wrap(err, "cannot parse the instance selector")
+		}
+
+		scope.Selector = parsed
+	}
+
+	return scope, nil
+}
+
+func (s Scope) IsEmpty() bool {
+	return len(s.Namespaces) == 0 && s.Selector == nil && len(s.Shard) == 0
+}
+
+// CacheOptions restricts the manager cache to the scope:
+// objects outside of it are neither retrieved nor reconciled.
+func (s Scope) CacheOptions(opts *cache.Options) {
+	if len(s.Namespaces) > 0 {
+		opts.DefaultNamespaces = make(map[string]cache.Config, len(s.Namespaces))
+		for _, namespace := range s.Namespaces {
+			opts.DefaultNamespaces[namespace] = cache.Config{}
+		}
+	}
+
+	if s.Selector == nil && len(s.Shard) == 0 {
+		return
+	}
+
+	if opts.ByObject == nil {
+		opts.ByObject = make(map[client.Object]cache.ByObject)
+	}
+
+	if s.Selector != nil {
+		opts.ByObject[&kamajiv1alpha1.DataStore{}] = cache.ByObject{Label: s.Selector}
+	}
+
+	opts.ByObject[&kamajiv1alpha1.TenantControlPlane{}] = cache.ByObject{Label: s.tenantControlPlaneSelector()}
+}
+
+func (s Scope) tenantControlPlaneSelector() labels.Selector {
+	selector := s.Selector
+	if selector == nil {
+		selector = labels.Everything()
+	}
+
+	if len(s.Shard) == 0 {
+		return selector
+	}
+
+	requirement, _ := labels.NewRequirement(constants.ShardLabelKey, selection.Equals, []string{s.Shard})
+
+	return selector.Add(*requirement)
+}
+
+// LeaderElectionID derives the leader election ID from the scope, preventing scoped instances from competing for the same lease:
+// the unscoped instance retains the given ID.
+func (s Scope) LeaderElectionID(id string) string {
+	if s.IsEmpty() {
+		return id
+	}
+
+	data := map[string]string{"namespaces": strings.Join(s.Namespaces, ",")}
+	if s.Selector != nil {
+		data["selector"] = s.Selector.String()
+	}
+
+	if len(s.Shard) > 0 {
+		data["shard"] = s.Shard
+	}
+
+	return fmt.Sprintf("%s.%s", utilities.CalculateMapChecksum(data)[:10], id)
+}
@@ -27,12 +27,18 @@ import (
 	"github.com/clastix/kamaji/controllers/utils"
 	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/faultinjection"
+	"github.com/clastix/kamaji/internal/notifications"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
 type CertificateLifecycle struct {
 	Channel  chan event.GenericEvent
 	Deadline time.Duration
+	// Notifier delivers the certificates about to expire to the NotificationSink objects: it's optional.
+	Notifier *notifications.Dispatcher
+	// FaultInjector simulates the certificates expiry, requesting their rotation, for development purposes: it's optional.
+	FaultInjector *faultinjection.Injector
 
 	client client.Client
 }
@@ -87,14 +93,37 @@ func (s *CertificateLifecycle) Reconcile(ctx context.Context, request reconcile.
 	}
 
 	deadline := time.Now().Add(s.Deadline)
+	expiring := deadline.After(crt.NotAfter)
+	// The simulated expiry requests the rotation, since the resources are regenerating the valid certificates only upon request.
+	if !expiring && s.FaultInjector.Inject(faultinjection.CertificateExpiry) {
+		logger.Info("injecting the certificate expiry, requesting its rotation")
 
-	if deadline.After(crt.NotAfter) {
+		if err = s.requestRotation(ctx, &secret); err != nil {
+			logger.Error(err, "cannot request the certificate rotation")
+
+			return reconcile.Result{}, err
+		}
+
+		expiring = true
+	}
+
+	if expiring {
 		logger.Info("certificate near expiration, must be rotated")
 
+		tcp, ok := kamajiv1alpha1.OwningTenantControlPlane(&secret)
+		if !ok {
+			logger.Info("missing Tenant Control Plane owner reference, shouldn't happen")
+
+			return reconcile.Result{}, nil
+		}
+
+		s.Notifier.Notify(tcp, kamajiv1alpha1.NotificationEventCertificateExpiring,
+			fmt.Sprintf("the certificate stored in the Secret %s expires at %s, triggering its rotation", secret.GetName(), crt.NotAfter.UTC().Format(time.RFC3339)))
+
 		s.Channel <- event.GenericEvent{Object: &kamajiv1alpha1.TenantControlPlane{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      secret.GetOwnerReferences()[0].Name,
-				Namespace: secret.Namespace,
+				Name:      tcp.Name,
+				Namespace: tcp.Namespace,
 			},
 		}}
 
@@ -110,6 +139,20 @@ func (s *CertificateLifecycle) Reconcile(ctx context.Context, request reconcile.
 	return reconcile.Result{RequeueAfter: after}, nil
 }
 
+func (s *CertificateLifecycle) requestRotation(ctx context.Context, secret *corev1.Secret) error {
+	patch := client.MergeFrom(secret.DeepCopy())
+
+	annotations := secret.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	annotations[utilities.RotateCertificateRequestAnnotation] = ""
+	secret.SetAnnotations(annotations)
+
+	return s.client.Patch(ctx, secret, patch)
+}
+
 func (s *CertificateLifecycle) extractCertificateFromBareSecret(secret corev1.Secret) (*x509.Certificate, error) {
 	var crt *x509.Certificate
 	var err error
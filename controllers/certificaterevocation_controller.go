@@ -0,0 +1,173 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+type CertificateRevocation struct {
+	Client client.Client
+	// TenantControlPlaneTrigger is the channel used to communicate across the controllers:
+	// if a Certificate Revocation is created, or deleted, the Tenant Control Plane must publish its CRL again.
+	TenantControlPlaneTrigger chan event.GenericEvent
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=certificaterevocations,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=certificaterevocations/status,verbs=get;update;patch
+
+func (r *CertificateRevocation) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var revocation kamajiv1alpha1.CertificateRevocation
+	if err := r.Client.Get(ctx, request.NamespacedName, &revocation); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+	// The specification is immutable, the certificate has been already resolved.
+	if len(revocation.Status.SerialNumber) > 0 {
+		r.trigger(ctx, &revocation)
+
+		return reconcile.Result{}, nil
+	}
+
+	serialNumber, subject, kubeconfigSecret, resolveErr := r.resolve(ctx, &revocation)
+	if resolveErr != nil {
+		logger.Error(resolveErr, "cannot resolve the revoked certificate")
+
+		revocation.Status.Error = resolveErr.Error()
+		if err := r.Client.Status().Update(ctx, &revocation); err != nil {
+			logger.Error(err, "cannot update the status for the given instance")
+		}
+
+		return reconcile.Result{}, resolveErr
+	}
+
+	revocation.Status = kamajiv1alpha1.CertificateRevocationStatus{
+		SerialNumber: serialNumber,
+		Subject:      subject,
+		RevokedAt:    metav1.Now(),
+	}
+
+	if err := r.Client.Status().Update(ctx, &revocation); err != nil {
+		logger.Error(err, "cannot update the status for the given instance")
+
+		return reconcile.Result{}, err
+	}
+	// The kubeconfig generated by Kamaji is rotated, replacing the revoked certificate.
+	if kubeconfigSecret != nil {
+		if tcp, ok := kamajiv1alpha1.OwningTenantControlPlane(kubeconfigSecret); ok && tcp.Name == revocation.Spec.TenantControlPlane {
+			patch := client.MergeFrom(kubeconfigSecret.DeepCopy())
+
+			kubeconfigSecret.SetAnnotations(utilities.MergeMaps(kubeconfigSecret.GetAnnotations(), map[string]string{utilities.RotateCertificateRequestAnnotation: ""}))
+
+			if err := r.Client.Patch(ctx, kubeconfigSecret, patch); err != nil {
+				logger.Error(err, "cannot request the rotation of the revoked kubeconfig")
+
+				return reconcile.Result{}, err
+			}
+
+			logger.Info("rotation of the revoked kubeconfig requested", "secret", kubeconfigSecret.GetName())
+		}
+	}
+
+	logger.Info("certificate revoked", "serialNumber", serialNumber)
+
+	r.trigger(ctx, &revocation)
+
+	return reconcile.Result{}, nil
+}
+
+// resolve returns the serial number of the revoked certificate, along with its subject,
+// and the kubeconfig Secret, when the certificate is referenced by a kubeconfig.
+func (r *CertificateRevocation) resolve(ctx context.Context, revocation *kamajiv1alpha1.CertificateRevocation) (string, string, *corev1.Secret, error) {
+	if selector := revocation.Spec.KubeconfigSecret; selector == nil {
+		serialNumber, ok := new(big.Int).SetString(revocation.Spec.SerialNumber, 16)
+		if !ok {
+			return "", "", nil, fmt.Errorf("invalid serial number %q", revocation.Spec.SerialNumber)
+		}
+
+		return fmt.Sprintf("%x", serialNumber), "", nil, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: revocation.GetNamespace(), Name: revocation.Spec.KubeconfigSecret.Name}, &secret); err != nil {
+		return "", "", nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the kubeconfig Secret %s", revocation.Spec.KubeconfigSecret.Name))
+	}
+
+	kubeconfig, err := utilities.DecodeKubeconfig(secret, revocation.Spec.KubeconfigSecret.Key)
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "cannot decode the kubeconfig")
+	}
+
+	if len(kubeconfig.AuthInfos) == 0 || len(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData) == 0 {
+		return "", "", nil, errors.New("the kubeconfig has no client certificate")
+	}
+
+	crt, err := crypto.ParseCertificateBytes(kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData)
+	if err != nil {
+		return "", "", nil, errors.Wrap(err, "cannot parse the kubeconfig client certificate")
+	}
+
+	return fmt.Sprintf("%x", crt.SerialNumber), crt.Subject.String(), &secret, nil
+}
+
+func (r *CertificateRevocation) trigger(ctx context.Context, revocation *kamajiv1alpha1.CertificateRevocation) {
+	var shrunkTCP kamajiv1alpha1.TenantControlPlane
+
+	shrunkTCP.Name = revocation.Spec.TenantControlPlane
+	shrunkTCP.Namespace = revocation.GetNamespace()
+
+	go utils.TriggerChannel(ctx, r.TenantControlPlaneTrigger, shrunkTCP)
+}
+
+func (r *CertificateRevocation) SetupWithManager(mgr controllerruntime.Manager) error {
+	enqueueFn := func(object client.Object, limitingInterface workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+		limitingInterface.Add(reconcile.Request{NamespacedName: client.ObjectKeyFromObject(object)})
+	}
+	// The deleted revocations are no longer available to the reconciliation:
+	// the Tenant Control Plane is triggered right away, removing the certificate from the CRL.
+	//nolint:forcetypeassert
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("certificaterevocation").
+		Watches(&kamajiv1alpha1.CertificateRevocation{}, handler.Funcs{
+			CreateFunc: func(_ context.Context, createEvent event.TypedCreateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				enqueueFn(createEvent.Object, w)
+			},
+			UpdateFunc: func(_ context.Context, updateEvent event.TypedUpdateEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				enqueueFn(updateEvent.ObjectNew, w)
+			},
+			DeleteFunc: func(ctx context.Context, deleteEvent event.TypedDeleteEvent[client.Object], _ workqueue.TypedRateLimitingInterface[reconcile.Request]) {
+				r.trigger(ctx, deleteEvent.Object.(*kamajiv1alpha1.CertificateRevocation))
+			},
+		}).
+		Complete(r)
+}
@@ -0,0 +1,229 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// newKubeconfigSecret returns a kubeconfig Secret, controlled by the given Tenant Control Plane,
+// along with the serial number of its client certificate.
+func newKubeconfigSecret(t *testing.T, tcpName string) (*corev1.Secret, string) {
+	t.Helper()
+
+	caCertificate, caPrivateKey, err := crypto.GenerateCACertificatePrivateKeyPair("kubernetes", false)
+	if err != nil {
+		t.Fatalf("cannot generate the CA: %s", err)
+	}
+
+	template := crypto.NewCertificateTemplate("kubernetes-admin")
+	template.Subject.Organization = []string{"kubeadm:cluster-admins"}
+
+	certificate, privateKey, err := crypto.GenerateCertificatePrivateKeyPair(template, caCertificate.Bytes(), caPrivateKey.Bytes(), false)
+	if err != nil {
+		t.Fatalf("cannot generate the client certificate: %s", err)
+	}
+
+	kubeconfig, err := utilities.EncodeToYaml(&clientcmdapiv1.Config{
+		AuthInfos: []clientcmdapiv1.NamedAuthInfo{{
+			Name: "kubernetes-admin",
+			AuthInfo: clientcmdapiv1.AuthInfo{
+				ClientCertificateData: certificate.Bytes(),
+				ClientKeyData:         privateKey.Bytes(),
+			},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("cannot encode the kubeconfig: %s", err)
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "tenants",
+			Name:      "tenant-00-admin-kubeconfig",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kamajiv1alpha1.GroupVersion.String(),
+				Kind:       "TenantControlPlane",
+				Name:       tcpName,
+				UID:        "tenant-uid",
+				Controller: ptr.To(true),
+			}},
+		},
+		Data: map[string][]byte{"admin.conf": kubeconfig},
+	}, fmt.Sprintf("%x", template.SerialNumber)
+}
+
+func reconcileRevocation(t *testing.T, objects ...client.Object) (client.Client, chan event.GenericEvent, error) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&kamajiv1alpha1.CertificateRevocation{}).
+		Build()
+
+	trigger := make(chan event.GenericEvent, 1)
+	r := &CertificateRevocation{Client: c, TenantControlPlaneTrigger: trigger}
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "tenants", Name: "revocation"}})
+
+	return c, trigger, err
+}
+
+func getRevocation(t *testing.T, c client.Client) kamajiv1alpha1.CertificateRevocation {
+	t.Helper()
+
+	var revocation kamajiv1alpha1.CertificateRevocation
+	if err := c.Get(context.Background(), client.ObjectKey{Namespace: "tenants", Name: "revocation"}, &revocation); err != nil {
+		t.Fatalf("cannot retrieve the revocation: %s", err)
+	}
+
+	return revocation
+}
+
+func expectTrigger(t *testing.T, trigger chan event.GenericEvent) {
+	t.Helper()
+
+	select {
+	case e := <-trigger:
+		if e.Object.GetNamespace() != "tenants" || e.Object.GetName() != "tenant-00" {
+			t.Errorf("expected the Tenant Control Plane tenants/tenant-00 to be triggered, got %s/%s", e.Object.GetNamespace(), e.Object.GetName())
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("expected the Tenant Control Plane to be triggered")
+	}
+}
+
+func TestCertificateRevocationSerialNumber(t *testing.T) {
+	revocation := &kamajiv1alpha1.CertificateRevocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "revocation"},
+		Spec:       kamajiv1alpha1.CertificateRevocationSpec{TenantControlPlane: "tenant-00", SerialNumber: "00FF1A"},
+	}
+
+	c, trigger, err := reconcileRevocation(t, revocation)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if status := getRevocation(t, c).Status; status.SerialNumber != "ff1a" || status.RevokedAt.IsZero() || len(status.Error) > 0 {
+		t.Errorf("expected the normalized serial number to be revoked, got %+v", status)
+	}
+
+	expectTrigger(t, trigger)
+}
+
+func TestCertificateRevocationKubeconfigSecret(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		owner    string
+		rotation bool
+	}{
+		{name: "owned by the Tenant Control Plane", owner: "tenant-00", rotation: true},
+		{name: "owned by another Tenant Control Plane", owner: "tenant-01", rotation: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			secret, serialNumber := newKubeconfigSecret(t, tc.owner)
+
+			revocation := &kamajiv1alpha1.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "revocation"},
+				Spec: kamajiv1alpha1.CertificateRevocationSpec{
+					TenantControlPlane: "tenant-00",
+					KubeconfigSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: secret.Name},
+						Key:                  "admin.conf",
+					},
+				},
+			}
+
+			c, trigger, err := reconcileRevocation(t, revocation, secret)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			status := getRevocation(t, c).Status
+			if status.SerialNumber != serialNumber {
+				t.Errorf("expected the serial number %s, got %s", serialNumber, status.SerialNumber)
+			}
+
+			if status.Subject != "CN=kubernetes-admin,O=kubeadm:cluster-admins" {
+				t.Errorf("unexpected subject %q", status.Subject)
+			}
+
+			var current corev1.Secret
+			if err = c.Get(context.Background(), client.ObjectKeyFromObject(secret), &current); err != nil {
+				t.Fatalf("cannot retrieve the kubeconfig Secret: %s", err)
+			}
+
+			if _, ok := current.GetAnnotations()[utilities.RotateCertificateRequestAnnotation]; ok != tc.rotation {
+				t.Errorf("expected the rotation requested to be %t, got the annotations %v", tc.rotation, current.GetAnnotations())
+			}
+
+			expectTrigger(t, trigger)
+		})
+	}
+}
+
+func TestCertificateRevocationUnresolved(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec kamajiv1alpha1.CertificateRevocationSpec
+	}{
+		{
+			name: "invalid serial number",
+			spec: kamajiv1alpha1.CertificateRevocationSpec{SerialNumber: "not-hexadecimal"},
+		},
+		{
+			name: "missing kubeconfig Secret",
+			spec: kamajiv1alpha1.CertificateRevocationSpec{KubeconfigSecret: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "admin.conf",
+			}},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.spec.TenantControlPlane = "tenant-00"
+
+			c, trigger, err := reconcileRevocation(t, &kamajiv1alpha1.CertificateRevocation{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "revocation"},
+				Spec:       tc.spec,
+			})
+			if err == nil {
+				t.Fatal("expected the resolution to fail")
+			}
+
+			if status := getRevocation(t, c).Status; len(status.SerialNumber) > 0 || status.Error != err.Error() {
+				t.Errorf("expected the error to be reported, got %+v", status)
+			}
+
+			select {
+			case <-trigger:
+				t.Error("expected the Tenant Control Plane not to be triggered")
+			case <-time.After(100 * time.Millisecond):
+			}
+		})
+	}
+}
@@ -5,9 +5,14 @@ package controllers
 
 import (
 	"context"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/fields"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -24,6 +29,21 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/datastore/provisioner"
+)
+
+const (
+	// dataStoreProbeTimeout is the deadline of the connection probe to the DataStore endpoints.
+	dataStoreProbeTimeout = 10 * time.Second
+	// dataStoreProbeRetryPeriod is the delay before probing again a DataStore which cannot be reached.
+	dataStoreProbeRetryPeriod = 30 * time.Second
+	// dataStoreProbeResyncPeriod is the delay before probing again a reachable DataStore,
+	// detecting the endpoints going down, or the referenced credentials being rotated.
+	dataStoreProbeResyncPeriod = 5 * time.Minute
+	// dataStoreProvisioningPeriod is the delay before checking again the readiness of a DataStore backend
+	// requested from an external operator, whose resources are not watched since their API may be missing.
+	dataStoreProvisioningPeriod = 15 * time.Second
 )
 
 type DataStore struct {
@@ -32,10 +52,16 @@ type DataStore struct {
 	// if a Data Source is updated, we have to be sure that the reconciliation of the certificates content
 	// for each Tenant Control Plane is put in place properly.
 	TenantControlPlaneTrigger chan event.GenericEvent
+
+	probesMu sync.Mutex
+	// probes tracks the time of the last successful probe of each DataStore.
+	probes map[string]time.Time
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=druid.gardener.cloud,resources=etcds,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=aiven.io,resources=postgresqls;mysqls,verbs=get;list;watch;create;update;patch;delete
 
 func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	logger := log.FromContext(ctx)
@@ -45,6 +71,8 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		if k8serrors.IsNotFound(err) {
 			logger.Info("resource may have been deleted, skipping")
 
+			r.setLastProbe(request.Name, time.Time{})
+
 			return reconcile.Result{}, nil
 		}
 
@@ -59,6 +87,25 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		return reconcile.Result{}, nil
 	}
 
+	provisioned, provisioningMessage := true, ""
+
+	if ds.Spec.Provisioner != nil {
+		var provisionErr error
+		if provisioned, provisioningMessage, provisionErr = provisioner.Provision(ctx, r.Client, &ds); provisionErr != nil {
+			logger.Error(provisionErr, "cannot provision the DataStore backend")
+
+			return reconcile.Result{}, provisionErr
+		}
+	}
+	// The connection is probed once the backend is ready, since its endpoints are not reachable before.
+	var probeErr error
+
+	if provisioned {
+		if probeErr = r.probe(ctx, ds); probeErr != nil {
+			logger.Error(probeErr, "cannot connect to the DataStore")
+		}
+	}
+
 	var tcpList kamajiv1alpha1.TenantControlPlaneList
 
 	updateErr := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -73,7 +120,22 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 			tcpSets.Insert(getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String())
 		}
 
+		previous := ds.Status.DeepCopy()
+
 		ds.Status.UsedBy = tcpSets.List()
+		// The schema versions of the Tenant Control Planes no longer using the DataStore release their migration slots.
+		ds.Status.SchemaVersions = slices.DeleteFunc(ds.Status.SchemaVersions, func(version kamajiv1alpha1.DataStoreTenantSchemaVersion) bool {
+			return !tcpSets.Has(version.TenantControlPlane)
+		})
+		if provisioned {
+			ds.SetStandardConditions(probeErr)
+		} else {
+			ds.SetProvisioningConditions(provisioningMessage)
+		}
+		// Avoiding a status update when unchanged, since it is triggered by every Tenant Control Plane change.
+		if equality.Semantic.DeepEqual(previous, &ds.Status) {
+			return nil
+		}
 
 		if sErr := r.Client.Status().Update(ctx, &ds); sErr != nil {
 			return errors.Wrap(sErr, "cannot update the status for the given instance")
@@ -96,7 +158,71 @@ func (r *DataStore) Reconcile(ctx context.Context, request reconcile.Request) (r
 		go utils.TriggerChannel(ctx, r.TenantControlPlaneTrigger, shrunkTCP)
 	}
 
-	return reconcile.Result{}, nil
+	if !provisioned {
+		logger.Info("waiting for the DataStore backend to be provisioned", "reason", provisioningMessage)
+
+		return reconcile.Result{RequeueAfter: dataStoreProvisioningPeriod}, nil
+	}
+
+	if probeErr != nil {
+		return reconcile.Result{RequeueAfter: dataStoreProbeRetryPeriod}, nil
+	}
+
+	return reconcile.Result{RequeueAfter: dataStoreProbeResyncPeriod}, nil
+}
+
+// probe connects to the DataStore endpoints with the declared credentials, and TLS settings:
+// the connection is probed upon a generation change, until it succeeds, or periodically,
+// since the reconciliation is triggered by every Tenant Control Plane change.
+func (r *DataStore) probe(ctx context.Context, ds kamajiv1alpha1.DataStore) error {
+	upToDate := ds.Status.ObservedGeneration == ds.GetGeneration() && meta.IsStatusConditionTrue(ds.Status.Conditions, kamajiv1alpha1.ConditionReady)
+	if upToDate && time.Since(r.lastProbe(ds.GetName())) < dataStoreProbeResyncPeriod {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, dataStoreProbeTimeout)
+	defer cancel()
+
+	conn, err := datastore.NewStorageConnection(ctx, r.Client, ds)
+	if err != nil {
+		return errors.Wrap(err, "cannot create the DataStore connection")
+	}
+	defer conn.Close()
+
+	if err = conn.Check(ctx); err != nil {
+		r.setLastProbe(ds.GetName(), time.Time{})
+
+		return err
+	}
+
+	r.setLastProbe(ds.GetName(), time.Now())
+
+	return nil
+}
+
+func (r *DataStore) lastProbe(name string) time.Time {
+	r.probesMu.Lock()
+	defer r.probesMu.Unlock()
+
+	return r.probes[name]
+}
+
+// setLastProbe records the time of the last successful probe, removing it when zero.
+func (r *DataStore) setLastProbe(name string, probedAt time.Time) {
+	r.probesMu.Lock()
+	defer r.probesMu.Unlock()
+
+	if probedAt.IsZero() {
+		delete(r.probes, name)
+
+		return
+	}
+
+	if r.probes == nil {
+		r.probes = map[string]time.Time{}
+	}
+
+	r.probes[name] = probedAt
 }
 
 func (r *DataStore) SetupWithManager(mgr controllerruntime.Manager) error {
@@ -0,0 +1,239 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/datastore"
+	"github.com/clastix/kamaji/internal/logging"
+	ds "github.com/clastix/kamaji/internal/resources/datastore"
+)
+
+// DataStoreJanitor purges the retained DataStore contents of the deleted Tenant Control Planes,
+// once their retainOnDelete window is expired: the contents adopted again by a Tenant Control Plane are released, instead.
+// The DataStores generated for the dedicated etcd clusters are deleted along with their Tenant Control Plane.
+type DataStoreJanitor struct {
+	Client client.Client
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;delete
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores/status,verbs=get;update;patch
+
+func (r *DataStoreJanitor) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var dataStore kamajiv1alpha1.DataStore
+	if err := r.Client.Get(ctx, request.NamespacedName, &dataStore); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+
+	if utils.IsPaused(&dataStore) {
+		return reconcile.Result{}, nil
+	}
+
+	if uid, ok := dataStore.GetLabels()[constants.DedicatedDataStoreLabelKey]; ok {
+		if err := r.releaseDedicated(ctx, dataStore, uid); err != nil {
+			logger.Error(err, "cannot release the dedicated DataStore")
+
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{}, nil
+	}
+
+	if len(dataStore.Status.Retained) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	adopted, err := r.adoptedSchemas(ctx, dataStore)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the schemas in use")
+
+		return reconcile.Result{}, err
+	}
+
+	var connection datastore.Connection
+
+	defer func() {
+		if connection != nil {
+			_ = connection.Close()
+		}
+	}()
+
+	var released []kamajiv1alpha1.DataStoreRetainedTenant
+
+	for _, retained := range dataStore.Status.Retained {
+		if adopted.Has(retained.Schema) {
+			logger.Info("retained data adopted by a Tenant Control Plane, releasing", logging.TenantKey, retained.TenantControlPlane, "schema", retained.Schema)
+
+			released = append(released, retained)
+
+			continue
+		}
+
+		if time.Now().Before(retained.ExpiresAt.Time) {
+			continue
+		}
+
+		if connection == nil {
+			if connection, err = datastore.NewStorageConnection(ctx, r.Client, dataStore); err != nil {
+				logger.Error(err, "cannot generate the DataStore connection")
+
+				return reconcile.Result{}, err
+			}
+		}
+
+		if err = r.purge(ctx, connection, retained); err != nil {
+			logger.Error(err, "cannot purge the retained data", logging.TenantKey, retained.TenantControlPlane, "schema", retained.Schema)
+
+			return reconcile.Result{}, err
+		}
+
+		logger.Info("retained data has been purged", logging.TenantKey, retained.TenantControlPlane, "schema", retained.Schema)
+
+		released = append(released, retained)
+	}
+
+	if len(released) > 0 {
+		if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if gErr := r.Client.Get(ctx, request.NamespacedName, &dataStore); gErr != nil {
+				return gErr
+			}
+
+			dataStore.Status.Retained = slices.DeleteFunc(dataStore.Status.Retained, func(retained kamajiv1alpha1.DataStoreRetainedTenant) bool {
+				return slices.Contains(released, retained)
+			})
+
+			return r.Client.Status().Update(ctx, &dataStore)
+		}); err != nil {
+			logger.Error(err, "cannot update the DataStore status")
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	if next, ok := dataStore.Status.NextRetentionExpiry(); ok {
+		return reconcile.Result{RequeueAfter: max(time.Until(next), time.Second)}, nil
+	}
+
+	return reconcile.Result{}, nil
+}
+
+// releaseDedicated deletes the DataStore generated for a dedicated etcd cluster, once its Tenant Control Plane is gone:
+// the etcd cluster itself is garbage collected, since owned by the Tenant Control Plane.
+func (r *DataStoreJanitor) releaseDedicated(ctx context.Context, dataStore kamajiv1alpha1.DataStore, uid string) error {
+	name, err := cache.ParseObjectName(dataStore.GetAnnotations()[constants.TenantControlPlaneAnnotation])
+	if err != nil {
+		return errors.Wrap(err, "cannot parse the Tenant Control Plane name")
+	}
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+
+	switch err = r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: name.Namespace, Name: name.Name}, &tcp); {
+	case k8serrors.IsNotFound(err):
+		break
+	case err != nil:
+		return errors.Wrap(err, "cannot retrieve the Tenant Control Plane")
+	case string(tcp.GetUID()) == uid:
+		return nil
+	}
+
+	if err = r.Client.Delete(ctx, &dataStore); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, fmt.Sprintf("cannot delete the dedicated DataStore %s", dataStore.GetName()))
+	}
+
+	log.FromContext(ctx).Info("dedicated DataStore has been deleted", logging.TenantKey, name.String())
+
+	return nil
+}
+
+// adoptedSchemas returns the schemas of the Tenant Control Planes using the given DataStore.
+func (r *DataStoreJanitor) adoptedSchemas(ctx context.Context, dataStore kamajiv1alpha1.DataStore) (sets.Set[string], error) {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := r.Client.List(ctx, &tcpList, client.MatchingFieldsSelector{
+		Selector: fields.OneTermEqualSelector(kamajiv1alpha1.TenantControlPlaneUsedDataStoreKey, dataStore.GetName()),
+	}); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve list of the Tenant Control Plane using the following instance")
+	}
+
+	schemas := sets.New[string]()
+
+	for _, tcp := range tcpList.Items {
+		if tcp.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		schemas.Insert(tcp.Spec.DataStoreSchema, tcp.Status.Storage.Setup.Schema)
+	}
+
+	schemas.Delete("")
+
+	return schemas, nil
+}
+
+// purge wipes the retained data, and deletes the orphaned Secret holding the DataStore credentials.
+func (r *DataStoreJanitor) purge(ctx context.Context, connection datastore.Connection, retained kamajiv1alpha1.DataStoreRetainedTenant) error {
+	if err := ds.Wipe(ctx, connection, retained.Schema, retained.User); err != nil {
+		return err
+	}
+
+	if len(retained.ConfigSecretName) == 0 {
+		return nil
+	}
+
+	tcp, err := cache.ParseObjectName(retained.TenantControlPlane)
+	if err != nil {
+		return errors.Wrap(err, "cannot parse the Tenant Control Plane name")
+	}
+
+	var secret corev1.Secret
+	if err = r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tcp.Namespace, Name: retained.ConfigSecretName}, &secret); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	// The credentials Secret is owned again upon the undeletion of the Tenant Control Plane.
+	if len(secret.GetOwnerReferences()) > 0 {
+		return nil
+	}
+
+	if err = r.Client.Delete(ctx, &secret); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, fmt.Sprintf("cannot delete the DataStore Configuration secret %s", secret.GetName()))
+	}
+
+	return nil
+}
+
+func (r *DataStoreJanitor) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-janitor").
+		For(&kamajiv1alpha1.DataStore{}).
+		Complete(r)
+}
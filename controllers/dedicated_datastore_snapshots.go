@@ -0,0 +1,116 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	ds "github.com/clastix/kamaji/internal/resources/datastore"
+)
+
+// DedicatedDataStoreSnapshots takes the periodic snapshots of the dedicated etcd clusters,
+// each one saved by a Job into its own persistent volume claim, pruning the ones exceeding the retention.
+type DedicatedDataStoreSnapshots struct {
+	Client client.Client
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+func (r *DedicatedDataStoreSnapshots) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, request.NamespacedName, &tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+
+	if utils.IsPaused(&tcp) || tcp.GetDeletionTimestamp() != nil || !hasDedicatedSnapshots(&tcp) {
+		return reconcile.Result{}, nil
+	}
+	// The snapshots are taken once the cluster has been bootstrapped, reported by the status update triggering the reconciliation.
+	status := tcp.Status.Storage.Dedicated
+	if status == nil || len(status.Members) == 0 {
+		return reconcile.Result{}, nil
+	}
+
+	interval := tcp.Spec.DedicatedDataStore.Snapshots.Interval.Duration
+	if next := status.LastSnapshotTime.Add(interval); time.Now().Before(next) {
+		return reconcile.Result{RequeueAfter: time.Until(next)}, nil
+	}
+
+	now := time.Now()
+
+	name, err := ds.TakeDedicatedSnapshot(ctx, r.Client, &tcp, now)
+	if err != nil {
+		logger.Error(err, "cannot take the dedicated DataStore snapshot")
+
+		return reconcile.Result{}, err
+	}
+
+	logger.Info("dedicated DataStore snapshot has been started", "snapshot", name)
+
+	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if gErr := r.Client.Get(ctx, request.NamespacedName, &tcp); gErr != nil {
+			return gErr
+		}
+
+		if tcp.Status.Storage.Dedicated == nil {
+			tcp.Status.Storage.Dedicated = &kamajiv1alpha1.DedicatedDataStoreStatus{}
+		}
+
+		tcp.Status.Storage.Dedicated.LastSnapshot = name
+		tcp.Status.Storage.Dedicated.LastSnapshotTime = metav1.NewTime(now)
+
+		return r.Client.Status().Update(ctx, &tcp)
+	}); err != nil {
+		logger.Error(err, "cannot update the dedicated DataStore snapshot status")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = ds.PruneDedicatedSnapshots(ctx, r.Client, &tcp); err != nil {
+		logger.Error(err, "cannot prune the dedicated DataStore snapshots")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+func hasDedicatedSnapshots(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.DedicatedDataStore != nil && tcp.Spec.DedicatedDataStore.Snapshots != nil
+}
+
+func (r *DedicatedDataStoreSnapshots) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("dedicated-datastore-snapshots").
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return hasDedicatedSnapshots(object.(*kamajiv1alpha1.TenantControlPlane)) //nolint:forcetypeassert
+		}))).
+		Complete(r)
+}
@@ -0,0 +1,337 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// driftReportMaxDiffSize truncates the diff of each object, preventing to exceed the ConfigMap size limit.
+const driftReportMaxDiffSize = 16 * 1024
+
+var driftReportInvalidKeyChars = regexp.MustCompile(`[^-._a-zA-Z0-9]`)
+
+// DriftRenderer renders the objects of a Tenant Control Plane starting from the given live ones, without applying them.
+type DriftRenderer interface {
+	RenderObjects(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore, objects ...runtime.Object) ([]client.Object, error)
+}
+
+// DriftDetection periodically compares the objects of the Tenant Control Planes, including the tenant-side addon ones,
+// with the ones rendered by Kamaji: the outcome is reported by the Drifted condition, and the detailed diff is stored
+// in the <name>-drift-report ConfigMap. The drifted objects are not reconciled.
+type DriftDetection struct {
+	Client   client.Client
+	Renderer DriftRenderer
+	Recorder record.EventRecorder
+	Interval time.Duration
+}
+
+func (r *DriftDetection) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, request.NamespacedName, &tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+	// The drift is meaningful only for the provisioned Tenant Control Planes,
+	// the other ones are still reconciled, or have been put to sleep.
+	if tcp.GetDeletionTimestamp() != nil || ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning) != kamajiv1alpha1.VersionReady {
+		return reconcile.Result{RequeueAfter: r.Interval}, nil
+	}
+
+	managementReport, err := r.detectManagementDrift(ctx, &tcp)
+	if err != nil {
+		logger.Error(err, "cannot detect the drift of the Tenant Control Plane objects")
+
+		return reconcile.Result{}, err
+	}
+
+	tenantReport := &utilities.DriftReport{}
+
+	for _, resource := range r.addonResources() {
+		if err = resource.Define(ctx, &tcp); err != nil {
+			return reconcile.Result{}, errors.Wrap(err, fmt.Sprintf("cannot define the %s addon", resource.GetName()))
+		}
+
+		if resource.ShouldCleanup(&tcp) {
+			continue
+		}
+
+		if _, err = resource.CreateOrUpdate(utilities.WithDriftReport(ctx, tenantReport), tcp.DeepCopy()); err != nil {
+			logger.Error(err, "cannot detect the drift of the addon", "addon", resource.GetName())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	data := map[string]string{}
+
+	for scope, report := range map[string]*utilities.DriftReport{"management": managementReport, "tenant": tenantReport} {
+		for _, entry := range report.Entries() {
+			data[driftReportKey(scope, entry)] = driftReportValue(entry)
+		}
+	}
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix("drift-report", &tcp),
+			Namespace: tcp.GetNamespace(),
+		},
+	}
+
+	if _, err = utilities.CreateOrUpdateWithConflict(ctx, r.Client, configMap, func() error {
+		configMap.Data = data
+
+		return controllerutil.SetControllerReference(&tcp, configMap, r.Client.Scheme())
+	}); err != nil {
+		logger.Error(err, "cannot store the drift report")
+
+		return reconcile.Result{}, err
+	}
+
+	if err = r.setDriftedCondition(ctx, &tcp, len(data), configMap.GetName()); err != nil {
+		logger.Error(err, "cannot update the Drifted condition")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: r.Interval}, nil
+}
+
+// detectManagementDrift renders the objects of the Tenant Control Plane starting from the live ones it owns,
+// reporting the ones differing from the rendered version, along with the missing ones.
+func (r *DriftDetection) detectManagementDrift(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (*utilities.DriftReport, error) {
+	var ds kamajiv1alpha1.DataStore
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, &ds); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the DataStore")
+	}
+
+	live := map[string]client.Object{}
+
+	var objects []runtime.Object
+
+	for _, list := range []client.ObjectList{&corev1.ServiceList{}, &corev1.ConfigMapList{}, &corev1.SecretList{}, &appsv1.DeploymentList{}, &networkingv1.IngressList{}} {
+		if err := r.Client.List(ctx, list, client.InNamespace(tcp.GetNamespace())); err != nil {
+			return nil, errors.Wrap(err, "cannot list the Tenant Control Plane objects")
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, item := range items {
+			obj := item.(client.Object) //nolint:forcetypeassert
+			if !metav1.IsControlledBy(obj, tcp) {
+				continue
+			}
+
+			key, err := r.objectKey(obj)
+			if err != nil {
+				return nil, err
+			}
+
+			live[key] = obj
+			objects = append(objects, obj.DeepCopyObject())
+		}
+	}
+
+	rendered, err := r.Renderer.RenderObjects(ctx, tcp, ds, objects...)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot render the Tenant Control Plane objects")
+	}
+
+	report := &utilities.DriftReport{}
+
+	for _, obj := range rendered {
+		if !metav1.IsControlledBy(obj, tcp) {
+			continue
+		}
+
+		key, err := r.objectKey(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		gvk, _ := apiutil.GVKForObject(obj, r.Client.Scheme())
+
+		current, ok := live[key]
+		if !ok {
+			report.Add(utilities.DriftEntry{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Missing: true})
+
+			continue
+		}
+
+		currentContent, err := r.toUnstructured(current)
+		if err != nil {
+			return nil, err
+		}
+
+		renderedContent, err := r.toUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+
+		if diff := utilities.DriftDiff(currentContent, renderedContent); len(diff) > 0 {
+			report.Add(utilities.DriftEntry{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Diff: diff})
+		}
+	}
+
+	return report, nil
+}
+
+func (r *DriftDetection) objectKey(obj client.Object) (string, error) {
+	gvk, err := apiutil.GVKForObject(obj, r.Client.Scheme())
+	if err != nil {
+		return "", err
+	}
+
+	return gvk.Kind + "/" + obj.GetName(), nil
+}
+
+// toUnstructured converts the given object, replacing the Secret values with their checksum:
+// the report must not disclose them, but the changed keys are still reported.
+func (r *DriftDetection) toUnstructured(obj client.Object) (*unstructured.Unstructured, error) {
+	obj = obj.DeepCopyObject().(client.Object) //nolint:forcetypeassert
+
+	if secret, ok := obj.(*corev1.Secret); ok {
+		for key, value := range secret.Data {
+			secret.Data[key] = fmt.Appendf(nil, "sha256:%x", sha256.Sum256(value))
+		}
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot convert object to unstructured")
+	}
+
+	u := &unstructured.Unstructured{Object: content}
+	unstructured.RemoveNestedField(u.Object, "apiVersion")
+	unstructured.RemoveNestedField(u.Object, "kind")
+
+	return u, nil
+}
+
+// addonResources returns the tenant-side addons applied with the server-side apply strategy,
+// which is performing dry-run requests only with the drift report.
+func (r *DriftDetection) addonResources() []resources.Resource {
+	return []resources.Resource{
+		&addons.CoreDNS{Client: r.Client},
+		&addons.KubeProxy{Client: r.Client},
+		&addons.FrontProxy{Client: r.Client},
+		&konnectivity.ServiceAccountResource{Client: r.Client},
+		&konnectivity.ClusterRoleBindingResource{Client: r.Client},
+	}
+}
+
+func (r *DriftDetection) setDriftedCondition(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, drifted int, configMapName string) error {
+	condition := metav1.Condition{
+		Type:    kamajiv1alpha1.ConditionDrifted,
+		Status:  metav1.ConditionFalse,
+		Reason:  kamajiv1alpha1.ReasonInSync,
+		Message: "the objects are matching the rendered ones",
+	}
+
+	if drifted > 0 {
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = kamajiv1alpha1.ReasonDriftDetected
+		condition.Message = fmt.Sprintf("%d objects are differing from the rendered ones, the diff is stored in the ConfigMap %s", drifted, configMapName)
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = r.Client.Get(ctx, client.ObjectKeyFromObject(tcp), tcp)
+			}
+		}()
+
+		condition.ObservedGeneration = tcp.GetGeneration()
+
+		if !meta.SetStatusCondition(&tcp.Status.Conditions, condition) {
+			return nil
+		}
+
+		if err = r.Client.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		if r.Recorder != nil && drifted > 0 {
+			r.Recorder.Event(tcp, corev1.EventTypeWarning, kamajiv1alpha1.ReasonDriftDetected, condition.Message)
+		}
+
+		return nil
+	})
+}
+
+// driftReportKey returns the ConfigMap key of the drifted object, such as tenant.deployment.kube-system.coredns.
+func driftReportKey(scope string, entry utilities.DriftEntry) string {
+	elements := []string{scope, strings.ToLower(entry.Kind)}
+	if len(entry.Namespace) > 0 {
+		elements = append(elements, entry.Namespace)
+	}
+
+	elements = append(elements, entry.Name)
+
+	return driftReportInvalidKeyChars.ReplaceAllString(strings.Join(elements, "."), "_")
+}
+
+func driftReportValue(entry utilities.DriftEntry) string {
+	if entry.Missing {
+		return "the object is missing\n"
+	}
+
+	if len(entry.Diff) > driftReportMaxDiffSize {
+		return entry.Diff[:driftReportMaxDiffSize] + "\n... truncated\n"
+	}
+
+	return entry.Diff
+}
+
+func (r *DriftDetection) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("driftdetection").
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Complete(r)
+}
@@ -0,0 +1,112 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/policies"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KamajiPolicy renders each KamajiPolicy into a ValidatingAdmissionPolicy, along with its binding,
+// owned by the KamajiPolicy and thus garbage collected upon its deletion.
+type KamajiPolicy struct {
+	Client client.Client
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=kamajipolicies,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=kamajipolicies/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingadmissionpolicies;validatingadmissionpolicybindings,verbs=get;list;watch;create;update;patch;delete
+
+func (r *KamajiPolicy) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var policy kamajiv1alpha1.KamajiPolicy
+	if err := r.Client.Get(ctx, request.NamespacedName, &policy); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+
+	labels := map[string]string{
+		constants.ProjectNameLabelKey: constants.ProjectNameLabelValue,
+	}
+
+	vap := &admissionregistrationv1.ValidatingAdmissionPolicy{}
+	vap.SetName(policies.Name(&policy))
+
+	if _, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, vap, func() error {
+		vap.SetLabels(utilities.MergeMaps(vap.GetLabels(), labels))
+		vap.Spec = policies.ValidatingAdmissionPolicySpec(&policy)
+
+		return controllerutil.SetControllerReference(&policy, vap, r.Client.Scheme())
+	}); err != nil {
+		logger.Error(err, "cannot create or update the ValidatingAdmissionPolicy")
+
+		return reconcile.Result{}, err
+	}
+
+	binding := &admissionregistrationv1.ValidatingAdmissionPolicyBinding{}
+	binding.SetName(policies.Name(&policy))
+
+	if _, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, binding, func() error {
+		binding.SetLabels(utilities.MergeMaps(binding.GetLabels(), labels))
+		binding.Spec = policies.ValidatingAdmissionPolicyBindingSpec(&policy)
+
+		return controllerutil.SetControllerReference(&policy, binding, r.Client.Scheme())
+	}); err != nil {
+		logger.Error(err, "cannot create or update the ValidatingAdmissionPolicyBinding")
+
+		return reconcile.Result{}, err
+	}
+
+	if policy.Status.ValidatingAdmissionPolicy == vap.GetName() {
+		return reconcile.Result{}, nil
+	}
+
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if gErr := r.Client.Get(ctx, request.NamespacedName, &policy); gErr != nil {
+			return gErr
+		}
+
+		policy.Status.ValidatingAdmissionPolicy = vap.GetName()
+
+		return r.Client.Status().Update(ctx, &policy)
+	}); err != nil {
+		logger.Error(err, "cannot update KamajiPolicy status")
+
+		return reconcile.Result{}, errors.Wrap(err, "cannot update the status for the given instance")
+	}
+
+	return reconcile.Result{}, nil
+}
+
+func (r *KamajiPolicy) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.KamajiPolicy{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Owns(&admissionregistrationv1.ValidatingAdmissionPolicy{}).
+		Owns(&admissionregistrationv1.ValidatingAdmissionPolicyBinding{}).
+		Complete(r)
+}
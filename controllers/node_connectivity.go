@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/resources/wireguard"
+)
+
+// NodeConnectivityProvider connects the Tenant Control Plane to the worker nodes, when they're in a different network:
+// the resources of each provider are always part of the pipeline, and they're cleaned up once the addon is disabled.
+type NodeConnectivityProvider interface {
+	// RequirementsResources are reconciled before the Tenant Control Plane Deployment.
+	RequirementsResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig) []resources.Resource
+	// PatchResources are patching the Tenant Control Plane Deployment, and Service.
+	PatchResources(c client.Client) []resources.Resource
+	// ExternalResources are reconciled in the Tenant Cluster by the soot controllers.
+	ExternalResources(c client.Client) []resources.Resource
+}
+
+var nodeConnectivityProviders = []NodeConnectivityProvider{
+	konnectivityProvider{},
+	wireGuardProvider{},
+}
+
+type konnectivityProvider struct{}
+
+// RequirementsResources returns the Konnectivity server requirements:
+// the kubeconfig is referencing the certificate, thus it can't be generated concurrently.
+func (konnectivityProvider) RequirementsResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig) []resources.Resource {
+	return []resources.Resource{
+		&resources.Group{
+			Name:        "konnectivity-requirements",
+			Concurrency: tcpReconcilerConfig.ResourcesConcurrency,
+			Resources: []resources.Resource{
+				&konnectivity.EgressSelectorConfigurationResource{Client: c},
+				&konnectivity.CertificateResource{Client: c},
+			},
+		},
+		&konnectivity.KubeconfigResource{Client: c},
+	}
+}
+
+// PatchResources returns the dedicated Konnectivity server resources after the Tenant Control Plane Service patch,
+// which releases the node port of the agents upon switching to the Deployment placement.
+func (konnectivityProvider) PatchResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&konnectivity.KubernetesDeploymentResource{Builder: builder.Konnectivity{Scheme: *c.Scheme()}, Client: c},
+		&konnectivity.ServiceResource{Client: c},
+		&konnectivity.ServerServiceResource{Client: c},
+		&konnectivity.ServerCertificateResource{Client: c},
+		&konnectivity.ServerDeploymentResource{Builder: builder.Konnectivity{Scheme: *c.Scheme()}, Client: c},
+	}
+}
+
+func (konnectivityProvider) ExternalResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&konnectivity.Agent{Client: c},
+		&konnectivity.ServiceAccountResource{Client: c},
+		&konnectivity.ClusterRoleBindingResource{Client: c},
+	}
+}
+
+type wireGuardProvider struct{}
+
+func (wireGuardProvider) RequirementsResources(c client.Client, _ TenantControlPlaneReconcilerConfig) []resources.Resource {
+	return []resources.Resource{
+		&wireguard.SecretResource{Client: c},
+	}
+}
+
+// PatchResources returns the Service first: the Deployment one is marking the addon as disabled upon the clean-up.
+func (wireGuardProvider) PatchResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&wireguard.ServiceResource{Client: c},
+		&wireguard.KubernetesDeploymentResource{Builder: builder.WireGuard{Scheme: *c.Scheme()}, Client: c},
+	}
+}
+
+// ExternalResources returns the peers first, since the agents are mounting their Secret.
+func (wireGuardProvider) ExternalResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&wireguard.Peers{Client: c},
+		&wireguard.Agent{Client: c},
+	}
+}
+
+func getNodeConnectivityRequirementsResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig) []resources.Resource {
+	var res []resources.Resource
+
+	for _, provider := range nodeConnectivityProviders {
+		res = append(res, provider.RequirementsResources(c, tcpReconcilerConfig)...)
+	}
+
+	return res
+}
+
+func getNodeConnectivityPatchResources(c client.Client) []resources.Resource {
+	var res []resources.Resource
+
+	for _, provider := range nodeConnectivityProviders {
+		res = append(res, provider.PatchResources(c)...)
+	}
+
+	return res
+}
+
+func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
+	return konnectivityProvider{}.ExternalResources(c)
+}
+
+func GetExternalWireGuardResources(c client.Client) []resources.Resource {
+	return wireGuardProvider{}.ExternalResources(c)
+}
@@ -0,0 +1,213 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// orphansGracePeriod prevents the collection of the objects created by an in-flight reconciliation,
+// since the Tenant Control Plane status is referencing them only once updated.
+const orphansGracePeriod = 10 * time.Minute
+
+var (
+	orphansFoundCollector = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "orphans",
+		Name:      "found",
+		Help:      "Kamaji-owned objects no longer referenced by their TenantControlPlane, found by the last collection.",
+	}, []string{"kind"})
+	orphansPrunedCollector = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "orphans",
+		Name:      "pruned_total",
+		Help:      "Kamaji-owned objects no longer referenced by their TenantControlPlane, deleted by the collector.",
+	}, []string{"kind"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(orphansFoundCollector, orphansPrunedCollector)
+}
+
+// OrphansCollector prunes the Kamaji-owned objects no longer referenced by their TenantControlPlane,
+// such as the ones left behind upon a change of the exposure mode: an object is referenced when its name
+// is reported in the TenantControlPlane status. The objects of the deleted TenantControlPlane objects
+// are left to the Kubernetes garbage collector, as well as the ones of the paused, or not ready, ones.
+type OrphansCollector struct {
+	Client   client.Client
+	Interval time.Duration
+	// DryRun reports the orphaned objects, without deleting them.
+	DryRun bool
+}
+
+func (o *OrphansCollector) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphans_collector")
+
+	ticker := time.NewTicker(o.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := o.collect(log.IntoContext(ctx, logger)); err != nil {
+				logger.Error(err, "cannot collect the orphaned objects")
+			}
+		}
+	}
+}
+
+func (o *OrphansCollector) collect(ctx context.Context) error {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := o.Client.List(ctx, &tcpList); err != nil {
+		return errors.Wrap(err, "cannot list TenantControlPlane objects")
+	}
+
+	tcps := make(map[types.UID]*kamajiv1alpha1.TenantControlPlane, len(tcpList.Items))
+	for i := range tcpList.Items {
+		tcps[tcpList.Items[i].GetUID()] = &tcpList.Items[i]
+	}
+
+	for kind, list := range map[string]client.ObjectList{
+		"Secret":     &corev1.SecretList{},
+		"Service":    &corev1.ServiceList{},
+		"Deployment": &appsv1.DeploymentList{},
+	} {
+		if err := o.Client.List(ctx, list, client.HasLabels{constants.ControlPlaneLabelKey}, client.MatchingLabels{constants.ProjectNameLabelKey: constants.ProjectNameLabelValue}); err != nil {
+			return errors.Wrapf(err, "cannot list %s objects", kind)
+		}
+
+		var found int
+
+		objects, err := meta.ExtractList(list)
+		if err != nil {
+			return errors.Wrapf(err, "cannot extract %s objects", kind)
+		}
+
+		for _, item := range objects {
+			object, ok := item.(client.Object)
+			if !ok {
+				continue
+			}
+
+			tcp := owningTenantControlPlane(object, tcps)
+			if tcp == nil || !isOrphan(object, tcp) {
+				continue
+			}
+
+			found++
+
+			logger := log.FromContext(ctx).WithValues("kind", kind, "namespace", object.GetNamespace(), "name", object.GetName(), logging.TenantKey, client.ObjectKeyFromObject(tcp).String())
+
+			if o.DryRun {
+				logger.Info("orphaned object found, skipping deletion due to dry-run")
+
+				continue
+			}
+
+			if err = o.Client.Delete(ctx, object, client.Preconditions{UID: ptr.To(object.GetUID()), ResourceVersion: ptr.To(object.GetResourceVersion())}); err != nil && !k8serrors.IsNotFound(err) {
+				logger.Error(err, "cannot delete the orphaned object")
+
+				continue
+			}
+
+			logger.Info("orphaned object deleted")
+
+			orphansPrunedCollector.WithLabelValues(kind).Inc()
+		}
+
+		orphansFoundCollector.WithLabelValues(kind).Set(float64(found))
+	}
+
+	return nil
+}
+
+// owningTenantControlPlane returns the TenantControlPlane controlling the given object,
+// nil if it doesn't exist, or if it's not eligible for the collection.
+func owningTenantControlPlane(object client.Object, tcps map[types.UID]*kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.TenantControlPlane {
+	owner := metav1.GetControllerOf(object)
+	if owner == nil || owner.Kind != "TenantControlPlane" || owner.APIVersion != kamajiv1alpha1.GroupVersion.String() {
+		return nil
+	}
+
+	tcp, ok := tcps[owner.UID]
+	if !ok || tcp.GetDeletionTimestamp() != nil || utils.IsPaused(tcp) || tcp.Status.Phase != kamajiv1alpha1.PhaseReady {
+		return nil
+	}
+
+	return tcp
+}
+
+func isOrphan(object client.Object, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	if time.Since(object.GetCreationTimestamp().Time) < orphansGracePeriod {
+		return false
+	}
+
+	references := sets.New(tcp.GetName(), utilities.ObjectName(tcp))
+	collectStatusReferences(reflect.ValueOf(tcp.Status), references)
+	// The component Deployments of the Split topology are not referenced by the status.
+	if tcp.HasSplitTopology() {
+		for _, component := range builder.SplitComponents {
+			references.Insert(builder.ComponentName(tcp, component))
+		}
+	}
+	// Neither is the Konnectivity server Deployment, sharing the name of its Service which could be still pending.
+	if konnectivity := tcp.Spec.Addons.Konnectivity; konnectivity != nil && konnectivity.HasDeploymentPlacement() {
+		references.Insert(builder.KonnectivityServerName(tcp))
+	}
+
+	return !references.Has(object.GetName())
+}
+
+// collectStatusReferences walks the TenantControlPlane status, collecting the string values:
+// the names of the referenced objects are part of them.
+func collectStatusReferences(value reflect.Value, references sets.Set[string]) {
+	switch value.Kind() { //nolint:exhaustive
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			collectStatusReferences(value.Elem(), references)
+		}
+	case reflect.Struct:
+		for i := range value.NumField() {
+			if value.Type().Field(i).IsExported() {
+				collectStatusReferences(value.Field(i), references)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range value.Len() {
+			collectStatusReferences(value.Index(i), references)
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			collectStatusReferences(iter.Value(), references)
+		}
+	case reflect.String:
+		references.Insert(value.String())
+	}
+}
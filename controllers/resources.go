@@ -5,10 +5,12 @@ package controllers
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/google/uuid"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -18,11 +20,12 @@ import (
 	"github.com/clastix/kamaji/internal/datastore"
 	"github.com/clastix/kamaji/internal/resources"
 	ds "github.com/clastix/kamaji/internal/resources/datastore"
-	"github.com/clastix/kamaji/internal/resources/konnectivity"
 )
 
 type GroupResourceBuilderConfiguration struct {
 	client               client.Client
+	workloadClient       client.Client
+	recorder             record.EventRecorder
 	log                  logr.Logger
 	tcpReconcilerConfig  TenantControlPlaneReconcilerConfig
 	tenantControlPlane   kamajiv1alpha1.TenantControlPlane
@@ -57,6 +60,15 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 	var res []resources.DeletableResource
 
 	if controllerutil.ContainsFinalizer(tcp, finalizers.DatastoreFinalizer) {
+		// The Secrets must be orphaned before removing the finalizer, letting the garbage collector delete the other objects.
+		if tcp.GetDeletionPolicy() == kamajiv1alpha1.DeletionPolicyOrphan {
+			res = append(res, &resources.OrphanSecrets{Client: config.client})
+		}
+
+		if tcp.Spec.DedicatedDataStore != nil && tcp.GetDeletionPolicy() == kamajiv1alpha1.DeletionPolicyDelete {
+			res = append(res, &ds.Dedicated{Client: config.client})
+		}
+
 		res = append(res, &ds.Setup{
 			Client:     config.client,
 			Connection: config.connection,
@@ -66,28 +78,104 @@ func GetDeletableResources(tcp *kamajiv1alpha1.TenantControlPlane, config GroupD
 			ConnString: config.connection.GetConnectionString(),
 			DataStore:  config.dataStore,
 		})
+		// The workloads in the target cluster are not garbage collected along with the Tenant Control Plane.
+		if tcp.TargetCluster() != nil {
+			res = append(res, &resources.TargetClusterWorkloads{Client: config.client})
+		}
 	}
 
 	return res
 }
 
+// getDedicatedDataStoreResource returns the resource provisioning the dedicated etcd cluster,
+// handled before retrieving the DataStore of the Tenant Control Plane.
+func getDedicatedDataStoreResource(c client.Client) resources.Resource {
+	return &ds.Dedicated{Client: c}
+}
+
+// GetRenderableResources returns the list of resources required to render the objects of a tenant control plane
+// without applying them: the resources requiring a live connection to the DataStore, or dealing with
+// migrations and upgrades, are skipped since they have side effects outside the given client.
+func GetRenderableResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
+	resources := getKubernetesServiceResources(c, nil)
+	resources = append(resources, getKubeadmConfigResources(c, getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane), dataStore)...)
+	resources = append(resources, getKubernetesCertificatesResources(c, tcpReconcilerConfig, tenantControlPlane)...)
+	resources = append(resources, getKubeconfigResources(c, tcpReconcilerConfig, tenantControlPlane)...)
+	resources = append(resources, &ds.Config{Client: c, DataStore: dataStore}, &ds.Certificate{Client: c, DataStore: dataStore})
+	resources = append(resources, getNodeConnectivityRequirementsResources(c, tcpReconcilerConfig)...)
+	resources = append(resources, getKubernetesDeploymentResources(c, tcpReconcilerConfig, dataStore)...)
+	resources = append(resources, getNodeConnectivityPatchResources(c)...)
+	resources = append(resources, getKubernetesIngressResources(c)...)
+
+	return resources
+}
+
+// GetProvisioningResources returns the list of resources provisioning a tenant control plane against the given DataStore connection:
+// like the renderable ones, the resources dealing with migrations, upgrades, and the external systems, are skipped.
+// It's used to benchmark the provisioning, since it's the same pipeline run by the controller.
+func GetProvisioningResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane, dataStore kamajiv1alpha1.DataStore, connection datastore.Connection) []resources.Resource {
+	resources := getKubernetesServiceResources(c, nil)
+	resources = append(resources, getKubeadmConfigResources(c, getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane), dataStore)...)
+	resources = append(resources, getKubernetesCertificatesResources(c, tcpReconcilerConfig, tenantControlPlane)...)
+	resources = append(resources, getKubeconfigResources(c, tcpReconcilerConfig, tenantControlPlane)...)
+	resources = append(resources, getKubernetesStorageResources(c, connection, dataStore)...)
+	resources = append(resources, getNodeConnectivityRequirementsResources(c, tcpReconcilerConfig)...)
+	resources = append(resources, getKubernetesDeploymentResources(c, tcpReconcilerConfig, dataStore)...)
+	resources = append(resources, getNodeConnectivityPatchResources(c)...)
+	resources = append(resources, getKubernetesIngressResources(c)...)
+
+	return resources
+}
+
 func getDefaultResources(config GroupResourceBuilderConfiguration) []resources.Resource {
-	resources := getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService)
+	resources := getTenantNamespaceResources(config.client)
+	resources = append(resources, getDataStoreMigratingResources(config.client, config.KamajiNamespace, config.KamajiMigrateImage, config.KamajiServiceAccount, config.KamajiService, config.tcpReconcilerConfig.MigrateWatchdogLeaseDuration)...)
+	resources = append(resources, getMaintenanceResources(config.client)...)
 	resources = append(resources, getUpgradeResources(config.client)...)
-	resources = append(resources, getKubernetesServiceResources(config.client)...)
+	resources = append(resources, getHostNetworkResources(config.workloadClient)...)
+	resources = append(resources, getKubernetesServiceResources(config.workloadClient, config.recorder)...)
 	resources = append(resources, getKubeadmConfigResources(config.client, getTmpDirectory(config.tcpReconcilerConfig.TmpBaseDirectory, config.tenantControlPlane), config.DataStore)...)
 	resources = append(resources, getKubernetesCertificatesResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
 	resources = append(resources, getKubeconfigResources(config.client, config.tcpReconcilerConfig, config.tenantControlPlane)...)
+	resources = append(resources, getCertificateRevocationListResources(config.client)...)
 	resources = append(resources, getKubernetesStorageResources(config.client, config.Connection, config.DataStore)...)
-	resources = append(resources, getKonnectivityServerRequirementsResources(config.client)...)
-	resources = append(resources, getKubernetesDeploymentResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
-	resources = append(resources, getKonnectivityServerPatchResources(config.client)...)
+	resources = append(resources, getNodeConnectivityRequirementsResources(config.client, config.tcpReconcilerConfig)...)
+	resources = append(resources, getImagesResources(config.client, config.tcpReconcilerConfig, config.DataStore)...)
+	resources = append(resources, getKubernetesDeploymentResources(config.workloadClient, config.tcpReconcilerConfig, config.DataStore)...)
+	resources = append(resources, getNodeConnectivityPatchResources(config.workloadClient)...)
 	resources = append(resources, getDataStoreMigratingCleanup(config.client, config.KamajiNamespace)...)
-	resources = append(resources, getKubernetesIngressResources(config.client)...)
+	resources = append(resources, getKubernetesIngressResources(config.workloadClient)...)
+	resources = append(resources, getSecretsBackendResources(config.client)...)
+	resources = append(resources, getRevisionsResources(config.client, config.tcpReconcilerConfig)...)
 
 	return resources
 }
 
+func getCertificateRevocationListResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.CertificateRevocationListResource{
+			Client: c,
+		},
+	}
+}
+
+func getRevisionsResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig) []resources.Resource {
+	return []resources.Resource{
+		&resources.TenantControlPlaneRevisionsResource{
+			Client:       c,
+			HistoryLimit: tcpReconcilerConfig.RevisionHistoryLimit,
+		},
+	}
+}
+
+func getTenantNamespaceResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.TenantNamespace{
+			Client: c,
+		},
+	}
+}
+
 func getDataStoreMigratingCleanup(c client.Client, kamajiNamespace string) []resources.Resource {
 	return []resources.Resource{
 		&ds.Migrate{
@@ -98,14 +186,23 @@ func getDataStoreMigratingCleanup(c client.Client, kamajiNamespace string) []res
 	}
 }
 
-func getDataStoreMigratingResources(c client.Client, kamajiNamespace, migrateImage string, kamajiServiceAccount, kamajiService string) []resources.Resource {
+func getDataStoreMigratingResources(c client.Client, kamajiNamespace, migrateImage string, kamajiServiceAccount, kamajiService string, watchdogLeaseDuration time.Duration) []resources.Resource {
 	return []resources.Resource{
 		&ds.Migrate{
-			Client:               c,
-			MigrateImage:         migrateImage,
-			KamajiNamespace:      kamajiNamespace,
-			KamajiServiceAccount: kamajiServiceAccount,
-			KamajiServiceName:    kamajiService,
+			Client:                c,
+			MigrateImage:          migrateImage,
+			KamajiNamespace:       kamajiNamespace,
+			KamajiServiceAccount:  kamajiServiceAccount,
+			KamajiServiceName:     kamajiService,
+			WatchdogLeaseDuration: watchdogLeaseDuration,
+		},
+	}
+}
+
+func getMaintenanceResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.Maintenance{
+			Client: c,
 		},
 	}
 }
@@ -118,9 +215,26 @@ func getUpgradeResources(c client.Client) []resources.Resource {
 	}
 }
 
-func getKubernetesServiceResources(c client.Client) []resources.Resource {
+func getHostNetworkResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.HostNetworkResource{
+			Client: c,
+		},
+	}
+}
+
+func getKubernetesServiceResources(c client.Client, recorder record.EventRecorder) []resources.Resource {
 	return []resources.Resource{
 		&resources.KubernetesServiceResource{
+			Client:   c,
+			Recorder: recorder,
+		},
+	}
+}
+
+func getSecretsBackendResources(c client.Client) []resources.Resource {
+	return []resources.Resource{
+		&resources.SecretsBackendResource{
 			Client: c,
 		},
 	}
@@ -145,60 +259,89 @@ func getKubeadmConfigResources(c client.Client, tmpDirectory string, dataStore k
 	}
 }
 
+// getKubernetesCertificatesResources groups the certificates which can be generated concurrently:
+// the CAs, and the Service Account key pair, must be available before signing the leaf certificates.
 func getKubernetesCertificatesResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
 	return []resources.Resource{
-		&resources.CACertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+		&resources.Group{
+			Name:        "certificate-authorities",
+			Concurrency: tcpReconcilerConfig.ResourcesConcurrency,
+			Resources: []resources.Resource{
+				&resources.CACertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.FrontProxyCACertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.SACertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+			},
 		},
-		&resources.FrontProxyCACertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.SACertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.APIServerCertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.APIServerKubeletClientCertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.FrontProxyClientCertificate{
-			Client:       c,
-			TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+		&resources.Group{
+			Name:        "certificates",
+			Concurrency: tcpReconcilerConfig.ResourcesConcurrency,
+			Resources: []resources.Resource{
+				&resources.APIServerCertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.APIServerKubeletClientCertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.FrontProxyClientCertificate{
+					Client:       c,
+					TmpDirectory: getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+			},
 		},
 	}
 }
 
 func getKubeconfigResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, tenantControlPlane kamajiv1alpha1.TenantControlPlane) []resources.Resource {
 	return []resources.Resource{
-		&resources.KubeconfigResource{
-			Name:               "admin-kubeconfig",
-			Client:             c,
-			KubeConfigFileName: resources.AdminKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.KubeconfigResource{
-			Name:               "admin-kubeconfig",
-			Client:             c,
-			KubeConfigFileName: resources.SuperAdminKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.KubeconfigResource{
-			Name:               "controller-manager-kubeconfig",
-			Client:             c,
-			KubeConfigFileName: resources.ControllerManagerKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
-		},
-		&resources.KubeconfigResource{
-			Name:               "scheduler-kubeconfig",
-			Client:             c,
-			KubeConfigFileName: resources.SchedulerKubeConfigFileName,
-			TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+		&resources.Group{
+			Name:        "kubeconfigs",
+			Concurrency: tcpReconcilerConfig.ResourcesConcurrency,
+			Resources: []resources.Resource{
+				&resources.KubeconfigResource{
+					Name:               "admin-kubeconfig",
+					Client:             c,
+					KubeConfigFileName: resources.AdminKubeConfigFileName,
+					TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.KubeconfigResource{
+					Name:               "admin-kubeconfig",
+					Client:             c,
+					KubeConfigFileName: resources.SuperAdminKubeConfigFileName,
+					TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.KubeconfigResource{
+					Name:               "controller-manager-kubeconfig",
+					Client:             c,
+					KubeConfigFileName: resources.ControllerManagerKubeConfigFileName,
+					TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.KubeconfigResource{
+					Name:               "scheduler-kubeconfig",
+					Client:             c,
+					KubeConfigFileName: resources.SchedulerKubeConfigFileName,
+					TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+				},
+				&resources.SootKubeconfigResource{
+					KubeconfigResource: resources.KubeconfigResource{
+						Name:               "soot-kubeconfig",
+						Client:             c,
+						KubeConfigFileName: resources.SootKubeConfigFileName,
+						TmpDirectory:       getTmpDirectory(tcpReconcilerConfig.TmpBaseDirectory, tenantControlPlane),
+					},
+					Enabled: tcpReconcilerConfig.SootLeastPrivilege,
+				},
+			},
 		},
 	}
 }
@@ -225,44 +368,63 @@ func getKubernetesStorageResources(c client.Client, dbConnection datastore.Conne
 	}
 }
 
-func getKubernetesDeploymentResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
+// getImagesResources resolves, and verifies, the component images before rolling out the Deployment:
+// it's not part of the renderable resources since it requires reaching the registries.
+// The FIPS compliance of the selected images is reported along with them.
+func getImagesResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
 	return []resources.Resource{
-		&resources.KubernetesDeploymentResource{
+		&resources.ImagesResource{
 			Client:             c,
 			DataStore:          dataStore,
 			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
 		},
+		&resources.FIPSComplianceResource{
+			Client:    c,
+			DataStore: dataStore,
+		},
 	}
 }
 
-func getKubernetesIngressResources(c client.Client) []resources.Resource {
-	return []resources.Resource{
-		&resources.KubernetesIngressResource{
+func getKubernetesDeploymentResources(c client.Client, tcpReconcilerConfig TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore) []resources.Resource {
+	res := []resources.Resource{
+		&resources.SchedulerConfigurationResource{
 			Client: c,
 		},
+		&resources.APIServerTracingResource{
+			Client: c,
+		},
+		&resources.APIServerNodeConnectivityResource{
+			Client: c,
+		},
+		&resources.APIServerEgressPolicyResource{
+			Client:    c,
+			DataStore: dataStore,
+		},
+		&resources.KubernetesDeploymentResource{
+			Client:             c,
+			DataStore:          dataStore,
+			KineContainerImage: tcpReconcilerConfig.KineContainerImage,
+		},
 	}
-}
-
-func GetExternalKonnectivityResources(c client.Client) []resources.Resource {
-	return []resources.Resource{
-		&konnectivity.Agent{Client: c},
-		&konnectivity.ServiceAccountResource{Client: c},
-		&konnectivity.ClusterRoleBindingResource{Client: c},
+	// The components running in dedicated Deployments with the Split topology.
+	for _, component := range builder.SplitComponents {
+		res = append(res,
+			&resources.KubernetesComponentDeploymentResource{Client: c, DataStore: dataStore, Component: component},
+			&resources.KubernetesComponentPodDisruptionBudgetResource{Client: c, Component: component},
+		)
 	}
-}
 
-func getKonnectivityServerRequirementsResources(c client.Client) []resources.Resource {
-	return []resources.Resource{
-		&konnectivity.EgressSelectorConfigurationResource{Client: c},
-		&konnectivity.CertificateResource{Client: c},
-		&konnectivity.KubeconfigResource{Client: c},
-	}
+	return res
 }
 
-func getKonnectivityServerPatchResources(c client.Client) []resources.Resource {
+func getKubernetesIngressResources(c client.Client) []resources.Resource {
 	return []resources.Resource{
-		&konnectivity.KubernetesDeploymentResource{Builder: builder.Konnectivity{Scheme: *c.Scheme()}, Client: c},
-		&konnectivity.ServiceResource{Client: c},
+		&resources.KubernetesIngressResource{
+			Client: c,
+		},
+		&resources.APIServerOIDCDiscoveryResource{
+			Client: c,
+		},
 	}
 }
 
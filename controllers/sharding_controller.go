@@ -0,0 +1,209 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/logging"
+)
+
+// ShardingController assigns the TenantControlPlane objects to the live Kamaji shards using the kamaji.clastix.io/shard label:
+// each shard renews its own Lease, and the live shard with the lowest name acts as the coordinator.
+// The assignment relies on the rendezvous hashing, moving only the objects of the added, or removed, shards upon rebalancing.
+type ShardingController struct {
+	Client    client.Client
+	APIReader client.Reader
+	// Shard is the name of the shard served by the running instance.
+	Shard     string
+	Namespace string
+	// Namespaces, and Selector, restrict the assigned TenantControlPlane objects to the instance scope.
+	Namespaces []string
+	Selector   labels.Selector
+	// LeaseDuration is the period after which a shard not renewing its Lease is considered gone.
+	LeaseDuration time.Duration
+}
+
+func (s *ShardingController) leaseName(shard string) string {
+	return fmt.Sprintf("kamaji-shard-%s", shard)
+}
+
+func (s *ShardingController) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithValues("shard", s.Shard)
+
+	ticker := time.NewTicker(s.LeaseDuration / 3)
+	defer ticker.Stop()
+
+	for {
+		if err := s.renewLease(ctx); err != nil {
+			logger.Error(err, "cannot renew the shard lease")
+		} else if err = s.rebalance(ctx); err != nil {
+			logger.Error(err, "cannot rebalance the shards")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *ShardingController) renewLease(ctx context.Context) error {
+	now := metav1.NewMicroTime(time.Now())
+
+	var lease coordinationv1.Lease
+	if err := s.APIReader.Get(ctx, types.NamespacedName{Namespace: s.Namespace, Name: s.leaseName(s.Shard)}, &lease); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, "cannot retrieve the shard lease")
+		}
+
+		lease = coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.leaseName(s.Shard),
+				Namespace: s.Namespace,
+				Labels:    map[string]string{constants.ShardLeaseLabelKey: s.Shard},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(s.Shard),
+				LeaseDurationSeconds: ptr.To(int32(s.LeaseDuration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+
+		return errors.Wrap(s.Client.Create(ctx, &lease), "cannot create the shard lease")
+	}
+
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(s.LeaseDuration.Seconds()))
+	lease.Spec.RenewTime = &now
+
+	return errors.Wrap(s.Client.Update(ctx, &lease), "cannot renew the shard lease")
+}
+
+// liveShards returns the sorted names of the shards whose Lease has been renewed within its duration.
+func (s *ShardingController) liveShards(ctx context.Context) ([]string, error) {
+	var leases coordinationv1.LeaseList
+	if err := s.APIReader.List(ctx, &leases, client.InNamespace(s.Namespace), client.HasLabels{constants.ShardLeaseLabelKey}); err != nil {
+		return nil, errors.Wrap(err, "cannot list the shard leases")
+	}
+
+	shards := make([]string, 0, len(leases.Items))
+
+	for _, lease := range leases.Items {
+		if lease.Spec.RenewTime == nil {
+			continue
+		}
+
+		duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+		if time.Since(lease.Spec.RenewTime.Time) > duration {
+			continue
+		}
+
+		shards = append(shards, lease.Labels[constants.ShardLeaseLabelKey])
+	}
+
+	slices.Sort(shards)
+
+	return shards, nil
+}
+
+func (s *ShardingController) rebalance(ctx context.Context) error {
+	shards, err := s.liveShards(ctx)
+	if err != nil {
+		return err
+	}
+	// Only the coordinator is assigning the shards, preventing the live shards from competing for the objects:
+	// the Lease of the current shard may not be listed yet, due to the API Server consistency.
+	if len(shards) == 0 || shards[0] != s.Shard {
+		return nil
+	}
+
+	namespaces := s.Namespaces
+	if len(namespaces) == 0 {
+		namespaces = []string{metav1.NamespaceAll}
+	}
+
+	for _, namespace := range namespaces {
+		opts := []client.ListOption{client.InNamespace(namespace)}
+		if s.Selector != nil {
+			opts = append(opts, client.MatchingLabelsSelector{Selector: s.Selector})
+		}
+		// Listing the metadata only, and bypassing the cache, which is restricted to the shard.
+		tcps := &metav1.PartialObjectMetadataList{}
+		tcps.SetGroupVersionKind(kamajiv1alpha1.GroupVersion.WithKind("TenantControlPlaneList"))
+
+		if err = s.APIReader.List(ctx, tcps, opts...); err != nil {
+			return errors.Wrap(err, "cannot list the TenantControlPlane objects")
+		}
+
+		for i := range tcps.Items {
+			if err = s.assign(ctx, &tcps.Items[i], shards); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *ShardingController) assign(ctx context.Context, tcp *metav1.PartialObjectMetadata, shards []string) error {
+	shard := rendezvousShard(client.ObjectKeyFromObject(tcp).String(), shards)
+	if tcp.GetLabels()[constants.ShardLabelKey] == shard {
+		return nil
+	}
+
+	log.FromContext(ctx).Info("assigning shard", logging.TenantKey, client.ObjectKeyFromObject(tcp).String(), "shard", shard)
+
+	patch := client.MergeFrom(tcp.DeepCopy())
+
+	labels := tcp.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+
+	labels[constants.ShardLabelKey] = shard
+	tcp.SetLabels(labels)
+
+	return errors.Wrap(s.Client.Patch(ctx, tcp, patch), "cannot assign the TenantControlPlane shard")
+}
+
+// rendezvousShard returns the shard with the highest score for the given key.
+func rendezvousShard(key string, shards []string) string {
+	var (
+		selected string
+		highest  uint64
+	)
+
+	for _, shard := range shards {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(key + "/" + shard))
+
+		if score := h.Sum64(); selected == "" || score > highest {
+			selected, highest = shard, score
+		}
+	}
+
+	return selected
+}
+
+func (s *ShardingController) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package soot
+
+import (
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// cacheOptions restricts the objects cached by the soot manager to the ones reconciled by Kamaji,
+// since a Tenant Cluster could have thousands of them: the namespaced objects are cached from the kube-system,
+// and kube-public, namespaces only, and the objects always managed by Kamaji are selected by label.
+// The cluster-scoped objects without a Kamaji label, such as the Nodes, or the kubeadm ClusterRoleBindings, are cached as a whole.
+func cacheOptions() cache.Options {
+	managed := labels.SelectorFromSet(labels.Set{constants.ProjectNameLabelKey: constants.ProjectNameLabelValue})
+
+	namespaces := make(map[string]cache.Config, len(resources.SootNamespaces))
+	for _, namespace := range resources.SootNamespaces {
+		namespaces[namespace] = cache.Config{}
+	}
+
+	return cache.Options{
+		DefaultNamespaces: namespaces,
+		DefaultTransform:  cache.TransformStripManagedFields(),
+		ByObject: map[client.Object]cache.ByObject{
+			&corev1.Secret{}:                            {Label: managed},
+			&flowcontrolv1.FlowSchema{}:                 {Label: managed},
+			&flowcontrolv1.PriorityLevelConfiguration{}: {Label: managed},
+			// Only the kubelet serving certificates are approved by Kamaji.
+			&certificatesv1.CertificateSigningRequest{}: {Field: fields.OneTermEqualSelector("spec.signerName", certificatesv1.KubeletServingSignerName)},
+			&corev1.Node{}: {Transform: stripNodeImages},
+		},
+	}
+}
+
+// stripNodeImages drops the managed fields, and the images, of the cached Nodes:
+// the images list is the largest part of a Node object, and it's never used by the soot controllers.
+func stripNodeImages(obj any) (any, error) {
+	obj, err := cache.TransformStripManagedFields()(obj)
+	if err != nil {
+		return obj, err
+	}
+
+	if node, ok := obj.(*corev1.Node); ok {
+		node.Status.Images = nil
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,163 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+const (
+	activitySamplingInterval = time.Minute
+	// activityGranularity is the minimum progress of the last use before recording it,
+	// preventing a status update upon each sampling of a Tenant Cluster continuously used.
+	activityGranularity = 5 * time.Minute
+)
+
+// Activity samples the use of the Tenant Cluster, for the Tenant Control Planes deleted after the last use:
+// the Tenant Cluster is used when a node heartbeat is recorded with its lease, or an event is emitted outside the system namespaces.
+type Activity struct {
+	Logger      logr.Logger
+	AdminClient client.Client
+	// TenantReader lists the Tenant Cluster leases, and events, without caching them.
+	TenantReader              client.Reader
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	lastSampling time.Time
+}
+
+func (a *Activity) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := a.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			a.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.Spec.TTLSecondsAfterLastUse == nil {
+		return reconcile.Result{}, nil
+	}
+	// The trigger is fired upon each Tenant Control Plane change, including the status updates issued by the sampling itself.
+	if elapsed := time.Since(a.lastSampling); elapsed < activitySamplingInterval {
+		return reconcile.Result{RequeueAfter: activitySamplingInterval - elapsed}, nil
+	}
+
+	a.lastSampling = time.Now()
+
+	lastUse, err := a.sample(ctx)
+	if err != nil {
+		a.Logger.Error(err, "cannot sample the Tenant Cluster activity")
+
+		return reconcile.Result{}, err
+	}
+
+	if ttl := tcp.Status.TTL; lastUse.IsZero() || (ttl != nil && ttl.LastUseTime != nil && lastUse.Sub(ttl.LastUseTime.Time) < activityGranularity) {
+		return reconcile.Result{RequeueAfter: activitySamplingInterval}, nil
+	}
+
+	if err = a.updateStatus(ctx, tcp, lastUse); err != nil {
+		a.Logger.Error(err, "cannot update the last use of the Tenant Cluster")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: activitySamplingInterval}, nil
+}
+
+// sample returns the latest use of the Tenant Cluster, zero if never used.
+func (a *Activity) sample(ctx context.Context) (time.Time, error) {
+	var lastUse time.Time
+
+	observe := func(t time.Time) {
+		if t.After(lastUse) && !t.After(time.Now()) {
+			lastUse = t
+		}
+	}
+
+	var leases coordinationv1.LeaseList
+	if err := a.TenantReader.List(ctx, &leases, client.InNamespace(corev1.NamespaceNodeLease)); err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot list the node leases")
+	}
+
+	for _, lease := range leases.Items {
+		if lease.Spec.RenewTime != nil {
+			observe(lease.Spec.RenewTime.Time)
+		}
+	}
+
+	var events corev1.EventList
+	if err := a.TenantReader.List(ctx, &events); err != nil {
+		return time.Time{}, errors.Wrap(err, "cannot list the events")
+	}
+
+	for _, item := range events.Items {
+		switch item.GetNamespace() {
+		case metav1.NamespaceSystem, metav1.NamespacePublic, corev1.NamespaceNodeLease:
+			continue
+		}
+
+		observe(item.LastTimestamp.Time)
+		observe(item.EventTime.Time)
+	}
+
+	return lastUse.Truncate(time.Second), nil
+}
+
+func (a *Activity) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, lastUse time.Time) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = a.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		if tcp.Status.TTL == nil {
+			tcp.Status.TTL = &kamajiv1alpha1.TTLStatus{}
+		}
+
+		tcp.Status.TTL.LastUseTime = &metav1.Time{Time: lastUse}
+
+		if err = a.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+func (a *Activity) SetupWithManager(mgr manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("activity").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		WatchesRawSource(source.Channel(a.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(a)
+}
@@ -24,9 +24,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
 	"github.com/clastix/kamaji/controllers/utils"
 	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/logging"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/addons"
 )
@@ -36,6 +38,7 @@ type CoreDNS struct {
 	AdminClient               client.Client
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
 }
 
 func (c *CoreDNS) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
@@ -50,13 +53,24 @@ func (c *CoreDNS) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 		return reconcile.Result{}, err
 	}
 
+	if ready, after := c.ReadinessGate.Ready(ctx); !ready {
+		c.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	var trait *kamajiv1alpha1.AddonApplyTrait
+	if tcp.Spec.Addons.CoreDNS != nil {
+		trait = &tcp.Spec.Addons.CoreDNS.AddonApplyTrait
+	}
+
 	c.Logger.Info("start processing")
 
 	resource := &addons.CoreDNS{Client: c.AdminClient}
 
 	result, handlingErr := resources.Handle(ctx, resource, tcp)
 	if handlingErr != nil {
-		c.Logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+		c.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
 
 		return reconcile.Result{}, handlingErr
 	}
@@ -64,18 +78,18 @@ func (c *CoreDNS) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 	if result == controllerutil.OperationResultNone {
 		c.Logger.Info("reconciliation completed")
 
-		return reconcile.Result{}, nil
+		return syncResult(trait), nil
 	}
 
 	if err = utils.UpdateStatus(ctx, c.AdminClient, tcp, resource); err != nil {
-		c.Logger.Error(err, "update status failed", "resource", resource.GetName())
+		c.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
 
 		return reconcile.Result{}, err
 	}
 
 	c.Logger.Info("reconciliation processed")
 
-	return reconcile.Result{}, nil
+	return syncResult(trait), nil
 }
 
 func (c *CoreDNS) SetupWithManager(mgr manager.Manager) error {
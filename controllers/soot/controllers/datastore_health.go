@@ -0,0 +1,148 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+const (
+	dataStoreProbeInterval = 30 * time.Second
+	dataStoreProbeTimeout  = 5 * time.Second
+)
+
+// DataStoreHealth probes the connection of the Tenant Control Plane API Server to its DataStore,
+// using the etcd readiness check, which is served by kine for the SQL drivers: the latency, and the outcome,
+// are exposed as metrics labelled with the Tenant Control Plane, and reported with the DataStoreConnectionHealthy condition.
+type DataStoreHealth struct {
+	Logger      logr.Logger
+	AdminClient client.Client
+	// RESTClient is the REST client of the Tenant Cluster.
+	RESTClient                rest.Interface
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	lastProbe time.Time
+}
+
+func (d *DataStoreHealth) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := d.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			d.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+	// The trigger is fired upon each Tenant Control Plane change, including the status updates issued by the probe itself.
+	if elapsed := time.Since(d.lastProbe); elapsed < dataStoreProbeInterval {
+		return reconcile.Result{RequeueAfter: dataStoreProbeInterval - elapsed}, nil
+	}
+
+	d.lastProbe = time.Now()
+
+	tenant := types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}.String()
+
+	condition := metav1.Condition{
+		Type:    kamajiv1alpha1.ConditionDataStoreConnectionHealthy,
+		Status:  metav1.ConditionTrue,
+		Reason:  kamajiv1alpha1.ReasonDataStoreReachable,
+		Message: "the etcd readiness check of the API Server succeeded",
+	}
+
+	duration, probeErr := d.probe(ctx)
+
+	dataStoreProbeDurationCollector.WithLabelValues(tenant).Set(duration.Seconds())
+
+	if probeErr != nil {
+		d.Logger.Error(probeErr, "the DataStore connection probe failed")
+		// The latency isn't reported by the message, preventing the status update upon each probe.
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionFalse, kamajiv1alpha1.ReasonDataStoreUnreachable, probeErr.Error()
+
+		dataStoreHealthyCollector.WithLabelValues(tenant).Set(0)
+	} else {
+		dataStoreHealthyCollector.WithLabelValues(tenant).Set(1)
+	}
+
+	if err = d.updateStatus(ctx, tcp, condition); err != nil {
+		d.Logger.Error(err, "cannot update the DataStore connection health condition")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: dataStoreProbeInterval}, nil
+}
+
+// probe performs the etcd readiness check of the API Server, returning its duration.
+func (d *DataStoreHealth) probe(ctx context.Context) (time.Duration, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, dataStoreProbeTimeout)
+	defer cancelFn()
+
+	start := time.Now()
+
+	if err := d.RESTClient.Get().AbsPath("/readyz/etcd").Do(ctx).Error(); err != nil {
+		return time.Since(start), errors.Wrap(err, "the etcd readiness check of the API Server failed")
+	}
+
+	return time.Since(start), nil
+}
+
+// updateStatus records the DataStoreConnectionHealthy condition, only when changed.
+func (d *DataStoreHealth) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, condition metav1.Condition) error {
+	if current := meta.FindStatusCondition(tcp.Status.Conditions, condition.Type); current != nil &&
+		current.Status == condition.Status && current.Reason == condition.Reason && current.Message == condition.Message && current.ObservedGeneration == tcp.GetGeneration() {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = d.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		condition.ObservedGeneration = tcp.GetGeneration()
+		meta.SetStatusCondition(&tcp.Status.Conditions, condition)
+
+		if err = d.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+func (d *DataStoreHealth) SetupWithManager(mgr manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("datastore-health").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		WatchesRawSource(source.Channel(d.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(d)
+}
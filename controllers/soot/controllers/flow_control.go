@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+// FlowControl reconciles the API Priority and Fairness objects declared for the Tenant Control Plane API server.
+type FlowControl struct {
+	Logger                    logr.Logger
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
+}
+
+func (f *FlowControl) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := f.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			f.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if ready, after := f.ReadinessGate.Ready(ctx); !ready {
+		f.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	var trait *kamajiv1alpha1.AddonApplyTrait
+	if tcp.Spec.Kubernetes.APIServer != nil && tcp.Spec.Kubernetes.APIServer.FlowControl != nil {
+		trait = &tcp.Spec.Kubernetes.APIServer.FlowControl.AddonApplyTrait
+	}
+
+	f.Logger.Info("start processing")
+
+	resource := &addons.FlowControl{Client: f.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		f.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		f.Logger.Info("reconciliation completed")
+
+		return syncResult(trait), nil
+	}
+
+	if err = utils.UpdateStatus(ctx, f.AdminClient, tcp, resource); err != nil {
+		f.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, err
+	}
+
+	f.Logger.Info("reconciliation processed")
+
+	return syncResult(trait), nil
+}
+
+func (f *FlowControl) SetupWithManager(mgr manager.Manager) error {
+	isManaged := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		return object.GetLabels()[constants.ProjectNameLabelKey] == constants.ProjectNameLabelValue
+	}))
+	// All the events are enqueued with the same request, since the objects are reconciled as a whole.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "flow-control"}}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("flow-control").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&flowcontrolv1.FlowSchema{}, enqueue, isManaged).
+		Watches(&flowcontrolv1.PriorityLevelConfiguration{}, enqueue, isManaged).
+		WatchesRawSource(source.Channel(f.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(f)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+type FrontProxy struct {
+	Logger                    logr.Logger
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
+}
+
+func (f *FrontProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := f.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			f.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if ready, after := f.ReadinessGate.Ready(ctx); !ready {
+		f.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	var trait *kamajiv1alpha1.AddonApplyTrait
+	if tcp.Spec.Addons.FrontProxy != nil {
+		trait = &tcp.Spec.Addons.FrontProxy.AddonApplyTrait
+	}
+
+	f.Logger.Info("start processing")
+
+	resource := &addons.FrontProxy{Client: f.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		f.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		f.Logger.Info("reconciliation completed")
+
+		return syncResult(trait), nil
+	}
+
+	if err = utils.UpdateStatus(ctx, f.AdminClient, tcp, resource); err != nil {
+		f.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, err
+	}
+
+	f.Logger.Info("reconciliation processed")
+
+	return syncResult(trait), nil
+}
+
+func (f *FrontProxy) SetupWithManager(mgr manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		For(&corev1.ConfigMap{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == addons.FrontProxyConfigMapName && object.GetNamespace() == kubeadm.KubeSystemNamespace
+		}))).
+		WatchesRawSource(source.Channel(f.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Owns(&rbacv1.Role{}).
+		Owns(&rbacv1.RoleBinding{}).
+		Complete(f)
+}
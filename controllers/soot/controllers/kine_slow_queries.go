@@ -0,0 +1,208 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/kine"
+)
+
+const (
+	kineSlowQueriesContainer       = "kine"
+	kineSlowQueriesDefaultInterval = time.Minute
+	// kineSlowQueriesLimit is the number of the slowest queries reported in the Tenant Control Plane status.
+	kineSlowQueriesLimit = 5
+)
+
+// KineSlowQueries collects the slow queries logged by the kine containers of the Tenant Control Plane,
+// when enabled by its SQL DataStore: the queries are aggregated in the Tenant Control Plane status,
+// and exposed as metrics labelled with the Tenant Control Plane.
+// The logs are read from the management cluster, hence the Control Plane pods running in a target cluster are skipped.
+type KineSlowQueries struct {
+	Logger      logr.Logger
+	AdminClient client.Client
+	// AdminClientset reads the logs of the Control Plane pods from the management cluster.
+	AdminClientset            kubernetes.Interface
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+
+	lastCollection time.Time
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+
+func (k *KineSlowQueries) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := k.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			k.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	tenant := types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}.String()
+
+	var ds kamajiv1alpha1.DataStore
+	if len(tcp.Status.Storage.DataStoreName) > 0 {
+		if err = k.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Status.Storage.DataStoreName}, &ds); client.IgnoreNotFound(err) != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	if ds.Spec.SlowQueries == nil || ds.Spec.Driver == kamajiv1alpha1.EtcdDriver || ds.Spec.Driver == kamajiv1alpha1.KineNatsDriver || tcp.Spec.ControlPlane.Deployment.TargetCluster != nil {
+		k.lastCollection = time.Time{}
+		ForgetKineSlowQueries(tenant)
+
+		if tcp.Status.Storage.SlowQueries == nil {
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, k.updateStatus(ctx, tcp, nil)
+	}
+
+	interval := ds.Spec.SlowQueries.Interval.Duration
+	if interval <= 0 {
+		interval = kineSlowQueriesDefaultInterval
+	}
+	// The trigger is fired upon each Tenant Control Plane change, including the status updates issued by the collection itself.
+	if k.lastCollection.IsZero() {
+		// The slow queries logged before the start of the soot manager are not collected, preventing double counting them.
+		k.lastCollection = time.Now()
+
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	if elapsed := time.Since(k.lastCollection); elapsed < interval {
+		return reconcile.Result{RequeueAfter: interval - elapsed}, nil
+	}
+
+	since := k.lastCollection
+	k.lastCollection = time.Now()
+
+	queries, err := k.collect(ctx, tcp, since)
+	if err != nil {
+		k.Logger.Error(err, "cannot collect the kine slow queries")
+
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	status := tcp.Status.Storage.SlowQueries.DeepCopy()
+	if status == nil {
+		status = &kamajiv1alpha1.SlowQueriesStatus{}
+	}
+
+	var maxDuration time.Duration
+	for _, query := range queries {
+		maxDuration = max(maxDuration, query.Duration)
+	}
+
+	kineSlowQueriesCollector.WithLabelValues(tenant).Add(float64(len(queries)))
+	kineSlowQueryMaxDurationCollector.WithLabelValues(tenant).Set(maxDuration.Seconds())
+
+	if len(queries) == 0 && tcp.Status.Storage.SlowQueries != nil {
+		return reconcile.Result{RequeueAfter: interval}, nil
+	}
+
+	status.Count += int64(len(queries))
+	status.TopQueries = kine.AggregateSlowQueries(status.TopQueries, queries, kineSlowQueriesLimit)
+
+	if err = k.updateStatus(ctx, tcp, status); err != nil {
+		k.Logger.Error(err, "cannot update the kine slow queries status")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: interval}, nil
+}
+
+// collect returns the slow queries logged by the kine containers of the Control Plane pods since the given time.
+func (k *KineSlowQueries) collect(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, since time.Time) ([]kine.SlowQuery, error) {
+	if len(tcp.Status.Kubernetes.Deployment.Selector) == 0 {
+		return nil, nil
+	}
+
+	pods, err := k.AdminClientset.CoreV1().Pods(tcp.GetNamespace()).List(ctx, metav1.ListOptions{LabelSelector: tcp.Status.Kubernetes.Deployment.Selector})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot list the Control Plane pods")
+	}
+
+	var queries []kine.SlowQuery
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		sinceTime := metav1.NewTime(since)
+
+		logs, logsErr := k.AdminClientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+			Container: kineSlowQueriesContainer,
+			SinceTime: &sinceTime,
+		}).DoRaw(ctx)
+		if logsErr != nil {
+			k.Logger.Error(logsErr, "cannot retrieve the kine logs", "pod", pod.GetName())
+
+			continue
+		}
+
+		queries = append(queries, kine.ParseSlowQueries(logs, since)...)
+	}
+
+	return queries, nil
+}
+
+// updateStatus records the aggregated slow queries, removing them when nil.
+func (k *KineSlowQueries) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, status *kamajiv1alpha1.SlowQueriesStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = k.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		tcp.Status.Storage.SlowQueries = status
+
+		if err = k.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+func (k *KineSlowQueries) SetupWithManager(mgr manager.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("kine-slow-queries").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		WatchesRawSource(source.Channel(k.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(k)
+}
@@ -25,9 +25,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers"
 	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/logging"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/konnectivity"
 )
@@ -37,6 +39,7 @@ type KonnectivityAgent struct {
 	AdminClient               client.Client
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
 }
 
 func (k *KonnectivityAgent) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
@@ -53,24 +56,30 @@ func (k *KonnectivityAgent) Reconcile(ctx context.Context, _ reconcile.Request)
 		return reconcile.Result{}, err
 	}
 
+	if ready, after := k.ReadinessGate.Ready(ctx); !ready {
+		k.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
 	for _, resource := range controllers.GetExternalKonnectivityResources(k.AdminClient) {
-		k.Logger.Info("start processing", "resource", resource.GetName())
+		k.Logger.Info("start processing", logging.ResourceKey, resource.GetName())
 
 		result, handlingErr := resources.Handle(ctx, resource, tcp)
 		if handlingErr != nil {
-			k.Logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+			k.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
 
 			return reconcile.Result{}, handlingErr
 		}
 
 		if result == controllerutil.OperationResultNone {
-			k.Logger.Info("resource processed", "resource", resource.GetName())
+			k.Logger.Info("resource processed", logging.ResourceKey, resource.GetName())
 
 			continue
 		}
 
 		if err = utils.UpdateStatus(ctx, k.AdminClient, tcp, resource); err != nil {
-			k.Logger.Error(err, "update status failed", "resource", resource.GetName())
+			k.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
 
 			return reconcile.Result{}, err
 		}
@@ -78,7 +87,24 @@ func (k *KonnectivityAgent) Reconcile(ctx context.Context, _ reconcile.Request)
 
 	k.Logger.Info("reconciliation completed")
 
-	return reconcile.Result{}, nil
+	return k.agentSyncResult(tcp), nil
+}
+
+// agentSyncResult requires a periodic re-sync of the addon when a reconcile interval is declared in its sync policy:
+// the agents of a remote cluster are not watched, and their token must be refreshed periodically.
+func (k *KonnectivityAgent) agentSyncResult(tcp *kamajiv1alpha1.TenantControlPlane) reconcile.Result {
+	if tcp.Spec.Addons.Konnectivity == nil {
+		return reconcile.Result{}
+	}
+
+	agent := tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec
+
+	result := syncResult(&agent.AddonApplyTrait)
+	if agent.RemoteCluster != nil && (result.RequeueAfter == 0 || result.RequeueAfter > konnectivity.RemoteAgentTokenResyncPeriod) {
+		result.RequeueAfter = konnectivity.RemoteAgentTokenResyncPeriod
+	}
+
+	return result
 }
 
 func (k *KonnectivityAgent) SetupWithManager(mgr manager.Manager) error {
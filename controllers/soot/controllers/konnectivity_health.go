@@ -0,0 +1,416 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/expfmt"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/resources/konnectivity"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const konnectivityProbeTimeout = 5 * time.Second
+
+// KonnectivityHealth probes the agents connected to the Konnectivity servers, comparing them with the expected ones,
+// and restarting the agents when disconnected for longer than the grace period: the outcome is reported with the
+// KonnectivityDegraded condition of the Tenant Control Plane.
+// It also completes the rotation of the Konnectivity server certificate, tracked by the KonnectivityCertificatesRotating condition.
+type KonnectivityHealth struct {
+	Logger      logr.Logger
+	AdminClient client.Client
+	// APIReader lists the Tenant Control Plane pods, without caching the pods of the management cluster.
+	APIReader client.Reader
+	// Client is the client of the Tenant Cluster.
+	Client                    client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+}
+
+func (k *KonnectivityHealth) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := k.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			k.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	rotationRequeue, err := k.completeCertificatesRotation(ctx, tcp)
+	if err != nil {
+		k.Logger.Error(err, "cannot complete the Konnectivity certificates rotation")
+
+		return reconcile.Result{}, err
+	}
+
+	if tcp.Spec.Addons.Konnectivity == nil || tcp.Spec.Addons.Konnectivity.HealthCheck == nil {
+		if err = k.updateStatus(ctx, tcp, nil, kamajiv1alpha1.KonnectivityHealthStatus{}); err != nil {
+			k.Logger.Error(err, "cannot reset the Konnectivity health status")
+
+			return reconcile.Result{}, err
+		}
+
+		return reconcile.Result{RequeueAfter: rotationRequeue}, nil
+	}
+
+	healthCheck := tcp.Spec.Addons.Konnectivity.HealthCheck
+	health := tcp.Status.Addons.Konnectivity.Health
+	// The trigger is fired upon each Tenant Control Plane change, including the status updates issued by the probe itself.
+	if elapsed := time.Since(health.LastProbe.Time); elapsed < healthCheck.Interval.Duration {
+		return reconcile.Result{RequeueAfter: earliestRequeue(healthCheck.Interval.Duration-elapsed, rotationRequeue)}, nil
+	}
+
+	health.LastProbe = metav1.Now()
+
+	condition := metav1.Condition{
+		Type:   kamajiv1alpha1.ConditionKonnectivityDegraded,
+		Status: metav1.ConditionFalse,
+		Reason: kamajiv1alpha1.ReasonAgentsConnected,
+	}
+
+	if probeErr := k.probe(ctx, tcp, &health); probeErr != nil {
+		k.Logger.Error(probeErr, "cannot probe the Konnectivity servers")
+
+		condition.Status, condition.Reason, condition.Message = metav1.ConditionUnknown, kamajiv1alpha1.ReasonProbeFailed, probeErr.Error()
+	}
+
+	if condition.Reason != kamajiv1alpha1.ReasonProbeFailed && health.ConnectedAgents < health.ExpectedAgents {
+		condition.Status, condition.Reason = metav1.ConditionTrue, kamajiv1alpha1.ReasonAgentsDisconnected
+	}
+
+	if condition.Reason != kamajiv1alpha1.ReasonProbeFailed {
+		condition.Message = fmt.Sprintf("%d agents connected out of %d expected, the Tenant Cluster has %d nodes", health.ConnectedAgents, health.ExpectedAgents, health.Nodes)
+	}
+
+	if condition.Status == metav1.ConditionTrue && healthCheck.RestartAgents && k.shouldRestart(tcp, healthCheck) {
+		if restartErr := k.restartAgents(ctx, tcp); restartErr != nil {
+			k.Logger.Error(restartErr, "cannot restart the Konnectivity agents")
+
+			return reconcile.Result{}, restartErr
+		}
+
+		k.Logger.Info("Konnectivity agents restarted", "connected", health.ConnectedAgents, "expected", health.ExpectedAgents)
+
+		health.LastRestart = metav1.Now()
+	}
+
+	if err = k.updateStatus(ctx, tcp, &condition, health); err != nil {
+		k.Logger.Error(err, "cannot update the Konnectivity health status")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: earliestRequeue(healthCheck.Interval.Duration, rotationRequeue)}, nil
+}
+
+// earliestRequeue returns the shortest of the given requeue periods, ignoring the zero one.
+func earliestRequeue(period, other time.Duration) time.Duration {
+	if other == 0 {
+		return period
+	}
+
+	return min(period, other)
+}
+
+// completeCertificatesRotation reverts the KonnectivityCertificatesRotating condition once the rotated certificate
+// has been reloaded by the Konnectivity servers, and the agents are connected, when monitored:
+// it returns the time left to the certificate propagation.
+func (k *KonnectivityHealth) completeCertificatesRotation(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (time.Duration, error) {
+	current := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityCertificatesRotating)
+	if current == nil || current.Status != metav1.ConditionTrue {
+		return 0, nil
+	}
+
+	if remaining := konnectivity.CertificatesPropagationPeriod - time.Since(current.LastTransitionTime.Time); remaining > 0 {
+		return remaining, nil
+	}
+	// The agents disconnected during the rotation are restarted by the health check,
+	// the next probe is triggering the reconciliation again.
+	if degraded := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityDegraded); degraded != nil && degraded.Status == metav1.ConditionTrue {
+		return 0, nil
+	}
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = k.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.ConditionKonnectivityCertificatesRotating,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: tcp.GetGeneration(),
+			Reason:             kamajiv1alpha1.ReasonCertificatesRotated,
+			Message:            "the Konnectivity server certificate has been reloaded by the servers",
+		})
+
+		if err = k.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot update the Konnectivity certificates rotation condition")
+	}
+
+	k.Logger.Info("Konnectivity certificates rotation completed")
+
+	return 0, nil
+}
+
+// probe collects the agents connected to each Konnectivity server, retaining the lowest count,
+// since each agent is expected to connect to all the servers.
+func (k *KonnectivityHealth) probe(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, health *kamajiv1alpha1.KonnectivityHealthStatus) error {
+	var nodes corev1.NodeList
+	if err := k.Client.List(ctx, &nodes); err != nil {
+		return errors.Wrap(err, "cannot list the Tenant Cluster nodes")
+	}
+
+	health.Nodes = int32(len(nodes.Items)) //nolint:gosec
+
+	expected, err := k.expectedAgents(ctx, tcp)
+	if err != nil {
+		return err
+	}
+
+	health.ExpectedAgents = expected
+
+	// The Konnectivity servers run in the kube-apiserver pods, unless the Deployment placement is declared.
+	selector := client.MatchingLabels{"kamaji.clastix.io/name": tcp.GetName()}
+	if tcp.Spec.Addons.Konnectivity.HasDeploymentPlacement() {
+		selector = controlplane.KonnectivityServerSelector(tcp)
+	}
+
+	var pods corev1.PodList
+	if err = k.APIReader.List(ctx, &pods, client.InNamespace(tcp.GetNamespace()), selector); err != nil {
+		return errors.Wrap(err, "cannot list the Konnectivity server pods")
+	}
+
+	connected := int32(math.MaxInt32)
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase != corev1.PodRunning || pod.Status.PodIP == "" || pod.GetDeletionTimestamp() != nil {
+			continue
+		}
+
+		count, countErr := k.connectedAgents(ctx, pod.Status.PodIP)
+		if countErr != nil {
+			return errors.Wrapf(countErr, "cannot retrieve the agents connected to the Konnectivity server of the pod %s", pod.GetName())
+		}
+
+		connected = min(connected, count)
+	}
+
+	if connected == math.MaxInt32 {
+		return errors.New("no running Konnectivity server")
+	}
+
+	health.ConnectedAgents = connected
+
+	return nil
+}
+
+// expectedAgents returns the agents scheduled by the DaemonSet, or the replicas of the Deployment.
+func (k *KonnectivityHealth) expectedAgents(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (int32, error) {
+	c, err := k.agentClient(ctx, tcp)
+	if err != nil {
+		return 0, err
+	}
+
+	key := types.NamespacedName{Namespace: konnectivity.AgentNamespace, Name: konnectivity.AgentName}
+
+	if tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDeployment {
+		var deployment appsv1.Deployment
+		if err = c.Get(ctx, key, &deployment); err != nil {
+			return 0, errors.Wrap(err, "cannot retrieve the Konnectivity agent Deployment")
+		}
+
+		return ptr.Deref(deployment.Spec.Replicas, 1), nil
+	}
+
+	var daemonSet appsv1.DaemonSet
+	if err = c.Get(ctx, key, &daemonSet); err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve the Konnectivity agent DaemonSet")
+	}
+
+	return daemonSet.Status.DesiredNumberScheduled, nil
+}
+
+func (k *KonnectivityHealth) connectedAgents(ctx context.Context, address string) (int32, error) {
+	ctx, cancelFn := context.WithTimeout(ctx, konnectivityProbeTimeout)
+	defer cancelFn()
+
+	endpoint := fmt.Sprintf("http://%s/metrics", net.JoinHostPort(address, strconv.Itoa(konnectivity.ServerAdminPort)))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	var parser expfmt.TextParser
+
+	families, err := parser.TextToMetricFamilies(response.Body)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot parse the metrics")
+	}
+
+	family, ok := families[konnectivity.ReadyBackendConnectionsMetric]
+	if !ok {
+		return 0, fmt.Errorf("missing %s metric", konnectivity.ReadyBackendConnectionsMetric)
+	}
+
+	var connected float64
+	for _, metric := range family.GetMetric() {
+		connected = max(connected, metric.GetGauge().GetValue())
+	}
+
+	return int32(connected), nil
+}
+
+// shouldRestart returns true when the agents are disconnected for longer than the grace period,
+// and they haven't been restarted within it.
+func (k *KonnectivityHealth) shouldRestart(tcp *kamajiv1alpha1.TenantControlPlane, healthCheck *kamajiv1alpha1.KonnectivityHealthCheckSpec) bool {
+	current := meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityDegraded)
+	if current == nil || current.Status != metav1.ConditionTrue {
+		return false
+	}
+
+	if time.Since(current.LastTransitionTime.Time) < healthCheck.GracePeriod.Duration {
+		return false
+	}
+
+	return time.Since(tcp.Status.Addons.Konnectivity.Health.LastRestart.Time) >= healthCheck.GracePeriod.Duration
+}
+
+// restartAgents triggers the rollout of the agents, annotating their pod template as kubectl rollout restart does.
+func (k *KonnectivityHealth) restartAgents(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	c, err := k.agentClient(ctx, tcp)
+	if err != nil {
+		return err
+	}
+
+	var obj client.Object = &appsv1.DaemonSet{}
+	if tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDeployment {
+		obj = &appsv1.Deployment{}
+	}
+
+	obj.SetNamespace(konnectivity.AgentNamespace)
+	obj.SetName(konnectivity.AgentName)
+
+	patch := fmt.Sprintf(`{"spec":{"template":{"metadata":{"annotations":{%q:%q}}}}}`, konnectivity.AgentRestartAnnotation, time.Now().Format(time.RFC3339))
+
+	return c.Patch(ctx, obj, client.RawPatch(types.StrategicMergePatchType, []byte(patch)))
+}
+
+// agentClient returns the client of the cluster hosting the agents: the remote one, when declared.
+func (k *KonnectivityHealth) agentClient(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (client.Client, error) {
+	remote := tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.RemoteCluster
+	if remote == nil {
+		return k.Client, nil
+	}
+
+	c, err := utilities.GetRemoteClusterClient(ctx, k.AdminClient, tcp, remote.KubeconfigSecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create the remote cluster client")
+	}
+
+	return c, nil
+}
+
+// updateStatus records the health status, and the KonnectivityDegraded condition: a nil condition removes it.
+func (k *KonnectivityHealth) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, condition *metav1.Condition, health kamajiv1alpha1.KonnectivityHealthStatus) error {
+	if condition == nil && meta.FindStatusCondition(tcp.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityDegraded) == nil && tcp.Status.Addons.Konnectivity.Health == health {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = k.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		tcp.Status.Addons.Konnectivity.Health = health
+
+		if condition == nil {
+			meta.RemoveStatusCondition(&tcp.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityDegraded)
+		} else {
+			condition.ObservedGeneration = tcp.GetGeneration()
+			meta.SetStatusCondition(&tcp.Status.Conditions, *condition)
+		}
+
+		if err = k.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+func (k *KonnectivityHealth) SetupWithManager(mgr manager.Manager) error {
+	// All the events are enqueued with the same request, since the agents are probed as a whole.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "konnectivity-health"}}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("konnectivity-health").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&corev1.Node{}, enqueue, builder.WithPredicates(predicate.Funcs{
+			// Only the nodes joining, or leaving, the cluster are changing the expected agents,
+			// they're taken into account by the next probe.
+			UpdateFunc: func(event.UpdateEvent) bool { return false },
+		})).
+		WatchesRawSource(source.Channel(k.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(k)
+}
@@ -28,6 +28,7 @@ import (
 type KubeadmPhase struct {
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
 	Phase                     resources.KubeadmPhaseResource
 
 	logger logr.Logger
@@ -45,6 +46,12 @@ func (k *KubeadmPhase) Reconcile(ctx context.Context, _ reconcile.Request) (reco
 		return reconcile.Result{}, err
 	}
 
+	if ready, after := k.ReadinessGate.Ready(ctx); !ready {
+		k.logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
 	k.logger.Info("start processing")
 
 	result, handlingErr := resources.Handle(ctx, k.Phase, tcp)
@@ -0,0 +1,252 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	kubeletServingCSRApprovedReason = "KamajiAutoApproved"
+	kubeletServingCSRDeniedReason   = "KamajiAutoDenied"
+)
+
+// KubeletServingCSR approves the kubelet serving CSRs matching the identity of the requesting node,
+// when the kubelet server certificate rotation is enabled: the CSRs not matching it are denied.
+type KubeletServingCSR struct {
+	Logger logr.Logger
+	// Client is the client of the Tenant Cluster.
+	Client                    client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+}
+
+// kubeletServingCSRReview is the payload sent to the verification hook.
+type kubeletServingCSRReview struct {
+	Name        string   `json:"name"`
+	NodeName    string   `json:"nodeName"`
+	Username    string   `json:"username"`
+	DNSNames    []string `json:"dnsNames,omitempty"`
+	IPAddresses []string `json:"ipAddresses,omitempty"`
+	Request     []byte   `json:"request"`
+}
+
+func (k *KubeletServingCSR) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := k.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			k.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	rotation := tcp.Spec.Kubernetes.Kubelet.ServerCertificateRotation
+	if rotation == nil {
+		return reconcile.Result{}, nil
+	}
+
+	var csrs certificatesv1.CertificateSigningRequestList
+	if err = k.Client.List(ctx, &csrs); err != nil {
+		return reconcile.Result{}, errors.Wrap(err, "cannot list the CertificateSigningRequest objects")
+	}
+
+	var errs []error
+
+	for i := range csrs.Items {
+		csr := &csrs.Items[i]
+
+		if !isPendingKubeletServingCSR(csr) {
+			continue
+		}
+
+		if err = k.review(ctx, csr, rotation); err != nil {
+			k.Logger.Error(err, "cannot review the kubelet serving CSR", "name", csr.GetName())
+
+			errs = append(errs, err)
+		}
+	}
+
+	return reconcile.Result{}, kerrors.NewAggregate(errs)
+}
+
+// review approves, or denies, the given CSR: the ones of the nodes not yet registered are left pending.
+func (k *KubeletServingCSR) review(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, rotation *kamajiv1alpha1.KubeletServerCertificateRotationSpec) error {
+	request, nodeName, err := utilities.ParseKubeletServingCSR(csr)
+	if err != nil {
+		return k.setApproval(ctx, csr, certificatesv1.CertificateDenied, kubeletServingCSRDeniedReason, err.Error())
+	}
+
+	var node corev1.Node
+	if err = k.Client.Get(ctx, types.NamespacedName{Name: nodeName}, &node); err != nil {
+		if k8serrors.IsNotFound(err) {
+			k.Logger.Info("the node requesting the kubelet serving CSR is not yet registered", "name", csr.GetName(), "node", nodeName)
+
+			return nil
+		}
+
+		return errors.Wrap(err, "cannot retrieve the requesting node")
+	}
+
+	if err = utilities.ValidateKubeletServingCSRAddresses(request, &node); err != nil {
+		return k.setApproval(ctx, csr, certificatesv1.CertificateDenied, kubeletServingCSRDeniedReason, err.Error())
+	}
+
+	if hook := rotation.VerificationHook; hook != nil {
+		review := kubeletServingCSRReview{
+			Name:     csr.GetName(),
+			NodeName: nodeName,
+			Username: csr.Spec.Username,
+			DNSNames: request.DNSNames,
+			Request:  csr.Spec.Request,
+		}
+
+		for _, ip := range request.IPAddresses {
+			review.IPAddresses = append(review.IPAddresses, ip.String())
+		}
+
+		allowed, message, hookErr := callKubeletServingCSRHook(ctx, hook, review)
+		if hookErr != nil {
+			return errors.Wrap(hookErr, "cannot call the verification hook")
+		}
+
+		if !allowed {
+			return k.setApproval(ctx, csr, certificatesv1.CertificateDenied, kubeletServingCSRDeniedReason, fmt.Sprintf("denied by the verification hook: %s", message))
+		}
+	}
+
+	return k.setApproval(ctx, csr, certificatesv1.CertificateApproved, kubeletServingCSRApprovedReason, fmt.Sprintf("kubelet serving certificate of the node %s", nodeName))
+}
+
+func (k *KubeletServingCSR) setApproval(ctx context.Context, csr *certificatesv1.CertificateSigningRequest, conditionType certificatesv1.RequestConditionType, reason, message string) error {
+	csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+		Type:           conditionType,
+		Status:         corev1.ConditionTrue,
+		Reason:         reason,
+		Message:        message,
+		LastUpdateTime: metav1.Now(),
+	})
+
+	if err := k.Client.SubResource("approval").Update(ctx, csr); err != nil {
+		return errors.Wrap(err, "cannot update the CSR approval")
+	}
+
+	k.Logger.Info("kubelet serving CSR reviewed", "name", csr.GetName(), "outcome", conditionType, "message", message)
+
+	return nil
+}
+
+// callKubeletServingCSRHook returns the outcome of the verification hook: a 2xx status code approves the CSR,
+// while a 4xx one denies it, with the response body as message.
+func callKubeletServingCSRHook(ctx context.Context, hook *kamajiv1alpha1.KubeletServingCSRVerificationHook, review kubeletServingCSRReview) (bool, string, error) {
+	payload, err := json.Marshal(review)
+	if err != nil {
+		return false, "", err
+	}
+
+	httpClient := &http.Client{Timeout: hook.Timeout.Duration}
+
+	if len(hook.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(hook.CABundle) {
+			return false, "", errors.New("cannot parse the CA bundle")
+		}
+
+		httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return false, "", err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return false, "", err
+	}
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 1024))
+
+	switch {
+	case response.StatusCode >= 200 && response.StatusCode < 300:
+		return true, "", nil
+	case response.StatusCode >= 400 && response.StatusCode < 500:
+		return false, string(body), nil
+	default:
+		return false, "", fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+}
+
+func isPendingKubeletServingCSR(csr *certificatesv1.CertificateSigningRequest) bool {
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName {
+		return false
+	}
+
+	for _, condition := range csr.Status.Conditions {
+		if condition.Type == certificatesv1.CertificateApproved || condition.Type == certificatesv1.CertificateDenied || condition.Type == certificatesv1.CertificateFailed {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (k *KubeletServingCSR) SetupWithManager(mgr manager.Manager) error {
+	// All the events are enqueued with the same request, since the pending CSRs are reviewed as a whole.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "kubelet-serving-csr"}}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("kubelet-serving-csr").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&certificatesv1.CertificateSigningRequest{}, enqueue, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			csr, ok := object.(*certificatesv1.CertificateSigningRequest)
+
+			return ok && isPendingKubeletServingCSR(csr)
+		}))).
+		// The CSRs of the nodes not yet registered are reviewed once they join the cluster.
+		Watches(&corev1.Node{}, enqueue, builder.WithPredicates(predicate.Funcs{
+			DeleteFunc: func(event.DeleteEvent) bool { return false },
+		})).
+		WatchesRawSource(source.Channel(k.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(k)
+}
@@ -24,9 +24,11 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
 	"github.com/clastix/kamaji/controllers/utils"
 	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/logging"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/resources/addons"
 )
@@ -36,6 +38,7 @@ type KubeProxy struct {
 	AdminClient               client.Client
 	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
 	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
 }
 
 func (k *KubeProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
@@ -52,13 +55,24 @@ func (k *KubeProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconci
 		return reconcile.Result{}, err
 	}
 
+	if ready, after := k.ReadinessGate.Ready(ctx); !ready {
+		k.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	var trait *kamajiv1alpha1.AddonApplyTrait
+	if tcp.Spec.Addons.KubeProxy != nil {
+		trait = &tcp.Spec.Addons.KubeProxy.AddonApplyTrait
+	}
+
 	k.Logger.Info("start processing")
 
 	resource := &addons.KubeProxy{Client: k.AdminClient}
 
 	result, handlingErr := resources.Handle(ctx, resource, tcp)
 	if handlingErr != nil {
-		k.Logger.Error(handlingErr, "resource process failed", "resource", resource.GetName())
+		k.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
 
 		return reconcile.Result{}, handlingErr
 	}
@@ -66,7 +80,7 @@ func (k *KubeProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconci
 	if result == controllerutil.OperationResultNone {
 		k.Logger.Info("reconciliation completed")
 
-		return reconcile.Result{}, nil
+		return syncResult(trait), nil
 	}
 
 	if err = utils.UpdateStatus(ctx, k.AdminClient, tcp, resource); err != nil {
@@ -77,7 +91,7 @@ func (k *KubeProxy) Reconcile(ctx context.Context, _ reconcile.Request) (reconci
 
 	k.Logger.Info("reconciliation processed")
 
-	return reconcile.Result{}, nil
+	return syncResult(trait), nil
 }
 
 func (k *KubeProxy) SetupWithManager(mgr manager.Manager) error {
@@ -0,0 +1,54 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var (
+	dataStoreProbeDurationCollector = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "datastore_probe_duration_seconds",
+		Help:      "The duration of the latest DataStore connection probe of the given TenantControlPlane.",
+	}, []string{"tenant"})
+	dataStoreHealthyCollector = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "datastore_healthy",
+		Help:      "Whether the latest DataStore connection probe of the given TenantControlPlane succeeded.",
+	}, []string{"tenant"})
+	kineSlowQueriesCollector = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "kine_slow_queries_total",
+		Help:      "The number of the slow queries logged by the kine containers of the given TenantControlPlane.",
+	}, []string{"tenant"})
+	kineSlowQueryMaxDurationCollector = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "tenantcontrolplane",
+		Name:      "kine_slow_query_max_duration_seconds",
+		Help:      "The duration of the slowest query logged by the kine containers of the given TenantControlPlane since the latest collection.",
+	}, []string{"tenant"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(dataStoreProbeDurationCollector, dataStoreHealthyCollector, kineSlowQueriesCollector, kineSlowQueryMaxDurationCollector)
+}
+
+// ForgetDataStoreHealth deletes the DataStore connection metrics of the given TenantControlPlane,
+// formatted as <namespace>/<name>, once its soot manager has been stopped.
+func ForgetDataStoreHealth(tenant string) {
+	dataStoreProbeDurationCollector.DeleteLabelValues(tenant)
+	dataStoreHealthyCollector.DeleteLabelValues(tenant)
+}
+
+// ForgetKineSlowQueries deletes the kine slow queries metrics of the given TenantControlPlane,
+// formatted as <namespace>/<name>, once its soot manager has been stopped, or the reporting disabled.
+func ForgetKineSlowQueries(tenant string) {
+	kineSlowQueriesCollector.DeleteLabelValues(tenant)
+	kineSlowQueryMaxDurationCollector.DeleteLabelValues(tenant)
+}
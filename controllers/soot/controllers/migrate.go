@@ -38,10 +38,18 @@ type Migrate struct {
 	WebhookNamespace          string
 	WebhookServiceName        string
 	WebhookCABundle           []byte
-	TriggerChannel            chan event.GenericEvent
+	// WebhookFailurePolicy is the failure policy of the freezing webhook, defaulting to Fail:
+	// with the Ignore one, the Tenant Cluster is not frozen anymore when Kamaji cannot be reached.
+	WebhookFailurePolicy admissionregistrationv1.FailurePolicyType
+	// WatchdogLeaseDuration enables the migration watchdog Lease, renewed by the migration Job:
+	// the freezing webhook is removed once the Lease expires, such as when the Job is not running anymore.
+	WatchdogLeaseDuration time.Duration
+	// Backoff computes the delay of the requests enqueued back, such as when waiting for the TenantControlPlane status.
+	Backoff        *utils.Backoff
+	TriggerChannel chan event.GenericEvent
 }
 
-func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+func (m *Migrate) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
 	tcp, err := m.GetTenantControlPlaneFunc()
 	if err != nil {
 		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
@@ -54,14 +62,29 @@ func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 	}
 	// Cannot detect the status of the TenantControlPlane, enqueuing back
 	if tcp.Status.Kubernetes.Version.Status == nil {
-		return reconcile.Result{RequeueAfter: time.Second}, nil
+		return m.Backoff.Requeue(request), nil
 	}
 
+	m.Backoff.Forget(request)
+
+	var requeueAfter time.Duration
+
 	switch *tcp.Status.Kubernetes.Version.Status {
 	case v1alpha1.VersionMigrating:
-		err = m.createOrUpdate(ctx)
+		// Break-glass procedure: the Tenant Cluster is unfrozen, regardless of the migration progress.
+		if _, ok := tcp.GetAnnotations()[v1alpha1.MigrationUnfreezeAnnotation]; ok {
+			m.Logger.Info("the Tenant Cluster is unfrozen due to the break-glass annotation", "annotation", v1alpha1.MigrationUnfreezeAnnotation)
+
+			err = m.cleanup(ctx)
+
+			break
+		}
+
+		requeueAfter, err = m.freeze(ctx)
 	case v1alpha1.VersionReady:
-		err = m.cleanup(ctx)
+		if err = m.cleanup(ctx); err == nil && m.WatchdogLeaseDuration > 0 {
+			err = utilities.DeleteMigrateWatchdog(ctx, m.Client)
+		}
 	}
 
 	if err != nil {
@@ -70,7 +93,32 @@ func (m *Migrate) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile
 		return reconcile.Result{}, err
 	}
 
-	return reconcile.Result{}, nil
+	return reconcile.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// freeze installs the freezing webhook, as long as the migration watchdog Lease is renewed:
+// it's created on behalf of the migration Job when missing, granting it the time to start.
+func (m *Migrate) freeze(ctx context.Context) (time.Duration, error) {
+	if m.WatchdogLeaseDuration == 0 {
+		return 0, m.createOrUpdate(ctx)
+	}
+
+	lease, err := utilities.GetMigrateWatchdog(ctx, m.Client)
+
+	switch {
+	case apierrors.IsNotFound(err):
+		if err = utilities.RenewMigrateWatchdog(ctx, m.Client, "kamaji", m.WatchdogLeaseDuration); err != nil {
+			return 0, errors.Wrap(err, "cannot create the migration watchdog Lease")
+		}
+	case err != nil:
+		return 0, errors.Wrap(err, "cannot retrieve the migration watchdog Lease")
+	case utilities.IsMigrateWatchdogExpired(lease, time.Now()):
+		m.Logger.Info("the migration watchdog Lease has expired, unfreezing the Tenant Cluster", "holder", pointer.Deref(lease.Spec.HolderIdentity, ""))
+
+		return m.WatchdogLeaseDuration, m.cleanup(ctx)
+	}
+
+	return m.WatchdogLeaseDuration / 2, m.createOrUpdate(ctx)
 }
 
 func (m *Migrate) cleanup(ctx context.Context) error {
@@ -85,6 +133,14 @@ func (m *Migrate) cleanup(ctx context.Context) error {
 	return nil
 }
 
+func (m *Migrate) failurePolicy() *admissionregistrationv1.FailurePolicyType {
+	if len(m.WebhookFailurePolicy) == 0 {
+		return pointer.To(admissionregistrationv1.Fail)
+	}
+
+	return pointer.To(m.WebhookFailurePolicy)
+}
+
 func (m *Migrate) createOrUpdate(ctx context.Context) error {
 	obj := m.object()
 
@@ -112,9 +168,7 @@ func (m *Migrate) createOrUpdate(ctx context.Context) error {
 						},
 					},
 				},
-				FailurePolicy: func(v admissionregistrationv1.FailurePolicyType) *admissionregistrationv1.FailurePolicyType {
-					return &v
-				}(admissionregistrationv1.Fail),
+				FailurePolicy: m.failurePolicy(),
 				MatchPolicy: func(v admissionregistrationv1.MatchPolicyType) *admissionregistrationv1.MatchPolicyType {
 					return &v
 				}(admissionregistrationv1.Equivalent),
@@ -153,9 +207,7 @@ func (m *Migrate) createOrUpdate(ctx context.Context) error {
 						},
 					},
 				},
-				FailurePolicy: func(v admissionregistrationv1.FailurePolicyType) *admissionregistrationv1.FailurePolicyType {
-					return &v
-				}(admissionregistrationv1.Fail),
+				FailurePolicy: m.failurePolicy(),
 				MatchPolicy: func(v admissionregistrationv1.MatchPolicyType) *admissionregistrationv1.MatchPolicyType {
 					return &v
 				}(admissionregistrationv1.Equivalent),
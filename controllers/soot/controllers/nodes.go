@@ -0,0 +1,206 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+)
+
+const (
+	// nodeHeartbeatGracePeriod is the default node monitor grace period of the controller manager,
+	// after which a node whose lease has not been renewed is marked as not ready.
+	nodeHeartbeatGracePeriod = 40 * time.Second
+	// nodesResyncInterval is the interval of the heartbeats check, since the node leases are not watched.
+	nodesResyncInterval = time.Minute
+	// maxStaleHeartbeats is the maximum number of the node names with a stale heartbeat reported in the status.
+	maxStaleHeartbeats = 10
+)
+
+// Nodes aggregates the health of the Tenant Cluster worker nodes in the Tenant Control Plane status,
+// providing the fleet view of the workers without querying each Tenant Cluster.
+type Nodes struct {
+	Logger      logr.Logger
+	AdminClient client.Client
+	// Client lists the Tenant Cluster nodes from the soot manager cache.
+	Client client.Client
+	// TenantReader lists the Tenant Cluster node leases without caching them, since they're renewed every 10 seconds.
+	TenantReader              client.Reader
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+}
+
+func (n *Nodes) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := n.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			n.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	status, err := n.aggregate(ctx)
+	if err != nil {
+		n.Logger.Error(err, "cannot aggregate the Tenant Cluster nodes status")
+
+		return reconcile.Result{}, err
+	}
+
+	if current := tcp.Status.Kubernetes.Nodes; current != nil {
+		status.LastUpdate = current.LastUpdate
+		// The status is updated only upon changes, since the trigger is fired by the status updates too.
+		if equality.Semantic.DeepEqual(*current, *status) {
+			return reconcile.Result{RequeueAfter: nodesResyncInterval}, nil
+		}
+	}
+
+	status.LastUpdate = metav1.Now()
+
+	if err = n.updateStatus(ctx, tcp, status); err != nil {
+		n.Logger.Error(err, "cannot update the Tenant Cluster nodes status")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{RequeueAfter: nodesResyncInterval}, nil
+}
+
+func (n *Nodes) aggregate(ctx context.Context) (*kamajiv1alpha1.KubernetesNodesStatus, error) {
+	var nodes corev1.NodeList
+	if err := n.Client.List(ctx, &nodes); err != nil {
+		return nil, errors.Wrap(err, "cannot list the nodes")
+	}
+
+	var leases coordinationv1.LeaseList
+	if err := n.TenantReader.List(ctx, &leases, client.InNamespace(corev1.NamespaceNodeLease)); err != nil {
+		return nil, errors.Wrap(err, "cannot list the node leases")
+	}
+
+	renewals := make(map[string]time.Time, len(leases.Items))
+	for _, lease := range leases.Items {
+		if lease.Spec.RenewTime != nil {
+			renewals[lease.GetName()] = lease.Spec.RenewTime.Time
+		}
+	}
+
+	status := &kamajiv1alpha1.KubernetesNodesStatus{Total: int32(len(nodes.Items))} //nolint:gosec
+
+	versions := map[string]int32{}
+
+	var stale []string
+
+	for _, node := range nodes.Items {
+		if nodeReady(node) {
+			status.Ready++
+		}
+
+		versions[node.Status.NodeInfo.KubeletVersion]++
+		// The nodes without a lease are reported too, since the kubelet creates it upon the registration.
+		if renewal, ok := renewals[node.GetName()]; !ok || time.Since(renewal) > nodeHeartbeatGracePeriod {
+			stale = append(stale, node.GetName())
+		}
+	}
+
+	for version, count := range versions {
+		status.Versions = append(status.Versions, kamajiv1alpha1.KubernetesNodesVersion{Version: version, Count: count})
+	}
+
+	slices.SortFunc(status.Versions, func(a, b kamajiv1alpha1.KubernetesNodesVersion) int {
+		return cmp.Compare(a.Version, b.Version)
+	})
+
+	slices.Sort(stale)
+
+	status.StaleHeartbeatsCount = int32(len(stale)) //nolint:gosec
+	status.StaleHeartbeats = stale[:min(len(stale), maxStaleHeartbeats)]
+
+	return status, nil
+}
+
+func (n *Nodes) updateStatus(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, status *kamajiv1alpha1.KubernetesNodesStatus) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = n.AdminClient.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		tcp.Status.Kubernetes.Nodes = status
+
+		if err = n.AdminClient.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+func nodeReady(node corev1.Node) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func (n *Nodes) SetupWithManager(mgr manager.Manager) error {
+	// The nodes status is updated by the kubelet periodically, and upon each image change:
+	// only the changes affecting the aggregated health are enqueued.
+	healthChanged := builder.WithPredicates(predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldNode, oldOk := e.ObjectOld.(*corev1.Node)
+			newNode, newOk := e.ObjectNew.(*corev1.Node)
+			if !oldOk || !newOk {
+				return true
+			}
+
+			return nodeReady(*oldNode) != nodeReady(*newNode) || oldNode.Status.NodeInfo.KubeletVersion != newNode.Status.NodeInfo.KubeletVersion
+		},
+	})
+	// All the events are enqueued with the same request, since the nodes are aggregated as a whole:
+	// the Tenant Control Plane triggers too, preventing multiple resyncs.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "nodes"}}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("nodes").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&corev1.Node{}, enqueue, healthChanged).
+		WatchesRawSource(source.Channel(n.TriggerChannel, enqueue)).
+		Complete(n)
+}
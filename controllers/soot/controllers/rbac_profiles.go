@@ -0,0 +1,107 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+// RBACProfiles reconciles the bindings of the RBAC profiles declared for the Tenant Cluster.
+type RBACProfiles struct {
+	Logger                    logr.Logger
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
+}
+
+func (r *RBACProfiles) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := r.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			r.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if ready, after := r.ReadinessGate.Ready(ctx); !ready {
+		r.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	r.Logger.Info("start processing")
+
+	resource := &addons.RBACProfiles{Client: r.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		r.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result == controllerutil.OperationResultNone {
+		r.Logger.Info("reconciliation completed")
+
+		return reconcile.Result{}, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, r.AdminClient, tcp, resource); err != nil {
+		r.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, err
+	}
+
+	r.Logger.Info("reconciliation processed")
+
+	return reconcile.Result{}, nil
+}
+
+func (r *RBACProfiles) SetupWithManager(mgr manager.Manager) error {
+	hasProfile := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, ok := object.GetLabels()[constants.RBACProfileLabelKey]
+
+		return ok
+	}))
+	// All the events are enqueued with the same request, since the bindings are reconciled as a whole.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "rbac-profiles"}}}
+	})
+	// The RoleBindings are not watched, since the soot user in least-privilege mode
+	// is allowed to list them only in the kube-system, and kube-public, namespaces.
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("rbac-profiles").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&rbacv1.ClusterRoleBinding{}, enqueue, hasProfile).
+		WatchesRawSource(source.Channel(r.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(r)
+}
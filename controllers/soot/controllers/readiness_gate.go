@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+const (
+	readinessCheckTTL     = 5 * time.Second
+	readinessCheckTimeout = 5 * time.Second
+	readinessRetryBase    = time.Second
+	readinessRetryMax     = time.Minute
+)
+
+// ReadinessGate delays the reconciliation of the soot controllers until the Tenant Control Plane API Server
+// reports itself as ready, preventing the kubeadm phases, and the addons, to fail noisily upon its start-up.
+// The gate is shared by all the controllers of a soot manager: the readiness check outcome is cached
+// for a short period, thus the API Server is queried once per sync, rather than once per controller.
+type ReadinessGate struct {
+	// RESTClient is the REST client of the Tenant Cluster.
+	RESTClient rest.Interface
+
+	mu        sync.Mutex
+	checkedAt time.Time
+	ready     bool
+	failures  int
+}
+
+// Ready returns whether the API Server is ready and, if not, the delay before retrying,
+// which is exponentially increased upon each consecutive failed check.
+// A nil gate is always considered ready.
+func (r *ReadinessGate) Ready(ctx context.Context) (bool, time.Duration) {
+	if r == nil {
+		return true, 0
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.checkedAt.IsZero() || time.Since(r.checkedAt) >= readinessCheckTTL {
+		r.check(ctx)
+	}
+
+	if r.ready {
+		return true, 0
+	}
+
+	return false, r.retryAfter()
+}
+
+func (r *ReadinessGate) check(ctx context.Context) {
+	ctx, cancelFn := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancelFn()
+
+	r.checkedAt = time.Now()
+
+	if err := r.RESTClient.Get().AbsPath("/readyz").Do(ctx).Error(); err != nil {
+		r.ready = false
+		r.failures++
+
+		return
+	}
+
+	r.ready, r.failures = true, 0
+}
+
+func (r *ReadinessGate) retryAfter() time.Duration {
+	delay := readinessRetryBase
+
+	for i := 1; i < r.failures && delay < readinessRetryMax; i++ {
+		delay *= 2
+	}
+
+	return min(delay, readinessRetryMax)
+}
@@ -0,0 +1,104 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/addons"
+)
+
+// SignedDiscovery rotates the discovery tokens signing the cluster-info ConfigMap of the Tenant Cluster,
+// requeueing the request when the current token must be replaced.
+type SignedDiscovery struct {
+	Logger                    logr.Logger
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
+}
+
+func (r *SignedDiscovery) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := r.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			r.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		return reconcile.Result{}, err
+	}
+
+	if ready, after := r.ReadinessGate.Ready(ctx); !ready {
+		r.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	r.Logger.Info("start processing")
+
+	resource := &addons.SignedDiscovery{Client: r.AdminClient}
+
+	result, handlingErr := resources.Handle(ctx, resource, tcp)
+	if handlingErr != nil {
+		r.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
+
+		return reconcile.Result{}, handlingErr
+	}
+
+	if result != controllerutil.OperationResultNone {
+		if err = utils.UpdateStatus(ctx, r.AdminClient, tcp, resource); err != nil {
+			r.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	r.Logger.Info("reconciliation completed", "nextRotation", resource.RequeueAfter())
+
+	return reconcile.Result{RequeueAfter: resource.RequeueAfter()}, nil
+}
+
+func (r *SignedDiscovery) SetupWithManager(mgr manager.Manager) error {
+	isDiscoveryToken := builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+		_, ok := object.GetLabels()[constants.SignedDiscoveryLabelKey]
+
+		return object.GetNamespace() == metav1.NamespaceSystem && ok
+	}))
+	// All the events are enqueued with the same request, since the tokens are rotated as a whole.
+	enqueue := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "signed-discovery"}}}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("signed-discovery").
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		Watches(&corev1.Secret{}, enqueue, isDiscoveryToken).
+		WatchesRawSource(source.Channel(r.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(r)
+}
@@ -0,0 +1,20 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// syncResult requires a periodic re-sync of the addon when a reconcile interval is declared in its sync policy,
+// the nil trait referring to a disabled addon.
+func syncResult(trait *kamajiv1alpha1.AddonApplyTrait) reconcile.Result {
+	if trait == nil {
+		return reconcile.Result{}
+	}
+
+	return reconcile.Result{RequeueAfter: trait.GetReconcileInterval()}
+}
@@ -0,0 +1,132 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers"
+	sooterrors "github.com/clastix/kamaji/controllers/soot/controllers/errors"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/wireguard"
+)
+
+// WireGuardAgent reconciles the WireGuard peers, and agents, of the Tenant Cluster:
+// the peers are provisioned again upon each change of the worker nodes, such as their addition or removal.
+type WireGuardAgent struct {
+	Logger                    logr.Logger
+	AdminClient               client.Client
+	GetTenantControlPlaneFunc utils.TenantControlPlaneRetrievalFn
+	TriggerChannel            chan event.GenericEvent
+	ReadinessGate             *ReadinessGate
+}
+
+func (w *WireGuardAgent) Reconcile(ctx context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	tcp, err := w.GetTenantControlPlaneFunc()
+	if err != nil {
+		if errors.Is(err, sooterrors.ErrPausedReconciliation) {
+			w.Logger.Info(err.Error())
+
+			return reconcile.Result{}, nil
+		}
+
+		w.Logger.Error(err, "cannot retrieve TenantControlPlane")
+
+		return reconcile.Result{}, err
+	}
+
+	if ready, after := w.ReadinessGate.Ready(ctx); !ready {
+		w.Logger.Info("waiting for the API Server readiness", "retryAfter", after)
+
+		return reconcile.Result{RequeueAfter: after}, nil
+	}
+
+	var trait *kamajiv1alpha1.AddonApplyTrait
+	if tcp.Spec.Addons.WireGuard != nil {
+		trait = &tcp.Spec.Addons.WireGuard.AddonApplyTrait
+	}
+
+	for _, resource := range controllers.GetExternalWireGuardResources(w.AdminClient) {
+		w.Logger.Info("start processing", logging.ResourceKey, resource.GetName())
+
+		result, handlingErr := resources.Handle(ctx, resource, tcp)
+		if handlingErr != nil {
+			w.Logger.Error(handlingErr, "resource process failed", logging.ResourceKey, resource.GetName())
+
+			return reconcile.Result{}, handlingErr
+		}
+
+		if result == controllerutil.OperationResultNone {
+			w.Logger.Info("resource processed", logging.ResourceKey, resource.GetName())
+
+			continue
+		}
+
+		if err = utils.UpdateStatus(ctx, w.AdminClient, tcp, resource); err != nil {
+			w.Logger.Error(err, "update status failed", logging.ResourceKey, resource.GetName())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	w.Logger.Info("reconciliation completed")
+
+	return syncResult(trait), nil
+}
+
+func (w *WireGuardAgent) SetupWithManager(mgr manager.Manager) error {
+	// All the events are enqueued with the same request, since the peers are computed from the whole set of nodes.
+	enqueueAgent := handler.EnqueueRequestsFromMapFunc(func(context.Context, client.Object) []reconcile.Request {
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Namespace: wireguard.AgentNamespace,
+					Name:      wireguard.AgentName,
+				},
+			},
+		}
+	})
+
+	return controllerruntime.NewControllerManagedBy(mgr).
+		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
+		For(&appsv1.DaemonSet{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == wireguard.AgentName && object.GetNamespace() == wireguard.AgentNamespace
+		}))).
+		Watches(&corev1.Secret{}, enqueueAgent, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			return object.GetName() == wireguard.PeersSecretName && object.GetNamespace() == wireguard.AgentNamespace
+		}))).
+		Watches(&corev1.Node{}, enqueueAgent, builder.WithPredicates(predicate.Funcs{
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldNode, newNode := e.ObjectOld.(*corev1.Node), e.ObjectNew.(*corev1.Node) //nolint:forcetypeassert
+
+				return !slices.Equal(oldNode.Spec.PodCIDRs, newNode.Spec.PodCIDRs) ||
+					!equality.Semantic.DeepEqual(oldNode.Status.Addresses, newNode.Status.Addresses)
+			},
+		})).
+		WatchesRawSource(source.Channel(w.TriggerChannel, &handler.EnqueueRequestForObject{})).
+		Complete(w)
+}
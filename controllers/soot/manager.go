@@ -6,9 +6,15 @@ package soot
 import (
 	"context"
 	"fmt"
+	"runtime/pprof"
+	"slices"
+	"sync"
 	"time"
 
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/utils/ptr"
@@ -31,14 +37,20 @@ import (
 	"github.com/clastix/kamaji/controllers/soot/controllers"
 	"github.com/clastix/kamaji/controllers/soot/controllers/errors"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/faultinjection"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/notifications"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
 type sootItem struct {
-	triggers    []chan event.GenericEvent
-	cancelFn    context.CancelFunc
-	completedCh chan struct{}
+	triggers      []chan event.GenericEvent
+	skippedPhases []kamajiv1alpha1.KubeadmPhaseName
+	qps           float32
+	burst         int
+	cancelFn      context.CancelFunc
+	completedCh   chan struct{}
 }
 
 type sootMap map[string]sootItem
@@ -46,6 +58,10 @@ type sootMap map[string]sootItem
 const (
 	sootManagerAnnotation       = "kamaji.clastix.io/soot"
 	sootManagerFailedAnnotation = "failed"
+	// sootCrashLoopThreshold is the number of consecutive failures of a soot manager notified as a crash loop.
+	sootCrashLoopThreshold = 3
+	// sootStableRunDuration resets the consecutive failures of a soot manager which has been running for longer.
+	sootStableRunDuration = 10 * time.Minute
 )
 
 type Manager struct {
@@ -53,20 +69,40 @@ type Manager struct {
 	// sootManagerErrChan is the channel that is going to be used
 	// when the soot manager cannot start due to any kind of problem.
 	sootManagerErrChan chan event.GenericEvent
+	// failures counts the consecutive failures of the soot managers, updated by their goroutines.
+	failures     map[string]int
+	failuresLock sync.Mutex
 
 	MigrateCABundle         []byte
 	MigrateServiceName      string
 	MigrateServiceNamespace string
-	AdminClient             client.Client
+	// MigrateWebhookFailurePolicy is the failure policy of the webhook freezing the Tenant Cluster during the migrations.
+	MigrateWebhookFailurePolicy admissionregistrationv1.FailurePolicyType
+	// MigrateWatchdogLeaseDuration enables the removal of the freezing webhook once the migration Job stops renewing its Lease.
+	MigrateWatchdogLeaseDuration time.Duration
+	AdminClient                  client.Client
+	// AdminClientset reads the logs of the Control Plane pods, such as the kine slow queries.
+	AdminClientset kubernetes.Interface
+	// Backoff computes the delay of the requests enqueued back, such as when waiting for the soot kubeconfig.
+	Backoff *utils.Backoff
+	// APIReader is used to retrieve the TenantControlPlane objects not yet updated in the informer cache.
+	APIReader client.Reader
+	// LeastPrivilege starts the soot managers with the scoped soot kubeconfig,
+	// restricting the cache to the namespaces the soot user is allowed to reconcile.
+	LeastPrivilege bool
+	// Notifier delivers the soot managers crash loops to the NotificationSink objects: it's optional.
+	Notifier *notifications.Dispatcher
+	// FaultInjector injects the failures of the Tenant Cluster API Server requests, for development purposes: it's optional.
+	FaultInjector *faultinjection.Injector
 }
 
 // retrieveTenantControlPlane is the function used to let an underlying controller of the soot manager
-// to retrieve its parent TenantControlPlane definition, required to understand which actions must be performed.
+// to retrieve its parent TenantControlPlane definition, required to understand which actions must be performed:
+// it's served by the informer cache, unless Kamaji has written a newer version not yet cached.
 func (m *Manager) retrieveTenantControlPlane(ctx context.Context, request reconcile.Request) utils.TenantControlPlaneRetrievalFn {
 	return func() (*kamajiv1alpha1.TenantControlPlane, error) {
-		tcp := &kamajiv1alpha1.TenantControlPlane{}
-
-		if err := m.AdminClient.Get(ctx, request.NamespacedName, tcp); err != nil {
+		tcp, err := utils.GetTenantControlPlane(ctx, m.AdminClient, m.APIReader, request.NamespacedName)
+		if err != nil {
 			return nil, err
 		}
 
@@ -91,12 +127,24 @@ func (m *Manager) cleanup(ctx context.Context, req reconcile.Request, tenantCont
 
 				controllerutil.RemoveFinalizer(tcp, finalizers.SootFinalizer)
 
-				return m.AdminClient.Update(ctx, tcp)
+				if tcpErr = m.AdminClient.Update(ctx, tcp); tcpErr != nil {
+					return tcpErr
+				}
+
+				utils.SetConsistencyToken(tcp)
+
+				return nil
 			})
 		}()
 	}
 
 	tcpName := req.NamespacedName.String()
+	// The failures of the deleted TenantControlPlane objects are forgotten, the ones of the restarted managers retained.
+	if tenantControlPlane == nil || tenantControlPlane.GetDeletionTimestamp() != nil {
+		m.failuresLock.Lock()
+		delete(m.failures, tcpName)
+		m.failuresLock.Unlock()
+	}
 
 	v, ok := m.sootMap[tcpName]
 	if !ok {
@@ -124,6 +172,9 @@ func (m *Manager) cleanup(ctx context.Context, req reconcile.Request, tenantCont
 	}
 
 	delete(m.sootMap, tcpName)
+	controllers.ForgetDataStoreHealth(tcpName)
+	controllers.ForgetKineSlowQueries(tcpName)
+	sootManagersRunningCollector.Set(float64(len(m.sootMap)))
 
 	return nil
 }
@@ -143,7 +194,13 @@ func (m *Manager) retryTenantControlPlaneAnnotations(ctx context.Context, reques
 
 		tcp.SetAnnotations(tcp.Annotations)
 
-		return m.AdminClient.Update(ctx, tcp)
+		if err = m.AdminClient.Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
 	})
 }
 
@@ -173,9 +230,12 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	// the soot manager if this is already registered.
 	v, ok := m.sootMap[request.String()]
 	if ok {
+		qps, burst, _ := utilities.TenantClientRateLimits(tcp)
+
 		switch {
 		case tcp.Annotations != nil && tcp.Annotations[sootManagerAnnotation] == sootManagerFailedAnnotation:
 			delete(m.sootMap, request.String())
+			sootManagersRunningCollector.Set(float64(len(m.sootMap)))
 
 			return reconcile.Result{}, m.retryTenantControlPlaneAnnotations(ctx, request, func(annotations map[string]string) {
 				delete(annotations, sootManagerAnnotation)
@@ -183,6 +243,17 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		case tcpStatus == kamajiv1alpha1.VersionCARotating:
 			// The TenantControlPlane CA has been rotated, it means the running manager
 			// must be restarted to avoid certificate signed by unknown authority errors.
+			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
+		case !slices.Equal(v.skippedPhases, tcp.SkippedKubeadmPhases()):
+			// The kubeadm phases are registered upon the manager start:
+			// it must be restarted to honor the updated selection.
+			log.FromContext(ctx).Info("restarting the soot manager, the skipped kubeadm phases have changed")
+
+			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
+		case v.qps != qps || v.burst != burst:
+			// The client rate limits are set upon the manager start, as for the kubeadm phases.
+			log.FromContext(ctx).Info("restarting the soot manager, the client rate limits have changed")
+
 			return reconcile.Result{}, m.cleanup(ctx, request, tcp)
 		case tcpStatus == kamajiv1alpha1.VersionNotReady:
 			// The TenantControlPlane is in non-ready mode, or marked for deletion:
@@ -198,6 +269,8 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 
 				go utils.TriggerChannel(ctx, trigger, shrunkTCP)
 			}
+
+			m.Backoff.Forget(request)
 		}
 
 		return reconcile.Result{}, nil
@@ -218,7 +291,21 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 			return nil
 		})
 
-		return reconcile.Result{RequeueAfter: time.Second}, finalizerErr
+		return m.Backoff.Requeue(request), finalizerErr
+	}
+	// The scoped kubeconfig is generated by the TenantControlPlane controller:
+	// the soot manager must not fall back to the admin one in least-privilege mode.
+	leastPrivilege := len(tcp.Status.KubeConfig.Soot.SecretName) > 0
+	if m.LeastPrivilege && !leastPrivilege {
+		log.FromContext(ctx).Info("waiting for the soot kubeconfig generation")
+
+		return m.Backoff.Requeue(request), nil
+	}
+
+	if leastPrivilege {
+		if err = m.grantSootPermissions(ctx, tcp); err != nil {
+			return reconcile.Result{}, err
+		}
 	}
 	// Generating the manager and starting it:
 	// in case of any error, reconciling the request to start it back from the beginning.
@@ -227,6 +314,8 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		return reconcile.Result{}, err
 	}
 
+	m.FaultInjector.WrapConfig(tcpRest)
+
 	tcpCtx, tcpCancelFn := context.WithCancel(ctx)
 	defer func() {
 		// If the reconciliation fails, we don't need to get a potential dangling goroutine.
@@ -236,8 +325,9 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	}()
 
 	mgr, err := controllerruntime.NewManager(tcpRest, controllerruntime.Options{
-		Logger: log.Log.WithName(fmt.Sprintf("soot_%s_%s", tcp.GetNamespace(), tcp.GetName())),
+		Logger: log.Log.WithName(fmt.Sprintf("soot_%s_%s", tcp.GetNamespace(), tcp.GetName())).WithValues(logging.TenantKey, request.String()),
 		Scheme: m.AdminClient.Scheme(),
+		Cache:  cacheOptions(),
 		Metrics: metricsserver.Options{
 			BindAddress: "0",
 		},
@@ -250,6 +340,14 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(tcpRest)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	// The readiness gate is shared by the controllers reconciling the Tenant Cluster objects,
+	// checking the API Server readiness once per sync, rather than once per controller.
+	readinessGate := &controllers.ReadinessGate{RESTClient: discoveryClient.RESTClient()}
 	//
 	// Register all the controllers of the soot here:
 	//
@@ -257,9 +355,12 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 		WebhookNamespace:          m.MigrateServiceNamespace,
 		WebhookServiceName:        m.MigrateServiceName,
 		WebhookCABundle:           m.MigrateCABundle,
+		WebhookFailurePolicy:      m.MigrateWebhookFailurePolicy,
+		WatchdogLeaseDuration:     m.MigrateWatchdogLeaseDuration,
+		Backoff:                   m.Backoff,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
 		Client:                    mgr.GetClient(),
-		Logger:                    mgr.GetLogger().WithName("migrate"),
+		Logger:                    mgr.GetLogger().WithName("migrate").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "migrate"),
 	}
 	if err = migrate.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
@@ -268,18 +369,31 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	konnectivityAgent := &controllers.KonnectivityAgent{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Logger:                    mgr.GetLogger().WithName("konnectivity_agent"),
+		Logger:                    mgr.GetLogger().WithName("konnectivity_agent").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "konnectivity_agent"),
 		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
 	if err = konnectivityAgent.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	wireGuardAgent := &controllers.WireGuardAgent{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("wireguard_agent").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "wireguard_agent"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
+	}
+	if err = wireGuardAgent.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
 	kubeProxy := &controllers.KubeProxy{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Logger:                    mgr.GetLogger().WithName("kube_proxy"),
+		Logger:                    mgr.GetLogger().WithName("kube_proxy").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "kube_proxy"),
 		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
 	if err = kubeProxy.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
@@ -288,65 +402,187 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	coreDNS := &controllers.CoreDNS{
 		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Logger:                    mgr.GetLogger().WithName("coredns"),
+		Logger:                    mgr.GetLogger().WithName("coredns").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "coredns"),
 		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
 	if err = coreDNS.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	uploadKubeadmConfig := &controllers.KubeadmPhase{
+	frontProxy := &controllers.FrontProxy{
+		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Phase: &resources.KubeadmPhase{
-			Client: m.AdminClient,
-			Phase:  resources.PhaseUploadConfigKubeadm,
-		},
-		TriggerChannel: make(chan event.GenericEvent),
+		Logger:                    mgr.GetLogger().WithName("front_proxy").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "front_proxy"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
-	if err = uploadKubeadmConfig.SetupWithManager(mgr); err != nil {
+	if err = frontProxy.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	uploadKubeletConfig := &controllers.KubeadmPhase{
+	flowControl := &controllers.FlowControl{
+		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Phase: &resources.KubeadmPhase{
-			Client: m.AdminClient,
-			Phase:  resources.PhaseUploadConfigKubelet,
-		},
-		TriggerChannel: make(chan event.GenericEvent),
+		Logger:                    mgr.GetLogger().WithName("flow_control").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "flow_control"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
-	if err = uploadKubeletConfig.SetupWithManager(mgr); err != nil {
+	if err = flowControl.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	bootstrapToken := &controllers.KubeadmPhase{
+	rbacProfiles := &controllers.RBACProfiles{
+		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Phase: &resources.KubeadmPhase{
-			Client: m.AdminClient,
-			Phase:  resources.PhaseBootstrapToken,
-		},
-		TriggerChannel: make(chan event.GenericEvent),
+		Logger:                    mgr.GetLogger().WithName("rbac_profiles").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "rbac_profiles"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
 	}
-	if err = bootstrapToken.SetupWithManager(mgr); err != nil {
+	if err = rbacProfiles.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
 
-	kubeadmRbac := &controllers.KubeadmPhase{
+	signedDiscovery := &controllers.SignedDiscovery{
+		AdminClient:               m.AdminClient,
 		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
-		Phase: &resources.KubeadmPhase{
-			Client: m.AdminClient,
-			Phase:  resources.PhaseClusterAdminRBAC,
-		},
-		TriggerChannel: make(chan event.GenericEvent),
+		Logger:                    mgr.GetLogger().WithName("signed_discovery").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "signed_discovery"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
+	}
+	if err = signedDiscovery.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	oidcDiscovery := &controllers.OIDCDiscovery{
+		AdminClient:               m.AdminClient,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("oidc_discovery").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "oidc_discovery"),
+		TriggerChannel:            make(chan event.GenericEvent),
+		ReadinessGate:             readinessGate,
+	}
+	if err = oidcDiscovery.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	konnectivityHealth := &controllers.KonnectivityHealth{
+		AdminClient:               m.AdminClient,
+		APIReader:                 m.APIReader,
+		Client:                    mgr.GetClient(),
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("konnectivity_health").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "konnectivity_health"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = konnectivityHealth.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
 	}
-	if err = kubeadmRbac.SetupWithManager(mgr); err != nil {
+
+	dataStoreHealth := &controllers.DataStoreHealth{
+		AdminClient:               m.AdminClient,
+		RESTClient:                discoveryClient.RESTClient(),
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("datastore_health").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "datastore_health"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = dataStoreHealth.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	kineSlowQueries := &controllers.KineSlowQueries{
+		AdminClient:               m.AdminClient,
+		AdminClientset:            m.AdminClientset,
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("kine_slow_queries").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "kine_slow_queries"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = kineSlowQueries.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	activity := &controllers.Activity{
+		AdminClient:               m.AdminClient,
+		TenantReader:              mgr.GetAPIReader(),
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("activity").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "activity"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = activity.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	nodes := &controllers.Nodes{
+		AdminClient:               m.AdminClient,
+		Client:                    mgr.GetClient(),
+		TenantReader:              mgr.GetAPIReader(),
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("nodes").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "nodes"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = nodes.SetupWithManager(mgr); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	kubeletServingCSR := &controllers.KubeletServingCSR{
+		Client:                    mgr.GetClient(),
+		GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+		Logger:                    mgr.GetLogger().WithName("kubelet_serving_csr").WithValues(logging.NamespaceKey, request.Namespace, logging.ControllerKey, "kubelet_serving_csr"),
+		TriggerChannel:            make(chan event.GenericEvent),
+	}
+	if err = kubeletServingCSR.SetupWithManager(mgr); err != nil {
 		return reconcile.Result{}, err
 	}
+
+	kubeadmPhases := map[kamajiv1alpha1.KubeadmPhaseName]*resources.KubeadmPhase{
+		kamajiv1alpha1.KubeadmPhaseUploadConfigKubeadm: {Client: m.AdminClient, Phase: resources.PhaseUploadConfigKubeadm},
+		kamajiv1alpha1.KubeadmPhaseUploadConfigKubelet: {Client: m.AdminClient, Phase: resources.PhaseUploadConfigKubelet},
+		kamajiv1alpha1.KubeadmPhaseBootstrapToken:      {Client: m.AdminClient, Phase: resources.PhaseBootstrapToken},
+		kamajiv1alpha1.KubeadmPhaseClusterAdminRBAC:    {Client: m.AdminClient, Phase: resources.PhaseClusterAdminRBAC},
+	}
+	// The soot user is not allowed to bind the cluster-admin ClusterRole:
+	// in least-privilege mode, the binding is ensured along with the soot permissions.
+	if leastPrivilege {
+		delete(kubeadmPhases, kamajiv1alpha1.KubeadmPhaseClusterAdminRBAC)
+	}
+
+	skippedPhases := tcp.SkippedKubeadmPhases()
+
+	var kubeadmTriggers []chan event.GenericEvent
+
+	for name, phase := range kubeadmPhases {
+		if slices.Contains(skippedPhases, name) {
+			continue
+		}
+
+		kubeadmPhase := &controllers.KubeadmPhase{
+			GetTenantControlPlaneFunc: m.retrieveTenantControlPlane(tcpCtx, request),
+			Phase:                     phase,
+			TriggerChannel:            make(chan event.GenericEvent),
+			ReadinessGate:             readinessGate,
+		}
+		if err = kubeadmPhase.SetupWithManager(mgr); err != nil {
+			return reconcile.Result{}, err
+		}
+
+		kubeadmTriggers = append(kubeadmTriggers, kubeadmPhase.TriggerChannel)
+	}
+
 	completedCh := make(chan struct{})
 	// Starting the manager
 	go func() {
-		if err = mgr.Start(tcpCtx); err != nil {
+		startedAt := time.Now()
+		// The soot manager goroutines are labelled with the TenantControlPlane,
+		// allowing to attribute their goroutines, and CPU samples, using the profiler.
+		pprof.Do(tcpCtx, pprof.Labels(sootTenantLabel, request.NamespacedName.String()), func(labelledCtx context.Context) {
+			err = mgr.Start(labelledCtx)
+		})
+
+		if err != nil {
 			log.FromContext(ctx).Error(err, "unable to start soot manager")
+
+			if failures := m.recordFailure(request.String(), time.Since(startedAt)); failures >= sootCrashLoopThreshold {
+				m.Notifier.Notify(request.NamespacedName, kamajiv1alpha1.NotificationEventSootCrashLooping,
+					fmt.Sprintf("the soot manager failed %d consecutive times, last error: %s", failures, err.Error()))
+			}
 			// The sootManagerAnnotation is used to propagate the error between reconciliations with its state:
 			// this is required to avoid mutex and prevent concurrent read/write on the soot map
 			annotationErr := m.retryTenantControlPlaneAnnotations(ctx, request, func(annotations map[string]string) {
@@ -369,25 +605,65 @@ func (m *Manager) Reconcile(ctx context.Context, request reconcile.Request) (res
 	}()
 
 	m.sootMap[request.NamespacedName.String()] = sootItem{
-		triggers: []chan event.GenericEvent{
+		triggers: append([]chan event.GenericEvent{
 			migrate.TriggerChannel,
 			konnectivityAgent.TriggerChannel,
+			wireGuardAgent.TriggerChannel,
 			kubeProxy.TriggerChannel,
 			coreDNS.TriggerChannel,
-			uploadKubeadmConfig.TriggerChannel,
-			uploadKubeletConfig.TriggerChannel,
-			bootstrapToken.TriggerChannel,
-		},
-		cancelFn:    tcpCancelFn,
-		completedCh: completedCh,
+			frontProxy.TriggerChannel,
+			flowControl.TriggerChannel,
+			rbacProfiles.TriggerChannel,
+			signedDiscovery.TriggerChannel,
+			oidcDiscovery.TriggerChannel,
+			konnectivityHealth.TriggerChannel,
+			dataStoreHealth.TriggerChannel,
+			kineSlowQueries.TriggerChannel,
+			activity.TriggerChannel,
+			nodes.TriggerChannel,
+			kubeletServingCSR.TriggerChannel,
+		}, kubeadmTriggers...),
+		skippedPhases: skippedPhases,
+		qps:           tcpRest.QPS,
+		burst:         tcpRest.Burst,
+		cancelFn:      tcpCancelFn,
+		completedCh:   completedCh,
+	}
+	sootManagersRunningCollector.Set(float64(len(m.sootMap)))
+
+	return m.Backoff.Requeue(request), nil
+}
+
+// recordFailure returns the consecutive failures of the given soot manager, including the current one:
+// the count is reset when the failed manager has been running long enough to be considered recovered.
+func (m *Manager) recordFailure(name string, runDuration time.Duration) int {
+	m.failuresLock.Lock()
+	defer m.failuresLock.Unlock()
+
+	if runDuration > sootStableRunDuration {
+		m.failures[name] = 0
+	}
+
+	m.failures[name]++
+
+	return m.failures[name]
+}
+
+// grantSootPermissions uses the admin kubeconfig to grant the soot user the permissions required by the soot controllers:
+// this is the only interaction with the Tenant Cluster performed with the admin credentials.
+func (m *Manager) grantSootPermissions(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	adminClient, err := utilities.GetTenantAdminClient(ctx, m.AdminClient, tcp)
+	if err != nil {
+		return fmt.Errorf("cannot create the Tenant Cluster admin client: %w", err)
 	}
 
-	return reconcile.Result{RequeueAfter: time.Second}, nil
+	return resources.EnsureSootRBAC(ctx, adminClient)
 }
 
 func (m *Manager) SetupWithManager(mgr manager.Manager) error {
 	m.sootManagerErrChan = make(chan event.GenericEvent)
 	m.sootMap = make(map[string]sootItem)
+	m.failures = make(map[string]int)
 
 	return controllerruntime.NewControllerManagedBy(mgr).
 		WithOptions(controller.TypedOptions[reconcile.Request]{SkipNameValidation: ptr.To(true)}).
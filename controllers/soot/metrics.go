@@ -0,0 +1,82 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package soot
+
+import (
+	"bytes"
+	"runtime/pprof"
+
+	"github.com/google/pprof/profile"
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// sootTenantLabel is the profiler label attached to the goroutines of each soot manager,
+// allowing to attribute both the goroutines, and the CPU samples, to the owning TenantControlPlane.
+const sootTenantLabel = "tenant"
+
+var (
+	sootManagersRunningCollector = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: "kamaji",
+		Subsystem: "soot",
+		Name:      "managers_running",
+		Help:      "The soot managers currently running, one per TenantControlPlane.",
+	})
+	sootGoroutinesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName("kamaji", "soot", "manager_goroutines"),
+		"The goroutines spawned by the soot manager of the given TenantControlPlane.",
+		[]string{"tenant"}, nil,
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(sootManagersRunningCollector, sootGoroutinesCollector{})
+}
+
+// sootGoroutinesCollector computes the goroutines of each soot manager upon scraping,
+// grouping the samples of the goroutine profile by the tenant label.
+type sootGoroutinesCollector struct{}
+
+func (sootGoroutinesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- sootGoroutinesDesc
+}
+
+func (sootGoroutinesCollector) Collect(ch chan<- prometheus.Metric) {
+	goroutines, err := sootGoroutines()
+	if err != nil {
+		ch <- prometheus.NewInvalidMetric(sootGoroutinesDesc, err)
+
+		return
+	}
+
+	for tenant, count := range goroutines {
+		ch <- prometheus.MustNewConstMetric(sootGoroutinesDesc, prometheus.GaugeValue, float64(count), tenant)
+	}
+}
+
+func sootGoroutines() (map[string]int64, error) {
+	var buf bytes.Buffer
+
+	if err := pprof.Lookup("goroutine").WriteTo(&buf, 0); err != nil {
+		return nil, err
+	}
+
+	p, err := profile.Parse(&buf)
+	if err != nil {
+		return nil, err
+	}
+
+	goroutines := make(map[string]int64)
+
+	for _, sample := range p.Sample {
+		tenants := sample.Label[sootTenantLabel]
+		if len(tenants) == 0 || len(sample.Value) == 0 {
+			continue
+		}
+
+		goroutines[tenants[0]] += sample.Value[0]
+	}
+
+	return goroutines, nil
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/supportbundle"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// SupportBundleSecretKey is the key of the support bundle Secret containing the gzipped tarball.
+	SupportBundleSecretKey = "bundle.tar.gz"
+	// supportBundleMaxSize prevents exceeding the Secret size limit, enforced by etcd.
+	supportBundleMaxSize = 1000 * 1024
+)
+
+// SupportBundle collects the support bundle of the Tenant Control Planes annotated with the SupportBundleAnnotation,
+// storing it in the <name>-support-bundle Secret owned by the Tenant Control Plane.
+type SupportBundle struct {
+	Client    client.Client
+	Collector *supportbundle.Collector
+	Recorder  record.EventRecorder
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list
+//+kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+//+kubebuilder:rbac:groups="",resources=events,verbs=list
+
+func (r *SupportBundle) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := r.Client.Get(ctx, request.NamespacedName, &tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+
+	if _, ok := tcp.GetAnnotations()[kamajiv1alpha1.SupportBundleAnnotation]; !ok {
+		return reconcile.Result{}, nil
+	}
+
+	logger.Info("collecting the support bundle")
+
+	var buf bytes.Buffer
+	if err := r.Collector.Collect(ctx, &tcp, &buf); err != nil {
+		logger.Error(err, "cannot collect the support bundle")
+
+		return reconcile.Result{}, err
+	}
+
+	if buf.Len() > supportBundleMaxSize {
+		r.Recorder.Event(&tcp, corev1.EventTypeWarning, "SupportBundleTooLarge",
+			fmt.Sprintf("the support bundle is %d bytes, exceeding the Secret size limit: collect it with kamajictl support-bundle", buf.Len()))
+
+		return reconcile.Result{}, r.removeAnnotation(ctx, &tcp)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      tcp.GetName() + "-support-bundle",
+			Namespace: tcp.GetNamespace(),
+		},
+	}
+
+	if _, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, secret, func() error {
+		secret.Data = map[string][]byte{SupportBundleSecretKey: buf.Bytes()}
+
+		return controllerutil.SetControllerReference(&tcp, secret, r.Client.Scheme())
+	}); err != nil {
+		logger.Error(err, "cannot store the support bundle")
+
+		return reconcile.Result{}, err
+	}
+
+	r.Recorder.Event(&tcp, corev1.EventTypeNormal, "SupportBundleCollected",
+		fmt.Sprintf("the support bundle has been stored in the Secret %s, key %s", secret.GetName(), SupportBundleSecretKey))
+
+	logger.Info("support bundle collected", "secret", secret.GetName())
+
+	return reconcile.Result{}, r.removeAnnotation(ctx, &tcp)
+}
+
+func (r *SupportBundle) removeAnnotation(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	patch := client.MergeFrom(tcp.DeepCopy())
+
+	delete(tcp.Annotations, kamajiv1alpha1.SupportBundleAnnotation)
+
+	return r.Client.Patch(ctx, tcp, patch)
+}
+
+func (r *SupportBundle) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		Named("supportbundle").
+		For(&kamajiv1alpha1.TenantControlPlane{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(object client.Object) bool {
+			_, ok := object.GetAnnotations()[kamajiv1alpha1.SupportBundleAnnotation]
+
+			return ok
+		}))).
+		Complete(r)
+}
@@ -0,0 +1,102 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// auditedSecret returns the name of the Secret generated by the resources handling the Tenant Control Plane certificates,
+// and kubeconfigs, as reported in the status: it's empty for the other resources.
+func auditedSecret(name string, tcp *kamajiv1alpha1.TenantControlPlane) string {
+	switch name {
+	case "ca":
+		return tcp.Status.Certificates.CA.SecretName
+	case "front-proxy-ca-certificate":
+		return tcp.Status.Certificates.FrontProxyCA.SecretName
+	case "sa-certificate":
+		return tcp.Status.Certificates.SA.SecretName
+	case "api-server-certificate":
+		return tcp.Status.Certificates.APIServer.SecretName
+	case "api-server-kubelet-client-certificate":
+		return tcp.Status.Certificates.APIServerKubeletClient.SecretName
+	case "front-proxy-client-certificate":
+		return tcp.Status.Certificates.FrontProxyClient.SecretName
+	case "admin-kubeconfig":
+		return tcp.Status.KubeConfig.Admin.SecretName
+	case "controller-manager-kubeconfig":
+		return tcp.Status.KubeConfig.ControllerManager.SecretName
+	case "scheduler-kubeconfig":
+		return tcp.Status.KubeConfig.Scheduler.SecretName
+	case "soot-kubeconfig":
+		return tcp.Status.KubeConfig.Soot.SecretName
+	default:
+		return ""
+	}
+}
+
+// auditResource records the administrative actions performed by the given resource, once its status has been updated:
+// the creations are not audited, only the changes of the existing objects, such as the certificate rotations.
+func (r *TenantControlPlaneReconciler) auditResource(ctx context.Context, resource resources.Resource, result controllerutil.OperationResult, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	if r.Auditor == nil {
+		return
+	}
+
+	updated := []resources.Resource{resource}
+
+	if group, ok := resource.(*resources.Group); ok {
+		updated = group.UpdatedResources()
+	} else if result != controllerutil.OperationResultUpdated {
+		return
+	}
+
+	for _, res := range updated {
+		name := res.GetName()
+
+		if name == "deployment" {
+			r.Auditor.Record(ctx, tenantControlPlane, kamajiv1alpha1.AuditActionDeploymentRollout, "the Control Plane Deployment has been changed", kamajiv1alpha1.AuditObjectReference{
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Namespace:  tenantControlPlane.Status.Kubernetes.Deployment.Namespace,
+				Name:       tenantControlPlane.Status.Kubernetes.Deployment.Name,
+			})
+
+			continue
+		}
+
+		if secretName := auditedSecret(name, tenantControlPlane); len(secretName) > 0 {
+			r.Auditor.Record(ctx, tenantControlPlane, kamajiv1alpha1.AuditActionSecretRotation, fmt.Sprintf("the %s Secret has been regenerated", name), kamajiv1alpha1.AuditObjectReference{
+				APIVersion: "v1",
+				Kind:       "Secret",
+				Namespace:  tenantControlPlane.GetNamespace(),
+				Name:       secretName,
+			})
+		}
+	}
+}
+
+// auditMigration records the start, and the completion, of the migrations to another DataStore.
+func (r *TenantControlPlaneReconciler) auditMigration(ctx context.Context, previous kamajiv1alpha1.KubernetesVersionStatus, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	current := ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning)
+
+	dataStore := kamajiv1alpha1.AuditObjectReference{
+		APIVersion: kamajiv1alpha1.GroupVersion.String(),
+		Kind:       "DataStore",
+		Name:       tenantControlPlane.Spec.DataStore,
+	}
+
+	switch {
+	case previous != kamajiv1alpha1.VersionMigrating && current == kamajiv1alpha1.VersionMigrating:
+		r.Auditor.Record(ctx, tenantControlPlane, kamajiv1alpha1.AuditActionMigrationStarted, fmt.Sprintf("migrating from the DataStore %s to %s", tenantControlPlane.Status.Storage.DataStoreName, tenantControlPlane.Spec.DataStore), dataStore)
+	case previous == kamajiv1alpha1.VersionMigrating && current == kamajiv1alpha1.VersionReady:
+		r.Auditor.Record(ctx, tenantControlPlane, kamajiv1alpha1.AuditActionMigrationFinished, fmt.Sprintf("the migration to the DataStore %s has been completed", tenantControlPlane.Spec.DataStore), dataStore)
+	}
+}
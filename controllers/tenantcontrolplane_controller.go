@@ -15,10 +15,13 @@ import (
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	k8stypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/utils/clock"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -34,15 +37,30 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/controllers/finalizers"
 	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/audit"
 	"github.com/clastix/kamaji/internal/datastore"
 	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/faultinjection"
+	"github.com/clastix/kamaji/internal/logging"
+	"github.com/clastix/kamaji/internal/notifications"
 	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// targetClusterResyncInterval is the interval of the reconciliation of the Tenant Control Planes running in a target cluster,
+// since the workloads there are not watched.
+const targetClusterResyncInterval = time.Minute
+
+// transientErrorsThreshold is the number of consecutive attempts failing with a transient error,
+// such as a conflict, before marking the Tenant Control Plane with the Failed phase.
+const transientErrorsThreshold = 5
+
 // TenantControlPlaneReconciler reconciles a TenantControlPlane object.
 type TenantControlPlaneReconciler struct {
-	Client                  client.Client
-	APIReader               client.Reader
+	Client    client.Client
+	APIReader client.Reader
+	// Recorder emits the events of the Tenant Control Planes, such as the changes of the Control Plane endpoint.
+	Recorder                record.EventRecorder
 	Config                  TenantControlPlaneReconcilerConfig
 	TriggerChan             chan event.GenericEvent
 	KamajiNamespace         string
@@ -50,10 +68,21 @@ type TenantControlPlaneReconciler struct {
 	KamajiService           string
 	KamajiMigrateImage      string
 	MaxConcurrentReconciles int
+	// Backoff computes the delay of the requests enqueued back, such as when waiting for the reconciliation lock.
+	Backoff *utils.Backoff
+	// UsePriorityQueue weights the reconciliation requests, prioritizing deletions, certificate rotations,
+	// and not-ready Tenant Control Planes over the routine re-syncs.
+	UsePriorityQueue bool
 	// CertificateChan is the channel used by the CertificateLifecycleController that is checking for
 	// certificates and kubeconfig user certs validity: a generic event for the given TCP will be triggered
 	// once the validity threshold for the given certificate is reached.
 	CertificateChan chan event.GenericEvent
+	// Notifier delivers the lifecycle events, such as the readiness, to the NotificationSink objects: it's optional.
+	Notifier *notifications.Dispatcher
+	// Auditor records the administrative actions, such as the secret rotations, in the audit trail: it's optional.
+	Auditor *audit.Recorder
+	// FaultInjector injects the DataStore timeouts, for development purposes: it's optional.
+	FaultInjector *faultinjection.Injector
 
 	clock mutex.Clock
 }
@@ -64,6 +93,23 @@ type TenantControlPlaneReconcilerConfig struct {
 	DefaultDataStoreName string
 	KineContainerImage   string
 	TmpBaseDirectory     string
+	// ResourcesConcurrency bounds the independent resources, such as certificates and kubeconfigs, handled concurrently.
+	ResourcesConcurrency int
+	// SootLeastPrivilege enables the generation of the scoped kubeconfig used to interact with the Tenant Cluster.
+	SootLeastPrivilege bool
+	// KamajiVersion is the running Kamaji version, recorded in the status of the reconciled Tenant Control Planes.
+	KamajiVersion string
+	// StagedUpgrades holds back the changes rendered by a new Kamaji version restarting the Control Plane pods,
+	// until confirmed with the OperatorUpgradeConfirmationAnnotation, or with ConfirmUpgrades.
+	StagedUpgrades bool
+	// ConfirmUpgrades confirms the changes rendered by a new Kamaji version for all the Tenant Control Planes.
+	ConfirmUpgrades bool
+	// RevisionHistoryLimit is the number of the retained Tenant Control Plane specification revisions,
+	// available for the rollbacks: the history is disabled if zero.
+	RevisionHistoryLimit int
+	// MigrateWatchdogLeaseDuration makes the migration Job renew the watchdog Lease in the Tenant Cluster,
+	// the webhook freezing it is removed once the Lease expires: the watchdog is disabled if zero.
+	MigrateWatchdogLeaseDuration time.Duration
 }
 
 //+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -72,11 +118,24 @@ type TenantControlPlaneReconcilerConfig struct {
 //+kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=core,resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=pods,verbs=get;list;delete
+//+kubebuilder:rbac:groups=core,resources=persistentvolumeclaims,verbs=get;list;watch;create;delete;deletecollection
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=datastores,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=core,resources=events,verbs=create;patch
+//+kubebuilder:rbac:groups=external-secrets.io,resources=pushsecrets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=core,resources=resourcequotas;limitranges,verbs=get;list;watch;create;update;patch;delete
 
 func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	// The tenant field is propagated to the resources loggers, allowing to route their entries to the tenant logs.
+	ctx = log.IntoContext(ctx, log.FromContext(ctx, logging.TenantKey, req.String()))
 	log := log.FromContext(ctx)
 
 	var cancelFn context.CancelFunc
@@ -107,11 +166,11 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 		case errors.As(err, &mutex.ErrTimeout):
 			log.Info("acquire timed out, current process is blocked by another reconciliation")
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.Backoff.Requeue(req), nil
 		case errors.As(err, &mutex.ErrCancelled):
 			log.Info("acquire cancelled")
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.Backoff.Requeue(req), nil
 		default:
 			log.Error(err, "acquire failed")
 
@@ -125,13 +184,56 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if markedToBeDeleted && !controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
 		return ctrl.Result{}, nil
 	}
+	if !markedToBeDeleted {
+		rolledBack, rollbackErr := r.rollback(ctx, tenantControlPlane)
+		if rollbackErr != nil {
+			log.Error(rollbackErr, "cannot roll back the Tenant Control Plane")
+
+			return ctrl.Result{}, rollbackErr
+		}
+		// The specification update triggers a new reconciliation.
+		if rolledBack {
+			log.Info("Tenant Control Plane specification rolled back")
+
+			return ctrl.Result{}, nil
+		}
+		// The time-to-live is evaluated before handling the resources, expiring the failing Tenant Control Planes too.
+		expired, _, expireErr := r.expire(ctx, tenantControlPlane)
+		if expireErr != nil {
+			log.Error(expireErr, "cannot handle the time-to-live")
+
+			return ctrl.Result{}, expireErr
+		}
+
+		if expired {
+			log.Info("time-to-live expired, the Tenant Control Plane has been deleted")
+
+			return ctrl.Result{}, nil
+		}
+	}
+	// The dedicated etcd cluster must be provisioned before retrieving its DataStore.
+	var dedicatedPending bool
+
+	if tenantControlPlane.Spec.DedicatedDataStore != nil && !markedToBeDeleted {
+		if dedicatedPending, err = r.dedicatedDataStore(ctx, tenantControlPlane); err != nil {
+			log.Error(err, "cannot provision the dedicated DataStore")
+
+			return ctrl.Result{}, err
+		}
+
+		if dedicated := tenantControlPlane.Status.Storage.Dedicated; dedicatedPending && (dedicated == nil || len(dedicated.Members) == 0) {
+			log.Info("waiting for the dedicated DataStore to be bootstrapped")
+
+			return r.Backoff.Requeue(req), nil
+		}
+	}
 	// Retrieving the DataStore to use for the current reconciliation
 	ds, err := r.dataStore(ctx, tenantControlPlane)
 	if err != nil {
-		if errors.Is(err, ErrMissingDataStore) {
+		if errors.Is(err, ErrMissingDataStore) || errors.Is(err, ErrProvisioningDataStore) {
 			log.Info(err.Error())
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.Backoff.Requeue(req), nil
 		}
 
 		log.Error(err, "cannot retrieve the DataStore for the given instance")
@@ -147,8 +249,21 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 	defer dsConnection.Close()
 
+	dsConnection = r.FaultInjector.WrapConnection(dsConnection)
+
 	if markedToBeDeleted && controllerutil.ContainsFinalizer(tenantControlPlane, finalizers.DatastoreFinalizer) {
-		log.Info("marked for deletion, performing clean-up")
+		if !tenantControlPlane.IsDeletionConfirmed() {
+			log.Info("marked for deletion, waiting for the confirmation of the protected Tenant Control Plane")
+
+			if r.Recorder != nil {
+				r.Recorder.Event(tenantControlPlane, corev1.EventTypeWarning, "DeletionConfirmationRequired",
+					fmt.Sprintf("the Tenant Control Plane is protected: annotate it with %s=%s to delete the DataStore contents", kamajiv1alpha1.DeletionConfirmationAnnotation, tenantControlPlane.GetName()))
+			}
+
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("marked for deletion, performing clean-up", "deletionPolicy", tenantControlPlane.GetDeletionPolicy())
 
 		groupDeletableResourceBuilderConfiguration := GroupDeletableResourceBuilderConfiguration{
 			client:              r.Client,
@@ -161,7 +276,7 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		for _, resource := range GetDeletableResources(tenantControlPlane, groupDeletableResourceBuilderConfiguration) {
 			if err = resources.HandleDeletion(ctx, resource, tenantControlPlane); err != nil {
-				log.Error(err, "resource deletion failed", "resource", resource.GetName())
+				log.Error(err, "resource deletion failed", logging.ResourceKey, resource.GetName())
 
 				return ctrl.Result{}, err
 			}
@@ -169,11 +284,23 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 
 		log.Info("resource deletions have been completed")
 
+		r.Auditor.Record(ctx, tenantControlPlane, kamajiv1alpha1.AuditActionDeletion, fmt.Sprintf("the Tenant Control Plane has been deleted with the %s deletion policy", tenantControlPlane.GetDeletionPolicy()))
+
 		return ctrl.Result{}, nil
 	}
 
+	// The workloads are routed to the target cluster, if any, the other objects are kept in the management one.
+	workloadClient, err := utilities.GetWorkloadClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		log.Error(err, "cannot generate the workload client")
+
+		return ctrl.Result{}, err
+	}
+
 	groupResourceBuilderConfiguration := GroupResourceBuilderConfiguration{
 		client:               r.Client,
+		workloadClient:       workloadClient,
+		recorder:             r.Recorder,
 		log:                  log,
 		tcpReconcilerConfig:  r.Config,
 		tenantControlPlane:   *tenantControlPlane,
@@ -186,16 +313,52 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 	}
 	registeredResources := GetResources(groupResourceBuilderConfiguration)
 
+	proceed, err := r.stageOperatorUpgrade(ctx, tenantControlPlane, registeredResources)
+	if err != nil {
+		log.Error(err, "cannot stage the operator upgrade")
+
+		return ctrl.Result{}, err
+	}
+
+	if !proceed {
+		log.Info("operator upgrade pending, waiting for the confirmation of the Control Plane rollout")
+
+		return ctrl.Result{}, nil
+	}
+
+	proceed, err = r.batchKineSchemaMigration(ctx, tenantControlPlane, ds, workloadClient)
+	if err != nil {
+		log.Error(err, "cannot batch the kine schema migration")
+
+		return ctrl.Result{}, err
+	}
+
+	if !proceed {
+		log.Info("kine schema migration queued, waiting for a free slot of the DataStore")
+
+		return ctrl.Result{RequeueAfter: kineSchemaMigrationRequeue}, nil
+	}
+
 	for _, resource := range registeredResources {
 		result, err := resources.Handle(ctx, resource, tenantControlPlane)
 		if err != nil {
 			if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
 				log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
 
-				return ctrl.Result{RequeueAfter: time.Second}, nil
+				return r.Backoff.Requeue(req), nil
+			}
+
+			if kamajierrors.IsTransientError(err) && r.Backoff.Attempts(req) < transientErrorsThreshold {
+				log.Info("transient error, enqueuing back request", logging.ResourceKey, resource.GetName(), "error", err.Error())
+
+				return r.Backoff.Requeue(req), nil
 			}
 
-			log.Error(err, "handling of resource failed", "resource", resource.GetName())
+			log.Error(err, "handling of resource failed", logging.ResourceKey, resource.GetName())
+
+			if phaseErr := utils.UpdateFailedPhase(ctx, r.Client, tenantControlPlane, resource, err); phaseErr != nil {
+				log.Error(phaseErr, "cannot update the failed phase", logging.ResourceKey, resource.GetName())
+			}
 
 			return ctrl.Result{}, err
 		}
@@ -204,30 +367,68 @@ func (r *TenantControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.R
 			continue
 		}
 
+		previousVersionStatus := ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning)
+
 		if err = utils.UpdateStatus(ctx, r.Client, tenantControlPlane, resource); err != nil {
 			if kamajierrors.ShouldReconcileErrorBeIgnored(err) {
 				log.V(1).Info("sentinel error, enqueuing back request", "error", err.Error())
 
-				return ctrl.Result{RequeueAfter: time.Second}, nil
+				return r.Backoff.Requeue(req), nil
 			}
 
-			log.Error(err, "update of the resource failed", "resource", resource.GetName())
+			log.Error(err, "update of the resource failed", logging.ResourceKey, resource.GetName())
 
 			return ctrl.Result{}, err
 		}
 
+		observeProvisioningDuration(previousVersionStatus, tenantControlPlane)
+		r.notifyReadiness(previousVersionStatus, tenantControlPlane)
+		r.auditMigration(ctx, previousVersionStatus, tenantControlPlane)
+		r.auditResource(ctx, resource, result, tenantControlPlane)
+
 		log.Info(fmt.Sprintf("%s has been configured", resource.GetName()))
 
 		if result == resources.OperationResultEnqueueBack {
 			log.Info("requested enqueuing back", "resources", resource.GetName())
 
-			return ctrl.Result{RequeueAfter: time.Second}, nil
+			return r.Backoff.Requeue(req), nil
 		}
 	}
 
+	if err = utils.UpdateObservedGeneration(ctx, r.Client, tenantControlPlane, r.Config.KamajiVersion); err != nil {
+		log.Error(err, "cannot update the observed generation")
+
+		return ctrl.Result{}, err
+	}
+
 	log.Info(fmt.Sprintf("%s has been reconciled", tenantControlPlane.GetName()))
 
-	return ctrl.Result{}, nil
+	expired, expiresIn, err := r.expire(ctx, tenantControlPlane)
+	if err != nil {
+		log.Error(err, "cannot handle the time-to-live")
+
+		return ctrl.Result{}, err
+	}
+
+	if expired {
+		log.Info("time-to-live expired, the Tenant Control Plane has been deleted")
+
+		return ctrl.Result{}, nil
+	}
+
+	if dedicatedPending {
+		log.Info("dedicated DataStore membership changes are pending, enqueuing back")
+
+		return r.Backoff.Requeue(req), nil
+	}
+
+	r.Backoff.Forget(req)
+	// The workloads of the target cluster are not watched, their status is resynced periodically.
+	if tenantControlPlane.TargetCluster() != nil && (expiresIn == 0 || expiresIn > targetClusterResyncInterval) {
+		expiresIn = targetClusterResyncInterval
+	}
+
+	return ctrl.Result{RequeueAfter: expiresIn}, nil
 }
 
 func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
@@ -244,14 +445,23 @@ func (r *TenantControlPlaneReconciler) mutexSpec(obj client.Object) mutex.Spec {
 func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.clock = clock.RealClock{}
 
+	opts := controller.Options{
+		MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+	}
+
+	if r.UsePriorityQueue {
+		opts.UsePriorityQueue = ptr.To(true)
+		opts.NewQueue = newTenantControlPlanePriorityQueue(mgr.GetCache())
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		WatchesRawSource(source.Channel(r.CertificateChan, handler.Funcs{GenericFunc: func(_ context.Context, genericEvent event.TypedGenericEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
-			w.AddRateLimited(ctrl.Request{
+			enqueueWithPriority(w, ctrl.Request{
 				NamespacedName: k8stypes.NamespacedName{
 					Namespace: genericEvent.Object.GetNamespace(),
 					Name:      genericEvent.Object.GetName(),
 				},
-			})
+			}, PriorityCertificateRotation)
 		}})).
 		WatchesRawSource(source.Channel(r.TriggerChan, handler.Funcs{GenericFunc: func(_ context.Context, genericEvent event.TypedGenericEvent[client.Object], w workqueue.TypedRateLimitingInterface[reconcile.Request]) {
 			w.AddRateLimited(ctrl.Request{
@@ -265,8 +475,11 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Owns(&corev1.Secret{}).
 		Owns(&corev1.ConfigMap{}).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
 		Owns(&corev1.Service{}).
 		Owns(&networkingv1.Ingress{}).
+		Owns(&networkingv1.NetworkPolicy{}).
+		Owns(&policyv1.PodDisruptionBudget{}).
 		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
 			labels := object.GetLabels()
 
@@ -295,9 +508,7 @@ func (r *TenantControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error
 
 			return ok && v == "migrate"
 		}))).
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
-		}).
+		WithOptions(opts).
 		Complete(r)
 }
 
@@ -320,9 +531,37 @@ func (r *TenantControlPlaneReconciler) RemoveFinalizer(ctx context.Context, tena
 
 var ErrMissingDataStore = errors.New("the Tenant Control Plane doesn't have a DataStore assigned, and Kamaji is running with no default DataStore fallback")
 
+var ErrProvisioningDataStore = errors.New("the DataStore backend is being provisioned by an external operator")
+
+// dedicatedDataStore provisions the dedicated etcd cluster of the Tenant Control Plane,
+// returning true when its membership changes are still pending.
+func (r *TenantControlPlaneReconciler) dedicatedDataStore(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	resource := getDedicatedDataStoreResource(r.Client)
+
+	result, err := resources.Handle(ctx, resource, tenantControlPlane)
+	if err != nil {
+		return false, err
+	}
+
+	if result == controllerutil.OperationResultNone {
+		return false, nil
+	}
+
+	if err = utils.UpdateStatus(ctx, r.Client, tenantControlPlane, resource); err != nil {
+		return false, err
+	}
+
+	return result == resources.OperationResultEnqueueBack, nil
+}
+
 // dataStore retrieves the override DataStore for the given Tenant Control Plane if specified,
 // otherwise fallback to the default one specified in the Kamaji setup.
 func (r *TenantControlPlaneReconciler) dataStore(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.DataStore, error) {
+	// The dedicated DataStore name is derived from the Tenant Control Plane UID.
+	if name := tenantControlPlane.DedicatedDataStoreName(); name != "" {
+		tenantControlPlane.Spec.DataStore = name
+	}
+
 	if tenantControlPlane.Spec.DataStore == "" && r.Config.DefaultDataStoreName == "" {
 		return nil, ErrMissingDataStore
 	}
@@ -336,5 +575,9 @@ func (r *TenantControlPlaneReconciler) dataStore(ctx context.Context, tenantCont
 		return nil, errors.Wrap(err, "cannot retrieve *kamajiv1alpha.DataStore object")
 	}
 
+	if ds.IsProvisioning() {
+		return nil, ErrProvisioningDataStore
+	}
+
 	return &ds, nil
 }
@@ -0,0 +1,142 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// kineSchemaMigrationRequeue is the delay before checking again for a free slot of the concurrent schema migrations.
+const kineSchemaMigrationRequeue = 15 * time.Second
+
+// batchKineSchemaMigration holds back the rollout of a new kine image, migrating the schema of the Tenant Control Plane,
+// until a slot of the concurrent migrations of the DataStore is acquired: it returns true when the reconciliation can proceed.
+// The slots are tracked in the DataStore status, whose optimistic concurrency acts as the lock across the reconciliations.
+func (r *TenantControlPlaneReconciler) batchKineSchemaMigration(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, ds *kamajiv1alpha1.DataStore, workloadClient client.Client) (bool, error) {
+	if ds.Spec.Driver == kamajiv1alpha1.EtcdDriver || ds.Spec.SchemaMigration == nil {
+		return true, nil
+	}
+
+	imageProfile, err := utilities.GetImageProfile(ctx, r.Client, tcp)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot retrieve the Image Profile")
+	}
+
+	desired := builder.Deployment{KineContainerImage: r.Config.KineContainerImage, DataStore: *ds, ImageProfile: imageProfile}.Images(*tcp)[kamajiv1alpha1.ImageProfileKine]
+
+	current, rolledOut, err := r.kineImage(ctx, tcp, workloadClient)
+	if err != nil {
+		return false, err
+	}
+
+	tenant, acquired := getNamespacedName(tcp.GetNamespace(), tcp.GetName()).String(), true
+
+	if err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if gErr := r.Client.Get(ctx, client.ObjectKeyFromObject(ds), ds); gErr != nil {
+			return gErr
+		}
+
+		record, tracked := ds.Status.SchemaVersion(tenant)
+
+		next := record
+		next.TenantControlPlane = tenant
+
+		switch {
+		case len(current) == 0:
+			// The Tenant Control Plane is not deployed yet: the schema is created by the desired kine image.
+			next.Version, next.TargetVersion = desired, ""
+		case len(next.TargetVersion) > 0:
+			// The migration is in-flight, keeping its slot even if the target image changed in the meanwhile.
+			if current == next.TargetVersion && rolledOut {
+				next.Version, next.TargetVersion = current, ""
+			} else {
+				next.TargetVersion = desired
+			}
+		case current == desired:
+			next.Version = desired
+		default:
+			if ds.Status.SchemaMigrationsInFlight() >= int(ds.Spec.SchemaMigration.MaxConcurrent) {
+				acquired = false
+
+				return nil
+			}
+
+			next.Version, next.TargetVersion = current, desired
+		}
+
+		if tracked && next.Version == record.Version && next.TargetVersion == record.TargetVersion {
+			return nil
+		}
+
+		next.LastTransitionTime = metav1.Now()
+		ds.Status.SetSchemaVersion(next)
+
+		return r.Client.Status().Update(ctx, ds)
+	}); err != nil {
+		return false, errors.Wrap(err, "cannot update the DataStore schema versions")
+	}
+
+	if !acquired {
+		message := fmt.Sprintf("the kine image %s is waiting for a slot of the %d concurrent schema migrations of the %s DataStore", desired, ds.Spec.SchemaMigration.MaxConcurrent, ds.GetName())
+
+		if r.Recorder != nil {
+			r.Recorder.Event(tcp, corev1.EventTypeNormal, kamajiv1alpha1.ReasonKineSchemaMigrationQueued, message)
+		}
+
+		return false, r.setPendingCondition(ctx, tcp, kamajiv1alpha1.ConditionKineSchemaMigrationPending, metav1.ConditionTrue, kamajiv1alpha1.ReasonKineSchemaMigrationQueued, message)
+	}
+
+	return true, r.setPendingCondition(ctx, tcp, kamajiv1alpha1.ConditionKineSchemaMigrationPending, metav1.ConditionFalse, kamajiv1alpha1.ReasonKineSchemaMigrationStarted, fmt.Sprintf("the kine image %s is rolling out", desired))
+}
+
+// kineImage returns the kine image of the Tenant Control Plane Deployment, without its digest,
+// and whether the Deployment has been completely rolled out: the image is empty when it's not deployed yet.
+func (r *TenantControlPlaneReconciler) kineImage(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, workloadClient client.Client) (string, bool, error) {
+	status := tcp.Status.Kubernetes.Deployment
+	if len(status.Name) == 0 {
+		return "", false, nil
+	}
+
+	var deployment appsv1.Deployment
+
+	if err := workloadClient.Get(ctx, client.ObjectKey{Namespace: status.Namespace, Name: status.Name}, &deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return "", false, nil
+		}
+
+		return "", false, errors.Wrap(err, "cannot retrieve the Tenant Control Plane Deployment")
+	}
+
+	var image string
+
+	for _, container := range deployment.Spec.Template.Spec.Containers {
+		if container.Name == "kine" {
+			image, _, _ = strings.Cut(container.Image, "@")
+		}
+	}
+
+	replicas := ptr.Deref(deployment.Spec.Replicas, 1)
+	rolledOut := deployment.Status.ObservedGeneration >= deployment.GetGeneration() &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.Replicas == replicas &&
+		deployment.Status.AvailableReplicas == replicas
+
+	return image, rolledOut, nil
+}
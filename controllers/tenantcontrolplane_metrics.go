@@ -0,0 +1,40 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var provisioningDurationCollector = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: "kamaji",
+	Subsystem: "tenantcontrolplane",
+	Name:      "provisioning_duration_seconds",
+	Help:      "Time elapsed from the Tenant Control Plane creation to its first readiness.",
+	Buckets:   []float64{5, 10, 15, 20, 30, 45, 60, 90, 120, 180, 240, 300, 450, 600, 900, 1200, 1800},
+})
+
+func init() {
+	metrics.Registry.MustRegister(provisioningDurationCollector)
+}
+
+// observeProvisioningDuration records the provisioning duration once the Tenant Control Plane is ready for the first time:
+// the transitions from other states, such as upgrades, or wake-ups, are not provisioning ones.
+func observeProvisioningDuration(previous kamajiv1alpha1.KubernetesVersionStatus, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	if previous != kamajiv1alpha1.VersionProvisioning {
+		return
+	}
+
+	if ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning) != kamajiv1alpha1.VersionReady {
+		return
+	}
+
+	provisioningDurationCollector.Observe(time.Since(tenantControlPlane.GetCreationTimestamp().Time).Seconds())
+}
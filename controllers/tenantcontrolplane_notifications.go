@@ -0,0 +1,30 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"fmt"
+
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// notifyReadiness notifies the transitions of the Tenant Control Plane to the ready state,
+// reporting the completion of the migrations to another DataStore as such.
+func (r *TenantControlPlaneReconciler) notifyReadiness(previous kamajiv1alpha1.KubernetesVersionStatus, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) {
+	current := ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning)
+	if previous == kamajiv1alpha1.VersionReady || current != kamajiv1alpha1.VersionReady {
+		return
+	}
+
+	key := client.ObjectKeyFromObject(tenantControlPlane)
+
+	if previous == kamajiv1alpha1.VersionMigrating {
+		r.Notifier.Notify(key, kamajiv1alpha1.NotificationEventMigrationFinished, fmt.Sprintf("the migration to the DataStore %s has been completed", tenantControlPlane.Spec.DataStore))
+	}
+
+	r.Notifier.Notify(key, kamajiv1alpha1.NotificationEventTenantReady, fmt.Sprintf("the Tenant Control Plane is ready, running Kubernetes %s, from the %s state", tenantControlPlane.Status.Kubernetes.Version.Version, previous))
+}
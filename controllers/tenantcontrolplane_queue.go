@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Priorities of the Tenant Control Plane reconciliation requests:
+// routine re-syncs are enqueued with the controller-runtime low priority, lower than any other request,
+// while the changes of the ready Tenant Control Planes retain the default one.
+const (
+	PriorityRoutine             = handler.LowPriority
+	PriorityChange              = 0
+	PriorityProvisioning        = 50
+	PriorityCertificateRotation = 75
+	PriorityDeletion            = 100
+)
+
+// tenantControlPlanePriorityQueue weights the reconciliation requests according to the Tenant Control Plane state,
+// preventing a burst of new tenants from starving the deletions, and the certificate rotations,
+// and ensuring not-ready tenants are reconciled before the routine re-syncs of the ready ones.
+type tenantControlPlanePriorityQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+
+	reader client.Reader
+}
+
+func newTenantControlPlanePriorityQueue(reader client.Reader) func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		return &tenantControlPlanePriorityQueue{
+			PriorityQueue: priorityqueue.New(controllerName, func(o *priorityqueue.Opts[reconcile.Request]) {
+				o.RateLimiter = rateLimiter
+			}),
+			reader: reader,
+		}
+	}
+}
+
+// priorityReadTimeout bounds the cache read of the Tenant Control Plane, since the items are added synchronously.
+const priorityReadTimeout = time.Second
+
+// priority returns the priority of the given request, retrieving the Tenant Control Plane from the cache:
+// the request priority is retained when higher, such as for the certificate rotations.
+func (q *tenantControlPlanePriorityQueue) priority(request reconcile.Request, priority int) int {
+	ctx, cancelFn := context.WithTimeout(context.Background(), priorityReadTimeout)
+	defer cancelFn()
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := q.reader.Get(ctx, request.NamespacedName, &tcp); err != nil {
+		// A missing Tenant Control Plane has been deleted, its request is releasing the resources.
+		if k8serrors.IsNotFound(err) {
+			return max(priority, PriorityDeletion)
+		}
+
+		return priority
+	}
+
+	switch {
+	case tcp.GetDeletionTimestamp() != nil:
+		return max(priority, PriorityDeletion)
+	case ptr.Deref(tcp.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning) != kamajiv1alpha1.VersionReady:
+		return max(priority, PriorityProvisioning)
+	default:
+		return priority
+	}
+}
+
+func (q *tenantControlPlanePriorityQueue) AddWithOpts(opts priorityqueue.AddOpts, items ...reconcile.Request) {
+	for _, item := range items {
+		itemOpts := opts
+		itemOpts.Priority = q.priority(item, opts.Priority)
+
+		q.PriorityQueue.AddWithOpts(itemOpts, item)
+	}
+}
+
+func (q *tenantControlPlanePriorityQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+func (q *tenantControlPlanePriorityQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration}, item)
+}
+
+func (q *tenantControlPlanePriorityQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+// enqueueWithPriority adds the request with the given priority when the priority queue is enabled.
+func enqueueWithPriority(w workqueue.TypedRateLimitingInterface[reconcile.Request], request reconcile.Request, priority int) {
+	if pq, ok := w.(priorityqueue.PriorityQueue[reconcile.Request]); ok {
+		pq.AddWithOpts(priorityqueue.AddOpts{RateLimited: true, Priority: priority}, request)
+
+		return
+	}
+
+	w.AddRateLimited(request)
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestTenantControlPlanePriority(t *testing.T) {
+	newTCP := func(status *kamajiv1alpha1.KubernetesVersionStatus, deleting bool) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00"}}
+		tcp.Status.Kubernetes.Version.Status = status
+
+		if deleting {
+			tcp.SetFinalizers([]string{"finalizer.kamaji.clastix.io"})
+			tcp.SetDeletionTimestamp(ptr.To(metav1.Now()))
+		}
+
+		return tcp
+	}
+
+	ready := ptr.To(kamajiv1alpha1.VersionReady)
+
+	for _, tc := range []struct {
+		name     string
+		tcp      *kamajiv1alpha1.TenantControlPlane
+		get      func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error
+		priority int
+		expected int
+	}{
+		{name: "routine re-sync of a ready Tenant Control Plane", tcp: newTCP(ready, false), priority: PriorityRoutine, expected: PriorityRoutine},
+		{name: "change of a ready Tenant Control Plane", tcp: newTCP(ready, false), priority: PriorityChange, expected: PriorityChange},
+		{name: "routine re-sync of a provisioning Tenant Control Plane", tcp: newTCP(nil, false), priority: PriorityRoutine, expected: PriorityProvisioning},
+		{name: "routine re-sync of an upgrading Tenant Control Plane", tcp: newTCP(ptr.To(kamajiv1alpha1.VersionUpgrading), false), priority: PriorityRoutine, expected: PriorityProvisioning},
+		{name: "certificate rotation of a ready Tenant Control Plane", tcp: newTCP(ready, false), priority: PriorityCertificateRotation, expected: PriorityCertificateRotation},
+		{name: "certificate rotation of a provisioning Tenant Control Plane", tcp: newTCP(nil, false), priority: PriorityCertificateRotation, expected: PriorityCertificateRotation},
+		{name: "deleting Tenant Control Plane", tcp: newTCP(ready, true), priority: PriorityRoutine, expected: PriorityDeletion},
+		{name: "deleted Tenant Control Plane", priority: PriorityRoutine, expected: PriorityDeletion},
+		{
+			name: "failed read",
+			tcp:  newTCP(nil, true),
+			get: func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+				return errors.New("cache not synced")
+			},
+			priority: PriorityRoutine,
+			expected: PriorityRoutine,
+		},
+		{
+			name: "timed out read",
+			tcp:  newTCP(nil, true),
+			get: func(ctx context.Context, _ client.WithWatch, _ client.ObjectKey, _ client.Object, _ ...client.GetOption) error {
+				if _, ok := ctx.Deadline(); !ok {
+					t.Error("expected the read to be bound by a deadline")
+				}
+
+				<-ctx.Done()
+
+				return ctx.Err()
+			},
+			priority: PriorityChange,
+			expected: PriorityChange,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tc.tcp != nil {
+				builder = builder.WithObjects(tc.tcp)
+			}
+
+			if tc.get != nil {
+				builder = builder.WithInterceptorFuncs(interceptor.Funcs{Get: tc.get})
+			}
+
+			q := &tenantControlPlanePriorityQueue{reader: builder.Build()}
+
+			started := time.Now()
+
+			if priority := q.priority(reconcile.Request{NamespacedName: client.ObjectKey{Namespace: "tenants", Name: "tenant-00"}}, tc.priority); priority != tc.expected {
+				t.Errorf("expected the priority %d, got %d", tc.expected, priority)
+			}
+
+			if elapsed := time.Since(started); elapsed > 2*priorityReadTimeout {
+				t.Errorf("expected the priority to be computed within the read timeout, took %s", elapsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// rollback restores the Tenant Control Plane specification of the revision requested with the RollbackToAnnotation,
+// removing the annotation: it returns true when the specification has been updated, triggering a new reconciliation.
+// A failed rollback, such as for a missing revision, or a rejected specification, is reported with an event.
+func (r *TenantControlPlaneReconciler) rollback(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	value, ok := tcp.GetAnnotations()[kamajiv1alpha1.RollbackToAnnotation]
+	if !ok {
+		return false, nil
+	}
+
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || revision <= 0 {
+		return false, r.abortRollback(ctx, tcp, fmt.Errorf("the revision %q is not a positive number", value))
+	}
+
+	spec, err := resources.GetTenantControlPlaneRevision(ctx, r.Client, tcp, revision)
+	if err != nil {
+		return false, r.abortRollback(ctx, tcp, err)
+	}
+
+	err = retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+			return err
+		}
+
+		tcp.Spec = *spec
+		delete(tcp.Annotations, kamajiv1alpha1.RollbackToAnnotation)
+
+		return r.Client.Update(ctx, tcp)
+	})
+
+	switch {
+	case k8serrors.IsInvalid(err), k8serrors.IsForbidden(err), k8serrors.IsBadRequest(err):
+		// The restored specification has been rejected by the validation, such as for a version downgrade.
+		return false, r.abortRollback(ctx, tcp, err)
+	case err != nil:
+		return false, errors.Wrap(err, fmt.Sprintf("cannot roll back to the revision %d", revision))
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(tcp, corev1.EventTypeNormal, "RolledBack", fmt.Sprintf("the specification has been rolled back to the revision %d", revision))
+	}
+
+	return true, nil
+}
+
+// abortRollback reports the failed rollback, removing the RollbackToAnnotation to prevent retrying it.
+func (r *TenantControlPlaneReconciler) abortRollback(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, rollbackErr error) error {
+	if r.Recorder != nil {
+		r.Recorder.Event(tcp, corev1.EventTypeWarning, "RollbackFailed", rollbackErr.Error())
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(tcp), tcp); err != nil {
+			return err
+		}
+
+		delete(tcp.Annotations, kamajiv1alpha1.RollbackToAnnotation)
+
+		return r.Client.Update(ctx, tcp)
+	})
+}
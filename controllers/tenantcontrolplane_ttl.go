@@ -0,0 +1,93 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// expire tracks the lifetime of the Tenant Control Plane with a time-to-live, deleting it once expired:
+// it returns true when the Tenant Control Plane has been deleted, otherwise the time left before its expiration,
+// zero if no time-to-live is declared. The last use is sampled in the Tenant Cluster by the soot manager.
+func (r *TenantControlPlaneReconciler) expire(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, time.Duration, error) {
+	var status *kamajiv1alpha1.TTLStatus
+
+	if tcp.Spec.TTLSecondsAfterReady != nil || tcp.Spec.TTLSecondsAfterLastUse != nil {
+		status = tcp.Status.TTL.DeepCopy()
+		if status == nil {
+			status = &kamajiv1alpha1.TTLStatus{}
+		}
+
+		if status.ReadyTime == nil && tcp.GetPhase() == kamajiv1alpha1.PhaseReady {
+			status.ReadyTime = &metav1.Time{Time: time.Now().Truncate(time.Second)}
+		}
+
+		status.ExpirationTime = nil
+		// The lifetime starts once the Tenant Control Plane is ready.
+		if status.ReadyTime != nil {
+			status.ExpirationTime = &metav1.Time{Time: expirationTime(tcp, status)}
+		}
+	}
+
+	if !equality.Semantic.DeepEqual(status, tcp.Status.TTL) {
+		patch := client.MergeFrom(tcp.DeepCopy())
+
+		tcp.Status.TTL = status
+
+		if err := r.Client.Status().Patch(ctx, tcp, patch); err != nil {
+			return false, 0, errors.Wrap(err, "cannot update the time-to-live status")
+		}
+	}
+
+	if status == nil || status.ExpirationTime == nil {
+		return false, 0, nil
+	}
+
+	if left := time.Until(status.ExpirationTime.Time); left > 0 {
+		return false, left, nil
+	}
+
+	if r.Recorder != nil {
+		r.Recorder.Event(tcp, corev1.EventTypeNormal, "Expired", fmt.Sprintf("the time-to-live expired at %s, deleting the Tenant Control Plane", status.ExpirationTime.UTC().Format(time.RFC3339)))
+	}
+
+	if err := r.Client.Delete(ctx, tcp); client.IgnoreNotFound(err) != nil {
+		return false, 0, errors.Wrap(err, "cannot delete the expired Tenant Control Plane")
+	}
+
+	return true, 0, nil
+}
+
+// expirationTime returns the earliest expiration according to the declared time-to-live settings:
+// the Tenant Control Plane never used is considered used last when it became ready.
+func expirationTime(tcp *kamajiv1alpha1.TenantControlPlane, status *kamajiv1alpha1.TTLStatus) time.Time {
+	var expiration time.Time
+
+	if ttl := tcp.Spec.TTLSecondsAfterReady; ttl != nil {
+		expiration = status.ReadyTime.Add(time.Duration(*ttl) * time.Second)
+	}
+
+	if ttl := tcp.Spec.TTLSecondsAfterLastUse; ttl != nil {
+		lastUse := status.ReadyTime.Time
+		if status.LastUseTime != nil && status.LastUseTime.After(lastUse) {
+			lastUse = status.LastUseTime.Time
+		}
+
+		if afterLastUse := lastUse.Add(time.Duration(*ttl) * time.Second); expiration.IsZero() || afterLastUse.Before(expiration) {
+			expiration = afterLastUse
+		}
+	}
+
+	return expiration
+}
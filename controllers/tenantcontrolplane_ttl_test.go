@@ -0,0 +1,87 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestExpirationTime(t *testing.T) {
+	ready := time.Date(2026, time.October, 15, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name       string
+		afterReady *int32
+		afterUse   *int32
+		lastUse    *time.Time
+		expected   time.Time
+	}{
+		{
+			name:       "ready only",
+			afterReady: ptr.To[int32](3600),
+			lastUse:    ptr.To(ready.Add(30 * time.Minute)),
+			expected:   ready.Add(time.Hour),
+		},
+		{
+			name:     "last use only",
+			afterUse: ptr.To[int32](600),
+			lastUse:  ptr.To(ready.Add(30 * time.Minute)),
+			expected: ready.Add(40 * time.Minute),
+		},
+		{
+			name:     "last use only, never used",
+			afterUse: ptr.To[int32](600),
+			expected: ready.Add(10 * time.Minute),
+		},
+		{
+			name:     "last use before ready",
+			afterUse: ptr.To[int32](600),
+			lastUse:  ptr.To(ready.Add(-time.Hour)),
+			expected: ready.Add(10 * time.Minute),
+		},
+		{
+			name:       "both, last use expiring first",
+			afterReady: ptr.To[int32](3600),
+			afterUse:   ptr.To[int32](600),
+			lastUse:    ptr.To(ready.Add(20 * time.Minute)),
+			expected:   ready.Add(30 * time.Minute),
+		},
+		{
+			name:       "both, ready expiring first",
+			afterReady: ptr.To[int32](3600),
+			afterUse:   ptr.To[int32](600),
+			lastUse:    ptr.To(ready.Add(55 * time.Minute)),
+			expected:   ready.Add(time.Hour),
+		},
+		{
+			name:       "both, never used",
+			afterReady: ptr.To[int32](3600),
+			afterUse:   ptr.To[int32](600),
+			expected:   ready.Add(10 * time.Minute),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tcp := &kamajiv1alpha1.TenantControlPlane{}
+			tcp.Spec.TTLSecondsAfterReady = tc.afterReady
+			tcp.Spec.TTLSecondsAfterLastUse = tc.afterUse
+
+			status := &kamajiv1alpha1.TTLStatus{ReadyTime: &metav1.Time{Time: ready}}
+			if tc.lastUse != nil {
+				status.LastUseTime = &metav1.Time{Time: *tc.lastUse}
+			}
+
+			if actual := expirationTime(tcp, status); !actual.Equal(tc.expected) {
+				t.Errorf("expected the expiration at %s, got %s", tc.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+// stageOperatorUpgrade renders in dry-run the resources of a Tenant Control Plane reconciled last by a different Kamaji version,
+// holding back the reconciliation if the Control Plane pods would be restarted, until the upgrade is confirmed:
+// it returns true when the reconciliation can proceed.
+func (r *TenantControlPlaneReconciler) stageOperatorUpgrade(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, registeredResources []resources.Resource) (bool, error) {
+	previousVersion := tcp.Status.OperatorVersion
+	// The Tenant Control Planes not yet deployed have no pods to restart.
+	if !r.Config.StagedUpgrades || previousVersion == r.Config.KamajiVersion || len(tcp.Status.Kubernetes.Deployment.Name) == 0 {
+		return true, nil
+	}
+
+	if r.Config.ConfirmUpgrades || tcp.GetAnnotations()[kamajiv1alpha1.OperatorUpgradeConfirmationAnnotation] == r.Config.KamajiVersion {
+		return true, r.setOperatorUpgradeCondition(ctx, tcp, metav1.ConditionFalse, kamajiv1alpha1.ReasonOperatorUpgradeApplied, fmt.Sprintf("the changes rendered by the Kamaji version %s have been confirmed", r.Config.KamajiVersion))
+	}
+
+	var rollouts []string
+
+	for _, resource := range registeredResources {
+		rolloutResource, ok := resource.(resources.RolloutResource)
+		if !ok {
+			continue
+		}
+
+		if err := resource.Define(ctx, tcp); err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot define the %s resource", resource.GetName()))
+		}
+
+		rollout, err := rolloutResource.WouldRollOut(ctx, tcp)
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot render the %s resource in dry-run", resource.GetName()))
+		}
+
+		if rollout {
+			rollouts = append(rollouts, resource.GetName())
+		}
+	}
+
+	if len(rollouts) == 0 {
+		return true, nil
+	}
+
+	if len(previousVersion) == 0 {
+		previousVersion = "a previous version"
+	}
+
+	message := fmt.Sprintf("the Kamaji version %s would restart the Control Plane pods of the %s resources, reconciled last by %s: annotate the Tenant Control Plane with %s=%s to apply the changes",
+		r.Config.KamajiVersion, strings.Join(rollouts, ", "), previousVersion, kamajiv1alpha1.OperatorUpgradeConfirmationAnnotation, r.Config.KamajiVersion)
+
+	if r.Recorder != nil {
+		r.Recorder.Event(tcp, corev1.EventTypeWarning, kamajiv1alpha1.ReasonRolloutConfirmationRequired, message)
+	}
+
+	return false, r.setOperatorUpgradeCondition(ctx, tcp, metav1.ConditionTrue, kamajiv1alpha1.ReasonRolloutConfirmationRequired, message)
+}
+
+// setOperatorUpgradeCondition updates the OperatorUpgradePending condition:
+// the confirmation is reported only if the Tenant Control Plane was waiting for it.
+func (r *TenantControlPlaneReconciler) setOperatorUpgradeCondition(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, status metav1.ConditionStatus, reason, message string) error {
+	return r.setPendingCondition(ctx, tcp, kamajiv1alpha1.ConditionOperatorUpgradePending, status, reason, message)
+}
+
+// setPendingCondition updates a condition reporting a held back rollout:
+// the completion is reported only if the Tenant Control Plane was waiting for it.
+func (r *TenantControlPlaneReconciler) setPendingCondition(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, conditionType string, status metav1.ConditionStatus, reason, message string) error {
+	if status == metav1.ConditionFalse && !meta.IsStatusConditionTrue(tcp.Status.Conditions, conditionType) {
+		return nil
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = r.Client.Get(ctx, client.ObjectKeyFromObject(tcp), tcp)
+			}
+		}()
+
+		if !meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+			Type:               conditionType,
+			Status:             status,
+			ObservedGeneration: tcp.GetGeneration(),
+			Reason:             reason,
+			Message:            message,
+		}) {
+			return nil
+		}
+
+		if err = r.Client.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		utils.SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
@@ -0,0 +1,273 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	controllerruntime "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TenantControlPlanePool keeps the pools provisioned with the desired number of unclaimed Tenant Control Planes,
+// binding the ready ones to the pending claims: a bound Tenant Control Plane is owned by its claim,
+// and deleted along with it, since its Tenant Cluster state can't be recycled for the next claims.
+type TenantControlPlanePool struct {
+	Client client.Client
+	// APIReader lists the pooled Tenant Control Planes, since the ones provisioned by the previous reconciliations
+	// could be missing from the informer cache, leading to exceed the pool size.
+	APIReader client.Reader
+}
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanepools,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplanepools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplaneclaims,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=tenantcontrolplaneclaims/status,verbs=get;update;patch
+
+func (r *TenantControlPlanePool) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var pool kamajiv1alpha1.TenantControlPlanePool
+	if err := r.Client.Get(ctx, request.NamespacedName, &pool); err != nil {
+		if k8serrors.IsNotFound(err) {
+			logger.Info("resource may have been deleted, skipping")
+
+			return reconcile.Result{}, nil
+		}
+
+		logger.Error(err, "cannot retrieve the required resource")
+
+		return reconcile.Result{}, err
+	}
+	// The unclaimed Tenant Control Planes are garbage collected along with the pool.
+	if pool.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	checksum, err := r.templateChecksum(&pool)
+	if err != nil {
+		logger.Error(err, "cannot compute the template checksum")
+
+		return reconcile.Result{}, err
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err = r.APIReader.List(ctx, &tcpList, client.InNamespace(pool.GetNamespace()), client.MatchingLabels{kamajiv1alpha1.TenantControlPlanePoolLabel: pool.GetName()}); err != nil {
+		logger.Error(err, "cannot list the pooled Tenant Control Planes")
+
+		return reconcile.Result{}, err
+	}
+
+	var claimList kamajiv1alpha1.TenantControlPlaneClaimList
+	if err = r.Client.List(ctx, &claimList, client.InNamespace(pool.GetNamespace()), client.MatchingFields{kamajiv1alpha1.TenantControlPlaneClaimPoolKey: pool.GetName()}); err != nil {
+		logger.Error(err, "cannot list the claims")
+
+		return reconcile.Result{}, err
+	}
+
+	var available, provisioning, claimed []kamajiv1alpha1.TenantControlPlane
+
+	pool.Status = kamajiv1alpha1.TenantControlPlanePoolStatus{}
+
+	for _, tcp := range tcpList.Items {
+		switch {
+		case tcp.GetDeletionTimestamp() != nil:
+			continue
+		case tcp.GetLabels()[kamajiv1alpha1.TenantControlPlaneClaimLabel] != "":
+			claimed = append(claimed, tcp)
+			pool.Status.Claimed++
+		case tcp.GetAnnotations()[kamajiv1alpha1.TenantControlPlanePoolTemplateChecksumAnnotation] != checksum:
+			// The unclaimed Tenant Control Planes provisioned with an outdated template are replaced.
+			if err = r.Client.Delete(ctx, &tcp); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "cannot delete the outdated Tenant Control Plane", "tcp", tcp.GetName())
+
+				return reconcile.Result{}, err
+			}
+		case tcp.GetPhase() == kamajiv1alpha1.PhaseReady:
+			available = append(available, tcp)
+		default:
+			provisioning = append(provisioning, tcp)
+		}
+	}
+
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+
+		if claim.GetDeletionTimestamp() != nil || claim.Status.TenantControlPlane != "" {
+			continue
+		}
+		// A Tenant Control Plane is already bound to the claim when its status update failed: the binding is completed.
+		if index := slices.IndexFunc(claimed, func(tcp kamajiv1alpha1.TenantControlPlane) bool {
+			return metav1.IsControlledBy(&tcp, claim)
+		}); index >= 0 {
+			if err = r.bind(ctx, &pool, claim, &claimed[index]); err != nil {
+				logger.Error(err, "cannot complete the binding of the Tenant Control Plane to the claim", "claim", claim.GetName())
+
+				return reconcile.Result{}, err
+			}
+
+			continue
+		}
+
+		if len(available) == 0 {
+			pool.Status.Pending++
+
+			continue
+		}
+
+		if err = r.bind(ctx, &pool, claim, &available[0]); err != nil {
+			logger.Error(err, "cannot bind the Tenant Control Plane to the claim", "claim", claim.GetName())
+
+			return reconcile.Result{}, err
+		}
+
+		logger.Info("Tenant Control Plane bound to the claim", "claim", claim.GetName(), "tcp", available[0].GetName())
+
+		available = available[1:]
+		pool.Status.Claimed++
+	}
+
+	unclaimed := len(available) + len(provisioning)
+	// Scaling down deletes the provisioning Tenant Control Planes first, preserving the ready ones.
+	for ; unclaimed > int(pool.Spec.Size); unclaimed-- {
+		var tcp kamajiv1alpha1.TenantControlPlane
+
+		if len(provisioning) > 0 {
+			tcp, provisioning = provisioning[len(provisioning)-1], provisioning[:len(provisioning)-1]
+		} else {
+			tcp, available = available[len(available)-1], available[:len(available)-1]
+		}
+
+		if err = r.Client.Delete(ctx, &tcp); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "cannot delete the exceeding Tenant Control Plane", "tcp", tcp.GetName())
+
+			return reconcile.Result{}, err
+		}
+	}
+
+	var provisionErr error
+
+	for ; unclaimed < int(pool.Spec.Size); unclaimed++ {
+		var tcp *kamajiv1alpha1.TenantControlPlane
+
+		if tcp, provisionErr = r.provision(ctx, &pool, checksum); provisionErr != nil {
+			logger.Error(provisionErr, "cannot provision the pooled Tenant Control Plane")
+
+			break
+		}
+
+		provisioning = append(provisioning, *tcp)
+	}
+
+	pool.Status.Available, pool.Status.Provisioning = int32(len(available)), int32(len(provisioning)) //nolint:gosec
+	if provisionErr != nil {
+		pool.Status.Error = provisionErr.Error()
+	}
+
+	if err = r.Client.Status().Update(ctx, &pool); err != nil {
+		logger.Error(err, "cannot update the status for the given instance")
+
+		return reconcile.Result{}, err
+	}
+
+	return reconcile.Result{}, provisionErr
+}
+
+// templateChecksum returns the checksum of the pool template, tracking the template of the pooled Tenant Control Planes.
+func (r *TenantControlPlanePool) templateChecksum(pool *kamajiv1alpha1.TenantControlPlanePool) (string, error) {
+	template, err := json.Marshal(pool.Spec.Template)
+	if err != nil {
+		return "", err
+	}
+
+	return utilities.CalculateMapChecksum(map[string][]byte{"template": template}), nil
+}
+
+// provision creates an unclaimed Tenant Control Plane from the pool template, owned by the pool.
+func (r *TenantControlPlanePool) provision(ctx context.Context, pool *kamajiv1alpha1.TenantControlPlanePool, checksum string) (*kamajiv1alpha1.TenantControlPlane, error) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: pool.GetName() + "-",
+			Namespace:    pool.GetNamespace(),
+			Labels:       utilities.MergeMaps(pool.Spec.Template.Labels, map[string]string{kamajiv1alpha1.TenantControlPlanePoolLabel: pool.GetName()}),
+			Annotations:  utilities.MergeMaps(pool.Spec.Template.Annotations, map[string]string{kamajiv1alpha1.TenantControlPlanePoolTemplateChecksumAnnotation: checksum}),
+		},
+		Spec: *pool.Spec.Template.Spec.DeepCopy(),
+	}
+
+	if err := controllerutil.SetControllerReference(pool, tcp, r.Client.Scheme()); err != nil {
+		return nil, errors.Wrap(err, "cannot set controller reference")
+	}
+
+	if err := r.Client.Create(ctx, tcp); err != nil {
+		return nil, errors.Wrap(err, "cannot create the Tenant Control Plane from the pool template")
+	}
+
+	return tcp, nil
+}
+
+// bind transfers the ownership of the Tenant Control Plane from the pool to the claim,
+// publishing the Tenant Control Plane, and its admin kubeconfig, in the claim status:
+// it's idempotent, thus it completes a binding whose claim status update failed.
+func (r *TenantControlPlanePool) bind(ctx context.Context, pool *kamajiv1alpha1.TenantControlPlanePool, claim *kamajiv1alpha1.TenantControlPlaneClaim, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	patch := client.MergeFrom(tcp.DeepCopy())
+
+	tcp.SetLabels(utilities.MergeMaps(tcp.GetLabels(), map[string]string{kamajiv1alpha1.TenantControlPlaneClaimLabel: claim.GetName()}))
+	tcp.SetOwnerReferences(slices.DeleteFunc(tcp.GetOwnerReferences(), func(reference metav1.OwnerReference) bool {
+		return reference.UID == pool.GetUID()
+	}))
+
+	if err := controllerutil.SetControllerReference(claim, tcp, r.Client.Scheme()); err != nil {
+		return errors.Wrap(err, "cannot set controller reference")
+	}
+
+	if err := r.Client.Patch(ctx, tcp, patch); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("cannot bind the Tenant Control Plane %s", tcp.GetName()))
+	}
+
+	claim.Status = kamajiv1alpha1.TenantControlPlaneClaimStatus{
+		TenantControlPlane: tcp.GetName(),
+		KubeconfigSecret:   tcp.Status.KubeConfig.Admin.SecretName,
+		BoundAt:            metav1.Now(),
+	}
+
+	if err := r.Client.Status().Update(ctx, claim); err != nil {
+		return errors.Wrap(err, "cannot update the claim status")
+	}
+
+	return nil
+}
+
+func (r *TenantControlPlanePool) SetupWithManager(mgr controllerruntime.Manager) error {
+	return controllerruntime.NewControllerManagedBy(mgr).
+		For(&kamajiv1alpha1.TenantControlPlanePool{}).
+		Watches(&kamajiv1alpha1.TenantControlPlane{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			pool, ok := object.GetLabels()[kamajiv1alpha1.TenantControlPlanePoolLabel]
+			if !ok {
+				return nil
+			}
+
+			return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: object.GetNamespace(), Name: pool}}}
+		})).
+		Watches(&kamajiv1alpha1.TenantControlPlaneClaim{}, handler.EnqueueRequestsFromMapFunc(func(_ context.Context, object client.Object) []reconcile.Request {
+			claim := object.(*kamajiv1alpha1.TenantControlPlaneClaim) //nolint:forcetypeassert
+
+			return []reconcile.Request{{NamespacedName: k8stypes.NamespacedName{Namespace: claim.GetNamespace(), Name: claim.Spec.PoolName}}}
+		})).
+		Complete(r)
+}
@@ -0,0 +1,307 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func newPool(size int32) *kamajiv1alpha1.TenantControlPlanePool {
+	pool := &kamajiv1alpha1.TenantControlPlanePool{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "ci", UID: "pool-uid"},
+		Spec: kamajiv1alpha1.TenantControlPlanePoolSpec{
+			Size: size,
+			Template: kamajiv1alpha1.TenantControlPlaneTemplate{
+				Labels: map[string]string{"env": "ci"},
+			},
+		},
+	}
+	pool.Spec.Template.Spec.Kubernetes.Version = "v1.33.0"
+
+	return pool
+}
+
+func newPooledTenantControlPlane(pool *kamajiv1alpha1.TenantControlPlanePool, name, checksum string, ready bool) *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:   pool.GetNamespace(),
+			Name:        name,
+			UID:         types.UID(name + "-uid"),
+			Labels:      map[string]string{kamajiv1alpha1.TenantControlPlanePoolLabel: pool.GetName()},
+			Annotations: map[string]string{kamajiv1alpha1.TenantControlPlanePoolTemplateChecksumAnnotation: checksum},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: kamajiv1alpha1.GroupVersion.String(),
+				Kind:       "TenantControlPlanePool",
+				Name:       pool.GetName(),
+				UID:        pool.GetUID(),
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+	tcp.Status.KubeConfig.Admin.SecretName = name + "-admin-kubeconfig"
+
+	if ready {
+		tcp.Status.Kubernetes.Version.Status = ptr.To(kamajiv1alpha1.VersionReady)
+	}
+
+	return tcp
+}
+
+func newClaim(pool *kamajiv1alpha1.TenantControlPlanePool, name string) *kamajiv1alpha1.TenantControlPlaneClaim {
+	return &kamajiv1alpha1.TenantControlPlaneClaim{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pool.GetNamespace(), Name: name, UID: types.UID(name + "-uid")},
+		Spec:       kamajiv1alpha1.TenantControlPlaneClaimSpec{PoolName: pool.GetName()},
+	}
+}
+
+func poolChecksum(t *testing.T, pool *kamajiv1alpha1.TenantControlPlanePool) string {
+	t.Helper()
+
+	checksum, err := (&TenantControlPlanePool{}).templateChecksum(pool)
+	if err != nil {
+		t.Fatalf("cannot compute the template checksum: %s", err)
+	}
+
+	return checksum
+}
+
+func newPoolClientBuilder(objects ...client.Object) *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	indexer := &kamajiv1alpha1.TenantControlPlaneClaimPool{}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&kamajiv1alpha1.TenantControlPlanePool{}, &kamajiv1alpha1.TenantControlPlaneClaim{}, &kamajiv1alpha1.TenantControlPlane{}).
+		WithIndex(indexer.Object(), indexer.Field(), indexer.ExtractValue())
+}
+
+func reconcilePool(t *testing.T, r *TenantControlPlanePool, pool *kamajiv1alpha1.TenantControlPlanePool) error {
+	t.Helper()
+
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: pool.GetNamespace(), Name: pool.GetName()}})
+
+	return err
+}
+
+func listPooled(t *testing.T, c client.Client, pool *kamajiv1alpha1.TenantControlPlanePool) []kamajiv1alpha1.TenantControlPlane {
+	t.Helper()
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := c.List(context.Background(), &tcpList, client.InNamespace(pool.GetNamespace()), client.MatchingLabels{kamajiv1alpha1.TenantControlPlanePoolLabel: pool.GetName()}); err != nil {
+		t.Fatalf("cannot list the pooled Tenant Control Planes: %s", err)
+	}
+
+	return tcpList.Items
+}
+
+func getPool(t *testing.T, c client.Client, pool *kamajiv1alpha1.TenantControlPlanePool) kamajiv1alpha1.TenantControlPlanePool {
+	t.Helper()
+
+	var current kamajiv1alpha1.TenantControlPlanePool
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(pool), &current); err != nil {
+		t.Fatalf("cannot retrieve the pool: %s", err)
+	}
+
+	return current
+}
+
+func TestTenantControlPlanePoolScaleUp(t *testing.T) {
+	pool := newPool(2)
+	apiServer := newPoolClientBuilder(pool).Build()
+	// The informer cache doesn't reflect the Tenant Control Planes created by the previous reconciliations yet.
+	staleCache := interceptor.NewClient(apiServer.(client.WithWatch), interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*kamajiv1alpha1.TenantControlPlaneList); ok {
+				return nil
+			}
+
+			return c.List(ctx, list, opts...)
+		},
+	})
+
+	r := &TenantControlPlanePool{Client: staleCache, APIReader: apiServer}
+
+	for range 2 {
+		if err := reconcilePool(t, r, pool); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	pooled := listPooled(t, apiServer, pool)
+	if len(pooled) != 2 {
+		t.Fatalf("expected 2 pooled Tenant Control Planes, got %d", len(pooled))
+	}
+
+	checksum := poolChecksum(t, pool)
+
+	for _, tcp := range pooled {
+		if tcp.GetLabels()["env"] != "ci" || tcp.GetAnnotations()[kamajiv1alpha1.TenantControlPlanePoolTemplateChecksumAnnotation] != checksum {
+			t.Errorf("expected the Tenant Control Plane %s to be provisioned from the pool template", tcp.GetName())
+		}
+
+		if !metav1.IsControlledBy(&tcp, pool) {
+			t.Errorf("expected the Tenant Control Plane %s to be controlled by the pool", tcp.GetName())
+		}
+	}
+
+	if status := getPool(t, apiServer, pool).Status; status.Provisioning != 2 || status.Available != 0 {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+}
+
+func TestTenantControlPlanePoolScaleDown(t *testing.T) {
+	pool := newPool(1)
+	checksum := poolChecksum(t, pool)
+
+	c := newPoolClientBuilder(
+		pool,
+		newPooledTenantControlPlane(pool, "ci-ready-0", checksum, true),
+		newPooledTenantControlPlane(pool, "ci-ready-1", checksum, true),
+		newPooledTenantControlPlane(pool, "ci-provisioning", checksum, false),
+	).Build()
+
+	if err := reconcilePool(t, &TenantControlPlanePool{Client: c, APIReader: c}, pool); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pooled := listPooled(t, c, pool)
+	if len(pooled) != 1 || pooled[0].GetName() != "ci-ready-0" {
+		t.Fatalf("expected the ready Tenant Control Plane ci-ready-0 to be preserved, got %d Tenant Control Planes", len(pooled))
+	}
+
+	if status := getPool(t, c, pool).Status; status.Available != 1 || status.Provisioning != 0 {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+}
+
+func TestTenantControlPlanePoolTemplateChange(t *testing.T) {
+	pool := newPool(1)
+
+	c := newPoolClientBuilder(pool, newPooledTenantControlPlane(pool, "ci-outdated", "outdated-checksum", true)).Build()
+
+	if err := reconcilePool(t, &TenantControlPlanePool{Client: c, APIReader: c}, pool); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	pooled := listPooled(t, c, pool)
+	if len(pooled) != 1 {
+		t.Fatalf("expected 1 pooled Tenant Control Plane, got %d", len(pooled))
+	}
+
+	if pooled[0].GetName() == "ci-outdated" || pooled[0].GetAnnotations()[kamajiv1alpha1.TenantControlPlanePoolTemplateChecksumAnnotation] != poolChecksum(t, pool) {
+		t.Errorf("expected the outdated Tenant Control Plane to be replaced, got %s", pooled[0].GetName())
+	}
+}
+
+func TestTenantControlPlanePoolBinding(t *testing.T) {
+	pool := newPool(1)
+	claim := newClaim(pool, "pipeline-00")
+
+	c := newPoolClientBuilder(pool, claim, newPooledTenantControlPlane(pool, "ci-ready", poolChecksum(t, pool), true)).Build()
+
+	if err := reconcilePool(t, &TenantControlPlanePool{Client: c, APIReader: c}, pool); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var bound kamajiv1alpha1.TenantControlPlaneClaim
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(claim), &bound); err != nil {
+		t.Fatalf("cannot retrieve the claim: %s", err)
+	}
+
+	if bound.Status.TenantControlPlane != "ci-ready" || bound.Status.KubeconfigSecret != "ci-ready-admin-kubeconfig" {
+		t.Errorf("unexpected claim status %+v", bound.Status)
+	}
+
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: pool.GetNamespace(), Name: "ci-ready"}, &tcp); err != nil {
+		t.Fatalf("cannot retrieve the bound Tenant Control Plane: %s", err)
+	}
+
+	if tcp.GetLabels()[kamajiv1alpha1.TenantControlPlaneClaimLabel] != claim.GetName() || !metav1.IsControlledBy(&tcp, claim) || metav1.IsControlledBy(&tcp, pool) {
+		t.Errorf("expected the Tenant Control Plane to be owned by the claim, got %v", tcp.GetOwnerReferences())
+	}
+	// The bound Tenant Control Plane is replaced in the pool.
+	if pooled := listPooled(t, c, pool); len(pooled) != 2 {
+		t.Errorf("expected 2 pooled Tenant Control Planes, got %d", len(pooled))
+	}
+
+	if status := getPool(t, c, pool).Status; status.Claimed != 1 || status.Provisioning != 1 || status.Pending != 0 {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+}
+
+func TestTenantControlPlanePoolBindingFailedClaimUpdate(t *testing.T) {
+	pool := newPool(2)
+	claim := newClaim(pool, "pipeline-00")
+	checksum := poolChecksum(t, pool)
+
+	failures := 1
+
+	c := newPoolClientBuilder(
+		pool,
+		claim,
+		newPooledTenantControlPlane(pool, "ci-ready-0", checksum, true),
+		newPooledTenantControlPlane(pool, "ci-ready-1", checksum, true),
+	).WithInterceptorFuncs(interceptor.Funcs{
+		SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+			if _, ok := obj.(*kamajiv1alpha1.TenantControlPlaneClaim); ok && failures > 0 {
+				failures--
+
+				return errors.New("etcdserver: request timed out")
+			}
+
+			return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	r := &TenantControlPlanePool{Client: c, APIReader: c}
+
+	if err := reconcilePool(t, r, pool); err == nil {
+		t.Fatal("expected the claim status update to fail")
+	}
+
+	if err := reconcilePool(t, r, pool); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var bound kamajiv1alpha1.TenantControlPlaneClaim
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(claim), &bound); err != nil {
+		t.Fatalf("cannot retrieve the claim: %s", err)
+	}
+
+	var claimed []string
+
+	for _, tcp := range listPooled(t, c, pool) {
+		if tcp.GetLabels()[kamajiv1alpha1.TenantControlPlaneClaimLabel] == claim.GetName() {
+			claimed = append(claimed, tcp.GetName())
+		}
+	}
+
+	if len(claimed) != 1 || claimed[0] != bound.Status.TenantControlPlane {
+		t.Errorf("expected a single Tenant Control Plane bound to the claim %s, got %v", bound.Status.TenantControlPlane, claimed)
+	}
+
+	if status := getPool(t, c, pool).Status; status.Claimed != 1 || status.Available != 1 || status.Provisioning != 1 {
+		t.Errorf("unexpected pool status %+v", status)
+	}
+}
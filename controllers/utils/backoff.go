@@ -0,0 +1,56 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// DefaultRequeueDelay is used when no Backoff has been configured.
+const DefaultRequeueDelay = time.Second
+
+// Backoff computes the delay of the requests enqueued back while waiting for a condition, such as the reconciliation lock,
+// or a resource being ready: the delay is doubled upon each attempt, up to the maximum one, and randomized by the jitter factor.
+type Backoff struct {
+	limiter workqueue.TypedRateLimiter[reconcile.Request]
+	jitter  float64
+}
+
+func NewBackoff(initial, maxDelay time.Duration, jitter float64) *Backoff {
+	return &Backoff{
+		limiter: workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](initial, maxDelay),
+		jitter:  jitter,
+	}
+}
+
+// Requeue returns the result enqueuing back the given request with the next delay.
+func (b *Backoff) Requeue(request reconcile.Request) reconcile.Result {
+	if b == nil {
+		return reconcile.Result{RequeueAfter: DefaultRequeueDelay}
+	}
+
+	return reconcile.Result{RequeueAfter: wait.Jitter(b.limiter.When(request), b.jitter)}
+}
+
+// Attempts returns the number of times the given request has been enqueued back since the last Forget.
+func (b *Backoff) Attempts(request reconcile.Request) int {
+	if b == nil {
+		return 0
+	}
+
+	return b.limiter.NumRequeues(request)
+}
+
+// Forget resets the delay of the given request, once the awaited condition has been met.
+func (b *Backoff) Forget(request reconcile.Request) {
+	if b == nil {
+		return
+	}
+
+	b.limiter.Forget(request)
+}
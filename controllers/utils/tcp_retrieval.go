@@ -4,7 +4,58 @@
 package utils
 
 import (
+	"context"
+	"sync"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 )
 
 type TenantControlPlaneRetrievalFn func() (*kamajiv1alpha1.TenantControlPlane, error)
+
+// consistencyTokens stores the resourceVersion of the latest TenantControlPlane objects written, or read from the API Server,
+// by Kamaji: a cached object with a different resourceVersion could be older, thus stale.
+var consistencyTokens sync.Map
+
+// SetConsistencyToken must be called once the given TenantControlPlane has been written,
+// preventing the following retrievals from returning the stale cached object.
+func SetConsistencyToken(tcp *kamajiv1alpha1.TenantControlPlane) {
+	consistencyTokens.Store(types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, tcp.GetResourceVersion())
+}
+
+// GetTenantControlPlane retrieves the TenantControlPlane from the informer cache,
+// falling back to the API Server when the cached object doesn't match the consistency token:
+// the token is dropped once the cache caught up.
+func GetTenantControlPlane(ctx context.Context, cache client.Reader, apiReader client.Reader, key types.NamespacedName) (*kamajiv1alpha1.TenantControlPlane, error) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+
+	if err := cache.Get(ctx, key, tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			consistencyTokens.Delete(key)
+		}
+
+		return nil, err
+	}
+
+	token, ok := consistencyTokens.Load(key)
+	if !ok {
+		return tcp, nil
+	}
+
+	if token.(string) == tcp.GetResourceVersion() { //nolint:forcetypeassert
+		consistencyTokens.CompareAndDelete(key, token)
+
+		return tcp, nil
+	}
+
+	if err := apiReader.Get(ctx, key, tcp); err != nil {
+		return nil, err
+	}
+
+	consistencyTokens.CompareAndSwap(key, token, tcp.GetResourceVersion())
+
+	return tcp, nil
+}
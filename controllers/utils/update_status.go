@@ -7,11 +7,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
 	"github.com/clastix/kamaji/internal/resources"
 )
 
@@ -27,12 +32,83 @@ func UpdateStatus(ctx context.Context, client client.Client, tcp *kamajiv1alpha1
 			return fmt.Errorf("error applying TenantcontrolPlane status: %w", err)
 		}
 
+		tcp.Status.Phase, tcp.Status.PhaseMessage = tcp.GetPhase(), ""
+		tcp.SetStandardConditions()
+
 		if err = client.Status().Update(ctx, tcp); err != nil {
 			return fmt.Errorf("error updating tenantControlPlane status: %w", err)
 		}
 
+		SetConsistencyToken(tcp)
+
 		return nil
 	})
 
 	return updateErr
 }
+
+// UpdateFailedPhase marks the TenantControlPlane with the Failed phase,
+// reporting the error preventing the reconciliation of the given resource.
+func UpdateFailedPhase(ctx context.Context, client client.Client, tcp *kamajiv1alpha1.TenantControlPlane, resource resources.Resource, reason error) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = client.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		tcp.Status.Phase = kamajiv1alpha1.PhaseFailed
+		tcp.Status.PhaseMessage = fmt.Sprintf("%s: %s", resource.GetName(), reason.Error())
+
+		if verificationErr := (kamajierrors.ImageVerificationError{}); errors.As(reason, &verificationErr) {
+			meta.SetStatusCondition(&tcp.Status.Conditions, metav1.Condition{
+				Type:               kamajiv1alpha1.ConditionImagesVerified,
+				Status:             metav1.ConditionFalse,
+				ObservedGeneration: tcp.GetGeneration(),
+				Reason:             kamajiv1alpha1.ReasonImageVerificationFailed,
+				Message:            verificationErr.Error(),
+			})
+		}
+
+		tcp.SetStandardConditions()
+
+		if err = client.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
+
+// UpdateObservedGeneration records the Tenant Control Plane generation, and the Kamaji version, once fully reconciled,
+// updating the standard conditions accordingly: no update is issued if they're already matching.
+func UpdateObservedGeneration(ctx context.Context, client client.Client, tcp *kamajiv1alpha1.TenantControlPlane, operatorVersion string) error {
+	return retry.RetryOnConflict(retry.DefaultRetry, func() (err error) {
+		defer func() {
+			if err != nil {
+				_ = client.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, tcp)
+			}
+		}()
+
+		previous := tcp.Status.DeepCopy()
+
+		tcp.Status.ObservedGeneration = tcp.GetGeneration()
+		tcp.Status.OperatorVersion = operatorVersion
+		tcp.Status.Phase, tcp.Status.PhaseMessage = tcp.GetPhase(), ""
+		tcp.SetStandardConditions()
+
+		if equality.Semantic.DeepEqual(previous, &tcp.Status) {
+			return nil
+		}
+
+		if err = client.Status().Update(ctx, tcp); err != nil {
+			return err
+		}
+
+		SetConsistencyToken(tcp)
+
+		return nil
+	})
+}
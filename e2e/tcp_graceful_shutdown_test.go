@@ -0,0 +1,158 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package e2e
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+	pointer "k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+var _ = Describe("Roll out a TenantControlPlane with the graceful shutdown", func() {
+	ctx := context.Background()
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "tcp-graceful-shutdown",
+			Namespace: "default",
+		},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			ControlPlane: kamajiv1alpha1.ControlPlane{
+				Deployment: kamajiv1alpha1.DeploymentSpec{
+					Replicas: pointer.To(int32(2)),
+				},
+				Service: kamajiv1alpha1.ServiceSpec{
+					ServiceType: "NodePort",
+				},
+			},
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{
+				Address: GetKindIPAddress(),
+				Port:    30005,
+			},
+			Kubernetes: kamajiv1alpha1.KubernetesSpec{
+				Version: "v1.30.0",
+				Kubelet: kamajiv1alpha1.KubeletSpec{
+					CGroupFS: "cgroupfs",
+				},
+				AdmissionControllers: kamajiv1alpha1.AdmissionControllers{
+					"LimitRanger",
+					"ResourceQuota",
+				},
+				APIServer: &kamajiv1alpha1.APIServerSpec{
+					GracefulShutdown: &kamajiv1alpha1.APIServerGracefulShutdownSpec{
+						DrainSeconds:         15,
+						ShutdownDelaySeconds: 5,
+					},
+				},
+			},
+			Addons: kamajiv1alpha1.AddonsSpec{},
+		},
+	}
+
+	JustBeforeEach(func() {
+		Expect(k8sClient.Create(ctx, tcp)).NotTo(HaveOccurred())
+		StatusMustEqualTo(tcp, kamajiv1alpha1.VersionReady)
+	})
+
+	JustAfterEach(func() {
+		Expect(k8sClient.Delete(ctx, tcp)).Should(Succeed())
+	})
+
+	It("Should not fail the requests during the rollout", func() {
+		var clientset *kubernetes.Clientset
+
+		By("building the Tenant Cluster client", func() {
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.KubeConfig.Admin.SecretName}, secret)).To(Succeed())
+
+			config, err := clientcmd.RESTConfigFromKubeConfig(secret.Data["admin.conf"])
+			Expect(err).ToNot(HaveOccurred())
+			config.Timeout = 10 * time.Second
+
+			clientset, err = kubernetes.NewForConfig(config)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		var (
+			requests, failures atomic.Int64
+			wg                 sync.WaitGroup
+		)
+
+		stopCh := make(chan struct{})
+
+		By("querying the API Server in the background", func() {
+			wg.Add(1)
+
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+
+				for {
+					select {
+					case <-stopCh:
+						return
+					case <-time.After(100 * time.Millisecond):
+						requests.Add(1)
+
+						_, err := clientset.CoreV1().Namespaces().List(ctx, metav1.ListOptions{Limit: 1})
+
+						var statusErr *apierrors.StatusError
+						if err != nil && (!errors.As(err, &statusErr) || statusErr.Status().Code >= 500) {
+							failures.Add(1)
+						}
+					}
+				}
+			}()
+		})
+
+		By("rolling out the API Server", func() {
+			Expect(retry.RetryOnConflict(retry.DefaultRetry, func() error {
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(tcp), tcp)).To(Succeed())
+
+				tcp.Spec.ControlPlane.Deployment.ExtraArgs = &kamajiv1alpha1.ControlPlaneExtraArgs{
+					APIServer: []string{"--v=2"},
+				}
+
+				return k8sClient.Update(ctx, tcp)
+			})).To(Succeed())
+		})
+
+		By("waiting for the rollout completion", func() {
+			Eventually(func() bool {
+				deployment := &appsv1.Deployment{}
+				if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.GetName()}, deployment); err != nil {
+					return false
+				}
+
+				return deployment.Status.ObservedGeneration == deployment.GetGeneration() &&
+					deployment.Status.UpdatedReplicas == *deployment.Spec.Replicas &&
+					deployment.Status.Replicas == *deployment.Spec.Replicas &&
+					deployment.Status.ReadyReplicas == *deployment.Spec.Replicas
+			}, 5*time.Minute, time.Second).Should(BeTrue())
+		})
+
+		close(stopCh)
+		wg.Wait()
+
+		Expect(requests.Load()).To(BeNumerically(">", 0))
+		Expect(failures.Load()).To(BeZero(), "%d out of %d requests failed during the rollout", failures.Load(), requests.Load())
+	})
+})
@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=kamajiauditevents,verbs=create
+
+// Recorder appends the administrative actions performed by Kamaji to the audit trail:
+// the KamajiAuditEvent objects, in the Namespace of the Tenant Control Plane, and the external sink, if any.
+// The audit events are not owned by the Tenant Control Plane, outliving its deletion.
+type Recorder struct {
+	Client client.Client
+	// Actor is the identity of Kamaji, such as its service account username.
+	Actor         string
+	KamajiVersion string
+	// Objects enables the creation of the KamajiAuditEvent objects.
+	Objects bool
+	// WebhookURL receives the JSON encoded audit events with a POST request, if not empty.
+	WebhookURL string
+
+	httpClient http.Client
+}
+
+// Record appends the given action to the audit trail: the failures are logged, rather than returned,
+// since the audited actions have been already performed. It's a no-op on a nil Recorder.
+func (r *Recorder) Record(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, action kamajiv1alpha1.AuditAction, reason string, objects ...kamajiv1alpha1.AuditObjectReference) {
+	if r == nil {
+		return
+	}
+
+	logger := log.FromContext(ctx).WithName("audit")
+
+	auditEvent := &kamajiv1alpha1.KamajiAuditEvent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kamajiv1alpha1.GroupVersion.String(),
+			Kind:       "KamajiAuditEvent",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: fmt.Sprintf("%s-%s-", tenantControlPlane.GetName(), strings.ToLower(string(action))),
+			Namespace:    tenantControlPlane.GetNamespace(),
+			Labels: map[string]string{
+				constants.ControlPlaneLabelKey: tenantControlPlane.GetName(),
+			},
+		},
+		Spec: kamajiv1alpha1.KamajiAuditEventSpec{
+			Action:             action,
+			Actor:              r.Actor,
+			Reason:             reason,
+			TenantControlPlane: tenantControlPlane.GetName(),
+			Objects:            objects,
+			Timestamp:          metav1.NewTime(time.Now().UTC().Truncate(time.Second)),
+			KamajiVersion:      r.KamajiVersion,
+		},
+	}
+
+	logger.Info("recording the audit event", "action", action, "reason", reason)
+
+	if r.Objects {
+		if err := r.Client.Create(ctx, auditEvent); err != nil {
+			logger.Error(err, "cannot create the audit event", "action", action)
+		}
+	}
+
+	if len(r.WebhookURL) > 0 {
+		if err := r.send(ctx, auditEvent); err != nil {
+			logger.Error(err, "cannot send the audit event", "action", action)
+		}
+	}
+}
+
+func (r *Recorder) send(ctx context.Context, auditEvent *kamajiv1alpha1.KamajiAuditEvent) error {
+	body, err := json.Marshal(auditEvent)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode the audit event")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, r.WebhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create the request")
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Kamaji-Tenant", auditEvent.GetNamespace()+"/"+auditEvent.Spec.TenantControlPlane)
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "cannot send the request")
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+func TestRecord(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "tenants"}}
+
+	var nilRecorder *Recorder
+	nilRecorder.Record(context.Background(), tcp, kamajiv1alpha1.AuditActionDeletion, "deleted")
+
+	received := make(chan kamajiv1alpha1.KamajiAuditEvent, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var auditEvent kamajiv1alpha1.KamajiAuditEvent
+		if err := json.NewDecoder(r.Body).Decode(&auditEvent); err != nil {
+			t.Errorf("cannot decode the audit event: %v", err)
+		}
+
+		if tenant := r.Header.Get("X-Kamaji-Tenant"); tenant != "tenants/tenant-00" {
+			t.Errorf("unexpected tenant header %q", tenant)
+		}
+
+		received <- auditEvent
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	if err := kamajiv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := &Recorder{
+		Client:     fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Actor:      "system:serviceaccount:kamaji-system:kamaji",
+		Objects:    true,
+		WebhookURL: server.URL,
+	}
+
+	secret := kamajiv1alpha1.AuditObjectReference{APIVersion: "v1", Kind: "Secret", Namespace: "tenants", Name: "tenant-00-ca"}
+	recorder.Record(context.Background(), tcp, kamajiv1alpha1.AuditActionSecretRotation, "the ca Secret has been regenerated", secret)
+
+	if auditEvent := <-received; auditEvent.Spec.Action != kamajiv1alpha1.AuditActionSecretRotation || auditEvent.Spec.Actor != recorder.Actor {
+		t.Errorf("unexpected audit event sent: %+v", auditEvent.Spec)
+	}
+
+	var auditEvents kamajiv1alpha1.KamajiAuditEventList
+	if err := recorder.Client.List(context.Background(), &auditEvents, client.InNamespace("tenants"), client.MatchingLabels{constants.ControlPlaneLabelKey: "tenant-00"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(auditEvents.Items) != 1 {
+		t.Fatalf("expected one audit event, got %d", len(auditEvents.Items))
+	}
+
+	if objects := auditEvents.Items[0].Spec.Objects; len(objects) != 1 || objects[0] != secret {
+		t.Errorf("unexpected audited objects %+v", objects)
+	}
+}
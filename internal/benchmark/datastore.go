@@ -0,0 +1,186 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// fakeConnection is an in-memory DataStore connection, shared by the synthetic Tenant Control Planes:
+// each call waits for the given latency, simulating the round-trip time to a remote DataStore.
+type fakeConnection struct {
+	latency time.Duration
+
+	mu     sync.Mutex
+	users  map[string]string
+	dbs    map[string]struct{}
+	grants map[string]struct{}
+}
+
+var _ datastore.Connection = &fakeConnection{}
+
+func newFakeConnection(latency time.Duration) *fakeConnection {
+	return &fakeConnection{
+		latency: latency,
+		users:   map[string]string{},
+		dbs:     map[string]struct{}{},
+		grants:  map[string]struct{}{},
+	}
+}
+
+func (f *fakeConnection) roundTrip(ctx context.Context) error {
+	if f.latency == 0 {
+		return ctx.Err()
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(f.latency):
+		return nil
+	}
+}
+
+func (f *fakeConnection) CreateUser(ctx context.Context, user, password string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.users[user] = password
+
+	return nil
+}
+
+func (f *fakeConnection) CreateDB(ctx context.Context, dbName string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.dbs[dbName] = struct{}{}
+
+	return nil
+}
+
+func (f *fakeConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.grants[user+"/"+dbName] = struct{}{}
+
+	return nil
+}
+
+func (f *fakeConnection) UserExists(ctx context.Context, user string) (bool, error) {
+	if err := f.roundTrip(ctx); err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.users[user]
+
+	return ok, nil
+}
+
+func (f *fakeConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if err := f.roundTrip(ctx); err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.dbs[dbName]
+
+	return ok, nil
+}
+
+func (f *fakeConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if err := f.roundTrip(ctx); err != nil {
+		return false, err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	_, ok := f.grants[user+"/"+dbName]
+
+	return ok, nil
+}
+
+func (f *fakeConnection) DeleteUser(ctx context.Context, user string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.users, user)
+
+	return nil
+}
+
+func (f *fakeConnection) DeleteDB(ctx context.Context, dbName string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.dbs, dbName)
+
+	return nil
+}
+
+func (f *fakeConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	if err := f.roundTrip(ctx); err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.grants, user+"/"+dbName)
+
+	return nil
+}
+
+func (f *fakeConnection) GetConnectionString() string {
+	return "fake.datastore.svc:2379"
+}
+
+func (f *fakeConnection) Close() error {
+	return nil
+}
+
+func (f *fakeConnection) Check(ctx context.Context) error {
+	return f.roundTrip(ctx)
+}
+
+func (f *fakeConnection) Driver() string {
+	return string(kamajiv1alpha1.EtcdDriver)
+}
+
+func (f *fakeConnection) Migrate(context.Context, kamajiv1alpha1.TenantControlPlane, datastore.Connection) error {
+	return fmt.Errorf("the migration is not supported by the benchmark DataStore")
+}
@@ -0,0 +1,313 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Package benchmark provisions synthetic Tenant Control Planes against an in-memory management cluster,
+// and a fake DataStore, measuring the provisioning latency and the steady-state CPU cost of the reconciliation pipeline.
+package benchmark
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/controllers"
+	"github.com/clastix/kamaji/controllers/utils"
+	"github.com/clastix/kamaji/internal/resources"
+)
+
+const (
+	namespace = "benchmark"
+	// maxProvisioningPasses is the upper bound of pipeline iterations for a Tenant Control Plane,
+	// the same way the controller requeues the object until all the resources are provisioned.
+	maxProvisioningPasses = 10
+)
+
+type Options struct {
+	// Tenants is the number of synthetic Tenant Control Planes to provision.
+	Tenants int
+	// Concurrency is the number of Tenant Control Planes provisioned in parallel, as the controller workers.
+	Concurrency int
+	// DataStoreLatency is the round-trip time of each call to the fake DataStore.
+	DataStoreLatency time.Duration
+	// SteadyStatePasses is the number of reconciliations of the provisioned Tenant Control Planes
+	// used to measure the CPU cost of the steady state, when nothing has to be changed.
+	SteadyStatePasses int
+	KubernetesVersion string
+	KineImage         string
+}
+
+// Harness runs the provisioning pipeline of the Tenant Control Plane controller,
+// skipping the resources dealing with migrations, upgrades, and the external systems.
+type Harness struct {
+	Scheme *runtime.Scheme
+	Options
+}
+
+func (h Harness) Run(ctx context.Context) (*Report, error) {
+	if h.Tenants <= 0 {
+		return nil, fmt.Errorf("the number of tenants must be greater than zero")
+	}
+
+	tmp, err := os.MkdirTemp("", "kamaji-benchmark-")
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create temporary directory")
+	}
+	defer os.RemoveAll(tmp)
+
+	config := controllers.TenantControlPlaneReconcilerConfig{
+		KineContainerImage: h.KineImage,
+		TmpBaseDirectory:   tmp,
+	}
+
+	dataStore := kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{Name: "benchmark", UID: uuid.NewUUID()},
+		Spec: kamajiv1alpha1.DataStoreSpec{
+			Driver:    kamajiv1alpha1.EtcdDriver,
+			Endpoints: kamajiv1alpha1.Endpoints{newFakeConnection(0).GetConnectionString()},
+		},
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(h.Scheme).
+		WithObjects(&dataStore).
+		WithStatusSubresource(&kamajiv1alpha1.TenantControlPlane{}).
+		WithInterceptorFuncs(interceptor.Funcs{Create: generateUID}).
+		Build()
+
+	connection := newFakeConnection(h.DataStoreLatency)
+
+	tenants := make([]*kamajiv1alpha1.TenantControlPlane, 0, h.Tenants)
+	for i := range h.Tenants {
+		tcp := h.tenantControlPlane(i, dataStore.GetName())
+		if err = c.Create(ctx, tcp); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot create the Tenant Control Plane %s", tcp.GetName()))
+		}
+
+		tenants = append(tenants, tcp)
+	}
+
+	report := &Report{
+		Tenants:     h.Tenants,
+		Concurrency: max(h.Concurrency, 1),
+	}
+
+	start := time.Now()
+
+	latencies, err := h.provision(ctx, c, config, dataStore, connection, tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	report.Duration = Seconds(time.Since(start))
+	report.setLatencies(latencies)
+
+	cpu, err := h.steadyState(ctx, c, config, dataStore, connection, tenants)
+	if err != nil {
+		return nil, err
+	}
+
+	if reconciliations := h.SteadyStatePasses * h.Tenants; reconciliations > 0 {
+		report.SteadyStateCPUPerReconciliation = Seconds(cpu / time.Duration(reconciliations))
+	}
+
+	return report, nil
+}
+
+// tenantControlPlane returns a synthetic Tenant Control Plane,
+// along with the defaults applied by the API Server, and the mutating webhook.
+func (h Harness) tenantControlPlane(index int, dataStore string) *kamajiv1alpha1.TenantControlPlane {
+	return &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("bench-%04d", index),
+			Namespace: namespace,
+		},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			DataStore:       dataStore,
+			DataStoreSchema: fmt.Sprintf("%s_bench_%04d", namespace, index),
+			ControlPlane: kamajiv1alpha1.ControlPlane{
+				Deployment: kamajiv1alpha1.DeploymentSpec{
+					Replicas:          ptr.To(int32(2)),
+					ComponentTopology: kamajiv1alpha1.ComponentTopologyMonolithic,
+					RegistrySettings: kamajiv1alpha1.RegistrySettings{
+						Registry:               "registry.k8s.io",
+						APIServerImage:         "kube-apiserver",
+						ControllerManagerImage: "kube-controller-manager",
+						SchedulerImage:         "kube-scheduler",
+					},
+					ServiceAccountName: "default",
+				},
+				Service: kamajiv1alpha1.ServiceSpec{
+					ServiceType: kamajiv1alpha1.ServiceTypeClusterIP,
+				},
+			},
+			Kubernetes: kamajiv1alpha1.KubernetesSpec{
+				Version: h.KubernetesVersion,
+				Kubelet: kamajiv1alpha1.KubeletSpec{
+					CGroupFS:              "systemd",
+					PreferredAddressTypes: []kamajiv1alpha1.KubeletPreferredAddressType{kamajiv1alpha1.NodeInternalIP, kamajiv1alpha1.NodeExternalIP, kamajiv1alpha1.NodeHostName},
+				},
+			},
+			NetworkProfile: kamajiv1alpha1.NetworkProfileSpec{
+				Address:       fmt.Sprintf("10.%d.%d.%d", 100+index/65536, (index/256)%256, index%256),
+				Port:          6443,
+				ClusterDomain: "cluster.local",
+				ServiceCIDR:   "10.96.0.0/16",
+				PodCIDR:       "10.244.0.0/16",
+				DNSServiceIPs: []string{"10.96.0.10"},
+			},
+		},
+	}
+}
+
+// provision reconciles the Tenant Control Planes until all their resources are provisioned,
+// returning the provisioning latency of each of them.
+func (h Harness) provision(ctx context.Context, c client.Client, config controllers.TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore, connection *fakeConnection, tenants []*kamajiv1alpha1.TenantControlPlane) ([]time.Duration, error) {
+	queue := make(chan int, len(tenants))
+	for i := range tenants {
+		queue <- i
+	}
+
+	close(queue)
+
+	latencies := make([]time.Duration, len(tenants))
+	errs := make([]error, len(tenants))
+
+	var wg sync.WaitGroup
+
+	for range max(h.Concurrency, 1) {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range queue {
+				start := time.Now()
+				errs[i] = reconcile(ctx, c, config, dataStore, connection, tenants[i])
+				latencies[i] = time.Since(start)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot provision the Tenant Control Plane %s", tenants[i].GetName()))
+		}
+	}
+
+	return latencies, nil
+}
+
+// steadyState reconciles the provisioned Tenant Control Planes, returning the CPU time consumed by the process.
+func (h Harness) steadyState(ctx context.Context, c client.Client, config controllers.TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore, connection *fakeConnection, tenants []*kamajiv1alpha1.TenantControlPlane) (time.Duration, error) {
+	start, err := cpuTime()
+	if err != nil {
+		return 0, err
+	}
+
+	for range h.SteadyStatePasses {
+		for _, tcp := range tenants {
+			if err = reconcile(ctx, c, config, dataStore, connection, tcp); err != nil {
+				return 0, errors.Wrap(err, fmt.Sprintf("cannot reconcile the Tenant Control Plane %s", tcp.GetName()))
+			}
+		}
+	}
+
+	end, err := cpuTime()
+	if err != nil {
+		return 0, err
+	}
+
+	return end - start, nil
+}
+
+func reconcile(ctx context.Context, c client.Client, config controllers.TenantControlPlaneReconcilerConfig, dataStore kamajiv1alpha1.DataStore, connection *fakeConnection, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	for range maxProvisioningPasses {
+		converged := true
+
+		for _, resource := range controllers.GetProvisioningResources(c, config, *tcp, dataStore, connection) {
+			result, err := resources.Handle(ctx, resource, tcp)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot handle resource %s", resource.GetName()))
+			}
+
+			if result == controllerutil.OperationResultNone {
+				continue
+			}
+			// The Deployment never becomes ready since no Pod is going to be scheduled.
+			if result != controllerutil.OperationResultUpdatedStatusOnly {
+				converged = false
+			}
+
+			if err = utils.UpdateStatus(ctx, c, tcp, resource); err != nil {
+				return errors.Wrap(err, fmt.Sprintf("cannot update status for resource %s", resource.GetName()))
+			}
+
+			if result == resources.OperationResultEnqueueBack {
+				break
+			}
+		}
+
+		if converged {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("provisioning didn't converge after %d passes", maxProvisioningPasses)
+}
+
+// generateUID mimics the API Server behaviour, since resources rely on the UID to detect created objects.
+func generateUID(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	if len(obj.GetUID()) == 0 {
+		obj.SetUID(uuid.NewUUID())
+	}
+
+	return c.Create(ctx, obj, opts...)
+}
+
+// cpuTime returns the user, and system, CPU time consumed by the process.
+func cpuTime() (time.Duration, error) {
+	var usage syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &usage); err != nil {
+		return 0, errors.Wrap(err, "cannot retrieve the resource usage")
+	}
+
+	return time.Duration(usage.Utime.Nano() + usage.Stime.Nano()), nil
+}
+
+// percentile returns the nearest-rank percentile of the sorted durations.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	rank := int(p*float64(len(sorted))+0.5) - 1
+
+	return sorted[min(max(rank, 0), len(sorted)-1)]
+}
+
+func (r *Report) setLatencies(latencies []time.Duration) {
+	sorted := slices.Clone(latencies)
+	slices.Sort(sorted)
+
+	r.ProvisioningP50 = Seconds(percentile(sorted, 0.50))
+	r.ProvisioningP95 = Seconds(percentile(sorted, 0.95))
+	r.ProvisioningP99 = Seconds(percentile(sorted, 0.99))
+	r.ProvisioningMax = Seconds(sorted[len(sorted)-1])
+}
@@ -0,0 +1,117 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package benchmark
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"os"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// The flags are passed after -args, along with the go test ones, such as -bench, -count, and -benchtime:
+// the defaults keep the run short enough for the unit tests, the make benchmark target provisions more tenants.
+var (
+	tenants           = flag.Int("benchmark.tenants", 3, "Number of synthetic Tenant Control Planes to provision.")
+	concurrency       = flag.Int("benchmark.concurrency", 1, "Number of Tenant Control Planes provisioned in parallel.")
+	dataStoreLatency  = flag.Duration("benchmark.datastore-latency", 0, "Round-trip time of each call to the fake DataStore.")
+	steadyStatePasses = flag.Int("benchmark.steady-state-passes", 1, "Number of reconciliations of the provisioned Tenant Control Planes measuring the steady-state CPU.")
+	provisioningP95   = flag.Duration("benchmark.provisioning-p95-budget", 0, "Budget of the provisioning p95 latency, zero disables it.")
+	steadyStateCPU    = flag.Duration("benchmark.steady-state-cpu-budget", 0, "Budget of the CPU time per steady-state reconciliation, zero disables it.")
+	reportPath        = flag.String("benchmark.report", "", "Path of the JSON report written upon the budgets assertion.")
+)
+
+func harness() Harness {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	return Harness{
+		Scheme: scheme,
+		Options: Options{
+			Tenants:           *tenants,
+			Concurrency:       *concurrency,
+			DataStoreLatency:  *dataStoreLatency,
+			SteadyStatePasses: *steadyStatePasses,
+			KubernetesVersion: "v1.33.0",
+			KineImage:         "rancher/kine:v0.11.10-amd64",
+		},
+	}
+}
+
+func TestProvisioningBudgets(t *testing.T) {
+	report, err := harness().Run(context.Background())
+	if err != nil {
+		t.Fatalf("cannot run the benchmark: %s", err)
+	}
+
+	t.Logf("provisioned %d tenants in %s, p50 %s, p95 %s, p99 %s, max %s, steady-state CPU per reconciliation %s",
+		report.Tenants, report.Duration.Duration(), report.ProvisioningP50.Duration(), report.ProvisioningP95.Duration(),
+		report.ProvisioningP99.Duration(), report.ProvisioningMax.Duration(), report.SteadyStateCPUPerReconciliation.Duration())
+
+	if *reportPath != "" {
+		out, mErr := json.MarshalIndent(report, "", "  ")
+		if mErr != nil {
+			t.Fatalf("cannot marshal the report: %s", mErr)
+		}
+
+		if wErr := os.WriteFile(*reportPath, out, 0o600); wErr != nil {
+			t.Fatalf("cannot write the report: %s", wErr)
+		}
+	}
+
+	if err = report.Check(Budgets{ProvisioningP95: *provisioningP95, SteadyStateCPUPerReconciliation: *steadyStateCPU}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReportCheck(t *testing.T) {
+	report := Report{
+		ProvisioningP95:                 Seconds(2 * time.Second),
+		SteadyStateCPUPerReconciliation: Seconds(10 * time.Millisecond),
+	}
+
+	if err := report.Check(Budgets{}); err != nil {
+		t.Errorf("expected the disabled budgets to pass, but got %s", err)
+	}
+
+	if err := report.Check(Budgets{ProvisioningP95: 3 * time.Second, SteadyStateCPUPerReconciliation: 20 * time.Millisecond}); err != nil {
+		t.Errorf("expected the budgets to pass, but got %s", err)
+	}
+
+	if err := report.Check(Budgets{ProvisioningP95: time.Second}); err == nil {
+		t.Errorf("expected the provisioning p95 budget to be exceeded")
+	}
+
+	if err := report.Check(Budgets{SteadyStateCPUPerReconciliation: time.Millisecond}); err == nil {
+		t.Errorf("expected the steady-state CPU budget to be exceeded")
+	}
+}
+
+// BenchmarkProvisioning reports the metrics in the go test format, to be compared across the runs with benchstat.
+func BenchmarkProvisioning(b *testing.B) {
+	h := harness()
+
+	var report *Report
+
+	for range b.N {
+		var err error
+		if report, err = h.Run(context.Background()); err != nil {
+			b.Fatalf("cannot run the benchmark: %s", err)
+		}
+	}
+
+	b.ReportMetric(report.ProvisioningP50.Duration().Seconds(), "p50-s/tenant")
+	b.ReportMetric(report.ProvisioningP95.Duration().Seconds(), "p95-s/tenant")
+	b.ReportMetric(report.ProvisioningP99.Duration().Seconds(), "p99-s/tenant")
+	b.ReportMetric(report.SteadyStateCPUPerReconciliation.Duration().Seconds(), "cpu-s/reconcile")
+}
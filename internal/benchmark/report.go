@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package benchmark
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Seconds is a duration marshalled as fractional seconds, easing the regression tracking of the reports.
+type Seconds time.Duration
+
+func (s Seconds) Duration() time.Duration {
+	return time.Duration(s)
+}
+
+func (s Seconds) MarshalJSON() ([]byte, error) {
+	return json.Marshal(time.Duration(s).Seconds())
+}
+
+func (s *Seconds) UnmarshalJSON(data []byte) error {
+	var seconds float64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+
+	*s = Seconds(seconds * float64(time.Second))
+
+	return nil
+}
+
+// Report contains the metrics of a benchmark run.
+type Report struct {
+	Tenants     int `json:"tenants"`
+	Concurrency int `json:"concurrency"`
+	// Duration is the time required to provision all the Tenant Control Planes.
+	Duration        Seconds `json:"durationSeconds"`
+	ProvisioningP50 Seconds `json:"provisioningP50Seconds"`
+	ProvisioningP95 Seconds `json:"provisioningP95Seconds"`
+	ProvisioningP99 Seconds `json:"provisioningP99Seconds"`
+	ProvisioningMax Seconds `json:"provisioningMaxSeconds"`
+	// SteadyStateCPUPerReconciliation is the CPU time consumed by the reconciliation of a provisioned Tenant Control Plane.
+	SteadyStateCPUPerReconciliation Seconds `json:"steadyStateCPUPerReconciliationSeconds"`
+}
+
+// Budgets are the performance budgets a benchmark run must satisfy: a zero value disables the budget.
+type Budgets struct {
+	ProvisioningP95                 time.Duration
+	SteadyStateCPUPerReconciliation time.Duration
+}
+
+// Check returns an error listing the exceeded budgets.
+func (r *Report) Check(budgets Budgets) error {
+	var exceeded []string
+
+	if budget := budgets.ProvisioningP95; budget > 0 && r.ProvisioningP95.Duration() > budget {
+		exceeded = append(exceeded, fmt.Sprintf("provisioning p95 latency %s exceeds the budget of %s", r.ProvisioningP95.Duration(), budget))
+	}
+
+	if budget := budgets.SteadyStateCPUPerReconciliation; budget > 0 && r.SteadyStateCPUPerReconciliation.Duration() > budget {
+		exceeded = append(exceeded, fmt.Sprintf("steady-state CPU per reconciliation %s exceeds the budget of %s", r.SteadyStateCPUPerReconciliation.Duration(), budget))
+	}
+
+	if len(exceeded) > 0 {
+		return fmt.Errorf("performance budgets exceeded: %s", strings.Join(exceeded, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// componentOfLabelKey selects the pods of the component Deployments with the Split topology: these are not labelled
+// with the Tenant Control Plane name, preventing them to be selected by the Service, and by the kube-apiserver Deployment.
+const componentOfLabelKey = "kamaji.clastix.io/component-of"
+
+// SplitComponents are the Control Plane components running in dedicated Deployments with the Split topology.
+var SplitComponents = []kamajiv1alpha1.SplitComponentName{
+	kamajiv1alpha1.SplitComponentControllerManager,
+	kamajiv1alpha1.SplitComponentScheduler,
+}
+
+// ComponentName returns the name of the Deployment, and of the PodDisruptionBudget, of the given component.
+func ComponentName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) string {
+	return utilities.AddTenantPrefix(string(component), tenantControlPlane)
+}
+
+// ComponentSelector returns the labels selecting the pods of the given component Deployment.
+func ComponentSelector(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) map[string]string {
+	return map[string]string{
+		componentOfLabelKey:           tenantControlPlane.GetName(),
+		"kamaji.clastix.io/component": string(component),
+	}
+}
+
+// BuildComponent builds the Deployment running the given Control Plane component with the Split topology:
+// a change to the component doesn't roll out the kube-apiserver pods, and vice versa.
+func (d Deployment) BuildComponent(ctx context.Context, deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	d.setLabels(deployment, utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), string(component)), tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Labels))
+	d.setAnnotations(deployment, utilities.MergeMaps(deployment.Annotations, tenantControlPlane.Spec.ControlPlane.Deployment.AdditionalMetadata.Annotations))
+	d.setTemplateLabels(&deployment.Spec.Template, utilities.MergeMaps(d.componentTemplateLabels(ctx, &tenantControlPlane, component), tenantControlPlane.Spec.ControlPlane.Deployment.PodAdditionalMetadata.Labels))
+	d.setTemplateAnnotations(&deployment.Spec.Template, tenantControlPlane.Spec.ControlPlane.Deployment.PodAdditionalMetadata.Annotations)
+	d.setNodeSelector(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setToleration(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setAffinity(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setStrategy(&deployment.Spec, tenantControlPlane)
+	d.setComponentSelector(&deployment.Spec, tenantControlPlane, component)
+	d.setTopologySpreadConstraints(&deployment.Spec, tenantControlPlane.Spec.ControlPlane.Deployment.TopologySpreadConstraints)
+	d.setRuntimeClass(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setHostNetwork(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.setComponentReplicas(&deployment.Spec, tenantControlPlane, component)
+	d.setComponentContainers(&deployment.Spec.Template.Spec, tenantControlPlane, component)
+	d.setComponentAdditionalVolumes(&deployment.Spec.Template.Spec, tenantControlPlane, component)
+	d.setComponentVolumes(&deployment.Spec.Template.Spec, tenantControlPlane, component)
+	d.setServiceAccount(&deployment.Spec.Template.Spec, tenantControlPlane)
+	setSecurityProfile(&deployment.Spec.Template.Spec, tenantControlPlane)
+	d.Client.Scheme().Default(deployment)
+}
+
+func (d Deployment) setComponentSelector(deploymentSpec *appsv1.DeploymentSpec, tcp kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	deploymentSpec.Selector = &metav1.LabelSelector{
+		MatchLabels: ComponentSelector(&tcp, component),
+	}
+}
+
+// setComponentReplicas sets the declared component replicas, falling back to the Control Plane Deployment ones.
+func (d Deployment) setComponentReplicas(deploymentSpec *appsv1.DeploymentSpec, tcp kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	deploymentSpec.Replicas = tcp.Spec.ControlPlane.Deployment.Replicas
+
+	if spec := tcp.SplitComponent(component); spec != nil && spec.Replicas != nil {
+		deploymentSpec.Replicas = spec.Replicas
+	}
+}
+
+func (d Deployment) setComponentContainers(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	containerName := schedulerContainerName
+
+	switch component {
+	case kamajiv1alpha1.SplitComponentControllerManager:
+		containerName = controlPlaneContainerName
+		d.buildControllerManager(podSpec, tcp)
+	case kamajiv1alpha1.SplitComponentScheduler:
+		d.buildScheduler(podSpec, tcp)
+	}
+
+	if found, index := utilities.HasNamedContainer(podSpec.Containers, containerName); found {
+		d.setEgressEnvironment(&podSpec.Containers[index], tcp)
+	}
+}
+
+func (d Deployment) setComponentVolumes(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	var volumes []func(*corev1.PodSpec, kamajiv1alpha1.TenantControlPlane)
+
+	switch component {
+	case kamajiv1alpha1.SplitComponentControllerManager:
+		volumes = []func(*corev1.PodSpec, kamajiv1alpha1.TenantControlPlane){
+			d.buildPKIVolume,
+			d.buildCAVolume,
+			d.buildShareCAVolume,
+			d.buildLocalShareCAVolume,
+			d.buildControllerManagerVolume,
+			d.buildTrustedCAsVolume,
+		}
+	case kamajiv1alpha1.SplitComponentScheduler:
+		volumes = []func(*corev1.PodSpec, kamajiv1alpha1.TenantControlPlane){
+			d.buildSchedulerVolume,
+			d.buildSchedulerConfigurationVolume,
+			d.buildTrustedCAsVolume,
+		}
+	}
+
+	for _, fn := range volumes {
+		fn(podSpec, tcp)
+	}
+}
+
+// setComponentAdditionalVolumes prepends the user-space volumes, as setAdditionalVolumes does for the kube-apiserver pods:
+// the first Kamaji volume of the scheduler pods is its kubeconfig one, since these are not mounting the PKI.
+func (d Deployment) setComponentAdditionalVolumes(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) {
+	firstSystemVolumeName := kubernetesPKIVolumeName
+	if component == kamajiv1alpha1.SplitComponentScheduler {
+		firstSystemVolumeName = schedulerKubeconfigVolumeName
+	}
+
+	volumes := tcp.Spec.ControlPlane.Deployment.AdditionalVolumes
+
+	if found, index := utilities.HasNamedVolume(podSpec.Volumes, firstSystemVolumeName); found {
+		volumes = append(volumes, podSpec.Volumes[index:]...)
+	}
+
+	podSpec.Volumes = volumes
+}
+
+// removeControllersContainers removes the controller-manager, and the scheduler, containers from the kube-apiserver pods
+// when switching to the Split topology.
+func (d Deployment) removeControllersContainers(podSpec *corev1.PodSpec) {
+	for _, containerName := range []string{schedulerContainerName, controlPlaneContainerName} {
+		if found, index := utilities.HasNamedContainer(podSpec.Containers, containerName); found {
+			podSpec.Containers = append(podSpec.Containers[:index:index], podSpec.Containers[index+1:]...)
+		}
+	}
+}
+
+func (d Deployment) removeControllersVolumes(podSpec *corev1.PodSpec) {
+	for _, volumeName := range []string{schedulerKubeconfigVolumeName, schedulerConfigurationVolumeName, controllerManagerKubeconfigVolumeName} {
+		if found, index := utilities.HasNamedVolume(podSpec.Volumes, volumeName); found {
+			podSpec.Volumes = append(podSpec.Volumes[:index:index], podSpec.Volumes[index+1:]...)
+		}
+	}
+}
+
+func (d Deployment) componentTemplateLabels(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, component kamajiv1alpha1.SplitComponentName) map[string]string {
+	hash := func(ctx context.Context, namespace, secretName string) string {
+		h, _ := d.secretHashValue(ctx, d.Client, namespace, secretName)
+
+		return h
+	}
+
+	labels := ComponentSelector(tenantControlPlane, component)
+
+	switch component {
+	case kamajiv1alpha1.SplitComponentControllerManager:
+		labels["component.kamaji.clastix.io/ca"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.CA.SecretName)
+		labels["component.kamaji.clastix.io/controller-manager-kubeconfig"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.KubeConfig.ControllerManager.SecretName)
+		labels["component.kamaji.clastix.io/front-proxy-ca-certificate"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.FrontProxyCA.SecretName)
+		labels["component.kamaji.clastix.io/service-account"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.Certificates.SA.SecretName)
+	case kamajiv1alpha1.SplitComponentScheduler:
+		labels["component.kamaji.clastix.io/scheduler-kubeconfig"] = hash(ctx, tenantControlPlane.GetNamespace(), tenantControlPlane.Status.KubeConfig.Scheduler.SecretName)
+
+		if tenantControlPlane.Status.SchedulerConfiguration != nil {
+			labels["component.kamaji.clastix.io/scheduler-configuration"] = tenantControlPlane.Status.SchedulerConfiguration.Checksum
+		}
+	}
+
+	if len(tenantControlPlane.Spec.ControlPlane.Deployment.TrustedCAs) > 0 {
+		labels["component.kamaji.clastix.io/trusted-cas"] = d.trustedCAsHashValue(ctx, tenantControlPlane)
+	}
+
+	return labels
+}
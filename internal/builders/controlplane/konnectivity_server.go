@@ -8,8 +8,10 @@ import (
 
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	pointer "k8s.io/utils/ptr"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -25,15 +27,53 @@ const (
 	konnectivityServerPath                      = "/run/konnectivity"
 
 	egressSelectorConfigurationVolume  = "egress-selector-configuration"
+	egressSelectorChecksumAnnotation   = "konnectivity.kamaji.clastix.io/egress-selector-configuration"
 	konnectivityUDSVolume              = "konnectivity-uds"
 	konnectivityServerKubeconfigVolume = "konnectivity-server-kubeconfig"
+	konnectivityServerKubeconfigPath   = "/etc/kubernetes/konnectivity/kubeconfig"
+
+	konnectivityClientCertificateVolume = "konnectivity-client-certificate"
+	konnectivityServerCertificateVolume = "konnectivity-server-certificate"
+	konnectivityServerCertificatePath   = "/etc/kubernetes/konnectivity/pki"
+	konnectivityServerCAVolume          = "konnectivity-server-ca"
+	serverCertificateChecksumAnnotation = "konnectivity.kamaji.clastix.io/server-certificate"
 )
 
+const (
+	// KonnectivityServerTunnelPort is the port the dedicated Konnectivity servers are listening to for the kube-apiserver connections.
+	KonnectivityServerTunnelPort = 8131
+	// KonnectivityClientCertificatePath is the folder of the kube-apiserver pods containing the client certificate,
+	// used to connect to the dedicated Konnectivity servers.
+	KonnectivityClientCertificatePath = "/etc/kubernetes/konnectivity/client"
+)
+
+// KonnectivityServerName returns the name of the Deployment, and of the Service, of the Konnectivity servers with the Deployment placement.
+func KonnectivityServerName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	return utilities.AddTenantPrefix(konnectivityServerName, tenantControlPlane)
+}
+
+// KonnectivityServerSelector returns the labels selecting the pods of the Konnectivity servers with the Deployment placement:
+// these are not labelled with the Tenant Control Plane name, preventing them to be selected by its Service.
+func KonnectivityServerSelector(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return map[string]string{
+		componentOfLabelKey:           tenantControlPlane.GetName(),
+		"kamaji.clastix.io/component": konnectivityServerName,
+	}
+}
+
 type Konnectivity struct {
-	Scheme runtime.Scheme
+	Scheme       runtime.Scheme
+	ImageProfile *kamajiv1alpha1.ImageProfile
 }
 
-func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.KonnectivitySpec, replicas int32, podSpec *corev1.PodSpec) {
+// Image returns the konnectivity-server image, resolved according to the Image Profile.
+func (k Konnectivity) Image(tenantControlPlane kamajiv1alpha1.TenantControlPlane) string {
+	addon := tenantControlPlane.Spec.Addons.Konnectivity
+
+	return k.ImageProfile.Resolve(kamajiv1alpha1.ImageProfileKonnectivityServer, fmt.Sprintf("%s:%s", addon.KonnectivityServerSpec.Image, addon.KonnectivityServerSpec.Version))
+}
+
+func (k Konnectivity) buildKonnectivityContainer(tenantControlPlane kamajiv1alpha1.TenantControlPlane, addon *kamajiv1alpha1.KonnectivitySpec, podSpec *corev1.PodSpec) {
 	found, index := utilities.HasNamedContainer(podSpec.Containers, konnectivityServerName)
 	if !found {
 		index = len(podSpec.Containers)
@@ -41,24 +81,41 @@ func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.Konnectiv
 	}
 
 	podSpec.Containers[index].Name = konnectivityServerName
-	podSpec.Containers[index].Image = fmt.Sprintf("%s:%s", addon.KonnectivityServerSpec.Image, addon.KonnectivityServerSpec.Version)
+	podSpec.Containers[index].Image = tenantControlPlane.Status.Images.PinnedImage(kamajiv1alpha1.ImageProfileKonnectivityServer, k.Image(tenantControlPlane))
 	podSpec.Containers[index].Command = []string{"/proxy-server"}
 
 	args := utilities.ArgsFromSliceToMap(addon.KonnectivityServerSpec.ExtraArgs)
 
-	args["--uds-name"] = fmt.Sprintf("%s/konnectivity-server.socket", konnectivityServerPath)
-	args["--cluster-cert"] = "/etc/kubernetes/pki/apiserver.crt"
-	args["--cluster-key"] = "/etc/kubernetes/pki/apiserver.key"
+	if addon.HasDeploymentPlacement() {
+		// The kube-apiserver pods are connected through the Service using mutual TLS,
+		// and the agents are verifying the certificate issued for the Konnectivity server addresses.
+		args["--server-port"] = fmt.Sprintf("%d", KonnectivityServerTunnelPort)
+		args["--server-ca-cert"] = "/etc/kubernetes/pki/ca.crt"
+		args["--server-cert"] = konnectivityServerCertificatePath + "/tls.crt"
+		args["--server-key"] = konnectivityServerCertificatePath + "/tls.key"
+		args["--cluster-cert"] = konnectivityServerCertificatePath + "/tls.crt"
+		args["--cluster-key"] = konnectivityServerCertificatePath + "/tls.key"
+	} else {
+		args["--uds-name"] = fmt.Sprintf("%s/konnectivity-server.socket", konnectivityServerPath)
+		args["--cluster-cert"] = "/etc/kubernetes/pki/apiserver.crt"
+		args["--cluster-key"] = "/etc/kubernetes/pki/apiserver.key"
+		args["--server-port"] = "0"
+	}
+
 	args["--mode"] = "grpc"
-	args["--server-port"] = "0"
 	args["--agent-port"] = fmt.Sprintf("%d", addon.KonnectivityServerSpec.Port)
 	args["--admin-port"] = "8133"
 	args["--health-port"] = "8134"
 	args["--agent-namespace"] = "kube-system"
 	args["--agent-service-account"] = AgentName
-	args["--kubeconfig"] = "/etc/kubernetes/konnectivity-server.conf"
+	args["--kubeconfig"] = konnectivityServerKubeconfigPath + "/konnectivity-server.conf"
 	args["--authentication-audience"] = CertCommonName
-	args["--server-count"] = fmt.Sprintf("%d", replicas)
+	args["--server-count"] = fmt.Sprintf("%d", tenantControlPlane.KonnectivityServerReplicas())
+	// The health check scrapes the connected agents from the metrics served by the admin endpoint,
+	// which is otherwise bound to the loopback address.
+	if _, ok := args["--admin-bind-address"]; !ok && addon.HealthCheck != nil {
+		args["--admin-bind-address"] = "0.0.0.0"
+	}
 
 	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 	podSpec.Containers[index].LivenessProbe = &corev1.Probe{
@@ -92,16 +149,56 @@ func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.Konnectiv
 			Protocol:      corev1.ProtocolTCP,
 		},
 	}
-	podSpec.Containers[index].VolumeMounts = []corev1.VolumeMount{
+	podSpec.Containers[index].VolumeMounts = k.sidecarVolumeMounts()
+	if addon.HasDeploymentPlacement() {
+		podSpec.Containers[index].VolumeMounts = k.serverVolumeMounts()
+	}
+
+	podSpec.Containers[index].ImagePullPolicy = corev1.PullAlways
+	podSpec.Containers[index].Resources = corev1.ResourceRequirements{
+		Limits:   nil,
+		Requests: nil,
+	}
+
+	if resources := addon.KonnectivityServerSpec.Resources; resources != nil {
+		podSpec.Containers[index].Resources.Limits = resources.Limits
+		podSpec.Containers[index].Resources.Requests = resources.Requests
+	}
+}
+
+// serverVolumeMounts returns the volume mounts of the dedicated Konnectivity servers, which are not sharing
+// the kube-apiserver PKI: only the CA certificate is mounted, verifying the kube-apiserver client certificates.
+func (k Konnectivity) serverVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
+		{
+			Name:      konnectivityServerCAVolume,
+			MountPath: "/etc/kubernetes/pki",
+			ReadOnly:  true,
+		},
+		{
+			Name:      konnectivityServerCertificateVolume,
+			MountPath: konnectivityServerCertificatePath,
+			ReadOnly:  true,
+		},
+		{
+			Name:      konnectivityServerKubeconfigVolume,
+			MountPath: konnectivityServerKubeconfigPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+func (k Konnectivity) sidecarVolumeMounts() []corev1.VolumeMount {
+	return []corev1.VolumeMount{
 		{
 			Name:      "etc-kubernetes-pki",
 			MountPath: "/etc/kubernetes/pki",
 			ReadOnly:  true,
 		},
 		{
-			Name:      "konnectivity-server-kubeconfig",
-			MountPath: "/etc/kubernetes/konnectivity-server.conf",
-			SubPath:   "konnectivity-server.conf",
+			// Mounting the whole Secret, rather than a sub path, allows the rotated certificate files to be synced, and reloaded.
+			Name:      konnectivityServerKubeconfigVolume,
+			MountPath: konnectivityServerKubeconfigPath,
 			ReadOnly:  true,
 		},
 		{
@@ -110,16 +207,6 @@ func (k Konnectivity) buildKonnectivityContainer(addon *kamajiv1alpha1.Konnectiv
 			ReadOnly:  false,
 		},
 	}
-	podSpec.Containers[index].ImagePullPolicy = corev1.PullAlways
-	podSpec.Containers[index].Resources = corev1.ResourceRequirements{
-		Limits:   nil,
-		Requests: nil,
-	}
-
-	if resources := addon.KonnectivityServerSpec.Resources; resources != nil {
-		podSpec.Containers[index].Resources.Limits = resources.Limits
-		podSpec.Containers[index].Resources.Requests = resources.Requests
-	}
 }
 
 func (k Konnectivity) RemovingVolumeMounts(podSpec *corev1.PodSpec) {
@@ -128,7 +215,7 @@ func (k Konnectivity) RemovingVolumeMounts(podSpec *corev1.PodSpec) {
 		return
 	}
 
-	for _, volumeMountName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityServerKubeconfigVolume} {
+	for _, volumeMountName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityServerKubeconfigVolume, konnectivityClientCertificateVolume} {
 		if ok, i := utilities.HasNamedVolumeMount(podSpec.Containers[index].VolumeMounts, volumeMountName); ok {
 			var volumesMounts []corev1.VolumeMount
 
@@ -141,7 +228,7 @@ func (k Konnectivity) RemovingVolumeMounts(podSpec *corev1.PodSpec) {
 }
 
 func (k Konnectivity) RemovingVolumes(podSpec *corev1.PodSpec) {
-	for _, volumeName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume} {
+	for _, volumeName := range []string{konnectivityUDSVolume, egressSelectorConfigurationVolume, konnectivityClientCertificateVolume} {
 		if volumeFound, volumeIndex := utilities.HasNamedVolume(podSpec.Volumes, volumeName); volumeFound {
 			var volumes []corev1.Volume
 
@@ -157,7 +244,8 @@ func (k Konnectivity) RemovingKubeAPIServerContainerArg(podSpec *corev1.PodSpec)
 	if found, index := utilities.HasNamedContainer(podSpec.Containers, apiServerContainerName); found {
 		argsMap := utilities.ArgsFromSliceToMap(podSpec.Containers[index].Args)
 
-		if utilities.ArgsRemoveFlag(argsMap, "--egress-selector-config-file") {
+		// The flag could reference the HTTP CONNECT proxy configuration, replacing Konnectivity.
+		if argsMap["--egress-selector-config-file"] == konnectivityEgressSelectorConfigurationPath && utilities.ArgsRemoveFlag(argsMap, "--egress-selector-config-file") {
 			podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(argsMap)
 		}
 	}
@@ -174,7 +262,9 @@ func (k Konnectivity) RemovingContainer(podSpec *corev1.PodSpec) {
 	}
 }
 
-func (k Konnectivity) buildVolumeMounts(podSpec *corev1.PodSpec) {
+// buildVolumeMounts mounts the egress selector configuration in the kube-apiserver container, along with the Unix domain socket
+// of the sidecar Konnectivity server, or the client certificate connecting to the dedicated ones with the Deployment placement.
+func (k Konnectivity) buildVolumeMounts(podSpec *corev1.PodSpec, deploymentPlacement bool) {
 	found, index := utilities.HasNamedContainer(podSpec.Containers, apiServerContainerName)
 	if !found {
 		return
@@ -186,50 +276,23 @@ func (k Konnectivity) buildVolumeMounts(podSpec *corev1.PodSpec) {
 
 	podSpec.Containers[index].Args = utilities.ArgsFromMapToSlice(args)
 
-	vFound, vIndex := false, 0 //nolint:wastedassign
-	// Patching the volume mounts
-	if vFound, vIndex = utilities.HasNamedVolumeMount(podSpec.Containers[index].VolumeMounts, konnectivityUDSVolume); !vFound {
-		vIndex = len(podSpec.Containers[index].VolumeMounts)
-		podSpec.Containers[index].VolumeMounts = append(podSpec.Containers[index].VolumeMounts, corev1.VolumeMount{})
-	}
+	container := &podSpec.Containers[index]
 
-	podSpec.Containers[index].VolumeMounts[vIndex].Name = konnectivityUDSVolume
-	podSpec.Containers[index].VolumeMounts[vIndex].ReadOnly = false
-	podSpec.Containers[index].VolumeMounts[vIndex].MountPath = konnectivityServerPath
+	if deploymentPlacement {
+		removeVolumeMounts(container, konnectivityUDSVolume)
+		setVolumeMount(container, egressSelectorConfigurationVolume, "/etc/kubernetes/konnectivity/configurations", false)
+		setVolumeMount(container, konnectivityClientCertificateVolume, KonnectivityClientCertificatePath, true)
 
-	if vFound, vIndex = utilities.HasNamedVolumeMount(podSpec.Containers[index].VolumeMounts, egressSelectorConfigurationVolume); !vFound {
-		vIndex = len(podSpec.Containers[index].VolumeMounts)
-		podSpec.Containers[index].VolumeMounts = append(podSpec.Containers[index].VolumeMounts, corev1.VolumeMount{})
+		return
 	}
 
-	podSpec.Containers[index].VolumeMounts[vIndex].Name = egressSelectorConfigurationVolume
-	podSpec.Containers[index].VolumeMounts[vIndex].ReadOnly = false
-	podSpec.Containers[index].VolumeMounts[vIndex].MountPath = "/etc/kubernetes/konnectivity/configurations"
+	removeVolumeMounts(container, konnectivityClientCertificateVolume)
+	setVolumeMount(container, konnectivityUDSVolume, konnectivityServerPath, false)
+	setVolumeMount(container, egressSelectorConfigurationVolume, "/etc/kubernetes/konnectivity/configurations", false)
 }
 
-func (k Konnectivity) buildVolumes(status kamajiv1alpha1.KonnectivityStatus, podSpec *corev1.PodSpec) {
-	// Defining volumes for the UDS socket
-	found, index := utilities.HasNamedVolume(podSpec.Volumes, konnectivityUDSVolume)
-	if !found {
-		index = len(podSpec.Volumes)
-		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
-	}
-
-	podSpec.Volumes[index].Name = konnectivityUDSVolume
-	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
-		EmptyDir: &corev1.EmptyDirVolumeSource{
-			Medium: "Memory",
-		},
-	}
-	// Defining volumes for the egress selector configuration
-	found, index = utilities.HasNamedVolume(podSpec.Volumes, egressSelectorConfigurationVolume)
-	if !found {
-		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
-		index = len(podSpec.Volumes) - 1
-	}
-
-	podSpec.Volumes[index].Name = egressSelectorConfigurationVolume
-	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
+func (k Konnectivity) buildVolumes(status kamajiv1alpha1.KonnectivityStatus, podSpec *corev1.PodSpec, deploymentPlacement bool) {
+	egressSelectorConfiguration := corev1.VolumeSource{
 		ConfigMap: &corev1.ConfigMapVolumeSource{
 			LocalObjectReference: corev1.LocalObjectReference{
 				Name: status.ConfigMap.Name,
@@ -237,26 +300,156 @@ func (k Konnectivity) buildVolumes(status kamajiv1alpha1.KonnectivityStatus, pod
 			DefaultMode: pointer.To(int32(420)),
 		},
 	}
-	// Defining volume for the Konnectivity kubeconfig
-	found, index = utilities.HasNamedVolume(podSpec.Volumes, konnectivityServerKubeconfigVolume)
-	if !found {
-		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
-		index = len(podSpec.Volumes) - 1
+
+	if deploymentPlacement {
+		removeVolumes(podSpec, konnectivityUDSVolume, konnectivityServerKubeconfigVolume)
+		setVolume(podSpec, egressSelectorConfigurationVolume, egressSelectorConfiguration)
+		setVolume(podSpec, konnectivityClientCertificateVolume, corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  status.Certificate.SecretName,
+				DefaultMode: pointer.To(int32(420)),
+			},
+		})
+
+		return
 	}
 
-	podSpec.Volumes[index].Name = konnectivityServerKubeconfigVolume
-	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
+	removeVolumes(podSpec, konnectivityClientCertificateVolume)
+	// Defining volumes for the UDS socket
+	setVolume(podSpec, konnectivityUDSVolume, corev1.VolumeSource{
+		EmptyDir: &corev1.EmptyDirVolumeSource{
+			Medium: "Memory",
+		},
+	})
+	// Defining volumes for the egress selector configuration
+	setVolume(podSpec, egressSelectorConfigurationVolume, egressSelectorConfiguration)
+	// Defining volume for the Konnectivity kubeconfig
+	setVolume(podSpec, konnectivityServerKubeconfigVolume, corev1.VolumeSource{
 		Secret: &corev1.SecretVolumeSource{
 			SecretName:  status.Kubeconfig.SecretName,
 			DefaultMode: pointer.To(int32(420)),
 		},
-	}
+	})
 }
 
+// Build patches the kube-apiserver Deployment: the Konnectivity server runs as a sidecar container,
+// unless the Deployment placement is declared, and the container is removed in favour of the dedicated Deployment.
 func (k Konnectivity) Build(deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane) {
-	k.buildKonnectivityContainer(tenantControlPlane.Spec.Addons.Konnectivity, *tenantControlPlane.Spec.ControlPlane.Deployment.Replicas, &deployment.Spec.Template.Spec)
-	k.buildVolumeMounts(&deployment.Spec.Template.Spec)
-	k.buildVolumes(tenantControlPlane.Status.Addons.Konnectivity, &deployment.Spec.Template.Spec)
+	deploymentPlacement := tenantControlPlane.Spec.Addons.Konnectivity.HasDeploymentPlacement()
+
+	if deploymentPlacement {
+		k.RemovingContainer(&deployment.Spec.Template.Spec)
+	} else {
+		k.buildKonnectivityContainer(tenantControlPlane, tenantControlPlane.Spec.Addons.Konnectivity, &deployment.Spec.Template.Spec)
+	}
+
+	k.buildVolumeMounts(&deployment.Spec.Template.Spec, deploymentPlacement)
+	k.buildVolumes(tenantControlPlane.Status.Addons.Konnectivity, &deployment.Spec.Template.Spec, deploymentPlacement)
+	k.buildEgressSelectorAnnotation(tenantControlPlane, &deployment.Spec.Template)
+	setSecurityProfile(&deployment.Spec.Template.Spec, tenantControlPlane)
 
 	k.Scheme.Default(deployment)
 }
+
+// BuildServer builds the dedicated Deployment of the Konnectivity servers with the Deployment placement,
+// rolled out upon the renewal of their certificate, whose checksum is provided.
+func (k Konnectivity) BuildServer(deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane, certificateChecksum string) {
+	deployment.SetLabels(utilities.MergeMaps(deployment.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), konnectivityServerName)))
+
+	deployment.Spec.Replicas = pointer.To(tenantControlPlane.KonnectivityServerReplicas())
+	deployment.Spec.Selector = &metav1.LabelSelector{MatchLabels: KonnectivityServerSelector(&tenantControlPlane)}
+	deployment.Spec.Template.SetLabels(KonnectivityServerSelector(&tenantControlPlane))
+	deployment.Spec.Template.SetAnnotations(utilities.MergeMaps(deployment.Spec.Template.GetAnnotations(), map[string]string{
+		serverCertificateChecksumAnnotation: certificateChecksum,
+	}))
+
+	podSpec := &deployment.Spec.Template.Spec
+
+	podSpec.NodeSelector = tenantControlPlane.Spec.ControlPlane.Deployment.NodeSelector
+	podSpec.Tolerations = tenantControlPlane.Spec.ControlPlane.Deployment.Tolerations
+	podSpec.AutomountServiceAccountToken = pointer.To(false)
+
+	k.buildKonnectivityContainer(tenantControlPlane, tenantControlPlane.Spec.Addons.Konnectivity, podSpec)
+
+	status := tenantControlPlane.Status.Addons.Konnectivity
+
+	setVolume(podSpec, konnectivityServerCAVolume, corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName:  tenantControlPlane.Status.Certificates.CA.SecretName,
+			Items:       []corev1.KeyToPath{{Key: kubeadmconstants.CACertName, Path: kubeadmconstants.CACertName}},
+			DefaultMode: pointer.To(int32(420)),
+		},
+	})
+	setVolume(podSpec, konnectivityServerCertificateVolume, corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName:  status.ServerCertificate.SecretName,
+			DefaultMode: pointer.To(int32(420)),
+		},
+	})
+	setVolume(podSpec, konnectivityServerKubeconfigVolume, corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName:  status.Kubeconfig.SecretName,
+			DefaultMode: pointer.To(int32(420)),
+		},
+	})
+
+	setSecurityProfile(podSpec, tenantControlPlane)
+
+	k.Scheme.Default(deployment)
+}
+
+func setVolume(podSpec *corev1.PodSpec, name string, source corev1.VolumeSource) {
+	found, index := utilities.HasNamedVolume(podSpec.Volumes, name)
+	if !found {
+		index = len(podSpec.Volumes)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
+	}
+
+	podSpec.Volumes[index].Name = name
+	podSpec.Volumes[index].VolumeSource = source
+}
+
+func removeVolumes(podSpec *corev1.PodSpec, names ...string) {
+	for _, name := range names {
+		if found, index := utilities.HasNamedVolume(podSpec.Volumes, name); found {
+			podSpec.Volumes = append(podSpec.Volumes[:index:index], podSpec.Volumes[index+1:]...)
+		}
+	}
+}
+
+func setVolumeMount(container *corev1.Container, name, mountPath string, readOnly bool) {
+	found, index := utilities.HasNamedVolumeMount(container.VolumeMounts, name)
+	if !found {
+		index = len(container.VolumeMounts)
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{})
+	}
+
+	container.VolumeMounts[index].Name = name
+	container.VolumeMounts[index].ReadOnly = readOnly
+	container.VolumeMounts[index].MountPath = mountPath
+}
+
+func removeVolumeMounts(container *corev1.Container, names ...string) {
+	for _, name := range names {
+		if found, index := utilities.HasNamedVolumeMount(container.VolumeMounts, name); found {
+			container.VolumeMounts = append(container.VolumeMounts[:index:index], container.VolumeMounts[index+1:]...)
+		}
+	}
+}
+
+// buildEgressSelectorAnnotation rolls out the API Server upon a change of the declared egress selections,
+// since the configuration file is read only at startup: the annotation is skipped for the default selections,
+// preventing the rollout of the existing Tenant Control Planes.
+func (k Konnectivity) buildEgressSelectorAnnotation(tenantControlPlane kamajiv1alpha1.TenantControlPlane, template *corev1.PodTemplateSpec) {
+	if tenantControlPlane.Spec.Addons.Konnectivity.EgressSelector == nil {
+		delete(template.Annotations, egressSelectorChecksumAnnotation)
+
+		return
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+
+	template.Annotations[egressSelectorChecksumAnnotation] = tenantControlPlane.Status.Addons.Konnectivity.ConfigMap.Checksum
+}
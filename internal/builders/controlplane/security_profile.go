@@ -0,0 +1,104 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	"slices"
+
+	corev1 "k8s.io/api/core/v1"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// securityProfileUser is the non-root user, and group, running the Control Plane containers with the Restricted profile,
+// matching the nonroot one of the distroless images.
+const securityProfileUser = int64(65532)
+
+// setSecurityProfile applies the security profile of the Tenant Control Plane to the containers managed by Kamaji,
+// skipping the additional ones, and the WireGuard sidecar: it must be called once the containers are rendered,
+// and the hardened fields are reset when the profile is removed.
+func setSecurityProfile(podSpec *corev1.PodSpec, tcp kamajiv1alpha1.TenantControlPlane) {
+	podSecurityContext, containerSecurityContext := securityContexts(tcp)
+
+	if podSecurityContext != nil {
+		podSpec.SecurityContext = podSecurityContext
+	} else if podSpec.SecurityContext != nil {
+		// The pod security context is defaulted to an empty one by the API server.
+		podSpec.SecurityContext.RunAsNonRoot = nil
+		podSpec.SecurityContext.RunAsUser = nil
+		podSpec.SecurityContext.RunAsGroup = nil
+		podSpec.SecurityContext.FSGroup = nil
+		podSpec.SecurityContext.SeccompProfile = nil
+		podSpec.SecurityContext.AppArmorProfile = nil
+	}
+
+	unmanaged := []string{wireGuardContainerName}
+
+	for _, container := range tcp.Spec.ControlPlane.Deployment.AdditionalInitContainers {
+		unmanaged = append(unmanaged, container.Name)
+	}
+
+	for _, container := range tcp.Spec.ControlPlane.Deployment.AdditionalContainers {
+		unmanaged = append(unmanaged, container.Name)
+	}
+
+	for _, containers := range [][]corev1.Container{podSpec.InitContainers, podSpec.Containers} {
+		for i := range containers {
+			if slices.Contains(unmanaged, containers[i].Name) {
+				continue
+			}
+
+			containers[i].SecurityContext = containerSecurityContext.DeepCopy()
+		}
+	}
+}
+
+// securityContexts returns the pod, and the container, security contexts of the Tenant Control Plane security profile:
+// both are nil when no profile is declared.
+func securityContexts(tcp kamajiv1alpha1.TenantControlPlane) (*corev1.PodSecurityContext, *corev1.SecurityContext) {
+	deployment := tcp.Spec.ControlPlane.Deployment
+
+	switch deployment.SecurityProfile {
+	case kamajiv1alpha1.SecurityProfileRestricted:
+		pod := &corev1.PodSecurityContext{
+			RunAsNonRoot:   pointer.To(true),
+			RunAsUser:      pointer.To(securityProfileUser),
+			RunAsGroup:     pointer.To(securityProfileUser),
+			FSGroup:        pointer.To(securityProfileUser),
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		container := &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.To(false),
+			ReadOnlyRootFilesystem:   pointer.To(true),
+			RunAsNonRoot:             pointer.To(true),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		}
+
+		return pod, container
+	case kamajiv1alpha1.SecurityProfileBaseline:
+		pod := &corev1.PodSecurityContext{
+			SeccompProfile: &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+		}
+		container := &corev1.SecurityContext{
+			AllowPrivilegeEscalation: pointer.To(false),
+			Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+		}
+
+		return pod, container
+	case kamajiv1alpha1.SecurityProfileCustom:
+		if deployment.SecurityContext == nil {
+			return nil, nil
+		}
+
+		podSecurityContext := deployment.SecurityContext.Pod.DeepCopy()
+		if podSecurityContext == nil {
+			podSecurityContext = &corev1.PodSecurityContext{}
+		}
+
+		return podSecurityContext, deployment.SecurityContext.Container.DeepCopy()
+	default:
+		return nil, nil
+	}
+}
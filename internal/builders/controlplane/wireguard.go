@@ -0,0 +1,134 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package controlplane
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	pointer "k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// WireGuardSyncScript brings up the wg0 interface from the configuration file referenced by the WG_CONFIG variable,
+	// waiting for it to be available: the peers are synced upon each change, without disrupting the established sessions,
+	// and the routes of the allowed IPs are kept in place since wg syncconf doesn't manage them.
+	WireGuardSyncScript = `set -eu
+CONFIG=/run/kamaji-wireguard/wg0.conf
+until [ -f "${WG_CONFIG}" ]; do sleep 5; done
+mkdir -p /run/kamaji-wireguard
+cp "${WG_CONFIG}" "${CONFIG}"
+wg-quick down "${CONFIG}" 2>/dev/null || true
+wg-quick up "${CONFIG}"
+trap 'wg-quick down "${CONFIG}"; exit 0' TERM INT
+while true; do
+  sleep 10 & wait $!
+  if ! cmp -s "${WG_CONFIG}" "${CONFIG}"; then
+    cp "${WG_CONFIG}" "${CONFIG}"
+    wg-quick strip "${CONFIG}" > /run/kamaji-wireguard/wg0.stripped
+    wg syncconf wg0 /run/kamaji-wireguard/wg0.stripped
+    for ip in $(wg show wg0 allowed-ips | cut -f2); do
+      case "${ip}" in
+        */*) ip route replace "${ip}" dev wg0 ;;
+      esac
+    done
+  fi
+done
+`
+
+	wireGuardContainerName = "wireguard"
+	wireGuardVolume        = "wireguard-config"
+	wireGuardConfigPath    = "/etc/kamaji-wireguard"
+)
+
+// WireGuard patches the Tenant Control Plane Deployment with the WireGuard sidecar container:
+// since it shares the network namespace of the Pod, the API Server reaches the worker nodes through the tunnel.
+type WireGuard struct {
+	Scheme runtime.Scheme
+}
+
+func (w WireGuard) buildContainer(addon *kamajiv1alpha1.WireGuardSpec, podSpec *corev1.PodSpec) {
+	found, index := utilities.HasNamedContainer(podSpec.Containers, wireGuardContainerName)
+	if !found {
+		index = len(podSpec.Containers)
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	podSpec.Containers[index].Name = wireGuardContainerName
+	podSpec.Containers[index].Image = addon.Image
+	podSpec.Containers[index].Command = []string{"/bin/sh", "-c", WireGuardSyncScript}
+	podSpec.Containers[index].Env = []corev1.EnvVar{
+		{
+			Name:  "WG_CONFIG",
+			Value: wireGuardConfigPath + "/wg0.conf",
+		},
+	}
+	podSpec.Containers[index].Ports = []corev1.ContainerPort{
+		{
+			Name:          "wireguard",
+			ContainerPort: addon.Port,
+			Protocol:      corev1.ProtocolUDP,
+		},
+	}
+	podSpec.Containers[index].SecurityContext = &corev1.SecurityContext{
+		Capabilities: &corev1.Capabilities{
+			Add: []corev1.Capability{"NET_ADMIN"},
+		},
+	}
+	podSpec.Containers[index].VolumeMounts = []corev1.VolumeMount{
+		{
+			Name:      wireGuardVolume,
+			MountPath: wireGuardConfigPath,
+			ReadOnly:  true,
+		},
+	}
+}
+
+func (w WireGuard) buildVolumes(status kamajiv1alpha1.WireGuardStatus, podSpec *corev1.PodSpec) {
+	found, index := utilities.HasNamedVolume(podSpec.Volumes, wireGuardVolume)
+	if !found {
+		index = len(podSpec.Volumes)
+		podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{})
+	}
+
+	podSpec.Volumes[index].Name = wireGuardVolume
+	podSpec.Volumes[index].VolumeSource = corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName:  status.SecretName,
+			DefaultMode: pointer.To(int32(420)),
+		},
+	}
+}
+
+func (w WireGuard) RemovingContainer(podSpec *corev1.PodSpec) {
+	if found, index := utilities.HasNamedContainer(podSpec.Containers, wireGuardContainerName); found {
+		var containers []corev1.Container
+
+		containers = append(containers, podSpec.Containers[:index]...)
+		containers = append(containers, podSpec.Containers[index+1:]...)
+
+		podSpec.Containers = containers
+	}
+}
+
+func (w WireGuard) RemovingVolumes(podSpec *corev1.PodSpec) {
+	if found, index := utilities.HasNamedVolume(podSpec.Volumes, wireGuardVolume); found {
+		var volumes []corev1.Volume
+
+		volumes = append(volumes, podSpec.Volumes[:index]...)
+		volumes = append(volumes, podSpec.Volumes[index+1:]...)
+
+		podSpec.Volumes = volumes
+	}
+}
+
+func (w WireGuard) Build(deployment *appsv1.Deployment, tenantControlPlane kamajiv1alpha1.TenantControlPlane) {
+	w.buildContainer(tenantControlPlane.Spec.Addons.WireGuard, &deployment.Spec.Template.Spec)
+	w.buildVolumes(tenantControlPlane.Status.Addons.WireGuard, &deployment.Spec.Template.Spec)
+
+	w.Scheme.Default(deployment)
+}
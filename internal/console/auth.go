@@ -0,0 +1,123 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package console
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// websocketTokenProtocolPrefix is the sub-protocol carrying the bearer token of the websocket connections,
+// since the browsers can't set their headers: it's the same one supported by the Kubernetes API Server.
+const websocketTokenProtocolPrefix = "base64url.bearer.authorization.k8s.io."
+
+// bearerToken returns the token of the request, from the Authorization header, or the websocket sub-protocols.
+func bearerToken(r *http.Request) string {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		return strings.TrimSpace(token)
+	}
+
+	for _, header := range r.Header.Values("Sec-WebSocket-Protocol") {
+		for _, protocol := range strings.Split(header, ",") {
+			encoded, ok := strings.CutPrefix(strings.TrimSpace(protocol), websocketTokenProtocolPrefix)
+			if !ok {
+				continue
+			}
+
+			token, err := base64.RawURLEncoding.DecodeString(encoded)
+			if err != nil {
+				return ""
+			}
+
+			return string(token)
+		}
+	}
+
+	return ""
+}
+
+// authorize authenticates the request with a TokenReview, and checks with a SubjectAccessReview the user is allowed
+// to perform the given verb on the TenantControlPlane resource, or sub-resource, referenced by the request path.
+func (s *Server) authorize(verb, subresource string, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		logger := log.FromContext(ctx)
+
+		token := bearerToken(r)
+		if len(token) == 0 {
+			writeStatus(w, http.StatusUnauthorized, metav1.StatusReasonUnauthorized, "missing bearer token")
+
+			return
+		}
+
+		tokenReview := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+		if err := s.Client.Create(ctx, tokenReview); err != nil {
+			logger.Error(err, "cannot review the bearer token")
+			writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot review the bearer token")
+
+			return
+		}
+
+		if !tokenReview.Status.Authenticated {
+			writeStatus(w, http.StatusUnauthorized, metav1.StatusReasonUnauthorized, "invalid bearer token")
+
+			return
+		}
+
+		user := tokenReview.Status.User
+
+		extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+		for key, value := range user.Extra {
+			extra[key] = authorizationv1.ExtraValue(value)
+		}
+
+		attributes := &authorizationv1.ResourceAttributes{
+			Namespace:   r.PathValue("namespace"),
+			Verb:        verb,
+			Group:       kamajiv1alpha1.GroupVersion.Group,
+			Version:     kamajiv1alpha1.GroupVersion.Version,
+			Resource:    "tenantcontrolplanes",
+			Subresource: subresource,
+			Name:        r.PathValue("name"),
+		}
+
+		review := &authorizationv1.SubjectAccessReview{
+			Spec: authorizationv1.SubjectAccessReviewSpec{
+				ResourceAttributes: attributes,
+				User:               user.Username,
+				Groups:             user.Groups,
+				Extra:              extra,
+				UID:                user.UID,
+			},
+		}
+		if err := s.Client.Create(ctx, review); err != nil {
+			logger.Error(err, "cannot review the user access")
+			writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot review the user access")
+
+			return
+		}
+
+		if !review.Status.Allowed {
+			resource := attributes.Resource
+			if len(subresource) > 0 {
+				resource += "/" + subresource
+			}
+
+			writeStatus(w, http.StatusForbidden, metav1.StatusReasonForbidden, fmt.Sprintf("user %q cannot %s resource %q in API group %q", user.Username, verb, resource, attributes.Group))
+
+			return
+		}
+
+		next(w, r.WithContext(log.IntoContext(ctx, logger.WithValues("user", user.Username))))
+	})
+}
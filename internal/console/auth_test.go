@@ -0,0 +1,205 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package console
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestBearerToken(t *testing.T) {
+	tests := map[string]http.Header{
+		"secret": {"Authorization": []string{"Bearer secret"}},
+		"socket": {"Sec-Websocket-Protocol": []string{EventsProtocol + ", " + websocketTokenProtocolPrefix + base64.RawURLEncoding.EncodeToString([]byte("socket"))}},
+		"":       {"Authorization": []string{"Basic dXNlcjpwYXNz"}},
+	}
+
+	for expect, header := range tests {
+		if got := bearerToken(&http.Request{Header: header}); got != expect {
+			t.Errorf("expected headers %+v to result in %q, but got %q", header, expect, got)
+		}
+	}
+}
+
+const validToken = "alice-token"
+
+// reviewer emulates the TokenReview and SubjectAccessReview APIs: the validToken authenticates the alice user,
+// allowed to perform the verbs on the TenantControlPlane resources, and sub-resources, in the granted set.
+type reviewer struct {
+	granted map[string]bool
+	reviews []authorizationv1.ResourceAttributes
+}
+
+func (r *reviewer) create(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+	switch review := obj.(type) {
+	case *authenticationv1.TokenReview:
+		if review.Spec.Token == validToken {
+			review.Status = authenticationv1.TokenReviewStatus{
+				Authenticated: true,
+				User:          authenticationv1.UserInfo{Username: "alice", UID: "alice-uid", Groups: []string{"tenant-admins"}},
+			}
+		}
+
+		return nil
+	case *authorizationv1.SubjectAccessReview:
+		attributes := *review.Spec.ResourceAttributes
+		r.reviews = append(r.reviews, attributes)
+
+		review.Status.Allowed = review.Spec.User == "alice" && r.granted[attributes.Verb+" "+attributes.Subresource]
+
+		return nil
+	default:
+		return c.Create(ctx, obj, opts...)
+	}
+}
+
+func newTestServer(r *reviewer, objects ...client.Object) *Server {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithIndex(&corev1.Event{}, "involvedObject.kind", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Event).InvolvedObject.Kind} //nolint:forcetypeassert
+		}).
+		WithIndex(&corev1.Event{}, "involvedObject.name", func(obj client.Object) []string {
+			return []string{obj.(*corev1.Event).InvolvedObject.Name} //nolint:forcetypeassert
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{Create: r.create}).
+		Build()
+
+	return &Server{Client: c, WatchClient: c}
+}
+
+func newTenantControlPlane() *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00"}}
+	tcp.Status.KubeConfig.Admin.SecretName = "tenant-00-admin-kubeconfig"
+
+	return tcp
+}
+
+func serve(s *Server, path, token string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, path, nil)
+	if len(token) > 0 {
+		request.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	recorder := httptest.NewRecorder()
+	s.handler().ServeHTTP(recorder, request)
+
+	return recorder
+}
+
+func decodeStatus(t *testing.T, recorder *httptest.ResponseRecorder) metav1.Status {
+	t.Helper()
+
+	var status metav1.Status
+	if err := json.NewDecoder(recorder.Body).Decode(&status); err != nil {
+		t.Fatalf("cannot decode the Status: %s", err)
+	}
+
+	return status
+}
+
+func TestAuthorizeUnauthenticated(t *testing.T) {
+	r := &reviewer{granted: map[string]bool{"get ": true, "get kubeconfig": true}}
+	s := newTestServer(r, newTenantControlPlane())
+
+	for name, token := range map[string]string{"missing token": "", "invalid token": "mallory-token"} {
+		t.Run(name, func(t *testing.T) {
+			recorder := serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00/kubeconfig", token)
+			if recorder.Code != http.StatusUnauthorized {
+				t.Fatalf("expected the %d status code, got %d", http.StatusUnauthorized, recorder.Code)
+			}
+
+			if status := decodeStatus(t, recorder); status.Reason != metav1.StatusReasonUnauthorized {
+				t.Errorf("expected the %s reason, got %s", metav1.StatusReasonUnauthorized, status.Reason)
+			}
+		})
+	}
+
+	if len(r.reviews) > 0 {
+		t.Errorf("expected no access review for the unauthenticated requests, got %v", r.reviews)
+	}
+}
+
+func TestAuthorizeForbidden(t *testing.T) {
+	r := &reviewer{granted: map[string]bool{}}
+	s := newTestServer(r, newTenantControlPlane())
+
+	recorder := serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00", validToken)
+	if recorder.Code != http.StatusForbidden {
+		t.Fatalf("expected the %d status code, got %d", http.StatusForbidden, recorder.Code)
+	}
+
+	if status := decodeStatus(t, recorder); status.Reason != metav1.StatusReasonForbidden || !strings.Contains(status.Message, `user "alice" cannot get resource "tenantcontrolplanes"`) {
+		t.Errorf("unexpected Status %+v", status)
+	}
+}
+
+func TestAuthorizeAllowed(t *testing.T) {
+	r := &reviewer{granted: map[string]bool{"get ": true}}
+	s := newTestServer(r, newTenantControlPlane())
+
+	recorder := serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00", validToken)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected the %d status code, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	var summary TenantControlPlaneSummary
+	if err := json.NewDecoder(recorder.Body).Decode(&summary); err != nil || summary.Name != "tenant-00" {
+		t.Errorf("unexpected summary %+v: %v", summary, err)
+	}
+
+	expected := authorizationv1.ResourceAttributes{
+		Namespace: "tenants",
+		Verb:      "get",
+		Group:     kamajiv1alpha1.GroupVersion.Group,
+		Version:   kamajiv1alpha1.GroupVersion.Version,
+		Resource:  "tenantcontrolplanes",
+		Name:      "tenant-00",
+	}
+	if len(r.reviews) != 1 || r.reviews[0] != expected {
+		t.Errorf("expected the access review %+v, got %+v", expected, r.reviews)
+	}
+}
+
+func TestAuthorizeVirtualSubresources(t *testing.T) {
+	// The user can read the TenantControlPlane, but neither its kubeconfig, nor its events.
+	r := &reviewer{granted: map[string]bool{"get ": true, "watch ": true}}
+	s := newTestServer(r, newTenantControlPlane())
+
+	for _, subresource := range []string{"kubeconfig", "events"} {
+		t.Run(subresource, func(t *testing.T) {
+			recorder := serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00/"+subresource, validToken)
+			if recorder.Code != http.StatusForbidden {
+				t.Fatalf("expected the %d status code, got %d", http.StatusForbidden, recorder.Code)
+			}
+
+			if status := decodeStatus(t, recorder); !strings.Contains(status.Message, `resource "tenantcontrolplanes/`+subresource+`"`) {
+				t.Errorf("expected the %s sub-resource to be reviewed, got %q", subresource, status.Message)
+			}
+		})
+	}
+}
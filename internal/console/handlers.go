@@ -0,0 +1,305 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package console
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// EventsProtocol is the websocket sub-protocol of the events stream, the clients must request it
+// along with the one carrying the bearer token.
+const EventsProtocol = "events.console.kamaji.clastix.io"
+
+// eventsPingInterval keeps the websocket connections alive through the proxies and the load balancers.
+const eventsPingInterval = 30 * time.Second
+
+// TenantControlPlaneSummary is the representation of a TenantControlPlane served to the user interfaces.
+type TenantControlPlaneSummary struct {
+	Name              string        `json:"name"`
+	Namespace         string        `json:"namespace"`
+	CreationTimestamp metav1.Time   `json:"creationTimestamp"`
+	Phase             string        `json:"phase"`
+	Version           string        `json:"version,omitempty"`
+	Endpoint          string        `json:"endpoint,omitempty"`
+	DataStore         string        `json:"dataStore,omitempty"`
+	Nodes             *NodesSummary `json:"nodes,omitempty"`
+}
+
+// NodesSummary is the aggregated health of the Tenant Cluster worker nodes.
+type NodesSummary struct {
+	Total int32 `json:"total"`
+	Ready int32 `json:"ready"`
+}
+
+// TenantControlPlaneSummaryList is the list of the TenantControlPlane summaries, sorted by namespaced name.
+type TenantControlPlaneSummaryList struct {
+	Items []TenantControlPlaneSummary `json:"items"`
+}
+
+// EventSummary is the representation of a TenantControlPlane event streamed to the user interfaces.
+type EventSummary struct {
+	// Action is the type of the watch notification, such as ADDED, or MODIFIED.
+	Action         string      `json:"action"`
+	Type           string      `json:"type"`
+	Reason         string      `json:"reason"`
+	Message        string      `json:"message"`
+	Count          int32       `json:"count,omitempty"`
+	Source         string      `json:"source,omitempty"`
+	FirstTimestamp metav1.Time `json:"firstTimestamp,omitempty"`
+	LastTimestamp  metav1.Time `json:"lastTimestamp,omitempty"`
+}
+
+func newSummary(tcp kamajiv1alpha1.TenantControlPlane) TenantControlPlaneSummary {
+	summary := TenantControlPlaneSummary{
+		Name:              tcp.GetName(),
+		Namespace:         tcp.GetNamespace(),
+		CreationTimestamp: tcp.GetCreationTimestamp(),
+		Phase:             string(tcp.GetPhase()),
+		Version:           tcp.Status.Kubernetes.Version.Version,
+		Endpoint:          tcp.Status.ControlPlaneEndpoint,
+		DataStore:         tcp.Status.Storage.DataStoreName,
+	}
+
+	if nodes := tcp.Status.Kubernetes.Nodes; nodes != nil {
+		summary.Nodes = &NodesSummary{Total: nodes.Total, Ready: nodes.Ready}
+	}
+
+	return summary
+}
+
+func newEventSummary(action watch.EventType, event corev1.Event) EventSummary {
+	source := event.Source.Component
+	if len(source) == 0 {
+		source = event.ReportingController
+	}
+
+	lastTimestamp := event.LastTimestamp
+	if lastTimestamp.IsZero() {
+		lastTimestamp = metav1.NewTime(event.EventTime.Time)
+	}
+
+	return EventSummary{
+		Action:         string(action),
+		Type:           event.Type,
+		Reason:         event.Reason,
+		Message:        event.Message,
+		Count:          event.Count,
+		Source:         source,
+		FirstTimestamp: event.FirstTimestamp,
+		LastTimestamp:  lastTimestamp,
+	}
+}
+
+func (s *Server) list(w http.ResponseWriter, r *http.Request) {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := s.Client.List(r.Context(), &tcpList, client.InNamespace(r.PathValue("namespace"))); err != nil {
+		log.FromContext(r.Context()).Error(err, "cannot list the TenantControlPlane objects")
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot list the TenantControlPlane objects")
+
+		return
+	}
+
+	list := TenantControlPlaneSummaryList{Items: make([]TenantControlPlaneSummary, 0, len(tcpList.Items))}
+	for _, tcp := range tcpList.Items {
+		list.Items = append(list.Items, newSummary(tcp))
+	}
+
+	slices.SortFunc(list.Items, func(a, b TenantControlPlaneSummary) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	writeJSON(w, http.StatusOK, list)
+}
+
+// getTenantControlPlane retrieves the TenantControlPlane referenced by the request path, reporting the failures.
+func (s *Server) getTenantControlPlane(w http.ResponseWriter, r *http.Request) (*kamajiv1alpha1.TenantControlPlane, bool) {
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := s.Client.Get(r.Context(), types.NamespacedName{Namespace: r.PathValue("namespace"), Name: r.PathValue("name")}, &tcp); err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, fmt.Sprintf("TenantControlPlane %q not found", r.PathValue("name")))
+
+			return nil, false
+		}
+
+		log.FromContext(r.Context()).Error(err, "cannot retrieve the TenantControlPlane")
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot retrieve the TenantControlPlane")
+
+		return nil, false
+	}
+
+	return &tcp, true
+}
+
+func (s *Server) get(w http.ResponseWriter, r *http.Request) {
+	tcp, ok := s.getTenantControlPlane(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, newSummary(*tcp))
+}
+
+func (s *Server) kubeconfig(w http.ResponseWriter, r *http.Request) {
+	tcp, ok := s.getTenantControlPlane(w, r)
+	if !ok {
+		return
+	}
+
+	secretName := tcp.Status.KubeConfig.Admin.SecretName
+	if len(secretName) == 0 {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the admin kubeconfig has not been generated yet")
+
+		return
+	}
+
+	var secret corev1.Secret
+	if err := s.Client.Get(r.Context(), types.NamespacedName{Namespace: tcp.GetNamespace(), Name: secretName}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the admin kubeconfig has not been generated yet")
+
+			return
+		}
+
+		log.FromContext(r.Context()).Error(err, "cannot retrieve the admin kubeconfig")
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot retrieve the admin kubeconfig")
+
+		return
+	}
+
+	content, ok := secret.Data[kubeadmconstants.AdminKubeConfigFileName]
+	if !ok {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, "the admin kubeconfig has not been generated yet")
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/yaml")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%s-%s.kubeconfig", tcp.GetNamespace(), tcp.GetName()))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	_, _ = w.Write(content)
+}
+
+// events streams the events involving the TenantControlPlane over a websocket: the existing ones are sent first,
+// followed by the ones recorded, or updated, afterwards, until the client closes the connection.
+func (s *Server) events(w http.ResponseWriter, r *http.Request) {
+	logger := log.FromContext(r.Context())
+
+	tcp, ok := s.getTenantControlPlane(w, r)
+	if !ok {
+		return
+	}
+
+	selector := &client.ListOptions{
+		Namespace: tcp.GetNamespace(),
+		FieldSelector: fields.SelectorFromSet(fields.Set{
+			"involvedObject.kind": "TenantControlPlane",
+			"involvedObject.name": tcp.GetName(),
+		}),
+	}
+
+	var eventList corev1.EventList
+	if err := s.WatchClient.List(r.Context(), &eventList, selector); err != nil {
+		logger.Error(err, "cannot list the TenantControlPlane events")
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, "cannot list the TenantControlPlane events")
+
+		return
+	}
+
+	upgrader := websocket.Upgrader{
+		Subprotocols: []string{EventsProtocol},
+		// The requests are authenticated with the bearer tokens, rather than the cookies, thus not exposed to cross-site hijacking.
+		CheckOrigin: func(*http.Request) bool { return true },
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader already replied with the failure.
+		return
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	// The client messages are discarded, the read loop is required to process the control frames, and the connection closure.
+	go func() {
+		defer cancel()
+
+		for {
+			if _, _, readErr := conn.NextReader(); readErr != nil {
+				return
+			}
+		}
+	}()
+
+	for _, event := range eventList.Items {
+		if err = conn.WriteJSON(newEventSummary(watch.Added, event)); err != nil {
+			return
+		}
+	}
+
+	selector.Raw = &metav1.ListOptions{ResourceVersion: eventList.GetResourceVersion()}
+
+	watcher, err := s.WatchClient.Watch(ctx, &corev1.EventList{}, selector)
+	if err != nil {
+		logger.Error(err, "cannot watch the TenantControlPlane events")
+		_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseInternalServerErr, "cannot watch the events"), time.Now().Add(time.Second))
+
+		return
+	}
+	defer watcher.Stop()
+
+	ticker := time.NewTicker(eventsPingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err = conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(10*time.Second)); err != nil {
+				return
+			}
+		case notification, open := <-watcher.ResultChan():
+			if !open {
+				// The watch has expired: the client is expected to reconnect, receiving the existing events again.
+				_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "the watch has expired"), time.Now().Add(time.Second))
+
+				return
+			}
+
+			event, isEvent := notification.Object.(*corev1.Event)
+			if !isEvent {
+				continue
+			}
+
+			if err = conn.WriteJSON(newEventSummary(notification.Type, *event)); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package console
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func TestKubeconfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00-admin-kubeconfig"},
+		Data:       map[string][]byte{kubeadmconstants.AdminKubeConfigFileName: []byte("admin-kubeconfig")},
+	}
+
+	r := &reviewer{granted: map[string]bool{"get kubeconfig": true}}
+	s := newTestServer(r, newTenantControlPlane(), secret)
+
+	recorder := serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00/kubeconfig", validToken)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("expected the %d status code, got %d: %s", http.StatusOK, recorder.Code, recorder.Body.String())
+	}
+
+	if body := recorder.Body.String(); body != "admin-kubeconfig" {
+		t.Errorf("unexpected kubeconfig %q", body)
+	}
+
+	if cacheControl := recorder.Header().Get("Cache-Control"); cacheControl != "no-store" {
+		t.Errorf("expected the kubeconfig not to be cached, got %q", cacheControl)
+	}
+
+	if len(r.reviews) != 1 || r.reviews[0].Verb != "get" || r.reviews[0].Subresource != "kubeconfig" {
+		t.Errorf("expected the kubeconfig sub-resource access to be reviewed, got %+v", r.reviews)
+	}
+
+	if recorder = serve(s, "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-01/kubeconfig", validToken); recorder.Code != http.StatusNotFound {
+		t.Errorf("expected the %d status code for a missing TenantControlPlane, got %d", http.StatusNotFound, recorder.Code)
+	}
+}
+
+func TestEvents(t *testing.T) {
+	event := func(name, reason string) *corev1.Event {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Namespace: "tenants", Name: name},
+			InvolvedObject: corev1.ObjectReference{Kind: "TenantControlPlane", Name: "tenant-00"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         reason,
+			Source:         corev1.EventSource{Component: "kamaji"},
+		}
+	}
+
+	r := &reviewer{granted: map[string]bool{"watch events": true}}
+	s := newTestServer(r, newTenantControlPlane(), event("tenant-00.provisioned", "Provisioned"))
+
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	dialer := websocket.Dialer{
+		Subprotocols: []string{EventsProtocol, websocketTokenProtocolPrefix + base64.RawURLEncoding.EncodeToString([]byte(validToken))},
+	}
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00/events"
+
+	conn, response, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("cannot connect to the events stream: %s", err)
+	}
+	defer conn.Close()
+	defer response.Body.Close()
+
+	if conn.Subprotocol() != EventsProtocol {
+		t.Errorf("expected the %s sub-protocol, got %q", EventsProtocol, conn.Subprotocol())
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+
+	var summary EventSummary
+	if err = conn.ReadJSON(&summary); err != nil {
+		t.Fatalf("cannot read the existing event: %s", err)
+	}
+
+	if summary.Action != string(watch.Added) || summary.Reason != "Provisioned" || summary.Source != "kamaji" {
+		t.Errorf("unexpected existing event %+v", summary)
+	}
+
+	if err = s.Client.Create(context.Background(), event("tenant-00.ready", "Ready")); err != nil {
+		t.Fatalf("cannot record the event: %s", err)
+	}
+
+	if err = conn.ReadJSON(&summary); err != nil {
+		t.Fatalf("cannot read the recorded event: %s", err)
+	}
+
+	if summary.Action != string(watch.Added) || summary.Reason != "Ready" {
+		t.Errorf("unexpected recorded event %+v", summary)
+	}
+
+	if len(r.reviews) != 1 || r.reviews[0].Verb != "watch" || r.reviews[0].Subresource != "events" {
+		t.Errorf("expected the events sub-resource access to be reviewed, got %+v", r.reviews)
+	}
+}
+
+func TestEventsUnauthorized(t *testing.T) {
+	s := newTestServer(&reviewer{granted: map[string]bool{"watch events": true}}, newTenantControlPlane())
+
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/api/v1/namespaces/tenants/tenantcontrolplanes/tenant-00/events"
+
+	conn, response, err := (&websocket.Dialer{Subprotocols: []string{EventsProtocol}}).Dial(url, nil)
+	if err == nil {
+		conn.Close()
+		t.Fatal("expected the connection without a bearer token to be rejected")
+	}
+
+	if response == nil {
+		t.Fatalf("expected the handshake to be rejected, got %s", err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusUnauthorized {
+		t.Errorf("expected the %d status code, got %d", http.StatusUnauthorized, response.StatusCode)
+	}
+}
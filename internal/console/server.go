@@ -0,0 +1,122 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package console
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+//+kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch
+
+// Server is the read-only HTTP API backing the user interfaces, such as consoles and dashboards:
+// it exposes the summaries of the TenantControlPlane objects, their admin kubeconfig, and their events,
+// without requiring the user interface to be granted access to the Kamaji resources.
+// The requests are authenticated with the bearer token of the management cluster users, by means of a TokenReview,
+// and authorized with a SubjectAccessReview against the TenantControlPlane resource, or one of its virtual sub-resources.
+type Server struct {
+	// Client is used to retrieve the TenantControlPlane objects and their Secrets, along with the reviews.
+	Client client.Client
+	// WatchClient is used to stream the events, not backed by the cache.
+	WatchClient client.WithWatch
+	// BindAddress is the address the TLS server listens to.
+	BindAddress string
+	// CertDir is the directory containing the tls.crt, and tls.key, files, reloaded upon changes.
+	CertDir string
+}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("console_api")
+
+	watcher, err := certwatcher.New(filepath.Join(s.CertDir, "tls.crt"), filepath.Join(s.CertDir, "tls.key"))
+	if err != nil {
+		return errors.Wrap(err, "cannot load the console API serving certificate")
+	}
+
+	go func() {
+		if watchErr := watcher.Start(ctx); watchErr != nil {
+			logger.Error(watchErr, "cannot watch the console API serving certificate")
+		}
+	}()
+
+	server := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: watcher.GetCertificate,
+		},
+		BaseContext: func(net.Listener) context.Context {
+			return log.IntoContext(ctx, logger)
+		},
+	}
+
+	go func() {
+		<-ctx.Done()
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+			logger.Error(shutdownErr, "cannot gracefully shutdown the console API server")
+		}
+	}()
+
+	logger.Info("starting the console API server", "address", s.BindAddress)
+
+	if err = server.ListenAndServeTLS("", ""); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "cannot serve the console API")
+	}
+
+	return nil
+}
+
+func (s *Server) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /api/v1/tenantcontrolplanes", s.authorize("list", "", s.list))
+	mux.Handle("GET /api/v1/namespaces/{namespace}/tenantcontrolplanes", s.authorize("list", "", s.list))
+	mux.Handle("GET /api/v1/namespaces/{namespace}/tenantcontrolplanes/{name}", s.authorize("get", "", s.get))
+	mux.Handle("GET /api/v1/namespaces/{namespace}/tenantcontrolplanes/{name}/kubeconfig", s.authorize("get", "kubeconfig", s.kubeconfig))
+	mux.Handle("GET /api/v1/namespaces/{namespace}/tenantcontrolplanes/{name}/events", s.authorize("watch", "events", s.events))
+
+	return mux
+}
+
+// writeJSON encodes the given object as the response body.
+func writeJSON(w http.ResponseWriter, code int, obj any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	_ = json.NewEncoder(w).Encode(obj)
+}
+
+// writeStatus reports the failures with a Kubernetes Status object, as the API Server does.
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, message string) {
+	writeJSON(w, code, metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	})
+}
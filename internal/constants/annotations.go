@@ -7,4 +7,10 @@ const (
 	// Checksum is the annotation label that we use to store the checksum for the resource:
 	// it allows to check by comparing it if the resource has been changed and must be aligned with the reconciliation.
 	Checksum = "kamaji.clastix.io/checksum"
+	// TenantControlPlaneAnnotation references the Tenant Control Plane, formatted as <namespace>/<name>,
+	// a cluster-scoped resource has been generated for.
+	TenantControlPlaneAnnotation = "kamaji.clastix.io/tenant-control-plane"
+	// ContentHashAnnotationPrefix is the prefix of the annotations storing the hash of the content applied by a resource handler,
+	// the handler name is the suffix since an object can be managed by several handlers, such as the Control Plane Deployment.
+	ContentHashAnnotationPrefix = "content-hash.kamaji.clastix.io/"
 )
@@ -0,0 +1,11 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+// APIServerTracingConfigurationKey is the ConfigMap key containing the TracingConfiguration of the Tenant Control Plane API server.
+const APIServerTracingConfigurationKey = "tracing-config.yaml"
+
+// APIServerEgressSelectorConfigurationKey is the ConfigMap key containing the EgressSelectorConfiguration of the API server
+// tunnelling the kubelet connections through an HTTP CONNECT proxy.
+const APIServerEgressSelectorConfigurationKey = "egress-selector-configuration.yaml"
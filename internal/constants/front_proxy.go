@@ -0,0 +1,13 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+const (
+	// RequestHeaderUsernameHeaders is the header used by the front-proxy to forward the authenticated user name.
+	RequestHeaderUsernameHeaders = "X-Remote-User"
+	// RequestHeaderGroupHeaders is the header used by the front-proxy to forward the authenticated user groups.
+	RequestHeaderGroupHeaders = "X-Remote-Group"
+	// RequestHeaderExtraHeadersPrefix is the prefix of the headers used by the front-proxy to forward the user extra info.
+	RequestHeaderExtraHeadersPrefix = "X-Remote-Extra-"
+)
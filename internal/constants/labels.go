@@ -10,4 +10,20 @@ const (
 	ControlPlaneLabelKey      = "kamaji.clastix.io/name"
 	ControlPlaneLabelResource = "kamaji.clastix.io/component"
 	ControllerLabelResource   = "kamaji.clastix.io/certificate_lifecycle_controller"
+	// RotationGenerationLabelKey is assigned to the generated Secrets, counting the times their content has been generated.
+	RotationGenerationLabelKey = "kamaji.clastix.io/rotation-generation"
+	// RBACProfileLabelKey is assigned to the Tenant Cluster bindings granting an RBAC profile, referencing its name.
+	RBACProfileLabelKey = "kamaji.clastix.io/rbac-profile"
+	// SignedDiscoveryLabelKey is assigned to the Tenant Cluster bootstrap tokens signing the cluster-info ConfigMap, referencing their ID.
+	SignedDiscoveryLabelKey = "kamaji.clastix.io/signed-discovery"
+	// DedicatedDataStoreLabelKey is assigned to the DataStore generated for a dedicated etcd cluster,
+	// referencing the UID of the Tenant Control Plane it belongs to.
+	DedicatedDataStoreLabelKey = "kamaji.clastix.io/dedicated-datastore"
+)
+
+const (
+	// ShardLabelKey is assigned to the TenantControlPlane objects, referencing the Kamaji shard reconciling them.
+	ShardLabelKey = "kamaji.clastix.io/shard"
+	// ShardLeaseLabelKey is assigned to the Lease objects renewed by the live Kamaji shards.
+	ShardLeaseLabelKey = "kamaji.clastix.io/shard-lease"
 )
@@ -0,0 +1,12 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+const (
+	// SchedulerConfigurationKey is the ConfigMap key containing the KubeSchedulerConfiguration of the Tenant Control Plane.
+	SchedulerConfigurationKey = "scheduler-config.yaml"
+	// SchedulerConfigurationMinVersion is the first Kubernetes version serving the kubescheduler.config.k8s.io/v1 API,
+	// the only KubeSchedulerConfiguration version supported by Kamaji.
+	SchedulerConfigurationMinVersion = "1.25.0"
+)
@@ -0,0 +1,11 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package constants
+
+const (
+	// SootKubeConfigFileName is the kubeconfig used by Kamaji to interact with the Tenant Cluster in least-privilege mode.
+	SootKubeConfigFileName = "soot.conf"
+	// SootUserName is the user of the soot kubeconfig, bound to the scoped Roles in the Tenant Cluster.
+	SootUserName = "kamaji:soot"
+)
@@ -19,8 +19,13 @@ import (
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/clastix/kamaji/internal/fips"
 )
 
+// defaultRSAKeySize is the size of the generated RSA keys, unless running in FIPS mode.
+const defaultRSAKeySize = 2048
+
 // CheckPublicAndPrivateKeyValidity checks if the given bytes for the private and public keys are valid.
 func CheckPublicAndPrivateKeyValidity(publicKey []byte, privateKey []byte) (bool, error) {
 	if len(publicKey) == 0 || len(privateKey) == 0 {
@@ -84,8 +89,8 @@ func CheckCertificateAndPrivateKeyPairValidity(certificate []byte, privateKey []
 }
 
 // GenerateCertificatePrivateKeyPair starts from the Certificate Authority bytes a certificate using the provided
-// template, returning the bytes both for the certificate and its key.
-func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate []byte, caPrivateKey []byte) (*bytes.Buffer, *bytes.Buffer, error) {
+// template, returning the bytes both for the certificate and its key: the FIPS mode enforces the FIPS key size.
+func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate []byte, caPrivateKey []byte, fipsMode bool) (*bytes.Buffer, *bytes.Buffer, error) {
 	caCertBytes, err := ParseCertificateBytes(caCertificate)
 	if err != nil {
 		return nil, nil, err
@@ -96,7 +101,7 @@ func GenerateCertificatePrivateKeyPair(template *x509.Certificate, caCertificate
 		return nil, nil, errors.Wrap(err, "provided CA private key for certificate generation cannot be parsed")
 	}
 
-	return generateCertificateKeyPairBytes(template, caCertBytes, caPrivKeyBytes)
+	return generateCertificateKeyPairBytes(template, caCertBytes, caPrivKeyBytes, fipsMode)
 }
 
 // ParseCertificateBytes takes the certificate bytes returning a x509 certificate by parsing it.
@@ -206,8 +211,25 @@ func VerifyCertificate(cert, ca []byte, usages ...x509.ExtKeyUsage) (bool, error
 	return len(chains) > 0, err
 }
 
-func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey crypto.Signer) (*bytes.Buffer, *bytes.Buffer, error) {
-	certPrivKey, err := rsa.GenerateKey(cryptorand.Reader, 2048)
+// rsaKeySize returns the size of the RSA keys generated by Kamaji, enforcing the FIPS one
+// for the Tenant Control Planes in FIPS mode, or when Kamaji is running in FIPS mode.
+func rsaKeySize(fipsMode bool) int {
+	if fipsMode || fips.Enabled() {
+		return fips.RSAKeySize
+	}
+
+	return defaultRSAKeySize
+}
+
+// generateCertificateKeyPairBytes generates a key as large as the RSA one of the Certificate Authority, if larger than the default one,
+// such as the 3072 bits keys of the Tenant Control Planes running in FIPS mode.
+func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Certificate, caKey crypto.Signer, fipsMode bool) (*bytes.Buffer, *bytes.Buffer, error) {
+	keySize := rsaKeySize(fipsMode)
+	if caPublicKey, ok := caKey.Public().(*rsa.PublicKey); ok {
+		keySize = max(keySize, caPublicKey.N.BitLen())
+	}
+
+	certPrivKey, err := rsa.GenerateKey(cryptorand.Reader, keySize)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "cannot generate an RSA key")
 	}
@@ -217,8 +239,38 @@ func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Ce
 		return nil, nil, errors.Wrap(err, "cannot create the certificate")
 	}
 
+	return encodeCertificatePrivateKeyPair(certBytes, certPrivKey)
+}
+
+// GenerateCACertificatePrivateKeyPair returns the bytes of a self-signed Certificate Authority, and of its key,
+// valid for ten years: the FIPS mode enforces the FIPS key size.
+func GenerateCACertificatePrivateKeyPair(commonName string, fipsMode bool) (*bytes.Buffer, *bytes.Buffer, error) {
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(mathrand.Int63()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().AddDate(10, 0, 0),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+	}
+
+	caPrivKey, err := rsa.GenerateKey(cryptorand.Reader, rsaKeySize(fipsMode))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot generate an RSA key")
+	}
+
+	certBytes, err := x509.CreateCertificate(cryptorand.Reader, template, template, &caPrivKey.PublicKey, caPrivKey)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot create the Certificate Authority")
+	}
+
+	return encodeCertificatePrivateKeyPair(certBytes, caPrivKey)
+}
+
+func encodeCertificatePrivateKeyPair(certBytes []byte, certPrivKey *rsa.PrivateKey) (*bytes.Buffer, *bytes.Buffer, error) {
 	certPEM := &bytes.Buffer{}
-	if err = pem.Encode(certPEM, &pem.Block{
+	if err := pem.Encode(certPEM, &pem.Block{
 		Type:    "CERTIFICATE",
 		Headers: nil,
 		Bytes:   certBytes,
@@ -227,7 +279,7 @@ func generateCertificateKeyPairBytes(template *x509.Certificate, caCert *x509.Ce
 	}
 
 	certPrivKeyPEM := &bytes.Buffer{}
-	if err = pem.Encode(certPrivKeyPEM, &pem.Block{
+	if err := pem.Encode(certPrivKeyPEM, &pem.Block{
 		Type:    "RSA PRIVATE KEY",
 		Headers: nil,
 		Bytes:   x509.MarshalPKCS1PrivateKey(certPrivKey),
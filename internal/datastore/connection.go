@@ -36,6 +36,10 @@ func NewStorageConnection(ctx context.Context, client client.Client, ds kamajiv1
 			cc.TLSConfig.ServerName = cc.Endpoints[0].Host
 		}
 
+		if ds.Spec.GetTenantPrefixStrategy() == kamajiv1alpha1.TenantPrefixStrategySchema {
+			return NewPostgreSQLSchemaConnection(*cc, ds.Spec.GetSharedDatabase())
+		}
+
 		return NewPostgreSQLConnection(*cc)
 	case kamajiv1alpha1.EtcdDriver:
 		return NewETCDConnection(*cc)
@@ -6,6 +6,7 @@ package datastore
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	goerrors "github.com/pkg/errors"
 	"go.etcd.io/etcd/api/v3/authpb"
@@ -195,3 +196,63 @@ func (e *EtcdClient) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantContr
 
 	return nil
 }
+
+// etcdImportPageSize is the number of keys retrieved at once from the source etcd cluster upon import.
+const etcdImportPageSize = 500
+
+// Import copies the keys stored by a kubeadm cluster under the given prefix, such as /registry, into the given schema,
+// replacing the prefix with the one used by the Tenant Control Plane API Server: the keys are read at the same revision,
+// and the ones attached to a lease, such as the Events, or the API Server leases, are skipped since they would never expire.
+// It returns the number of imported keys.
+func (e *EtcdClient) Import(ctx context.Context, source etcdclient.KV, prefix, schema string) (int, error) {
+	key, end := prefix+"/", etcdclient.GetPrefixRangeEnd(prefix+"/")
+
+	var revision int64
+
+	imported := 0
+
+	for {
+		options := []etcdclient.OpOption{etcdclient.WithRange(end), etcdclient.WithLimit(etcdImportPageSize)}
+		if revision > 0 {
+			options = append(options, etcdclient.WithRev(revision))
+		}
+
+		response, err := source.Get(ctx, key, options...)
+		if err != nil {
+			return imported, goerrors.Wrap(err, fmt.Sprintf("cannot retrieve the keys from %s", key))
+		}
+
+		if revision == 0 {
+			revision = response.Header.Revision
+		}
+
+		for _, kv := range response.Kvs {
+			if kv.Lease != 0 {
+				continue
+			}
+
+			target := fmt.Sprintf("/%s%s", schema, strings.TrimPrefix(string(kv.Key), prefix))
+			if _, err = e.Client.Put(ctx, target, string(kv.Value)); err != nil {
+				return imported, goerrors.Wrap(err, fmt.Sprintf("cannot import the key %s", kv.Key))
+			}
+
+			imported++
+		}
+
+		if !response.More || len(response.Kvs) == 0 {
+			return imported, nil
+		}
+
+		key = string(response.Kvs[len(response.Kvs)-1].Key) + "\x00"
+	}
+}
+
+// IsEmpty returns true when no keys are stored in the given schema.
+func (e *EtcdClient) IsEmpty(ctx context.Context, schema string) (bool, error) {
+	response, err := e.Client.Get(ctx, e.buildKey(schema), etcdclient.WithPrefix(), etcdclient.WithCountOnly())
+	if err != nil {
+		return false, err
+	}
+
+	return response.Count == 0, nil
+}
@@ -17,19 +17,27 @@ import (
 )
 
 const (
-	postgresqlFetchDBStatement            = "SELECT FROM pg_database WHERE datname = ?"
-	postgresqlCreateDBStatement           = "CREATE DATABASE %s"
-	postgresqlUserExists                  = "SELECT 1 FROM pg_roles WHERE rolname = ?"
-	postgresqlCreateUserStatement         = "CREATE ROLE %s LOGIN PASSWORD ?"
-	postgresqlShowGrantsStatement         = "SELECT has_database_privilege(rolname, ?, 'create') from pg_roles where rolcanlogin and rolname = ?"
-	postgresqlShowOwnershipStatement      = "SELECT 't' FROM pg_catalog.pg_database AS d WHERE d.datname = ? AND pg_catalog.pg_get_userbyid(d.datdba) = ?"
-	postgresqlShowTableOwnershipStatement = "SELECT 't' from pg_tables where tableowner = ? AND tablename = ?"
-	postgresqlKineTableExistsStatement    = "SELECT 't' FROM pg_tables WHERE schemaname = ? AND tablename  = ?"
-	postgresqlGrantPrivilegesStatement    = "GRANT ALL PRIVILEGES ON DATABASE %s TO %s"
-	postgresqlChangeOwnerStatement        = "ALTER DATABASE %s OWNER TO %s"
-	postgresqlRevokePrivilegesStatement   = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s"
-	postgresqlDropRoleStatement           = "DROP ROLE %s"
-	postgresqlDropDBStatement             = "DROP DATABASE %s WITH (FORCE)"
+	postgresqlFetchDBStatement             = "SELECT FROM pg_database WHERE datname = ?"
+	postgresqlCreateDBStatement            = "CREATE DATABASE %s"
+	postgresqlUserExists                   = "SELECT 1 FROM pg_roles WHERE rolname = ?"
+	postgresqlCreateUserStatement          = "CREATE ROLE %s LOGIN PASSWORD ?"
+	postgresqlShowGrantsStatement          = "SELECT has_database_privilege(rolname, ?, 'create') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlShowOwnershipStatement       = "SELECT 't' FROM pg_catalog.pg_database AS d WHERE d.datname = ? AND pg_catalog.pg_get_userbyid(d.datdba) = ?"
+	postgresqlShowTableOwnershipStatement  = "SELECT 't' from pg_tables where tableowner = ? AND tablename = ?"
+	postgresqlKineTableExistsStatement     = "SELECT 't' FROM pg_tables WHERE schemaname = ? AND tablename  = ?"
+	postgresqlGrantPrivilegesStatement     = "GRANT ALL PRIVILEGES ON DATABASE %s TO %s"
+	postgresqlChangeOwnerStatement         = "ALTER DATABASE %s OWNER TO %s"
+	postgresqlRevokePrivilegesStatement    = "REVOKE ALL PRIVILEGES ON DATABASE %s FROM %s"
+	postgresqlDropRoleStatement            = "DROP ROLE %s"
+	postgresqlDropDBStatement              = "DROP DATABASE %s WITH (FORCE)"
+	postgresqlFetchSchemaStatement         = "SELECT FROM pg_namespace WHERE nspname = ?"
+	postgresqlCreateSchemaStatement        = "CREATE SCHEMA %s"
+	postgresqlShowSchemaGrantsStatement    = "SELECT has_database_privilege(rolname, ?, 'connect') from pg_roles where rolcanlogin and rolname = ?"
+	postgresqlShowSchemaOwnershipStatement = "SELECT 't' FROM pg_namespace WHERE nspname = ? AND pg_catalog.pg_get_userbyid(nspowner) = ?"
+	postgresqlShowSchemaTableOwnership     = "SELECT 't' from pg_tables where tableowner = ? AND tablename = ? AND schemaname = ?"
+	postgresqlGrantConnectStatement        = "GRANT CONNECT ON DATABASE %s TO %s"
+	postgresqlChangeSchemaOwnerStatement   = "ALTER SCHEMA %s OWNER TO %s"
+	postgresqlDropSchemaStatement          = "DROP SCHEMA %s CASCADE"
 )
 
 type PostgreSQLConnection struct {
@@ -37,6 +45,10 @@ type PostgreSQLConnection struct {
 	connection       ConnectionEndpoint
 	rootUser         string
 	switchDatabaseFn func(dbName string) *pg.DB
+	// sharedDatabase hosts the tenant schemas with the Schema strategy:
+	// when empty, each tenant has its own database.
+	sharedDatabase string
+	options        pg.Options
 }
 
 func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target Connection) error {
@@ -51,7 +63,11 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		}
 	}
 
-	targetConn := target.(*PostgreSQLConnection).switchDatabaseFn(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
+	targetConn := target.(*PostgreSQLConnection).tenantDB(tcp.Status.Storage.Setup.Schema) //nolint:forcetypeassert
+	defer targetConn.Close()
+
+	sourceConn := r.tenantDB(tcp.Status.Storage.Setup.Schema)
+	defer sourceConn.Close()
 
 	err := targetConn.RunInTransaction(ctx, func(tx *pg.Tx) error {
 		for _, stm := range []string{
@@ -80,7 +96,7 @@ func (r *PostgreSQLConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.T
 		// Dumping the old datastore in a local buffer
 		var buf bytes.Buffer
 
-		if _, err := r.switchDatabaseFn(tcp.Status.Storage.Setup.Schema).WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil {
+		if _, err := sourceConn.WithContext(ctx).CopyTo(&buf, "COPY kine TO STDOUT"); err != nil {
 			return fmt.Errorf("unable to copy from the origin datastore: %w", err)
 		}
 
@@ -118,9 +134,66 @@ func NewPostgreSQLConnection(config ConnectionConfig) (Connection, error) {
 		switchDatabaseFn: fn,
 		rootUser:         config.User,
 		connection:       config.Endpoints[0],
+		options:          *opt,
 	}, nil
 }
 
+// NewPostgreSQLSchemaConnection returns a connection storing each tenant in its own schema of the shared database,
+// rather than in its own database: the tenant name is used as schema name.
+func NewPostgreSQLSchemaConnection(config ConnectionConfig, sharedDatabase string) (Connection, error) {
+	connection, err := NewPostgreSQLConnection(config)
+	if err != nil {
+		return nil, err
+	}
+
+	connection.(*PostgreSQLConnection).sharedDatabase = sharedDatabase //nolint:forcetypeassert
+
+	return connection, nil
+}
+
+// tenantDB returns a connection to the tenant data: the tenant database,
+// or the shared one with the search path set to the tenant schema.
+func (r *PostgreSQLConnection) tenantDB(name string) *pg.DB {
+	if r.sharedDatabase == "" {
+		return r.switchDatabaseFn(name)
+	}
+
+	opt := r.options
+	opt.Database = r.sharedDatabase
+	opt.OnConnect = func(ctx context.Context, conn *pg.Conn) error {
+		_, err := conn.ExecContext(ctx, fmt.Sprintf("SET search_path TO %s", name))
+
+		return err
+	}
+
+	return pg.Connect(&opt)
+}
+
+// tenantSchema returns the schema hosting the kine table of the given tenant.
+func (r *PostgreSQLConnection) tenantSchema(name string) string {
+	if r.sharedDatabase == "" {
+		return "public"
+	}
+
+	return name
+}
+
+// ensureSharedDatabase creates the shared database hosting the tenant schemas, if missing.
+func (r *PostgreSQLConnection) ensureSharedDatabase(ctx context.Context) error {
+	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, r.sharedDatabase)
+	if err != nil {
+		return err
+	}
+
+	if rows.RowsReturned() > 0 {
+		return nil
+	}
+
+	_, err = r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, r.sharedDatabase))
+
+	return err
+}
+
 func (r *PostgreSQLConnection) Driver() string {
 	return string(kamajiv1alpha1.KinePostgreSQLDriver)
 }
@@ -144,6 +217,10 @@ func (r *PostgreSQLConnection) CreateUser(ctx context.Context, user, password st
 }
 
 func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if r.sharedDatabase != "" {
+		return r.schemaExists(ctx, dbName)
+	}
+
 	rows, err := r.db.ExecContext(ctx, postgresqlFetchDBStatement, dbName)
 	if err != nil {
 		return false, errors.NewCheckDatabaseExistError(err)
@@ -153,6 +230,10 @@ func (r *PostgreSQLConnection) DBExists(ctx context.Context, dbName string) (boo
 }
 
 func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) error {
+	if r.sharedDatabase != "" {
+		return r.createSchema(ctx, dbName)
+	}
+
 	_, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlCreateDBStatement, dbName))
 	if err != nil {
 		return errors.NewCreateDBError(err)
@@ -162,6 +243,10 @@ func (r *PostgreSQLConnection) CreateDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if r.sharedDatabase != "" {
+		return r.schemaGrantPrivilegesExists(ctx, user, dbName)
+	}
+
 	var hasDatabasePrivilege string
 
 	_, err := r.db.QueryContext(ctx, pg.Scan(&hasDatabasePrivilege), postgresqlShowGrantsStatement, dbName, user)
@@ -184,7 +269,7 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 	dbConn := r.switchDatabaseFn(dbName)
 	defer dbConn.Close()
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return false, errors.NewGrantPrivilegesError(err)
 	}
@@ -201,6 +286,10 @@ func (r *PostgreSQLConnection) GrantPrivilegesExists(ctx context.Context, user,
 }
 
 func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if r.sharedDatabase != "" {
+		return r.grantSchemaPrivileges(ctx, user, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantPrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -212,7 +301,7 @@ func (r *PostgreSQLConnection) GrantPrivileges(ctx context.Context, user, dbName
 		return errors.NewGrantPrivilegesError(err)
 	}
 
-	tableExists, err := r.kineTableExists(ctx, dbConn)
+	tableExists, err := r.kineTableExists(ctx, dbConn, "public")
 	if err != nil {
 		return errors.NewGrantPrivilegesError(err)
 	}
@@ -239,6 +328,10 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 		return errors.NewCannotDeleteDatabaseError(goerrors.Wrap(err, "cannot grant privileges to root user"))
 	}
 
+	if r.sharedDatabase != "" {
+		return r.dropSchema(ctx, dbName)
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlDropDBStatement, dbName)); err != nil {
 		return errors.NewCannotDeleteDatabaseError(err)
 	}
@@ -247,6 +340,12 @@ func (r *PostgreSQLConnection) DeleteDB(ctx context.Context, dbName string) erro
 }
 
 func (r *PostgreSQLConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	// With the Schema strategy, the privileges are the ones on the shared database,
+	// while the schema ownership is taken over by the root user upon its deletion.
+	if r.sharedDatabase != "" {
+		dbName = r.sharedDatabase
+	}
+
 	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlRevokePrivilegesStatement, dbName, user)); err != nil {
 		return errors.NewRevokePrivilegesError(err)
 	}
@@ -274,12 +373,118 @@ func (r *PostgreSQLConnection) Check(ctx context.Context) error {
 	return nil
 }
 
-func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB) (bool, error) {
+func (r *PostgreSQLConnection) kineTableExists(ctx context.Context, db *pg.DB, schema string) (bool, error) {
 	var tableExists string
 
-	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, "public", "kine"); err != nil {
+	if _, err := db.QueryContext(ctx, pg.Scan(&tableExists), postgresqlKineTableExistsStatement, schema, "kine"); err != nil {
 		return false, err
 	}
 
 	return tableExists == "t", nil
 }
+
+func (r *PostgreSQLConnection) schemaExists(ctx context.Context, schema string) (bool, error) {
+	if err := r.ensureSharedDatabase(ctx); err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	rows, err := dbConn.ExecContext(ctx, postgresqlFetchSchemaStatement, schema)
+	if err != nil {
+		return false, errors.NewCheckDatabaseExistError(err)
+	}
+
+	return rows.RowsReturned() > 0, nil
+}
+
+func (r *PostgreSQLConnection) createSchema(ctx context.Context, schema string) error {
+	if err := r.ensureSharedDatabase(ctx); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlCreateSchemaStatement, schema)); err != nil {
+		return errors.NewCreateDBError(err)
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) schemaGrantPrivilegesExists(ctx context.Context, user, schema string) (bool, error) {
+	var hasConnectPrivilege string
+
+	if _, err := r.db.QueryContext(ctx, pg.Scan(&hasConnectPrivilege), postgresqlShowSchemaGrantsStatement, r.sharedDatabase, user); err != nil {
+		if strings.Contains(err.Error(), "does not exist") {
+			return false, nil
+		}
+
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	var isOwner string
+
+	if _, err := dbConn.QueryContext(ctx, pg.Scan(&isOwner), postgresqlShowSchemaOwnershipStatement, schema, user); err != nil {
+		return false, errors.NewCheckGrantExistsError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return false, errors.NewGrantPrivilegesError(err)
+	}
+
+	if tableExists {
+		var isTableOwner string
+
+		if _, err = dbConn.QueryContext(ctx, pg.Scan(&isTableOwner), postgresqlShowSchemaTableOwnership, user, "kine", schema); err != nil {
+			return false, errors.NewCheckGrantExistsError(err)
+		}
+
+		return hasConnectPrivilege == "t" && isOwner == "t" && isTableOwner == "t", nil
+	}
+
+	return hasConnectPrivilege == "t" && isOwner == "t", nil
+}
+
+func (r *PostgreSQLConnection) grantSchemaPrivileges(ctx context.Context, user, schema string) error {
+	if _, err := r.db.ExecContext(ctx, fmt.Sprintf(postgresqlGrantConnectStatement, r.sharedDatabase, user)); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlChangeSchemaOwnerStatement, schema, user)); err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	tableExists, err := r.kineTableExists(ctx, dbConn, schema)
+	if err != nil {
+		return errors.NewGrantPrivilegesError(err)
+	}
+
+	if tableExists {
+		if _, err = dbConn.ExecContext(ctx, fmt.Sprintf("ALTER TABLE %s.kine OWNER TO %s", schema, user)); err != nil {
+			return errors.NewGrantPrivilegesError(err)
+		}
+	}
+
+	return nil
+}
+
+func (r *PostgreSQLConnection) dropSchema(ctx context.Context, schema string) error {
+	dbConn := r.switchDatabaseFn(r.sharedDatabase)
+	defer dbConn.Close()
+
+	if _, err := dbConn.ExecContext(ctx, fmt.Sprintf(postgresqlDropSchemaStatement, schema)); err != nil {
+		return errors.NewCannotDeleteDatabaseError(err)
+	}
+
+	return nil
+}
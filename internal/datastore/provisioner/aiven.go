@@ -0,0 +1,46 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const aivenRunningState = "RUNNING"
+
+// Aiven requests a managed SQL service from the Aiven operator, by means of a PostgreSQL, or a MySQL, resource.
+type Aiven struct{}
+
+func (a Aiven) GroupVersionKind(driver kamajiv1alpha1.Driver) (schema.GroupVersionKind, error) {
+	gvk := schema.GroupVersionKind{Group: "aiven.io", Version: "v1alpha1"}
+
+	switch driver {
+	case kamajiv1alpha1.KinePostgreSQLDriver:
+		gvk.Kind = "PostgreSQL"
+	case kamajiv1alpha1.KineMySQLDriver:
+		gvk.Kind = "MySQL"
+	default:
+		return schema.GroupVersionKind{}, fmt.Errorf("the Aiven operator doesn't support the %s driver", driver)
+	}
+
+	return gvk, nil
+}
+
+func (a Aiven) IsReady(object *unstructured.Unstructured) (bool, string) {
+	state, _, _ := unstructured.NestedString(object.Object, "status", "state")
+	if state == aivenRunningState {
+		return true, ""
+	}
+
+	if state == "" {
+		state = "unknown"
+	}
+
+	return false, fmt.Sprintf("the %s service state is %s", object.GetKind(), state)
+}
@@ -0,0 +1,32 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// EtcdDruid requests an etcd cluster from etcd-druid, by means of an Etcd resource.
+type EtcdDruid struct{}
+
+func (e EtcdDruid) GroupVersionKind(driver kamajiv1alpha1.Driver) (schema.GroupVersionKind, error) {
+	if driver != kamajiv1alpha1.EtcdDriver {
+		return schema.GroupVersionKind{}, fmt.Errorf("etcd-druid doesn't support the %s driver", driver)
+	}
+
+	return schema.GroupVersionKind{Group: "druid.gardener.cloud", Version: "v1alpha1", Kind: "Etcd"}, nil
+}
+
+func (e EtcdDruid) IsReady(object *unstructured.Unstructured) (bool, string) {
+	if ready, _, _ := unstructured.NestedBool(object.Object, "status", "ready"); ready {
+		return true, ""
+	}
+
+	return false, "the Etcd resource is not ready"
+}
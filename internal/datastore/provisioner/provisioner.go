@@ -0,0 +1,90 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// DataStoreProvisioner requests the backend of a DataStore from an external operator,
+// by means of a resource whose specification is declared by the DataStore.
+type DataStoreProvisioner interface {
+	// GroupVersionKind returns the kind of the resource requested for the given driver.
+	GroupVersionKind(driver kamajiv1alpha1.Driver) (schema.GroupVersionKind, error)
+	// IsReady returns true once the requested resource is ready, or the reason it isn't otherwise.
+	IsReady(object *unstructured.Unstructured) (bool, string)
+}
+
+// New returns the DataStoreProvisioner of the given DataStore.
+func New(dataStore kamajiv1alpha1.DataStore) (DataStoreProvisioner, error) {
+	if dataStore.Spec.Provisioner == nil {
+		return nil, fmt.Errorf("the DataStore %s has no provisioner", dataStore.GetName())
+	}
+
+	switch dataStore.Spec.Provisioner.Name {
+	case kamajiv1alpha1.DataStoreProvisionerEtcdDruid:
+		return EtcdDruid{}, nil
+	case kamajiv1alpha1.DataStoreProvisionerAiven:
+		return Aiven{}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized DataStore provisioner %s", dataStore.Spec.Provisioner.Name)
+	}
+}
+
+// Provision creates, or updates, the resource requested for the given DataStore, owned by it:
+// it returns true once the resource is ready, or the reason it isn't otherwise.
+func Provision(ctx context.Context, c client.Client, dataStore *kamajiv1alpha1.DataStore) (bool, string, error) {
+	provisioner, err := New(*dataStore)
+	if err != nil {
+		return false, "", err
+	}
+
+	gvk, err := provisioner.GroupVersionKind(dataStore.Spec.Driver)
+	if err != nil {
+		return false, "", err
+	}
+
+	spec := map[string]any{}
+
+	if raw := dataStore.Spec.Provisioner.Spec; raw != nil && len(raw.Raw) > 0 {
+		if err = json.Unmarshal(raw.Raw, &spec); err != nil {
+			return false, "", errors.Wrap(err, "cannot decode the provisioner specification")
+		}
+	}
+
+	object := &unstructured.Unstructured{}
+	object.SetGroupVersionKind(gvk)
+	object.SetName(dataStore.GetName())
+	object.SetNamespace(dataStore.Spec.Provisioner.Namespace)
+
+	if _, err = controllerutil.CreateOrUpdate(ctx, c, object, func() error {
+		object.SetLabels(utilities.MergeMaps(object.GetLabels(), map[string]string{
+			constants.ProjectNameLabelKey: constants.ProjectNameLabelValue,
+		}))
+
+		if err = unstructured.SetNestedField(object.Object, spec, "spec"); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(dataStore, object, c.Scheme())
+	}); err != nil {
+		return false, "", errors.Wrap(err, fmt.Sprintf("cannot reconcile the %s %s/%s", gvk.Kind, object.GetNamespace(), object.GetName()))
+	}
+
+	ready, reason := provisioner.IsReady(object)
+
+	return ready, reason, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package provisioner
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestGroupVersionKind(t *testing.T) {
+	if _, err := (EtcdDruid{}).GroupVersionKind(kamajiv1alpha1.KinePostgreSQLDriver); err == nil {
+		t.Errorf("expected etcd-druid to reject the PostgreSQL driver")
+	}
+
+	if gvk, _ := (Aiven{}).GroupVersionKind(kamajiv1alpha1.KineMySQLDriver); gvk.Kind != "MySQL" {
+		t.Errorf("expected the MySQL kind, but got %q", gvk.Kind)
+	}
+
+	if _, err := (Aiven{}).GroupVersionKind(kamajiv1alpha1.KineNatsDriver); err == nil {
+		t.Errorf("expected the Aiven operator to reject the NATS driver")
+	}
+}
+
+func TestIsReady(t *testing.T) {
+	tests := map[string]struct {
+		provisioner DataStoreProvisioner
+		status      map[string]any
+		expect      bool
+	}{
+		"etcd-druid without status": {provisioner: EtcdDruid{}, status: nil, expect: false},
+		"etcd-druid not ready":      {provisioner: EtcdDruid{}, status: map[string]any{"ready": false}, expect: false},
+		"etcd-druid ready":          {provisioner: EtcdDruid{}, status: map[string]any{"ready": true}, expect: true},
+		"aiven rebuilding":          {provisioner: Aiven{}, status: map[string]any{"state": "REBUILDING"}, expect: false},
+		"aiven running":             {provisioner: Aiven{}, status: map[string]any{"state": "RUNNING"}, expect: true},
+	}
+
+	for name, test := range tests {
+		object := &unstructured.Unstructured{Object: map[string]any{}}
+		if test.status != nil {
+			object.Object["status"] = test.status
+		}
+
+		if got, reason := test.provisioner.IsReady(object); got != test.expect {
+			t.Errorf("%s: expected readiness %t, but got %t (%s)", name, test.expect, got, reason)
+		}
+	}
+}
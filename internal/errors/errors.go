@@ -20,3 +20,18 @@ type MissingValidIPError struct{}
 func (m MissingValidIPError) Error() string {
 	return "the actual resource doesn't have yet a valid IP address"
 }
+
+// ImageVerificationError reports a component image failing the signature verification:
+// the reconciliation is stopped, preventing the unverified image from being rolled out.
+type ImageVerificationError struct {
+	Image string
+	Err   error
+}
+
+func (i ImageVerificationError) Error() string {
+	return "the image " + i.Image + " cannot be verified: " + i.Err.Error()
+}
+
+func (i ImageVerificationError) Unwrap() error {
+	return i.Err
+}
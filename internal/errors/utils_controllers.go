@@ -3,7 +3,13 @@
 
 package errors
 
-import "github.com/pkg/errors"
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
 
 func ShouldReconcileErrorBeIgnored(err error) bool {
 	switch {
@@ -17,3 +23,22 @@ func ShouldReconcileErrorBeIgnored(err error) bool {
 		return false
 	}
 }
+
+// IsTransientError returns true for the errors expected to be solved by retrying,
+// such as the optimistic concurrency conflicts, the timeouts, and the API Server throttling.
+func IsTransientError(err error) bool {
+	var netErr net.Error
+
+	switch {
+	case apierrors.IsConflict(err), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err):
+		return true
+	case apierrors.IsTooManyRequests(err), apierrors.IsServiceUnavailable(err), apierrors.IsInternalError(err):
+		return true
+	case errors.Is(err, context.DeadlineExceeded):
+		return true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return true
+	default:
+		return false
+	}
+}
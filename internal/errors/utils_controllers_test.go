@@ -0,0 +1,42 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package errors
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestIsTransientError(t *testing.T) {
+	resource := schema.GroupResource{Group: "kamaji.clastix.io", Resource: "tenantcontrolplanes"}
+
+	testCases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "conflict", err: apierrors.NewConflict(resource, "tenant-00", errors.New("the object has been modified")), expected: true},
+		{name: "wrapped conflict", err: fmt.Errorf("cannot apply: %w", apierrors.NewConflict(resource, "tenant-00", errors.New("the object has been modified"))), expected: true},
+		{name: "server timeout", err: apierrors.NewServerTimeout(resource, "update", 1), expected: true},
+		{name: "throttling", err: apierrors.NewTooManyRequests("slow down", 1), expected: true},
+		{name: "service unavailable", err: apierrors.NewServiceUnavailable("etcd leader changed"), expected: true},
+		{name: "deadline exceeded", err: errors.Wrap(context.DeadlineExceeded, "cannot retrieve the Secret"), expected: true},
+		{name: "invalid", err: apierrors.NewInvalid(schema.GroupKind{Kind: "Deployment"}, "tenant-00", nil), expected: false},
+		{name: "forbidden", err: apierrors.NewForbidden(resource, "tenant-00", errors.New("denied")), expected: false},
+		{name: "generic", err: errors.New("cannot generate the certificate"), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if actual := IsTransientError(tc.err); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
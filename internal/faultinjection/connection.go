@@ -0,0 +1,125 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+// WrapConnection injects the DataStoreTimeouts fault in the calls performed with the given DataStore connection:
+// the failed calls are not performed, returning a deadline exceeded error.
+func (i *Injector) WrapConnection(connection datastore.Connection) datastore.Connection {
+	if i == nil || i.probabilities[DataStoreTimeouts] == 0 {
+		return connection
+	}
+
+	return &faultyConnection{Connection: connection, injector: i}
+}
+
+type faultyConnection struct {
+	datastore.Connection
+
+	injector *Injector
+}
+
+func (f *faultyConnection) fault() error {
+	if !f.injector.Inject(DataStoreTimeouts) {
+		return nil
+	}
+
+	return errors.Wrap(context.DeadlineExceeded, "injected fault: "+string(DataStoreTimeouts))
+}
+
+func (f *faultyConnection) CreateUser(ctx context.Context, user, password string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.CreateUser(ctx, user, password)
+}
+
+func (f *faultyConnection) CreateDB(ctx context.Context, dbName string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.CreateDB(ctx, dbName)
+}
+
+func (f *faultyConnection) GrantPrivileges(ctx context.Context, user, dbName string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.GrantPrivileges(ctx, user, dbName)
+}
+
+func (f *faultyConnection) UserExists(ctx context.Context, user string) (bool, error) {
+	if err := f.fault(); err != nil {
+		return false, err
+	}
+
+	return f.Connection.UserExists(ctx, user)
+}
+
+func (f *faultyConnection) DBExists(ctx context.Context, dbName string) (bool, error) {
+	if err := f.fault(); err != nil {
+		return false, err
+	}
+
+	return f.Connection.DBExists(ctx, dbName)
+}
+
+func (f *faultyConnection) GrantPrivilegesExists(ctx context.Context, user, dbName string) (bool, error) {
+	if err := f.fault(); err != nil {
+		return false, err
+	}
+
+	return f.Connection.GrantPrivilegesExists(ctx, user, dbName)
+}
+
+func (f *faultyConnection) DeleteUser(ctx context.Context, user string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.DeleteUser(ctx, user)
+}
+
+func (f *faultyConnection) DeleteDB(ctx context.Context, dbName string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.DeleteDB(ctx, dbName)
+}
+
+func (f *faultyConnection) RevokePrivileges(ctx context.Context, user, dbName string) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.RevokePrivileges(ctx, user, dbName)
+}
+
+func (f *faultyConnection) Check(ctx context.Context) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.Check(ctx)
+}
+
+func (f *faultyConnection) Migrate(ctx context.Context, tcp kamajiv1alpha1.TenantControlPlane, target datastore.Connection) error {
+	if err := f.fault(); err != nil {
+		return err
+	}
+
+	return f.Connection.Migrate(ctx, tcp, target)
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Package faultinjection provides the hooks injecting controlled failures, exercising the error handling paths of Kamaji,
+// such as the soot manager failed annotation, the clean-ups, and the backoff: it's intended for development purposes only.
+package faultinjection
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fault is a failure injected by the Injector.
+type Fault string
+
+const (
+	// TenantAPIErrors makes the requests to the Tenant Cluster API Server, performed by the soot managers, fail with a 500 status.
+	TenantAPIErrors Fault = "TenantAPIErrors"
+	// DataStoreTimeouts makes the calls to the DataStore, performed by the Tenant Control Plane controller, time out.
+	DataStoreTimeouts Fault = "DataStoreTimeouts"
+	// CertificateExpiry makes the certificate lifecycle controller consider the checked certificates as expiring, triggering their rotation.
+	CertificateExpiry Fault = "CertificateExpiry"
+)
+
+var faults = []Fault{TenantAPIErrors, DataStoreTimeouts, CertificateExpiry}
+
+// Injector decides whether a fault must be injected, according to its probability: a nil Injector never injects faults.
+type Injector struct {
+	probabilities map[Fault]float64
+}
+
+// Parse returns the Injector for the given comma separated list of faults and their probabilities,
+// such as TenantAPIErrors=0.1,DataStoreTimeouts=0.05: it returns a nil Injector if the value is empty.
+func Parse(value string) (*Injector, error) {
+	if len(value) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	injector := &Injector{probabilities: map[Fault]float64{}}
+
+	for _, item := range strings.Split(value, ",") {
+		name, rawProbability, ok := strings.Cut(strings.TrimSpace(item), "=")
+		if !ok {
+			return nil, fmt.Errorf("the fault %q must be expressed as name=probability", item)
+		}
+
+		fault := Fault(name)
+		if !slices.Contains(faults, fault) {
+			return nil, fmt.Errorf("unknown fault %q, supported ones are %v", name, faults)
+		}
+
+		probability, err := strconv.ParseFloat(rawProbability, 64)
+		if err != nil || probability < 0 || probability > 1 {
+			return nil, fmt.Errorf("the probability of the fault %s must be a number between 0 and 1, got %q", name, rawProbability)
+		}
+
+		injector.probabilities[fault] = probability
+	}
+
+	return injector, nil
+}
+
+// Inject returns true if the given fault must be injected.
+func (i *Injector) Inject(fault Fault) bool {
+	if i == nil {
+		return false
+	}
+
+	probability := i.probabilities[fault]
+
+	return probability > 0 && rand.Float64() < probability //nolint:gosec
+}
+
+// String returns the enabled faults, along with their probabilities.
+func (i *Injector) String() string {
+	if i == nil {
+		return ""
+	}
+
+	items := make([]string, 0, len(i.probabilities))
+
+	for _, fault := range faults {
+		if probability, ok := i.probabilities[fault]; ok {
+			items = append(items, fmt.Sprintf("%s=%s", fault, strconv.FormatFloat(probability, 'f', -1, 64)))
+		}
+	}
+
+	return strings.Join(items, ",")
+}
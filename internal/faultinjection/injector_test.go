@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/clastix/kamaji/internal/datastore"
+)
+
+func TestParse(t *testing.T) {
+	injector, err := Parse("")
+	if err != nil || injector != nil {
+		t.Fatalf("expected a nil injector for the empty value, got %v, %v", injector, err)
+	}
+
+	if injector.Inject(TenantAPIErrors) {
+		t.Errorf("expected the nil injector to never inject faults")
+	}
+
+	for _, value := range []string{"TenantAPIErrors", "Unknown=0.1", "TenantAPIErrors=2", "DataStoreTimeouts=-1", "CertificateExpiry=often"} {
+		if _, err = Parse(value); err == nil {
+			t.Errorf("expected %q to be rejected", value)
+		}
+	}
+
+	injector, err = Parse("CertificateExpiry=0, TenantAPIErrors=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expect := "TenantAPIErrors=1,CertificateExpiry=0"; injector.String() != expect {
+		t.Errorf("expected %q, got %q", expect, injector.String())
+	}
+
+	if !injector.Inject(TenantAPIErrors) || injector.Inject(CertificateExpiry) || injector.Inject(DataStoreTimeouts) {
+		t.Errorf("expected only the faults with probability 1 to be injected")
+	}
+}
+
+type stubConnection struct {
+	datastore.Connection
+
+	checks int
+}
+
+func (s *stubConnection) Check(context.Context) error {
+	s.checks++
+
+	return nil
+}
+
+func TestWrapConnection(t *testing.T) {
+	connection := &stubConnection{}
+
+	injector, _ := Parse("TenantAPIErrors=1")
+	if injector.WrapConnection(connection) != connection {
+		t.Errorf("expected the connection not to be wrapped without the DataStoreTimeouts fault")
+	}
+
+	injector, _ = Parse("DataStoreTimeouts=1")
+	if err := injector.WrapConnection(connection).Check(context.Background()); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected a deadline exceeded error, got %v", err)
+	}
+
+	if connection.checks != 0 {
+		t.Errorf("expected the faulty call not to reach the DataStore")
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+// WrapConfig injects the TenantAPIErrors fault in the requests performed with the given REST configuration:
+// the failed requests are not sent, and a 500 status is returned, as an overloaded API Server would do.
+func (i *Injector) WrapConfig(config *rest.Config) {
+	if i == nil || i.probabilities[TenantAPIErrors] == 0 {
+		return
+	}
+
+	config.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return &roundTripper{injector: i, next: rt}
+	})
+}
+
+type roundTripper struct {
+	injector *Injector
+	next     http.RoundTripper
+}
+
+func (r *roundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	if !r.injector.Inject(TenantAPIErrors) {
+		return r.next.RoundTrip(request)
+	}
+
+	if request.Body != nil {
+		_ = request.Body.Close()
+	}
+
+	body, err := json.Marshal(metav1.Status{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Status"},
+		Status:   metav1.StatusFailure,
+		Message:  "injected fault: " + string(TenantAPIErrors),
+		Reason:   metav1.StatusReasonInternalError,
+		Code:     http.StatusInternalServerError,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		Status:        "500 Internal Server Error",
+		StatusCode:    http.StatusInternalServerError,
+		Proto:         request.Proto,
+		ProtoMajor:    request.ProtoMajor,
+		ProtoMinor:    request.ProtoMinor,
+		Header:        http.Header{"Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       request,
+	}, nil
+}
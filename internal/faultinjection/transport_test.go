@@ -0,0 +1,110 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package faultinjection
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
+)
+
+// newTestServer returns an API Server serving the default Namespace, counting the received requests.
+func newTestServer(t *testing.T) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+
+	var requests atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Method != http.MethodGet || r.URL.Path != "/api/v1/namespaces/default" {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(corev1.Namespace{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Namespace"},
+			ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func newTestClient(t *testing.T, server *httptest.Server, faults string) kubernetes.Interface {
+	t.Helper()
+
+	injector, err := Parse(faults)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := &rest.Config{Host: server.URL}
+	injector.WrapConfig(config)
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clientset
+}
+
+func TestWrapConfigInjectsErrors(t *testing.T) {
+	server, requests := newTestServer(t)
+
+	_, err := newTestClient(t, server, "TenantAPIErrors=1").CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+	if !apierrors.IsInternalError(err) {
+		t.Fatalf("expected an internal error, got %v", err)
+	}
+
+	if requests.Load() != 0 {
+		t.Errorf("expected the failed requests not to be sent, got %d requests", requests.Load())
+	}
+}
+
+func TestWrapConfigWithoutFault(t *testing.T) {
+	server, requests := newTestServer(t)
+
+	namespace, err := newTestClient(t, server, "DataStoreTimeouts=1").CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if namespace.GetName() != "default" || requests.Load() != 1 {
+		t.Errorf("expected the request to reach the API Server, got %q after %d requests", namespace.GetName(), requests.Load())
+	}
+}
+
+func TestWrapConfigRetries(t *testing.T) {
+	server, _ := newTestServer(t)
+
+	clientset := newTestClient(t, server, "TenantAPIErrors=0.5")
+
+	backoff := wait.Backoff{Steps: 20, Duration: 10 * time.Millisecond, Factor: 1}
+
+	err := retry.OnError(backoff, apierrors.IsInternalError, func() error {
+		_, err := clientset.CoreV1().Namespaces().Get(context.Background(), "default", metav1.GetOptions{})
+
+		return err
+	})
+	if err != nil {
+		t.Errorf("expected the retries with backoff to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,13 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build fips
+
+package fips
+
+import (
+	// Restricting the TLS settings to the FIPS approved ones, it requires the GOEXPERIMENT=boringcrypto toolchain.
+	_ "crypto/tls/fipsonly"
+)
+
+const boringCrypto = true
@@ -0,0 +1,37 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fips reports whether Kamaji is running in FIPS mode, generating the Tenant Control Planes PKI
+// with the FIPS 140 validated cryptographic module only.
+package fips
+
+import (
+	"crypto/fips140"
+	"slices"
+)
+
+const (
+	// MinTLSVersion is the minimum TLS version served by the Tenant Control Planes running in FIPS mode.
+	MinTLSVersion = "VersionTLS12"
+	// RSAKeySize is the size of the RSA keys generated in FIPS mode, the 2048 bits ones being deprecated by NIST SP 800-131A.
+	RSAKeySize = 3072
+)
+
+// CipherSuites are the FIPS approved TLS 1.2 cipher suites, using the ECDHE key exchange, and the AES-GCM encryption.
+var CipherSuites = []string{
+	"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384",
+	"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256",
+	"TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384",
+}
+
+// IsApprovedCipherSuite returns true when the given cipher suite is FIPS approved.
+func IsApprovedCipherSuite(name string) bool {
+	return slices.Contains(CipherSuites, name)
+}
+
+// Enabled returns true when Kamaji has been built with the fips tag, linking the BoringCrypto module,
+// or when the Go FIPS 140-3 module is enabled, such as with the GODEBUG=fips140=on environment variable.
+func Enabled() bool {
+	return boringCrypto || fips140.Enabled()
+}
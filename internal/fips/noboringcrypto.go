@@ -0,0 +1,8 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !fips
+
+package fips
+
+const boringCrypto = false
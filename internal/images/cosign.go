@@ -0,0 +1,462 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+)
+
+const (
+	signatureAnnotation   = "dev.cosignproject.cosign/signature"
+	certificateAnnotation = "dev.sigstore.cosign/certificate"
+	chainAnnotation       = "dev.sigstore.cosign/chain"
+	bundleAnnotation      = "dev.sigstore.cosign/bundle"
+
+	simpleSigningType = "cosign container image signature"
+	// maxPayloadSize limits the size of the signature payloads retrieved from the registry.
+	maxPayloadSize = 1 << 20
+)
+
+var (
+	// fulcioIssuerOID is the deprecated Fulcio extension containing the OIDC issuer as raw string.
+	fulcioIssuerOID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1}
+	// fulcioIssuerV2OID is the Fulcio extension containing the OIDC issuer as DER encoded UTF8String.
+	fulcioIssuerV2OID = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 8}
+)
+
+// Verifier verifies the cosign signatures attached to the images using the tag-based discovery,
+// such as registry.k8s.io/kube-apiserver:sha256-<digest>.sig: an image is verified when any signature is trusted.
+type Verifier struct {
+	PublicKeys []crypto.PublicKey
+	Keyless    []KeylessVerifier
+}
+
+// KeylessVerifier trusts the signatures created with a Fulcio certificate issued to the given identity,
+// and recorded in the Rekor transparency log.
+type KeylessVerifier struct {
+	Issuer                   string
+	Subject                  string
+	Roots                    *x509.CertPool
+	Intermediates            *x509.CertPool
+	TransparencyLogPublicKey crypto.PublicKey
+}
+
+// ParsePublicKey parses the PEM encoded public key, as generated by cosign.
+func ParsePublicKey(content []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, fmt.Errorf("cannot decode the PEM public key")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the public key")
+	}
+
+	return key, nil
+}
+
+// NewKeylessVerifier returns a KeylessVerifier for the given identity: the self-signed certificates are used as roots,
+// the other ones as intermediates.
+func NewKeylessVerifier(issuer, subject string, certificates, transparencyLogPublicKey []byte) (KeylessVerifier, error) {
+	verifier := KeylessVerifier{
+		Issuer:        issuer,
+		Subject:       subject,
+		Roots:         x509.NewCertPool(),
+		Intermediates: x509.NewCertPool(),
+	}
+
+	parsed, err := parseCertificates(certificates)
+	if err != nil {
+		return KeylessVerifier{}, err
+	}
+
+	for _, certificate := range parsed {
+		if bytes.Equal(certificate.RawIssuer, certificate.RawSubject) {
+			verifier.Roots.AddCert(certificate)
+
+			continue
+		}
+
+		verifier.Intermediates.AddCert(certificate)
+	}
+
+	if verifier.TransparencyLogPublicKey, err = ParsePublicKey(transparencyLogPublicKey); err != nil {
+		return KeylessVerifier{}, errors.Wrap(err, "cannot parse the transparency log public key")
+	}
+
+	return verifier, nil
+}
+
+// Signature is a cosign signature layer, composed of the simple signing payload and its annotations.
+type Signature struct {
+	Payload     []byte
+	Annotations map[string]string
+}
+
+// Verify retrieves the signatures of the given image digest, returning an error if none of them is trusted.
+func (v Verifier) Verify(digest name.Digest, opts ...remote.Option) error {
+	signatures, err := fetchSignatures(digest, opts...)
+	if err != nil {
+		return err
+	}
+
+	if len(signatures) == 0 {
+		return fmt.Errorf("no signatures found")
+	}
+
+	var errs []string
+
+	for _, signature := range signatures {
+		verifyErr := v.VerifySignature(signature, digest.DigestStr())
+		if verifyErr == nil {
+			return nil
+		}
+
+		errs = append(errs, verifyErr.Error())
+	}
+
+	return fmt.Errorf("no trusted signature found: %s", strings.Join(errs, ", "))
+}
+
+// VerifySignature checks the given signature has been created for the image digest by any of the trusted signers.
+func (v Verifier) VerifySignature(signature Signature, digest string) error {
+	if err := checkPayload(signature.Payload, digest); err != nil {
+		return err
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(signature.Annotations[signatureAnnotation])
+	if err != nil {
+		return errors.Wrap(err, "cannot decode the signature")
+	}
+
+	for _, key := range v.PublicKeys {
+		if verifyRaw(key, signature.Payload, raw) == nil {
+			return nil
+		}
+	}
+
+	if _, ok := signature.Annotations[certificateAnnotation]; !ok {
+		return fmt.Errorf("the signature doesn't match any of the public keys")
+	}
+
+	var errs []string
+
+	for _, keyless := range v.Keyless {
+		keylessErr := keyless.verify(signature, raw)
+		if keylessErr == nil {
+			return nil
+		}
+
+		errs = append(errs, keylessErr.Error())
+	}
+
+	return fmt.Errorf("the signature doesn't match any of the keyless identities: %s", strings.Join(errs, ", "))
+}
+
+func (k KeylessVerifier) verify(signature Signature, raw []byte) error {
+	certificates, err := parseCertificates([]byte(signature.Annotations[certificateAnnotation]))
+	if err != nil || len(certificates) == 0 {
+		return fmt.Errorf("cannot parse the signing certificate")
+	}
+
+	certificate := certificates[0]
+	// The signing certificates are short-lived: the transparency log proves the signature has been created while valid.
+	entry, err := k.verifyBundle(signature.Annotations[bundleAnnotation])
+	if err != nil {
+		return err
+	}
+
+	intermediates := k.Intermediates.Clone()
+
+	chain, _ := parseCertificates([]byte(signature.Annotations[chainAnnotation]))
+	for _, c := range chain {
+		if !bytes.Equal(c.RawIssuer, c.RawSubject) {
+			intermediates.AddCert(c)
+		}
+	}
+
+	if _, err = certificate.Verify(x509.VerifyOptions{
+		Roots:         k.Roots,
+		Intermediates: intermediates,
+		CurrentTime:   time.Unix(entry.IntegratedTime, 0),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+	}); err != nil {
+		return errors.Wrap(err, "cannot verify the signing certificate")
+	}
+
+	if err = k.checkIdentity(certificate); err != nil {
+		return err
+	}
+
+	if err = verifyRaw(certificate.PublicKey, signature.Payload, raw); err != nil {
+		return errors.Wrap(err, "the signature doesn't match the signing certificate")
+	}
+
+	return entry.matches(signature.Payload, raw, certificate)
+}
+
+func (k KeylessVerifier) checkIdentity(certificate *x509.Certificate) error {
+	subjects := slices.Clone(certificate.EmailAddresses)
+	for _, uri := range certificate.URIs {
+		subjects = append(subjects, uri.String())
+	}
+
+	if !slices.Contains(subjects, k.Subject) {
+		return fmt.Errorf("the signing certificate is not issued to %s", k.Subject)
+	}
+
+	var issuer string
+
+	for _, extension := range certificate.Extensions {
+		switch {
+		case extension.Id.Equal(fulcioIssuerV2OID):
+			if _, err := asn1.Unmarshal(extension.Value, &issuer); err != nil {
+				return errors.Wrap(err, "cannot decode the certificate issuer extension")
+			}
+		case extension.Id.Equal(fulcioIssuerOID) && len(issuer) == 0:
+			issuer = string(extension.Value)
+		}
+	}
+
+	if issuer != k.Issuer {
+		return fmt.Errorf("the signing certificate is not issued by %s", k.Issuer)
+	}
+
+	return nil
+}
+
+type bundle struct {
+	SignedEntryTimestamp []byte        `json:"SignedEntryTimestamp"`
+	Payload              bundlePayload `json:"Payload"`
+}
+
+// bundlePayload fields are sorted as required by the canonical JSON used to sign the entry timestamp.
+type bundlePayload struct {
+	Body           string `json:"body"`
+	IntegratedTime int64  `json:"integratedTime"`
+	LogID          string `json:"logID"`
+	LogIndex       int64  `json:"logIndex"`
+}
+
+// hashedRekord is the transparency log entry type used by cosign.
+type hashedRekord struct {
+	Kind string `json:"kind"`
+	Spec struct {
+		Data struct {
+			Hash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"hash"`
+		} `json:"data"`
+		Signature struct {
+			Content   []byte `json:"content"`
+			PublicKey struct {
+				Content []byte `json:"content"`
+			} `json:"publicKey"`
+		} `json:"signature"`
+	} `json:"spec"`
+}
+
+func (k KeylessVerifier) verifyBundle(annotation string) (*bundlePayload, error) {
+	if len(annotation) == 0 {
+		return nil, fmt.Errorf("the signature is not recorded in the transparency log")
+	}
+
+	var b bundle
+	if err := json.Unmarshal([]byte(annotation), &b); err != nil {
+		return nil, errors.Wrap(err, "cannot decode the transparency log bundle")
+	}
+
+	canonical, err := canonicalPayload(b.Payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = verifyRaw(k.TransparencyLogPublicKey, canonical, b.SignedEntryTimestamp); err != nil {
+		return nil, errors.Wrap(err, "cannot verify the signed entry timestamp")
+	}
+
+	return &b.Payload, nil
+}
+
+// canonicalPayload encodes the bundle payload as canonical JSON:
+// keys are sorted by the struct declaration, and HTML characters must not be escaped.
+func canonicalPayload(payload bundlePayload) ([]byte, error) {
+	buf := bytes.NewBuffer(nil)
+
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+
+	if err := encoder.Encode(payload); err != nil {
+		return nil, errors.Wrap(err, "cannot encode the transparency log bundle")
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// matches checks the transparency log entry is referring to the given signature.
+func (p bundlePayload) matches(payload, signature []byte, certificate *x509.Certificate) error {
+	body, err := base64.StdEncoding.DecodeString(p.Body)
+	if err != nil {
+		return errors.Wrap(err, "cannot decode the transparency log entry")
+	}
+
+	var entry hashedRekord
+	if err = json.Unmarshal(body, &entry); err != nil {
+		return errors.Wrap(err, "cannot decode the transparency log entry")
+	}
+
+	if entry.Kind != "hashedrekord" {
+		return fmt.Errorf("unsupported transparency log entry kind %s", entry.Kind)
+	}
+
+	hash := sha256.Sum256(payload)
+
+	if entry.Spec.Data.Hash.Algorithm != "sha256" || entry.Spec.Data.Hash.Value != hex.EncodeToString(hash[:]) {
+		return fmt.Errorf("the transparency log entry doesn't match the signed payload")
+	}
+
+	if !bytes.Equal(entry.Spec.Signature.Content, signature) {
+		return fmt.Errorf("the transparency log entry doesn't match the signature")
+	}
+
+	block, _ := pem.Decode(entry.Spec.Signature.PublicKey.Content)
+	if block == nil || !bytes.Equal(block.Bytes, certificate.Raw) {
+		return fmt.Errorf("the transparency log entry doesn't match the signing certificate")
+	}
+
+	return nil
+}
+
+// checkPayload checks the simple signing payload is referring to the given image digest.
+func checkPayload(payload []byte, digest string) error {
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+			Type string `json:"type"`
+		} `json:"critical"`
+	}
+
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil {
+		return errors.Wrap(err, "cannot decode the signature payload")
+	}
+
+	if simpleSigning.Critical.Type != simpleSigningType {
+		return fmt.Errorf("unexpected signature payload type %q", simpleSigning.Critical.Type)
+	}
+
+	if simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return fmt.Errorf("the signature is referring to %s", simpleSigning.Critical.Image.DockerManifestDigest)
+	}
+
+	return nil
+}
+
+func verifyRaw(key crypto.PublicKey, message, signature []byte) error {
+	hash := sha256.Sum256(message)
+
+	switch k := key.(type) {
+	case *ecdsa.PublicKey:
+		if !ecdsa.VerifyASN1(k, hash[:], signature) {
+			return fmt.Errorf("invalid ECDSA signature")
+		}
+
+		return nil
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, hash[:], signature)
+	case ed25519.PublicKey:
+		if !ed25519.Verify(k, message, signature) {
+			return fmt.Errorf("invalid ED25519 signature")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported public key type %T", key)
+	}
+}
+
+func parseCertificates(content []byte) ([]*x509.Certificate, error) {
+	var certificates []*x509.Certificate
+
+	for block, rest := pem.Decode(content); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+
+		certificate, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot parse the certificate")
+		}
+
+		certificates = append(certificates, certificate)
+	}
+
+	return certificates, nil
+}
+
+func fetchSignatures(digest name.Digest, opts ...remote.Option) ([]Signature, error) {
+	tag := digest.Context().Tag(strings.Replace(digest.DigestStr(), ":", "-", 1) + ".sig")
+
+	image, err := remote.Image(tag, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the signatures from %s", tag))
+	}
+
+	manifest, err := image.Manifest()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the signatures manifest")
+	}
+
+	signatures := make([]Signature, 0, len(manifest.Layers))
+
+	for _, descriptor := range manifest.Layers {
+		if descriptor.Size > maxPayloadSize {
+			continue
+		}
+
+		layer, layerErr := image.LayerByDigest(descriptor.Digest)
+		if layerErr != nil {
+			return nil, errors.Wrap(layerErr, "cannot retrieve the signature layer")
+		}
+
+		reader, layerErr := layer.Compressed()
+		if layerErr != nil {
+			return nil, errors.Wrap(layerErr, "cannot retrieve the signature payload")
+		}
+
+		payload, layerErr := io.ReadAll(io.LimitReader(reader, maxPayloadSize))
+		_ = reader.Close()
+
+		if layerErr != nil {
+			return nil, errors.Wrap(layerErr, "cannot read the signature payload")
+		}
+
+		signatures = append(signatures, Signature{Payload: payload, Annotations: descriptor.Annotations})
+	}
+
+	return signatures, nil
+}
@@ -0,0 +1,210 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func testPayload(digest string) []byte {
+	return []byte(fmt.Sprintf(`{"critical":{"identity":{"docker-reference":"registry.k8s.io/kube-apiserver"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"},"optional":null}`, digest))
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, message []byte) []byte {
+	t.Helper()
+
+	hash := sha256.Sum256(message)
+
+	signature, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signature
+}
+
+func generateKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key
+}
+
+func TestVerifySignatureWithPublicKey(t *testing.T) {
+	trusted, untrusted := generateKey(t), generateKey(t)
+
+	verifier := Verifier{PublicKeys: []crypto.PublicKey{&trusted.PublicKey}}
+
+	payload := testPayload(testDigest)
+
+	signature := Signature{
+		Payload:     payload,
+		Annotations: map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(sign(t, trusted, payload))},
+	}
+
+	if err := verifier.VerifySignature(signature, testDigest); err != nil {
+		t.Errorf("expected the signature to be trusted, but got %v", err)
+	}
+
+	if err := verifier.VerifySignature(signature, "sha256:ffff"); err == nil {
+		t.Errorf("expected the signature of a different digest to be rejected")
+	}
+
+	signature.Annotations[signatureAnnotation] = base64.StdEncoding.EncodeToString(sign(t, untrusted, payload))
+
+	if err := verifier.VerifySignature(signature, testDigest); err == nil {
+		t.Errorf("expected the signature of an untrusted key to be rejected")
+	}
+}
+
+func TestVerifySignatureKeyless(t *testing.T) {
+	const (
+		issuer  = "https://token.actions.githubusercontent.com"
+		subject = "https://github.com/clastix/kamaji/.github/workflows/release.yml@refs/heads/master"
+	)
+
+	caKey, signerKey, rekorKey := generateKey(t), generateKey(t), generateKey(t)
+
+	now := time.Now()
+
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "fulcio"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	issuerValue, err := asn1.Marshal(issuer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	subjectURI, err := url.Parse(subject)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The signing certificate is valid for a few minutes, and expired when the signature is verified.
+	signerTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		NotBefore:       now.Add(-30 * time.Minute),
+		NotAfter:        now.Add(-20 * time.Minute),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		URIs:            []*url.URL{subjectURI},
+		ExtraExtensions: []pkix.Extension{{Id: fulcioIssuerV2OID, Value: issuerValue}},
+	}
+
+	signerDER, err := x509.CreateCertificate(rand.Reader, signerTemplate, caTemplate, &signerKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	signerPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: signerDER})
+
+	rekorDER, err := x509.MarshalPKIXPublicKey(&rekorKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rekorPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: rekorDER})
+
+	payload := testPayload(testDigest)
+	rawSignature := sign(t, signerKey, payload)
+	hash := sha256.Sum256(payload)
+
+	var entry hashedRekord
+	entry.Kind = "hashedrekord"
+	entry.Spec.Data.Hash.Algorithm = "sha256"
+	entry.Spec.Data.Hash.Value = hex.EncodeToString(hash[:])
+	entry.Spec.Signature.Content = rawSignature
+	entry.Spec.Signature.PublicKey.Content = signerPEM
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b := bundle{
+		Payload: bundlePayload{
+			Body:           base64.StdEncoding.EncodeToString(body),
+			IntegratedTime: now.Add(-25 * time.Minute).Unix(),
+			LogID:          "c0d23d6ad406973f9559f3ba2d1ca01f84147d8ffc5b8445c224f98b9591801d",
+			LogIndex:       42,
+		},
+	}
+
+	canonical, err := canonicalPayload(b.Payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b.SignedEntryTimestamp = sign(t, rekorKey, canonical)
+
+	encodedBundle, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyless, err := NewKeylessVerifier(issuer, subject, caPEM, rekorPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := Signature{
+		Payload: payload,
+		Annotations: map[string]string{
+			signatureAnnotation:   base64.StdEncoding.EncodeToString(rawSignature),
+			certificateAnnotation: string(signerPEM),
+			bundleAnnotation:      string(encodedBundle),
+		},
+	}
+
+	if err = (Verifier{Keyless: []KeylessVerifier{keyless}}).VerifySignature(signature, testDigest); err != nil {
+		t.Errorf("expected the keyless signature to be trusted, but got %v", err)
+	}
+
+	other := keyless
+	other.Subject = "https://github.com/clastix/kamaji/.github/workflows/other.yml@refs/heads/master"
+
+	if err = (Verifier{Keyless: []KeylessVerifier{other}}).VerifySignature(signature, testDigest); err == nil {
+		t.Errorf("expected the signature of a different identity to be rejected")
+	}
+
+	delete(signature.Annotations, bundleAnnotation)
+
+	if err = (Verifier{Keyless: []KeylessVerifier{keyless}}).VerifySignature(signature, testDigest); err == nil {
+		t.Errorf("expected the signature not recorded in the transparency log to be rejected")
+	}
+}
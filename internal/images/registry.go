@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/pkg/errors"
+)
+
+// RemoteOptions returns the options used to reach the registries:
+// credentials are retrieved from the Kamaji environment, such as the Docker config file, or the cloud provider helpers.
+func RemoteOptions(ctx context.Context) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
+
+// ResolveDigest resolves the given image reference to its manifest digest:
+// the multi-architecture images are resolved to the index digest, which is the one signed by cosign.
+func ResolveDigest(image string, opts ...remote.Option) (name.Digest, error) {
+	ref, err := name.ParseReference(image)
+	if err != nil {
+		return name.Digest{}, errors.Wrap(err, fmt.Sprintf("cannot parse the image reference %s", image))
+	}
+
+	if digest, ok := ref.(name.Digest); ok {
+		return digest, nil
+	}
+
+	descriptor, err := remote.Head(ref, opts...)
+	if err != nil {
+		// Some registries don't support the HEAD requests for manifests.
+		getDescriptor, getErr := remote.Get(ref, opts...)
+		if getErr != nil {
+			return name.Digest{}, errors.Wrap(err, fmt.Sprintf("cannot resolve the digest of %s", image))
+		}
+
+		descriptor = &getDescriptor.Descriptor
+	}
+
+	return ref.Context().Digest(descriptor.Digest.String()), nil
+}
+
+// Tag returns the tag of the given image reference, even when pinned to a digest:
+// an empty string is returned when the image is referenced by digest only, or it's not tagged.
+func Tag(image string) string {
+	if index := strings.Index(image, "@"); index >= 0 {
+		image = image[:index]
+	}
+
+	if index := strings.LastIndex(image, ":"); index > strings.LastIndex(image, "/") {
+		return image[index+1:]
+	}
+
+	return ""
+}
@@ -0,0 +1,21 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package images
+
+import "testing"
+
+func TestTag(t *testing.T) {
+	for image, expected := range map[string]string{
+		"registry.k8s.io/coredns/coredns:v1.11.3":              "v1.11.3",
+		"registry.k8s.io/kube-proxy:v1.30.2@" + testDigest:     "v1.30.2",
+		"registry.k8s.io/kube-proxy@" + testDigest:             "",
+		"localhost:5000/kas-network-proxy/proxy-agent":         "",
+		"localhost:5000/kas-network-proxy/proxy-agent:v0.28.6": "v0.28.6",
+		"docker.io/library/busybox":                            "",
+	} {
+		if actual := Tag(image); actual != expected {
+			t.Errorf("expected tag %q for %s, got %q", expected, image, actual)
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// MaxQueryLength is the length the normalized queries are truncated to.
+const MaxQueryLength = 256
+
+// slowQueryRegexp matches the message logged by kine for the queries exceeding the --slow-sql-threshold flag,
+// such as "Slow SQL (started: <time>) (total time: <duration>): <query> : [<args>]".
+var slowQueryRegexp = regexp.MustCompile(`(?s)^Slow SQL \(started: ([^)]+)\) \(total time: ([^)]+)\): (.+) : \[.*\]$`)
+
+// startedLayout is the layout of the query start time, as formatted by the Go fmt package.
+const startedLayout = "2006-01-02 15:04:05.999999999 -0700 MST"
+
+// SlowQuery is a query logged by kine as a slow one.
+type SlowQuery struct {
+	// Query is the whitespace normalized, and truncated, SQL statement, without the arguments.
+	Query    string
+	Duration time.Duration
+	Started  time.Time
+}
+
+// ParseSlowQueries returns the slow queries logged by kine, skipping the other log lines:
+// the queries started before the given time are skipped too, since the logs are collected with a seconds precision.
+func ParseSlowQueries(logs []byte, since time.Time) []SlowQuery {
+	var queries []SlowQuery
+
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		query, ok := parseSlowQuery(scanner.Text())
+		if !ok || query.Started.Before(since) {
+			continue
+		}
+
+		queries = append(queries, query)
+	}
+
+	return queries
+}
+
+func parseSlowQuery(line string) (SlowQuery, bool) {
+	message := line
+	// kine logs with the logrus text formatter, quoting the message.
+	if _, quoted, found := strings.Cut(line, "msg="); found {
+		unquoted, err := strconv.QuotedPrefix(quoted)
+		if err != nil {
+			return SlowQuery{}, false
+		}
+
+		if message, err = strconv.Unquote(unquoted); err != nil {
+			return SlowQuery{}, false
+		}
+	}
+
+	matches := slowQueryRegexp.FindStringSubmatch(message)
+	if matches == nil {
+		return SlowQuery{}, false
+	}
+
+	// The monotonic clock reading is appended to the start time, such as "m=+3.141592653".
+	started, _, _ := strings.Cut(matches[1], " m=")
+
+	startedAt, err := time.Parse(startedLayout, started)
+	if err != nil {
+		return SlowQuery{}, false
+	}
+
+	duration, err := time.ParseDuration(matches[2])
+	if err != nil {
+		return SlowQuery{}, false
+	}
+
+	return SlowQuery{
+		Query:    NormalizeQuery(matches[3]),
+		Duration: duration,
+		Started:  startedAt,
+	}, true
+}
+
+// NormalizeQuery collapses the whitespaces of the query, and truncates it to the MaxQueryLength.
+func NormalizeQuery(query string) string {
+	normalized := strings.Join(strings.Fields(query), " ")
+	if len(normalized) > MaxQueryLength {
+		normalized = normalized[:MaxQueryLength]
+	}
+
+	return normalized
+}
+
+// AggregateSlowQueries merges the collected slow queries into the reported ones, by their normalized statement:
+// the queries are ordered by their maximum duration, and only the slowest ones are kept, up to the given limit.
+func AggregateSlowQueries(reported []kamajiv1alpha1.SlowQueryStatus, queries []SlowQuery, limit int) []kamajiv1alpha1.SlowQueryStatus {
+	aggregated := slices.Clone(reported)
+
+	for _, query := range queries {
+		index := slices.IndexFunc(aggregated, func(status kamajiv1alpha1.SlowQueryStatus) bool {
+			return status.Query == query.Query
+		})
+		if index < 0 {
+			aggregated = append(aggregated, kamajiv1alpha1.SlowQueryStatus{Query: query.Query})
+			index = len(aggregated) - 1
+		}
+
+		aggregated[index].Count++
+		aggregated[index].MaxDuration.Duration = max(aggregated[index].MaxDuration.Duration, query.Duration)
+
+		if query.Started.After(aggregated[index].LastSeen.Time) {
+			aggregated[index].LastSeen = metav1.NewTime(query.Started)
+		}
+	}
+
+	slices.SortStableFunc(aggregated, func(a, b kamajiv1alpha1.SlowQueryStatus) int {
+		return cmp.Compare(b.MaxDuration.Duration, a.MaxDuration.Duration)
+	})
+
+	if len(aggregated) > limit {
+		aggregated = aggregated[:limit]
+	}
+
+	return aggregated
+}
@@ -0,0 +1,76 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kine
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestParseSlowQueries(t *testing.T) {
+	logs := strings.Join([]string{
+		`time="2024-05-02T10:00:00Z" level=info msg="Configuring database table schema and indexes, this may take a moment..."`,
+		`time="2024-05-02T10:00:01Z" level=info msg="Slow SQL (started: 2024-05-02 10:00:00.5 +0000 UTC m=+12.5) (total time: 1.5s): SELECT *\n\tFROM kine WHERE name LIKE ? : [/registry/pods/% 0]"`,
+		`time="2024-05-02T10:00:02Z" level=info msg="Slow SQL (started: 2024-05-02 09:59:00 +0000 UTC) (total time: 2s): SELECT 1 : []"`,
+		`time="2024-05-02T10:00:03Z" level=info msg="Slow SQL (started: 2024-05-02 10:00:02.25 +0000 UTC m=+14.25) (total time: 3.25s): SELECT \"name\" FROM kine : [a]"`,
+	}, "\n")
+
+	queries := ParseSlowQueries([]byte(logs), time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC))
+	if len(queries) != 2 {
+		t.Fatalf("expected 2 slow queries, got %d", len(queries))
+	}
+
+	if expected := "SELECT * FROM kine WHERE name LIKE ?"; queries[0].Query != expected {
+		t.Errorf("expected the query %q, got %q", expected, queries[0].Query)
+	}
+
+	if queries[0].Duration != 1500*time.Millisecond {
+		t.Errorf("expected the duration 1.5s, got %s", queries[0].Duration)
+	}
+
+	if expected := time.Date(2024, 5, 2, 10, 0, 0, 500000000, time.UTC); !queries[0].Started.Equal(expected) {
+		t.Errorf("expected the start time %s, got %s", expected, queries[0].Started)
+	}
+
+	if expected := `SELECT "name" FROM kine`; queries[1].Query != expected {
+		t.Errorf("expected the query %q, got %q", expected, queries[1].Query)
+	}
+}
+
+func TestNormalizeQuery(t *testing.T) {
+	if actual := NormalizeQuery(strings.Repeat("SELECT  ", 100)); len(actual) != MaxQueryLength {
+		t.Errorf("expected the query to be truncated to %d characters, got %d", MaxQueryLength, len(actual))
+	}
+}
+
+func TestAggregateSlowQueries(t *testing.T) {
+	now := time.Now()
+
+	reported := []kamajiv1alpha1.SlowQueryStatus{
+		{Query: "SELECT 1", Count: 3, MaxDuration: metav1.Duration{Duration: time.Second}, LastSeen: metav1.NewTime(now.Add(-time.Hour))},
+	}
+
+	aggregated := AggregateSlowQueries(reported, []SlowQuery{
+		{Query: "SELECT 1", Duration: 2 * time.Second, Started: now},
+		{Query: "SELECT 2", Duration: 5 * time.Second, Started: now},
+		{Query: "SELECT 3", Duration: 500 * time.Millisecond, Started: now},
+	}, 2)
+
+	if len(aggregated) != 2 {
+		t.Fatalf("expected 2 aggregated queries, got %d", len(aggregated))
+	}
+
+	if aggregated[0].Query != "SELECT 2" {
+		t.Errorf("expected the slowest query first, got %q", aggregated[0].Query)
+	}
+
+	if aggregated[1].Count != 4 || aggregated[1].MaxDuration.Duration != 2*time.Second || !aggregated[1].LastSeen.Time.Equal(now) {
+		t.Errorf("expected the reported query to be merged, got %+v", aggregated[1])
+	}
+}
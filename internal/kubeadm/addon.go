@@ -5,7 +5,9 @@ package kubeadm
 
 import (
 	"bytes"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/addons/dns"
@@ -27,6 +29,37 @@ const (
 	CoreDNSClusterRoleBindingName = "system:coredns"
 )
 
+// coreDNSVersions maps the Kubernetes minor releases to the CoreDNS version shipped by the matching kubeadm release.
+var coreDNSVersions = map[string]string{
+	"1.22": "v1.8.4",
+	"1.23": "v1.8.6",
+	"1.24": "v1.8.6",
+	"1.25": "v1.9.3",
+	"1.26": "v1.9.3",
+	"1.27": "v1.10.1",
+	"1.28": "v1.10.1",
+	"1.29": "v1.11.1",
+	"1.30": "v1.11.1",
+	"1.31": "v1.11.3",
+	"1.32": "v1.11.3",
+	"1.33": "v1.12.0",
+}
+
+// CoreDNSVersion returns the CoreDNS version kubeadm installs for the given Kubernetes version,
+// falling back to the one of the vendored kubeadm for the unknown releases.
+func CoreDNSVersion(kubernetesVersion string) string {
+	ver, err := version.ParseGeneric(kubernetesVersion)
+	if err != nil {
+		return constants.CoreDNSVersion
+	}
+
+	if v, ok := coreDNSVersions[fmt.Sprintf("%d.%d", ver.Major(), ver.Minor())]; ok {
+		return v
+	}
+
+	return constants.CoreDNSVersion
+}
+
 func AddCoreDNS(client kubernetes.Interface, config *Configuration) ([]byte, error) {
 	// We're passing the values from the parameters here because they wouldn't be hashed by the YAML encoder:
 	// the struct kubeadm.ClusterConfiguration hasn't struct tags, and it wouldn't be hashed properly.
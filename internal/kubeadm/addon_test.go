@@ -0,0 +1,24 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/cmd/kubeadm/app/constants"
+)
+
+func TestCoreDNSVersion(t *testing.T) {
+	for kubernetesVersion, expected := range map[string]string{
+		"v1.23.6": "v1.8.6",
+		"v1.29.0": "v1.11.1",
+		"1.31.2":  "v1.11.3",
+		"v1.99.0": constants.CoreDNSVersion,
+		"invalid": constants.CoreDNSVersion,
+	} {
+		if actual := CoreDNSVersion(kubernetesVersion); actual != expected {
+			t.Errorf("CoreDNSVersion(%q) = %q, expected %q", kubernetesVersion, actual, expected)
+		}
+	}
+}
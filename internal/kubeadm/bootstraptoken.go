@@ -4,6 +4,10 @@
 package kubeadm
 
 import (
+	"bytes"
+	"fmt"
+	"slices"
+
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -39,15 +43,7 @@ func BootstrapToken(client kubernetes.Interface, config *Configuration) error {
 		return err
 	}
 
-	bootstrapConfig := &clientcmdapi.Config{
-		Clusters: map[string]*clientcmdapi.Cluster{
-			"": {
-				Server:                   config.Kubeconfig.Clusters[0].Cluster.Server,
-				CertificateAuthorityData: config.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData,
-			},
-		},
-	}
-	bootstrapBytes, err := clientcmd.Write(*bootstrapConfig)
+	bootstrapBytes, err := clientcmd.Write(*clusterInfoConfig(config))
 	if err != nil {
 		return err
 	}
@@ -71,3 +67,40 @@ func BootstrapToken(client kubernetes.Interface, config *Configuration) error {
 
 	return nil
 }
+
+// clusterInfoConfig returns the kubeconfig published in the cluster-info ConfigMap: the unnamed cluster is the one
+// used by kubeadm for the discovery, the additional endpoints are published as the endpoint-N clusters.
+func clusterInfoConfig(config *Configuration) *clientcmdapi.Config {
+	server := config.Kubeconfig.Clusters[0].Cluster.Server
+	caData := config.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData
+
+	var endpoints []string
+
+	if options := config.Parameters.ClusterInfo; options != nil {
+		if len(options.Endpoints) > 0 {
+			server, endpoints = options.Endpoints[0], options.Endpoints[1:]
+		}
+
+		if len(options.CABundle) > 0 {
+			caData = append(append(bytes.TrimRight(slices.Clone(caData), "\n"), '\n'), options.CABundle...)
+		}
+	}
+
+	bootstrapConfig := &clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"": {
+				Server:                   server,
+				CertificateAuthorityData: caData,
+			},
+		},
+	}
+
+	for i, endpoint := range endpoints {
+		bootstrapConfig.Clusters[fmt.Sprintf("endpoint-%d", i+1)] = &clientcmdapi.Cluster{
+			Server:                   endpoint,
+			CertificateAuthorityData: caData,
+		}
+	}
+
+	return bootstrapConfig
+}
@@ -0,0 +1,48 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package kubeadm
+
+import (
+	"testing"
+
+	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
+)
+
+func TestClusterInfoConfig(t *testing.T) {
+	config := &Configuration{
+		Kubeconfig: clientcmdapiv1.Config{
+			Clusters: []clientcmdapiv1.NamedCluster{{Cluster: clientcmdapiv1.Cluster{Server: "https://10.0.0.1:6443", CertificateAuthorityData: []byte("tenant-ca\n")}}},
+		},
+	}
+
+	if actual := clusterInfoConfig(config); len(actual.Clusters) != 1 || actual.Clusters[""].Server != "https://10.0.0.1:6443" {
+		t.Errorf("clusterInfoConfig() without options = %v, expected the Tenant Control Plane endpoint only", actual.Clusters)
+	}
+
+	config.Parameters.ClusterInfo = &ClusterInfoOptions{
+		Endpoints: []string{"https://tenant.example.com:6443", "https://tenant.dr.example.com:6443"},
+		CABundle:  []byte("lb-ca\n"),
+	}
+
+	actual := clusterInfoConfig(config)
+	if len(actual.Clusters) != 2 {
+		t.Fatalf("clusterInfoConfig() = %v, expected 2 clusters", actual.Clusters)
+	}
+
+	if server := actual.Clusters[""].Server; server != "https://tenant.example.com:6443" {
+		t.Errorf("unnamed cluster server = %q, expected the first endpoint", server)
+	}
+
+	if server := actual.Clusters["endpoint-1"].Server; server != "https://tenant.dr.example.com:6443" {
+		t.Errorf("endpoint-1 cluster server = %q, expected the second endpoint", server)
+	}
+
+	if ca := string(actual.Clusters[""].CertificateAuthorityData); ca != "tenant-ca\nlb-ca\n" {
+		t.Errorf("CA data = %q, expected the Tenant CA followed by the bundle", ca)
+	}
+
+	if ca := string(config.Kubeconfig.Clusters[0].Cluster.CertificateAuthorityData); ca != "tenant-ca\n" {
+		t.Errorf("the kubeconfig CA data has been modified: %q", ca)
+	}
+}
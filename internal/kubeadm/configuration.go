@@ -56,12 +56,17 @@ func CreateKubeadmInitConfiguration(params Parameters) (*Configuration, error) {
 	}
 	conf.KubernetesVersion = params.TenantControlPlaneVersion
 	conf.ControlPlaneEndpoint = params.TenantControlPlaneEndpoint
+	serviceName := params.TenantControlPlaneName
+	if len(params.TenantControlPlaneServiceName) > 0 {
+		serviceName = params.TenantControlPlaneServiceName
+	}
+
 	conf.APIServer.CertSANs = append([]string{
 		"127.0.0.1",
 		"localhost",
-		params.TenantControlPlaneName,
-		fmt.Sprintf("%s.%s.svc", params.TenantControlPlaneName, params.TenantControlPlaneNamespace),
-		fmt.Sprintf("%s.%s.svc.cluster.local", params.TenantControlPlaneName, params.TenantControlPlaneNamespace),
+		serviceName,
+		fmt.Sprintf("%s.%s.svc", serviceName, params.TenantControlPlaneNamespace),
+		fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, params.TenantControlPlaneNamespace),
 		params.TenantControlPlaneAddress,
 	}, params.TenantControlPlaneCertSANs...)
 	conf.APIServer.ControlPlaneComponent.ExtraArgs = []kubeadmapi.Arg{
@@ -69,6 +74,10 @@ func CreateKubeadmInitConfiguration(params Parameters) (*Configuration, error) {
 		{Name: "etcd-prefix", Value: fmt.Sprintf("/%s", params.TenantControlPlaneName)},
 	}
 	conf.ClusterName = params.TenantControlPlaneName
+	// The algorithm of the keys generated by kubeadm, such as the Certificate Authority, and the Service Account ones.
+	if len(params.EncryptionAlgorithm) > 0 {
+		conf.EncryptionAlgorithm = kubeadmapi.EncryptionAlgorithmType(params.EncryptionAlgorithm)
+	}
 
 	return &Configuration{InitConfiguration: *conf}, nil
 }
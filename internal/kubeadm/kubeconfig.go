@@ -8,10 +8,12 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"time"
 
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/kubeconfig"
 
+	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/crypto"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -37,6 +39,21 @@ func CreateKubeconfig(kubeconfigName string, ca CertificatePrivateKeyPair, confi
 	}
 
 	defer deleteCertificateDirectory(config.InitConfiguration.CertificatesDir)
+	// The soot kubeconfig is not a kubeadm one: the client certificate is issued to a user with no groups,
+	// granted only the permissions declared by Kamaji.
+	if kubeconfigName == constants.SootKubeConfigFileName {
+		notAfter := time.Now().Add(kubeadmconstants.CertificateValidityPeriod)
+		if validity := config.InitConfiguration.CertificateValidityPeriod; validity != nil {
+			notAfter = time.Now().Add(validity.Duration)
+		}
+
+		buf := bytes.NewBuffer(nil)
+		if err := kubeconfig.WriteKubeConfigWithClientCert(buf, &config.InitConfiguration, constants.SootUserName, nil, notAfter); err != nil {
+			return nil, err
+		}
+
+		return buf.Bytes(), nil
+	}
 
 	if err := kubeconfig.CreateKubeConfigFile(kubeconfigName, config.InitConfiguration.CertificatesDir, &config.InitConfiguration); err != nil {
 		return nil, err
@@ -11,6 +11,9 @@ import (
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// checksumJSON sorts the map keys, such as the feature gates ones, providing a deterministic checksum.
+var checksumJSON = json.Config{EscapeHTML: true, SortMapKeys: true}.Froze()
+
 type Configuration struct {
 	InitConfiguration kubeadmapi.InitConfiguration
 	Kubeconfig        clientcmdapiv1.Config
@@ -19,8 +22,8 @@ type Configuration struct {
 
 func (c *Configuration) Checksum() string {
 	initConfiguration, _ := utilities.EncodeToYaml(&c.InitConfiguration)
-	kubeconfig, _ := json.Marshal(c.Kubeconfig)
-	parameters, _ := json.Marshal(c.Parameters)
+	kubeconfig, _ := checksumJSON.Marshal(c.Kubeconfig)
+	parameters, _ := checksumJSON.Marshal(c.Parameters)
 
 	data := map[string][]byte{
 		"InitConfiguration": initConfiguration,
@@ -49,6 +52,26 @@ type Parameters struct {
 	KubeconfigDir                   string
 	KubeProxyOptions                *AddonOptions
 	CoreDNSOptions                  *AddonOptions
+	// KubeletFeatureGates is omitted when empty to preserve the checksum of the existing configurations.
+	KubeletFeatureGates map[string]bool `json:",omitempty"`
+	// KubeletServerTLSBootstrap is omitted when disabled to preserve the checksum of the existing configurations.
+	KubeletServerTLSBootstrap bool `json:",omitempty"`
+	// KubeletConfig is omitted when empty to preserve the checksum of the existing configurations.
+	KubeletConfig *KubeletConfigOptions `json:",omitempty"`
+	// TenantControlPlaneServiceName is omitted when matching the Tenant Control Plane name to preserve the checksum of the existing configurations.
+	TenantControlPlaneServiceName string `json:",omitempty"`
+	// KubeletPools is omitted when empty to preserve the checksum of the existing configurations.
+	KubeletPools []KubeletPoolOptions `json:",omitempty"`
+	// ClusterInfo is omitted when not customized to preserve the checksum of the existing configurations.
+	ClusterInfo *ClusterInfoOptions `json:",omitempty"`
+	// EncryptionAlgorithm is the algorithm of the keys generated by kubeadm, defaulted by kubeadm when empty.
+	EncryptionAlgorithm string `json:",omitempty"`
+}
+
+// ClusterInfoOptions are the contents of the cluster-info ConfigMap declared in the Tenant Control Plane.
+type ClusterInfoOptions struct {
+	Endpoints []string
+	CABundle  []byte
 }
 
 type AddonOptions struct {
@@ -56,10 +79,31 @@ type AddonOptions struct {
 	Tag        string
 }
 
+// KubeletConfigOptions are the fields of the kubelet configuration declared in the Tenant Control Plane,
+// the unset ones are left to the kubelet defaults.
+type KubeletConfigOptions struct {
+	MaxPods                 *int32
+	SerializeImagePulls     *bool
+	MaxParallelImagePulls   *int32
+	EvictionHard            map[string]string
+	EvictionSoft            map[string]string
+	EvictionSoftGracePeriod map[string]string
+}
+
+// KubeletPoolOptions define a variant of the kubelet configuration, uploaded to the kubelet-config-<name> ConfigMap.
+type KubeletPoolOptions struct {
+	Name         string
+	CGroupDriver string
+	Options      *KubeletConfigOptions
+}
+
 type KubeletConfiguration struct {
 	TenantControlPlaneDomain        string
 	TenantControlPlaneDNSServiceIPs []string
 	TenantControlPlaneCgroupDriver  string
+	FeatureGates                    map[string]bool
+	ServerTLSBootstrap              bool
+	Options                         *KubeletConfigOptions
 }
 
 type CertificatePrivateKeyPair struct {
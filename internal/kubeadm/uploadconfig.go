@@ -4,12 +4,14 @@
 package kubeadm
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	kubelettypes "k8s.io/kubelet/config/v1beta1"
@@ -25,6 +27,10 @@ import (
 const (
 	// kubeletConfigMapName defines base kubelet configuration ConfigMap name for kubeadm < 1.24.
 	kubeletConfigMapName = "kubelet-config-%d.%d"
+	// kubeletPoolConfigMapPrefix is the prefix of the ConfigMaps containing the kubelet configuration of the pools.
+	kubeletPoolConfigMapPrefix = "kubelet-config"
+	// kubeletPoolLabel references the pool of the kubelet configuration ConfigMaps.
+	kubeletPoolLabel = "kamaji.clastix.io/kubelet-pool"
 )
 
 // minVerUnversionedKubeletConfig defines minimum version from which kubeadm uses kubelet-config as a ConfigMap name.
@@ -34,25 +40,63 @@ func UploadKubeadmConfig(client kubernetes.Interface, config *Configuration) ([]
 	return nil, uploadconfig.UploadConfiguration(&config.InitConfiguration, client)
 }
 
-func UploadKubeletConfig(client kubernetes.Interface, config *Configuration) ([]byte, error) {
+func UploadKubeletConfig(ctx context.Context, client kubernetes.Interface, config *Configuration) ([]byte, error) {
 	kubeletConfiguration := KubeletConfiguration{
 		TenantControlPlaneDomain:        config.InitConfiguration.Networking.DNSDomain,
 		TenantControlPlaneDNSServiceIPs: config.Parameters.TenantDNSServiceIPs,
 		TenantControlPlaneCgroupDriver:  config.Parameters.TenantControlPlaneCGroupDriver,
+		FeatureGates:                    config.Parameters.KubeletFeatureGates,
+		ServerTLSBootstrap:              config.Parameters.KubeletServerTLSBootstrap,
+		Options:                         config.Parameters.KubeletConfig,
 	}
-	content, err := getKubeletConfigmapContent(kubeletConfiguration)
+
+	configMapName, err := generateKubeletConfigMapName(config.Parameters.TenantControlPlaneVersion)
 	if err != nil {
 		return nil, err
 	}
 
-	configMapName, err := generateKubeletConfigMapName(config.Parameters.TenantControlPlaneVersion)
-	if err != nil {
+	if err = uploadKubeletConfigMap(client, configMapName, "", kubeletConfiguration); err != nil {
 		return nil, err
 	}
 
+	configMapNames := []string{configMapName}
+	pools := make(map[string]struct{}, len(config.Parameters.KubeletPools))
+
+	for _, pool := range config.Parameters.KubeletPools {
+		poolConfiguration := kubeletConfiguration
+		poolConfiguration.TenantControlPlaneCgroupDriver = pool.CGroupDriver
+		poolConfiguration.Options = pool.Options
+
+		poolConfigMapName := fmt.Sprintf("%s-%s", kubeletPoolConfigMapPrefix, pool.Name)
+
+		if err = uploadKubeletConfigMap(client, poolConfigMapName, pool.Name, poolConfiguration); err != nil {
+			return nil, errors.Wrapf(err, "error uploading the kubelet configuration of the %s pool", pool.Name)
+		}
+
+		configMapNames = append(configMapNames, poolConfigMapName)
+		pools[pool.Name] = struct{}{}
+	}
+
+	if err = pruneKubeletPoolConfigMaps(ctx, client, pools); err != nil {
+		return nil, errors.Wrap(err, "error pruning the kubelet configuration of the removed pools")
+	}
+
+	if err = createConfigMapRBACRules(client, configMapNames...); err != nil {
+		return nil, errors.Wrap(err, "error creating kubelet configuration configmap RBAC rules")
+	}
+
+	return nil, nil
+}
+
+func uploadKubeletConfigMap(client kubernetes.Interface, name, pool string, kubeletConfiguration KubeletConfiguration) error {
+	content, err := getKubeletConfigmapContent(kubeletConfiguration)
+	if err != nil {
+		return err
+	}
+
 	configMap := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      configMapName,
+			Name:      name,
 			Namespace: metav1.NamespaceSystem,
 		},
 		Data: map[string]string{
@@ -60,15 +104,31 @@ func UploadKubeletConfig(client kubernetes.Interface, config *Configuration) ([]
 		},
 	}
 
-	if err = apiclient.CreateOrUpdate[*corev1.ConfigMap](client.CoreV1().ConfigMaps(metav1.NamespaceSystem), configMap); err != nil {
-		return nil, err
+	if len(pool) > 0 {
+		configMap.SetLabels(map[string]string{kubeletPoolLabel: pool})
 	}
 
-	if err = createConfigMapRBACRules(client, configMapName); err != nil {
-		return nil, errors.Wrap(err, "error creating kubelet configuration configmap RBAC rules")
+	return apiclient.CreateOrUpdate[*corev1.ConfigMap](client.CoreV1().ConfigMaps(metav1.NamespaceSystem), configMap)
+}
+
+// pruneKubeletPoolConfigMaps deletes the kubelet configuration ConfigMaps of the pools no longer declared.
+func pruneKubeletPoolConfigMaps(ctx context.Context, client kubernetes.Interface, pools map[string]struct{}) error {
+	configMaps, err := client.CoreV1().ConfigMaps(metav1.NamespaceSystem).List(ctx, metav1.ListOptions{LabelSelector: kubeletPoolLabel})
+	if err != nil {
+		return err
 	}
 
-	return nil, nil
+	for _, configMap := range configMaps.Items {
+		if _, ok := pools[configMap.GetLabels()[kubeletPoolLabel]]; ok {
+			continue
+		}
+
+		if err = client.CoreV1().ConfigMaps(metav1.NamespaceSystem).Delete(ctx, configMap.GetName(), metav1.DeleteOptions{}); err != nil && !k8serrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]byte, error) {
@@ -82,7 +142,9 @@ func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]by
 	kc.CgroupDriver = kubeletConfiguration.TenantControlPlaneCgroupDriver
 	kc.ClusterDNS = kubeletConfiguration.TenantControlPlaneDNSServiceIPs
 	kc.ClusterDomain = kubeletConfiguration.TenantControlPlaneDomain
+	kc.FeatureGates = kubeletConfiguration.FeatureGates
 	kc.RotateCertificates = true
+	kc.ServerTLSBootstrap = kubeletConfiguration.ServerTLSBootstrap
 	kc.StaticPodPath = "/etc/kubernetes/manifests"
 	// TODO(prometherion): drop support of <= v1.27 TCP versions
 	// a numeric value is required due to strict marshaling
@@ -93,10 +155,36 @@ func getKubeletConfigmapContent(kubeletConfiguration KubeletConfiguration) ([]by
 	// determine the resolvConf location, as reported in clastix/kamaji#581.
 	kc.ResolverConfig = nil
 
+	if opts := kubeletConfiguration.Options; opts != nil {
+		if opts.MaxPods != nil {
+			kc.MaxPods = *opts.MaxPods
+		}
+
+		if opts.SerializeImagePulls != nil {
+			kc.SerializeImagePulls = opts.SerializeImagePulls
+		}
+
+		if opts.MaxParallelImagePulls != nil {
+			kc.MaxParallelImagePulls = opts.MaxParallelImagePulls
+		}
+
+		if len(opts.EvictionHard) > 0 {
+			kc.EvictionHard = opts.EvictionHard
+		}
+
+		if len(opts.EvictionSoft) > 0 {
+			kc.EvictionSoft = opts.EvictionSoft
+		}
+
+		if len(opts.EvictionSoftGracePeriod) > 0 {
+			kc.EvictionSoftGracePeriod = opts.EvictionSoftGracePeriod
+		}
+	}
+
 	return utilities.EncodeToYaml(&kc)
 }
 
-func createConfigMapRBACRules(client kubernetes.Interface, configMapName string) error {
+func createConfigMapRBACRules(client kubernetes.Interface, configMapNames ...string) error {
 	configMapRBACName := kubeadmconstants.KubeletBaseConfigMapRole
 
 	if err := apiclient.CreateOrUpdate[*rbacv1.Role](client.RbacV1().Roles(metav1.NamespaceSystem), &rbacv1.Role{
@@ -109,7 +197,7 @@ func createConfigMapRBACRules(client kubernetes.Interface, configMapName string)
 				Verbs:         []string{"get"},
 				APIGroups:     []string{""},
 				Resources:     []string{"configmaps"},
-				ResourceNames: []string{configMapName},
+				ResourceNames: configMapNames,
 			},
 		},
 	}); err != nil {
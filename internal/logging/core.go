@@ -0,0 +1,94 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Sink receives the JSON encoded log entries of a single tenant.
+type Sink interface {
+	Write(tenant string, entry []byte) error
+	Sync() error
+}
+
+// tenantCore is a zap core encoding only the entries referring to a tenant, by means of the TenantKey field,
+// writing them to the Sink: it's meant to be teed with the operator one, which keeps receiving all the entries.
+type tenantCore struct {
+	zapcore.LevelEnabler
+
+	encoder zapcore.Encoder
+	sink    Sink
+	tenant  string
+}
+
+// NewTenantCore returns the zap core routing the entries enabled by the given level to the Sink.
+func NewTenantCore(level zapcore.LevelEnabler, sink Sink) zapcore.Core {
+	return &tenantCore{
+		LevelEnabler: level,
+		encoder:      zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		sink:         sink,
+	}
+}
+
+// WithTenantSink returns the zap option teeing the logger core with the tenant one.
+func WithTenantSink(level zapcore.LevelEnabler, sink Sink) zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewTee(core, NewTenantCore(level, sink))
+	})
+}
+
+func (c *tenantCore) With(fields []zapcore.Field) zapcore.Core {
+	clone := &tenantCore{
+		LevelEnabler: c.LevelEnabler,
+		encoder:      c.encoder.Clone(),
+		sink:         c.sink,
+		tenant:       tenantFrom(c.tenant, fields),
+	}
+
+	for _, field := range fields {
+		field.AddTo(clone.encoder)
+	}
+
+	return clone
+}
+
+func (c *tenantCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+
+	return checked
+}
+
+func (c *tenantCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	tenant := tenantFrom(c.tenant, fields)
+	if tenant == "" {
+		return nil
+	}
+
+	buf, err := c.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return err
+	}
+	defer buf.Free()
+
+	return c.sink.Write(tenant, buf.Bytes())
+}
+
+func (c *tenantCore) Sync() error {
+	return c.sink.Sync()
+}
+
+// tenantFrom returns the tenant declared by the given fields, or the current one otherwise.
+func tenantFrom(current string, fields []zapcore.Field) string {
+	for _, field := range fields {
+		if field.Key == TenantKey && field.Type == zapcore.StringType {
+			current = field.String
+		}
+	}
+
+	return current
+}
@@ -0,0 +1,95 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type memorySink struct {
+	lock    sync.Mutex
+	entries map[string][]string
+}
+
+func (m *memorySink) Write(tenant string, entry []byte) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.entries[tenant] = append(m.entries[tenant], string(entry))
+
+	return nil
+}
+
+func (m *memorySink) Sync() error {
+	return nil
+}
+
+func TestTenantCore(t *testing.T) {
+	sink := &memorySink{entries: map[string][]string{}}
+
+	logger := zap.New(NewTenantCore(zapcore.InfoLevel, sink))
+
+	logger.Info("operator entry")
+	logger.With(zap.String(TenantKey, "default/foo")).Info("foo entry", zap.String(ResourceKey, "deployment"))
+	logger.Info("bar entry", zap.String(TenantKey, "default/bar"))
+	logger.With(zap.String(TenantKey, "default/foo")).Debug("foo debug entry")
+
+	if len(sink.entries) != 2 {
+		t.Fatalf("expected entries of 2 tenants, got %v", sink.entries)
+	}
+
+	foo := sink.entries["default/foo"]
+	if len(foo) != 1 {
+		t.Fatalf("expected a single entry for default/foo, got %v", foo)
+	}
+
+	var entry map[string]any
+	if err := json.Unmarshal([]byte(foo[0]), &entry); err != nil {
+		t.Fatalf("unexpected error decoding the entry: %v", err)
+	}
+
+	if entry["msg"] != "foo entry" || entry[TenantKey] != "default/foo" || entry[ResourceKey] != "deployment" {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+
+	if bar := sink.entries["default/bar"]; len(bar) != 1 || !strings.Contains(bar[0], "bar entry") {
+		t.Fatalf("unexpected entries for default/bar: %v", bar)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	directory := t.TempDir()
+
+	sink, err := NewFileSink(directory)
+	if err != nil {
+		t.Fatalf("unexpected error creating the sink: %v", err)
+	}
+
+	for _, entry := range []string{"first\n", "second\n"} {
+		if err = sink.Write("default/foo", []byte(entry)); err != nil {
+			t.Fatalf("unexpected error writing the entry: %v", err)
+		}
+	}
+
+	if err = sink.Sync(); err != nil {
+		t.Fatalf("unexpected error syncing the sink: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(directory, "default_foo.log"))
+	if err != nil {
+		t.Fatalf("unexpected error reading the tenant log file: %v", err)
+	}
+
+	if string(content) != "first\nsecond\n" {
+		t.Fatalf("unexpected tenant log file content %q", content)
+	}
+}
@@ -0,0 +1,17 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+// The standard fields of the Kamaji logs, shared by the operator and the soot controllers:
+// the tenant one is used to route the entries to the per-tenant sinks.
+const (
+	// TenantKey is the Tenant Control Plane the entry refers to, formatted as <namespace>/<name>.
+	TenantKey = "tenant"
+	// NamespaceKey is the namespace of the Tenant Control Plane.
+	NamespaceKey = "namespace"
+	// ControllerKey is the controller emitting the entry.
+	ControllerKey = "controller"
+	// ResourceKey is the resource handled by the controller.
+	ResourceKey = "resource"
+)
@@ -0,0 +1,145 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// FileSink appends the entries of each tenant to its own file, named <namespace>_<name>.log, in the given directory.
+type FileSink struct {
+	directory string
+
+	lock  sync.Mutex
+	files map[string]*os.File
+}
+
+func NewFileSink(directory string) (*FileSink, error) {
+	if err := os.MkdirAll(directory, 0o750); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("cannot create the tenant logs directory %s", directory))
+	}
+
+	return &FileSink{directory: directory, files: map[string]*os.File{}}, nil
+}
+
+func (f *FileSink) Write(tenant string, entry []byte) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	file, ok := f.files[tenant]
+	if !ok {
+		var err error
+
+		name := filepath.Join(f.directory, strings.ReplaceAll(tenant, "/", "_")+".log")
+		if file, err = os.OpenFile(name, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o640); err != nil { //nolint:gosec
+			return errors.Wrap(err, fmt.Sprintf("cannot open the tenant log file %s", name))
+		}
+
+		f.files[tenant] = file
+	}
+
+	_, err := file.Write(entry)
+
+	return err
+}
+
+func (f *FileSink) Sync() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	var errs []error
+
+	for _, file := range f.files {
+		if err := file.Sync(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("cannot sync the tenant log files: %v", errs)
+	}
+
+	return nil
+}
+
+type webhookEntry struct {
+	tenant string
+	body   []byte
+}
+
+// WebhookSink sends each entry to the given URL with a POST request, along with the X-Kamaji-Tenant header.
+// The entries are buffered and sent asynchronously, dropping them when the buffer is full:
+// a slow, or unavailable, receiver must not block the operator.
+type WebhookSink struct {
+	url     string
+	client  http.Client
+	entries chan webhookEntry
+}
+
+func NewWebhookSink(ctx context.Context, url string, bufferSize int) *WebhookSink {
+	sink := &WebhookSink{
+		url:     url,
+		client:  http.Client{Timeout: 5 * time.Second},
+		entries: make(chan webhookEntry, bufferSize),
+	}
+
+	go sink.run(ctx)
+
+	return sink
+}
+
+func (w *WebhookSink) Write(tenant string, entry []byte) error {
+	// The entry buffer is reused by the encoder once written.
+	body := make([]byte, len(entry))
+	copy(body, entry)
+
+	select {
+	case w.entries <- webhookEntry{tenant: tenant, body: body}:
+	default:
+	}
+
+	return nil
+}
+
+func (w *WebhookSink) Sync() error {
+	return nil
+}
+
+func (w *WebhookSink) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case entry := <-w.entries:
+			w.send(ctx, entry)
+		}
+	}
+}
+
+func (w *WebhookSink) send(ctx context.Context, entry webhookEntry) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(entry.body))
+	if err != nil {
+		return
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("X-Kamaji-Tenant", entry.tenant)
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return
+	}
+
+	_ = response.Body.Close()
+}
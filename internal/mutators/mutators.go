@@ -0,0 +1,149 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package mutators
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Mutator mutates a rendered object of the Tenant Control Plane, JSON encoded, right before it's applied:
+// the returned object must retain the identity of the provided one.
+type Mutator interface {
+	Mutate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, kind kamajiv1alpha1.MutationTargetKind, name string, data []byte) ([]byte, error)
+}
+
+// FromProfile returns the mutators declared by the given MutationProfile:
+// the patches are applied in the declared order, followed by the webhook.
+func FromProfile(profile *kamajiv1alpha1.MutationProfile) []Mutator {
+	mutators := make([]Mutator, 0, len(profile.Spec.Patches)+1)
+
+	for _, patch := range profile.Spec.Patches {
+		mutators = append(mutators, Patch(patch))
+	}
+
+	if profile.Spec.Webhook != nil {
+		mutators = append(mutators, Webhook(*profile.Spec.Webhook))
+	}
+
+	return mutators
+}
+
+// Apply mutates the given object with the MutationProfile objects referenced by the Tenant Control Plane,
+// in the declared order: it must be called once the object is rendered, as last step of the mutation function.
+func Apply(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane, obj client.Object) error {
+	if len(tcp.Spec.ControlPlane.Mutators) == 0 {
+		return nil
+	}
+
+	var mutators []Mutator
+
+	for _, name := range tcp.Spec.ControlPlane.Mutators {
+		var profile kamajiv1alpha1.MutationProfile
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, &profile); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot retrieve the MutationProfile %s", name))
+		}
+
+		mutators = append(mutators, FromProfile(&profile)...)
+	}
+
+	return Mutate(ctx, tcp, obj, mutators...)
+}
+
+// Mutate applies the given mutators to the object, decoding the result back into it:
+// the mutators cannot change the object kind, name, or namespace.
+func Mutate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, obj client.Object, mutators ...Mutator) error {
+	if len(mutators) == 0 {
+		return nil
+	}
+
+	kind, gvk, err := targetKind(obj)
+	if err != nil {
+		return err
+	}
+	// The type information is usually missing from the typed objects, although required by the webhooks:
+	// it's restored once decoded to prevent the object from being considered as changed.
+	current := obj.GetObjectKind().GroupVersionKind()
+	defer obj.GetObjectKind().SetGroupVersionKind(current)
+
+	obj.GetObjectKind().SetGroupVersionKind(gvk)
+
+	data, err := json.Marshal(obj)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode the object to mutate")
+	}
+
+	for _, mutator := range mutators {
+		if data, err = mutator.Mutate(ctx, tcp, kind, obj.GetName(), data); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot mutate the %s %s", kind, obj.GetName()))
+		}
+	}
+
+	name, namespace := obj.GetName(), obj.GetNamespace()
+
+	value := reflect.ValueOf(obj).Elem()
+	value.Set(reflect.Zero(value.Type()))
+
+	if err = json.Unmarshal(data, obj); err != nil {
+		return errors.Wrap(err, "cannot decode the mutated object")
+	}
+
+	if obj.GetObjectKind().GroupVersionKind() != gvk || obj.GetName() != name || obj.GetNamespace() != namespace {
+		return fmt.Errorf("the mutators cannot change the identity of the %s %s", kind, name)
+	}
+
+	return nil
+}
+
+func targetKind(obj client.Object) (kamajiv1alpha1.MutationTargetKind, schema.GroupVersionKind, error) {
+	switch obj.(type) {
+	case *appsv1.Deployment:
+		return kamajiv1alpha1.MutationTargetDeployment, appsv1.SchemeGroupVersion.WithKind("Deployment"), nil
+	case *corev1.Service:
+		return kamajiv1alpha1.MutationTargetService, corev1.SchemeGroupVersion.WithKind("Service"), nil
+	case *corev1.ConfigMap:
+		return kamajiv1alpha1.MutationTargetConfigMap, corev1.SchemeGroupVersion.WithKind("ConfigMap"), nil
+	default:
+		return "", schema.GroupVersionKind{}, fmt.Errorf("unsupported mutation target %T", obj)
+	}
+}
+
+// dataStruct returns the Go type of the given kind, used to compute the strategic merge patches.
+func dataStruct(kind kamajiv1alpha1.MutationTargetKind) any {
+	switch kind {
+	case kamajiv1alpha1.MutationTargetDeployment:
+		return appsv1.Deployment{}
+	case kamajiv1alpha1.MutationTargetService:
+		return corev1.Service{}
+	default:
+		return corev1.ConfigMap{}
+	}
+}
+
+// matches returns true when the object of the given kind, and name, is selected by the target.
+func matches(target kamajiv1alpha1.MutationTarget, kind kamajiv1alpha1.MutationTargetKind, name string) bool {
+	if target.Kind != kind {
+		return false
+	}
+
+	if len(target.Name) == 0 {
+		return true
+	}
+
+	matched, _ := path.Match(target.Name, name)
+
+	return matched
+}
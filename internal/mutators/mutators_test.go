@@ -0,0 +1,183 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package mutators
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func tenantControlPlane() *kamajiv1alpha1.TenantControlPlane {
+	return &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "default"}}
+}
+
+func deployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "kube-apiserver", Image: "registry.k8s.io/kube-apiserver:v1.33.0"}},
+				},
+			},
+		},
+	}
+}
+
+func TestPatchStrategicMerge(t *testing.T) {
+	obj := deployment()
+
+	err := Mutate(context.Background(), tenantControlPlane(), obj, Patch{
+		Target: kamajiv1alpha1.MutationTarget{Kind: kamajiv1alpha1.MutationTargetDeployment},
+		Patch: `
+spec:
+  template:
+    spec:
+      containers:
+      - name: audit-shipper
+        image: corp.example.com/audit-shipper:v1
+`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	containers := obj.Spec.Template.Spec.Containers
+	if len(containers) != 2 || containers[0].Name != "audit-shipper" || containers[1].Name != "kube-apiserver" {
+		t.Errorf("expected the sidecar to be merged, got %v", containers)
+	}
+
+	if obj.GetObjectKind().GroupVersionKind().Kind != "" {
+		t.Errorf("expected the type information to be restored, got %v", obj.GetObjectKind().GroupVersionKind())
+	}
+}
+
+func TestPatchJSON(t *testing.T) {
+	obj := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-00-scheduler-configuration", Namespace: "default"},
+		Data:       map[string]string{"scheduler-config.yaml": "kind: KubeSchedulerConfiguration"},
+	}
+
+	err := Mutate(context.Background(), tenantControlPlane(), obj, Patch{
+		Target: kamajiv1alpha1.MutationTarget{Kind: kamajiv1alpha1.MutationTargetConfigMap, Name: "*-scheduler-configuration"},
+		Type:   kamajiv1alpha1.MutationPatchJSON,
+		Patch:  `[{"op": "add", "path": "/metadata/labels", "value": {"corp.example.com/owner": "platform"}}]`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.GetLabels()["corp.example.com/owner"] != "platform" {
+		t.Errorf("expected the label to be added, got %v", obj.GetLabels())
+	}
+}
+
+func TestPatchNotMatching(t *testing.T) {
+	obj := deployment()
+
+	err := Mutate(context.Background(), tenantControlPlane(), obj, Patch{
+		Target: kamajiv1alpha1.MutationTarget{Kind: kamajiv1alpha1.MutationTargetDeployment, Name: "other-*"},
+		Patch:  `{"spec": {"replicas": 5}}`,
+	}, Patch{
+		Target: kamajiv1alpha1.MutationTarget{Kind: kamajiv1alpha1.MutationTargetService},
+		Patch:  `{"spec": {"replicas": 5}}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if obj.Spec.Replicas != nil {
+		t.Errorf("expected the object to be unchanged, got %d replicas", *obj.Spec.Replicas)
+	}
+}
+
+func TestPatchIdentity(t *testing.T) {
+	err := Mutate(context.Background(), tenantControlPlane(), deployment(), Patch{
+		Target: kamajiv1alpha1.MutationTarget{Kind: kamajiv1alpha1.MutationTargetDeployment},
+		Patch:  `{"metadata": {"name": "renamed"}}`,
+	})
+	if err == nil {
+		t.Error("expected the identity change to be rejected")
+	}
+}
+
+func TestWebhook(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		var review webhookReview
+		if err := json.Unmarshal(body, &review); err != nil || review.TenantControlPlane.Name != "tenant-00" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		var obj appsv1.Deployment
+		if err := json.Unmarshal(review.Object, &obj); err != nil || obj.Kind != "Deployment" {
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		obj.Spec.Template.Spec.Containers[0].Image = "corp.example.com/kube-apiserver:v1.33.0"
+
+		_ = json.NewEncoder(w).Encode(obj)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	obj := deployment()
+
+	err := Mutate(context.Background(), tenantControlPlane(), obj, Webhook{
+		URL:      server.URL,
+		CABundle: caBundle,
+		Timeout:  metav1.Duration{Duration: time.Second},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if image := obj.Spec.Template.Spec.Containers[0].Image; image != "corp.example.com/kube-apiserver:v1.33.0" {
+		t.Errorf("expected the webhook mutation, got %s", image)
+	}
+}
+
+func TestWebhookFailurePolicy(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	caBundle := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	webhook := Webhook{
+		URL:           server.URL,
+		CABundle:      caBundle,
+		Timeout:       metav1.Duration{Duration: time.Second},
+		FailurePolicy: kamajiv1alpha1.MutationFailurePolicyFail,
+	}
+
+	if err := Mutate(context.Background(), tenantControlPlane(), deployment(), webhook); err == nil {
+		t.Error("expected the webhook failure to be returned")
+	}
+
+	webhook.FailurePolicy = kamajiv1alpha1.MutationFailurePolicyIgnore
+
+	if err := Mutate(context.Background(), tenantControlPlane(), deployment(), webhook); err != nil {
+		t.Errorf("expected the webhook failure to be ignored, got %v", err)
+	}
+}
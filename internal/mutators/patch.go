@@ -0,0 +1,41 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package mutators
+
+import (
+	"context"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/util/yaml"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Patch is the in-process Mutator applying the given patch to the matching objects.
+type Patch kamajiv1alpha1.MutationPatch
+
+func (p Patch) Mutate(_ context.Context, _ *kamajiv1alpha1.TenantControlPlane, kind kamajiv1alpha1.MutationTargetKind, name string, data []byte) ([]byte, error) {
+	if !matches(p.Target, kind, name) {
+		return data, nil
+	}
+
+	patch, err := yaml.ToJSON([]byte(p.Patch))
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode the patch")
+	}
+
+	switch p.Type {
+	case kamajiv1alpha1.MutationPatchJSON:
+		operations, decodeErr := jsonpatch.DecodePatch(patch)
+		if decodeErr != nil {
+			return nil, errors.Wrap(decodeErr, "cannot decode the JSON patch")
+		}
+
+		return operations.Apply(data)
+	default:
+		return strategicpatch.StrategicMergePatch(data, patch, dataStruct(kind))
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package mutators
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// webhookMaxResponseSize limits the size of the mutated objects returned by the webhooks.
+const webhookMaxResponseSize = 4 << 20
+
+// Webhook is the Mutator sending the matching objects to an external endpoint,
+// which replies with the mutated object, or with no content when no changes are required.
+type Webhook kamajiv1alpha1.MutationWebhook
+
+// webhookReview is the payload sent to the mutation webhook.
+type webhookReview struct {
+	TenantControlPlane webhookReviewReference `json:"tenantControlPlane"`
+	Object             json.RawMessage        `json:"object"`
+}
+
+type webhookReviewReference struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+func (w Webhook) Mutate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, kind kamajiv1alpha1.MutationTargetKind, name string, data []byte) ([]byte, error) {
+	if len(w.Kinds) > 0 && !slices.Contains(w.Kinds, kind) {
+		return data, nil
+	}
+
+	mutated, err := w.call(ctx, webhookReview{
+		TenantControlPlane: webhookReviewReference{Namespace: tcp.GetNamespace(), Name: tcp.GetName()},
+		Object:             data,
+	})
+	if err != nil {
+		if w.FailurePolicy == kamajiv1alpha1.MutationFailurePolicyIgnore {
+			log.FromContext(ctx).Error(err, "ignoring the mutation webhook failure", "url", w.URL, "kind", kind, "name", name)
+
+			return data, nil
+		}
+
+		return nil, errors.Wrap(err, "cannot call the mutation webhook")
+	}
+
+	if mutated == nil {
+		return data, nil
+	}
+
+	return mutated, nil
+}
+
+// call returns the mutated object replied by the webhook, a nil one when no content is returned.
+func (w Webhook) call(ctx context.Context, review webhookReview) ([]byte, error) {
+	payload, err := json.Marshal(review)
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := &http.Client{Timeout: w.Timeout.Duration}
+
+	if len(w.CABundle) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(w.CABundle) {
+			return nil, errors.New("cannot parse the CA bundle")
+		}
+
+		httpClient.Transport = &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		body, readErr := io.ReadAll(io.LimitReader(response.Body, webhookMaxResponseSize))
+		if readErr != nil {
+			return nil, errors.Wrap(readErr, "cannot read the mutated object")
+		}
+
+		return body, nil
+	case http.StatusNoContent:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unexpected status code %d", response.StatusCode)
+	}
+}
@@ -0,0 +1,240 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/pkg/errors"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=notificationsinks,verbs=get;list;watch
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=notificationsinks/status,verbs=get;update;patch
+
+// bufferSize is the number of the notifications waiting for the delivery, the further ones are dropped.
+const bufferSize = 100
+
+// Dispatcher delivers the lifecycle events of the Tenant Control Planes to the matching NotificationSink objects.
+// The notifications are buffered and delivered asynchronously, dropping them when the buffer is full:
+// a slow, or unavailable, receiver must not block the reconciliations.
+type Dispatcher struct {
+	Client client.Client
+
+	notifications chan Notification
+	httpClient    http.Client
+	// sent tracks the last delivery of each event, per sink and Tenant Control Plane, enforcing the sink throttle.
+	sent map[string]time.Time
+}
+
+func NewDispatcher(c client.Client) *Dispatcher {
+	return &Dispatcher{
+		Client:        c,
+		notifications: make(chan Notification, bufferSize),
+		httpClient:    http.Client{Timeout: 10 * time.Second},
+		sent:          map[string]time.Time{},
+	}
+}
+
+// Notify enqueues the given event of the Tenant Control Plane: it's a no-op on a nil Dispatcher.
+func (d *Dispatcher) Notify(tenantControlPlane types.NamespacedName, event kamajiv1alpha1.NotificationEvent, message string) {
+	if d == nil {
+		return
+	}
+
+	notification := Notification{
+		Event:    event,
+		Severity: severity(event),
+		Message:  message,
+		Time:     time.Now().UTC(),
+		TenantControlPlane: TenantControlPlaneReference{
+			Name:      tenantControlPlane.Name,
+			Namespace: tenantControlPlane.Namespace,
+		},
+	}
+
+	select {
+	case d.notifications <- notification:
+	default:
+	}
+}
+
+// NeedLeaderElection ensures the notifications are delivered once, the events are produced by the leader anyway.
+func (d *Dispatcher) NeedLeaderElection() bool {
+	return true
+}
+
+func (d *Dispatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("notifications")
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case notification := <-d.notifications:
+			if err := d.dispatch(log.IntoContext(ctx, logger), notification); err != nil {
+				logger.Error(err, "cannot dispatch the notification", "event", notification.Event, "tenant", notification.TenantControlPlane.String())
+			}
+		}
+	}
+}
+
+func (d *Dispatcher) dispatch(ctx context.Context, notification Notification) error {
+	var sinkList kamajiv1alpha1.NotificationSinkList
+	if err := d.Client.List(ctx, &sinkList); err != nil {
+		return errors.Wrap(err, "cannot list the NotificationSink objects")
+	}
+
+	if len(sinkList.Items) == 0 {
+		return nil
+	}
+	// The labels are retrieved by the dispatcher, rather than the producers, since some of them,
+	// such as the certificates lifecycle, are not dealing with the Tenant Control Plane object.
+	var tcp kamajiv1alpha1.TenantControlPlane
+	if err := d.Client.Get(ctx, types.NamespacedName{Namespace: notification.TenantControlPlane.Namespace, Name: notification.TenantControlPlane.Name}, &tcp); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the notified Tenant Control Plane")
+	}
+
+	for _, sink := range sinkList.Items {
+		matches, err := d.matches(sink, notification, tcp.GetLabels())
+		if err != nil {
+			log.FromContext(ctx).Error(err, "cannot evaluate the sink filters", "sink", sink.GetName())
+
+			continue
+		}
+
+		if !matches {
+			continue
+		}
+
+		key := fmt.Sprintf("%s/%s/%s", sink.GetName(), notification.TenantControlPlane.String(), notification.Event)
+		if last, ok := d.sent[key]; ok && time.Since(last) < sink.Spec.Throttle.Duration {
+			continue
+		}
+
+		d.sent[key] = time.Now()
+
+		deliveryErr := d.deliver(ctx, sink, notification)
+		if deliveryErr != nil {
+			log.FromContext(ctx).Error(deliveryErr, "cannot deliver the notification", "sink", sink.GetName())
+		}
+
+		if err = d.updateStatus(ctx, sink, notification, deliveryErr); err != nil {
+			log.FromContext(ctx).Error(err, "cannot update the sink status", "sink", sink.GetName())
+		}
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) matches(sink kamajiv1alpha1.NotificationSink, notification Notification, tcpLabels map[string]string) (bool, error) {
+	if len(sink.Spec.Events) > 0 && !slices.Contains(sink.Spec.Events, notification.Event) {
+		return false, nil
+	}
+
+	if len(sink.Spec.Namespaces) > 0 && !slices.Contains(sink.Spec.Namespaces, notification.TenantControlPlane.Namespace) {
+		return false, nil
+	}
+
+	if sink.Spec.Selector == nil {
+		return true, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(sink.Spec.Selector)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot parse the selector")
+	}
+
+	return selector.Matches(labels.Set(tcpLabels)), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, sink kamajiv1alpha1.NotificationSink, notification Notification) error {
+	url := sink.Spec.URL
+	if sink.Spec.Type == kamajiv1alpha1.NotificationSinkTypePagerDuty && len(url) == 0 {
+		url = pagerDutyEventsURL
+	}
+
+	if ref := sink.Spec.URLSecretRef; ref != nil {
+		content, err := (&kamajiv1alpha1.ContentRef{SecretRef: ref}).GetContent(ctx, d.Client)
+		if err != nil {
+			return errors.Wrap(err, "cannot retrieve the URL")
+		}
+
+		url = string(bytes.TrimSpace(content))
+	}
+
+	if ref := sink.Spec.RoutingKeySecretRef; ref != nil {
+		content, err := (&kamajiv1alpha1.ContentRef{SecretRef: ref}).GetContent(ctx, d.Client)
+		if err != nil {
+			return errors.Wrap(err, "cannot retrieve the routing key")
+		}
+
+		notification.RoutingKey = string(bytes.TrimSpace(content))
+	}
+
+	body, err := Render(sink.Spec.Type, sink.Spec.Template, notification)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "cannot create the request")
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	for name, value := range sink.Spec.Headers {
+		request.Header.Set(name, value)
+	}
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "cannot send the request")
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, 4096))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("unexpected response status %s", response.Status)
+	}
+
+	return nil
+}
+
+func (d *Dispatcher) updateStatus(ctx context.Context, sink kamajiv1alpha1.NotificationSink, notification Notification, deliveryErr error) error {
+	delivery := &kamajiv1alpha1.NotificationDeliveryStatus{
+		Event:              notification.Event,
+		TenantControlPlane: notification.TenantControlPlane.String(),
+		Time:               metav1.NewTime(notification.Time),
+		Succeeded:          deliveryErr == nil,
+	}
+
+	if deliveryErr != nil {
+		delivery.Error = deliveryErr.Error()
+	}
+
+	patch := client.MergeFrom(sink.DeepCopy())
+
+	sink.Status.LastDelivery = delivery
+	if deliveryErr != nil {
+		sink.Status.LastFailure = delivery
+	}
+
+	return d.Client.Status().Patch(ctx, &sink, patch)
+}
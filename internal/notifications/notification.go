@@ -0,0 +1,91 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"bytes"
+	"encoding/json"
+	"text/template"
+	"time"
+
+	"github.com/pkg/errors"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+const pagerDutyEventsURL = "https://events.pagerduty.com/v2/enqueue"
+
+const (
+	slackTemplate     = `{"text": {{ printf "*%s* %s: %s" .Event .TenantControlPlane .Message | json }}}`
+	pagerDutyTemplate = `{"routing_key": {{ json .RoutingKey }}, "event_action": "trigger", "dedup_key": {{ printf "%s/%s" .TenantControlPlane .Event | json }}, ` +
+		`"payload": {"summary": {{ printf "%s: %s" .TenantControlPlane .Message | json }}, "source": {{ json .TenantControlPlane.String }}, ` +
+		`"severity": {{ json .Severity }}, "timestamp": {{ json .Time }}, "component": "kamaji", "class": {{ json .Event }}}}`
+)
+
+// TenantControlPlaneReference is the notified Tenant Control Plane.
+type TenantControlPlaneReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+func (t TenantControlPlaneReference) String() string {
+	return t.Namespace + "/" + t.Name
+}
+
+// Notification is the lifecycle event delivered to the sinks, and the data of their templates.
+type Notification struct {
+	Event kamajiv1alpha1.NotificationEvent `json:"event"`
+	// Severity is one of info, warning, or error, as the PagerDuty ones.
+	Severity           string                      `json:"severity"`
+	Message            string                      `json:"message"`
+	Time               time.Time                   `json:"time"`
+	TenantControlPlane TenantControlPlaneReference `json:"tenantControlPlane"`
+	// RoutingKey is the PagerDuty integration key, if any: it's not part of the Webhook payload.
+	RoutingKey string `json:"-"`
+}
+
+func severity(event kamajiv1alpha1.NotificationEvent) string {
+	switch event {
+	case kamajiv1alpha1.NotificationEventCertificateExpiring:
+		return "warning"
+	case kamajiv1alpha1.NotificationEventSootCrashLooping:
+		return "error"
+	default:
+		return "info"
+	}
+}
+
+// Render returns the request body of the given notification, using the custom template if any,
+// or the default one of the sink type otherwise.
+func Render(sinkType kamajiv1alpha1.NotificationSinkType, customTemplate string, notification Notification) ([]byte, error) {
+	text := customTemplate
+	if len(text) == 0 {
+		switch sinkType {
+		case kamajiv1alpha1.NotificationSinkTypeSlack:
+			text = slackTemplate
+		case kamajiv1alpha1.NotificationSinkTypePagerDuty:
+			text = pagerDutyTemplate
+		default:
+			return json.Marshal(notification)
+		}
+	}
+
+	tpl, err := template.New("notification").Funcs(template.FuncMap{
+		"json": func(value any) (string, error) {
+			encoded, jsonErr := json.Marshal(value)
+
+			return string(encoded), jsonErr
+		},
+	}).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the template")
+	}
+
+	var body bytes.Buffer
+	if err = tpl.Execute(&body, notification); err != nil {
+		return nil, errors.Wrap(err, "cannot render the template")
+	}
+
+	return body.Bytes(), nil
+}
@@ -0,0 +1,47 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package notifications
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestRender(t *testing.T) {
+	notification := Notification{
+		Event:              kamajiv1alpha1.NotificationEventSootCrashLooping,
+		Severity:           severity(kamajiv1alpha1.NotificationEventSootCrashLooping),
+		Message:            `the soot manager failed: "timeout"`,
+		Time:               time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+		TenantControlPlane: TenantControlPlaneReference{Name: "tenant-00", Namespace: "tenants"},
+		RoutingKey:         "secret",
+	}
+
+	for _, sinkType := range []kamajiv1alpha1.NotificationSinkType{kamajiv1alpha1.NotificationSinkTypeWebhook, kamajiv1alpha1.NotificationSinkTypeSlack, kamajiv1alpha1.NotificationSinkTypePagerDuty} {
+		body, err := Render(sinkType, "", notification)
+		if err != nil {
+			t.Fatalf("cannot render the %s payload: %v", sinkType, err)
+		}
+
+		if !json.Valid(body) {
+			t.Errorf("expected the %s payload to be valid JSON, but got %s", sinkType, body)
+		}
+	}
+
+	body, err := Render(kamajiv1alpha1.NotificationSinkTypeWebhook, `{{ .TenantControlPlane.Name }} is {{ .Severity }}`, notification)
+	if err != nil {
+		t.Fatalf("cannot render the custom template: %v", err)
+	}
+
+	if expect := "tenant-00 is error"; string(body) != expect {
+		t.Errorf("expected %q, but got %q", expect, body)
+	}
+
+	if _, err = Render(kamajiv1alpha1.NotificationSinkTypeWebhook, `{{ .Unknown }}`, notification); err == nil {
+		t.Errorf("expected the unknown fields to fail the rendering")
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"fmt"
+	"strings"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Name returns the name of the ValidatingAdmissionPolicy, and of its binding, enforcing the given KamajiPolicy.
+func Name(policy *kamajiv1alpha1.KamajiPolicy) string {
+	return "kamaji-policy-" + policy.GetName()
+}
+
+// ValidatingAdmissionPolicySpec returns the CEL validations enforcing the given KamajiPolicy on the TenantControlPlane objects.
+// The requests are matched using the equivalent v1alpha1 version, thus the expressions refer to its fields.
+func ValidatingAdmissionPolicySpec(policy *kamajiv1alpha1.KamajiPolicy) admissionregistrationv1.ValidatingAdmissionPolicySpec {
+	var validations []admissionregistrationv1.Validation
+
+	if versions := policy.Spec.AllowedVersions; len(versions) > 0 {
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("%s.exists(v, object.spec.kubernetes.version == v || object.spec.kubernetes.version.startsWith(v + '.'))", celList(versions)),
+			Message:    fmt.Sprintf("the Kubernetes version is not allowed by the KamajiPolicy %s, allowed versions: %s", policy.GetName(), strings.Join(versions, ", ")),
+			Reason:     ptr.To(metav1.StatusReasonForbidden),
+		})
+	}
+	// The Tenant Control Planes with a dedicated DataStore are not declaring any DataStore object.
+	if dataStores := policy.Spec.AllowedDataStores; len(dataStores) > 0 {
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("!has(object.spec.dataStore) || object.spec.dataStore == '' || object.spec.dataStore in %s", celList(dataStores)),
+			Message:    fmt.Sprintf("the DataStore is not allowed by the KamajiPolicy %s, allowed DataStore objects: %s", policy.GetName(), strings.Join(dataStores, ", ")),
+			Reason:     ptr.To(metav1.StatusReasonForbidden),
+		})
+	}
+
+	for _, label := range policy.Spec.RequiredLabels {
+		validations = append(validations, admissionregistrationv1.Validation{
+			Expression: fmt.Sprintf("has(object.metadata.labels) && %q in object.metadata.labels", label),
+			Message:    fmt.Sprintf("the label %s is required by the KamajiPolicy %s", label, policy.GetName()),
+			Reason:     ptr.To(metav1.StatusReasonForbidden),
+		})
+	}
+
+	return admissionregistrationv1.ValidatingAdmissionPolicySpec{
+		FailurePolicy: ptr.To(admissionregistrationv1.Fail),
+		MatchConstraints: &admissionregistrationv1.MatchResources{
+			MatchPolicy: ptr.To(admissionregistrationv1.Equivalent),
+			ResourceRules: []admissionregistrationv1.NamedRuleWithOperations{
+				{
+					RuleWithOperations: admissionregistrationv1.RuleWithOperations{
+						Operations: []admissionregistrationv1.OperationType{admissionregistrationv1.Create, admissionregistrationv1.Update},
+						Rule: admissionregistrationv1.Rule{
+							APIGroups:   []string{kamajiv1alpha1.GroupVersion.Group},
+							APIVersions: []string{kamajiv1alpha1.GroupVersion.Version},
+							Resources:   []string{"tenantcontrolplanes"},
+							Scope:       ptr.To(admissionregistrationv1.NamespacedScope),
+						},
+					},
+				},
+			},
+		},
+		Validations: validations,
+	}
+}
+
+// ValidatingAdmissionPolicyBindingSpec returns the binding of the policy to the namespaces selected by the given KamajiPolicy.
+func ValidatingAdmissionPolicyBindingSpec(policy *kamajiv1alpha1.KamajiPolicy) admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec {
+	actions := policy.Spec.ValidationActions
+	if len(actions) == 0 {
+		actions = []admissionregistrationv1.ValidationAction{admissionregistrationv1.Deny}
+	}
+
+	spec := admissionregistrationv1.ValidatingAdmissionPolicyBindingSpec{
+		PolicyName:        Name(policy),
+		ValidationActions: actions,
+	}
+
+	if policy.Spec.NamespaceSelector != nil {
+		spec.MatchResources = &admissionregistrationv1.MatchResources{
+			NamespaceSelector: policy.Spec.NamespaceSelector.DeepCopy(),
+		}
+	}
+
+	return spec
+}
+
+// celList returns the CEL list literal of the given strings.
+func celList(items []string) string {
+	quoted := make([]string, 0, len(items))
+
+	for _, item := range items {
+		quoted = append(quoted, fmt.Sprintf("%q", item))
+	}
+
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
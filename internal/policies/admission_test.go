@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package policies
+
+import (
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestValidatingAdmissionPolicySpec(t *testing.T) {
+	policy := &kamajiv1alpha1.KamajiPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "hygiene"},
+		Spec: kamajiv1alpha1.KamajiPolicySpec{
+			AllowedVersions:   []string{"v1.32", "v1.33.1"},
+			AllowedDataStores: []string{"default"},
+			RequiredLabels:    []string{"team", "cost-center"},
+		},
+	}
+
+	spec := ValidatingAdmissionPolicySpec(policy)
+
+	expected := []string{
+		`["v1.32", "v1.33.1"].exists(v, object.spec.kubernetes.version == v || object.spec.kubernetes.version.startsWith(v + '.'))`,
+		`!has(object.spec.dataStore) || object.spec.dataStore == '' || object.spec.dataStore in ["default"]`,
+		`has(object.metadata.labels) && "team" in object.metadata.labels`,
+		`has(object.metadata.labels) && "cost-center" in object.metadata.labels`,
+	}
+
+	if len(spec.Validations) != len(expected) {
+		t.Fatalf("expected %d validations, got %d", len(expected), len(spec.Validations))
+	}
+
+	for i, validation := range spec.Validations {
+		if validation.Expression != expected[i] {
+			t.Errorf("unexpected expression %q, expected %q", validation.Expression, expected[i])
+		}
+	}
+
+	rule := spec.MatchConstraints.ResourceRules[0].Rule
+	if rule.APIVersions[0] != kamajiv1alpha1.GroupVersion.Version || rule.Resources[0] != "tenantcontrolplanes" {
+		t.Errorf("unexpected resource rule %v", rule)
+	}
+}
+
+func TestValidatingAdmissionPolicyBindingSpec(t *testing.T) {
+	policy := &kamajiv1alpha1.KamajiPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "team-a"},
+		Spec: kamajiv1alpha1.KamajiPolicySpec{
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+			AllowedDataStores: []string{"team-a"},
+		},
+	}
+
+	spec := ValidatingAdmissionPolicyBindingSpec(policy)
+
+	if spec.PolicyName != "kamaji-policy-team-a" {
+		t.Errorf("unexpected policy name %s", spec.PolicyName)
+	}
+
+	if len(spec.ValidationActions) != 1 || spec.ValidationActions[0] != admissionregistrationv1.Deny {
+		t.Errorf("expected the Deny validation action by default, got %v", spec.ValidationActions)
+	}
+
+	if spec.MatchResources == nil || spec.MatchResources.NamespaceSelector.MatchLabels["team"] != "a" {
+		t.Errorf("expected the namespace selector to be propagated, got %v", spec.MatchResources)
+	}
+}
@@ -36,6 +36,7 @@ type CoreDNS struct {
 	clusterRole        *rbacv1.ClusterRole
 	clusterRoleBinding *rbacv1.ClusterRoleBinding
 	serviceAccount     *corev1.ServiceAccount
+	workloadStatus     kamajiv1alpha1.AddonWorkloadStatus
 }
 
 func (c *CoreDNS) GetHistogram() prometheus.Histogram {
@@ -150,7 +151,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 
 	reconciliationResult := controllerutil.OperationResultNone
 	// ClusterRoleBinding
-	operationResult, err = c.mutateClusterRoleBinding(ctx, tenantClient)
+	operationResult, err = c.mutateClusterRoleBinding(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ClusterRoleBinding reconciliation failed")
 
@@ -158,7 +159,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// Deployment
-	operationResult, err = c.mutateDeployment(ctx, tenantClient)
+	operationResult, err = c.mutateDeployment(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "Deployment reconciliation failed")
 
@@ -166,7 +167,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// ConfigMap
-	operationResult, err = c.mutateConfigMap(ctx, tenantClient)
+	operationResult, err = c.mutateConfigMap(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ConfigMap reconciliation failed")
 
@@ -174,7 +175,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// Service
-	operationResult, err = c.mutateService(ctx, tenantClient)
+	operationResult, err = c.mutateService(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "Service reconciliation failed")
 
@@ -182,7 +183,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// ClusterRole
-	operationResult, err = c.mutateClusterRole(ctx, tenantClient)
+	operationResult, err = c.mutateClusterRole(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ClusterRole reconciliation failed")
 
@@ -190,7 +191,7 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// ServiceAccount
-	operationResult, err = c.mutateServiceAccount(ctx, tenantClient)
+	operationResult, err = c.mutateServiceAccount(ctx, tenantClient, tcp.Spec.Addons.CoreDNS.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ServiceAccount reconciliation failed")
 
@@ -198,6 +199,15 @@ func (c *CoreDNS) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tenant
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 
+	workload := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: c.deployment.GetName(), Namespace: c.deployment.GetNamespace()}}
+
+	c.workloadStatus, err = addons_utils.GetWorkloadStatus(ctx, tenantClient, workload, tcp.Status.Addons.CoreDNS.AddonWorkloadStatus)
+	if err != nil {
+		logger.Error(err, "Deployment status retrieval failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
 	return reconciliationResult, nil
 }
 
@@ -206,12 +216,13 @@ func (c *CoreDNS) GetName() string {
 }
 
 func (c *CoreDNS) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
-	return tcp.Spec.Addons.CoreDNS != nil && !tcp.Status.Addons.CoreDNS.Enabled
+	return tcp.Spec.Addons.CoreDNS != nil && (!tcp.Status.Addons.CoreDNS.Enabled || addons_utils.WorkloadStatusChanged(c.workloadStatus, tcp.Status.Addons.CoreDNS.AddonWorkloadStatus))
 }
 
 func (c *CoreDNS) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
 	tcp.Status.Addons.CoreDNS.Enabled = tcp.Spec.Addons.CoreDNS != nil
 	tcp.Status.Addons.CoreDNS.LastUpdate = metav1.Now()
+	tcp.Status.Addons.CoreDNS.AddonWorkloadStatus = c.workloadStatus
 
 	return nil
 }
@@ -229,10 +240,21 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 		config.Parameters.CoreDNSOptions.Repository = tcp.Spec.Addons.CoreDNS.ImageRepository
 	}
 
-	if len(tcp.Spec.Addons.CoreDNS.ImageRepository) > 0 {
-		config.Parameters.CoreDNSOptions.Tag = tcp.Spec.Addons.CoreDNS.ImageTag
+	installed, err := tcpClient.AppsV1().Deployments(kubeadm.KubeSystemNamespace).Get(ctx, kubeadm.CoreDNSName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		installed = nil
+	case err != nil:
+		return errors.Wrap(err, "unable to retrieve the installed Deployment")
 	}
 
+	var template *corev1.PodTemplateSpec
+	if installed != nil {
+		template = &installed.Spec.Template
+	}
+
+	config.Parameters.CoreDNSOptions.Tag = addons_utils.ImageTag(*tcp.Spec.Addons.CoreDNS, template, kubeadm.CoreDNSVersion(tcp.RunningKubernetesVersion()))
+
 	manifests, err := kubeadm.AddCoreDNS(tcpClient, config)
 	if err != nil {
 		return errors.Wrap(err, "unable to generate manifests")
@@ -245,6 +267,15 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 	}
 	addons_utils.SetKamajiManagedLabels(c.deployment)
 
+	imageProfile, err := utilities.GetImageProfile(ctx, c.Client, tcp)
+	if err != nil {
+		return err
+	}
+
+	for i, container := range c.deployment.Spec.Template.Spec.Containers {
+		c.deployment.Spec.Template.Spec.Containers[i].Image = imageProfile.Resolve(kamajiv1alpha1.ImageProfileCoreDNS, container.Image)
+	}
+
 	if err = utilities.DecodeFromYAML(string(parts[2]), c.configMap); err != nil {
 		return errors.Wrap(err, "unable to decode ConfigMap manifest")
 	}
@@ -273,7 +304,7 @@ func (c *CoreDNS) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Tenan
 	return nil
 }
 
-func (c *CoreDNS) mutateClusterRoleBinding(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateClusterRoleBinding(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	crb := &rbacv1.ClusterRoleBinding{}
 	crb.SetName(c.clusterRoleBinding.GetName())
 
@@ -281,7 +312,7 @@ func (c *CoreDNS) mutateClusterRoleBinding(ctx context.Context, tenantClient cli
 		c.clusterRoleBinding.SetUID(crb.GetUID())
 	}()
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, crb, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, crb, trait, func() error {
 		crb.SetLabels(utilities.MergeMaps(crb.GetLabels(), c.clusterRoleBinding.GetLabels()))
 		crb.SetAnnotations(utilities.MergeMaps(crb.GetAnnotations(), c.clusterRoleBinding.GetAnnotations()))
 		crb.Subjects = c.clusterRoleBinding.Subjects
@@ -291,12 +322,12 @@ func (c *CoreDNS) mutateClusterRoleBinding(ctx context.Context, tenantClient cli
 	})
 }
 
-func (c *CoreDNS) mutateDeployment(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateDeployment(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	d := &appsv1.Deployment{}
 	d.SetName(c.deployment.GetName())
 	d.SetNamespace(c.deployment.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, d, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, d, trait, func() error {
 		d.SetLabels(utilities.MergeMaps(d.GetLabels(), c.deployment.GetLabels()))
 		d.SetAnnotations(utilities.MergeMaps(d.GetAnnotations(), c.deployment.GetAnnotations()))
 		d.Spec.Replicas = c.deployment.Spec.Replicas
@@ -375,12 +406,12 @@ func (c *CoreDNS) mutateDeployment(ctx context.Context, tenantClient client.Clie
 	})
 }
 
-func (c *CoreDNS) mutateConfigMap(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateConfigMap(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	cm := &corev1.ConfigMap{}
 	cm.SetName(c.configMap.GetName())
 	cm.SetNamespace(c.configMap.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, cm, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, cm, trait, func() error {
 		cm.SetLabels(utilities.MergeMaps(cm.GetLabels(), c.configMap.GetLabels()))
 		cm.SetAnnotations(utilities.MergeMaps(cm.GetAnnotations(), c.configMap.GetAnnotations()))
 		cm.Data = c.configMap.Data
@@ -389,12 +420,12 @@ func (c *CoreDNS) mutateConfigMap(ctx context.Context, tenantClient client.Clien
 	})
 }
 
-func (c *CoreDNS) mutateService(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateService(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	svc := &corev1.Service{}
 	svc.SetName(c.service.GetName())
 	svc.SetNamespace(c.service.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, svc, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, svc, trait, func() error {
 		svc.SetLabels(utilities.MergeMaps(svc.GetLabels(), c.service.GetLabels()))
 		svc.SetAnnotations(utilities.MergeMaps(svc.GetAnnotations(), c.service.GetAnnotations()))
 
@@ -406,12 +437,12 @@ func (c *CoreDNS) mutateService(ctx context.Context, tenantClient client.Client)
 	})
 }
 
-func (c *CoreDNS) mutateClusterRole(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateClusterRole(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	cr := &rbacv1.ClusterRole{}
 	cr.SetName(c.clusterRole.GetName())
 	cr.SetNamespace(c.clusterRole.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, cr, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, cr, trait, func() error {
 		cr.SetLabels(utilities.MergeMaps(cr.GetLabels(), c.clusterRole.GetLabels()))
 		cr.SetAnnotations(utilities.MergeMaps(cr.GetAnnotations(), c.clusterRole.GetAnnotations()))
 		cr.Rules = c.clusterRole.Rules
@@ -420,12 +451,12 @@ func (c *CoreDNS) mutateClusterRole(ctx context.Context, tenantClient client.Cli
 	})
 }
 
-func (c *CoreDNS) mutateServiceAccount(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (c *CoreDNS) mutateServiceAccount(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	sa := &corev1.ServiceAccount{}
 	sa.SetName(c.serviceAccount.GetName())
 	sa.SetNamespace(c.serviceAccount.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, sa, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, sa, trait, func() error {
 		sa.SetLabels(utilities.MergeMaps(sa.GetLabels(), c.serviceAccount.GetLabels()))
 		sa.SetAnnotations(utilities.MergeMaps(sa.GetAnnotations(), c.serviceAccount.GetAnnotations()))
 
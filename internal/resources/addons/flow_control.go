@@ -0,0 +1,233 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+	flowcontrolv1 "k8s.io/api/flowcontrol/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// FlowControl installs in the Tenant Cluster the API Priority and Fairness objects declared for the Tenant Control Plane API server:
+// the objects labelled as managed by Kamaji, and no longer declared, are deleted.
+type FlowControl struct {
+	Client client.Client
+
+	priorityLevels []string
+	flowSchemas    []string
+}
+
+func (f *FlowControl) GetHistogram() prometheus.Histogram {
+	flowControlCollector = resources.LazyLoadHistogramFromResource(flowControlCollector, f)
+
+	return flowControlCollector
+}
+
+func (f *FlowControl) spec(tcp *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.APIServerFlowControlSpec {
+	if tcp.Spec.Kubernetes.APIServer == nil {
+		return nil
+	}
+
+	return tcp.Spec.Kubernetes.APIServer.FlowControl
+}
+
+func (f *FlowControl) Define(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	f.priorityLevels, f.flowSchemas = nil, nil
+
+	spec := f.spec(tcp)
+	if spec == nil {
+		return nil
+	}
+
+	for _, priorityLevel := range spec.PriorityLevels {
+		f.priorityLevels = append(f.priorityLevels, priorityLevel.Name)
+	}
+
+	for _, flowSchema := range spec.FlowSchemas {
+		f.flowSchemas = append(f.flowSchemas, flowSchema.Name)
+	}
+
+	slices.Sort(f.priorityLevels)
+	slices.Sort(f.flowSchemas)
+
+	return nil
+}
+
+func (f *FlowControl) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return f.spec(tcp) == nil && tcp.Status.Addons.FlowControl.Enabled
+}
+
+func (f *FlowControl) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", f.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if _, err = f.prune(ctx, tenantClient); err != nil {
+		logger.Error(err, "cannot delete the flow control objects")
+
+		return false, err
+	}
+	// Returning true in any case, since the status must be cleared also when the objects have been already deleted.
+	return true, nil
+}
+
+func (f *FlowControl) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", f.GetName())
+
+	spec := f.spec(tcp)
+	if spec == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+	// Priority levels are applied first, since they're referenced by the flow schemas.
+	for _, priorityLevel := range spec.PriorityLevels {
+		plc := &flowcontrolv1.PriorityLevelConfiguration{}
+		plc.SetName(priorityLevel.Name)
+
+		operationResult, applyErr := utilities.ServerSideApply(ctx, tenantClient, plc, spec.AddonApplyTrait, func() error {
+			addons_utils.SetKamajiManagedLabels(plc)
+			plc.Spec = priorityLevel.Spec
+
+			return nil
+		})
+		if applyErr != nil {
+			logger.Error(applyErr, "PriorityLevelConfiguration reconciliation failed", "name", priorityLevel.Name)
+
+			return controllerutil.OperationResultNone, applyErr
+		}
+
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+	}
+
+	for _, flowSchema := range spec.FlowSchemas {
+		fs := &flowcontrolv1.FlowSchema{}
+		fs.SetName(flowSchema.Name)
+
+		operationResult, applyErr := utilities.ServerSideApply(ctx, tenantClient, fs, spec.AddonApplyTrait, func() error {
+			addons_utils.SetKamajiManagedLabels(fs)
+			fs.Spec = flowSchema.Spec
+
+			return nil
+		})
+		if applyErr != nil {
+			logger.Error(applyErr, "FlowSchema reconciliation failed", "name", flowSchema.Name)
+
+			return controllerutil.OperationResultNone, applyErr
+		}
+
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+	}
+
+	deleted, err := f.prune(ctx, tenantClient)
+	if err != nil {
+		logger.Error(err, "cannot delete the flow control objects no longer declared")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if deleted {
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, controllerutil.OperationResultUpdated)
+	}
+
+	return reconciliationResult, nil
+}
+
+// prune deletes the flow control objects managed by Kamaji, and no longer declared:
+// the flow schemas are deleted first, since they're referencing the priority levels.
+func (f *FlowControl) prune(ctx context.Context, tenantClient client.Client) (bool, error) {
+	var deleted bool
+
+	selector := client.MatchingLabels{constants.ProjectNameLabelKey: constants.ProjectNameLabelValue}
+
+	var flowSchemas flowcontrolv1.FlowSchemaList
+	if err := tenantClient.List(ctx, &flowSchemas, selector); err != nil {
+		return false, err
+	}
+
+	declaredFlowSchemas := sets.New(f.flowSchemas...)
+
+	for i := range flowSchemas.Items {
+		if declaredFlowSchemas.Has(flowSchemas.Items[i].GetName()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &flowSchemas.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	var priorityLevels flowcontrolv1.PriorityLevelConfigurationList
+	if err := tenantClient.List(ctx, &priorityLevels, selector); err != nil {
+		return false, err
+	}
+
+	declaredPriorityLevels := sets.New(f.priorityLevels...)
+
+	for i := range priorityLevels.Items {
+		if declaredPriorityLevels.Has(priorityLevels.Items[i].GetName()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &priorityLevels.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (f *FlowControl) GetName() string {
+	return "flow-control"
+}
+
+func (f *FlowControl) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Addons.FlowControl
+
+	return status.Enabled != (f.spec(tcp) != nil) ||
+		!slices.Equal(status.PriorityLevels, f.priorityLevels) ||
+		!slices.Equal(status.FlowSchemas, f.flowSchemas)
+}
+
+func (f *FlowControl) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.FlowControl = kamajiv1alpha1.FlowControlStatus{
+		Enabled:        f.spec(tcp) != nil,
+		PriorityLevels: f.priorityLevels,
+		FlowSchemas:    f.flowSchemas,
+		LastUpdate:     metav1.Now(),
+	}
+
+	return nil
+}
@@ -0,0 +1,292 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/kubeadm"
+	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// FrontProxyConfigMapName is the well-known ConfigMap, in the kube-system namespace of the Tenant Cluster,
+	// containing the front-proxy configuration required by the extension API servers.
+	FrontProxyConfigMapName = "kamaji-front-proxy"
+	frontProxyRoleName      = "kamaji:front-proxy-reader"
+
+	frontProxyClientCAKey           = "requestheader-client-ca-file"
+	frontProxyClientCertificateKey  = "proxy-client-cert-file"
+	frontProxyAllowedNamesKey       = "requestheader-allowed-names"
+	frontProxyUsernameHeadersKey    = "requestheader-username-headers"
+	frontProxyGroupHeadersKey       = "requestheader-group-headers"
+	frontProxyExtraHeadersPrefixKey = "requestheader-extra-headers-prefix"
+)
+
+// FrontProxy publishes in the Tenant Cluster the front-proxy configuration used by the kube-aggregator,
+// allowing the extension API servers to trust the requests proxied by the Tenant Control Plane.
+// The front-proxy client private key is never copied to the Tenant Cluster, since it would allow impersonating any user.
+type FrontProxy struct {
+	Client client.Client
+
+	configMap   *corev1.ConfigMap
+	role        *rbacv1.Role
+	roleBinding *rbacv1.RoleBinding
+}
+
+func (f *FrontProxy) GetHistogram() prometheus.Histogram {
+	frontProxyCollector = resources.LazyLoadHistogramFromResource(frontProxyCollector, f)
+
+	return frontProxyCollector
+}
+
+func (f *FrontProxy) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	f.configMap = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      FrontProxyConfigMapName,
+			Namespace: kubeadm.KubeSystemNamespace,
+		},
+	}
+	f.role = &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      frontProxyRoleName,
+			Namespace: kubeadm.KubeSystemNamespace,
+		},
+	}
+	f.roleBinding = &rbacv1.RoleBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      frontProxyRoleName,
+			Namespace: kubeadm.KubeSystemNamespace,
+		},
+	}
+
+	return nil
+}
+
+func (f *FrontProxy) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.FrontProxy == nil && tcp.Status.Addons.FrontProxy.Enabled
+}
+
+func (f *FrontProxy) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", f.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	var deleted bool
+
+	for _, obj := range []client.Object{f.roleBinding, f.role, f.configMap} {
+		if err = tenantClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return false, err
+		}
+		// Don't delete resource if it is not managed by Kamaji
+		if labels := obj.GetLabels(); labels == nil || labels[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+			continue
+		}
+
+		if err = tenantClient.Delete(ctx, obj); err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (f *FrontProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", f.GetName())
+
+	if tcp.Spec.Addons.FrontProxy == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, f.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	data, err := f.configMapData(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot retrieve the front-proxy certificates")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	trait := tcp.Spec.Addons.FrontProxy.AddonApplyTrait
+
+	var operationResult controllerutil.OperationResult
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	operationResult, err = f.mutateConfigMap(ctx, tenantClient, trait, data)
+	if err != nil {
+		logger.Error(err, "ConfigMap reconciliation failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
+	operationResult, err = f.mutateRole(ctx, tenantClient, trait)
+	if err != nil {
+		logger.Error(err, "Role reconciliation failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
+	operationResult, err = f.mutateRoleBinding(ctx, tenantClient, trait)
+	if err != nil {
+		logger.Error(err, "RoleBinding reconciliation failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+
+	return reconciliationResult, nil
+}
+
+func (f *FrontProxy) GetName() string {
+	return "front-proxy"
+}
+
+func (f *FrontProxy) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.FrontProxy != nil && !tcp.Status.Addons.FrontProxy.Enabled
+}
+
+func (f *FrontProxy) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.Addons.FrontProxy.Enabled = tcp.Spec.Addons.FrontProxy != nil
+	tcp.Status.Addons.FrontProxy.LastUpdate = metav1.Now()
+
+	return nil
+}
+
+// configMapData mimics the extension-apiserver-authentication ConfigMap format,
+// adding the front-proxy client certificate used by the kube-aggregator to reach the extension API servers.
+func (f *FrontProxy) configMapData(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (map[string]string, error) {
+	var ca, clientCertificate corev1.Secret
+
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.FrontProxyCA.SecretName}, &ca); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the front-proxy CA")
+	}
+
+	if err := f.Client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: tcp.Status.Certificates.FrontProxyClient.SecretName}, &clientCertificate); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the front-proxy client certificate")
+	}
+
+	data := map[string]string{
+		frontProxyClientCAKey:          string(ca.Data[kubeadmconstants.FrontProxyCACertName]),
+		frontProxyClientCertificateKey: string(clientCertificate.Data[kubeadmconstants.FrontProxyClientCertName]),
+	}
+
+	for key, values := range map[string][]string{
+		frontProxyAllowedNamesKey:       {kubeadmconstants.FrontProxyClientCertCommonName},
+		frontProxyUsernameHeadersKey:    {constants.RequestHeaderUsernameHeaders},
+		frontProxyGroupHeadersKey:       {constants.RequestHeaderGroupHeaders},
+		frontProxyExtraHeadersPrefixKey: {constants.RequestHeaderExtraHeadersPrefix},
+	} {
+		encoded, err := json.Marshal(values)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot encode the front-proxy configuration")
+		}
+
+		data[key] = string(encoded)
+	}
+
+	return data, nil
+}
+
+func (f *FrontProxy) mutateConfigMap(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait, data map[string]string) (controllerutil.OperationResult, error) {
+	cm := &corev1.ConfigMap{}
+	cm.SetName(f.configMap.GetName())
+	cm.SetNamespace(f.configMap.GetNamespace())
+
+	defer func() {
+		f.configMap.SetUID(cm.GetUID())
+	}()
+
+	return utilities.ServerSideApply(ctx, tenantClient, cm, trait, func() error {
+		addons_utils.SetKamajiManagedLabels(cm)
+		cm.Data = data
+
+		return nil
+	})
+}
+
+func (f *FrontProxy) mutateRole(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
+	r := &rbacv1.Role{}
+	r.SetName(f.role.GetName())
+	r.SetNamespace(f.role.GetNamespace())
+
+	return utilities.ServerSideApply(ctx, tenantClient, r, trait, func() error {
+		addons_utils.SetKamajiManagedLabels(r)
+		r.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups:     []string{""},
+				Resources:     []string{"configmaps"},
+				ResourceNames: []string{f.configMap.GetName()},
+				Verbs:         []string{"get", "list", "watch"},
+			},
+		}
+
+		return controllerutil.SetControllerReference(f.configMap, r, tenantClient.Scheme())
+	})
+}
+
+func (f *FrontProxy) mutateRoleBinding(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
+	rb := &rbacv1.RoleBinding{}
+	rb.SetName(f.roleBinding.GetName())
+	rb.SetNamespace(f.roleBinding.GetNamespace())
+
+	return utilities.ServerSideApply(ctx, tenantClient, rb, trait, func() error {
+		addons_utils.SetKamajiManagedLabels(rb)
+		// The ConfigMap contains only public certificates, as the extension-apiserver-authentication one.
+		rb.Subjects = []rbacv1.Subject{
+			{
+				Kind:     rbacv1.GroupKind,
+				APIGroup: rbacv1.GroupName,
+				Name:     "system:authenticated",
+			},
+		}
+		rb.RoleRef = rbacv1.RoleRef{
+			APIGroup: rbacv1.GroupName,
+			Kind:     "Role",
+			Name:     f.role.GetName(),
+		}
+
+		return controllerutil.SetControllerReference(f.configMap, rb, tenantClient.Scheme())
+	})
+}
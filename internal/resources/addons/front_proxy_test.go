@@ -0,0 +1,181 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/kubeadm"
+)
+
+func newFrontProxyTenantControlPlane() *kamajiv1alpha1.TenantControlPlane {
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00"},
+	}
+	tcp.Spec.Addons.FrontProxy = &kamajiv1alpha1.FrontProxySpec{}
+	tcp.Status.Certificates.FrontProxyCA.SecretName = "tenant-00-front-proxy-ca-certificate"
+	tcp.Status.Certificates.FrontProxyClient.SecretName = "tenant-00-front-proxy-client-certificate"
+
+	return tcp
+}
+
+// applyPatch emulates the Server-Side Apply creating the missing objects, since it's not supported by the fake client.
+func applyPatch(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(obj.GetObjectKind().GroupVersionKind())
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+
+		return c.Create(ctx, obj)
+	}
+
+	obj.SetResourceVersion(current.GetResourceVersion())
+
+	return c.Update(ctx, obj)
+}
+
+func TestFrontProxyConfigMapData(t *testing.T) {
+	tcp := newFrontProxyTenantControlPlane()
+
+	c := fake.NewClientBuilder().WithObjects(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: tcp.Status.Certificates.FrontProxyCA.SecretName},
+			Data: map[string][]byte{
+				kubeadmconstants.FrontProxyCACertName: []byte("ca-certificate"),
+				kubeadmconstants.FrontProxyCAKeyName:  []byte("ca-private-key"),
+			},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: tcp.Status.Certificates.FrontProxyClient.SecretName},
+			Data: map[string][]byte{
+				kubeadmconstants.FrontProxyClientCertName: []byte("client-certificate"),
+				kubeadmconstants.FrontProxyClientKeyName:  []byte("client-private-key"),
+			},
+		},
+	).Build()
+
+	data, err := (&FrontProxy{Client: c}).configMapData(context.Background(), tcp)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := map[string]string{
+		frontProxyClientCAKey:           "ca-certificate",
+		frontProxyClientCertificateKey:  "client-certificate",
+		frontProxyAllowedNamesKey:       `["` + kubeadmconstants.FrontProxyClientCertCommonName + `"]`,
+		frontProxyUsernameHeadersKey:    `["` + constants.RequestHeaderUsernameHeaders + `"]`,
+		frontProxyGroupHeadersKey:       `["` + constants.RequestHeaderGroupHeaders + `"]`,
+		frontProxyExtraHeadersPrefixKey: `["` + constants.RequestHeaderExtraHeadersPrefix + `"]`,
+	}
+
+	if len(data) != len(expected) {
+		t.Errorf("expected %d keys, got %d", len(expected), len(data))
+	}
+
+	for key, value := range expected {
+		if data[key] != value {
+			t.Errorf("expected the key %s to be %q, got %q", key, value, data[key])
+		}
+	}
+	// The private keys must never reach the Tenant Cluster.
+	for _, value := range data {
+		if value == "ca-private-key" || value == "client-private-key" {
+			t.Errorf("unexpected private key in the front-proxy ConfigMap")
+		}
+	}
+}
+
+func TestFrontProxyConfigMapDataMissingCertificates(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+
+	if _, err := (&FrontProxy{Client: c}).configMapData(context.Background(), newFrontProxyTenantControlPlane()); err == nil {
+		t.Error("expected an error when the front-proxy certificates are missing")
+	}
+}
+
+func TestFrontProxyRBAC(t *testing.T) {
+	ctx := context.Background()
+	tcp := newFrontProxyTenantControlPlane()
+	tenantClient := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{Patch: applyPatch}).Build()
+
+	f := &FrontProxy{}
+	if err := f.Define(ctx, tcp); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	trait := tcp.Spec.Addons.FrontProxy.AddonApplyTrait
+
+	if _, err := f.mutateConfigMap(ctx, tenantClient, trait, map[string]string{frontProxyClientCAKey: "ca-certificate"}); err != nil {
+		t.Fatalf("cannot apply the ConfigMap: %s", err)
+	}
+
+	if _, err := f.mutateRole(ctx, tenantClient, trait); err != nil {
+		t.Fatalf("cannot apply the Role: %s", err)
+	}
+
+	if _, err := f.mutateRoleBinding(ctx, tenantClient, trait); err != nil {
+		t.Fatalf("cannot apply the RoleBinding: %s", err)
+	}
+
+	var cm corev1.ConfigMap
+	if err := tenantClient.Get(ctx, client.ObjectKey{Namespace: kubeadm.KubeSystemNamespace, Name: FrontProxyConfigMapName}, &cm); err != nil {
+		t.Fatalf("cannot retrieve the ConfigMap: %s", err)
+	}
+
+	if cm.Data[frontProxyClientCAKey] != "ca-certificate" {
+		t.Errorf("unexpected ConfigMap data %v", cm.Data)
+	}
+
+	var role rbacv1.Role
+	if err := tenantClient.Get(ctx, client.ObjectKey{Namespace: kubeadm.KubeSystemNamespace, Name: frontProxyRoleName}, &role); err != nil {
+		t.Fatalf("cannot retrieve the Role: %s", err)
+	}
+
+	if len(role.Rules) != 1 || len(role.Rules[0].ResourceNames) != 1 || role.Rules[0].ResourceNames[0] != FrontProxyConfigMapName {
+		t.Errorf("expected the Role to grant the access to the front-proxy ConfigMap only, got %v", role.Rules)
+	}
+
+	var roleBinding rbacv1.RoleBinding
+	if err := tenantClient.Get(ctx, client.ObjectKey{Namespace: kubeadm.KubeSystemNamespace, Name: frontProxyRoleName}, &roleBinding); err != nil {
+		t.Fatalf("cannot retrieve the RoleBinding: %s", err)
+	}
+
+	if roleBinding.RoleRef.Name != frontProxyRoleName || len(roleBinding.Subjects) != 1 || roleBinding.Subjects[0].Name != "system:authenticated" {
+		t.Errorf("unexpected RoleBinding %v, %v", roleBinding.RoleRef, roleBinding.Subjects)
+	}
+
+	for _, obj := range []client.Object{&cm, &role, &roleBinding} {
+		if obj.GetLabels()[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+			t.Errorf("expected the %s object to be labelled as managed by Kamaji", obj.GetName())
+		}
+	}
+
+	for _, obj := range []client.Object{&role, &roleBinding} {
+		if owners := obj.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != FrontProxyConfigMapName {
+			t.Errorf("expected the %s object to be owned by the front-proxy ConfigMap, got %v", obj.GetName(), owners)
+		}
+	}
+}
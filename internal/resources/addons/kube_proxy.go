@@ -38,6 +38,7 @@ type KubeProxy struct {
 	roleBinding        *rbacv1.RoleBinding
 	configMap          *corev1.ConfigMap
 	daemonSet          *appsv1.DaemonSet
+	workloadStatus     kamajiv1alpha1.AddonWorkloadStatus
 }
 
 func (k *KubeProxy) GetHistogram() prometheus.Histogram {
@@ -152,7 +153,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 
 	reconciliationResult := controllerutil.OperationResultNone
 	// ClusterRoleBinding
-	operationResult, err = k.mutateClusterRoleBinding(ctx, tenantClient)
+	operationResult, err = k.mutateClusterRoleBinding(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ClusterRoleBinding reconciliation failed")
 
@@ -160,7 +161,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// DaemonSet
-	operationResult, err = k.mutateDaemonSet(ctx, tenantClient)
+	operationResult, err = k.mutateDaemonSet(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "DaemonSet reconciliation failed")
 
@@ -168,7 +169,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// ConfigMap
-	operationResult, err = k.mutateConfigMap(ctx, tenantClient)
+	operationResult, err = k.mutateConfigMap(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ConfigMap reconciliation failed")
 
@@ -176,7 +177,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// RoleBinding
-	operationResult, err = k.mutateRoleBinding(ctx, tenantClient)
+	operationResult, err = k.mutateRoleBinding(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "RoleBinding reconciliation failed")
 
@@ -184,7 +185,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// Role
-	operationResult, err = k.mutateRole(ctx, tenantClient)
+	operationResult, err = k.mutateRole(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "Role reconciliation failed")
 
@@ -192,7 +193,7 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 	// ServiceAccount
-	operationResult, err = k.mutateServiceAccount(ctx, tenantClient)
+	operationResult, err = k.mutateServiceAccount(ctx, tenantClient, tcp.Spec.Addons.KubeProxy.AddonApplyTrait)
 	if err != nil {
 		logger.Error(err, "ServiceAccount reconciliation failed")
 
@@ -200,6 +201,15 @@ func (k *KubeProxy) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.Tena
 	}
 	reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
 
+	workload := &appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Name: k.daemonSet.GetName(), Namespace: k.daemonSet.GetNamespace()}}
+
+	k.workloadStatus, err = addon_utils.GetWorkloadStatus(ctx, tenantClient, workload, tcp.Status.Addons.KubeProxy.AddonWorkloadStatus)
+	if err != nil {
+		logger.Error(err, "DaemonSet status retrieval failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
 	return reconciliationResult, nil
 }
 
@@ -208,17 +218,18 @@ func (k *KubeProxy) GetName() string {
 }
 
 func (k *KubeProxy) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
-	return tcp.Spec.Addons.KubeProxy != nil && !tcp.Status.Addons.KubeProxy.Enabled
+	return tcp.Spec.Addons.KubeProxy != nil && (!tcp.Status.Addons.KubeProxy.Enabled || addon_utils.WorkloadStatusChanged(k.workloadStatus, tcp.Status.Addons.KubeProxy.AddonWorkloadStatus))
 }
 
 func (k *KubeProxy) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
 	tcp.Status.Addons.KubeProxy.Enabled = tcp.Spec.Addons.KubeProxy != nil
 	tcp.Status.Addons.KubeProxy.LastUpdate = metav1.Now()
+	tcp.Status.Addons.KubeProxy.AddonWorkloadStatus = k.workloadStatus
 
 	return nil
 }
 
-func (k *KubeProxy) mutateClusterRoleBinding(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateClusterRoleBinding(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	crb := &rbacv1.ClusterRoleBinding{}
 	crb.SetName(k.clusterRoleBinding.GetName())
 
@@ -226,7 +237,7 @@ func (k *KubeProxy) mutateClusterRoleBinding(ctx context.Context, tenantClient c
 		k.clusterRoleBinding.SetUID(crb.GetUID())
 	}()
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, crb, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, crb, trait, func() error {
 		crb.SetLabels(utilities.MergeMaps(crb.GetLabels(), k.clusterRoleBinding.GetLabels()))
 		crb.SetAnnotations(utilities.MergeMaps(crb.GetAnnotations(), k.clusterRoleBinding.GetAnnotations()))
 		crb.Subjects = k.clusterRoleBinding.Subjects
@@ -236,12 +247,12 @@ func (k *KubeProxy) mutateClusterRoleBinding(ctx context.Context, tenantClient c
 	})
 }
 
-func (k *KubeProxy) mutateServiceAccount(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateServiceAccount(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	sa := &corev1.ServiceAccount{}
 	sa.SetName(k.serviceAccount.GetName())
 	sa.SetNamespace(k.serviceAccount.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, sa, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, sa, trait, func() error {
 		sa.SetLabels(utilities.MergeMaps(sa.GetLabels(), k.serviceAccount.GetLabels()))
 		sa.SetAnnotations(utilities.MergeMaps(sa.GetAnnotations(), k.serviceAccount.GetAnnotations()))
 
@@ -249,12 +260,12 @@ func (k *KubeProxy) mutateServiceAccount(ctx context.Context, tenantClient clien
 	})
 }
 
-func (k *KubeProxy) mutateRole(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateRole(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	r := &rbacv1.Role{}
 	r.SetName(k.role.GetName())
 	r.SetNamespace(k.role.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, r, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, r, trait, func() error {
 		r.SetLabels(utilities.MergeMaps(r.GetLabels(), k.role.GetLabels()))
 		r.SetAnnotations(utilities.MergeMaps(r.GetAnnotations(), k.role.GetAnnotations()))
 		r.Rules = k.role.Rules
@@ -263,12 +274,12 @@ func (k *KubeProxy) mutateRole(ctx context.Context, tenantClient client.Client)
 	})
 }
 
-func (k *KubeProxy) mutateRoleBinding(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateRoleBinding(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	rb := &rbacv1.RoleBinding{}
 	rb.SetName(k.roleBinding.GetName())
 	rb.SetNamespace(k.roleBinding.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, rb, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, rb, trait, func() error {
 		rb.SetLabels(utilities.MergeMaps(rb.GetLabels(), k.roleBinding.GetLabels()))
 		rb.SetAnnotations(utilities.MergeMaps(rb.GetAnnotations(), k.roleBinding.GetAnnotations()))
 		if len(rb.Subjects) == 0 {
@@ -283,12 +294,12 @@ func (k *KubeProxy) mutateRoleBinding(ctx context.Context, tenantClient client.C
 	})
 }
 
-func (k *KubeProxy) mutateConfigMap(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateConfigMap(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	cm := &corev1.ConfigMap{}
 	cm.SetName(k.configMap.GetName())
 	cm.SetNamespace(k.configMap.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, cm, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, cm, trait, func() error {
 		cm.SetLabels(utilities.MergeMaps(cm.GetLabels(), k.configMap.GetLabels()))
 		cm.SetAnnotations(utilities.MergeMaps(cm.GetAnnotations(), k.configMap.GetAnnotations()))
 		cm.Data = k.configMap.Data
@@ -297,12 +308,12 @@ func (k *KubeProxy) mutateConfigMap(ctx context.Context, tenantClient client.Cli
 	})
 }
 
-func (k *KubeProxy) mutateDaemonSet(ctx context.Context, tenantClient client.Client) (controllerutil.OperationResult, error) {
+func (k *KubeProxy) mutateDaemonSet(ctx context.Context, tenantClient client.Client, trait kamajiv1alpha1.AddonApplyTrait) (controllerutil.OperationResult, error) {
 	ds := &appsv1.DaemonSet{}
 	ds.SetName(k.daemonSet.GetName())
 	ds.SetNamespace(k.daemonSet.GetNamespace())
 
-	return utilities.CreateOrUpdateWithConflict(ctx, tenantClient, ds, func() error {
+	return utilities.ServerSideApply(ctx, tenantClient, ds, trait, func() error {
 		ds.SetLabels(utilities.MergeMaps(ds.GetLabels(), k.daemonSet.GetLabels()))
 		ds.SetAnnotations(utilities.MergeMaps(ds.GetAnnotations(), k.daemonSet.GetAnnotations()))
 		ds.Spec.Selector = k.daemonSet.Spec.Selector
@@ -389,12 +400,21 @@ func (k *KubeProxy) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Ten
 		config.Parameters.KubeProxyOptions.Repository = "registry.k8s.io"
 	}
 
-	if len(tcp.Spec.Addons.KubeProxy.ImageTag) > 0 {
-		config.Parameters.KubeProxyOptions.Tag = tcp.Spec.Addons.KubeProxy.ImageTag
-	} else {
-		config.Parameters.KubeProxyOptions.Tag = tcp.Spec.Kubernetes.Version
+	installed, err := tcpClient.AppsV1().DaemonSets(kubeadm.KubeSystemNamespace).Get(ctx, kubeadm.KubeProxyName, metav1.GetOptions{})
+	switch {
+	case k8serrors.IsNotFound(err):
+		installed = nil
+	case err != nil:
+		return errors.Wrap(err, "unable to retrieve the installed DaemonSet")
 	}
 
+	var template *corev1.PodTemplateSpec
+	if installed != nil {
+		template = &installed.Spec.Template
+	}
+
+	config.Parameters.KubeProxyOptions.Tag = addon_utils.ImageTag(*tcp.Spec.Addons.KubeProxy, template, tcp.RunningKubernetesVersion())
+
 	manifests, err := kubeadm.AddKubeProxy(tcpClient, config)
 	if err != nil {
 		return errors.Wrap(err, "unable to generate manifests")
@@ -432,5 +452,14 @@ func (k *KubeProxy) decodeManifests(ctx context.Context, tcp *kamajiv1alpha1.Ten
 	}
 	addon_utils.SetKamajiManagedLabels(k.daemonSet)
 
+	imageProfile, err := utilities.GetImageProfile(ctx, k.Client, tcp)
+	if err != nil {
+		return err
+	}
+
+	for i, container := range k.daemonSet.Spec.Template.Spec.Containers {
+		k.daemonSet.Spec.Template.Spec.Containers[i].Image = imageProfile.Resolve(kamajiv1alpha1.ImageProfileKubeProxy, container.Image)
+	}
+
 	return nil
 }
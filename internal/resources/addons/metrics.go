@@ -8,6 +8,11 @@ import (
 )
 
 var (
-	kubeProxyCollector prometheus.Histogram
-	coreDNSCollector   prometheus.Histogram
+	kubeProxyCollector       prometheus.Histogram
+	coreDNSCollector         prometheus.Histogram
+	frontProxyCollector      prometheus.Histogram
+	flowControlCollector     prometheus.Histogram
+	rbacProfilesCollector    prometheus.Histogram
+	signedDiscoveryCollector prometheus.Histogram
+	oidcDiscoveryCollector   prometheus.Histogram
 )
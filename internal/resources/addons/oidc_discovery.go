@@ -0,0 +1,127 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// OIDCDiscoveryBindingName is the name of the Tenant Cluster binding granting the anonymous access to the OpenID discovery documents.
+	OIDCDiscoveryBindingName = "kamaji:oidc-discovery"
+	// oidcDiscoveryClusterRole is the default ClusterRole allowing to retrieve the OpenID discovery documents.
+	oidcDiscoveryClusterRole = "system:service-account-issuer-discovery"
+)
+
+// OIDCDiscovery grants in the Tenant Cluster the anonymous access to the OpenID discovery documents published through the Ingress,
+// since the default binding of the system:service-account-issuer-discovery ClusterRole covers the service accounts only.
+// The admin kubeconfig is used, since the soot user is not allowed to bind the ClusterRole.
+type OIDCDiscovery struct {
+	Client client.Client
+
+	binding *rbacv1.ClusterRoleBinding
+}
+
+func (r *OIDCDiscovery) GetHistogram() prometheus.Histogram {
+	oidcDiscoveryCollector = resources.LazyLoadHistogramFromResource(oidcDiscoveryCollector, r)
+
+	return oidcDiscoveryCollector
+}
+
+func (r *OIDCDiscovery) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	r.binding = &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: OIDCDiscoveryBindingName}}
+
+	return nil
+}
+
+func (r *OIDCDiscovery) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Kubernetes.OIDCDiscovery() == nil && tcp.Status.KubeadmPhase.OIDCDiscovery != nil
+}
+
+func (r *OIDCDiscovery) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if err = tenantClient.Delete(ctx, r.binding); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the OIDC discovery binding")
+
+		return false, err
+	}
+	// Returning true in any case, since the status must be cleared also when the binding has been already deleted.
+	return true, nil
+}
+
+func (r *OIDCDiscovery) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if tcp.Spec.Kubernetes.OIDCDiscovery() == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	operationResult, err := controllerutil.CreateOrUpdate(ctx, tenantClient, r.binding, func() error {
+		addons_utils.SetKamajiManagedLabels(r.binding)
+		// The role reference is immutable, and it's never changed since the binding has been created by Kamaji.
+		r.binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: oidcDiscoveryClusterRole}
+		r.binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: user.AllUnauthenticated}}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "OIDC discovery binding reconciliation failed")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	return operationResult, nil
+}
+
+func (r *OIDCDiscovery) GetName() string {
+	return "oidc-discovery"
+}
+
+func (r *OIDCDiscovery) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return (tcp.Spec.Kubernetes.OIDCDiscovery() == nil) != (tcp.Status.KubeadmPhase.OIDCDiscovery == nil)
+}
+
+func (r *OIDCDiscovery) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.Kubernetes.OIDCDiscovery() == nil {
+		tcp.Status.KubeadmPhase.OIDCDiscovery = nil
+
+		return nil
+	}
+
+	tcp.Status.KubeadmPhase.OIDCDiscovery = &kamajiv1alpha1.ExternalKubernetesObjectStatus{
+		Name:       r.binding.GetName(),
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
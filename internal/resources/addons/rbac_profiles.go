@@ -0,0 +1,296 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const rbacProfileBindingPrefix = "kamaji:rbac-profile:"
+
+// rbacProfileClusterRoles are the default ClusterRoles bound by the RBAC profiles.
+var rbacProfileClusterRoles = map[kamajiv1alpha1.RBACProfileName]string{
+	kamajiv1alpha1.RBACProfileClusterAdmin:   "cluster-admin",
+	kamajiv1alpha1.RBACProfileView:           "view",
+	kamajiv1alpha1.RBACProfileEdit:           "edit",
+	kamajiv1alpha1.RBACProfileNamespaceAdmin: "admin",
+}
+
+// RBACProfiles grants in the Tenant Cluster the RBAC profiles declared for the Tenant Control Plane:
+// the bindings labelled with a profile, and no longer declared, are deleted.
+// The admin kubeconfig is used, since the soot user is not allowed to bind the ClusterRoles of the profiles.
+type RBACProfiles struct {
+	Client client.Client
+
+	profiles []string
+}
+
+func (r *RBACProfiles) GetHistogram() prometheus.Histogram {
+	rbacProfilesCollector = resources.LazyLoadHistogramFromResource(rbacProfilesCollector, r)
+
+	return rbacProfilesCollector
+}
+
+func (r *RBACProfiles) spec(tcp *kamajiv1alpha1.TenantControlPlane) []kamajiv1alpha1.RBACProfile {
+	if tcp.Spec.Bootstrap == nil {
+		return nil
+	}
+
+	return tcp.Spec.Bootstrap.RBACProfiles
+}
+
+func (r *RBACProfiles) Define(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	r.profiles = nil
+
+	for _, profile := range r.spec(tcp) {
+		r.profiles = append(r.profiles, profile.Name)
+	}
+
+	slices.Sort(r.profiles)
+
+	return nil
+}
+
+func (r *RBACProfiles) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return len(r.spec(tcp)) == 0 && len(tcp.Status.KubeadmPhase.RBACProfiles.Profiles) > 0
+}
+
+func (r *RBACProfiles) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	if _, err = r.prune(ctx, tenantClient, nil); err != nil {
+		logger.Error(err, "cannot delete the RBAC profiles bindings")
+
+		return false, err
+	}
+	// Returning true in any case, since the status must be cleared also when the bindings have been already deleted.
+	return true, nil
+}
+
+func (r *RBACProfiles) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	profiles := r.spec(tcp)
+	if len(profiles) == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	reconciliationResult := controllerutil.OperationResultNone
+
+	for _, profile := range profiles {
+		var bindings []func() client.Object
+
+		switch profile.Profile {
+		case kamajiv1alpha1.RBACProfileNamespaceAdmin:
+			for _, namespace := range profile.Namespaces {
+				if err = r.ensureNamespace(ctx, tenantClient, namespace); err != nil {
+					logger.Error(err, "cannot create the RBAC profile namespace", "profile", profile.Name, "namespace", namespace)
+
+					return controllerutil.OperationResultNone, err
+				}
+
+				bindings = append(bindings, func() client.Object {
+					return &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: rbacProfileBindingPrefix + profile.Name, Namespace: namespace}}
+				})
+			}
+		default:
+			bindings = append(bindings, func() client.Object {
+				return &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: rbacProfileBindingPrefix + profile.Name}}
+			})
+		}
+
+		for _, binding := range bindings {
+			operationResult, bindingErr := r.ensureBinding(ctx, tenantClient, binding, profile)
+			if bindingErr != nil {
+				logger.Error(bindingErr, "RBAC profile reconciliation failed", "profile", profile.Name)
+
+				return controllerutil.OperationResultNone, bindingErr
+			}
+
+			reconciliationResult = utils.UpdateOperationResult(reconciliationResult, operationResult)
+		}
+	}
+
+	deleted, err := r.prune(ctx, tenantClient, profiles)
+	if err != nil {
+		logger.Error(err, "cannot delete the RBAC profiles bindings no longer declared")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if deleted {
+		reconciliationResult = utils.UpdateOperationResult(reconciliationResult, controllerutil.OperationResultUpdated)
+	}
+
+	return reconciliationResult, nil
+}
+
+func (r *RBACProfiles) ensureNamespace(ctx context.Context, tenantClient client.Client, name string) error {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+	if err := tenantClient.Create(ctx, namespace); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return err
+	}
+
+	return nil
+}
+
+// ensureBinding reconciles the binding of the given profile: since the role reference is immutable,
+// the binding is created again when the profile of an existing one has been changed.
+func (r *RBACProfiles) ensureBinding(ctx context.Context, tenantClient client.Client, newBinding func() client.Object, profile kamajiv1alpha1.RBACProfile) (controllerutil.OperationResult, error) {
+	roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: rbacProfileClusterRoles[profile.Profile]}
+
+	subjects := make([]rbacv1.Subject, 0, len(profile.Groups))
+	for _, group := range profile.Groups {
+		subjects = append(subjects, rbacv1.Subject{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: group})
+	}
+
+	binding := newBinding()
+
+	switch err := tenantClient.Get(ctx, client.ObjectKeyFromObject(binding), binding); {
+	case k8serrors.IsNotFound(err):
+		break
+	case err != nil:
+		return controllerutil.OperationResultNone, err
+	case bindingRoleRef(binding) != roleRef:
+		if err = tenantClient.Delete(ctx, binding); err != nil && !k8serrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, errors.Wrap(err, fmt.Sprintf("cannot delete the binding %s with a different role", binding.GetName()))
+		}
+
+		binding = newBinding()
+	}
+
+	return controllerutil.CreateOrUpdate(ctx, tenantClient, binding, func() error {
+		addons_utils.SetKamajiManagedLabels(binding)
+		binding.SetLabels(utilities.MergeMaps(binding.GetLabels(), map[string]string{constants.RBACProfileLabelKey: profile.Name}))
+
+		switch b := binding.(type) {
+		case *rbacv1.ClusterRoleBinding:
+			b.RoleRef, b.Subjects = roleRef, subjects
+		case *rbacv1.RoleBinding:
+			b.RoleRef, b.Subjects = roleRef, subjects
+		}
+
+		return nil
+	})
+}
+
+func bindingRoleRef(binding client.Object) rbacv1.RoleRef {
+	switch b := binding.(type) {
+	case *rbacv1.ClusterRoleBinding:
+		return b.RoleRef
+	case *rbacv1.RoleBinding:
+		return b.RoleRef
+	default:
+		return rbacv1.RoleRef{}
+	}
+}
+
+// prune deletes the bindings labelled with an RBAC profile, and no longer declared.
+func (r *RBACProfiles) prune(ctx context.Context, tenantClient client.Client, profiles []kamajiv1alpha1.RBACProfile) (bool, error) {
+	var deleted bool
+
+	clusterBindings, namespacedBindings := sets.New[string](), sets.New[string]()
+
+	for _, profile := range profiles {
+		if profile.Profile != kamajiv1alpha1.RBACProfileNamespaceAdmin {
+			clusterBindings.Insert(rbacProfileBindingPrefix + profile.Name)
+
+			continue
+		}
+
+		for _, namespace := range profile.Namespaces {
+			namespacedBindings.Insert(namespace + "/" + rbacProfileBindingPrefix + profile.Name)
+		}
+	}
+
+	selector := client.HasLabels{constants.RBACProfileLabelKey}
+
+	var clusterRoleBindings rbacv1.ClusterRoleBindingList
+	if err := tenantClient.List(ctx, &clusterRoleBindings, selector); err != nil {
+		return false, err
+	}
+
+	for i := range clusterRoleBindings.Items {
+		if clusterBindings.Has(clusterRoleBindings.Items[i].GetName()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &clusterRoleBindings.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	var roleBindings rbacv1.RoleBindingList
+	if err := tenantClient.List(ctx, &roleBindings, selector); err != nil {
+		return false, err
+	}
+
+	for i := range roleBindings.Items {
+		if namespacedBindings.Has(client.ObjectKeyFromObject(&roleBindings.Items[i]).String()) {
+			continue
+		}
+
+		if err := tenantClient.Delete(ctx, &roleBindings.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return false, err
+		}
+
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (r *RBACProfiles) GetName() string {
+	return "rbac-profiles"
+}
+
+func (r *RBACProfiles) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return !slices.Equal(tcp.Status.KubeadmPhase.RBACProfiles.Profiles, r.profiles)
+}
+
+func (r *RBACProfiles) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	tcp.Status.KubeadmPhase.RBACProfiles = kamajiv1alpha1.RBACProfilesStatus{
+		Profiles:   r.profiles,
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
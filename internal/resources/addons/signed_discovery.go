@@ -0,0 +1,272 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package addons
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	certutil "k8s.io/client-go/util/cert"
+	bootstrapapi "k8s.io/cluster-bootstrap/token/api"
+	bootstraputil "k8s.io/cluster-bootstrap/token/util"
+	"k8s.io/kubernetes/cmd/kubeadm/app/util/pubkeypin"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// SignedDiscoveryTokenKey is the key of the discovery token in the Secret of the management cluster.
+	SignedDiscoveryTokenKey = "token"
+	// SignedDiscoveryCACertHashKey is the key of the CA public key hash in the Secret of the management cluster,
+	// in the format expected by the kubeadm --discovery-token-ca-cert-hash flag.
+	SignedDiscoveryCACertHashKey = "ca-cert-hash"
+)
+
+// SignedDiscovery rotates in the Tenant Cluster the signing-only bootstrap tokens, used by the bootstrapsigner
+// controller to sign the cluster-info ConfigMap: the expired tokens are deleted by the tokencleaner controller.
+// The current token is published, along with the CA public key hash, in a Secret of the Tenant Control Plane namespace.
+type SignedDiscovery struct {
+	Client client.Client
+
+	secretName   string
+	tokenID      string
+	lastRotation metav1.Time
+	requeueAfter time.Duration
+}
+
+func (r *SignedDiscovery) GetHistogram() prometheus.Histogram {
+	signedDiscoveryCollector = resources.LazyLoadHistogramFromResource(signedDiscoveryCollector, r)
+
+	return signedDiscoveryCollector
+}
+
+func (r *SignedDiscovery) spec(tcp *kamajiv1alpha1.TenantControlPlane) *kamajiv1alpha1.SignedDiscoverySpec {
+	if tcp.Spec.Bootstrap == nil || tcp.Spec.Bootstrap.ClusterInfo == nil {
+		return nil
+	}
+
+	return tcp.Spec.Bootstrap.ClusterInfo.SignedDiscovery
+}
+
+// RequeueAfter returns the time left before the next rotation of the discovery token.
+func (r *SignedDiscovery) RequeueAfter() time.Duration {
+	return r.requeueAfter
+}
+
+func (r *SignedDiscovery) Define(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	r.secretName = utilities.AddTenantPrefix("discovery-token", tcp)
+	r.tokenID, r.lastRotation, r.requeueAfter = "", metav1.Time{}, 0
+
+	return nil
+}
+
+func (r *SignedDiscovery) ShouldCleanup(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.spec(tcp) == nil && tcp.Status.KubeadmPhase.SignedDiscovery != nil
+}
+
+func (r *SignedDiscovery) CleanUp(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "addon", r.GetName())
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return false, err
+	}
+
+	tokens, err := r.tokens(ctx, tenantClient)
+	if err != nil {
+		logger.Error(err, "cannot list the discovery tokens")
+
+		return false, err
+	}
+
+	for i := range tokens {
+		if err = tenantClient.Delete(ctx, &tokens[i]); err != nil && !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the discovery token", "name", tokens[i].GetName())
+
+			return false, err
+		}
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: r.secretName, Namespace: tcp.GetNamespace()}}
+	if err = r.Client.Delete(ctx, secret); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the discovery token Secret")
+
+		return false, err
+	}
+	// Returning true in any case, since the status must be cleared also when the tokens have been already deleted.
+	return true, nil
+}
+
+func (r *SignedDiscovery) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "addon", r.GetName())
+
+	spec := r.spec(tcp)
+	if spec == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	tenantClient, err := utilities.GetTenantClient(ctx, r.Client, tcp)
+	if err != nil {
+		logger.Error(err, "cannot generate Tenant client")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	tokens, err := r.tokens(ctx, tenantClient)
+	if err != nil {
+		logger.Error(err, "cannot list the discovery tokens")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	var current *corev1.Secret
+
+	for i := range tokens {
+		if current == nil || tokens[i].CreationTimestamp.After(current.CreationTimestamp.Time) {
+			current = &tokens[i]
+		}
+	}
+
+	period := spec.RotationPeriod.Duration
+
+	if current == nil || time.Since(current.CreationTimestamp.Time) >= period {
+		if current, err = r.issueToken(ctx, tenantClient, period); err != nil {
+			logger.Error(err, "cannot issue the discovery token")
+
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	r.tokenID = string(current.Data[bootstrapapi.BootstrapTokenIDKey])
+	r.lastRotation = current.CreationTimestamp
+	r.requeueAfter = period - time.Since(current.CreationTimestamp.Time)
+
+	caCertHash, err := r.caCertHash(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot compute the CA public key hash")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: r.secretName, Namespace: tcp.GetNamespace()}}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), r.GetName())))
+		secret.Data = map[string][]byte{
+			SignedDiscoveryTokenKey:      []byte(bootstraputil.TokenFromIDAndSecret(r.tokenID, string(current.Data[bootstrapapi.BootstrapTokenSecretKey]))),
+			SignedDiscoveryCACertHashKey: []byte(caCertHash),
+		}
+
+		return ctrl.SetControllerReference(tcp, secret, r.Client.Scheme())
+	})
+}
+
+// tokens returns the discovery tokens issued by Kamaji in the Tenant Cluster.
+func (r *SignedDiscovery) tokens(ctx context.Context, tenantClient client.Client) ([]corev1.Secret, error) {
+	var secrets corev1.SecretList
+	if err := tenantClient.List(ctx, &secrets, client.InNamespace(metav1.NamespaceSystem), client.HasLabels{constants.SignedDiscoveryLabelKey}); err != nil {
+		return nil, err
+	}
+
+	return secrets.Items, nil
+}
+
+// issueToken creates a bootstrap token allowed only to sign the cluster-info ConfigMap,
+// expiring after two rotation periods to let the nodes pick up the next one.
+func (r *SignedDiscovery) issueToken(ctx context.Context, tenantClient client.Client, period time.Duration) (*corev1.Secret, error) {
+	token, err := bootstraputil.GenerateBootstrapToken()
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate the bootstrap token")
+	}
+
+	id, tokenSecret := token[:bootstrapapi.BootstrapTokenIDBytes], token[bootstrapapi.BootstrapTokenIDBytes+1:]
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      bootstraputil.BootstrapTokenSecretName(id),
+			Namespace: metav1.NamespaceSystem,
+			Labels:    map[string]string{constants.SignedDiscoveryLabelKey: id},
+		},
+		Type: bootstrapapi.SecretTypeBootstrapToken,
+		StringData: map[string]string{
+			bootstrapapi.BootstrapTokenIDKey:               id,
+			bootstrapapi.BootstrapTokenSecretKey:           tokenSecret,
+			bootstrapapi.BootstrapTokenDescriptionKey:      "Discovery token issued by Kamaji for the signing of the cluster-info ConfigMap.",
+			bootstrapapi.BootstrapTokenExpirationKey:       time.Now().Add(2 * period).UTC().Format(time.RFC3339),
+			bootstrapapi.BootstrapTokenUsageSigningKey:     "true",
+			bootstrapapi.BootstrapTokenUsageAuthentication: "false",
+		},
+	}
+	addons_utils.SetKamajiManagedLabels(secret)
+
+	if err = tenantClient.Create(ctx, secret); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("cannot create the bootstrap token %s", id))
+	}
+
+	return secret, nil
+}
+
+// caCertHash returns the hash of the Tenant CA public key, used by the nodes to validate the cluster-info ConfigMap.
+func (r *SignedDiscovery) caCertHash(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	kubeconfig, err := utilities.GetTenantKubeconfig(ctx, r.Client, tcp)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot retrieve the Tenant kubeconfig")
+	}
+
+	if len(kubeconfig.Clusters) == 0 {
+		return "", errors.New("the Tenant kubeconfig has no clusters")
+	}
+
+	certificates, err := certutil.ParseCertsPEM(kubeconfig.Clusters[0].Cluster.CertificateAuthorityData)
+	if err != nil {
+		return "", errors.Wrap(err, "cannot parse the Tenant CA")
+	}
+
+	return pubkeypin.Hash(certificates[0]), nil
+}
+
+func (r *SignedDiscovery) GetName() string {
+	return "signed-discovery"
+}
+
+func (r *SignedDiscovery) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.KubeadmPhase.SignedDiscovery
+	if status == nil {
+		return r.tokenID != ""
+	}
+
+	return status.TokenID != r.tokenID || status.SecretName != r.secretName
+}
+
+func (r *SignedDiscovery) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if r.spec(tcp) == nil {
+		tcp.Status.KubeadmPhase.SignedDiscovery = nil
+
+		return nil
+	}
+
+	tcp.Status.KubeadmPhase.SignedDiscovery = &kamajiv1alpha1.SignedDiscoveryStatus{
+		SecretName:   r.secretName,
+		TokenID:      r.tokenID,
+		LastRotation: r.lastRotation,
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// ImageTag returns the addon image tag: the pinned one, the one installed in the Tenant Cluster with the Manual
+// version policy, or the desired one matching the Tenant Control Plane version.
+// The installed template is nil when the addon workload has not been deployed yet.
+func ImageTag(spec kamajiv1alpha1.AddonSpec, installed *corev1.PodTemplateSpec, desired string) string {
+	if len(spec.ImageTag) > 0 {
+		return spec.ImageTag
+	}
+
+	if spec.VersionPolicy == kamajiv1alpha1.AddonVersionPolicyManual && installed != nil && len(installed.Spec.Containers) > 0 {
+		if tag := tagFromImage(installed.Spec.Containers[0].Image); len(tag) > 0 {
+			return tag
+		}
+	}
+
+	return desired
+}
+
+func tagFromImage(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+
+	index := strings.LastIndex(image, ":")
+	if index == -1 || strings.Contains(image[index:], "/") {
+		return ""
+	}
+
+	return image[index+1:]
+}
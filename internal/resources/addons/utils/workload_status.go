@@ -0,0 +1,79 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/images"
+)
+
+// GetWorkloadStatus retrieves the addon workload, a Deployment or a DaemonSet, reporting its image, and its readiness:
+// the Ready condition is set on top of the given current status, preserving its transition time when unchanged.
+func GetWorkloadStatus(ctx context.Context, c client.Client, workload client.Object, current kamajiv1alpha1.AddonWorkloadStatus) (kamajiv1alpha1.AddonWorkloadStatus, error) {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(workload), workload); err != nil {
+		return kamajiv1alpha1.AddonWorkloadStatus{}, errors.Wrap(err, "cannot retrieve the addon workload")
+	}
+
+	status := kamajiv1alpha1.AddonWorkloadStatus{
+		Conditions: append([]metav1.Condition(nil), current.Conditions...),
+	}
+
+	condition := metav1.Condition{
+		Type:   kamajiv1alpha1.ConditionReady,
+		Status: metav1.ConditionFalse,
+		Reason: kamajiv1alpha1.ReasonWorkloadProgressing,
+	}
+
+	var desired, updated, available int32
+
+	switch obj := workload.(type) {
+	case *appsv1.Deployment:
+		if len(obj.Spec.Template.Spec.Containers) > 0 {
+			status.Image = obj.Spec.Template.Spec.Containers[0].Image
+		}
+
+		desired, updated, available = ptr.Deref(obj.Spec.Replicas, 1), obj.Status.UpdatedReplicas, obj.Status.AvailableReplicas
+		if obj.Status.ObservedGeneration < obj.GetGeneration() {
+			updated = 0
+		}
+	case *appsv1.DaemonSet:
+		if len(obj.Spec.Template.Spec.Containers) > 0 {
+			status.Image = obj.Spec.Template.Spec.Containers[0].Image
+		}
+
+		desired, updated, available = obj.Status.DesiredNumberScheduled, obj.Status.UpdatedNumberScheduled, obj.Status.NumberAvailable
+		if obj.Status.ObservedGeneration < obj.GetGeneration() {
+			updated = 0
+		}
+	default:
+		return kamajiv1alpha1.AddonWorkloadStatus{}, fmt.Errorf("unsupported addon workload %T", workload)
+	}
+
+	status.Version = images.Tag(status.Image)
+
+	condition.Message = fmt.Sprintf("%d/%d replicas updated, %d/%d available", updated, desired, available, desired)
+	if updated >= desired && available >= desired {
+		condition.Status, condition.Reason = metav1.ConditionTrue, kamajiv1alpha1.ReasonWorkloadAvailable
+	}
+
+	meta.SetStatusCondition(&status.Conditions, condition)
+
+	return status, nil
+}
+
+// WorkloadStatusChanged returns true when the observed workload status differs from the recorded one.
+func WorkloadStatusChanged(observed, recorded kamajiv1alpha1.AddonWorkloadStatus) bool {
+	return !equality.Semantic.DeepEqual(observed, recorded)
+}
@@ -0,0 +1,226 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// kubeletPort is the default port of the kubelet API, reached by the API server for the logs, exec, and port-forward requests.
+const kubeletPort = 10250
+
+// APIServerEgressPolicyResource restricts the egress traffic of the Tenant Control Plane pods with a NetworkPolicy,
+// allowing only the DNS resolution, the DataStore, the OIDC issuer, the webhooks, and the declared extra rules.
+type APIServerEgressPolicyResource struct {
+	resource  *networkingv1.NetworkPolicy
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+
+	exists bool
+}
+
+func (r *APIServerEgressPolicyResource) GetHistogram() prometheus.Histogram {
+	apiserveregresspolicyCollector = LazyLoadHistogramFromResource(apiserveregresspolicyCollector, r)
+
+	return apiserveregresspolicyCollector
+}
+
+func (r *APIServerEgressPolicyResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+	// The NetworkPolicy has no status counterpart, its existence is checked to remove it once the egress policy is unset.
+	if r.isDeclared(tenantControlPlane) {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &networkingv1.NetworkPolicy{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the API server egress NetworkPolicy")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *APIServerEgressPolicyResource) isDeclared(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Kubernetes.APIServer != nil && tenantControlPlane.Spec.Kubernetes.APIServer.EgressPolicy != nil
+}
+
+func (r *APIServerEgressPolicyResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared(tenantControlPlane) && r.exists
+}
+
+func (r *APIServerEgressPolicyResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func (r *APIServerEgressPolicyResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *APIServerEgressPolicyResource) GetName() string {
+	return "apiserver-egress"
+}
+
+func (r *APIServerEgressPolicyResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *APIServerEgressPolicyResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *APIServerEgressPolicyResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		rules, err := APIServerEgressRules(tenantControlPlane.Spec.Kubernetes.APIServer.EgressPolicy, r.DataStore)
+		if err != nil {
+			return errors.Wrap(err, "cannot render the API server egress rules")
+		}
+
+		if nodeConnectivity := tenantControlPlane.Spec.Kubernetes.NodeConnectivity(); nodeConnectivity != nil && len(nodeConnectivity.NodeCIDRs) > 0 {
+			peers, peersErr := apiServerEgressPeers(nodeConnectivity.NodeCIDRs)
+			if peersErr != nil {
+				return errors.Wrap(peersErr, "cannot render the API server egress rule of the kubelets")
+			}
+
+			rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+				Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(kubeletPort))}},
+				To:    peers,
+			})
+		}
+
+		r.resource.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"kamaji.clastix.io/name":      tenantControlPlane.GetName(),
+					"kamaji.clastix.io/component": "deployment",
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      rules,
+		}
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// APIServerEgressRules returns the NetworkPolicy egress rules of the given policy,
+// the DataStore rule allows the ports of its endpoints.
+func APIServerEgressRules(policy *kamajiv1alpha1.APIServerEgressPolicySpec, dataStore kamajiv1alpha1.DataStore) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	dns := []networkingv1.NetworkPolicyPort{
+		{Protocol: ptr.To(corev1.ProtocolUDP), Port: ptr.To(intstr.FromInt32(53))},
+		{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(53))},
+	}
+
+	rules := []networkingv1.NetworkPolicyEgressRule{{Ports: dns}}
+
+	dataStorePorts := make([]networkingv1.NetworkPolicyPort, 0, len(dataStore.Spec.Endpoints))
+	seen := map[int32]bool{}
+
+	for _, endpoint := range dataStore.Spec.Endpoints {
+		_, stringPort, err := net.SplitHostPort(endpoint)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot parse the DataStore endpoint %s", endpoint))
+		}
+
+		port, err := strconv.ParseInt(stringPort, 10, 32)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot parse the port of the DataStore endpoint %s", endpoint))
+		}
+
+		if seen[int32(port)] {
+			continue
+		}
+
+		seen[int32(port)] = true
+
+		dataStorePorts = append(dataStorePorts, networkingv1.NetworkPolicyPort{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(int32(port)))})
+	}
+
+	if len(dataStorePorts) > 0 {
+		dataStorePeers, err := apiServerEgressPeers(policy.DataStoreCIDRs)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{Ports: dataStorePorts, To: dataStorePeers})
+	}
+
+	if len(policy.OIDCIssuerCIDRs) > 0 {
+		peers, err := apiServerEgressPeers(policy.OIDCIssuerCIDRs)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{
+			Ports: []networkingv1.NetworkPolicyPort{{Protocol: ptr.To(corev1.ProtocolTCP), Port: ptr.To(intstr.FromInt32(443))}},
+			To:    peers,
+		})
+	}
+
+	if len(policy.WebhookCIDRs) > 0 {
+		peers, err := apiServerEgressPeers(policy.WebhookCIDRs)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, networkingv1.NetworkPolicyEgressRule{To: peers})
+	}
+
+	return append(rules, policy.ExtraRules...), nil
+}
+
+func apiServerEgressPeers(cidrs []string) ([]networkingv1.NetworkPolicyPeer, error) {
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(cidrs))
+
+	for _, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("invalid egress CIDR %s", cidr))
+		}
+
+		peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: &networkingv1.IPBlock{CIDR: cidr}})
+	}
+
+	return peers, nil
+}
@@ -0,0 +1,152 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/mutators"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// APIServerNodeConnectivityResource renders the EgressSelectorConfiguration tunnelling the API server connections
+// to the kubelets through the declared HTTP CONNECT proxy, when the node connectivity addons are not enabled.
+type APIServerNodeConnectivityResource struct {
+	resource *corev1.ConfigMap
+	Client   client.Client
+}
+
+func (r *APIServerNodeConnectivityResource) GetHistogram() prometheus.Histogram {
+	apiservernodeconnectivityCollector = LazyLoadHistogramFromResource(apiservernodeconnectivityCollector, r)
+
+	return apiservernodeconnectivityCollector
+}
+
+func (r *APIServerNodeConnectivityResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *APIServerNodeConnectivityResource) isDeclared(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	nodeConnectivity := tenantControlPlane.Spec.Kubernetes.NodeConnectivity()
+
+	return nodeConnectivity != nil && len(nodeConnectivity.HTTPConnectProxy) > 0
+}
+
+func (r *APIServerNodeConnectivityResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared(tenantControlPlane) && tenantControlPlane.Status.APIServerNodeConnectivity != nil
+}
+
+func (r *APIServerNodeConnectivityResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+	// Returning true in any case, since the status must be cleared to remove the configuration from the API server.
+	return true, nil
+}
+
+func (r *APIServerNodeConnectivityResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *APIServerNodeConnectivityResource) GetName() string {
+	return "apiserver-node-connectivity"
+}
+
+func (r *APIServerNodeConnectivityResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.isDeclared(tenantControlPlane) {
+		return tenantControlPlane.Status.APIServerNodeConnectivity != nil
+	}
+
+	return tenantControlPlane.Status.APIServerNodeConnectivity == nil || tenantControlPlane.Status.APIServerNodeConnectivity.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *APIServerNodeConnectivityResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.isDeclared(tenantControlPlane) {
+		tenantControlPlane.Status.APIServerNodeConnectivity = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.APIServerNodeConnectivity = &kamajiv1alpha1.APIServerNodeConnectivityStatus{
+		ConfigMapName: r.resource.GetName(),
+		Checksum:      utilities.GetObjectChecksum(r.resource),
+		LastUpdate:    metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *APIServerNodeConnectivityResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		// The cluster egress selection is covering the connections to the kubelets, and to the Tenant Cluster Services:
+		// the control plane, and etcd, ones are established directly.
+		configuration := &apiserverv1beta1.EgressSelectorConfiguration{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: apiserverv1beta1.SchemeGroupVersion.String(),
+				Kind:       "EgressSelectorConfiguration",
+			},
+			EgressSelections: []apiserverv1beta1.EgressSelection{
+				{
+					Name: "cluster",
+					Connection: apiserverv1beta1.Connection{
+						ProxyProtocol: apiserverv1beta1.ProtocolHTTPConnect,
+						Transport: &apiserverv1beta1.Transport{
+							TCP: &apiserverv1beta1.TCPTransport{
+								URL: tenantControlPlane.Spec.Kubernetes.NodeConnectivity().HTTPConnectProxy,
+							},
+						},
+					},
+				},
+			},
+		}
+
+		content, err := utilities.EncodeToYaml(configuration)
+		if err != nil {
+			return errors.Wrap(err, "cannot encode the API server egress selector configuration")
+		}
+
+		r.resource.Data = map[string]string{
+			constants.APIServerEgressSelectorConfigurationKey: string(content),
+		}
+
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
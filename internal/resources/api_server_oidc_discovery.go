@@ -0,0 +1,163 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// oidcDiscoveryPaths are the API server paths serving the OpenID discovery document, and the JWKS.
+var oidcDiscoveryPaths = []string{"/.well-known/openid-configuration", "/openid/v1/jwks"}
+
+// APIServerOIDCDiscoveryResource publishes the OpenID discovery documents of the service account issuer
+// through an Ingress routing only the discovery paths to the Tenant Control Plane Service.
+type APIServerOIDCDiscoveryResource struct {
+	resource *networkingv1.Ingress
+	Client   client.Client
+}
+
+func (r *APIServerOIDCDiscoveryResource) GetHistogram() prometheus.Histogram {
+	apiserveroidcdiscoveryCollector = LazyLoadHistogramFromResource(apiserveroidcdiscoveryCollector, r)
+
+	return apiserveroidcdiscoveryCollector
+}
+
+func (r *APIServerOIDCDiscoveryResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *APIServerOIDCDiscoveryResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Kubernetes.OIDCDiscovery() == nil && tenantControlPlane.Status.APIServerOIDCDiscovery != nil
+}
+
+func (r *APIServerOIDCDiscoveryResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+	// Returning true in any case, since the status must be cleared to remove the JWKS URI from the API server.
+	return true, nil
+}
+
+func (r *APIServerOIDCDiscoveryResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Kubernetes.OIDCDiscovery() == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *APIServerOIDCDiscoveryResource) GetName() string {
+	return "oidc-discovery"
+}
+
+func (r *APIServerOIDCDiscoveryResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Spec.Kubernetes.OIDCDiscovery() == nil {
+		return tenantControlPlane.Status.APIServerOIDCDiscovery != nil
+	}
+
+	return tenantControlPlane.Status.APIServerOIDCDiscovery == nil || tenantControlPlane.Status.APIServerOIDCDiscovery.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *APIServerOIDCDiscoveryResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	oidcDiscovery := tenantControlPlane.Spec.Kubernetes.OIDCDiscovery()
+	if oidcDiscovery == nil {
+		tenantControlPlane.Status.APIServerOIDCDiscovery = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.APIServerOIDCDiscovery = &kamajiv1alpha1.APIServerOIDCDiscoveryStatus{
+		IngressName: r.resource.GetName(),
+		JWKSURI:     oidcDiscovery.JWKSURI(),
+		Checksum:    utilities.GetObjectChecksum(r.resource),
+		LastUpdate:  metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *APIServerOIDCDiscoveryResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		oidcDiscovery := tenantControlPlane.Spec.Kubernetes.OIDCDiscovery()
+
+		if tenantControlPlane.Status.Kubernetes.Service.Name == "" ||
+			tenantControlPlane.Status.Kubernetes.Service.Port == 0 {
+			return fmt.Errorf("the OIDC discovery ingress cannot be configured yet")
+		}
+
+		labels := utilities.MergeMaps(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()), oidcDiscovery.AdditionalMetadata.Labels)
+		r.resource.SetLabels(labels)
+
+		annotations := utilities.MergeMaps(r.resource.GetAnnotations(), oidcDiscovery.AdditionalMetadata.Annotations)
+		r.resource.SetAnnotations(annotations)
+
+		r.resource.Spec.IngressClassName = nil
+		if oidcDiscovery.IngressClassName != "" {
+			r.resource.Spec.IngressClassName = ptr.To(oidcDiscovery.IngressClassName)
+		}
+
+		backend := networkingv1.IngressBackend{
+			Service: &networkingv1.IngressServiceBackend{
+				Name: tenantControlPlane.Status.Kubernetes.Service.Name,
+				Port: networkingv1.ServiceBackendPort{Number: tenantControlPlane.Status.Kubernetes.Service.Port},
+			},
+		}
+		// The paths are matched exactly, the other API server endpoints must not be reachable through the Ingress.
+		paths := make([]networkingv1.HTTPIngressPath, 0, len(oidcDiscoveryPaths))
+		for _, path := range oidcDiscoveryPaths {
+			paths = append(paths, networkingv1.HTTPIngressPath{
+				Path:     path,
+				PathType: ptr.To(networkingv1.PathTypeExact),
+				Backend:  backend,
+			})
+		}
+
+		r.resource.Spec.Rules = []networkingv1.IngressRule{
+			{
+				Host: oidcDiscovery.Hostname,
+				IngressRuleValue: networkingv1.IngressRuleValue{
+					HTTP: &networkingv1.HTTPIngressRuleValue{Paths: paths},
+				},
+			},
+		}
+
+		r.resource.Spec.TLS = nil
+		if oidcDiscovery.TLSSecretName != "" {
+			r.resource.Spec.TLS = []networkingv1.IngressTLS{
+				{
+					Hosts:      []string{oidcDiscovery.Hostname},
+					SecretName: oidcDiscovery.TLSSecretName,
+				},
+			}
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	apiserverv1beta1 "k8s.io/apiserver/pkg/apis/apiserver/v1beta1"
+	tracingapi "k8s.io/component-base/tracing/api/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/mutators"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// apiServerTracingMinVersion is the first Kubernetes version serving the apiserver.config.k8s.io/v1beta1 TracingConfiguration.
+var apiServerTracingMinVersion = semver.MustParse("1.27.0")
+
+type APIServerTracingResource struct {
+	resource *corev1.ConfigMap
+	Client   client.Client
+}
+
+func (r *APIServerTracingResource) GetHistogram() prometheus.Histogram {
+	apiservertracingCollector = LazyLoadHistogramFromResource(apiservertracingCollector, r)
+
+	return apiservertracingCollector
+}
+
+func (r *APIServerTracingResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *APIServerTracingResource) isDeclared(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Kubernetes.APIServer != nil && tenantControlPlane.Spec.Kubernetes.APIServer.Tracing != nil
+}
+
+func (r *APIServerTracingResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared(tenantControlPlane) && tenantControlPlane.Status.APIServerTracing != nil
+}
+
+func (r *APIServerTracingResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+	// Returning true in any case, since the status must be cleared to remove the configuration from the API server.
+	return true, nil
+}
+
+func (r *APIServerTracingResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *APIServerTracingResource) GetName() string {
+	return "apiserver-tracing"
+}
+
+func (r *APIServerTracingResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.isDeclared(tenantControlPlane) {
+		return tenantControlPlane.Status.APIServerTracing != nil
+	}
+
+	return tenantControlPlane.Status.APIServerTracing == nil || tenantControlPlane.Status.APIServerTracing.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *APIServerTracingResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.isDeclared(tenantControlPlane) {
+		tenantControlPlane.Status.APIServerTracing = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.APIServerTracing = &kamajiv1alpha1.APIServerTracingStatus{
+		ConfigMapName: r.resource.GetName(),
+		Checksum:      utilities.GetObjectChecksum(r.resource),
+		LastUpdate:    metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *APIServerTracingResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		content, err := r.render(tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot render the API server tracing configuration")
+
+			return err
+		}
+
+		r.resource.Data = map[string]string{
+			constants.APIServerTracingConfigurationKey: string(content),
+		}
+
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// render returns the TracingConfiguration file consumed by the API server with the --tracing-config-file flag.
+func (r *APIServerTracingResource) render(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	ver, err := semver.ParseTolerant(tenantControlPlane.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the Kubernetes version")
+	}
+
+	if ver.LT(apiServerTracingMinVersion) {
+		return nil, fmt.Errorf("the API server tracing is not supported for Kubernetes %s", tenantControlPlane.Spec.Kubernetes.Version)
+	}
+
+	tracing := tenantControlPlane.Spec.Kubernetes.APIServer.Tracing
+
+	config := &apiserverv1beta1.TracingConfiguration{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: apiserverv1beta1.SchemeGroupVersion.String(),
+			Kind:       "TracingConfiguration",
+		},
+		TracingConfiguration: tracingapi.TracingConfiguration{
+			Endpoint:               &tracing.Endpoint,
+			SamplingRatePerMillion: tracing.SamplingRatePerMillion,
+		},
+	}
+
+	content, err := utilities.EncodeToYaml(config)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode the API server tracing configuration")
+	}
+
+	return content, nil
+}
@@ -0,0 +1,254 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// CertificateRevocationListKey is the Secret key containing the PEM encoded CRL of the Tenant CA.
+const CertificateRevocationListKey = "ca.crl"
+
+// certificateRevocationListValidity is the interval between the CRL issuing, and its next update:
+// the CRL is issued again once half of its validity has elapsed.
+const certificateRevocationListValidity = 7 * 24 * time.Hour
+
+// CertificateRevocationListResource publishes the Certificate Revocation List of the Tenant CA,
+// listing the certificates revoked by the CertificateRevocation objects referencing the Tenant Control Plane.
+type CertificateRevocationListResource struct {
+	resource    *corev1.Secret
+	revocations []kamajiv1alpha1.CertificateRevocation
+	serials     map[string][]byte
+	checksum    string
+	number      int64
+	nextUpdate  time.Time
+	Client      client.Client
+}
+
+func (r *CertificateRevocationListResource) GetHistogram() prometheus.Histogram {
+	certificaterevocationlistCollector = LazyLoadHistogramFromResource(certificaterevocationlistCollector, r)
+
+	return certificaterevocationlistCollector
+}
+
+func (r *CertificateRevocationListResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	var revocations kamajiv1alpha1.CertificateRevocationList
+	if err := r.Client.List(ctx, &revocations, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingFields{kamajiv1alpha1.CertificateRevocationTenantControlPlaneKey: tenantControlPlane.GetName()}); err != nil {
+		return errors.Wrap(err, "cannot list the certificate revocations")
+	}
+
+	r.revocations, r.serials = nil, map[string][]byte{}
+
+	for _, revocation := range revocations.Items {
+		// The revocations are listed once the controller resolved the certificate serial number.
+		if len(revocation.Status.SerialNumber) == 0 {
+			continue
+		}
+
+		r.revocations = append(r.revocations, revocation)
+		r.serials[revocation.Status.SerialNumber] = []byte(revocation.Spec.Reason)
+	}
+
+	// The CRL must be issued again upon a CA rotation.
+	if len(r.revocations) > 0 {
+		r.serials["ca-checksum"] = []byte(tenantControlPlane.Status.Certificates.CA.Checksum)
+	}
+
+	r.checksum = utilities.CalculateMapChecksum(r.serials)
+
+	return nil
+}
+
+func (r *CertificateRevocationListResource) isDeclared() bool {
+	return len(r.revocations) > 0
+}
+
+func (r *CertificateRevocationListResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared() && tenantControlPlane.Status.Certificates.CRL != nil
+}
+
+func (r *CertificateRevocationListResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+	// Returning true in any case, since the status must be cleared once no certificates are revoked.
+	return true, nil
+}
+
+func (r *CertificateRevocationListResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared() {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *CertificateRevocationListResource) GetName() string {
+	return "crl"
+}
+
+func (r *CertificateRevocationListResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Certificates.CRL
+
+	if !r.isDeclared() {
+		return status != nil
+	}
+
+	return status == nil || status.Checksum != r.checksum || status.Number != r.number
+}
+
+func (r *CertificateRevocationListResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.isDeclared() {
+		tenantControlPlane.Status.Certificates.CRL = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Certificates.CRL = &kamajiv1alpha1.CertificateRevocationListStatus{
+		SecretName: r.resource.GetName(),
+		Checksum:   r.checksum,
+		Number:     r.number,
+		NextUpdate: metav1.NewTime(r.nextUpdate),
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *CertificateRevocationListResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
+
+			return err
+		}
+
+		r.number, r.nextUpdate = 0, time.Time{}
+
+		status := tenantControlPlane.Status.Certificates.CRL
+		if status != nil {
+			r.number, r.nextUpdate = status.Number, status.NextUpdate.Time
+		}
+
+		var shouldCreate bool
+
+		shouldCreate = shouldCreate || status == nil || status.Checksum != r.checksum                 // Revocations changed
+		shouldCreate = shouldCreate || len(r.resource.Data[CertificateRevocationListKey]) == 0        // Missing CRL
+		shouldCreate = shouldCreate || time.Until(r.nextUpdate) < certificateRevocationListValidity/2 // CRL to be refreshed
+		shouldCreate = shouldCreate || utilities.GetObjectChecksum(r.resource) != r.checksum          // CRL not matching the revocations
+
+		if !shouldCreate {
+			return nil
+		}
+
+		crl, err := r.issue(ctx, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot issue the Certificate Revocation List")
+
+			return err
+		}
+
+		r.resource.Data = map[string][]byte{
+			CertificateRevocationListKey: crl,
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.serials)
+
+		return nil
+	}
+}
+
+// issue returns the PEM encoded CRL signed by the Tenant CA, with the next CRL number.
+func (r *CertificateRevocationListResource) issue(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	var caSecret corev1.Secret
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}, &caSecret); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the CA")
+	}
+
+	caCertificate, err := crypto.ParseCertificateBytes(caSecret.Data[kubeadmconstants.CACertName])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the CA certificate")
+	}
+
+	caPrivateKey, err := crypto.ParsePrivateKeyBytes(caSecret.Data[kubeadmconstants.CAKeyName])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot parse the CA private key")
+	}
+
+	// The Tenant CA generated by kubeadm lacks the cRLSign key usage, enforced by the x509 package when issuing:
+	// the CRL is still signed by the CA private key, and verified against its public key.
+	issuer := *caCertificate
+	issuer.KeyUsage |= x509.KeyUsageCRLSign
+
+	entries := make([]x509.RevocationListEntry, 0, len(r.revocations))
+
+	for _, revocation := range r.revocations {
+		serialNumber, ok := new(big.Int).SetString(revocation.Status.SerialNumber, 16)
+		if !ok {
+			return nil, fmt.Errorf("invalid serial number %q of the revocation %s", revocation.Status.SerialNumber, revocation.GetName())
+		}
+
+		entries = append(entries, x509.RevocationListEntry{
+			SerialNumber:   serialNumber,
+			RevocationTime: revocation.Status.RevokedAt.UTC(),
+			ReasonCode:     revocation.Spec.Reason.ReasonCode(),
+		})
+	}
+
+	now := time.Now()
+
+	r.number++
+	r.nextUpdate = now.Add(certificateRevocationListValidity).Truncate(time.Second)
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(r.number),
+		ThisUpdate:                now,
+		NextUpdate:                r.nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, &issuer, caPrivateKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create the Certificate Revocation List")
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), nil
+}
@@ -0,0 +1,253 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+)
+
+func newRevocation(name, serialNumber string, reason kamajiv1alpha1.RevocationReason) *kamajiv1alpha1.CertificateRevocation {
+	revocation := &kamajiv1alpha1.CertificateRevocation{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: name},
+		Spec:       kamajiv1alpha1.CertificateRevocationSpec{TenantControlPlane: "tenant-00", Reason: reason},
+	}
+
+	if len(serialNumber) > 0 {
+		revocation.Status = kamajiv1alpha1.CertificateRevocationStatus{
+			SerialNumber: serialNumber,
+			RevokedAt:    metav1.NewTime(time.Date(2026, time.October, 15, 8, 0, 0, 0, time.UTC)),
+		}
+	}
+
+	return revocation
+}
+
+// crlFixture is a Tenant Control Plane, along with its CA, whose CRL is published by the resource.
+type crlFixture struct {
+	client client.Client
+	tcp    *kamajiv1alpha1.TenantControlPlane
+	ca     *x509.Certificate
+}
+
+func newCRLFixture(t *testing.T, revocations ...client.Object) *crlFixture {
+	t.Helper()
+
+	caCertificate, caPrivateKey, err := crypto.GenerateCACertificatePrivateKeyPair("kubernetes", false)
+	if err != nil {
+		t.Fatalf("cannot generate the CA: %s", err)
+	}
+
+	ca, err := crypto.ParseCertificateBytes(caCertificate.Bytes())
+	if err != nil {
+		t.Fatalf("cannot parse the CA: %s", err)
+	}
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00", UID: "tenant-00-uid"}}
+	tcp.Status.Certificates.CA.SecretName = "tenant-00-ca"
+	tcp.Status.Certificates.CA.Checksum = "ca-checksum"
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00-ca"},
+		Data: map[string][]byte{
+			kubeadmconstants.CACertName: caCertificate.Bytes(),
+			kubeadmconstants.CAKeyName:  caPrivateKey.Bytes(),
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(kamajiv1alpha1.AddToScheme(scheme))
+
+	indexer := &kamajiv1alpha1.CertificateRevocationTenantControlPlane{}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(revocations, caSecret)...).
+		WithIndex(indexer.Object(), indexer.Field(), indexer.ExtractValue()).
+		Build()
+
+	return &crlFixture{client: c, tcp: tcp, ca: ca}
+}
+
+// publish handles the resource as the Tenant Control Plane reconciliation does, returning the published CRL.
+func (f *crlFixture) publish(t *testing.T) (controllerutil.OperationResult, *x509.RevocationList) {
+	t.Helper()
+
+	ctx := context.Background()
+	r := &CertificateRevocationListResource{Client: f.client}
+
+	if err := r.Define(ctx, f.tcp); err != nil {
+		t.Fatalf("cannot define the resource: %s", err)
+	}
+
+	result, err := r.CreateOrUpdate(ctx, f.tcp)
+	if err != nil {
+		t.Fatalf("cannot publish the CRL: %s", err)
+	}
+
+	if r.ShouldStatusBeUpdated(ctx, f.tcp) {
+		if err = r.UpdateTenantControlPlaneStatus(ctx, f.tcp); err != nil {
+			t.Fatalf("cannot update the status: %s", err)
+		}
+	}
+
+	var secret corev1.Secret
+	if err = f.client.Get(ctx, client.ObjectKey{Namespace: "tenants", Name: "tenant-00-crl"}, &secret); err != nil {
+		t.Fatalf("cannot retrieve the CRL Secret: %s", err)
+	}
+
+	block, _ := pem.Decode(secret.Data[CertificateRevocationListKey])
+	if block == nil || block.Type != "X509 CRL" {
+		t.Fatalf("expected a PEM encoded CRL, got %q", secret.Data[CertificateRevocationListKey])
+	}
+
+	crl, err := x509.ParseRevocationList(block.Bytes)
+	if err != nil {
+		t.Fatalf("cannot parse the CRL: %s", err)
+	}
+
+	if !bytes.Equal(crl.RawIssuer, f.ca.RawSubject) {
+		t.Fatalf("expected the CRL to be issued by the Tenant CA, got %s", crl.Issuer)
+	}
+
+	if err = f.ca.CheckSignature(crl.SignatureAlgorithm, crl.RawTBSRevocationList, crl.Signature); err != nil {
+		t.Fatalf("expected the CRL to be signed by the Tenant CA: %s", err)
+	}
+
+	return result, crl
+}
+
+func revokedSerials(crl *x509.RevocationList) map[string]int {
+	serials := map[string]int{}
+
+	for _, entry := range crl.RevokedCertificateEntries {
+		serials[entry.SerialNumber.Text(16)] = entry.ReasonCode
+	}
+
+	return serials
+}
+
+func TestCertificateRevocationList(t *testing.T) {
+	f := newCRLFixture(t,
+		newRevocation("compromised", "1a2b", kamajiv1alpha1.RevocationReasonKeyCompromise),
+		newRevocation("superseded", "ff", kamajiv1alpha1.RevocationReasonSuperseded),
+		newRevocation("unresolved", "", kamajiv1alpha1.RevocationReasonUnspecified),
+	)
+
+	result, crl := f.publish(t)
+	if result != controllerutil.OperationResultCreated {
+		t.Errorf("expected the CRL to be created, got %s", result)
+	}
+
+	if crl.Number.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("expected the CRL number 1, got %s", crl.Number)
+	}
+
+	serials := revokedSerials(crl)
+	if len(serials) != 2 || serials["1a2b"] != 1 || serials["ff"] != 4 {
+		t.Errorf("expected the resolved revocations to be listed with their reason, got %v", serials)
+	}
+
+	if !crl.NextUpdate.Equal(f.tcp.Status.Certificates.CRL.NextUpdate.Time) || time.Until(crl.NextUpdate) < certificateRevocationListValidity-time.Minute {
+		t.Errorf("unexpected next update %s, reported as %s", crl.NextUpdate, f.tcp.Status.Certificates.CRL.NextUpdate)
+	}
+	// The CRL is not issued again until half of its validity has elapsed.
+	if result, crl = f.publish(t); result != controllerutil.OperationResultNone || crl.Number.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("expected the CRL to be left untouched, got %s with the number %s", result, crl.Number)
+	}
+
+	f.tcp.Status.Certificates.CRL.NextUpdate = metav1.NewTime(time.Now().Add(certificateRevocationListValidity/2 - time.Minute))
+
+	if result, crl = f.publish(t); result != controllerutil.OperationResultUpdated || crl.Number.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("expected the CRL to be issued again with the number 2, got %s with the number %s", result, crl.Number)
+	}
+
+	if f.tcp.Status.Certificates.CRL.Number != 2 || time.Until(f.tcp.Status.Certificates.CRL.NextUpdate.Time) < certificateRevocationListValidity-time.Minute {
+		t.Errorf("unexpected CRL status %+v", f.tcp.Status.Certificates.CRL)
+	}
+	// A new revocation issues the CRL again.
+	if err := f.client.Create(context.Background(), newRevocation("ceased", "abc", kamajiv1alpha1.RevocationReasonCessationOfOperation)); err != nil {
+		t.Fatalf("cannot create the revocation: %s", err)
+	}
+
+	if _, crl = f.publish(t); crl.Number.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("expected the CRL number 3, got %s", crl.Number)
+	}
+
+	if serials = revokedSerials(crl); len(serials) != 3 || serials["abc"] != 5 {
+		t.Errorf("expected the new revocation to be listed, got %v", serials)
+	}
+	// A CA rotation issues the CRL again, signed by the new CA.
+	rotated := newCRLFixture(t)
+	if err := f.client.Update(context.Background(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "tenants", Name: "tenant-00-ca"},
+		Data:       caSecretData(t, rotated),
+	}); err != nil {
+		t.Fatalf("cannot rotate the CA: %s", err)
+	}
+
+	f.ca, f.tcp.Status.Certificates.CA.Checksum = rotated.ca, "rotated-ca-checksum"
+
+	if _, crl = f.publish(t); crl.Number.Cmp(big.NewInt(4)) != 0 {
+		t.Errorf("expected the CRL number 4, got %s", crl.Number)
+	}
+}
+
+func caSecretData(t *testing.T, f *crlFixture) map[string][]byte {
+	t.Helper()
+
+	var secret corev1.Secret
+	if err := f.client.Get(context.Background(), client.ObjectKey{Namespace: "tenants", Name: "tenant-00-ca"}, &secret); err != nil {
+		t.Fatalf("cannot retrieve the CA: %s", err)
+	}
+
+	return secret.Data
+}
+
+func TestCertificateRevocationListCleanUp(t *testing.T) {
+	f := newCRLFixture(t, newRevocation("compromised", "1a2b", kamajiv1alpha1.RevocationReasonKeyCompromise))
+	f.publish(t)
+
+	if err := f.client.Delete(context.Background(), newRevocation("compromised", "", "")); err != nil {
+		t.Fatalf("cannot delete the revocation: %s", err)
+	}
+
+	ctx := context.Background()
+	r := &CertificateRevocationListResource{Client: f.client}
+
+	if err := r.Define(ctx, f.tcp); err != nil {
+		t.Fatalf("cannot define the resource: %s", err)
+	}
+
+	if !r.ShouldCleanup(f.tcp) {
+		t.Fatal("expected the CRL to be removed once no certificates are revoked")
+	}
+
+	if _, err := r.CleanUp(ctx, f.tcp); err != nil {
+		t.Fatalf("cannot remove the CRL: %s", err)
+	}
+
+	if err := r.UpdateTenantControlPlaneStatus(ctx, f.tcp); err != nil || f.tcp.Status.Certificates.CRL != nil {
+		t.Errorf("expected the CRL status to be removed, got %+v", f.tcp.Status.Certificates.CRL)
+	}
+}
@@ -31,6 +31,8 @@ type Migrate struct {
 	KamajiServiceName    string
 	ShouldCleanUp        bool
 	MigrateImage         string
+	// WatchdogLeaseDuration makes the Job renew the migration watchdog Lease in the Tenant Cluster, if greater than zero.
+	WatchdogLeaseDuration time.Duration
 
 	actualDatastore  *kamajiv1alpha1.DataStore
 	desiredDatastore *kamajiv1alpha1.DataStore
@@ -124,6 +126,10 @@ func (d *Migrate) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamaji
 			fmt.Sprintf("--target-datastore=%s", tenantControlPlane.Spec.DataStore),
 		}
 
+		if d.WatchdogLeaseDuration > 0 {
+			d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--watchdog-lease-duration=%s", d.WatchdogLeaseDuration.String()))
+		}
+
 		if annotations := tenantControlPlane.GetAnnotations(); annotations != nil {
 			v, _ := strconv.ParseBool(annotations["kamaji.clastix.io/cleanup-prior-migration"])
 			d.job.Spec.Template.Spec.Containers[0].Args = append(d.job.Spec.Template.Spec.Containers[0].Args, fmt.Sprintf("--cleanup-prior-migration=%t", v))
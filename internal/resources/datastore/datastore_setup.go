@@ -5,10 +5,14 @@ package datastore
 
 import (
 	"context"
+	"fmt"
+	"slices"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -145,22 +149,23 @@ func (r *Setup) GetName() string {
 func (r *Setup) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	logger := log.FromContext(ctx, "resource", r.GetName())
 
-	if err := r.revokeGrantPrivileges(ctx, tenantControlPlane); err != nil {
-		logger.Error(err, "unable to revoke privileges")
+	lifecycle := tenantControlPlane.Spec.DataStoreLifecycle
 
-		return err
-	}
-
-	if err := r.deleteDB(ctx, tenantControlPlane); err != nil {
-		logger.Error(err, "unable to delete datastore data")
-
-		return err
-	}
+	switch policy := tenantControlPlane.GetDeletionPolicy(); {
+	case policy != kamajiv1alpha1.DeletionPolicyDelete:
+		logger.Info("retaining the datastore data", "deletionPolicy", policy)
+	case lifecycle != nil && lifecycle.RetainOnDelete != nil && lifecycle.RetainOnDelete.Duration > 0:
+		if err := r.retain(ctx, tenantControlPlane, lifecycle.RetainOnDelete.Duration); err != nil {
+			logger.Error(err, "unable to retain the datastore data")
 
-	if err := r.deleteUser(ctx, tenantControlPlane); err != nil {
-		logger.Error(err, "unable to delete user")
+			return err
+		}
 
-		return err
+		logger.Info("retaining the datastore data until the expiration", "retainOnDelete", lifecycle.RetainOnDelete.Duration.String())
+	default:
+		if err := r.wipe(ctx, tenantControlPlane); err != nil {
+			return err
+		}
 	}
 
 	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
@@ -180,81 +185,143 @@ func (r *Setup) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 	return nil
 }
 
-func (r *Setup) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
-	tenantControlPlane.Status.Storage.Setup.Schema = r.resource.schema
-	tenantControlPlane.Status.Storage.Setup.User = r.resource.user
-	tenantControlPlane.Status.Storage.Setup.LastUpdate = metav1.Now()
-	tenantControlPlane.Status.Storage.Setup.Checksum = tenantControlPlane.Status.Storage.Config.Checksum
+// wipe revokes the privileges, and deletes the datastore data, and user, of the given Tenant Control Plane.
+func (r *Setup) wipe(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
+	if err := Wipe(ctx, r.Connection, r.resource.schema, r.resource.user); err != nil {
+		log.FromContext(ctx, "resource", r.GetName()).Error(err, "unable to wipe the datastore data")
+
+		return err
+	}
 
 	return nil
 }
 
-func (r *Setup) createDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	exists, err := r.Connection.DBExists(ctx, r.resource.schema)
-	if err != nil {
-		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if datastore exists")
-	}
+// retain records the DataStore contents of the given Tenant Control Plane in the DataStore status,
+// delaying their wipe until the expiration of the retainOnDelete window: the DataStore credentials
+// are orphaned, allowing the undeletion of the Tenant Control Plane with the same name.
+func (r *Setup) retain(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ttl time.Duration) error {
+	secretName := tenantControlPlane.Status.Storage.Config.SecretName
 
-	if exists {
-		return controllerutil.OperationResultNone, nil
-	}
+	if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var secret corev1.Secret
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: secretName}, &secret); err != nil {
+			return err
+		}
 
-	if err := r.Connection.CreateDB(ctx, r.resource.schema); err != nil {
-		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the datastore")
+		secret.SetOwnerReferences(slices.DeleteFunc(secret.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+			return ref.UID == tenantControlPlane.GetUID()
+		}))
+
+		return r.Client.Update(ctx, &secret)
+	}); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot orphan the DataStore Configuration secret")
 	}
 
-	return controllerutil.OperationResultCreated, nil
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var ds kamajiv1alpha1.DataStore
+		if err := r.Client.Get(ctx, types.NamespacedName{Name: tenantControlPlane.Status.Storage.DataStoreName}, &ds); err != nil {
+			return errors.Wrap(err, "cannot retrieve the DataStore")
+		}
+
+		ds.Status.RetainTenant(kamajiv1alpha1.DataStoreRetainedTenant{
+			TenantControlPlane: client.ObjectKeyFromObject(tenantControlPlane).String(),
+			Schema:             r.resource.schema,
+			User:               r.resource.user,
+			ConfigSecretName:   secretName,
+			ExpiresAt:          metav1.NewTime(time.Now().Add(ttl)),
+		})
+
+		return r.Client.Status().Update(ctx, &ds)
+	})
 }
 
-func (r *Setup) deleteDB(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
-	exists, err := r.Connection.DBExists(ctx, r.resource.schema)
+// Wipe revokes the privileges, and deletes the datastore data, and user, skipping the missing ones.
+func Wipe(ctx context.Context, connection datastore.Connection, schema, user string) error {
+	exists, err := connection.GrantPrivilegesExists(ctx, user, schema)
 	if err != nil {
+		return errors.Wrap(err, "unable to check if privileges exist")
+	}
+
+	if exists {
+		if err = connection.RevokePrivileges(ctx, user, schema); err != nil {
+			return errors.Wrap(err, "unable to revoke privileges")
+		}
+	}
+
+	if exists, err = connection.DBExists(ctx, schema); err != nil {
 		return errors.Wrap(err, "unable to check if datastore exists")
 	}
 
-	if !exists {
-		return nil
+	if exists {
+		if err = connection.DeleteDB(ctx, schema); err != nil {
+			return errors.Wrap(err, "unable to delete the datastore")
+		}
 	}
 
-	if err := r.Connection.DeleteDB(ctx, r.resource.schema); err != nil {
-		return errors.Wrap(err, "unable to delete the datastore")
+	if exists, err = connection.UserExists(ctx, user); err != nil {
+		return errors.Wrap(err, "unable to check if user exists")
+	}
+
+	if exists {
+		if err = connection.DeleteUser(ctx, user); err != nil {
+			return errors.Wrap(err, "unable to remove the user")
+		}
 	}
 
 	return nil
 }
 
-func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	exists, err := r.Connection.UserExists(ctx, r.resource.user)
+func (r *Setup) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.Storage.Setup.Schema = r.resource.schema
+	tenantControlPlane.Status.Storage.Setup.User = r.resource.user
+	tenantControlPlane.Status.Storage.Setup.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.Storage.Setup.Checksum = tenantControlPlane.Status.Storage.Config.Checksum
+
+	return nil
+}
+
+func (r *Setup) createDB(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	exists, err := r.Connection.DBExists(ctx, r.resource.schema)
 	if err != nil {
-		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if user exists")
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if datastore exists")
 	}
+	// The adoption is performed once, upon the first setup: an empty schema would bootstrap a new cluster.
+	adopting := tenantControlPlane.AdoptsDataStore() && len(tenantControlPlane.Status.Storage.Setup.Schema) == 0
 
 	if exists {
+		if adopting {
+			log.FromContext(ctx, "resource", r.GetName()).Info("adopting the existing datastore data", "schema", r.resource.schema)
+		}
+
 		return controllerutil.OperationResultNone, nil
 	}
 
-	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
-		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the user")
+	if adopting {
+		return controllerutil.OperationResultNone, fmt.Errorf("the datastore %s to adopt does not exist", r.resource.schema)
+	}
+
+	if err := r.Connection.CreateDB(ctx, r.resource.schema); err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the datastore")
 	}
 
 	return controllerutil.OperationResultCreated, nil
 }
 
-func (r *Setup) deleteUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
+func (r *Setup) createUser(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	exists, err := r.Connection.UserExists(ctx, r.resource.user)
 	if err != nil {
-		return errors.Wrap(err, "unable to check if user exists")
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to check if user exists")
 	}
 
-	if !exists {
-		return nil
+	if exists {
+		return controllerutil.OperationResultNone, nil
 	}
 
-	if err := r.Connection.DeleteUser(ctx, r.resource.user); err != nil {
-		return errors.Wrap(err, "unable to remove the user")
+	if err := r.Connection.CreateUser(ctx, r.resource.user, r.resource.password); err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "unable to create the user")
 	}
 
-	return nil
+	return controllerutil.OperationResultCreated, nil
 }
 
 func (r *Setup) createGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
@@ -273,20 +340,3 @@ func (r *Setup) createGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.Ten
 
 	return controllerutil.OperationResultCreated, nil
 }
-
-func (r *Setup) revokeGrantPrivileges(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
-	exists, err := r.Connection.GrantPrivilegesExists(ctx, r.resource.user, r.resource.schema)
-	if err != nil {
-		return errors.Wrap(err, "unable to check if privileges exist")
-	}
-
-	if !exists {
-		return nil
-	}
-
-	if err := r.Connection.RevokePrivileges(ctx, r.resource.user, r.resource.schema); err != nil {
-		return errors.Wrap(err, "unable to revoke privileges")
-	}
-
-	return nil
-}
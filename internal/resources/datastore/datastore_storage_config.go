@@ -15,7 +15,6 @@ import (
 	kubeerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -139,9 +138,8 @@ func (r *Config) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.
 			return []byte(strings.ReplaceAll(fmt.Sprintf("%s_%s", tenantControlPlane.GetNamespace(), tenantControlPlane.GetName()), "-", "_"))
 		}
 
-		finalizersList := sets.New[string](r.resource.GetFinalizers()...)
-		finalizersList.Insert(finalizers.DatastoreSecretFinalizer)
-		r.resource.SetFinalizers(finalizersList.UnsortedList())
+		// Preserving the order of the existing finalizers, a random one would update the Secret upon each reconciliation.
+		controllerutil.AddFinalizer(r.resource, finalizers.DatastoreSecretFinalizer)
 
 		// TODO(thecodeassassin): remove this after multi-tenancy is implemented for NATS.
 		// Due to NATS is missing a programmatic approach to create users and password,
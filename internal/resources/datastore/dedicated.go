@@ -0,0 +1,576 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/resources/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	dedicatedClientPort  = 2379
+	dedicatedPeerPort    = 2380
+	dedicatedMetricsPort = 2381
+
+	dedicatedDataDir         = "/var/lib/etcd"
+	dedicatedCertificatesDir = "/etc/etcd/pki"
+	// dedicatedRootUser is the etcd user of the client certificate used by Kamaji.
+	dedicatedRootUser = "root"
+)
+
+// Dedicated provisions the etcd cluster backing a single Tenant Control Plane, along with the DataStore pointing to it.
+// The membership is changed one member at a time: the new members join the cluster as learners, promoted once in sync
+// with the leader, and the voting members not ready for a while are replaced, as long as the quorum is preserved.
+type Dedicated struct {
+	Client client.Client
+
+	// members maps the ordinal of the StatefulSet Pods to the etcd membership, true for the learners.
+	members map[int]bool
+}
+
+func (r *Dedicated) GetHistogram() prometheus.Histogram {
+	dedicatedCollector = resources.LazyLoadHistogramFromResource(dedicatedCollector, r)
+
+	return dedicatedCollector
+}
+
+func (r *Dedicated) GetName() string {
+	return "dedicated-datastore"
+}
+
+func (r *Dedicated) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *Dedicated) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *Dedicated) Define(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	r.members = map[int]bool{}
+
+	if status := tcp.Status.Storage.Dedicated; status != nil {
+		for _, member := range status.Members {
+			r.members[dedicatedOrdinal(member)] = false
+		}
+
+		for _, learner := range status.Learners {
+			r.members[dedicatedOrdinal(learner)] = true
+		}
+	}
+
+	return nil
+}
+
+func (r *Dedicated) CreateOrUpdate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if tcp.Spec.DedicatedDataStore == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	certificates, certificatesResult, err := r.ensureCertificates(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot reconcile the etcd certificates")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	serviceResult, err := r.ensureService(ctx, tcp)
+	if err != nil {
+		logger.Error(err, "cannot reconcile the etcd headless service")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	statefulSet := &appsv1.StatefulSet{}
+	if err = r.Client.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: DedicatedName(tcp)}, statefulSet); err != nil && !k8serrors.IsNotFound(err) {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot retrieve the etcd StatefulSet")
+	}
+
+	var pending bool
+	// The membership can be reconciled only once, at least, a member is serving the clients.
+	if statefulSet.Status.ReadyReplicas > 0 {
+		if pending, err = r.reconcileMembers(ctx, tcp, certificates); err != nil {
+			logger.Error(err, "cannot reconcile the etcd members")
+
+			return controllerutil.OperationResultNone, err
+		}
+	} else {
+		pending = true
+	}
+
+	statefulSetResult, err := r.ensureStatefulSet(ctx, tcp, statefulSet)
+	if err != nil {
+		logger.Error(err, "cannot reconcile the etcd StatefulSet")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	dataStoreResult, err := r.ensureDataStore(ctx, tcp, certificates)
+	if err != nil {
+		logger.Error(err, "cannot reconcile the dedicated DataStore")
+
+		return controllerutil.OperationResultNone, err
+	}
+
+	if pending || statefulSet.Status.ReadyReplicas < ptr.Deref(statefulSet.Spec.Replicas, 0) {
+		return resources.OperationResultEnqueueBack, nil
+	}
+
+	result := utils.UpdateOperationResult(certificatesResult, serviceResult)
+	result = utils.UpdateOperationResult(result, statefulSetResult)
+
+	return utils.UpdateOperationResult(result, dataStoreResult), nil
+}
+
+func (r *Dedicated) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tcp.Status.Storage.Dedicated
+	if status == nil {
+		return true
+	}
+
+	members, learners := r.memberNames(tcp)
+
+	return !slices.Equal(status.Members, members) || !slices.Equal(status.Learners, learners)
+}
+
+func (r *Dedicated) UpdateTenantControlPlaneStatus(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Status.Storage.Dedicated == nil {
+		tcp.Status.Storage.Dedicated = &kamajiv1alpha1.DedicatedDataStoreStatus{}
+	}
+	// The snapshots are reported by their own controller, thus they must not be overwritten.
+	tcp.Status.Storage.Dedicated.Members, tcp.Status.Storage.Dedicated.Learners = r.memberNames(tcp)
+
+	return nil
+}
+
+// Delete deletes the persistent volume claims of the snapshots, since not owned by the Tenant Control Plane:
+// the ones of the members are deleted by the StatefulSet controller, according to its retention policy.
+func (r *Dedicated) Delete(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if err := r.Client.DeleteAllOf(ctx, &corev1.PersistentVolumeClaim{}, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels(DedicatedSnapshotLabels(tcp))); err != nil {
+		return errors.Wrap(err, "cannot delete the etcd snapshots")
+	}
+
+	return nil
+}
+
+// memberNames returns the sorted names of the voting members, and of the learners.
+func (r *Dedicated) memberNames(tcp *kamajiv1alpha1.TenantControlPlane) (members []string, learners []string) {
+	for _, ordinal := range r.ordinals() {
+		name := dedicatedMemberName(tcp, ordinal)
+
+		if r.members[ordinal] {
+			learners = append(learners, name)
+
+			continue
+		}
+
+		members = append(members, name)
+	}
+
+	return members, learners
+}
+
+// ordinals returns the sorted ordinals of the members, including the learners.
+func (r *Dedicated) ordinals() []int {
+	ordinals := make([]int, 0, len(r.members))
+
+	for ordinal := range r.members {
+		ordinals = append(ordinals, ordinal)
+	}
+
+	slices.Sort(ordinals)
+
+	return ordinals
+}
+
+// bootstrapOrdinals returns the ordinals of the members, or the desired ones when the cluster has not been bootstrapped yet.
+func (r *Dedicated) bootstrapOrdinals(tcp *kamajiv1alpha1.TenantControlPlane) []int {
+	if len(r.members) > 0 {
+		return r.ordinals()
+	}
+
+	ordinals := make([]int, 0, tcp.Spec.DedicatedDataStore.Replicas)
+
+	for ordinal := range int(tcp.Spec.DedicatedDataStore.Replicas) {
+		ordinals = append(ordinals, ordinal)
+	}
+
+	return ordinals
+}
+
+func (r *Dedicated) ensureCertificates(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (*corev1.Secret, controllerutil.OperationResult, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DedicatedCertificatesName(tcp),
+			Namespace: tcp.GetNamespace(),
+		},
+	}
+
+	result, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, secret, func() error {
+		secret.SetLabels(utilities.MergeMaps(secret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), r.GetName())))
+		// Generating a new CA invalidates all the certificates signed by the previous one.
+		if valid, _ := crypto.CheckCertificateAndPrivateKeyPairValidity(secret.Data["ca.crt"], secret.Data["ca.key"]); !valid {
+			crt, key, err := crypto.GenerateCACertificatePrivateKeyPair("etcd-ca", tcp.IsFIPS())
+			if err != nil {
+				return errors.Wrap(err, "cannot generate the etcd CA")
+			}
+
+			secret.Data = map[string][]byte{
+				"ca.crt": crt.Bytes(),
+				"ca.key": key.Bytes(),
+			}
+		}
+
+		service := DedicatedName(tcp)
+		namespace := tcp.GetNamespace()
+
+		server := crypto.NewCertificateTemplate(service)
+		server.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+		server.DNSNames = []string{
+			"localhost",
+			service,
+			fmt.Sprintf("%s.%s", service, namespace),
+			fmt.Sprintf("%s.%s.svc", service, namespace),
+			fmt.Sprintf("%s.%s.svc.cluster.local", service, namespace),
+			fmt.Sprintf("*.%s.%s.svc", service, namespace),
+			fmt.Sprintf("*.%s.%s.svc.cluster.local", service, namespace),
+		}
+		// The server certificate is used for the peer communication too.
+		if err := r.ensureCertificate(secret, "server", server, append(server.DNSNames, "127.0.0.1"), tcp.IsFIPS()); err != nil {
+			return err
+		}
+		// The root certificate authenticates Kamaji, and the snapshots Jobs, as the etcd root user.
+		if err := r.ensureCertificate(secret, dedicatedRootUser, crypto.NewCertificateTemplate(dedicatedRootUser), nil, tcp.IsFIPS()); err != nil {
+			return err
+		}
+
+		return ctrl.SetControllerReference(tcp, secret, r.Client.Scheme())
+	})
+	if err != nil {
+		return nil, controllerutil.OperationResultNone, err
+	}
+
+	return secret, result, nil
+}
+
+func (r *Dedicated) ensureCertificate(secret *corev1.Secret, name string, template *x509.Certificate, entries []string, fipsMode bool) error {
+	crtKey, keyKey := name+".crt", name+".key"
+
+	valid, _ := crypto.CheckCertificateAndPrivateKeyPairValidity(secret.Data[crtKey], secret.Data[keyKey])
+	if valid && len(entries) > 0 {
+		valid, _ = crypto.CheckCertificateNamesAndIPs(secret.Data[crtKey], entries)
+	}
+
+	if valid {
+		if valid, _ = crypto.VerifyCertificate(secret.Data[crtKey], secret.Data["ca.crt"], x509.ExtKeyUsageClientAuth); valid {
+			return nil
+		}
+	}
+
+	crt, key, err := crypto.GenerateCertificatePrivateKeyPair(template, secret.Data["ca.crt"], secret.Data["ca.key"], fipsMode)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("cannot generate the etcd %s certificate", name))
+	}
+
+	secret.Data[crtKey], secret.Data[keyKey] = crt.Bytes(), key.Bytes()
+
+	return nil
+}
+
+func (r *Dedicated) ensureService(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DedicatedName(tcp),
+			Namespace: tcp.GetNamespace(),
+		},
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, service, func() error {
+		labels := utilities.KamajiLabels(tcp.GetName(), r.GetName())
+
+		service.SetLabels(utilities.MergeMaps(service.GetLabels(), labels))
+		service.Spec.ClusterIP = corev1.ClusterIPNone
+		// The members must be resolved by their peers before being ready, upon the bootstrap.
+		service.Spec.PublishNotReadyAddresses = true
+		service.Spec.Selector = labels
+		service.Spec.Ports = []corev1.ServicePort{
+			{Name: "client", Port: dedicatedClientPort, TargetPort: intstr.FromInt32(dedicatedClientPort), Protocol: corev1.ProtocolTCP},
+			{Name: "peer", Port: dedicatedPeerPort, TargetPort: intstr.FromInt32(dedicatedPeerPort), Protocol: corev1.ProtocolTCP},
+		}
+
+		return ctrl.SetControllerReference(tcp, service, r.Client.Scheme())
+	})
+}
+
+func (r *Dedicated) ensureStatefulSet(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, statefulSet *appsv1.StatefulSet) (controllerutil.OperationResult, error) {
+	statefulSet.SetName(DedicatedName(tcp))
+	statefulSet.SetNamespace(tcp.GetNamespace())
+
+	spec := tcp.Spec.DedicatedDataStore
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, statefulSet, func() error {
+		labels := utilities.KamajiLabels(tcp.GetName(), r.GetName())
+
+		statefulSet.SetLabels(utilities.MergeMaps(statefulSet.GetLabels(), labels))
+		// The selector, the pod management policy, and the volume claim templates, are immutable.
+		if statefulSet.GetResourceVersion() == "" {
+			statefulSet.Spec.ServiceName = DedicatedName(tcp)
+			statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: labels}
+			statefulSet.Spec.PodManagementPolicy = appsv1.ParallelPodManagement
+			statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						StorageClassName: spec.StorageClassName,
+						Resources: corev1.VolumeResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceStorage: spec.StorageSize},
+						},
+					},
+				},
+			}
+		}
+
+		ordinals := r.bootstrapOrdinals(tcp)
+		// Scaling down removes the Pods with the highest ordinals: the replicas cover the highest member,
+		// and the missing ordinals are not members yet, thus they're not started.
+		statefulSet.Spec.Replicas = ptr.To(int32(ordinals[len(ordinals)-1] + 1))
+
+		whenDeleted := appsv1.RetainPersistentVolumeClaimRetentionPolicyType
+		if tcp.GetDeletionPolicy() == kamajiv1alpha1.DeletionPolicyDelete {
+			whenDeleted = appsv1.DeletePersistentVolumeClaimRetentionPolicyType
+		}
+
+		statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+			WhenDeleted: whenDeleted,
+			WhenScaled:  appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+		}
+
+		statefulSet.Spec.Template.SetLabels(utilities.MergeMaps(statefulSet.Spec.Template.GetLabels(), labels))
+
+		podSpec := &statefulSet.Spec.Template.Spec
+		podSpec.Affinity = &corev1.Affinity{
+			PodAntiAffinity: &corev1.PodAntiAffinity{
+				PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{
+					{
+						Weight: 100,
+						PodAffinityTerm: corev1.PodAffinityTerm{
+							LabelSelector: &metav1.LabelSelector{MatchLabels: labels},
+							TopologyKey:   corev1.LabelHostname,
+						},
+					},
+				},
+			},
+		}
+		podSpec.Volumes = []corev1.Volume{
+			{
+				Name: "certs",
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName: DedicatedCertificatesName(tcp),
+						Items: []corev1.KeyToPath{
+							{Key: "ca.crt", Path: "ca.crt"},
+							{Key: "server.crt", Path: "server.crt"},
+							{Key: "server.key", Path: "server.key"},
+						},
+					},
+				},
+			},
+		}
+
+		found, index := utilities.HasNamedContainer(podSpec.Containers, "etcd")
+		if !found {
+			index = len(podSpec.Containers)
+			podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+		}
+
+		container := &podSpec.Containers[index]
+		container.Name = "etcd"
+		container.Image = spec.Image
+		container.Command = append([]string{"etcd"}, r.args(tcp, ordinals)...)
+		container.Env = []corev1.EnvVar{
+			{
+				Name:      "POD_NAME",
+				ValueFrom: &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+			},
+		}
+		container.Ports = []corev1.ContainerPort{
+			{Name: "client", ContainerPort: dedicatedClientPort, Protocol: corev1.ProtocolTCP},
+			{Name: "peer", ContainerPort: dedicatedPeerPort, Protocol: corev1.ProtocolTCP},
+			{Name: "metrics", ContainerPort: dedicatedMetricsPort, Protocol: corev1.ProtocolTCP},
+		}
+		container.VolumeMounts = []corev1.VolumeMount{
+			{Name: "data", MountPath: dedicatedDataDir},
+			{Name: "certs", MountPath: dedicatedCertificatesDir, ReadOnly: true},
+		}
+		container.Resources = ptr.Deref(spec.Resources, corev1.ResourceRequirements{})
+		container.StartupProbe = &corev1.Probe{
+			ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health?serializable=true", Port: intstr.FromInt32(dedicatedMetricsPort), Scheme: corev1.URISchemeHTTP}},
+			PeriodSeconds:    10,
+			TimeoutSeconds:   15,
+			FailureThreshold: 24,
+		}
+		container.LivenessProbe = &corev1.Probe{
+			ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health?serializable=true", Port: intstr.FromInt32(dedicatedMetricsPort), Scheme: corev1.URISchemeHTTP}},
+			PeriodSeconds:    10,
+			TimeoutSeconds:   15,
+			FailureThreshold: 8,
+		}
+		container.ReadinessProbe = &corev1.Probe{
+			ProbeHandler:     corev1.ProbeHandler{HTTPGet: &corev1.HTTPGetAction{Path: "/health", Port: intstr.FromInt32(dedicatedMetricsPort), Scheme: corev1.URISchemeHTTP}},
+			PeriodSeconds:    5,
+			TimeoutSeconds:   15,
+			FailureThreshold: 3,
+		}
+
+		return ctrl.SetControllerReference(tcp, statefulSet, r.Client.Scheme())
+	})
+}
+
+// args returns the etcd arguments: the initial cluster is used only upon the first start of a member,
+// thus changing it due to the membership changes doesn't affect the running members, besides rolling them.
+func (r *Dedicated) args(tcp *kamajiv1alpha1.TenantControlPlane, ordinals []int) []string {
+	initialCluster := make([]string, 0, len(ordinals))
+
+	for _, ordinal := range ordinals {
+		initialCluster = append(initialCluster, fmt.Sprintf("%s=%s", dedicatedMemberName(tcp, ordinal), dedicatedPeerURL(tcp, ordinal)))
+	}
+
+	initialClusterState := "new"
+	if status := tcp.Status.Storage.Dedicated; status != nil && len(status.Members) > 0 {
+		initialClusterState = "existing"
+	}
+
+	host := fmt.Sprintf("$(POD_NAME).%s.%s.svc", DedicatedName(tcp), tcp.GetNamespace())
+
+	return []string{
+		"--name=$(POD_NAME)",
+		"--data-dir=" + dedicatedDataDir,
+		fmt.Sprintf("--initial-advertise-peer-urls=https://%s:%d", host, dedicatedPeerPort),
+		fmt.Sprintf("--listen-peer-urls=https://0.0.0.0:%d", dedicatedPeerPort),
+		fmt.Sprintf("--listen-client-urls=https://0.0.0.0:%d", dedicatedClientPort),
+		fmt.Sprintf("--advertise-client-urls=https://%s:%d", host, dedicatedClientPort),
+		fmt.Sprintf("--listen-metrics-urls=http://0.0.0.0:%d", dedicatedMetricsPort),
+		"--initial-cluster=" + strings.Join(initialCluster, ","),
+		"--initial-cluster-state=" + initialClusterState,
+		"--initial-cluster-token=" + string(tcp.GetUID()),
+		"--client-cert-auth=true",
+		"--trusted-ca-file=" + dedicatedCertificatesDir + "/" + "ca.crt",
+		"--cert-file=" + dedicatedCertificatesDir + "/server.crt",
+		"--key-file=" + dedicatedCertificatesDir + "/server.key",
+		"--peer-client-cert-auth=true",
+		"--peer-trusted-ca-file=" + dedicatedCertificatesDir + "/" + "ca.crt",
+		"--peer-cert-file=" + dedicatedCertificatesDir + "/server.crt",
+		"--peer-key-file=" + dedicatedCertificatesDir + "/server.key",
+		"--auto-compaction-mode=periodic",
+		"--auto-compaction-retention=5m",
+		"--snapshot-count=10000",
+		"--quota-backend-bytes=8589934592",
+	}
+}
+
+func (r *Dedicated) ensureDataStore(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, certificates *corev1.Secret) (controllerutil.OperationResult, error) {
+	dataStore := &kamajiv1alpha1.DataStore{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: tcp.DedicatedDataStoreName(),
+		},
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, dataStore, func() error {
+		dataStore.SetLabels(utilities.MergeMaps(dataStore.GetLabels(), map[string]string{
+			constants.ProjectNameLabelKey:        constants.ProjectNameLabelValue,
+			constants.DedicatedDataStoreLabelKey: string(tcp.GetUID()),
+		}))
+		dataStore.SetAnnotations(utilities.MergeMaps(dataStore.GetAnnotations(), map[string]string{
+			constants.TenantControlPlaneAnnotation: client.ObjectKeyFromObject(tcp).String(),
+		}))
+		// The learners are not serving the clients, thus they're not used as endpoints.
+		var endpoints []string
+
+		for _, ordinal := range r.bootstrapOrdinals(tcp) {
+			if r.members[ordinal] {
+				continue
+			}
+
+			endpoints = append(endpoints, dedicatedClientEndpoint(tcp, ordinal))
+		}
+
+		secret := corev1.SecretReference{Name: certificates.GetName(), Namespace: certificates.GetNamespace()}
+
+		dataStore.Spec.Driver = kamajiv1alpha1.EtcdDriver
+		dataStore.Spec.Endpoints = endpoints
+		dataStore.Spec.TLSConfig = &kamajiv1alpha1.TLSConfig{
+			CertificateAuthority: kamajiv1alpha1.CertKeyPair{
+				Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: secret, KeyPath: "ca.crt"}},
+				PrivateKey:  &kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: secret, KeyPath: "ca.key"}},
+			},
+			ClientCertificate: &kamajiv1alpha1.ClientCertificate{
+				Certificate: kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: secret, KeyPath: "root.crt"}},
+				PrivateKey:  kamajiv1alpha1.ContentRef{SecretRef: &kamajiv1alpha1.SecretReference{SecretReference: secret, KeyPath: "root.key"}},
+			},
+		}
+
+		return nil
+	})
+}
+
+// DedicatedName returns the name of the StatefulSet, and of the headless Service, of the dedicated etcd cluster.
+func DedicatedName(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return utilities.AddTenantPrefix("etcd", tcp)
+}
+
+// DedicatedCertificatesName returns the name of the Secret holding the CA, and the certificates, of the dedicated etcd cluster.
+func DedicatedCertificatesName(tcp *kamajiv1alpha1.TenantControlPlane) string {
+	return utilities.AddTenantPrefix("etcd-certs", tcp)
+}
+
+func dedicatedMemberName(tcp *kamajiv1alpha1.TenantControlPlane, ordinal int) string {
+	return fmt.Sprintf("%s-%d", DedicatedName(tcp), ordinal)
+}
+
+func dedicatedOrdinal(member string) int {
+	ordinal, _ := strconv.Atoi(member[strings.LastIndex(member, "-")+1:])
+
+	return ordinal
+}
+
+func dedicatedMemberHost(tcp *kamajiv1alpha1.TenantControlPlane, ordinal int) string {
+	return fmt.Sprintf("%s.%s.%s.svc", dedicatedMemberName(tcp, ordinal), DedicatedName(tcp), tcp.GetNamespace())
+}
+
+func dedicatedPeerURL(tcp *kamajiv1alpha1.TenantControlPlane, ordinal int) string {
+	return fmt.Sprintf("https://%s:%d", dedicatedMemberHost(tcp, ordinal), dedicatedPeerPort)
+}
+
+func dedicatedClientEndpoint(tcp *kamajiv1alpha1.TenantControlPlane, ordinal int) string {
+	return fmt.Sprintf("%s:%d", dedicatedMemberHost(tcp, ordinal), dedicatedClientPort)
+}
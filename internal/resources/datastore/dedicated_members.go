@@ -0,0 +1,290 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/v3rpc/rpctypes"
+	etcdclient "go.etcd.io/etcd/client/v3"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	dedicatedEtcdTimeout = 10 * time.Second
+	// dedicatedReplacementThreshold is the time a voting member must be not ready for, before being replaced.
+	dedicatedReplacementThreshold = 5 * time.Minute
+)
+
+// reconcileMembers aligns the etcd membership to the desired replicas, performing a single change per reconciliation:
+// it returns true when further changes are pending, such as a learner not yet in sync with the leader.
+func (r *Dedicated) reconcileMembers(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, certificates *corev1.Secret) (bool, error) {
+	etcdClient, err := r.etcdClient(tcp, certificates)
+	if err != nil {
+		return false, err
+	}
+	defer etcdClient.Close()
+
+	ctx, cancelFn := context.WithTimeout(ctx, dedicatedEtcdTimeout)
+	defer cancelFn()
+
+	list, err := etcdClient.MemberList(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot list the etcd members")
+	}
+
+	members := map[int]*etcdserverpb.Member{}
+
+	for _, member := range list.Members {
+		if ordinal, ok := dedicatedPeerOrdinal(tcp, member); ok {
+			members[ordinal] = member
+		}
+	}
+
+	defer func() {
+		r.members = map[int]bool{}
+
+		for ordinal, member := range members {
+			r.members[ordinal] = member.IsLearner
+		}
+	}()
+
+	if err = r.ensureAuthentication(ctx, etcdClient); err != nil {
+		return false, err
+	}
+
+	return r.changeMembership(ctx, tcp, etcdClient, members)
+}
+
+// changeMembership performs the first required membership change, updating the given members accordingly.
+func (r *Dedicated) changeMembership(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, etcdClient *etcdclient.Client, members map[int]*etcdserverpb.Member) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	replicas := int(tcp.Spec.DedicatedDataStore.Replicas)
+	// Promoting the learners, which are refused until in sync with the leader.
+	for ordinal, member := range members {
+		if !member.IsLearner {
+			continue
+		}
+
+		if _, err := etcdClient.MemberPromote(ctx, member.ID); err != nil {
+			if errors.Is(err, rpctypes.ErrMemberLearnerNotReady) {
+				logger.Info("etcd learner not yet in sync with the leader", "member", dedicatedMemberName(tcp, ordinal))
+
+				return true, nil
+			}
+
+			return false, errors.Wrap(err, fmt.Sprintf("cannot promote the etcd learner %s", dedicatedMemberName(tcp, ordinal)))
+		}
+
+		logger.Info("etcd learner has been promoted", "member", dedicatedMemberName(tcp, ordinal))
+
+		member.IsLearner = false
+
+		return true, nil
+	}
+	// Removing the members exceeding the replicas, starting from the highest ordinal.
+	for ordinal := len(members) + replicas; ordinal >= replicas; ordinal-- {
+		member, ok := members[ordinal]
+		if !ok {
+			continue
+		}
+
+		if _, err := etcdClient.MemberRemove(ctx, member.ID); err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot remove the etcd member %s", dedicatedMemberName(tcp, ordinal)))
+		}
+
+		logger.Info("etcd member has been removed", "member", dedicatedMemberName(tcp, ordinal))
+
+		delete(members, ordinal)
+
+		return true, nil
+	}
+
+	if pending, err := r.replaceUnhealthyMember(ctx, tcp, etcdClient, members); pending || err != nil {
+		return pending, err
+	}
+	// Adding the missing members as learners, one at a time.
+	for ordinal := range replicas {
+		if _, ok := members[ordinal]; ok {
+			continue
+		}
+
+		response, err := etcdClient.MemberAddAsLearner(ctx, []string{dedicatedPeerURL(tcp, ordinal)})
+		if err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot add the etcd learner %s", dedicatedMemberName(tcp, ordinal)))
+		}
+
+		logger.Info("etcd learner has been added", "member", dedicatedMemberName(tcp, ordinal))
+
+		members[ordinal] = response.Member
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// replaceUnhealthyMember removes a voting member whose Pod is not ready for longer than the threshold,
+// along with its volume, letting it join the cluster again as a learner with an empty data directory.
+// The replacement is performed only when the remaining members are ready, preserving the quorum.
+func (r *Dedicated) replaceUnhealthyMember(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, etcdClient *etcdclient.Client, members map[int]*etcdserverpb.Member) (bool, error) {
+	voting := map[int]*etcdserverpb.Member{}
+
+	for ordinal, member := range members {
+		if !member.IsLearner {
+			voting[ordinal] = member
+		}
+	}
+	// A single member cannot be replaced without losing the data.
+	if len(voting) < 3 {
+		return false, nil
+	}
+
+	var podList corev1.PodList
+	if err := r.Client.List(ctx, &podList, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels(utilities.KamajiLabels(tcp.GetName(), r.GetName()))); err != nil {
+		return false, errors.Wrap(err, "cannot list the etcd pods")
+	}
+
+	notReadySince := map[int]time.Time{}
+
+	for _, pod := range podList.Items {
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status != corev1.ConditionTrue {
+				notReadySince[dedicatedOrdinal(pod.GetName())] = condition.LastTransitionTime.Time
+			}
+		}
+	}
+
+	for ordinal, member := range voting {
+		since, ok := notReadySince[ordinal]
+		if !ok {
+			continue
+		}
+
+		if len(notReadySince) > 1 {
+			return false, nil
+		}
+
+		if time.Since(since) < dedicatedReplacementThreshold {
+			return true, nil
+		}
+
+		if _, err := etcdClient.MemberRemove(ctx, member.ID); err != nil {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot remove the unhealthy etcd member %s", dedicatedMemberName(tcp, ordinal)))
+		}
+
+		delete(members, ordinal)
+
+		name := dedicatedMemberName(tcp, ordinal)
+
+		pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "data-" + name, Namespace: tcp.GetNamespace()}}
+		if err := r.Client.Delete(ctx, pvc); err != nil && !k8serrors.IsNotFound(err) {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot delete the volume of the etcd member %s", name))
+		}
+
+		pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: tcp.GetNamespace()}}
+		if err := r.Client.Delete(ctx, pod); err != nil && !k8serrors.IsNotFound(err) {
+			return false, errors.Wrap(err, fmt.Sprintf("cannot delete the pod of the etcd member %s", name))
+		}
+
+		log.FromContext(ctx, "resource", r.GetName()).Info("unhealthy etcd member has been removed, replacing it", "member", name)
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// ensureAuthentication enables the etcd authentication, required to scope each Tenant Control Plane user to its prefix:
+// the root user is authenticated by means of the client certificate, thus it has no password.
+func (r *Dedicated) ensureAuthentication(ctx context.Context, etcdClient *etcdclient.Client) error {
+	status, err := etcdClient.AuthStatus(ctx)
+	if err != nil {
+		return errors.Wrap(err, "cannot retrieve the etcd authentication status")
+	}
+
+	if status.Enabled {
+		return nil
+	}
+
+	if _, err = etcdClient.UserAddWithOptions(ctx, dedicatedRootUser, "", &etcdclient.UserAddOptions{NoPassword: true}); err != nil && !errors.Is(err, rpctypes.ErrUserAlreadyExist) {
+		return errors.Wrap(err, "cannot create the etcd root user")
+	}
+
+	if _, err = etcdClient.UserGrantRole(ctx, dedicatedRootUser, dedicatedRootUser); err != nil {
+		return errors.Wrap(err, "cannot grant the root role to the etcd root user")
+	}
+
+	if _, err = etcdClient.AuthEnable(ctx); err != nil {
+		return errors.Wrap(err, "cannot enable the etcd authentication")
+	}
+
+	return nil
+}
+
+func (r *Dedicated) etcdClient(tcp *kamajiv1alpha1.TenantControlPlane, certificates *corev1.Secret) (*etcdclient.Client, error) {
+	certificate, err := tls.X509KeyPair(certificates.Data["root.crt"], certificates.Data["root.key"])
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load the etcd root certificate")
+	}
+
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM(certificates.Data["ca.crt"])
+
+	endpoints := make([]string, 0, len(r.members))
+
+	for _, ordinal := range r.bootstrapOrdinals(tcp) {
+		endpoints = append(endpoints, "https://"+dedicatedClientEndpoint(tcp, ordinal))
+	}
+
+	etcdClient, err := etcdclient.New(etcdclient.Config{
+		Endpoints:   endpoints,
+		DialTimeout: dedicatedEtcdTimeout,
+		TLS: &tls.Config{
+			Certificates: []tls.Certificate{certificate},
+			RootCAs:      pool,
+			MinVersion:   tls.VersionTLS12,
+		},
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot create the etcd client")
+	}
+
+	return etcdClient, nil
+}
+
+// dedicatedPeerOrdinal returns the ordinal of the StatefulSet Pod of the given member, by means of its peer URL.
+func dedicatedPeerOrdinal(tcp *kamajiv1alpha1.TenantControlPlane, member *etcdserverpb.Member) (int, bool) {
+	for _, peerURL := range member.PeerURLs {
+		parsed, err := url.Parse(peerURL)
+		if err != nil {
+			continue
+		}
+
+		name, _, _ := strings.Cut(parsed.Hostname(), ".")
+		if !strings.HasPrefix(name, DedicatedName(tcp)+"-") {
+			continue
+		}
+
+		return dedicatedOrdinal(name), true
+	}
+
+	return 0, false
+}
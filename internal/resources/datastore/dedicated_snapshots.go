@@ -0,0 +1,148 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package datastore
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const dedicatedSnapshotsDir = "/snapshots"
+
+// DedicatedSnapshotLabels returns the labels of the persistent volume claims holding the dedicated etcd cluster snapshots.
+func DedicatedSnapshotLabels(tcp *kamajiv1alpha1.TenantControlPlane) map[string]string {
+	return utilities.KamajiLabels(tcp.GetName(), "dedicated-datastore-snapshot")
+}
+
+// TakeDedicatedSnapshot creates a persistent volume claim, and the Job saving the etcd snapshot into it, returning their name.
+// The persistent volume claim isn't owned by the Tenant Control Plane, since the snapshots are retained according to its deletion policy.
+func TakeDedicatedSnapshot(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane, now time.Time) (string, error) {
+	spec := tcp.Spec.DedicatedDataStore
+	name := fmt.Sprintf("%s-snapshot-%s", DedicatedName(tcp), now.UTC().Format("20060102150405"))
+
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tcp.GetNamespace(),
+			Labels:    DedicatedSnapshotLabels(tcp),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+			StorageClassName: spec.StorageClassName,
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: spec.Snapshots.StorageSize},
+			},
+		},
+	}
+
+	if err := c.Create(ctx, pvc); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, fmt.Sprintf("cannot create the snapshot volume %s", name))
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: tcp.GetNamespace(),
+			Labels:    DedicatedSnapshotLabels(tcp),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To(int32(3)),
+			TTLSecondsAfterFinished: ptr.To(int32(3600)),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyOnFailure,
+					Volumes: []corev1.Volume{
+						{
+							Name:         "snapshot",
+							VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name}},
+						},
+						{
+							Name: "certs",
+							VolumeSource: corev1.VolumeSource{
+								Secret: &corev1.SecretVolumeSource{
+									SecretName: DedicatedCertificatesName(tcp),
+									Items: []corev1.KeyToPath{
+										{Key: "ca.crt", Path: "ca.crt"},
+										{Key: "root.crt", Path: "root.crt"},
+										{Key: "root.key", Path: "root.key"},
+									},
+								},
+							},
+						},
+					},
+					Containers: []corev1.Container{
+						{
+							Name:  "etcdctl",
+							Image: spec.Image,
+							Command: []string{
+								"etcdctl",
+								fmt.Sprintf("--endpoints=https://%s.%s.svc:%d", DedicatedName(tcp), tcp.GetNamespace(), dedicatedClientPort),
+								"--cacert=" + dedicatedCertificatesDir + "/ca.crt",
+								"--cert=" + dedicatedCertificatesDir + "/root.crt",
+								"--key=" + dedicatedCertificatesDir + "/root.key",
+								"snapshot",
+								"save",
+								dedicatedSnapshotsDir + "/snapshot.db",
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{Name: "snapshot", MountPath: dedicatedSnapshotsDir},
+								{Name: "certs", MountPath: dedicatedCertificatesDir, ReadOnly: true},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if err := ctrl.SetControllerReference(tcp, job, c.Scheme()); err != nil {
+		return "", errors.Wrap(err, "cannot set the controller reference of the snapshot Job")
+	}
+
+	if err := c.Create(ctx, job); err != nil && !k8serrors.IsAlreadyExists(err) {
+		return "", errors.Wrap(err, fmt.Sprintf("cannot create the snapshot Job %s", name))
+	}
+
+	return name, nil
+}
+
+// PruneDedicatedSnapshots deletes the oldest snapshots, exceeding the retained ones.
+func PruneDedicatedSnapshots(ctx context.Context, c client.Client, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	var pvcList corev1.PersistentVolumeClaimList
+	if err := c.List(ctx, &pvcList, client.InNamespace(tcp.GetNamespace()), client.MatchingLabels(DedicatedSnapshotLabels(tcp))); err != nil {
+		return errors.Wrap(err, "cannot list the snapshot volumes")
+	}
+
+	retain := int(tcp.Spec.DedicatedDataStore.Snapshots.Retain)
+	if len(pvcList.Items) <= retain {
+		return nil
+	}
+	// The names are suffixed by the snapshot time, thus sorting them is sorting the snapshots.
+	slices.SortFunc(pvcList.Items, func(a, b corev1.PersistentVolumeClaim) int {
+		return strings.Compare(b.GetName(), a.GetName())
+	})
+
+	for i := retain; i < len(pvcList.Items); i++ {
+		if err := c.Delete(ctx, &pvcList.Items[i]); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, fmt.Sprintf("cannot delete the snapshot volume %s", pvcList.Items[i].GetName()))
+		}
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ import (
 
 var (
 	certificateCollector  prometheus.Histogram
+	dedicatedCollector    prometheus.Histogram
 	migrateCollector      prometheus.Histogram
 	multiTenancyCollector prometheus.Histogram
 	setupCollector        prometheus.Histogram
@@ -0,0 +1,199 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/fips"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// FIPSComplianceResource reports the FIPS compliance of the Tenant Control Planes running in FIPS mode,
+// listing the gaps with the FIPSCompliant condition, such as the components missing a FIPS-validated image.
+type FIPSComplianceResource struct {
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+
+	gaps []string
+}
+
+func (r *FIPSComplianceResource) GetHistogram() prometheus.Histogram {
+	fipscomplianceCollector = LazyLoadHistogramFromResource(fipscomplianceCollector, r)
+
+	return fipscomplianceCollector
+}
+
+func (r *FIPSComplianceResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.gaps = nil
+
+	if !tenantControlPlane.IsFIPS() {
+		return nil
+	}
+
+	if !fips.Enabled() {
+		r.gaps = append(r.gaps, "Kamaji is not running in FIPS mode")
+	}
+
+	profile, err := utilities.GetImageProfile(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		return err
+	}
+
+	if missing := r.missingImages(tenantControlPlane, profile); len(missing) > 0 {
+		r.gaps = append(r.gaps, fmt.Sprintf("the FIPS-validated images of %s are not declared in the ImageProfile", strings.Join(missing, ", ")))
+	}
+
+	keySize, err := r.caKeySize(ctx, tenantControlPlane)
+	if err != nil {
+		return err
+	}
+
+	if keySize > 0 && keySize < fips.RSAKeySize {
+		r.gaps = append(r.gaps, fmt.Sprintf("the Certificate Authority key is %d bits large, rotate it to generate a %d bits one", keySize, fips.RSAKeySize))
+	}
+
+	return nil
+}
+
+// missingImages returns the components of the Tenant Control Plane lacking a FIPS-validated image.
+func (r *FIPSComplianceResource) missingImages(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, profile *kamajiv1alpha1.ImageProfile) []string {
+	components := []kamajiv1alpha1.ImageProfileComponent{
+		kamajiv1alpha1.ImageProfileAPIServer,
+		kamajiv1alpha1.ImageProfileControllerManager,
+		kamajiv1alpha1.ImageProfileScheduler,
+	}
+
+	if r.DataStore.Spec.Driver != kamajiv1alpha1.EtcdDriver {
+		components = append(components, kamajiv1alpha1.ImageProfileKine)
+	}
+
+	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
+		components = append(components, kamajiv1alpha1.ImageProfileKonnectivityServer, kamajiv1alpha1.ImageProfileKonnectivityAgent)
+	}
+
+	if tenantControlPlane.Spec.Addons.CoreDNS != nil {
+		components = append(components, kamajiv1alpha1.ImageProfileCoreDNS)
+	}
+
+	if tenantControlPlane.Spec.Addons.KubeProxy != nil {
+		components = append(components, kamajiv1alpha1.ImageProfileKubeProxy)
+	}
+
+	var missing []string
+
+	for _, component := range components {
+		if !profile.HasFIPSImage(component) {
+			missing = append(missing, string(component))
+		}
+	}
+
+	slices.Sort(missing)
+
+	return missing
+}
+
+// caKeySize returns the size of the Certificate Authority RSA key, zero when it's not generated yet.
+func (r *FIPSComplianceResource) caKeySize(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (int, error) {
+	secretName := tenantControlPlane.Status.Certificates.CA.SecretName
+	if len(secretName) == 0 {
+		return 0, nil
+	}
+
+	var secret corev1.Secret
+	if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: secretName}, &secret); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return 0, nil
+		}
+
+		return 0, errors.Wrap(err, "cannot retrieve the Certificate Authority")
+	}
+
+	certificate, err := crypto.ParseCertificateBytes(secret.Data[kubeadmconstants.CACertName])
+	if err != nil {
+		return 0, err
+	}
+
+	publicKey, ok := certificate.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return 0, nil
+	}
+
+	return publicKey.N.BitLen(), nil
+}
+
+func (r *FIPSComplianceResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *FIPSComplianceResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *FIPSComplianceResource) CreateOrUpdate(context.Context, *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	// Nothing to create, the compliance is reported in the status only.
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *FIPSComplianceResource) GetName() string {
+	return "fips-compliance"
+}
+
+func (r *FIPSComplianceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	condition := meta.FindStatusCondition(tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionFIPSCompliant)
+
+	if !tenantControlPlane.IsFIPS() {
+		return condition != nil
+	}
+
+	status, reason, message := r.compliance()
+
+	return condition == nil || condition.Status != status || condition.Reason != reason || condition.Message != message ||
+		condition.ObservedGeneration != tenantControlPlane.GetGeneration()
+}
+
+func (r *FIPSComplianceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !tenantControlPlane.IsFIPS() {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionFIPSCompliant)
+
+		return nil
+	}
+
+	status, reason, message := r.compliance()
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionFIPSCompliant,
+		Status:             status,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             reason,
+		Message:            message,
+	})
+
+	return nil
+}
+
+func (r *FIPSComplianceResource) compliance() (metav1.ConditionStatus, string, string) {
+	if len(r.gaps) == 0 {
+		return metav1.ConditionTrue, kamajiv1alpha1.ReasonFIPSCompliant, "the Tenant Control Plane runs in FIPS mode"
+	}
+
+	return metav1.ConditionFalse, kamajiv1alpha1.ReasonFIPSNonCompliant, strings.Join(r.gaps, "; ")
+}
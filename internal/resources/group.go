@@ -0,0 +1,148 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/sync/errgroup"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Group handles a set of independent resources concurrently, such as the certificates signed by the same CA:
+// the resources must not depend on the status updated by the other ones of the Group.
+// The Tenant Control Plane status is updated once for the whole Group, reducing the API Server round trips.
+type Group struct {
+	Name      string
+	Resources []Resource
+	// Concurrency bounds the resources handled at the same time, sequentially if lower than 2.
+	Concurrency int
+
+	results []controllerutil.OperationResult
+}
+
+func (g *Group) GetHistogram() prometheus.Histogram {
+	return nil
+}
+
+func (g *Group) Define(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	g.results = make([]controllerutil.OperationResult, len(g.Resources))
+
+	return nil
+}
+
+func (g *Group) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (g *Group) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+// CreateOrUpdate handles the resources of the Group: each one is working on its own copy of the Tenant Control Plane,
+// and the durations are observed once all the resources have been handled, since the histograms are lazy loaded.
+func (g *Group) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	durations := make([]time.Duration, len(g.Resources))
+
+	eg, egCtx := errgroup.WithContext(ctx)
+	eg.SetLimit(max(g.Concurrency, 1))
+
+	for i, resource := range g.Resources {
+		tcp := tenantControlPlane.DeepCopy()
+
+		eg.Go(func() error {
+			startTime := time.Now()
+			defer func() {
+				durations[i] = time.Since(startTime)
+			}()
+
+			result, err := handle(egCtx, resource, tcp)
+			if err != nil {
+				return errors.Wrap(err, resource.GetName())
+			}
+
+			g.results[i] = result
+
+			return nil
+		})
+	}
+
+	err := eg.Wait()
+
+	for i, resource := range g.Resources {
+		if durations[i] > 0 {
+			resource.GetHistogram().Observe(durations[i].Seconds())
+		}
+	}
+
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	return g.result(), nil
+}
+
+// result aggregates the results of the Group resources:
+// the enqueuing back takes precedence, and the status-only result is returned if no resource has been changed.
+func (g *Group) result() controllerutil.OperationResult {
+	result := controllerutil.OperationResultNone
+
+	for _, r := range g.results {
+		switch r {
+		case OperationResultEnqueueBack:
+			return OperationResultEnqueueBack
+		case controllerutil.OperationResultNone:
+			continue
+		case controllerutil.OperationResultUpdatedStatusOnly:
+			if result == controllerutil.OperationResultNone {
+				result = r
+			}
+		default:
+			result = controllerutil.OperationResultUpdated
+		}
+	}
+
+	return result
+}
+
+func (g *Group) GetName() string {
+	return g.Name
+}
+
+func (g *Group) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (g *Group) UpdateTenantControlPlaneStatus(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	for i, resource := range g.Resources {
+		if g.results[i] == controllerutil.OperationResultNone {
+			continue
+		}
+
+		if err := resource.UpdateTenantControlPlaneStatus(ctx, tenantControlPlane); err != nil {
+			return errors.Wrap(err, resource.GetName())
+		}
+	}
+
+	return nil
+}
+
+// UpdatedResources returns the resources of the Group that have been updated by the last CreateOrUpdate,
+// the created ones and the status-only changes are excluded.
+func (g *Group) UpdatedResources() []Resource {
+	var updated []Resource
+
+	for i, resource := range g.Resources {
+		if i < len(g.results) && g.results[i] == controllerutil.OperationResultUpdated {
+			updated = append(updated, resource)
+		}
+	}
+
+	return updated
+}
@@ -0,0 +1,207 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// hostNetworkPortsBlock is the number of the host ports allocated to each Tenant Control Plane:
+// the kube-apiserver, the kube-controller-manager, the kube-scheduler, and the kine metrics, ones.
+const hostNetworkPortsBlock = 4
+
+// HostNetworkResource allocates the host ports of the Control Plane components running in the host network namespace,
+// and collects the addresses of the nodes eligible to run the Control Plane pods, added to the API Server certificate SANs.
+// The blocks of ports are not shared across the Tenant Control Planes: when two of them are allocated the same block,
+// such as upon concurrent reconciliations, the younger one is allocated a new block.
+type HostNetworkResource struct {
+	Client client.Client
+
+	status *kamajiv1alpha1.KubernetesHostNetworkStatus
+}
+
+func (r *HostNetworkResource) GetHistogram() prometheus.Histogram {
+	hostnetworkCollector = LazyLoadHistogramFromResource(hostnetworkCollector, r)
+
+	return hostnetworkCollector
+}
+
+func (r *HostNetworkResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.status = tenantControlPlane.Status.Kubernetes.HostNetwork.DeepCopy()
+
+	return nil
+}
+
+func (r *HostNetworkResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.HostNetwork() == nil && tenantControlPlane.Status.Kubernetes.HostNetwork != nil
+}
+
+func (r *HostNetworkResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The ports are released by clearing the status, the Deployment is rolled out back to the pod network.
+	r.status = nil
+
+	return true, nil
+}
+
+func (r *HostNetworkResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	spec := tenantControlPlane.HostNetwork()
+	if spec == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	taken, err := r.takenPorts(ctx, tenantControlPlane)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	base := int32(0)
+	if current := tenantControlPlane.Status.Kubernetes.HostNetwork; current != nil {
+		base = current.APIServerPort
+
+		if !r.isAvailable(base, spec.PortRange, taken) {
+			logger.Info("the allocated host ports are conflicting, or out of range, allocating new ones", "port", base)
+
+			base = 0
+		}
+	}
+
+	if base == 0 {
+		for candidate := spec.PortRange.From; candidate+hostNetworkPortsBlock-1 <= spec.PortRange.To; candidate += hostNetworkPortsBlock {
+			if r.isAvailable(candidate, spec.PortRange, taken) {
+				base = candidate
+
+				break
+			}
+		}
+	}
+
+	if base == 0 {
+		return controllerutil.OperationResultNone, fmt.Errorf("no host ports available in the range %d-%d", spec.PortRange.From, spec.PortRange.To)
+	}
+
+	addresses, err := r.nodeAddresses(ctx, tenantControlPlane)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	status := &kamajiv1alpha1.KubernetesHostNetworkStatus{
+		APIServerPort:         base,
+		ControllerManagerPort: base + 1,
+		SchedulerPort:         base + 2,
+		KineMetricsPort:       base + 3,
+		NodeAddresses:         addresses,
+	}
+
+	if r.status != nil {
+		status.LastUpdate = r.status.LastUpdate
+
+		if equality.Semantic.DeepEqual(*r.status, *status) {
+			return controllerutil.OperationResultNone, nil
+		}
+	}
+
+	status.LastUpdate = metav1.Now()
+	r.status = status
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// takenPorts returns the host ports allocated to the other Tenant Control Planes prevailing over the given one:
+// the older ones, or the ones with the lower namespaced name upon the same creation time.
+func (r *HostNetworkResource) takenPorts(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (map[int32]struct{}, error) {
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err := r.Client.List(ctx, &tcpList); err != nil {
+		return nil, errors.Wrap(err, "cannot list the Tenant Control Planes")
+	}
+
+	taken := map[int32]struct{}{}
+
+	for _, tcp := range tcpList.Items {
+		if tcp.GetUID() == tenantControlPlane.GetUID() || tcp.Status.Kubernetes.HostNetwork == nil {
+			continue
+		}
+
+		prevails := tcp.CreationTimestamp.Before(&tenantControlPlane.CreationTimestamp) ||
+			tcp.CreationTimestamp.Equal(&tenantControlPlane.CreationTimestamp) && client.ObjectKeyFromObject(&tcp).String() < client.ObjectKeyFromObject(tenantControlPlane).String()
+		// The conflicting younger Tenant Control Planes are moving to a new block, the free ones are not allocated anyway.
+		if !prevails && tenantControlPlane.Status.Kubernetes.HostNetwork != nil {
+			continue
+		}
+
+		for port := range hostNetworkPortsBlock {
+			taken[tcp.Status.Kubernetes.HostNetwork.APIServerPort+int32(port)] = struct{}{}
+		}
+	}
+
+	return taken, nil
+}
+
+func (r *HostNetworkResource) isAvailable(base int32, portRange kamajiv1alpha1.HostPortRange, taken map[int32]struct{}) bool {
+	if base < portRange.From || base+hostNetworkPortsBlock-1 > portRange.To {
+		return false
+	}
+
+	for port := range hostNetworkPortsBlock {
+		if _, ok := taken[base+int32(port)]; ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// nodeAddresses returns the addresses of the nodes matching the node selector of the Control Plane pods.
+func (r *HostNetworkResource) nodeAddresses(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]string, error) {
+	var nodeList corev1.NodeList
+	if err := r.Client.List(ctx, &nodeList, client.MatchingLabels(tenantControlPlane.Spec.ControlPlane.Deployment.NodeSelector)); err != nil {
+		return nil, errors.Wrap(err, "cannot list the nodes")
+	}
+
+	var addresses []string
+
+	for _, node := range nodeList.Items {
+		for _, address := range node.Status.Addresses {
+			if address.Type != corev1.NodeInternalIP && address.Type != corev1.NodeExternalIP {
+				continue
+			}
+
+			if !slices.Contains(addresses, address.Address) {
+				addresses = append(addresses, address.Address)
+			}
+		}
+	}
+
+	slices.Sort(addresses)
+
+	return addresses, nil
+}
+
+func (r *HostNetworkResource) GetName() string {
+	return "host-network"
+}
+
+func (r *HostNetworkResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !equality.Semantic.DeepEqual(tenantControlPlane.Status.Kubernetes.HostNetwork, r.status)
+}
+
+func (r *HostNetworkResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.Kubernetes.HostNetwork = r.status
+
+	return nil
+}
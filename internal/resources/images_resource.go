@@ -0,0 +1,251 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	kamajierrors "github.com/clastix/kamaji/internal/errors"
+	"github.com/clastix/kamaji/internal/images"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ImagesResource pins the Control Plane component images to their digests, verifying their signatures,
+// when required by the referenced Image Profile: the Deployment is rolled out with the pinned references,
+// and it's never reached when an image fails the verification.
+type ImagesResource struct {
+	Client             client.Client
+	DataStore          kamajiv1alpha1.DataStore
+	KineContainerImage string
+
+	profile  *kamajiv1alpha1.ImageProfile
+	images   map[kamajiv1alpha1.ImageProfileComponent]string
+	checksum string
+	verifier *images.Verifier
+	status   *kamajiv1alpha1.ImagesStatus
+}
+
+func (r *ImagesResource) GetHistogram() prometheus.Histogram {
+	imagesCollector = LazyLoadHistogramFromResource(imagesCollector, r)
+
+	return imagesCollector
+}
+
+func (r *ImagesResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	if r.profile, err = utilities.GetImageProfile(ctx, r.Client, tenantControlPlane); err != nil {
+		return err
+	}
+
+	if !r.profile.RequiresDigests() {
+		return nil
+	}
+
+	r.images = (builder.Deployment{
+		DataStore:          r.DataStore,
+		KineContainerImage: r.KineContainerImage,
+		ImageProfile:       r.profile,
+	}).Images(*tenantControlPlane)
+
+	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
+		r.images[kamajiv1alpha1.ImageProfileKonnectivityServer] = (builder.Konnectivity{ImageProfile: r.profile}).Image(*tenantControlPlane)
+	}
+
+	return r.defineVerification(ctx)
+}
+
+// defineVerification retrieves the trusted signers, computing the checksum of the verification settings:
+// the images are verified again upon any change, such as a rotated public key.
+func (r *ImagesResource) defineVerification(ctx context.Context) error {
+	spec, err := json.Marshal(r.profile.Spec)
+	if err != nil {
+		return errors.Wrap(err, "cannot encode the Image Profile specification")
+	}
+
+	checksumData := map[string][]byte{"spec": spec}
+
+	verification := r.profile.Spec.Verification
+	if verification == nil {
+		r.checksum = utilities.CalculateMapChecksum(checksumData)
+
+		return nil
+	}
+
+	r.verifier = &images.Verifier{}
+
+	for i, ref := range verification.PublicKeys {
+		content, contentErr := ref.GetContent(ctx, r.Client)
+		if contentErr != nil {
+			return errors.Wrap(contentErr, "cannot retrieve the public key")
+		}
+
+		key, keyErr := images.ParsePublicKey(content)
+		if keyErr != nil {
+			return keyErr
+		}
+
+		checksumData[fmt.Sprintf("publicKey-%d", i)] = content
+		r.verifier.PublicKeys = append(r.verifier.PublicKeys, key)
+	}
+
+	for i, identity := range verification.Keyless {
+		roots, rootsErr := identity.Roots.GetContent(ctx, r.Client)
+		if rootsErr != nil {
+			return errors.Wrap(rootsErr, "cannot retrieve the keyless roots")
+		}
+
+		transparencyLogPublicKey, keyErr := identity.TransparencyLogPublicKey.GetContent(ctx, r.Client)
+		if keyErr != nil {
+			return errors.Wrap(keyErr, "cannot retrieve the transparency log public key")
+		}
+
+		keyless, keylessErr := images.NewKeylessVerifier(identity.Issuer, identity.Subject, roots, transparencyLogPublicKey)
+		if keylessErr != nil {
+			return keylessErr
+		}
+
+		checksumData[fmt.Sprintf("keyless-%d-roots", i)] = roots
+		checksumData[fmt.Sprintf("keyless-%d-transparencyLog", i)] = transparencyLogPublicKey
+		r.verifier.Keyless = append(r.verifier.Keyless, keyless)
+	}
+
+	r.checksum = utilities.CalculateMapChecksum(checksumData)
+
+	return nil
+}
+
+func (r *ImagesResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.profile.RequiresDigests() && tenantControlPlane.Status.Images != nil
+}
+
+func (r *ImagesResource) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// Nothing to delete, the status must be cleared to roll out the tag references.
+	return true, nil
+}
+
+func (r *ImagesResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.profile.RequiresDigests() || r.isResolved(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	opts := images.RemoteOptions(ctx)
+
+	status := &kamajiv1alpha1.ImagesStatus{Checksum: r.checksum}
+
+	for _, component := range r.components() {
+		image := r.images[component]
+
+		digest, err := images.ResolveDigest(image, opts...)
+		if err != nil {
+			logger.Error(err, "cannot resolve the image digest", "image", image)
+
+			return controllerutil.OperationResultNone, err
+		}
+
+		if r.verifier != nil {
+			if err = r.verifier.Verify(digest, opts...); err != nil {
+				logger.Error(err, "image verification failed", "image", image)
+
+				return controllerutil.OperationResultNone, kamajierrors.ImageVerificationError{Image: image, Err: err}
+			}
+		}
+
+		status.Components = append(status.Components, kamajiv1alpha1.ComponentImageStatus{
+			Component: component,
+			Image:     image,
+			Digest:    digest.DigestStr(),
+			Verified:  r.verifier != nil,
+		})
+	}
+
+	r.status = status
+
+	return controllerutil.OperationResultUpdatedStatusOnly, nil
+}
+
+// isResolved returns true when all the images have been resolved with the current verification settings:
+// the digests are not resolved again, preventing a tag mutation from rolling out the Control Plane.
+func (r *ImagesResource) isResolved(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	status := tenantControlPlane.Status.Images
+	if status == nil || status.Checksum != r.checksum || len(status.Components) != len(r.images) {
+		return false
+	}
+
+	for _, component := range status.Components {
+		if image, ok := r.images[component.Component]; !ok || image != component.Image {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r *ImagesResource) components() []kamajiv1alpha1.ImageProfileComponent {
+	components := make([]kamajiv1alpha1.ImageProfileComponent, 0, len(r.images))
+	for component := range r.images {
+		components = append(components, component)
+	}
+
+	slices.Sort(components)
+
+	return components
+}
+
+func (r *ImagesResource) GetName() string {
+	return "images"
+}
+
+func (r *ImagesResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.profile.RequiresDigests() {
+		return tenantControlPlane.Status.Images != nil
+	}
+
+	return r.status != nil
+}
+
+func (r *ImagesResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.profile.RequiresDigests() {
+		tenantControlPlane.Status.Images = nil
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionImagesVerified)
+
+		return nil
+	}
+
+	if r.status == nil {
+		return nil
+	}
+
+	r.status.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.Images = r.status
+
+	if r.verifier == nil {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionImagesVerified)
+
+		return nil
+	}
+
+	meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+		Type:               kamajiv1alpha1.ConditionImagesVerified,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: tenantControlPlane.GetGeneration(),
+		Reason:             kamajiv1alpha1.ReasonImagesVerified,
+		Message:            "the Control Plane component images are signed by a trusted signer",
+	})
+
+	return nil
+}
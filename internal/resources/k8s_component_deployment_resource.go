@@ -0,0 +1,127 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/mutators"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubernetesComponentDeploymentResource manages the Deployment running a Control Plane component
+// of the Tenant Control Planes with the Split topology, deleting it once switched back to the Monolithic one.
+type KubernetesComponentDeploymentResource struct {
+	resource  *appsv1.Deployment
+	Client    client.Client
+	DataStore kamajiv1alpha1.DataStore
+	Component kamajiv1alpha1.SplitComponentName
+
+	exists bool
+}
+
+func (r *KubernetesComponentDeploymentResource) GetHistogram() prometheus.Histogram {
+	switch r.Component {
+	case kamajiv1alpha1.SplitComponentControllerManager:
+		controllermanagerdeploymentCollector = LazyLoadHistogramFromResource(controllermanagerdeploymentCollector, r)
+
+		return controllermanagerdeploymentCollector
+	default:
+		schedulerdeploymentCollector = LazyLoadHistogramFromResource(schedulerdeploymentCollector, r)
+
+		return schedulerdeploymentCollector
+	}
+}
+
+func (r *KubernetesComponentDeploymentResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.ComponentName(tenantControlPlane, r.Component),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+	// The Deployment has no status counterpart, its existence is checked to remove it once the Monolithic topology is restored.
+	if tenantControlPlane.HasSplitTopology() {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &appsv1.Deployment{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the component Deployment")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *KubernetesComponentDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !tenantControlPlane.HasSplitTopology() && r.exists
+}
+
+func (r *KubernetesComponentDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func (r *KubernetesComponentDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !tenantControlPlane.HasSplitTopology() {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *KubernetesComponentDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		imageProfile, err := utilities.GetImageProfile(ctx, r.Client, tenantControlPlane)
+		if err != nil {
+			return err
+		}
+
+		(builder.Deployment{
+			Client:       r.Client,
+			DataStore:    r.DataStore,
+			ImageProfile: imageProfile,
+		}).BuildComponent(ctx, r.resource, *tenantControlPlane, r.Component)
+
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *KubernetesComponentDeploymentResource) GetName() string {
+	return string(r.Component) + "-deployment"
+}
+
+func (r *KubernetesComponentDeploymentResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubernetesComponentDeploymentResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
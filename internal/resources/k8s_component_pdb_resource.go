@@ -0,0 +1,128 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// KubernetesComponentPodDisruptionBudgetResource manages the PodDisruptionBudget of a Control Plane component
+// running in a dedicated Deployment with the Split topology, deleting it once no longer declared.
+type KubernetesComponentPodDisruptionBudgetResource struct {
+	resource  *policyv1.PodDisruptionBudget
+	Client    client.Client
+	Component kamajiv1alpha1.SplitComponentName
+
+	exists bool
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) GetHistogram() prometheus.Histogram {
+	switch r.Component {
+	case kamajiv1alpha1.SplitComponentControllerManager:
+		controllermanagerpdbCollector = LazyLoadHistogramFromResource(controllermanagerpdbCollector, r)
+
+		return controllermanagerpdbCollector
+	default:
+		schedulerpdbCollector = LazyLoadHistogramFromResource(schedulerpdbCollector, r)
+
+		return schedulerpdbCollector
+	}
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &policyv1.PodDisruptionBudget{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.ComponentName(tenantControlPlane, r.Component),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+	// The PodDisruptionBudget has no status counterpart, its existence is checked to remove it once no longer declared.
+	if r.isDeclared(tenantControlPlane) {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &policyv1.PodDisruptionBudget{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the component PodDisruptionBudget")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) isDeclared(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !tenantControlPlane.HasSplitTopology() {
+		return false
+	}
+
+	spec := tenantControlPlane.SplitComponent(r.Component)
+
+	return spec != nil && spec.PodDisruptionBudget != nil
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared(tenantControlPlane) && r.exists
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		spec := tenantControlPlane.SplitComponent(r.Component).PodDisruptionBudget
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		r.resource.Spec.Selector = &metav1.LabelSelector{MatchLabels: builder.ComponentSelector(tenantControlPlane, r.Component)}
+		r.resource.Spec.MinAvailable = spec.MinAvailable
+		r.resource.Spec.MaxUnavailable = spec.MaxUnavailable
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) GetName() string {
+	return string(r.Component) + "-pdb"
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *KubernetesComponentPodDisruptionBudgetResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -15,6 +16,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/mutators"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -36,7 +38,13 @@ func (r *KubernetesDeploymentResource) isStatusEqual(tenantControlPlane *kamajiv
 }
 
 func (r *KubernetesDeploymentResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version
+	return !r.isStatusEqual(tenantControlPlane) || tenantControlPlane.Spec.Kubernetes.Version != tenantControlPlane.Status.Kubernetes.Version.Version ||
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Kubernetes.Deployment.Zones, r.zones(tenantControlPlane))
+}
+
+// zones returns the replicas expected in each zone of the DataStore endpoints.
+func (r *KubernetesDeploymentResource) zones(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) []kamajiv1alpha1.DataStoreZoneReplicas {
+	return r.DataStore.Spec.ZoneReplicas(ptr.Deref(tenantControlPlane.Spec.ControlPlane.Deployment.Replicas, 2))
 }
 
 func (r *KubernetesDeploymentResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -50,7 +58,7 @@ func (r *KubernetesDeploymentResource) CleanUp(context.Context, *kamajiv1alpha1.
 func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	r.resource = &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tenantControlPlane.GetName(),
+			Name:      utilities.ObjectName(tenantControlPlane),
 			Namespace: tenantControlPlane.GetNamespace(),
 		},
 	}
@@ -60,18 +68,28 @@ func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPl
 
 func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() error {
+		imageProfile, err := utilities.GetImageProfile(ctx, r.Client, tenantControlPlane)
+		if err != nil {
+			return err
+		}
+
 		(builder.Deployment{
 			Client:             r.Client,
 			DataStore:          r.DataStore,
 			KineContainerImage: r.KineContainerImage,
+			ImageProfile:       imageProfile,
 		}).Build(ctx, r.resource, *tenantControlPlane)
 
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
 		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
 
 func (r *KubernetesDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *KubernetesDeploymentResource) GetName() string {
@@ -99,6 +117,7 @@ func (r *KubernetesDeploymentResource) UpdateTenantControlPlaneStatus(_ context.
 		Name:             r.resource.GetName(),
 		Namespace:        r.resource.GetNamespace(),
 		LastUpdate:       metav1.Now(),
+		Zones:            r.zones(tenantControlPlane),
 	}
 
 	return nil
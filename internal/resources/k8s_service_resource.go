@@ -5,18 +5,22 @@ package resources
 
 import (
 	"context"
+	"fmt"
 	"net"
 	"strconv"
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/mutators"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -25,6 +29,8 @@ import (
 type KubernetesServiceResource struct {
 	resource *corev1.Service
 	Client   client.Client
+	// Recorder emits the Tenant Control Plane events, such as the endpoint changes: it's optional.
+	Recorder record.EventRecorder
 }
 
 func (r *KubernetesServiceResource) GetHistogram() prometheus.Histogram {
@@ -33,10 +39,18 @@ func (r *KubernetesServiceResource) GetHistogram() prometheus.Histogram {
 	return serviceCollector
 }
 
-func (r *KubernetesServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
+func (r *KubernetesServiceResource) ShouldStatusBeUpdated(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Status.Kubernetes.Service.Name != r.resource.GetName() ||
 		tenantControlPlane.Status.Kubernetes.Service.Namespace != r.resource.GetNamespace() ||
-		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port
+		tenantControlPlane.Status.Kubernetes.Service.Port != r.resource.Spec.Ports[0].Port ||
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Kubernetes.Service.ServiceStatus, r.resource.Status) {
+		return true
+	}
+	// The load balancer could assign a different address with no changes to the Service specification:
+	// the endpoint must be tracked to issue again the API Server certificate, and to roll out the Control Plane.
+	endpoint, err := r.controlPlaneEndpoint(ctx, tenantControlPlane)
+
+	return err == nil && endpoint != tenantControlPlane.Status.ControlPlaneEndpoint
 }
 
 func (r *KubernetesServiceResource) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
@@ -53,19 +67,40 @@ func (r *KubernetesServiceResource) UpdateTenantControlPlaneStatus(ctx context.C
 	tenantControlPlane.Status.Kubernetes.Service.Namespace = r.resource.GetNamespace()
 	tenantControlPlane.Status.Kubernetes.Service.Port = r.resource.Spec.Ports[0].Port
 
-	address, err := tenantControlPlane.DeclaredControlPlaneAddress(ctx, r.Client)
+	endpoint, err := r.controlPlaneEndpoint(ctx, tenantControlPlane)
 	if err != nil {
 		return err
 	}
-	tenantControlPlane.Status.ControlPlaneEndpoint = net.JoinHostPort(address, strconv.FormatInt(int64(tenantControlPlane.Spec.NetworkProfile.Port), 10))
+
+	if previous := tenantControlPlane.Status.ControlPlaneEndpoint; len(previous) > 0 && previous != endpoint && r.Recorder != nil {
+		r.Recorder.Event(tenantControlPlane, corev1.EventTypeNormal, "ControlPlaneEndpointChanged",
+			fmt.Sprintf("Control Plane endpoint changed from %s to %s, the API Server certificate is going to be issued again", previous, endpoint))
+	}
+
+	tenantControlPlane.Status.ControlPlaneEndpoint = endpoint
 
 	return nil
 }
 
+func (r *KubernetesServiceResource) controlPlaneEndpoint(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	address, err := tenantControlPlane.DeclaredControlPlaneAddress(ctx, r.Client)
+	if err != nil {
+		return "", err
+	}
+
+	port := tenantControlPlane.Spec.NetworkProfile.Port
+	// In the host network mode, the worker nodes are reaching the kube-apiserver through the node addresses.
+	if tenantControlPlane.HostNetwork() != nil {
+		port = tenantControlPlane.APIServerPort()
+	}
+
+	return net.JoinHostPort(address, strconv.FormatInt(int64(port), 10)), nil
+}
+
 func (r *KubernetesServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	r.resource = &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tenantControlPlane.GetName(),
+			Name:      utilities.ObjectName(tenantControlPlane),
 			Namespace: tenantControlPlane.GetNamespace(),
 		},
 	}
@@ -74,7 +109,7 @@ func (r *KubernetesServiceResource) Define(_ context.Context, tenantControlPlane
 }
 
 func (r *KubernetesServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
@@ -100,7 +135,7 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 		r.resource.Spec.Ports[0].Name = "kube-apiserver"
 		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
 		r.resource.Spec.Ports[0].Port = tenantControlPlane.Spec.NetworkProfile.Port
-		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.Spec.NetworkProfile.Port))
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt(int(tenantControlPlane.APIServerPort()))
 
 		switch tenantControlPlane.Spec.ControlPlane.Service.ServiceType {
 		case kamajiv1alpha1.ServiceTypeLoadBalancer:
@@ -128,6 +163,10 @@ func (r *KubernetesServiceResource) mutate(ctx context.Context, tenantControlPla
 			}
 		}
 
+		if err := mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
 		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
 	}
 }
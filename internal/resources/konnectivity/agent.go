@@ -6,13 +6,18 @@ package konnectivity
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	appsv1 "k8s.io/api/apps/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	pointer "k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -21,6 +26,7 @@ import (
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/constants"
 	"github.com/clastix/kamaji/internal/resources"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
@@ -28,6 +34,10 @@ type Agent struct {
 	resource     client.Object
 	Client       client.Client
 	tenantClient client.Client
+	// agentClient is the client of the cluster hosting the agent:
+	// the remote one, when declared, the Tenant Cluster otherwise.
+	agentClient    client.Client
+	workloadStatus kamajiv1alpha1.AddonWorkloadStatus
 }
 
 func (r *Agent) GetHistogram() prometheus.Histogram {
@@ -39,7 +49,13 @@ func (r *Agent) GetHistogram() prometheus.Histogram {
 func (r *Agent) ShouldStatusBeUpdated(_ context.Context, tcp *kamajiv1alpha1.TenantControlPlane) bool {
 	return tcp.Spec.Addons.Konnectivity == nil && (tcp.Status.Addons.Konnectivity.Agent.Namespace != "" || tcp.Status.Addons.Konnectivity.Agent.Name != "") ||
 		tcp.Spec.Addons.Konnectivity != nil && (tcp.Status.Addons.Konnectivity.Agent.Namespace != r.resource.GetNamespace() || tcp.Status.Addons.Konnectivity.Agent.Name != r.resource.GetName()) ||
-		tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode != tcp.Status.Addons.Konnectivity.Agent.Mode
+		tcp.Spec.Addons.Konnectivity != nil && tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode != tcp.Status.Addons.Konnectivity.Agent.Mode ||
+		tcp.Spec.Addons.Konnectivity != nil && isRemote(tcp) != tcp.Status.Addons.Konnectivity.Agent.Remote ||
+		tcp.Spec.Addons.Konnectivity != nil && addons_utils.WorkloadStatusChanged(r.workloadStatus, tcp.Status.Addons.Konnectivity.Agent.AddonWorkloadStatus)
+}
+
+func isRemote(tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	return tcp.Spec.Addons.Konnectivity != nil && tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.RemoteCluster != nil
 }
 
 func (r *Agent) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
@@ -49,7 +65,7 @@ func (r *Agent) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPl
 func (r *Agent) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
 	logger := log.FromContext(ctx, "resource", r.GetName())
 
-	if err := r.tenantClient.Get(ctx, client.ObjectKeyFromObject(r.resource), r.resource); err != nil {
+	if err := r.agentClient.Get(ctx, client.ObjectKeyFromObject(r.resource), r.resource); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return false, nil
 		}
@@ -63,7 +79,7 @@ func (r *Agent) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlan
 		return false, nil
 	}
 
-	if err := r.tenantClient.Delete(ctx, r.resource); err != nil {
+	if err := r.agentClient.Delete(ctx, r.resource); err != nil {
 		if k8serrors.IsNotFound(err) {
 			return false, nil
 		}
@@ -79,10 +95,14 @@ func (r *Agent) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlan
 func (r *Agent) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
 	logger := log.FromContext(ctx, "resource", r.GetName())
 
-	switch tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode {
-	case kamajiv1alpha1.KonnectivityAgentModeDaemonSet:
+	switch {
+	case tenantControlPlane.Spec.Addons.Konnectivity == nil:
+		// The addon is disabled, such as when another node connectivity provider is used:
+		// the DaemonSet is the only object to be cleaned up, the Deployment mode is removed upon changes.
 		r.resource = &appsv1.DaemonSet{}
-	case kamajiv1alpha1.KonnectivityAgentModeDeployment:
+	case tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDaemonSet:
+		r.resource = &appsv1.DaemonSet{}
+	case tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDeployment:
 		r.resource = &appsv1.Deployment{}
 	default:
 		logger.Info("TenantControlPlane CRD is not updated, or validation failed, fallback to DaemonSet")
@@ -99,16 +119,61 @@ func (r *Agent) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 		return err
 	}
 
+	r.agentClient = r.tenantClient
+
+	if isRemote(tenantControlPlane) {
+		if r.agentClient, err = utilities.GetRemoteClusterClient(ctx, r.Client, tenantControlPlane, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.RemoteCluster.KubeconfigSecretRef); err != nil {
+			logger.Error(err, "unable to retrieve the remote cluster client")
+
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
-		or, err := controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resource, r.mutate(ctx, tenantControlPlane))
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		if isRemote(tenantControlPlane) {
+			if err := r.ensureRemoteToken(ctx, tenantControlPlane); err != nil {
+				logger.Error(err, "cannot reconcile the agent token in the remote cluster")
+
+				return controllerutil.OperationResultNone, err
+			}
+		}
+
+		or, err := utilities.ServerSideApply(ctx, r.agentClient, r.resource, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.AddonApplyTrait, r.mutate(ctx, tenantControlPlane))
+		if err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		r.workloadStatus, err = addons_utils.GetWorkloadStatus(ctx, r.agentClient, r.resource, tenantControlPlane.Status.Addons.Konnectivity.Agent.AddonWorkloadStatus)
 		if err != nil {
+			logger.Error(err, "cannot retrieve the agent status")
+
 			return controllerutil.OperationResultNone, err
 		}
 
+		switch remote := isRemote(tenantControlPlane); {
+		case remote && !tenantControlPlane.Status.Addons.Konnectivity.Agent.Remote:
+			// The agent has been moved to the remote cluster, the one of the Tenant Cluster is no longer required.
+			for _, obj := range []client.Object{&appsv1.DaemonSet{}, &appsv1.Deployment{}} {
+				obj.SetName(r.resource.GetName())
+				obj.SetNamespace(r.resource.GetNamespace())
+
+				if cleanupErr := r.tenantClient.Delete(ctx, obj); cleanupErr != nil && !k8serrors.IsNotFound(cleanupErr) {
+					logger.Error(cleanupErr, "cannot cleanup the agent of the Tenant Cluster")
+				}
+			}
+
+			return or, nil
+		case !remote && tenantControlPlane.Status.Addons.Konnectivity.Agent.Remote:
+			// The reference to the remote cluster is gone, its agent cannot be removed by Kamaji.
+			logger.Info("the agent has been moved from the remote cluster to the Tenant Cluster, the remote one must be removed manually")
+		}
+
 		switch {
 		case tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDaemonSet &&
 			tenantControlPlane.Status.Addons.Konnectivity.Agent.Mode != kamajiv1alpha1.KonnectivityAgentModeDaemonSet:
@@ -116,8 +181,8 @@ func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1
 			obj.SetName(r.resource.GetName())
 			obj.SetNamespace(r.resource.GetNamespace())
 
-			if cleanupErr := r.tenantClient.Delete(ctx, &obj); cleanupErr != nil {
-				log.FromContext(ctx, "resource", r.GetName()).Error(cleanupErr, "cannot cleanup older appsv1.Deployment")
+			if cleanupErr := r.agentClient.Delete(ctx, &obj); cleanupErr != nil {
+				logger.Error(cleanupErr, "cannot cleanup older appsv1.Deployment")
 			}
 		case tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode == kamajiv1alpha1.KonnectivityAgentModeDeployment &&
 			tenantControlPlane.Status.Addons.Konnectivity.Agent.Mode != kamajiv1alpha1.KonnectivityAgentModeDeployment:
@@ -125,8 +190,8 @@ func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1
 			obj.SetName(r.resource.GetName())
 			obj.SetNamespace(r.resource.GetNamespace())
 
-			if cleanupErr := r.tenantClient.Delete(ctx, &obj); cleanupErr != nil {
-				log.FromContext(ctx, "resource", r.GetName()).Error(cleanupErr, "cannot cleanup older appsv1.DaemonSet")
+			if cleanupErr := r.agentClient.Delete(ctx, &obj); cleanupErr != nil {
+				logger.Error(cleanupErr, "cannot cleanup older appsv1.DaemonSet")
 			}
 		}
 
@@ -136,6 +201,67 @@ func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1
 	return controllerutil.OperationResultNone, nil
 }
 
+// ensureRemoteToken stores in the remote cluster the agent token, issued by the Tenant Cluster for the agent ServiceAccount,
+// along with the Tenant Cluster CA: the token is issued again once half of its lifetime is elapsed.
+func (r *Agent) ensureRemoteToken(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	secret := &corev1.Secret{}
+	secret.SetName(agentTokenName)
+	secret.SetNamespace(AgentNamespace)
+
+	if err := r.agentClient.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the agent token Secret")
+	}
+
+	token, refreshAt := secret.Data[agentTokenName], time.Time{}
+	if value, ok := secret.GetAnnotations()[agentTokenRefreshAnnotation]; ok {
+		refreshAt, _ = time.Parse(time.RFC3339, value)
+	}
+
+	if len(token) == 0 || time.Now().After(refreshAt) {
+		sa := &corev1.ServiceAccount{}
+		sa.SetName(AgentName)
+		sa.SetNamespace(AgentNamespace)
+
+		tokenRequest := &authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				Audiences:         []string{tenantControlPlane.Status.Addons.Konnectivity.ClusterRoleBinding.Name},
+				ExpirationSeconds: pointer.To(int64(remoteAgentTokenExpiration.Seconds())),
+			},
+		}
+
+		if err := r.tenantClient.SubResource("token").Create(ctx, sa, tokenRequest); err != nil {
+			return errors.Wrap(err, "cannot issue the agent token")
+		}
+
+		token = []byte(tokenRequest.Status.Token)
+		refreshAt = time.Now().Add(remoteAgentTokenExpiration / 2)
+	}
+
+	var ca corev1.Secret
+	if err := r.Client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}, &ca); err != nil {
+		return errors.Wrap(err, "cannot retrieve the Tenant Control Plane CA")
+	}
+
+	applied := &corev1.Secret{}
+	applied.SetName(secret.GetName())
+	applied.SetNamespace(secret.GetNamespace())
+
+	_, err := utilities.ServerSideApply(ctx, r.agentClient, applied, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.AddonApplyTrait, func() error {
+		applied.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
+		applied.SetAnnotations(map[string]string{
+			agentTokenRefreshAnnotation: refreshAt.UTC().Format(time.RFC3339),
+		})
+		applied.Data = map[string][]byte{
+			agentTokenName:              token,
+			kubeadmconstants.CACertName: ca.Data[kubeadmconstants.CACertName],
+		}
+
+		return nil
+	})
+
+	return err
+}
+
 func (r *Agent) GetName() string {
 	return "konnectivity-agent"
 }
@@ -150,7 +276,9 @@ func (r *Agent) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlP
 				Namespace:  r.resource.GetNamespace(),
 				LastUpdate: metav1.Now(),
 			},
-			Mode: tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode,
+			Mode:                tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode,
+			Remote:              isRemote(tenantControlPlane),
+			AddonWorkloadStatus: r.workloadStatus,
 		}
 	}
 
@@ -161,9 +289,16 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 	return func() error {
 		logger := log.FromContext(ctx, "resource", r.GetName())
 
-		address, _, err := tenantControlPlane.AssignedControlPlaneAddress()
+		address, err := tenantControlPlane.KonnectivityServerAddress()
 		if err != nil {
-			logger.Error(err, "unable to retrieve the Tenant Control Plane address")
+			logger.Error(err, "unable to retrieve the Konnectivity server address")
+
+			return err
+		}
+
+		imageProfile, err := utilities.GetImageProfile(ctx, r.Client, tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "unable to retrieve the Image Profile")
 
 			return err
 		}
@@ -194,6 +329,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			"kubernetes.io/os": "linux",
 		}
 		podTemplateSpec.Spec.ServiceAccountName = AgentName
+		podTemplateSpec.Spec.AutomountServiceAccountToken = nil
 		podTemplateSpec.Spec.Volumes = []corev1.Volume{
 			{
 				Name: agentTokenName,
@@ -218,7 +354,7 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			podTemplateSpec.Spec.Containers = make([]corev1.Container, 1)
 		}
 
-		podTemplateSpec.Spec.Containers[0].Image = fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Version)
+		podTemplateSpec.Spec.Containers[0].Image = imageProfile.Resolve(kamajiv1alpha1.ImageProfileKonnectivityAgent, fmt.Sprintf("%s:%s", tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Image, tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Version))
 		podTemplateSpec.Spec.Containers[0].Name = AgentName
 		podTemplateSpec.Spec.Containers[0].Command = []string{"/proxy-agent"}
 
@@ -238,6 +374,10 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			args[k] = v
 		}
 
+		if isRemote(tenantControlPlane) {
+			r.setRemoteToken(podTemplateSpec, args)
+		}
+
 		podTemplateSpec.Spec.Containers[0].Args = utilities.ArgsFromMapToSlice(args)
 		podTemplateSpec.Spec.Containers[0].VolumeMounts = []corev1.VolumeMount{
 			{
@@ -260,16 +400,54 @@ func (r *Agent) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.T
 			FailureThreshold:    3,
 		}
 
+		maxUnavailable := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.MaxUnavailable
+
 		switch tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Mode {
 		case kamajiv1alpha1.KonnectivityAgentModeDaemonSet:
 			r.resource.(*appsv1.DaemonSet).Spec.Template = *podTemplateSpec //nolint:forcetypeassert
+			// The agents are rolled out in waves, keeping the tunnels of the remaining ones up.
+			if maxUnavailable != nil {
+				//nolint:forcetypeassert
+				r.resource.(*appsv1.DaemonSet).Spec.UpdateStrategy = appsv1.DaemonSetUpdateStrategy{
+					Type:          appsv1.RollingUpdateDaemonSetStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDaemonSet{MaxUnavailable: maxUnavailable},
+				}
+			}
 		case kamajiv1alpha1.KonnectivityAgentModeDeployment:
 			//nolint:forcetypeassert
 			r.resource.(*appsv1.Deployment).Spec.Template = *podTemplateSpec
 			//nolint:forcetypeassert
 			r.resource.(*appsv1.Deployment).Spec.Replicas = pointer.To(tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityAgentSpec.Replicas)
+
+			if maxUnavailable != nil {
+				//nolint:forcetypeassert
+				r.resource.(*appsv1.Deployment).Spec.Strategy = appsv1.DeploymentStrategy{
+					Type:          appsv1.RollingUpdateDeploymentStrategyType,
+					RollingUpdate: &appsv1.RollingUpdateDeployment{MaxUnavailable: maxUnavailable},
+				}
+			}
 		}
 
 		return nil
 	}
 }
+
+// setRemoteToken replaces the projected ServiceAccount token, issued by the remote cluster, with the Secret containing
+// the token issued by the Tenant Cluster, and its CA, since the remote cluster ServiceAccount is unknown to the Tenant Cluster.
+func (r *Agent) setRemoteToken(podTemplateSpec *corev1.PodTemplateSpec, args map[string]string) {
+	podTemplateSpec.Spec.ServiceAccountName = ""
+	podTemplateSpec.Spec.AutomountServiceAccountToken = pointer.To(false)
+	podTemplateSpec.Spec.Volumes = []corev1.Volume{
+		{
+			Name: agentTokenName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  agentTokenName,
+					DefaultMode: pointer.To(int32(420)),
+				},
+			},
+		},
+	}
+
+	args["--ca-cert"] = "/var/run/secrets/tokens/" + kubeadmconstants.CACertName
+}
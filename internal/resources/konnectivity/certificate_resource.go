@@ -77,7 +77,7 @@ func (r *CertificateResource) CreateOrUpdate(ctx context.Context, tenantControlP
 		return controllerutil.OperationResultNone, nil
 	}
 
-	return controllerutil.CreateOrUpdate(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *CertificateResource) GetName() string {
@@ -140,7 +140,7 @@ func (r *CertificateResource) mutate(ctx context.Context, tenantControlPlane *ka
 			PrivateKey:  secretCA.Data[kubeadmconstants.CAKeyName],
 		}
 
-		cert, privKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(CertCommonName), ca.Certificate, ca.PrivateKey)
+		cert, privKey, err := crypto.GenerateCertificatePrivateKeyPair(crypto.NewCertificateTemplate(CertCommonName), ca.Certificate, ca.PrivateKey, tenantControlPlane.IsFIPS())
 		if err != nil {
 			logger.Error(err, "unable to generate certificate and private key")
 
@@ -4,6 +4,8 @@
 package konnectivity
 
 import (
+	"time"
+
 	"k8s.io/kubernetes/pkg/apis/core"
 )
 
@@ -13,13 +15,35 @@ const (
 	AgentNamespace = core.NamespaceSystem
 
 	agentTokenName                  = "konnectivity-agent-token"
+	agentTokenRefreshAnnotation     = "konnectivity.kamaji.clastix.io/token-refresh"
 	apiServerAPIVersion             = "apiserver.k8s.io/v1beta1"
 	defaultClusterName              = "kubernetes"
 	defaultUDSName                  = "/run/konnectivity/konnectivity-server.socket"
 	egressSelectorConfigurationKind = "EgressSelectorConfiguration"
-	egressSelectorConfigurationName = "cluster"
 	konnectivityCertAndKeyBaseName  = "konnectivity"
 	konnectivityKubeconfigFileName  = "konnectivity-server.conf"
+	konnectivityServerCertFileName  = "konnectivity-server.crt"
+	konnectivityServerKeyFileName   = "konnectivity-server.key"
+	konnectivityKubeconfigMountPath = "/etc/kubernetes/konnectivity/kubeconfig"
 	kubeconfigAPIVersion            = "v1"
 	roleAuthDelegator               = "system:auth-delegator"
 )
+
+// RemoteAgentTokenResyncPeriod is the period of the reconciliation of the agents deployed in a remote cluster:
+// their token is issued by the Tenant Cluster, and it must be refreshed before its expiration.
+const RemoteAgentTokenResyncPeriod = time.Hour
+
+const remoteAgentTokenExpiration = 24 * time.Hour
+
+const (
+	// ServerAdminPort serves the metrics of the Konnectivity server.
+	ServerAdminPort = 8133
+	// ReadyBackendConnectionsMetric is the gauge of the agents connected to a Konnectivity server.
+	ReadyBackendConnectionsMetric = "konnectivity_network_proxy_server_ready_backend_connections"
+	// AgentRestartAnnotation triggers the rollout of the agents, as kubectl rollout restart does.
+	AgentRestartAnnotation = "kubectl.kubernetes.io/restartedAt"
+)
+
+// CertificatesPropagationPeriod is the time required by a rotated Konnectivity server certificate to be reloaded
+// by the running servers: the Secret is synced by the kubelet, and the certificate files are reloaded by the client.
+const CertificatesPropagationPeriod = 10 * time.Minute
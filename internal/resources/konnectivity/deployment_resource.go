@@ -16,6 +16,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/mutators"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -74,7 +75,7 @@ func (r *KubernetesDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1a
 func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	r.resource = &appsv1.Deployment{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tenantControlPlane.GetName(),
+			Name:      utilities.ObjectName(tenantControlPlane),
 			Namespace: tenantControlPlane.GetNamespace(),
 		},
 	}
@@ -82,7 +83,7 @@ func (r *KubernetesDeploymentResource) Define(_ context.Context, tenantControlPl
 	return nil
 }
 
-func (r *KubernetesDeploymentResource) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+func (r *KubernetesDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
 	return func() (err error) {
 		// If konnectivity is disabled, no operation is required:
 		// removal of the container will be performed by clean-up.
@@ -94,9 +95,14 @@ func (r *KubernetesDeploymentResource) mutate(_ context.Context, tenantControlPl
 			return fmt.Errorf("the Deployment resource is not ready to be mangled for Konnectivity server enrichment")
 		}
 
+		if r.Builder.ImageProfile, err = utilities.GetImageProfile(ctx, r.Client, tenantControlPlane); err != nil {
+			return err
+		}
+
 		r.Builder.Build(r.resource, *tenantControlPlane)
 
-		return nil
+		// The mutators are applied by both the Deployment resources, since each one renders only its own fields.
+		return mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource)
 	}
 }
 
@@ -105,7 +111,7 @@ func (r *KubernetesDeploymentResource) CreateOrUpdate(ctx context.Context, tenan
 		return controllerutil.OperationResultNone, nil
 	}
 
-	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *KubernetesDeploymentResource) GetName() string {
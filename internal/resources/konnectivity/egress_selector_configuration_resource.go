@@ -5,6 +5,8 @@ package konnectivity
 
 import (
 	"context"
+	"fmt"
+	"path"
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
@@ -17,6 +19,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/mutators"
 	"github.com/clastix/kamaji/internal/resources"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -90,7 +94,7 @@ func (r *EgressSelectorConfigurationResource) UpdateTenantControlPlaneStatus(_ c
 	return nil
 }
 
-func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
+func (r *EgressSelectorConfigurationResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
 	return func() error {
 		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
 
@@ -99,19 +103,34 @@ func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantCo
 				Kind:       egressSelectorConfigurationKind,
 				APIVersion: apiServerAPIVersion,
 			},
-			EgressSelections: []apiserverv1alpha1.EgressSelection{
-				{
-					Name: egressSelectorConfigurationName,
-					Connection: apiserverv1alpha1.Connection{
-						ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
-						Transport: &apiserverv1alpha1.Transport{
-							UDS: &apiserverv1alpha1.UDSTransport{
-								UDSName: defaultUDSName,
-							},
-						},
+		}
+		transport := &apiserverv1alpha1.Transport{
+			UDS: &apiserverv1alpha1.UDSTransport{
+				UDSName: defaultUDSName,
+			},
+		}
+		// The dedicated Konnectivity servers are reached through their Service, using mutual TLS.
+		if tenantControlPlane.Spec.Addons.Konnectivity.HasDeploymentPlacement() {
+			transport = &apiserverv1alpha1.Transport{
+				TCP: &apiserverv1alpha1.TCPTransport{
+					URL: fmt.Sprintf("https://%s.%s.svc:%d", builder.KonnectivityServerName(tenantControlPlane), tenantControlPlane.GetNamespace(), builder.KonnectivityServerTunnelPort),
+					TLSConfig: &apiserverv1alpha1.TLSConfig{
+						CABundle:   "/etc/kubernetes/pki/ca.crt",
+						ClientKey:  path.Join(builder.KonnectivityClientCertificatePath, corev1.TLSPrivateKeyKey),
+						ClientCert: path.Join(builder.KonnectivityClientCertificatePath, corev1.TLSCertKey),
 					},
 				},
-			},
+			}
+		}
+		// The traffic types not listed are established directly by the API Server.
+		for _, selection := range tenantControlPlane.Spec.Addons.Konnectivity.GetEgressSelections() {
+			configuration.EgressSelections = append(configuration.EgressSelections, apiserverv1alpha1.EgressSelection{
+				Name: string(selection),
+				Connection: apiserverv1alpha1.Connection{
+					ProxyProtocol: apiserverv1alpha1.ProtocolGRPC,
+					Transport:     transport,
+				},
+			})
 		}
 
 		yamlConfiguration, err := utilities.EncodeToYaml(configuration)
@@ -123,6 +142,10 @@ func (r *EgressSelectorConfigurationResource) mutate(_ context.Context, tenantCo
 			"egress-selector-configuration.yaml": string(yamlConfiguration),
 		}
 
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
 		utilities.SetObjectChecksum(r.resource, r.resource.Data)
 
 		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
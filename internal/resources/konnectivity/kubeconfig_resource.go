@@ -4,12 +4,15 @@
 package konnectivity
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"path"
 
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
@@ -25,9 +28,13 @@ import (
 	"github.com/clastix/kamaji/internal/utilities"
 )
 
+// KubeconfigResource contains the kubeconfig used by the Konnectivity server, along with its certificate files:
+// the kubeconfig references them, rather than embedding their contents, allowing the servers to reload them upon a rotation.
 type KubeconfigResource struct {
 	resource *corev1.Secret
 	Client   client.Client
+
+	rotated bool
 }
 
 func (r *KubeconfigResource) GetHistogram() prometheus.Histogram {
@@ -76,7 +83,7 @@ func (r *KubeconfigResource) CreateOrUpdate(ctx context.Context, tenantControlPl
 		return controllerutil.OperationResultNone, nil
 	}
 
-	return controllerutil.CreateOrUpdate(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *KubeconfigResource) GetName() string {
@@ -86,10 +93,24 @@ func (r *KubeconfigResource) GetName() string {
 func (r *KubeconfigResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig = kamajiv1alpha1.KubeconfigStatus{}
 
-	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
-		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.LastUpdate = metav1.Now()
-		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.SecretName = r.resource.GetName()
-		tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum = utilities.GetObjectChecksum(r.resource)
+	if tenantControlPlane.Spec.Addons.Konnectivity == nil {
+		meta.RemoveStatusCondition(&tenantControlPlane.Status.Conditions, kamajiv1alpha1.ConditionKonnectivityCertificatesRotating)
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.LastUpdate = metav1.Now()
+	tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum = utilities.GetObjectChecksum(r.resource)
+	// The condition is reverted once the certificate has been reloaded by the servers, as tracked by the Konnectivity health controller.
+	if r.rotated {
+		meta.SetStatusCondition(&tenantControlPlane.Status.Conditions, metav1.Condition{
+			Type:               kamajiv1alpha1.ConditionKonnectivityCertificatesRotating,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: tenantControlPlane.GetGeneration(),
+			Reason:             kamajiv1alpha1.ReasonCertificatesPropagating,
+			Message:            "the Konnectivity server certificate has been issued, waiting for the servers to reload it",
+		})
 	}
 
 	return nil
@@ -103,7 +124,8 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			r.resource.GetLabels(),
 			utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()),
 			map[string]string{
-				constants.ControllerLabelResource: utilities.CertificateKubeconfigLabel,
+				// The certificate is tracked from its file, since the kubeconfig is not embedding it.
+				constants.ControllerLabelResource: utilities.CertificateX509Label,
 			},
 		))
 
@@ -115,8 +137,19 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 
 		isRotationRequested := utilities.IsRotationRequested(r.resource)
 
+		certificateNamespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Addons.Konnectivity.Certificate.SecretName}
+		secretCertificate := &corev1.Secret{}
+		if err := r.Client.Get(ctx, certificateNamespacedName, secretCertificate); err != nil {
+			logger.Error(err, "cannot retrieve the Konnectivity Certificate secret")
+
+			return err
+		}
+		// A certificate different from the stored one has been issued, thus it must be propagated to the servers.
+		currentCertificate := r.resource.Data[konnectivityServerCertFileName]
+		isCertificateChanged := !bytes.Equal(currentCertificate, secretCertificate.Data[corev1.TLSCertKey])
+
 		checksum := tenantControlPlane.Status.Addons.Konnectivity.Kubeconfig.Checksum
-		if len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) && !isRotationRequested {
+		if len(checksum) > 0 && checksum == utilities.GetObjectChecksum(r.resource) && !isRotationRequested && !isCertificateChanged {
 			return nil
 		}
 
@@ -128,14 +161,6 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			return err
 		}
 
-		certificateNamespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Addons.Konnectivity.Certificate.SecretName}
-		secretCertificate := &corev1.Secret{}
-		if err := r.Client.Get(ctx, certificateNamespacedName, secretCertificate); err != nil {
-			logger.Error(err, "cannot retrieve the Konnectivity Certificate secret")
-
-			return err
-		}
-
 		userName := CertCommonName
 		clusterName := defaultClusterName
 		contextName := fmt.Sprintf("%s@%s", userName, clusterName)
@@ -147,8 +172,8 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 				{
 					Name: userName,
 					AuthInfo: clientcmdapiv1.AuthInfo{
-						ClientKeyData:         secretCertificate.Data[corev1.TLSPrivateKeyKey],
-						ClientCertificateData: secretCertificate.Data[corev1.TLSCertKey],
+						ClientKey:         path.Join(konnectivityKubeconfigMountPath, konnectivityServerKeyFileName),
+						ClientCertificate: path.Join(konnectivityKubeconfigMountPath, konnectivityServerCertFileName),
 					},
 				},
 			},
@@ -156,7 +181,7 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 				{
 					Name: clusterName,
 					Cluster: clientcmdapiv1.Cluster{
-						Server:                   fmt.Sprintf("https://%s:%d", "localhost", tenantControlPlane.Spec.NetworkProfile.Port),
+						Server:                   fmt.Sprintf("https://%s:%d", "localhost", tenantControlPlane.APIServerPort()),
 						CertificateAuthorityData: secretCA.Data[kubeadmconstants.CACertName],
 					},
 				},
@@ -182,7 +207,12 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 
 		r.resource.Data = map[string][]byte{
 			konnectivityKubeconfigFileName: kubeconfigBytes,
+			konnectivityServerCertFileName: secretCertificate.Data[corev1.TLSCertKey],
+			konnectivityServerKeyFileName:  secretCertificate.Data[corev1.TLSPrivateKeyKey],
 		}
+		// The secrets of the previous releases are embedding the certificate in the kubeconfig:
+		// their migration is rolling out the servers, since the mounted files are changing.
+		r.rotated = len(currentCertificate) > 0 && isCertificateChanged
 
 		utilities.SetLastRotationTimestamp(r.resource)
 
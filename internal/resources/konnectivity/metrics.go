@@ -14,6 +14,9 @@ var (
 	deploymentCollector         prometheus.Histogram
 	egressCollector             prometheus.Histogram
 	kubeconfigCollector         prometheus.Histogram
+	serverCertificateCollector  prometheus.Histogram
+	serverDeploymentCollector   prometheus.Histogram
+	serverServiceCollector      prometheus.Histogram
 	serviceaccountCollector     prometheus.Histogram
 	serviceCollector            prometheus.Histogram
 )
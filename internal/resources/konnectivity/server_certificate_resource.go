@@ -0,0 +1,210 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/crypto"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServerCertificateResource manages the serving certificate of the Konnectivity servers with the Deployment placement:
+// it's issued for the Service addresses, used by the kube-apiserver pods, and for the address advertised to the agents.
+type ServerCertificateResource struct {
+	resource *corev1.Secret
+	Client   client.Client
+
+	exists bool
+}
+
+func (r *ServerCertificateResource) GetHistogram() prometheus.Histogram {
+	serverCertificateCollector = resources.LazyLoadHistogramFromResource(serverCertificateCollector, r)
+
+	return serverCertificateCollector
+}
+
+func (r *ServerCertificateResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if hasDeploymentPlacement(tenantControlPlane) {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &corev1.Secret{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the Konnectivity server certificate")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *ServerCertificateResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !hasDeploymentPlacement(tenantControlPlane) && r.exists
+}
+
+func (r *ServerCertificateResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+func (r *ServerCertificateResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !hasDeploymentPlacement(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *ServerCertificateResource) GetName() string {
+	return "konnectivity-server-certificate"
+}
+
+func (r *ServerCertificateResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *ServerCertificateResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate = kamajiv1alpha1.CertificatePrivateKeyPairStatus{}
+
+	if hasDeploymentPlacement(tenantControlPlane) {
+		tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.LastUpdate = metav1.Now()
+		tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.SecretName = r.resource.GetName()
+		tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.Checksum = utilities.GetObjectChecksum(r.resource)
+	}
+
+	return nil
+}
+
+// serverNames returns the Service DNS names, and the address advertised to the agents.
+func (r *ServerCertificateResource) serverNames(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]string, error) {
+	name, namespace := builder.KonnectivityServerName(tenantControlPlane), tenantControlPlane.GetNamespace()
+
+	address, err := tenantControlPlane.KonnectivityServerAddress()
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		name,
+		fmt.Sprintf("%s.%s", name, namespace),
+		fmt.Sprintf("%s.%s.svc", name, namespace),
+		address,
+	}, nil
+}
+
+func (r *ServerCertificateResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		r.resource.SetLabels(utilities.MergeMaps(
+			r.resource.GetLabels(),
+			utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()),
+			map[string]string{
+				constants.ControllerLabelResource: utilities.CertificateX509Label,
+			},
+		))
+
+		if err := ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme()); err != nil {
+			logger.Error(err, "cannot set controller reference", "resource", r.GetName())
+
+			return err
+		}
+
+		names, err := r.serverNames(tenantControlPlane)
+		if err != nil {
+			return errors.Wrap(err, "cannot retrieve the Konnectivity server addresses")
+		}
+
+		isRotationRequested := utilities.IsRotationRequested(r.resource)
+
+		if checksum := tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.Checksum; !isRotationRequested && (len(checksum) > 0 && checksum == utilities.CalculateMapChecksum(r.resource.Data)) {
+			isValid, validErr := crypto.IsValidCertificateKeyPairBytes(r.resource.Data[corev1.TLSCertKey], r.resource.Data[corev1.TLSPrivateKeyKey])
+			if validErr != nil {
+				logger.Info(fmt.Sprintf("Konnectivity server certificate-private_key pair is not valid: %s", validErr.Error()))
+			}
+			// The certificate is issued again once the address advertised to the agents is changed.
+			if hasNames, _ := crypto.CheckCertificateNamesAndIPs(r.resource.Data[corev1.TLSCertKey], names); isValid && hasNames {
+				return nil
+			}
+		}
+
+		namespacedName := k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Certificates.CA.SecretName}
+		secretCA := &corev1.Secret{}
+		if err = r.Client.Get(ctx, namespacedName, secretCA); err != nil {
+			logger.Error(err, "cannot retrieve the CA secret")
+
+			return err
+		}
+
+		template := crypto.NewCertificateTemplate(CertCommonName)
+
+		for _, name := range names {
+			if ip := net.ParseIP(name); ip != nil {
+				template.IPAddresses = append(template.IPAddresses, ip)
+
+				continue
+			}
+
+			template.DNSNames = append(template.DNSNames, name)
+		}
+
+		cert, privKey, err := crypto.GenerateCertificatePrivateKeyPair(template, secretCA.Data[kubeadmconstants.CACertName], secretCA.Data[kubeadmconstants.CAKeyName], tenantControlPlane.IsFIPS())
+		if err != nil {
+			logger.Error(err, "unable to generate certificate and private key")
+
+			return err
+		}
+
+		if isRotationRequested {
+			utilities.SetLastRotationTimestamp(r.resource)
+		}
+
+		r.resource.Type = corev1.SecretTypeTLS
+		r.resource.Data = map[string][]byte{
+			corev1.TLSCertKey:       cert.Bytes(),
+			corev1.TLSPrivateKeyKey: privKey.Bytes(),
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return nil
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/mutators"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServerDeploymentResource manages the Deployment of the Konnectivity servers with the Deployment placement,
+// scaled independently of the kube-apiserver one: it's deleted once switched back to the Sidecar placement.
+type ServerDeploymentResource struct {
+	resource *appsv1.Deployment
+	Builder  builder.Konnectivity
+	Client   client.Client
+
+	exists bool
+}
+
+func (r *ServerDeploymentResource) GetHistogram() prometheus.Histogram {
+	serverDeploymentCollector = resources.LazyLoadHistogramFromResource(serverDeploymentCollector, r)
+
+	return serverDeploymentCollector
+}
+
+func (r *ServerDeploymentResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.KonnectivityServerName(tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if hasDeploymentPlacement(tenantControlPlane) {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &appsv1.Deployment{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the Konnectivity server Deployment")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *ServerDeploymentResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !hasDeploymentPlacement(tenantControlPlane) && r.exists
+}
+
+func (r *ServerDeploymentResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (r *ServerDeploymentResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !hasDeploymentPlacement(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *ServerDeploymentResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() (err error) {
+		if r.Builder.ImageProfile, err = utilities.GetImageProfile(ctx, r.Client, tenantControlPlane); err != nil {
+			return err
+		}
+
+		r.Builder.BuildServer(r.resource, *tenantControlPlane, tenantControlPlane.Status.Addons.Konnectivity.ServerCertificate.Checksum)
+
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *ServerDeploymentResource) GetName() string {
+	return "konnectivity-server-deployment"
+}
+
+func (r *ServerDeploymentResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *ServerDeploymentResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
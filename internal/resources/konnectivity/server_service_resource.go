@@ -0,0 +1,162 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package konnectivity
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServerServiceResource manages the Service of the Konnectivity servers with the Deployment placement,
+// exposing them to the agents, and to the kube-apiserver pods: it's deleted once switched back to the Sidecar placement.
+type ServerServiceResource struct {
+	resource *corev1.Service
+	Client   client.Client
+
+	exists bool
+}
+
+func (r *ServerServiceResource) GetHistogram() prometheus.Histogram {
+	serverServiceCollector = resources.LazyLoadHistogramFromResource(serverServiceCollector, r)
+
+	return serverServiceCollector
+}
+
+func (r *ServerServiceResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      builder.KonnectivityServerName(tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	if hasDeploymentPlacement(tenantControlPlane) {
+		return nil
+	}
+
+	err := r.Client.Get(ctx, client.ObjectKeyFromObject(r.resource), &corev1.Service{})
+	if err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot retrieve the Konnectivity server Service")
+	}
+
+	r.exists = err == nil
+
+	return nil
+}
+
+func (r *ServerServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !hasDeploymentPlacement(tenantControlPlane) && r.exists
+}
+
+func (r *ServerServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return false, nil
+}
+
+func (r *ServerServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !hasDeploymentPlacement(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(tenantControlPlane))
+}
+
+// mutate exposes the agent port as the Tenant Control Plane Service does, and the port used by the kube-apiserver pods,
+// which is protected by mutual TLS.
+func (r *ServerServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		r.resource.Spec.Selector = builder.KonnectivityServerSelector(tenantControlPlane)
+
+		if len(r.resource.Spec.Ports) != 2 {
+			r.resource.Spec.Ports = make([]corev1.ServicePort, 2)
+		}
+
+		port := tenantControlPlane.Spec.Addons.Konnectivity.KonnectivityServerSpec.Port
+
+		r.resource.Spec.Ports[0].Name = "konnectivity-server"
+		r.resource.Spec.Ports[0].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[0].Port = port
+		r.resource.Spec.Ports[0].TargetPort = intstr.FromInt32(port)
+		r.resource.Spec.Ports[1].Name = "konnectivity-tunnel"
+		r.resource.Spec.Ports[1].Protocol = corev1.ProtocolTCP
+		r.resource.Spec.Ports[1].Port = builder.KonnectivityServerTunnelPort
+		r.resource.Spec.Ports[1].TargetPort = intstr.FromInt32(builder.KonnectivityServerTunnelPort)
+
+		switch tenantControlPlane.Spec.ControlPlane.Service.ServiceType {
+		case kamajiv1alpha1.ServiceTypeLoadBalancer:
+			r.resource.Spec.Type = corev1.ServiceTypeLoadBalancer
+
+			if tenantControlPlane.Spec.NetworkProfile.LoadBalancerClass != nil {
+				r.resource.Spec.LoadBalancerClass = ptr.To(*tenantControlPlane.Spec.NetworkProfile.LoadBalancerClass)
+			}
+
+			r.resource.Spec.LoadBalancerSourceRanges = tenantControlPlane.Spec.NetworkProfile.LoadBalancerSourceRanges
+		case kamajiv1alpha1.ServiceTypeNodePort:
+			r.resource.Spec.Type = corev1.ServiceTypeNodePort
+			r.resource.Spec.Ports[0].NodePort = port
+		default:
+			r.resource.Spec.Type = corev1.ServiceTypeClusterIP
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *ServerServiceResource) GetName() string {
+	return "konnectivity-server-service"
+}
+
+func (r *ServerServiceResource) status() kamajiv1alpha1.KubernetesServiceStatus {
+	status := kamajiv1alpha1.KubernetesServiceStatus{
+		Name:          r.resource.GetName(),
+		Namespace:     r.resource.GetNamespace(),
+		ServiceStatus: r.resource.Status,
+	}
+
+	if len(r.resource.Spec.Ports) > 0 {
+		status.Port = r.resource.Spec.Ports[0].Port
+	}
+
+	return status
+}
+
+func (r *ServerServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return hasDeploymentPlacement(tenantControlPlane) && !equality.Semantic.DeepEqual(tenantControlPlane.Status.Addons.Konnectivity.Service, r.status())
+}
+
+func (r *ServerServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if hasDeploymentPlacement(tenantControlPlane) {
+		tenantControlPlane.Status.Addons.Konnectivity.Service = r.status()
+	}
+
+	return nil
+}
+
+func hasDeploymentPlacement(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.Konnectivity != nil && tenantControlPlane.Spec.Addons.Konnectivity.HasDeploymentPlacement()
+}
@@ -96,7 +96,7 @@ func (r *ServiceAccountResource) CreateOrUpdate(ctx context.Context, tcp *kamaji
 		return controllerutil.OperationResultNone, nil
 	}
 
-	return controllerutil.CreateOrUpdate(ctx, r.tenantClient, r.resource, r.mutate(tcp))
+	return utilities.ServerSideApply(ctx, r.tenantClient, r.resource, tcp.Spec.Addons.Konnectivity.KonnectivityAgentSpec.AddonApplyTrait, r.mutate(tcp))
 }
 
 func (r *ServiceAccountResource) GetName() string {
@@ -74,8 +74,14 @@ func (r *ServiceResource) ShouldStatusBeUpdated(_ context.Context, tenantControl
 	return false
 }
 
+// ShouldCleanup removes the Konnectivity port from the Tenant Control Plane Service with the Deployment placement too,
+// since the agents are connecting to the dedicated Service.
 func (r *ServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
-	return tenantControlPlane.Spec.Addons.Konnectivity == nil && tenantControlPlane.Status.Addons.Konnectivity.Enabled
+	if konnectivity := tenantControlPlane.Spec.Addons.Konnectivity; konnectivity != nil {
+		return konnectivity.HasDeploymentPlacement()
+	}
+
+	return tenantControlPlane.Status.Addons.Konnectivity.Enabled
 }
 
 func (r *ServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
@@ -107,6 +113,11 @@ func (r *ServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantC
 }
 
 func (r *ServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	// The status is reported by the ServerServiceResource with the Deployment placement.
+	if konnectivity := tenantControlPlane.Spec.Addons.Konnectivity; konnectivity != nil && konnectivity.HasDeploymentPlacement() {
+		return nil
+	}
+
 	tenantControlPlane.Status.Addons.Konnectivity.Service = kamajiv1alpha1.KubernetesServiceStatus{}
 
 	if tenantControlPlane.Spec.Addons.Konnectivity != nil {
@@ -122,7 +133,7 @@ func (r *ServiceResource) UpdateTenantControlPlaneStatus(_ context.Context, tena
 func (r *ServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	r.resource = &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      tenantControlPlane.GetName(),
+			Name:      utilities.ObjectName(tenantControlPlane),
 			Namespace: tenantControlPlane.GetNamespace(),
 		},
 	}
@@ -135,7 +146,7 @@ func (r *ServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane
 		return controllerutil.OperationResultNone, nil
 	}
 
-	return controllerutil.CreateOrUpdate(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(ctx, tenantControlPlane))
 }
 
 func (r *ServiceResource) mutate(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
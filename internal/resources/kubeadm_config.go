@@ -11,12 +11,14 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmapi "k8s.io/kubernetes/cmd/kubeadm/app/apis/kubeadm"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/fips"
 	"github.com/clastix/kamaji/internal/kubeadm"
 	"github.com/clastix/kamaji/internal/utilities"
 )
@@ -96,6 +98,13 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 			return err
 		}
 
+		certSANs, err := utilities.ResolveCertSANs(tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot resolve the certificate SANs")
+
+			return err
+		}
+
 		r.resource.SetLabels(utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName()))
 
 		params := kubeadm.Parameters{
@@ -104,7 +113,7 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 			TenantControlPlaneName:          tenantControlPlane.GetName(),
 			TenantControlPlaneNamespace:     tenantControlPlane.GetNamespace(),
 			TenantControlPlaneEndpoint:      r.getControlPlaneEndpoint(tenantControlPlane.Spec.ControlPlane.Ingress, address, port),
-			TenantControlPlaneCertSANs:      tenantControlPlane.Spec.NetworkProfile.CertSANs,
+			TenantControlPlaneCertSANs:      certSANs,
 			TenantControlPlaneClusterDomain: tenantControlPlane.Spec.NetworkProfile.ClusterDomain,
 			TenantControlPlanePodCIDR:       tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 			TenantControlPlaneServiceCIDR:   tenantControlPlane.Spec.NetworkProfile.ServiceCIDR,
@@ -113,6 +122,14 @@ func (r *KubeadmConfigResource) mutate(ctx context.Context, tenantControlPlane *
 			CertificatesDir:                 r.TmpDirectory,
 		}
 
+		if name := utilities.ObjectName(tenantControlPlane); name != tenantControlPlane.GetName() {
+			params.TenantControlPlaneServiceName = name
+		}
+		// The keys generated in FIPS mode are 3072 bits large, since the 2048 bits ones are deprecated.
+		if tenantControlPlane.IsFIPS() || fips.Enabled() {
+			params.EncryptionAlgorithm = string(kubeadmapi.EncryptionAlgorithmRSA3072)
+		}
+
 		config, err := kubeadm.CreateKubeadmInitConfiguration(params)
 		if err != nil {
 			return err
@@ -150,7 +150,9 @@ func (r *KubeadmPhase) GetKubeadmFunction(ctx context.Context, tcp *kamajiv1alph
 	case PhaseUploadConfigKubeadm:
 		return kubeadm.UploadKubeadmConfig, nil
 	case PhaseUploadConfigKubelet:
-		return kubeadm.UploadKubeletConfig, nil
+		return func(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
+			return kubeadm.UploadKubeletConfig(ctx, client, config)
+		}, nil
 	case PhaseBootstrapToken:
 		return func(client clientset.Interface, config *kubeadm.Configuration) ([]byte, error) {
 			config.InitConfiguration.BootstrapTokens = nil
@@ -6,9 +6,12 @@ package resources
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/kubernetes/cmd/kubeadm/app/phases/upgrade"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -83,7 +86,7 @@ func (k *KubernetesUpgrade) CreateOrUpdate(ctx context.Context, tenantControlPla
 		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot retrieve available Upgrades for Kubernetes upgrade plan")
 	}
 
-	if err = k.isUpgradable(); err != nil {
+	if err = k.isUpgradable(tenantControlPlane); err != nil {
 		return controllerutil.OperationResultNone, fmt.Errorf("the required upgrade plan is not available")
 	}
 
@@ -103,6 +106,23 @@ func (k *KubernetesUpgrade) ShouldStatusBeUpdated(context.Context, *kamajiv1alph
 func (k *KubernetesUpgrade) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	if k.inProgress {
 		tenantControlPlane.Status.Kubernetes.Version.Status = &kamajiv1alpha1.VersionUpgrading
+
+		if k.isDowngrade() {
+			annotations := tenantControlPlane.GetAnnotations()
+
+			downgrade := &kamajiv1alpha1.KubernetesVersionDowngrade{
+				From:            k.upgrade.Before.KubeVersion,
+				To:              k.upgrade.After.KubeVersion,
+				BackupReference: annotations[kamajiv1alpha1.DowngradeBackupAnnotation],
+				ApprovedAt:      metav1.Now(),
+			}
+
+			if timestamp, err := time.Parse(time.RFC3339, annotations[kamajiv1alpha1.DowngradeBackupTimestampAnnotation]); err == nil {
+				downgrade.BackupTimestamp = metav1.NewTime(timestamp)
+			}
+
+			tenantControlPlane.Status.Kubernetes.Version.Downgrade = downgrade
+		}
 	}
 
 	if tenantControlPlane.Spec.Kubernetes.Version == tenantControlPlane.Status.Kubernetes.Version.Version {
@@ -112,7 +132,16 @@ func (k *KubernetesUpgrade) UpdateTenantControlPlaneStatus(_ context.Context, te
 	return nil
 }
 
-func (k *KubernetesUpgrade) isUpgradable() error {
+// isDowngrade returns true if the desired Kubernetes version is a previous minor one,
+// as allowed by the admission webhook when forced with a fresh backup.
+func (k *KubernetesUpgrade) isDowngrade() bool {
+	newK8sVersion, newErr := version.ParseSemantic(k.upgrade.After.KubeVersion)
+	oldK8sVersion, oldErr := version.ParseSemantic(k.upgrade.Before.KubeVersion)
+
+	return newErr == nil && oldErr == nil && newK8sVersion.Minor() < oldK8sVersion.Minor()
+}
+
+func (k *KubernetesUpgrade) isUpgradable(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
 	newK8sVersion, err := version.ParseSemantic(k.upgrade.After.KubeVersion)
 	if err != nil {
 		return errors.Wrap(err, fmt.Sprintf("unable to parse normalized version %q as a semantic version", k.upgrade.After.KubeVersion))
@@ -124,6 +153,11 @@ func (k *KubernetesUpgrade) isUpgradable() error {
 	}
 
 	if newK8sVersion.Minor() < oldK8sVersion.Minor() {
+		// Forced downgrades to the previous minor release are allowed
+		if newK8sVersion.Minor()+1 == oldK8sVersion.Minor() && strings.TrimPrefix(tenantControlPlane.GetAnnotations()[kamajiv1alpha1.ForceDowngradeAnnotation], "v") == strings.TrimPrefix(tenantControlPlane.Spec.Kubernetes.Version, "v") {
+			return nil
+		}
+
 		return fmt.Errorf("cannot downgrade to a previous minor version of Kubernetes")
 	}
 	// Patch upgrades are allowed
@@ -5,6 +5,7 @@ package resources
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -38,6 +39,11 @@ func GetKubeadmManifestDeps(ctx context.Context, client client.Client, tenantCon
 		return nil, nil, errors.Wrap(err, "cannot retrieve Tenant Control Plane address")
 	}
 
+	certSANs, err := utilities.ResolveCertSANs(tenantControlPlane)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "cannot resolve the certificate SANs")
+	}
+
 	config.Kubeconfig = *kubeconfig
 	config.Parameters = kubeadm.Parameters{
 		TenantControlPlaneName:         tenantControlPlane.GetName(),
@@ -45,9 +51,13 @@ func GetKubeadmManifestDeps(ctx context.Context, client client.Client, tenantCon
 		TenantControlPlaneVersion:      tenantControlPlane.Spec.Kubernetes.Version,
 		TenantControlPlanePodCIDR:      tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 		TenantControlPlaneAddress:      address,
-		TenantControlPlaneCertSANs:     tenantControlPlane.Spec.NetworkProfile.CertSANs,
-		TenantControlPlanePort:         tenantControlPlane.Spec.NetworkProfile.Port,
+		TenantControlPlaneCertSANs:     certSANs,
+		TenantControlPlanePort:         tenantControlPlane.APIServerPort(),
 		TenantControlPlaneCGroupDriver: tenantControlPlane.Spec.Kubernetes.Kubelet.CGroupFS.String(),
+		KubeletFeatureGates:            tenantControlPlane.Spec.Kubernetes.KubeletFeatureGates(),
+		KubeletServerTLSBootstrap:      tenantControlPlane.Spec.Kubernetes.Kubelet.ServerCertificateRotation != nil,
+		KubeletConfig:                  kubeletConfigOptions(tenantControlPlane.Spec.Kubernetes.Kubelet.Config),
+		KubeletPools:                   kubeletPoolOptions(tenantControlPlane.Spec.Kubernetes.Kubelet),
 	}
 	// If CoreDNS addon is enabled and with an override, adding these to the kubeadm init configuration
 	if coreDNS := tenantControlPlane.Spec.Addons.CoreDNS; coreDNS != nil {
@@ -57,7 +67,7 @@ func GetKubeadmManifestDeps(ctx context.Context, client client.Client, tenantCon
 			config.Parameters.CoreDNSOptions.Repository = coreDNS.ImageRepository
 		}
 
-		if len(coreDNS.ImageRepository) > 0 {
+		if len(coreDNS.ImageTag) > 0 {
 			config.Parameters.CoreDNSOptions.Tag = coreDNS.ImageTag
 		}
 	}
@@ -191,6 +201,13 @@ func KubeadmPhaseCreate(ctx context.Context, r KubeadmPhaseResource, logger logr
 		return controllerutil.OperationResultNone, err
 	}
 
+	certSANs, err := utilities.ResolveCertSANs(tenantControlPlane)
+	if err != nil {
+		logger.Error(err, "cannot resolve the certificate SANs")
+
+		return controllerutil.OperationResultNone, err
+	}
+
 	config.Kubeconfig = *kubeconfig
 	config.Parameters = kubeadm.Parameters{
 		TenantControlPlaneName:         tenantControlPlane.GetName(),
@@ -198,9 +215,19 @@ func KubeadmPhaseCreate(ctx context.Context, r KubeadmPhaseResource, logger logr
 		TenantControlPlaneVersion:      tenantControlPlane.Spec.Kubernetes.Version,
 		TenantControlPlanePodCIDR:      tenantControlPlane.Spec.NetworkProfile.PodCIDR,
 		TenantControlPlaneAddress:      address,
-		TenantControlPlaneCertSANs:     tenantControlPlane.Spec.NetworkProfile.CertSANs,
-		TenantControlPlanePort:         tenantControlPlane.Spec.NetworkProfile.Port,
+		TenantControlPlaneCertSANs:     certSANs,
+		TenantControlPlanePort:         tenantControlPlane.APIServerPort(),
 		TenantControlPlaneCGroupDriver: tenantControlPlane.Spec.Kubernetes.Kubelet.CGroupFS.String(),
+		KubeletFeatureGates:            tenantControlPlane.Spec.Kubernetes.KubeletFeatureGates(),
+		KubeletServerTLSBootstrap:      tenantControlPlane.Spec.Kubernetes.Kubelet.ServerCertificateRotation != nil,
+		KubeletConfig:                  kubeletConfigOptions(tenantControlPlane.Spec.Kubernetes.Kubelet.Config),
+		KubeletPools:                   kubeletPoolOptions(tenantControlPlane.Spec.Kubernetes.Kubelet),
+	}
+
+	if config.Parameters.ClusterInfo, err = clusterInfoOptions(ctx, r.GetClient(), tenantControlPlane); err != nil {
+		logger.Error(err, "cannot resolve the cluster-info options")
+
+		return controllerutil.OperationResultNone, err
 	}
 
 	var checksum string
@@ -254,3 +281,84 @@ func KubeadmPhaseCreate(ctx context.Context, r KubeadmPhaseResource, logger logr
 
 	return controllerutil.OperationResultUpdated, nil
 }
+
+// clusterInfoOptions returns the cluster-info contents declared in the Tenant Control Plane, nil if not customized:
+// the CA bundle is read from the referenced ConfigMap, or Secret, to let its changes trigger the kubeadm phase.
+func clusterInfoOptions(ctx context.Context, reader client.Reader, tcp *kamajiv1alpha1.TenantControlPlane) (*kubeadm.ClusterInfoOptions, error) {
+	if tcp.Spec.Bootstrap == nil || tcp.Spec.Bootstrap.ClusterInfo == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	spec := tcp.Spec.Bootstrap.ClusterInfo
+
+	var options kubeadm.ClusterInfoOptions
+
+	for _, endpoint := range spec.Endpoints {
+		options.Endpoints = append(options.Endpoints, string(endpoint))
+	}
+
+	switch caBundle := spec.CABundle; {
+	case caBundle == nil:
+		break
+	case caBundle.ConfigMap != nil:
+		var configMap corev1.ConfigMap
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: caBundle.ConfigMap.Name}, &configMap); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the CA bundle ConfigMap %s", caBundle.ConfigMap.Name))
+		}
+
+		options.CABundle = []byte(configMap.Data[caBundle.ConfigMap.Key])
+	case caBundle.Secret != nil:
+		var secret corev1.Secret
+		if err := reader.Get(ctx, types.NamespacedName{Namespace: tcp.GetNamespace(), Name: caBundle.Secret.Name}, &secret); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the CA bundle Secret %s", caBundle.Secret.Name))
+		}
+
+		options.CABundle = secret.Data[caBundle.Secret.Key]
+	}
+
+	if caBundle := spec.CABundle; caBundle != nil && len(options.CABundle) == 0 {
+		return nil, errors.New("the CA bundle of the cluster-info is empty")
+	}
+
+	if len(options.Endpoints) == 0 && len(options.CABundle) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &options, nil
+}
+
+// kubeletConfigOptions returns the kubelet configuration fields declared in the Tenant Control Plane, nil if none.
+func kubeletConfigOptions(config *kamajiv1alpha1.KubeletConfigSpec) *kubeadm.KubeletConfigOptions {
+	if config == nil {
+		return nil
+	}
+
+	return &kubeadm.KubeletConfigOptions{
+		MaxPods:                 config.MaxPods,
+		SerializeImagePulls:     config.SerializeImagePulls,
+		MaxParallelImagePulls:   config.MaxParallelImagePulls,
+		EvictionHard:            config.EvictionHard,
+		EvictionSoft:            config.EvictionSoft,
+		EvictionSoftGracePeriod: config.EvictionSoftGracePeriod,
+	}
+}
+
+// kubeletPoolOptions returns the kubelet configuration variants declared in the Tenant Control Plane.
+func kubeletPoolOptions(kubelet kamajiv1alpha1.KubeletSpec) []kubeadm.KubeletPoolOptions {
+	pools := make([]kubeadm.KubeletPoolOptions, 0, len(kubelet.Pools))
+
+	for _, pool := range kubelet.Pools {
+		cgroupDriver := kubelet.CGroupFS.String()
+		if len(pool.CGroupFS) > 0 {
+			cgroupDriver = pool.CGroupFS.String()
+		}
+
+		pools = append(pools, kubeadm.KubeletPoolOptions{
+			Name:         pool.Name,
+			CGroupDriver: cgroupDriver,
+			Options:      kubeletConfigOptions(kubelet.PoolConfig(pool)),
+		})
+	}
+
+	return pools
+}
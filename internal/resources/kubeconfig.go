@@ -29,6 +29,7 @@ const (
 	SuperAdminKubeConfigFileName        = kubeadmconstants.SuperAdminKubeConfigFileName
 	ControllerManagerKubeConfigFileName = kubeadmconstants.ControllerManagerKubeConfigFileName
 	SchedulerKubeConfigFileName         = kubeadmconstants.SchedulerKubeConfigFileName
+	SootKubeConfigFileName              = constants.SootKubeConfigFileName
 	localhost                           = "127.0.0.1"
 )
 
@@ -117,6 +118,8 @@ func (r *KubeconfigResource) getKubeconfigStatus(tenantControlPlane *kamajiv1alp
 		return &tenantControlPlane.Status.KubeConfig.ControllerManager, nil
 	case kubeadmconstants.SchedulerKubeConfigFileName:
 		return &tenantControlPlane.Status.KubeConfig.Scheduler, nil
+	case constants.SootKubeConfigFileName:
+		return &tenantControlPlane.Status.KubeConfig.Soot, nil
 	default:
 		return nil, fmt.Errorf("kubeconfigfilename %s is not a right name", r.KubeConfigFileName)
 	}
@@ -160,7 +163,8 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			return err
 		}
 
-		checksum := r.checksum(caCertificatesSecret, config.Checksum())
+		server := r.serverOverride(tenantControlPlane)
+		checksum := r.checksum(caCertificatesSecret, config.Checksum()+server)
 
 		status, err := r.getKubeconfigStatus(tenantControlPlane)
 		if err != nil {
@@ -216,6 +220,14 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 				return kcErr
 			}
 
+			if len(server) > 0 {
+				if kubeconfig, kcErr = setKubeconfigServer(kubeconfig, server); kcErr != nil {
+					logger.Error(kcErr, "cannot set the kubeconfig server")
+
+					return kcErr
+				}
+			}
+
 			if shouldRotate {
 				utilities.SetLastRotationTimestamp(r.resource)
 			}
@@ -228,7 +240,7 @@ func (r *KubeconfigResource) mutate(ctx context.Context, tenantControlPlane *kam
 			if strings.Contains(r.KubeConfigFileName, "admin") {
 				key := strings.ReplaceAll(r.KubeConfigFileName, ".conf", ".svc")
 
-				config.InitConfiguration.ControlPlaneEndpoint = fmt.Sprintf("%s.%s.svc:%d", tenantControlPlane.Name, tenantControlPlane.Namespace, tenantControlPlane.Spec.NetworkProfile.Port)
+				config.InitConfiguration.ControlPlaneEndpoint = fmt.Sprintf("%s.%s.svc:%d", utilities.ObjectName(tenantControlPlane), tenantControlPlane.Namespace, tenantControlPlane.Spec.NetworkProfile.Port)
 				kubeconfig, kcErr = kubeadm.CreateKubeconfig(r.KubeConfigFileName, crtKeyPair, config)
 				if kcErr != nil {
 					logger.Error(kcErr, "cannot create a valid kubeconfig")
@@ -255,6 +267,34 @@ func (r *KubeconfigResource) customizeConfig(config *kubeadm.Configuration) erro
 	}
 }
 
+// serverOverride returns the Service endpoint for the controller-manager, and the scheduler, kubeconfigs
+// of the Tenant Control Planes with the Split topology: these are not sharing the network namespace of the kube-apiserver.
+func (r *KubeconfigResource) serverOverride(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	if !tenantControlPlane.HasSplitTopology() {
+		return ""
+	}
+
+	switch r.KubeConfigFileName {
+	case kubeadmconstants.ControllerManagerKubeConfigFileName, kubeadmconstants.SchedulerKubeConfigFileName:
+		return fmt.Sprintf("https://%s.%s.svc:%d", utilities.ObjectName(tenantControlPlane), tenantControlPlane.GetNamespace(), tenantControlPlane.Spec.NetworkProfile.Port)
+	default:
+		return ""
+	}
+}
+
+func setKubeconfigServer(kubeconfig []byte, server string) ([]byte, error) {
+	config, err := utilities.DecodeKubeconfigYAML(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range config.Clusters {
+		config.Clusters[i].Cluster.Server = server
+	}
+
+	return utilities.EncodeToYaml(config)
+}
+
 func (r *KubeconfigResource) localhostAsAdvertiseAddress(config *kubeadm.Configuration) error {
 	config.InitConfiguration.LocalAPIEndpoint.AdvertiseAddress = localhost
 
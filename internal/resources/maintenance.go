@@ -0,0 +1,261 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Maintenance notifies the Tenant Cluster workloads before the disruptive operations, such as the Certificate Authority rotation,
+// and the minor version upgrades, by means of a ConfigMap, and an Event, in the Tenant Cluster:
+// the reconciliation is held back until the ConfigMap is annotated with the operation ID, or the timeout expires.
+// The ConfigMap is deleted once the operation is completed, signalling the in-tenant automation to resume.
+type Maintenance struct {
+	Client client.Client
+
+	status *kamajiv1alpha1.MaintenanceStatus
+}
+
+func (r *Maintenance) GetHistogram() prometheus.Histogram {
+	maintenanceCollector = LazyLoadHistogramFromResource(maintenanceCollector, r)
+
+	return maintenanceCollector
+}
+
+func (r *Maintenance) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.status = tenantControlPlane.Status.Maintenance.DeepCopy()
+
+	return nil
+}
+
+func (r *Maintenance) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Maintenance == nil && tenantControlPlane.Status.Maintenance != nil
+}
+
+func (r *Maintenance) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	status := tenantControlPlane.Status.Maintenance
+	// The ConfigMap is no longer declared, it's deleted according to the notified one.
+	if err := r.deleteConfigMap(ctx, tenantControlPlane, status.ConfigMapNamespace, status.ConfigMapName); err != nil {
+		return false, err
+	}
+
+	r.status = nil
+
+	return true, nil
+}
+
+func (r *Maintenance) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	spec := tenantControlPlane.Spec.Maintenance
+	if spec == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	operation, description, err := r.pendingOperation(ctx, tenantControlPlane)
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if len(operation) == 0 {
+		// The notified operation is in progress, the ConfigMap is kept until the Tenant Control Plane is ready.
+		if r.status == nil || (r.status.Outcome != kamajiv1alpha1.MaintenanceOutcomePending && !isVersionReady(tenantControlPlane)) {
+			return controllerutil.OperationResultNone, nil
+		}
+
+		if err = r.deleteConfigMap(ctx, tenantControlPlane, r.status.ConfigMapNamespace, r.status.ConfigMapName); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+
+		r.status = nil
+
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	if r.status != nil && r.status.Operation == operation && r.status.Outcome != kamajiv1alpha1.MaintenanceOutcomePending {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	isNew := r.status == nil || r.status.Operation != operation
+	if isNew {
+		now := metav1.Now()
+
+		r.status = &kamajiv1alpha1.MaintenanceStatus{
+			ID:          fmt.Sprintf("%s-%d", strings.ToLower(string(operation)), now.Unix()),
+			Operation:   operation,
+			Description: description,
+			Outcome:     kamajiv1alpha1.MaintenanceOutcomePending,
+			NotifiedAt:  now,
+			// The ConfigMap is tracked, since its name could be changed, or the maintenance disabled, meanwhile.
+			ConfigMapName:      spec.ConfigMapName,
+			ConfigMapNamespace: spec.Namespace,
+		}
+	}
+	// The notification errors are not blocking, the operation proceeds once the timeout expires,
+	// otherwise an unreachable Tenant Cluster would prevent the operations required to recover it.
+	acknowledged, notifyErr := r.notify(ctx, tenantControlPlane, *spec, isNew)
+	if notifyErr != nil {
+		logger.Error(notifyErr, "cannot notify the Tenant Cluster about the maintenance")
+	}
+
+	switch {
+	case acknowledged:
+		r.status.Outcome = kamajiv1alpha1.MaintenanceOutcomeAcknowledged
+	case time.Since(r.status.NotifiedAt.Time) > spec.Timeout.Duration:
+		r.status.Outcome = kamajiv1alpha1.MaintenanceOutcomeTimedOut
+	default:
+		logger.Info("waiting for the maintenance acknowledgment", "id", r.status.ID, "operation", operation)
+
+		return OperationResultEnqueueBack, nil
+	}
+
+	logger.Info("maintenance notification completed", "id", r.status.ID, "outcome", r.status.Outcome)
+
+	return controllerutil.OperationResultUpdated, nil
+}
+
+// pendingOperation returns the disruptive operation about to be started, if any:
+// the operations are notified only for the ready Tenant Control Planes, since there's nothing to quiesce otherwise.
+func (r *Maintenance) pendingOperation(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (kamajiv1alpha1.MaintenanceOperation, string, error) {
+	if !isVersionReady(tenantControlPlane) {
+		return "", "", nil
+	}
+
+	if secretName := tenantControlPlane.Status.Certificates.CA.SecretName; len(secretName) > 0 {
+		var ca corev1.Secret
+		if err := r.Client.Get(ctx, types.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: secretName}, &ca); err != nil && !k8serrors.IsNotFound(err) {
+			return "", "", errors.Wrap(err, "cannot retrieve the Certificate Authority")
+		}
+
+		if utilities.IsRotationRequested(&ca) {
+			return kamajiv1alpha1.MaintenanceOperationCARotation, "rotation of the Certificate Authority", nil
+		}
+	}
+
+	current, desired := tenantControlPlane.Status.Kubernetes.Version.Version, tenantControlPlane.Spec.Kubernetes.Version
+	if len(current) == 0 || current == desired {
+		return "", "", nil
+	}
+
+	from, fromErr := version.ParseGeneric(current)
+	to, toErr := version.ParseGeneric(desired)
+	// The patch upgrades are not disruptive for the workloads, the unparsable versions are rejected by the upgrade.
+	if fromErr != nil || toErr != nil || (from.Major() == to.Major() && from.Minor() == to.Minor()) {
+		return "", "", nil
+	}
+
+	return kamajiv1alpha1.MaintenanceOperationUpgrade, fmt.Sprintf("upgrade from %s to %s", current, desired), nil
+}
+
+// notify ensures the maintenance ConfigMap in the Tenant Cluster, emitting an Event for the new operations,
+// returning whether the ConfigMap has been annotated with the operation ID.
+func (r *Maintenance) notify(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, spec kamajiv1alpha1.MaintenanceSpec, isNew bool) (bool, error) {
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		return false, errors.Wrap(err, "cannot generate Tenant client")
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: r.status.ConfigMapName, Namespace: r.status.ConfigMapNamespace}}
+
+	if _, err = controllerutil.CreateOrUpdate(ctx, tenantClient, configMap, func() error {
+		addons_utils.SetKamajiManagedLabels(configMap)
+
+		if isNew {
+			delete(configMap.Annotations, kamajiv1alpha1.MaintenanceAcknowledgedAnnotation)
+		}
+
+		configMap.Data = map[string]string{
+			"id":          r.status.ID,
+			"operation":   string(r.status.Operation),
+			"description": r.status.Description,
+			"notifiedAt":  r.status.NotifiedAt.UTC().Format(time.RFC3339),
+			"deadline":    r.status.NotifiedAt.Add(spec.Timeout.Duration).UTC().Format(time.RFC3339),
+		}
+
+		return nil
+	}); err != nil {
+		return false, errors.Wrap(err, "cannot reconcile the maintenance ConfigMap")
+	}
+
+	if isNew {
+		now := metav1.Now()
+
+		event := &corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: configMap.GetName() + "-", Namespace: configMap.GetNamespace()},
+			InvolvedObject: corev1.ObjectReference{
+				APIVersion: "v1",
+				Kind:       "ConfigMap",
+				Name:       configMap.GetName(),
+				Namespace:  configMap.GetNamespace(),
+				UID:        configMap.GetUID(),
+			},
+			Reason: "MaintenancePending",
+			Message: fmt.Sprintf("The %s starts by %s, acknowledge it by annotating the ConfigMap with %s=%s",
+				r.status.Description, configMap.Data["deadline"], kamajiv1alpha1.MaintenanceAcknowledgedAnnotation, r.status.ID),
+			Type:           corev1.EventTypeNormal,
+			Source:         corev1.EventSource{Component: "kamaji"},
+			FirstTimestamp: now,
+			LastTimestamp:  now,
+			Count:          1,
+		}
+
+		if err = tenantClient.Create(ctx, event); err != nil {
+			return false, errors.Wrap(err, "cannot emit the maintenance event")
+		}
+	}
+
+	return configMap.GetAnnotations()[kamajiv1alpha1.MaintenanceAcknowledgedAnnotation] == r.status.ID, nil
+}
+
+func (r *Maintenance) deleteConfigMap(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, namespace, name string) error {
+	tenantClient, err := utilities.GetTenantAdminClient(ctx, r.Client, tenantControlPlane)
+	if err != nil {
+		return errors.Wrap(err, "cannot generate Tenant client")
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	if err = tenantClient.Delete(ctx, configMap); err != nil && !k8serrors.IsNotFound(err) {
+		return errors.Wrap(err, "cannot delete the maintenance ConfigMap")
+	}
+
+	return nil
+}
+
+func isVersionReady(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return ptr.Deref(tenantControlPlane.Status.Kubernetes.Version.Status, kamajiv1alpha1.VersionProvisioning) == kamajiv1alpha1.VersionReady
+}
+
+func (r *Maintenance) GetName() string {
+	return "maintenance"
+}
+
+func (r *Maintenance) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !equality.Semantic.DeepEqual(tenantControlPlane.Status.Maintenance, r.status)
+}
+
+func (r *Maintenance) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	tenantControlPlane.Status.Maintenance = r.status
+
+	return nil
+}
@@ -9,18 +9,35 @@ import (
 )
 
 var (
-	apiservercertificateCollector      prometheus.Histogram
-	clientcertificateCollector         prometheus.Histogram
-	certificateauthorityCollector      prometheus.Histogram
-	frontproxycertificateCollector     prometheus.Histogram
-	frontproxycaCollector              prometheus.Histogram
-	deploymentCollector                prometheus.Histogram
-	ingressCollector                   prometheus.Histogram
-	serviceCollector                   prometheus.Histogram
-	kubeadmconfigCollector             prometheus.Histogram
-	kubeadmupgradeCollector            prometheus.Histogram
-	kubeconfigCollector                prometheus.Histogram
-	serviceaccountcertificateCollector prometheus.Histogram
+	apiservercertificateCollector        prometheus.Histogram
+	clientcertificateCollector           prometheus.Histogram
+	certificateauthorityCollector        prometheus.Histogram
+	frontproxycertificateCollector       prometheus.Histogram
+	frontproxycaCollector                prometheus.Histogram
+	deploymentCollector                  prometheus.Histogram
+	controllermanagerdeploymentCollector prometheus.Histogram
+	schedulerdeploymentCollector         prometheus.Histogram
+	controllermanagerpdbCollector        prometheus.Histogram
+	schedulerpdbCollector                prometheus.Histogram
+	ingressCollector                     prometheus.Histogram
+	serviceCollector                     prometheus.Histogram
+	kubeadmconfigCollector               prometheus.Histogram
+	kubeadmupgradeCollector              prometheus.Histogram
+	kubeconfigCollector                  prometheus.Histogram
+	serviceaccountcertificateCollector   prometheus.Histogram
+	schedulerconfigurationCollector      prometheus.Histogram
+	revisionsCollector                   prometheus.Histogram
+	apiservertracingCollector            prometheus.Histogram
+	certificaterevocationlistCollector   prometheus.Histogram
+	apiserveregresspolicyCollector       prometheus.Histogram
+	apiservernodeconnectivityCollector   prometheus.Histogram
+	maintenanceCollector                 prometheus.Histogram
+	hostnetworkCollector                 prometheus.Histogram
+	apiserveroidcdiscoveryCollector      prometheus.Histogram
+	imagesCollector                      prometheus.Histogram
+	fipscomplianceCollector              prometheus.Histogram
+	secretsbackendCollector              prometheus.Histogram
+	tenantnamespaceCollector             prometheus.Histogram
 
 	kubeadmphaseUploadConfigKubeadmCollector prometheus.Histogram
 	kubeadmphaseUploadConfigKubeletCollector prometheus.Histogram
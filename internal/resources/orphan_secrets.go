@@ -0,0 +1,66 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// OrphanSecrets removes the Tenant Control Plane owner reference from the generated Secrets,
+// retaining them upon the deletion with the Orphan policy, such as the PKI, and the kubeconfigs.
+type OrphanSecrets struct {
+	Client client.Client
+
+	secrets []corev1.Secret
+}
+
+func (r *OrphanSecrets) GetName() string {
+	return "orphan-secrets"
+}
+
+func (r *OrphanSecrets) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	var secretList corev1.SecretList
+	if err := r.Client.List(ctx, &secretList, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingFields{
+		kamajiv1alpha1.SecretTenantControlPlaneKey: client.ObjectKeyFromObject(tenantControlPlane).String(),
+	}); err != nil {
+		return errors.Wrap(err, "cannot list the Tenant Control Plane Secrets")
+	}
+
+	r.secrets = secretList.Items
+
+	return nil
+}
+
+func (r *OrphanSecrets) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	for i := range r.secrets {
+		secret := &r.secrets[i]
+
+		if err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+			if err := r.Client.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+				return err
+			}
+
+			secret.SetOwnerReferences(slices.DeleteFunc(secret.GetOwnerReferences(), func(ref metav1.OwnerReference) bool {
+				return ref.UID == tenantControlPlane.GetUID()
+			}))
+
+			return r.Client.Update(ctx, secret)
+		}); err != nil && !k8serrors.IsNotFound(err) {
+			return errors.Wrap(err, fmt.Sprintf("cannot orphan the Secret %s", secret.GetName()))
+		}
+	}
+
+	return nil
+}
@@ -69,9 +69,15 @@ type HandlerConfig struct {
 func Handle(ctx context.Context, resource Resource, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	startTime := time.Now()
 	defer func() {
-		resource.GetHistogram().Observe(time.Since(startTime).Seconds())
+		if histogram := resource.GetHistogram(); histogram != nil {
+			histogram.Observe(time.Since(startTime).Seconds())
+		}
 	}()
 
+	return handle(ctx, resource, tenantControlPlane)
+}
+
+func handle(ctx context.Context, resource Resource, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
 	if err := resource.Define(ctx, tenantControlPlane); err != nil {
 		return "", err
 	}
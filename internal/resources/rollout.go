@@ -0,0 +1,55 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// RolloutResource is implemented by the resources running the Control Plane pods:
+// WouldRollOut renders the desired state without applying it, reporting if the pods would be restarted.
+type RolloutResource interface {
+	WouldRollOut(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) (bool, error)
+}
+
+func (r *KubernetesDeploymentResource) WouldRollOut(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return wouldRollOut(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *KubernetesComponentDeploymentResource) WouldRollOut(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	if !tenantControlPlane.HasSplitTopology() {
+		return false, nil
+	}
+
+	return wouldRollOut(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+// wouldRollOut mutates in memory the deployed Deployment, comparing the resulting pod template with the current one:
+// a missing Deployment is not reported, since there are no pods to restart.
+func wouldRollOut(ctx context.Context, c client.Client, deployment *appsv1.Deployment, mutate controllerutil.MutateFn) (bool, error) {
+	if err := c.Get(ctx, client.ObjectKeyFromObject(deployment), deployment); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, errors.Wrap(err, "cannot retrieve the deployed Deployment")
+	}
+
+	current := deployment.Spec.Template.DeepCopy()
+
+	if err := mutate(); err != nil {
+		return false, errors.Wrap(err, "cannot render the desired Deployment")
+	}
+
+	return !equality.Semantic.DeepEqual(*current, deployment.Spec.Template), nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	schedulerv1 "k8s.io/kube-scheduler/config/v1"
+	schedulerscheme "k8s.io/kubernetes/pkg/scheduler/apis/config/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/mutators"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// schedulerKubeconfigPath is the path of the kubeconfig mounted in the scheduler container:
+	// the kubeconfig flag is ignored when a configuration file is provided.
+	schedulerKubeconfigPath = "/etc/kubernetes/scheduler.conf"
+)
+
+var schedulerV1MinVersion = semver.MustParse(constants.SchedulerConfigurationMinVersion)
+
+type SchedulerConfigurationResource struct {
+	resource *corev1.ConfigMap
+	Client   client.Client
+
+	apiVersion string
+}
+
+func (r *SchedulerConfigurationResource) GetHistogram() prometheus.Histogram {
+	schedulerconfigurationCollector = LazyLoadHistogramFromResource(schedulerconfigurationCollector, r)
+
+	return schedulerconfigurationCollector
+}
+
+func (r *SchedulerConfigurationResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *SchedulerConfigurationResource) isDeclared(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Kubernetes.Scheduler != nil && tenantControlPlane.Spec.Kubernetes.Scheduler.Config != nil
+}
+
+func (r *SchedulerConfigurationResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.isDeclared(tenantControlPlane) && tenantControlPlane.Status.SchedulerConfiguration != nil
+}
+
+func (r *SchedulerConfigurationResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+	// Returning true in any case, since the status must be cleared to remove the configuration from the scheduler.
+	return true, nil
+}
+
+func (r *SchedulerConfigurationResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.isDeclared(tenantControlPlane) {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(ctx, tenantControlPlane))
+}
+
+func (r *SchedulerConfigurationResource) GetName() string {
+	return "scheduler-configuration"
+}
+
+func (r *SchedulerConfigurationResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.isDeclared(tenantControlPlane) {
+		return tenantControlPlane.Status.SchedulerConfiguration != nil
+	}
+
+	return tenantControlPlane.Status.SchedulerConfiguration == nil || tenantControlPlane.Status.SchedulerConfiguration.Checksum != utilities.GetObjectChecksum(r.resource)
+}
+
+func (r *SchedulerConfigurationResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.isDeclared(tenantControlPlane) {
+		tenantControlPlane.Status.SchedulerConfiguration = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.SchedulerConfiguration = &kamajiv1alpha1.SchedulerConfigurationStatus{
+		ConfigMapName: r.resource.GetName(),
+		APIVersion:    r.apiVersion,
+		Checksum:      utilities.GetObjectChecksum(r.resource),
+		LastUpdate:    metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *SchedulerConfigurationResource) mutate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		logger := log.FromContext(ctx, "resource", r.GetName())
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		content, err := r.render(tenantControlPlane)
+		if err != nil {
+			logger.Error(err, "cannot render the scheduler configuration")
+
+			return err
+		}
+
+		r.resource.Data = map[string]string{
+			constants.SchedulerConfigurationKey: string(content),
+		}
+
+		if err = mutators.Apply(ctx, r.Client, tenantControlPlane, r.resource); err != nil {
+			return err
+		}
+
+		utilities.SetObjectChecksum(r.resource, r.resource.Data)
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// render decodes the declared KubeSchedulerConfiguration, and converts it to the API version served by the
+// Tenant Control Plane version: defaults are not applied, since they depend on the scheduler version.
+func (r *SchedulerConfigurationResource) render(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]byte, error) {
+	gv, err := r.schedulerConfigurationVersion(tenantControlPlane.Spec.Kubernetes.Version)
+	if err != nil {
+		return nil, err
+	}
+
+	obj, gvk, err := schedulerscheme.Codecs.UniversalDeserializer().Decode(tenantControlPlane.Spec.Kubernetes.Scheduler.Config.Raw, nil, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot decode the scheduler configuration")
+	}
+
+	if gvk.GroupVersion() != gv {
+		if obj, err = schedulerscheme.Scheme.ConvertToVersion(obj, gv); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot convert the scheduler configuration from %s to %s", gvk.GroupVersion(), gv))
+		}
+	}
+
+	config, ok := obj.(*schedulerv1.KubeSchedulerConfiguration)
+	if !ok {
+		return nil, fmt.Errorf("unexpected scheduler configuration type %T", obj)
+	}
+
+	config.ClientConnection.Kubeconfig = schedulerKubeconfigPath
+
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, schedulerscheme.Scheme, schedulerscheme.Scheme, json.SerializerOptions{Yaml: true})
+
+	content, err := runtime.Encode(schedulerscheme.Codecs.EncoderForVersion(serializer, gv), config)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot encode the scheduler configuration")
+	}
+
+	r.apiVersion = gv.String()
+
+	return content, nil
+}
+
+// schedulerConfigurationVersion returns the KubeSchedulerConfiguration API version served by the given Kubernetes version.
+func (r *SchedulerConfigurationResource) schedulerConfigurationVersion(kubernetesVersion string) (schema.GroupVersion, error) {
+	ver, err := semver.ParseTolerant(kubernetesVersion)
+	if err != nil {
+		return schema.GroupVersion{}, errors.Wrap(err, "cannot parse the Kubernetes version")
+	}
+
+	if ver.LT(schedulerV1MinVersion) {
+		return schema.GroupVersion{}, fmt.Errorf("the scheduler configuration is not supported for Kubernetes %s", kubernetesVersion)
+	}
+
+	return schedulerv1.SchemeGroupVersion, nil
+}
@@ -0,0 +1,136 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"slices"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/secretsbackend"
+)
+
+// SecretsBackendResource writes the generated credentials of the Tenant Control Plane, such as the PKI, and the kubeconfigs,
+// to the declared secrets backend: it must be processed once all the credentials have been generated.
+// The Kubernetes Secrets are still required, since they're mounted by the Control Plane pods.
+type SecretsBackendResource struct {
+	Client client.Client
+
+	backend secretsbackend.Backend
+	secrets []corev1.Secret
+	names   []string
+}
+
+func (r *SecretsBackendResource) GetHistogram() prometheus.Histogram {
+	secretsbackendCollector = LazyLoadHistogramFromResource(secretsbackendCollector, r)
+
+	return secretsbackendCollector
+}
+
+func (r *SecretsBackendResource) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.backend = secretsbackend.New(r.Client, tenantControlPlane); r.backend == nil {
+		return nil
+	}
+
+	var secretList corev1.SecretList
+	if err := r.Client.List(ctx, &secretList, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingFields{
+		kamajiv1alpha1.SecretTenantControlPlaneKey: client.ObjectKeyFromObject(tenantControlPlane).String(),
+	}); err != nil {
+		return errors.Wrap(err, "cannot list the Tenant Control Plane Secrets")
+	}
+
+	r.secrets = secretList.Items
+	slices.SortFunc(r.secrets, func(a, b corev1.Secret) int {
+		switch {
+		case a.GetName() < b.GetName():
+			return -1
+		case a.GetName() > b.GetName():
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	r.names = make([]string, 0, len(r.secrets))
+	for _, secret := range r.secrets {
+		r.names = append(r.names, secret.GetName())
+	}
+
+	return nil
+}
+
+func (r *SecretsBackendResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.backend == nil && tenantControlPlane.Status.SecretsBackend != nil
+}
+
+func (r *SecretsBackendResource) CleanUp(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	// The backend is no longer declared: the External Secrets one is the only supported, and it's used to stop pushing the credentials.
+	backend := &secretsbackend.ExternalSecrets{Client: r.Client}
+
+	if err := backend.Remove(ctx, tenantControlPlane, tenantControlPlane.Status.SecretsBackend.Secrets); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *SecretsBackendResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if r.backend == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	if err := r.backend.Write(ctx, tenantControlPlane, r.secrets); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	if status := tenantControlPlane.Status.SecretsBackend; status != nil {
+		var removed []string
+
+		for _, name := range status.Secrets {
+			if !slices.Contains(r.names, name) {
+				removed = append(removed, name)
+			}
+		}
+
+		if err := r.backend.Remove(ctx, tenantControlPlane, removed); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+func (r *SecretsBackendResource) GetName() string {
+	return "secrets-backend"
+}
+
+func (r *SecretsBackendResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if r.backend == nil {
+		return tenantControlPlane.Status.SecretsBackend != nil
+	}
+
+	return tenantControlPlane.Status.SecretsBackend == nil || !slices.Equal(tenantControlPlane.Status.SecretsBackend.Secrets, r.names)
+}
+
+func (r *SecretsBackendResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.backend == nil {
+		tenantControlPlane.Status.SecretsBackend = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.SecretsBackend = &kamajiv1alpha1.SecretsBackendStatus{
+		Secrets:    r.names,
+		LastUpdate: metav1.Now(),
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// SootKubeconfigResource generates the scoped kubeconfig used by Kamaji to interact with the Tenant Cluster
+// when running in least-privilege mode, rather than the admin one: it's removed once the mode is disabled.
+type SootKubeconfigResource struct {
+	KubeconfigResource
+
+	Enabled bool
+}
+
+func (r *SootKubeconfigResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return !r.Enabled && len(tenantControlPlane.Status.KubeConfig.Soot.SecretName) > 0
+}
+
+func (r *SootKubeconfigResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil && !k8serrors.IsNotFound(err) {
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+	// Returning true in any case, since the status must be cleared to fall back to the admin kubeconfig.
+	return true, nil
+}
+
+func (r *SootKubeconfigResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if !r.Enabled {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return r.KubeconfigResource.CreateOrUpdate(ctx, tenantControlPlane)
+}
+
+func (r *SootKubeconfigResource) ShouldStatusBeUpdated(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if !r.Enabled {
+		return len(tenantControlPlane.Status.KubeConfig.Soot.SecretName) > 0
+	}
+
+	return r.KubeconfigResource.ShouldStatusBeUpdated(ctx, tenantControlPlane)
+}
+
+func (r *SootKubeconfigResource) UpdateTenantControlPlaneStatus(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if !r.Enabled {
+		tenantControlPlane.Status.KubeConfig.Soot = kamajiv1alpha1.KubeconfigStatus{}
+
+		return nil
+	}
+
+	return r.KubeconfigResource.UpdateTenantControlPlaneStatus(ctx, tenantControlPlane)
+}
@@ -0,0 +1,251 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/clastix/kamaji/internal/constants"
+	addons_utils "github.com/clastix/kamaji/internal/resources/addons/utils"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const sootRoleName = "kamaji:soot"
+
+// SootNamespaces are the Tenant Cluster namespaces the soot user is allowed to reconcile.
+var SootNamespaces = []string{metav1.NamespaceSystem, metav1.NamespacePublic}
+
+var sootVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+
+// sootBoundClusterRoles are the ClusterRoles referenced by the bindings created by the kubeadm phases, and the addons:
+// the soot user is allowed to bind them, although not holding their permissions.
+var sootBoundClusterRoles = []string{
+	kubeadmconstants.NodeBootstrapperClusterRoleName,
+	kubeadmconstants.CSRAutoApprovalClusterRoleName,
+	kubeadmconstants.NodeSelfCSRAutoApprovalClusterRoleName,
+	kubeadmconstants.KubeProxyClusterRoleName,
+	"system:auth-delegator",
+}
+
+// EnsureSootRBAC grants the soot user the permissions required to reconcile the Tenant Cluster resources:
+// the namespaced ones are scoped to the kube-system, and kube-public, namespaces.
+// It must be performed with the admin client, which is also used to bind the kubeadm cluster admins,
+// since the soot user is not allowed to bind the cluster-admin ClusterRole.
+func EnsureSootRBAC(ctx context.Context, tenantClient client.Client) error {
+	clusterRole := &rbacv1.ClusterRole{ObjectMeta: metav1.ObjectMeta{Name: sootRoleName}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, tenantClient, clusterRole, func() error {
+		addons_utils.SetKamajiManagedLabels(clusterRole)
+		clusterRole.Rules = []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"clusterroles", "clusterrolebindings"},
+				Verbs:     sootVerbs,
+			},
+			{
+				APIGroups:     []string{rbacv1.GroupName},
+				Resources:     []string{"clusterroles"},
+				ResourceNames: sootBoundClusterRoles,
+				Verbs:         []string{"bind"},
+			},
+			{
+				APIGroups: []string{"admissionregistration.k8s.io"},
+				Resources: []string{"validatingwebhookconfigurations"},
+				Verbs:     sootVerbs,
+			},
+			// Required by the API Priority and Fairness objects declared for the Tenant Control Plane API server.
+			{
+				APIGroups: []string{"flowcontrol.apiserver.k8s.io"},
+				Resources: []string{"flowschemas", "prioritylevelconfigurations"},
+				Verbs:     sootVerbs,
+			},
+			// Required by the approval of the kubelet serving CSRs.
+			{
+				APIGroups: []string{"certificates.k8s.io"},
+				Resources: []string{"certificatesigningrequests"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			{
+				APIGroups: []string{"certificates.k8s.io"},
+				Resources: []string{"certificatesigningrequests/approval"},
+				Verbs:     []string{"update"},
+			},
+			{
+				APIGroups:     []string{"certificates.k8s.io"},
+				Resources:     []string{"signers"},
+				ResourceNames: []string{"kubernetes.io/kubelet-serving"},
+				Verbs:         []string{"approve"},
+			},
+			// Required by the kubeadm upgrade plan, and the kubeadm:get-nodes ClusterRole.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"nodes"},
+				Verbs:     []string{"get", "list", "watch"},
+			},
+			// Required by the CoreDNS ClusterRole.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"endpoints", "services", "pods", "namespaces"},
+				Verbs:     []string{"list", "watch"},
+			},
+			{
+				APIGroups: []string{"discovery.k8s.io"},
+				Resources: []string{"endpointslices"},
+				Verbs:     []string{"list", "watch"},
+			},
+			// Required by the DataStore connection health probe.
+			{
+				NonResourceURLs: []string{"/readyz/etcd"},
+				Verbs:           []string{"get"},
+			},
+			// Required by the activity sampling of the Tenant Control Planes deleted after the last use.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"events"},
+				Verbs:     []string{"list"},
+			},
+		}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "cannot reconcile the soot ClusterRole")
+	}
+
+	if err := ensureSootClusterRoleBinding(ctx, tenantClient); err != nil {
+		return err
+	}
+
+	for namespace, rules := range map[string][]rbacv1.PolicyRule{
+		metav1.NamespaceSystem: {
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps", "secrets", "services", "serviceaccounts"},
+				Verbs:     sootVerbs,
+			},
+			// Required by the Konnectivity agents deployed in a remote cluster, authenticating with a token of the Tenant Cluster.
+			{
+				APIGroups: []string{""},
+				Resources: []string{"serviceaccounts/token"},
+				Verbs:     []string{"create"},
+			},
+			// Required by the migration watchdog, checking the Lease renewed by the migration Job.
+			{
+				APIGroups:     []string{"coordination.k8s.io"},
+				Resources:     []string{"leases"},
+				ResourceNames: []string{utilities.MigrateWatchdogLeaseName},
+				Verbs:         []string{"get", "update", "patch", "delete"},
+			},
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups: []string{"apps"},
+				Resources: []string{"deployments", "daemonsets"},
+				Verbs:     sootVerbs,
+			},
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"roles", "rolebindings"},
+				Verbs:     sootVerbs,
+			},
+		},
+		// Required by the activity sampling, checking the node heartbeats.
+		corev1.NamespaceNodeLease: {
+			{
+				APIGroups: []string{"coordination.k8s.io"},
+				Resources: []string{"leases"},
+				Verbs:     []string{"list"},
+			},
+		},
+		// Required by the bootstrap token phase, publishing the cluster-info ConfigMap.
+		metav1.NamespacePublic: {
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps"},
+				Verbs:     sootVerbs,
+			},
+			{
+				APIGroups: []string{rbacv1.GroupName},
+				Resources: []string{"roles", "rolebindings"},
+				Verbs:     sootVerbs,
+			},
+		},
+	} {
+		if err := ensureSootRole(ctx, tenantClient, namespace, rules); err != nil {
+			return err
+		}
+	}
+
+	return ensureClusterAdminsBinding(ctx, tenantClient)
+}
+
+func ensureSootClusterRoleBinding(ctx context.Context, tenantClient client.Client) error {
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: sootRoleName}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, tenantClient, binding, func() error {
+		addons_utils.SetKamajiManagedLabels(binding)
+		binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: sootRoleName}
+		binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: constants.SootUserName}}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "cannot reconcile the soot ClusterRoleBinding")
+	}
+
+	return nil
+}
+
+func ensureSootRole(ctx context.Context, tenantClient client.Client, namespace string, rules []rbacv1.PolicyRule) error {
+	role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: sootRoleName, Namespace: namespace}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, tenantClient, role, func() error {
+		addons_utils.SetKamajiManagedLabels(role)
+		role.Rules = rules
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "cannot reconcile the soot Role in "+namespace)
+	}
+
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: sootRoleName, Namespace: namespace}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, tenantClient, binding, func() error {
+		addons_utils.SetKamajiManagedLabels(binding)
+		binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: sootRoleName}
+		binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.UserKind, Name: constants.SootUserName}}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "cannot reconcile the soot RoleBinding in "+namespace)
+	}
+
+	return nil
+}
+
+// ensureClusterAdminsBinding mimics the kubeadm ClusterRoleBinding granting cluster-admin to the kubeadm:cluster-admins group,
+// replacing the PhaseClusterAdminRBAC which cannot be performed by the soot user.
+func ensureClusterAdminsBinding(ctx context.Context, tenantClient client.Client) error {
+	binding := &rbacv1.ClusterRoleBinding{ObjectMeta: metav1.ObjectMeta{Name: kubeadmconstants.ClusterAdminsGroupAndClusterRoleBinding}}
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, tenantClient, binding, func() error {
+		binding.RoleRef = rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "cluster-admin"}
+		binding.Subjects = []rbacv1.Subject{{APIGroup: rbacv1.GroupName, Kind: rbacv1.GroupKind, Name: kubeadmconstants.ClusterAdminsGroupAndClusterRoleBinding}}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, "cannot reconcile the cluster admins ClusterRoleBinding")
+	}
+
+	return nil
+}
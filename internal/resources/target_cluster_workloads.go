@@ -0,0 +1,78 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TargetClusterWorkloads deletes the Control Plane workloads, and the mirrored Secrets, and ConfigMaps, from the target cluster:
+// they're not owned by the Tenant Control Plane, thus they're not deleted by the garbage collector of the management cluster.
+type TargetClusterWorkloads struct {
+	Client client.Client
+
+	target client.Client
+}
+
+func (r *TargetClusterWorkloads) GetName() string {
+	return "target-cluster-workloads"
+}
+
+func (r *TargetClusterWorkloads) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	targetCluster := tenantControlPlane.TargetCluster()
+
+	target, err := utilities.GetRemoteClusterClient(ctx, r.Client, tenantControlPlane, targetCluster.KubeconfigSecretRef)
+	if err != nil {
+		return errors.Wrap(err, "cannot generate the target cluster client")
+	}
+
+	r.target = target
+
+	return nil
+}
+
+func (r *TargetClusterWorkloads) Delete(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	lists := append(utilities.WorkloadObjects(), &corev1.SecretList{}, &corev1.ConfigMapList{})
+
+	for _, list := range lists {
+		if err := r.target.List(ctx, list, client.InNamespace(tenantControlPlane.GetNamespace()), client.MatchingLabels{
+			constants.ControlPlaneLabelKey: tenantControlPlane.GetName(),
+		}); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot list the %T objects of the target cluster", list))
+		}
+		// The objects are deleted one by one, since the Services don't support the collection deletion.
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			obj := item.(client.Object) //nolint:forcetypeassert
+			// The mirrored Secrets, and ConfigMaps, are labelled as such, the other ones are not managed by Kamaji.
+			switch obj.(type) {
+			case *corev1.Secret, *corev1.ConfigMap:
+				if obj.GetLabels()[utilities.MirroredLabelKey] != "true" {
+					continue
+				}
+			}
+
+			if err = r.target.Delete(ctx, obj); err != nil && !k8serrors.IsNotFound(err) {
+				return errors.Wrap(err, fmt.Sprintf("cannot delete the object %s from the target cluster", obj.GetName()))
+			}
+		}
+	}
+
+	return nil
+}
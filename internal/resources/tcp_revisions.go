@@ -0,0 +1,199 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TenantControlPlaneRevisionsResource keeps the history of the applied Tenant Control Plane specifications in a ConfigMap,
+// keyed by the revision number, as the Deployment ReplicaSets do: re-applying a previous specification, such as upon a rollback,
+// moves it to a new revision.
+type TenantControlPlaneRevisionsResource struct {
+	resource *corev1.ConfigMap
+	Client   client.Client
+	// HistoryLimit is the number of the retained revisions, the history is disabled if zero.
+	HistoryLimit int
+
+	revision int64
+}
+
+func (r *TenantControlPlaneRevisionsResource) GetHistogram() prometheus.Histogram {
+	revisionsCollector = LazyLoadHistogramFromResource(revisionsCollector, r)
+
+	return revisionsCollector
+}
+
+func (r *TenantControlPlaneRevisionsResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *TenantControlPlaneRevisionsResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return r.HistoryLimit == 0 && tenantControlPlane.Status.Revisions != nil
+}
+
+func (r *TenantControlPlaneRevisionsResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if !k8serrors.IsNotFound(err) {
+			logger.Error(err, "cannot delete the requested resource")
+
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (r *TenantControlPlaneRevisionsResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if r.HistoryLimit == 0 {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *TenantControlPlaneRevisionsResource) GetName() string {
+	return "revisions"
+}
+
+func (r *TenantControlPlaneRevisionsResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if r.HistoryLimit == 0 {
+		return tenantControlPlane.Status.Revisions != nil
+	}
+
+	return tenantControlPlane.Status.Revisions == nil || tenantControlPlane.Status.Revisions.Revision != r.revision
+}
+
+func (r *TenantControlPlaneRevisionsResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if r.HistoryLimit == 0 {
+		tenantControlPlane.Status.Revisions = nil
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Revisions = &kamajiv1alpha1.RevisionsStatus{
+		ConfigMapName: r.resource.GetName(),
+		Revision:      r.revision,
+		LastUpdate:    metav1.Now(),
+	}
+
+	return nil
+}
+
+func (r *TenantControlPlaneRevisionsResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		spec, err := json.Marshal(tenantControlPlane.Spec)
+		if err != nil {
+			return errors.Wrap(err, "cannot encode the Tenant Control Plane specification")
+		}
+
+		revisions := sortedRevisions(r.resource.Data)
+		// The latest revision is matching the applied specification, nothing to record.
+		if latest := len(revisions); latest > 0 && bytes.Equal([]byte(r.resource.Data[strconv.FormatInt(revisions[latest-1], 10)]), spec) {
+			r.revision = revisions[latest-1]
+
+			return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+		}
+
+		data := make(map[string]string, len(r.resource.Data)+1)
+
+		for key, value := range r.resource.Data {
+			if value == string(spec) {
+				continue
+			}
+
+			data[key] = value
+		}
+
+		r.revision = 1
+		if len(revisions) > 0 {
+			r.revision = revisions[len(revisions)-1] + 1
+		}
+
+		data[strconv.FormatInt(r.revision, 10)] = string(spec)
+		// Trimming the oldest revisions exceeding the limit.
+		for _, revision := range sortedRevisions(data) {
+			if len(data) <= r.HistoryLimit {
+				break
+			}
+
+			delete(data, strconv.FormatInt(revision, 10))
+		}
+
+		r.resource.Data = data
+
+		return ctrl.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+// sortedRevisions returns the revision numbers of the given ConfigMap data in ascending order.
+func sortedRevisions(data map[string]string) []int64 {
+	revisions := make([]int64, 0, len(data))
+
+	for key := range data {
+		revision, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	slices.Sort(revisions)
+
+	return revisions
+}
+
+// GetTenantControlPlaneRevision returns the Tenant Control Plane specification of the given revision.
+func GetTenantControlPlaneRevision(ctx context.Context, c client.Reader, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, revision int64) (*kamajiv1alpha1.TenantControlPlaneSpec, error) {
+	if tenantControlPlane.Status.Revisions == nil {
+		return nil, fmt.Errorf("the revision history is not available")
+	}
+
+	var configMap corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.Revisions.ConfigMapName}, &configMap); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the revision history")
+	}
+
+	value, ok := configMap.Data[strconv.FormatInt(revision, 10)]
+	if !ok {
+		return nil, fmt.Errorf("the revision %d is not available, the available ones are %v", revision, sortedRevisions(configMap.Data))
+	}
+
+	var spec kamajiv1alpha1.TenantControlPlaneSpec
+	if err := json.Unmarshal([]byte(value), &spec); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("cannot decode the revision %d", revision))
+	}
+
+	return &spec, nil
+}
@@ -0,0 +1,174 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package resources
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// TenantNamespaceObjectName is the name of the ResourceQuota, and LimitRange, objects managed in the Tenant Control Plane namespace.
+const TenantNamespaceObjectName = "kamaji-tenant"
+
+// TenantNamespace applies the KamajiDefaults tenant namespace policies to the namespace hosting the Tenant Control Plane:
+// the ResourceQuota, and LimitRange, are owned by all the Tenant Control Planes of the namespace,
+// and garbage collected once the last one has been deleted.
+type TenantNamespace struct {
+	Client client.Client
+
+	spec *kamajiv1alpha1.TenantNamespaceSpec
+}
+
+func (r *TenantNamespace) GetHistogram() prometheus.Histogram {
+	tenantnamespaceCollector = LazyLoadHistogramFromResource(tenantnamespaceCollector, r)
+
+	return tenantnamespaceCollector
+}
+
+func (r *TenantNamespace) Define(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) error {
+	r.spec = nil
+
+	var defaults kamajiv1alpha1.KamajiDefaults
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: kamajiv1alpha1.KamajiDefaultsName}, &defaults); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, "cannot retrieve the KamajiDefaults")
+	}
+
+	r.spec = defaults.Spec.TenantNamespace
+
+	return nil
+}
+
+func (r *TenantNamespace) ShouldCleanup(*kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *TenantNamespace) CleanUp(context.Context, *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	return false, nil
+}
+
+func (r *TenantNamespace) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if r.spec == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	results := make([]controllerutil.OperationResult, 0, 3)
+
+	result, err := r.namespace(ctx, tenantControlPlane)
+	if err != nil {
+		return result, errors.Wrap(err, "cannot apply the tenant namespace metadata")
+	}
+
+	results = append(results, result)
+
+	quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: TenantNamespaceObjectName, Namespace: tenantControlPlane.GetNamespace()}}
+
+	result, err = r.ownedObject(ctx, tenantControlPlane, quota, r.spec.ResourceQuota != nil, func() {
+		quota.Spec = *r.spec.ResourceQuota
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "cannot apply the tenant namespace resource quota")
+	}
+
+	results = append(results, result)
+
+	limitRange := &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: TenantNamespaceObjectName, Namespace: tenantControlPlane.GetNamespace()}}
+
+	result, err = r.ownedObject(ctx, tenantControlPlane, limitRange, r.spec.LimitRange != nil, func() {
+		limitRange.Spec = *r.spec.LimitRange
+	})
+	if err != nil {
+		return result, errors.Wrap(err, "cannot apply the tenant namespace limit range")
+	}
+
+	results = append(results, result)
+
+	for _, result = range results {
+		if result != controllerutil.OperationResultNone {
+			return controllerutil.OperationResultUpdated, nil
+		}
+	}
+
+	return controllerutil.OperationResultNone, nil
+}
+
+// namespace merges the labels, and the annotations, of the Tenant Control Plane namespace.
+func (r *TenantNamespace) namespace(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: tenantControlPlane.GetNamespace()}}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, namespace, func() error {
+		labels := utilities.MergeMaps(namespace.GetLabels(), r.spec.Labels)
+
+		if ps := r.spec.PodSecurity; ps != nil {
+			for mode, level := range map[string]kamajiv1alpha1.PodSecurityLevel{"enforce": ps.Enforce, "audit": ps.Audit, "warn": ps.Warn} {
+				if level != "" {
+					labels["pod-security.kubernetes.io/"+mode] = string(level)
+				}
+			}
+		}
+
+		namespace.SetLabels(labels)
+		namespace.SetAnnotations(utilities.MergeMaps(namespace.GetAnnotations(), r.spec.Annotations))
+
+		return nil
+	})
+}
+
+// ownedObject creates, or updates, the given object when desired, adding the Tenant Control Plane to its owners:
+// an existing object managed by Kamaji is deleted otherwise.
+func (r *TenantNamespace) ownedObject(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, obj client.Object, desired bool, mutate func()) (controllerutil.OperationResult, error) {
+	if !desired {
+		if err := r.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			return controllerutil.OperationResultNone, client.IgnoreNotFound(err)
+		}
+
+		if obj.GetLabels()[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+			return controllerutil.OperationResultNone, nil
+		}
+
+		if err := r.Client.Delete(ctx, obj); err != nil {
+			return controllerutil.OperationResultNone, client.IgnoreNotFound(err)
+		}
+
+		return controllerutil.OperationResultUpdated, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, obj, func() error {
+		obj.SetLabels(utilities.MergeMaps(obj.GetLabels(), map[string]string{
+			constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+			constants.ControlPlaneLabelResource: r.GetName(),
+		}))
+
+		mutate()
+
+		return controllerutil.SetOwnerReference(tenantControlPlane, obj, r.Client.Scheme())
+	})
+}
+
+func (r *TenantNamespace) GetName() string {
+	return "tenant-namespace"
+}
+
+func (r *TenantNamespace) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *TenantNamespace) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
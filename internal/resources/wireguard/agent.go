@@ -0,0 +1,179 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pointer "k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	builder "github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Agent deploys the WireGuard agents on the worker nodes of the Tenant Cluster:
+// each agent brings up the tunnel with the configuration of its own node, using the host network.
+type Agent struct {
+	resource     *appsv1.DaemonSet
+	Client       client.Client
+	tenantClient client.Client
+}
+
+func (r *Agent) GetHistogram() prometheus.Histogram {
+	agentCollector = resources.LazyLoadHistogramFromResource(agentCollector, r)
+
+	return agentCollector
+}
+
+func (r *Agent) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *Agent) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.WireGuard == nil
+}
+
+func (r *Agent) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.tenantClient.Get(ctx, client.ObjectKeyFromObject(r.resource), r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		logger.Error(err, "cannot retrieve the requested resource for deletion")
+
+		return false, err
+	}
+
+	if labels := r.resource.GetLabels(); labels == nil || labels[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+		return false, nil
+	}
+
+	if err := r.tenantClient.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Agent) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	r.resource = &appsv1.DaemonSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      AgentName,
+			Namespace: AgentNamespace,
+		},
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		log.FromContext(ctx, "resource", r.GetName()).Error(err, "unable to retrieve the Tenant Control Plane client")
+
+		return err
+	}
+
+	return nil
+}
+
+func (r *Agent) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.ServerSideApply(ctx, r.tenantClient, r.resource, tenantControlPlane.Spec.Addons.WireGuard.AddonApplyTrait, r.mutate(tenantControlPlane))
+}
+
+func (r *Agent) GetName() string {
+	return "wireguard-agent"
+}
+
+func (r *Agent) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *Agent) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		addon := tenantControlPlane.Spec.Addons.WireGuard
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		r.resource.Spec.Selector = &metav1.LabelSelector{
+			MatchLabels: map[string]string{
+				"k8s-app": AgentName,
+			},
+		}
+
+		podSpec := &r.resource.Spec.Template.Spec
+
+		r.resource.Spec.Template.SetLabels(utilities.MergeMaps(r.resource.Spec.Template.GetLabels(), r.resource.Spec.Selector.MatchLabels))
+		podSpec.PriorityClassName = "system-node-critical"
+		podSpec.HostNetwork = true
+		podSpec.Tolerations = addon.Tolerations
+		podSpec.NodeSelector = map[string]string{
+			"kubernetes.io/os": "linux",
+		}
+		podSpec.Volumes = []corev1.Volume{
+			{
+				Name: PeersSecretName,
+				VolumeSource: corev1.VolumeSource{
+					Secret: &corev1.SecretVolumeSource{
+						SecretName:  PeersSecretName,
+						DefaultMode: pointer.To(int32(256)),
+						Optional:    pointer.To(true),
+					},
+				},
+			},
+		}
+
+		if len(podSpec.Containers) != 1 {
+			podSpec.Containers = make([]corev1.Container, 1)
+		}
+
+		podSpec.Containers[0].Name = AgentName
+		podSpec.Containers[0].Image = addon.Image
+		podSpec.Containers[0].Command = []string{"/bin/sh", "-c", builder.WireGuardSyncScript}
+		podSpec.Containers[0].Env = []corev1.EnvVar{
+			{
+				Name: "NODE_NAME",
+				ValueFrom: &corev1.EnvVarSource{
+					FieldRef: &corev1.ObjectFieldSelector{FieldPath: "spec.nodeName"},
+				},
+			},
+			{
+				Name:  "WG_CONFIG",
+				Value: "/etc/kamaji-wireguard/$(NODE_NAME).conf",
+			},
+		}
+		podSpec.Containers[0].SecurityContext = &corev1.SecurityContext{
+			Capabilities: &corev1.Capabilities{
+				Add: []corev1.Capability{"NET_ADMIN"},
+			},
+		}
+		podSpec.Containers[0].VolumeMounts = []corev1.VolumeMount{
+			{
+				Name:      PeersSecretName,
+				MountPath: "/etc/kamaji-wireguard",
+				ReadOnly:  true,
+			},
+		}
+
+		return nil
+	}
+}
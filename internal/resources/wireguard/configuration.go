@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// GenerateKeyPair returns a new Curve25519 key pair, base64 encoded as expected by WireGuard.
+func GenerateKeyPair() (privateKey string, publicKey string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key.Bytes()), base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// PublicKey returns the public key of the given base64 encoded private key.
+func PublicKey(privateKey string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
+
+// ServerAddress returns the overlay network address of the Tenant Control Plane, the first one of the CIDR.
+func ServerAddress(cidr string) (netip.Prefix, error) {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return netip.Prefix{}, err
+	}
+
+	return netip.PrefixFrom(prefix.Masked().Addr().Next(), prefix.Bits()), nil
+}
+
+// AllocateAddress returns the first overlay network address not used by the Tenant Control Plane, or by the given peers.
+func AllocateAddress(cidr string, peers []kamajiv1alpha1.WireGuardPeerStatus) (string, error) {
+	server, err := ServerAddress(cidr)
+	if err != nil {
+		return "", err
+	}
+
+	used := make(map[string]struct{}, len(peers))
+	for _, peer := range peers {
+		used[peer.Address] = struct{}{}
+	}
+
+	for addr := server.Addr().Next(); server.Contains(addr); addr = addr.Next() {
+		if _, ok := used[addr.String()]; !ok && addr != lastAddress(server) {
+			return addr.String(), nil
+		}
+	}
+
+	return "", fmt.Errorf("the WireGuard CIDR %s has no addresses left", cidr)
+}
+
+func lastAddress(prefix netip.Prefix) netip.Addr {
+	addr := prefix.Masked().Addr().AsSlice()
+	ones := prefix.Bits()
+
+	for i := range addr {
+		bits := max(min(ones-i*8, 8), 0)
+		addr[i] |= byte(0xff >> bits)
+	}
+
+	last, _ := netip.AddrFromSlice(addr)
+
+	return last
+}
+
+// ServerConfiguration renders the WireGuard configuration of the Tenant Control Plane,
+// routing the allowed IPs of each peer through the tunnel.
+func ServerConfiguration(privateKey string, spec kamajiv1alpha1.WireGuardSpec, peers []kamajiv1alpha1.WireGuardPeerStatus) (string, error) {
+	address, err := ServerAddress(spec.CIDR)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[Interface]\nPrivateKey = %s\nAddress = %s\nListenPort = %d\n", privateKey, address.String(), spec.Port)
+
+	for _, peer := range peers {
+		allowedIPs := append([]string{peer.Address + "/32"}, peer.AllowedIPs...)
+
+		fmt.Fprintf(&b, "\n[Peer]\n# %s\nPublicKey = %s\nAllowedIPs = %s\n", peer.Node, peer.PublicKey, strings.Join(allowedIPs, ", "))
+	}
+
+	return b.String(), nil
+}
+
+// PeerConfiguration renders the WireGuard configuration of a worker node,
+// routing only the Tenant Control Plane address through the tunnel.
+func PeerConfiguration(privateKey, address, serverPublicKey, serverEndpoint string, spec kamajiv1alpha1.WireGuardSpec) (string, error) {
+	server, err := ServerAddress(spec.CIDR)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("[Interface]\nPrivateKey = %s\nAddress = %s/32\n\n[Peer]\nPublicKey = %s\nEndpoint = %s\nAllowedIPs = %s/32\nPersistentKeepalive = 25\n",
+		privateKey, address, serverPublicKey, net.JoinHostPort(serverEndpoint, fmt.Sprintf("%d", spec.Port)), server.Addr().String()), nil
+}
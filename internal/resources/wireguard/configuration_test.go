@@ -0,0 +1,50 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources/wireguard"
+)
+
+var _ = Describe("WireGuard configuration", func() {
+	spec := kamajiv1alpha1.WireGuardSpec{Port: 51820, CIDR: "10.250.0.0/30"}
+
+	It("should derive the public key from the private one", func() {
+		privateKey, publicKey, err := wireguard.GenerateKeyPair()
+		Expect(err).ToNot(HaveOccurred())
+
+		derived, err := wireguard.PublicKey(privateKey)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(derived).To(Equal(publicKey))
+	})
+
+	It("should allocate the lowest free address, skipping the Tenant Control Plane, and the broadcast ones", func() {
+		address, err := wireguard.AllocateAddress(spec.CIDR, nil)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(address).To(Equal("10.250.0.2"))
+
+		_, err = wireguard.AllocateAddress(spec.CIDR, []kamajiv1alpha1.WireGuardPeerStatus{{Node: "worker", Address: "10.250.0.2"}})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should route the allowed IPs of the peers through the tunnel", func() {
+		configuration, err := wireguard.ServerConfiguration("key", spec, []kamajiv1alpha1.WireGuardPeerStatus{
+			{Node: "worker", Address: "10.250.0.2", PublicKey: "peer", AllowedIPs: []string{"192.168.1.10/32", "10.244.0.0/24"}},
+		})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configuration).To(ContainSubstring("Address = 10.250.0.1/30\n"))
+		Expect(configuration).To(ContainSubstring("AllowedIPs = 10.250.0.2/32, 192.168.1.10/32, 10.244.0.0/24\n"))
+	})
+
+	It("should point the peers to the Tenant Control Plane endpoint", func() {
+		configuration, err := wireguard.PeerConfiguration("key", "10.250.0.2", "server", "192.168.0.1", spec)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(configuration).To(ContainSubstring("Endpoint = 192.168.0.1:51820\n"))
+		Expect(configuration).To(ContainSubstring("AllowedIPs = 10.250.0.1/32\n"))
+	})
+})
@@ -0,0 +1,20 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"k8s.io/kubernetes/pkg/apis/core"
+)
+
+const (
+	AgentName       = "kamaji-wireguard-agent"
+	AgentNamespace  = core.NamespaceSystem
+	PeersSecretName = "kamaji-wireguard-peers"
+
+	// ConfigurationKey is the key of the Tenant Control Plane Secret containing the WireGuard configuration.
+	ConfigurationKey = "wg0.conf"
+	privateKeyKey    = "private-key"
+	publicKeyKey     = "public-key"
+	servicePortName  = "wireguard"
+)
@@ -0,0 +1,16 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	agentCollector      prometheus.Histogram
+	deploymentCollector prometheus.Histogram
+	peersCollector      prometheus.Histogram
+	secretCollector     prometheus.Histogram
+	serviceCollector    prometheus.Histogram
+)
@@ -0,0 +1,241 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// Peers provisions a WireGuard key pair, and an overlay network address, for each worker node of the Tenant Cluster:
+// the node configurations are stored in the Tenant Cluster Secret mounted by the agents,
+// and the peers are added to the Tenant Control Plane configuration.
+type Peers struct {
+	resource     *corev1.Secret
+	peers        []kamajiv1alpha1.WireGuardPeerStatus
+	Client       client.Client
+	tenantClient client.Client
+}
+
+func (r *Peers) GetHistogram() prometheus.Histogram {
+	peersCollector = resources.LazyLoadHistogramFromResource(peersCollector, r)
+
+	return peersCollector
+}
+
+func (r *Peers) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.WireGuard != nil && tenantControlPlane.Status.Addons.WireGuard.PublicKey != "" &&
+		!equality.Semantic.DeepEqual(tenantControlPlane.Status.Addons.WireGuard.Peers, r.peers)
+}
+
+func (r *Peers) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.WireGuard == nil
+}
+
+func (r *Peers) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	if err := r.tenantClient.Get(ctx, client.ObjectKeyFromObject(r.resource), r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		logger.Error(err, "cannot retrieve the requested resource for deletion")
+
+		return false, err
+	}
+
+	if labels := r.resource.GetLabels(); labels == nil || labels[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+		return false, nil
+	}
+
+	if err := r.tenantClient.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		logger.Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *Peers) Define(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (err error) {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      PeersSecretName,
+			Namespace: AgentNamespace,
+		},
+	}
+
+	if r.tenantClient, err = utilities.GetTenantClient(ctx, r.Client, tenantControlPlane); err != nil {
+		log.FromContext(ctx, "resource", r.GetName()).Error(err, "unable to retrieve the Tenant Control Plane client")
+
+		return err
+	}
+
+	return nil
+}
+
+// CreateOrUpdate waits for the Tenant Control Plane key pair: the soot controller is triggered again upon its provisioning.
+func (r *Peers) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil || tenantControlPlane.Status.Addons.WireGuard.PublicKey == "" {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	var nodes corev1.NodeList
+	if err := r.tenantClient.List(ctx, &nodes); err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot list the Tenant Cluster nodes")
+	}
+
+	peersResult, err := utilities.CreateOrUpdateWithConflict(ctx, r.tenantClient, r.resource, r.mutate(tenantControlPlane, nodes.Items))
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot provision the WireGuard peers")
+	}
+
+	server := &corev1.Secret{}
+	server.SetName(tenantControlPlane.Status.Addons.WireGuard.SecretName)
+	server.SetNamespace(tenantControlPlane.GetNamespace())
+
+	serverResult, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, server, func() error {
+		privateKey := string(server.Data[privateKeyKey])
+		if privateKey == "" {
+			return fmt.Errorf("the WireGuard key pair of the Tenant Control Plane is not yet provisioned")
+		}
+
+		configuration, configErr := ServerConfiguration(privateKey, *tenantControlPlane.Spec.Addons.WireGuard, r.peers)
+		if configErr != nil {
+			return configErr
+		}
+
+		server.Data[ConfigurationKey] = []byte(configuration)
+
+		return nil
+	})
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot update the WireGuard configuration of the Tenant Control Plane")
+	}
+
+	if peersResult != controllerutil.OperationResultNone {
+		return peersResult, nil
+	}
+
+	return serverResult, nil
+}
+
+func (r *Peers) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane, nodes []corev1.Node) controllerutil.MutateFn {
+	return func() error {
+		addon := tenantControlPlane.Spec.Addons.WireGuard
+
+		address, _, err := tenantControlPlane.AssignedControlPlaneAddress()
+		if err != nil {
+			return errors.Wrap(err, "cannot retrieve the Tenant Control Plane address")
+		}
+
+		current := make(map[string]kamajiv1alpha1.WireGuardPeerStatus, len(tenantControlPlane.Status.Addons.WireGuard.Peers))
+		for _, peer := range tenantControlPlane.Status.Addons.WireGuard.Peers {
+			current[peer.Node] = peer
+		}
+
+		slices.SortFunc(nodes, func(a, b corev1.Node) int {
+			return strings.Compare(a.GetName(), b.GetName())
+		})
+
+		data := make(map[string][]byte, 2*len(nodes))
+		r.peers = make([]kamajiv1alpha1.WireGuardPeerStatus, 0, len(nodes))
+		// Nodes keep the address assigned previously, the new ones are getting the lowest available one.
+		for _, node := range nodes {
+			if peer, ok := current[node.GetName()]; ok {
+				r.peers = append(r.peers, kamajiv1alpha1.WireGuardPeerStatus{Node: peer.Node, Address: peer.Address})
+			}
+		}
+
+		for _, node := range nodes {
+			if _, ok := current[node.GetName()]; ok {
+				continue
+			}
+
+			peerAddress, allocationErr := AllocateAddress(addon.CIDR, r.peers)
+			if allocationErr != nil {
+				return allocationErr
+			}
+
+			r.peers = append(r.peers, kamajiv1alpha1.WireGuardPeerStatus{Node: node.GetName(), Address: peerAddress})
+		}
+
+		slices.SortFunc(r.peers, func(a, b kamajiv1alpha1.WireGuardPeerStatus) int {
+			return strings.Compare(a.Node, b.Node)
+		})
+
+		for i, node := range nodes {
+			peer := &r.peers[i]
+
+			privateKey := string(r.resource.Data[node.GetName()+".key"])
+			if privateKey == "" {
+				if privateKey, _, err = GenerateKeyPair(); err != nil {
+					return err
+				}
+			}
+
+			if peer.PublicKey, err = PublicKey(privateKey); err != nil {
+				return err
+			}
+
+			for _, nodeAddress := range node.Status.Addresses {
+				if nodeAddress.Type == corev1.NodeInternalIP {
+					peer.AllowedIPs = append(peer.AllowedIPs, nodeAddress.Address+"/32")
+				}
+			}
+
+			peer.AllowedIPs = append(peer.AllowedIPs, node.Spec.PodCIDRs...)
+
+			configuration, configErr := PeerConfiguration(privateKey, peer.Address, tenantControlPlane.Status.Addons.WireGuard.PublicKey, address, *addon)
+			if configErr != nil {
+				return configErr
+			}
+
+			data[node.GetName()+".key"] = []byte(privateKey)
+			data[node.GetName()+".conf"] = []byte(configuration)
+		}
+
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+		r.resource.Data = data
+
+		return nil
+	}
+}
+
+func (r *Peers) GetName() string {
+	return "wireguard-peers"
+}
+
+func (r *Peers) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.WireGuard.Peers = r.peers
+	tenantControlPlane.Status.Addons.WireGuard.LastUpdate = metav1.Now()
+
+	return nil
+}
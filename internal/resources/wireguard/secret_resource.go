@@ -0,0 +1,136 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// SecretResource manages the Secret containing the Tenant Control Plane key pair, and the WireGuard configuration
+// mounted by the sidecar container: the peers are the ones reported in the status by the soot controller.
+type SecretResource struct {
+	resource *corev1.Secret
+	Client   client.Client
+}
+
+func (r *SecretResource) GetHistogram() prometheus.Histogram {
+	secretCollector = resources.LazyLoadHistogramFromResource(secretCollector, r)
+
+	return secretCollector
+}
+
+func (r *SecretResource) ShouldStatusBeUpdated(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		return tenantControlPlane.Status.Addons.WireGuard.SecretName != ""
+	}
+
+	return tenantControlPlane.Status.Addons.WireGuard.SecretName != r.resource.GetName() ||
+		tenantControlPlane.Status.Addons.WireGuard.PublicKey != string(r.resource.Data[publicKeyKey])
+}
+
+// ShouldCleanup waits for the sidecar container to be removed from the Deployment, since it's mounting the Secret.
+func (r *SecretResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.WireGuard == nil && !tenantControlPlane.Status.Addons.WireGuard.Enabled &&
+		tenantControlPlane.Status.Addons.WireGuard.SecretName != ""
+}
+
+func (r *SecretResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	if err := r.Client.Delete(ctx, r.resource); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return true, nil
+		}
+
+		log.FromContext(ctx, "resource", r.GetName()).Error(err, "cannot delete the requested resource")
+
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *SecretResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.AddTenantPrefix(r.GetName(), tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *SecretResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *SecretResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) controllerutil.MutateFn {
+	return func() error {
+		r.resource.SetLabels(utilities.MergeMaps(r.resource.GetLabels(), utilities.KamajiLabels(tenantControlPlane.GetName(), r.GetName())))
+
+		if r.resource.Data == nil {
+			r.resource.Data = map[string][]byte{}
+		}
+
+		privateKey, publicKey := string(r.resource.Data[privateKeyKey]), ""
+
+		var err error
+
+		switch {
+		case privateKey == "":
+			privateKey, publicKey, err = GenerateKeyPair()
+		default:
+			publicKey, err = PublicKey(privateKey)
+		}
+
+		if err != nil {
+			return fmt.Errorf("cannot generate the WireGuard key pair: %w", err)
+		}
+
+		configuration, err := ServerConfiguration(privateKey, *tenantControlPlane.Spec.Addons.WireGuard, tenantControlPlane.Status.Addons.WireGuard.Peers)
+		if err != nil {
+			return fmt.Errorf("cannot render the WireGuard configuration: %w", err)
+		}
+
+		r.resource.Data[privateKeyKey] = []byte(privateKey)
+		r.resource.Data[publicKeyKey] = []byte(publicKey)
+		r.resource.Data[ConfigurationKey] = []byte(configuration)
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *SecretResource) GetName() string {
+	return "wireguard"
+}
+
+func (r *SecretResource) UpdateTenantControlPlaneStatus(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		tenantControlPlane.Status.Addons.WireGuard = kamajiv1alpha1.WireGuardStatus{}
+
+		return nil
+	}
+
+	tenantControlPlane.Status.Addons.WireGuard.SecretName = r.resource.GetName()
+	tenantControlPlane.Status.Addons.WireGuard.PublicKey = string(r.resource.Data[publicKeyKey])
+	tenantControlPlane.Status.Addons.WireGuard.LastUpdate = metav1.Now()
+
+	return nil
+}
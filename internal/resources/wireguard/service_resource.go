@@ -0,0 +1,124 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package wireguard
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/resources"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// ServiceResource exposes the WireGuard UDP port with the Tenant Control Plane Service:
+// the port is looked up by name, since the Konnectivity one could have been declared previously.
+type ServiceResource struct {
+	resource *corev1.Service
+	Client   client.Client
+}
+
+func (r *ServiceResource) GetHistogram() prometheus.Histogram {
+	serviceCollector = resources.LazyLoadHistogramFromResource(serviceCollector, r)
+
+	return serviceCollector
+}
+
+func (r *ServiceResource) ShouldStatusBeUpdated(context.Context, *kamajiv1alpha1.TenantControlPlane) bool {
+	return false
+}
+
+func (r *ServiceResource) ShouldCleanup(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) bool {
+	return tenantControlPlane.Spec.Addons.WireGuard == nil && tenantControlPlane.Status.Addons.WireGuard.Enabled
+}
+
+func (r *ServiceResource) CleanUp(ctx context.Context, _ *kamajiv1alpha1.TenantControlPlane) (bool, error) {
+	logger := log.FromContext(ctx, "resource", r.GetName())
+
+	res, err := utilities.CreateOrUpdateWithConflict(ctx, r.Client, r.resource, func() error {
+		if found, index := r.port(); found {
+			r.resource.Spec.Ports = append(r.resource.Spec.Ports[:index:index], r.resource.Spec.Ports[index+1:]...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "unable to cleanup the resource")
+
+		return false, err
+	}
+
+	return res == controllerutil.OperationResultUpdated, nil
+}
+
+func (r *ServiceResource) UpdateTenantControlPlaneStatus(context.Context, *kamajiv1alpha1.TenantControlPlane) error {
+	return nil
+}
+
+func (r *ServiceResource) Define(_ context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) error {
+	r.resource = &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      utilities.ObjectName(tenantControlPlane),
+			Namespace: tenantControlPlane.GetNamespace(),
+		},
+	}
+
+	return nil
+}
+
+func (r *ServiceResource) CreateOrUpdate(ctx context.Context, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (controllerutil.OperationResult, error) {
+	if tenantControlPlane.Spec.Addons.WireGuard == nil {
+		return controllerutil.OperationResultNone, nil
+	}
+
+	return utilities.CreateOrUpdateWithContentHash(ctx, r.Client, r.GetName(), r.resource, r.mutate(tenantControlPlane))
+}
+
+func (r *ServiceResource) port() (bool, int) {
+	for index, port := range r.resource.Spec.Ports {
+		if port.Name == servicePortName {
+			return true, index
+		}
+	}
+
+	return false, -1
+}
+
+func (r *ServiceResource) mutate(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) func() error {
+	return func() error {
+		if len(r.resource.Spec.Ports) == 0 {
+			return fmt.Errorf("current state of the Service is not ready to be mangled for WireGuard")
+		}
+
+		found, index := r.port()
+		if !found {
+			index = len(r.resource.Spec.Ports)
+			r.resource.Spec.Ports = append(r.resource.Spec.Ports, corev1.ServicePort{})
+		}
+
+		addon := tenantControlPlane.Spec.Addons.WireGuard
+
+		r.resource.Spec.Ports[index].Name = servicePortName
+		r.resource.Spec.Ports[index].Protocol = corev1.ProtocolUDP
+		r.resource.Spec.Ports[index].Port = addon.Port
+		r.resource.Spec.Ports[index].TargetPort = intstr.FromInt32(addon.Port)
+		if tenantControlPlane.Spec.ControlPlane.Service.ServiceType == kamajiv1alpha1.ServiceTypeNodePort {
+			r.resource.Spec.Ports[index].NodePort = addon.Port
+		}
+
+		return controllerutil.SetControllerReference(tenantControlPlane, r.resource, r.Client.Scheme())
+	}
+}
+
+func (r *ServiceResource) GetName() string {
+	return "wireguard-service"
+}
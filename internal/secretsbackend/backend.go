@@ -0,0 +1,33 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Package secretsbackend writes the credentials generated for the Tenant Control Planes, such as the PKI, and the kubeconfigs,
+// to an external store, besides the Kubernetes Secrets mounted by the Control Plane pods.
+package secretsbackend
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// Backend writes the generated credentials of a Tenant Control Plane to an external store.
+type Backend interface {
+	// Write stores the given Secrets in the external store, overwriting the previous content.
+	Write(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, secrets []corev1.Secret) error
+	// Remove stops writing the Secrets with the given names to the external store.
+	Remove(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, names []string) error
+}
+
+// New returns the Backend declared by the given Tenant Control Plane,
+// or nil when the credentials are kept in the Kubernetes Secrets only.
+func New(c client.Client, tcp *kamajiv1alpha1.TenantControlPlane) Backend {
+	if tcp.Spec.SecretsBackend == nil || tcp.Spec.SecretsBackend.ExternalSecrets == nil {
+		return nil
+	}
+
+	return &ExternalSecrets{Client: c, Spec: *tcp.Spec.SecretsBackend.ExternalSecrets}
+}
@@ -0,0 +1,118 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretsbackend
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+// PushSecretGroupVersionKind is the External Secrets Operator kind pushing a Kubernetes Secret to the external store:
+// it's handled as an unstructured object, the External Secrets Operator installation is required only when a backend is declared.
+var PushSecretGroupVersionKind = schema.GroupVersionKind{Group: "external-secrets.io", Version: "v1alpha1", Kind: "PushSecret"}
+
+// ExternalSecrets is the Backend creating a PushSecret object for each Secret, named after it,
+// in the Tenant Control Plane namespace: the External Secrets Operator pushes the whole Secret to the referenced store,
+// such as Vault, or AWS Secrets Manager, with the <prefix>/<namespace>/<secret-name> remote key.
+type ExternalSecrets struct {
+	Client client.Client
+	Spec   kamajiv1alpha1.ExternalSecretsBackend
+}
+
+func (e *ExternalSecrets) Write(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, secrets []corev1.Secret) error {
+	for _, secret := range secrets {
+		pushSecret := newPushSecret(tcp.GetNamespace(), secret.GetName())
+
+		if _, err := controllerutil.CreateOrUpdate(ctx, e.Client, pushSecret, func() error {
+			pushSecret.SetLabels(utilities.MergeMaps(pushSecret.GetLabels(), utilities.KamajiLabels(tcp.GetName(), "secrets-backend")))
+
+			if err := unstructured.SetNestedMap(pushSecret.Object, e.spec(tcp, secret.GetName()), "spec"); err != nil {
+				return err
+			}
+
+			return controllerutil.SetControllerReference(tcp, pushSecret, e.Client.Scheme())
+		}); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot write the Secret %s to the external store", secret.GetName()))
+		}
+	}
+
+	return nil
+}
+
+func (e *ExternalSecrets) Remove(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, names []string) error {
+	for _, name := range names {
+		if err := e.Client.Delete(ctx, newPushSecret(tcp.GetNamespace(), name)); err != nil && !k8serrors.IsNotFound(err) && !meta.IsNoMatchError(err) {
+			return errors.Wrap(err, fmt.Sprintf("cannot remove the Secret %s from the external store", name))
+		}
+	}
+
+	return nil
+}
+
+func (e *ExternalSecrets) spec(tcp *kamajiv1alpha1.TenantControlPlane, secretName string) map[string]any {
+	kind := e.Spec.SecretStoreRef.Kind
+	if len(kind) == 0 {
+		kind = kamajiv1alpha1.ExternalSecretStoreKindCluster
+	}
+
+	deletionPolicy := e.Spec.DeletionPolicy
+	if len(deletionPolicy) == 0 {
+		deletionPolicy = kamajiv1alpha1.ExternalSecretsDeletionPolicyNone
+	}
+
+	spec := map[string]any{
+		"deletionPolicy": string(deletionPolicy),
+		"secretStoreRefs": []any{
+			map[string]any{"name": e.Spec.SecretStoreRef.Name, "kind": string(kind)},
+		},
+		"selector": map[string]any{
+			"secret": map[string]any{"name": secretName},
+		},
+		// With no secretKey, the whole Secret is pushed as a JSON object to the remote key.
+		"data": []any{
+			map[string]any{
+				"match": map[string]any{
+					"remoteRef": map[string]any{"remoteKey": RemoteKey(e.Spec.RemoteKeyPrefix, tcp.GetNamespace(), secretName)},
+				},
+			},
+		},
+	}
+
+	if e.Spec.RefreshInterval.Duration > 0 {
+		spec["refreshInterval"] = e.Spec.RefreshInterval.Duration.String()
+	}
+
+	return spec
+}
+
+// RemoteKey returns the key of the given Secret in the external store.
+func RemoteKey(prefix, namespace, secretName string) string {
+	if len(prefix) == 0 {
+		prefix = "kamaji"
+	}
+
+	return path.Join(prefix, namespace, secretName)
+}
+
+func newPushSecret(namespace, name string) *unstructured.Unstructured {
+	pushSecret := &unstructured.Unstructured{}
+	pushSecret.SetGroupVersionKind(PushSecretGroupVersionKind)
+	pushSecret.SetNamespace(namespace)
+	pushSecret.SetName(name)
+
+	return pushSecret
+}
@@ -0,0 +1,84 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package secretsbackend
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestExternalSecrets(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = kamajiv1alpha1.AddToScheme(scheme)
+
+	tcp := &kamajiv1alpha1.TenantControlPlane{
+		ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "default", UID: "uid"},
+		Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+			SecretsBackend: &kamajiv1alpha1.SecretsBackendSpec{
+				ExternalSecrets: &kamajiv1alpha1.ExternalSecretsBackend{
+					SecretStoreRef: kamajiv1alpha1.ExternalSecretStoreReference{Name: "vault"},
+				},
+			},
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	ctx := context.Background()
+
+	backend := New(c, tcp)
+	if backend == nil {
+		t.Fatal("expected the External Secrets backend")
+	}
+
+	secrets := []corev1.Secret{{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00-ca", Namespace: "default"}}}
+	if err := backend.Write(ctx, tcp, secrets); err != nil {
+		t.Fatal(err)
+	}
+
+	pushSecret := newPushSecret("default", "tenant-00-ca")
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pushSecret), pushSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	if kind, _, _ := unstructured.NestedString(pushSecret.Object, "spec", "deletionPolicy"); kind != "None" {
+		t.Errorf("expected the None deletion policy, got %s", kind)
+	}
+
+	data, _, _ := unstructured.NestedSlice(pushSecret.Object, "spec", "data")
+	if len(data) != 1 {
+		t.Fatalf("expected a single data entry, got %v", data)
+	}
+
+	if key, _, _ := unstructured.NestedString(data[0].(map[string]any), "match", "remoteRef", "remoteKey"); key != "kamaji/default/tenant-00-ca" {
+		t.Errorf("unexpected remote key %s", key)
+	}
+
+	if owners := pushSecret.GetOwnerReferences(); len(owners) != 1 || owners[0].Name != "tenant-00" {
+		t.Errorf("expected the Tenant Control Plane owner reference, got %v", owners)
+	}
+
+	if err := backend.Remove(ctx, tcp, []string{"tenant-00-ca", "missing"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pushSecret), newPushSecret("default", "tenant-00-ca")); !k8serrors.IsNotFound(err) {
+		t.Errorf("expected the PushSecret to be removed, got %v", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if backend := New(nil, &kamajiv1alpha1.TenantControlPlane{}); backend != nil {
+		t.Errorf("expected no backend, got %T", backend)
+	}
+}
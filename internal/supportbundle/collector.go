@@ -0,0 +1,371 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+// Package supportbundle collects the diagnostics of a Tenant Control Plane, such as its conditions, events, manifests,
+// and logs, into an archive attached to the bug reports: the sensitive data is redacted.
+package supportbundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+)
+
+const (
+	// DefaultKamajiSelector matches the Kamaji pods deployed with the Helm chart.
+	DefaultKamajiSelector = "app.kubernetes.io/name=kamaji"
+	// DefaultLogLines is the default number of the most recent log lines collected per container.
+	DefaultLogLines = 1000
+	// eventsLimit is the number of the most recent events collected.
+	eventsLimit = 200
+)
+
+// Collector collects the support bundle of a Tenant Control Plane: the collection is best effort,
+// the failures are reported in the errors.txt file of the archive rather than aborting it.
+type Collector struct {
+	// Reader retrieves the objects, it should not be backed by a cache since pods and events are listed.
+	Reader client.Reader
+	Scheme *runtime.Scheme
+	// Clientset retrieves the pod logs.
+	Clientset kubernetes.Interface
+	// KamajiNamespace is the Namespace of the Kamaji pods, their logs are not collected if empty.
+	KamajiNamespace string
+	// KamajiSelector is the label selector of the Kamaji pods, DefaultKamajiSelector if empty.
+	KamajiSelector string
+	// LogLines is the number of the most recent log lines collected per container, DefaultLogLines if zero.
+	LogLines int64
+}
+
+// Collect writes the gzipped tarball of the support bundle of the given Tenant Control Plane.
+func (c *Collector) Collect(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, w io.Writer) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	b := &bundle{writer: tw, root: fmt.Sprintf("%s-%s", tcp.GetNamespace(), tcp.GetName()), time: time.Now()}
+
+	b.addObject(c.Scheme, "tenantcontrolplane.yaml", tcp.DeepCopy())
+	b.addYAML("conditions.yaml", tcp.Status.Conditions)
+
+	involved := c.collectManifests(ctx, b, tcp)
+	involved = append(involved, c.collectControlPlaneLogs(ctx, b, tcp)...)
+
+	c.collectEvents(ctx, b, tcp, involved)
+	c.collectKamajiLogs(ctx, b, tcp)
+
+	if len(b.errors) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.errors, "\n")+"\n"))
+	}
+
+	if err := tw.Close(); err != nil {
+		return errors.Wrap(err, "cannot close the tarball")
+	}
+
+	return errors.Wrap(gz.Close(), "cannot close the gzip stream")
+}
+
+// collectManifests adds the objects owned by the Tenant Control Plane, returning their names.
+func (c *Collector) collectManifests(ctx context.Context, b *bundle, tcp *kamajiv1alpha1.TenantControlPlane) []string {
+	lists := []client.ObjectList{
+		&appsv1.DeploymentList{},
+		&corev1.ServiceList{},
+		&corev1.ConfigMapList{},
+		&corev1.SecretList{},
+		&networkingv1.IngressList{},
+	}
+
+	names := []string{tcp.GetName()}
+
+	for _, list := range lists {
+		if err := c.Reader.List(ctx, list, client.InNamespace(tcp.GetNamespace())); err != nil {
+			b.fail(err, "cannot list the %T objects", list)
+
+			continue
+		}
+
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			b.fail(err, "cannot extract the %T items", list)
+
+			continue
+		}
+
+		for _, item := range items {
+			obj, ok := item.(client.Object)
+			if !ok || !isOwnedBy(obj, tcp) {
+				continue
+			}
+
+			if secret, ok := obj.(*corev1.Secret); ok {
+				RedactSecret(secret)
+			}
+
+			gvk, _ := apiutil.GVKForObject(obj, c.Scheme)
+			b.addObject(c.Scheme, path.Join("manifests", fmt.Sprintf("%s-%s.yaml", strings.ToLower(gvk.Kind), obj.GetName())), obj)
+
+			names = append(names, obj.GetName())
+		}
+	}
+
+	return names
+}
+
+// collectControlPlaneLogs adds the logs of the Control Plane pods running in the management cluster, returning their names.
+func (c *Collector) collectControlPlaneLogs(ctx context.Context, b *bundle, tcp *kamajiv1alpha1.TenantControlPlane) []string {
+	if tcp.TargetCluster() != nil {
+		b.fail(errors.New("the Control Plane pods are running in a target cluster"), "cannot collect the Control Plane logs")
+
+		return nil
+	}
+
+	selector, err := labels.Parse(tcp.Status.Kubernetes.Deployment.Selector)
+	if err != nil || selector.Empty() {
+		b.fail(errors.New("missing Deployment selector"), "cannot collect the Control Plane logs")
+
+		return nil
+	}
+
+	pods, err := c.listPods(ctx, tcp.GetNamespace(), selector)
+	if err != nil {
+		b.fail(err, "cannot list the Control Plane pods")
+
+		return nil
+	}
+
+	names := make([]string, 0, len(pods))
+
+	for _, pod := range pods {
+		names = append(names, pod.GetName())
+
+		for _, container := range pod.Spec.Containers {
+			logs, logsErr := c.logs(ctx, pod, container.Name)
+			if logsErr != nil {
+				b.fail(logsErr, "cannot retrieve the logs of %s/%s", pod.GetName(), container.Name)
+
+				continue
+			}
+
+			b.add(path.Join("logs", "control-plane", pod.GetName(), container.Name+".log"), Redact(logs))
+		}
+	}
+
+	return names
+}
+
+// collectKamajiLogs adds the log lines of the Kamaji pods referring to the Tenant Control Plane.
+func (c *Collector) collectKamajiLogs(ctx context.Context, b *bundle, tcp *kamajiv1alpha1.TenantControlPlane) {
+	if len(c.KamajiNamespace) == 0 {
+		return
+	}
+
+	rawSelector := c.KamajiSelector
+	if len(rawSelector) == 0 {
+		rawSelector = DefaultKamajiSelector
+	}
+
+	selector, err := labels.Parse(rawSelector)
+	if err != nil {
+		b.fail(err, "cannot parse the Kamaji selector")
+
+		return
+	}
+
+	pods, err := c.listPods(ctx, c.KamajiNamespace, selector)
+	if err != nil {
+		b.fail(err, "cannot list the Kamaji pods")
+
+		return
+	}
+
+	for _, pod := range pods {
+		for _, container := range pod.Spec.Containers {
+			logs, logsErr := c.logs(ctx, pod, container.Name)
+			if logsErr != nil {
+				b.fail(logsErr, "cannot retrieve the logs of %s/%s", pod.GetName(), container.Name)
+
+				continue
+			}
+
+			b.add(path.Join("logs", "kamaji", pod.GetName(), container.Name+".log"), Redact(tenantLines(logs, tcp)))
+		}
+	}
+}
+
+// collectEvents adds the most recent events of the Tenant Control Plane, and of the objects involved with it.
+func (c *Collector) collectEvents(ctx context.Context, b *bundle, tcp *kamajiv1alpha1.TenantControlPlane, involved []string) {
+	var eventList corev1.EventList
+	if err := c.Reader.List(ctx, &eventList, client.InNamespace(tcp.GetNamespace())); err != nil {
+		b.fail(err, "cannot list the events")
+
+		return
+	}
+
+	events := slices.DeleteFunc(eventList.Items, func(event corev1.Event) bool {
+		return !slices.Contains(involved, event.InvolvedObject.Name)
+	})
+
+	slices.SortFunc(events, func(a, b corev1.Event) int {
+		return eventTime(a).Compare(eventTime(b))
+	})
+
+	if len(events) > eventsLimit {
+		events = events[len(events)-eventsLimit:]
+	}
+
+	var out bytes.Buffer
+
+	for _, event := range events {
+		_, _ = fmt.Fprintf(&out, "%s\t%s\t%s/%s\t%s\t%s\n", eventTime(event).UTC().Format(time.RFC3339), event.Type, event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, event.Message)
+	}
+
+	b.add("events.txt", Redact(out.Bytes()))
+}
+
+func (c *Collector) listPods(ctx context.Context, namespace string, selector labels.Selector) ([]*corev1.Pod, error) {
+	var podList corev1.PodList
+	if err := c.Reader.List(ctx, &podList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	pods := make([]*corev1.Pod, 0, len(podList.Items))
+	for i := range podList.Items {
+		pods = append(pods, &podList.Items[i])
+	}
+
+	return pods, nil
+}
+
+func (c *Collector) logs(ctx context.Context, pod *corev1.Pod, container string) ([]byte, error) {
+	lines := c.LogLines
+	if lines == 0 {
+		lines = DefaultLogLines
+	}
+
+	return c.Clientset.CoreV1().Pods(pod.GetNamespace()).GetLogs(pod.GetName(), &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &lines,
+	}).DoRaw(ctx)
+}
+
+// tenantLines returns the log lines referring to the given Tenant Control Plane, by its namespaced name, or by its name and Namespace.
+func tenantLines(logs []byte, tcp *kamajiv1alpha1.TenantControlPlane) []byte {
+	var out bytes.Buffer
+
+	namespacedName := tcp.GetNamespace() + "/" + tcp.GetName()
+
+	scanner := bufio.NewScanner(bytes.NewReader(logs))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if strings.Contains(line, namespacedName) || (strings.Contains(line, `"`+tcp.GetName()+`"`) && strings.Contains(line, `"`+tcp.GetNamespace()+`"`)) {
+			out.WriteString(line)
+			out.WriteByte('\n')
+		}
+	}
+
+	return out.Bytes()
+}
+
+func eventTime(event corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+func isOwnedBy(obj client.Object, tcp *kamajiv1alpha1.TenantControlPlane) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == tcp.GetUID() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bundle writes the files of the support bundle, collecting the failures.
+type bundle struct {
+	writer *tar.Writer
+	root   string
+	time   time.Time
+	errors []string
+}
+
+func (b *bundle) fail(err error, format string, args ...any) {
+	b.errors = append(b.errors, fmt.Sprintf(format, args...)+": "+err.Error())
+}
+
+func (b *bundle) add(name string, content []byte) {
+	header := &tar.Header{
+		Name:    path.Join(b.root, name),
+		Mode:    0o600,
+		Size:    int64(len(content)),
+		ModTime: b.time,
+	}
+
+	if err := b.writer.WriteHeader(header); err != nil {
+		b.fail(err, "cannot write the header of %s", name)
+
+		return
+	}
+
+	if _, err := b.writer.Write(content); err != nil {
+		b.fail(err, "cannot write %s", name)
+	}
+}
+
+func (b *bundle) addObject(scheme *runtime.Scheme, name string, obj client.Object) {
+	if gvk, err := apiutil.GVKForObject(obj, scheme); err == nil {
+		obj.GetObjectKind().SetGroupVersionKind(gvk)
+	}
+
+	obj.SetManagedFields(nil)
+
+	out, err := utilities.EncodeToYaml(obj)
+	if err != nil {
+		b.fail(err, "cannot encode %s", name)
+
+		return
+	}
+
+	b.add(name, Redact(out))
+}
+
+func (b *bundle) addYAML(name string, value any) {
+	out, err := yaml.Marshal(value)
+	if err != nil {
+		b.fail(err, "cannot encode %s", name)
+
+		return
+	}
+
+	b.add(name, Redact(out))
+}
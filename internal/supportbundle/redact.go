@@ -0,0 +1,46 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package supportbundle
+
+import (
+	"regexp"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const redacted = "REDACTED"
+
+var redactions = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	// PEM encoded certificates and keys, such as the ones embedded in the kubeconfig files.
+	{regexp.MustCompile(`(?s)-----BEGIN [A-Z0-9 ]+-----.*?-----END [A-Z0-9 ]+-----`), "-----" + redacted + "-----"},
+	// Credentials expressed as key/value pairs, in YAML, JSON, command line flags, and logs.
+	{regexp.MustCompile(`(?i)((?:password|passwd|token|secret|client-key-data|client-certificate-data|certificate-authority-data|private[-_]?key)["']?\s*[:=]\s*["']?)[^\s"',}]+`), "${1}" + redacted},
+	// Bearer tokens of the HTTP authorization headers.
+	{regexp.MustCompile(`(?i)(bearer\s+)[a-z0-9\-._~+/]+=*`), "${1}" + redacted},
+	// Credentials of the connection strings, such as the DataStore ones.
+	{regexp.MustCompile(`(://[^:/\s@]+:)[^@\s]+@`), "${1}" + redacted + "@"},
+}
+
+// Redact replaces the sensitive data of the given content, such as keys, tokens, and passwords.
+func Redact(content []byte) []byte {
+	for _, r := range redactions {
+		content = r.pattern.ReplaceAll(content, []byte(r.replacement))
+	}
+
+	return content
+}
+
+// RedactSecret replaces the values of the given Secret, retaining its keys.
+func RedactSecret(secret *corev1.Secret) {
+	for key := range secret.Data {
+		secret.Data[key] = []byte(redacted)
+	}
+
+	for key := range secret.StringData {
+		secret.StringData[key] = redacted
+	}
+}
@@ -59,3 +59,16 @@ func ArgsAddFlagValue(args map[string]string, flag, value string) bool {
 
 	return !ok
 }
+
+// FeatureGatesFromMapToArg returns the value of the --feature-gates flag, sorted by the gate name.
+func FeatureGatesFromMapToArg(gates map[string]bool) string {
+	values := make([]string, 0, len(gates))
+
+	for gate, enabled := range gates {
+		values = append(values, fmt.Sprintf("%s=%t", gate, enabled))
+	}
+
+	sort.Strings(values)
+
+	return strings.Join(values, ",")
+}
@@ -28,3 +28,10 @@ func TestArgsFromSliceToMap(t *testing.T) {
 		}
 	}
 }
+
+func TestFeatureGatesFromMapToArg(t *testing.T) {
+	got := FeatureGatesFromMapToArg(map[string]bool{"b": false, "a": true})
+	if expect := "a=true,b=false"; got != expect {
+		t.Errorf("expected %q, but got %q", expect, got)
+	}
+}
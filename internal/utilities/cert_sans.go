@@ -0,0 +1,120 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// certSANsUnresolvedValue replaces the empty template values, detecting the entries which cannot be resolved yet.
+const certSANsUnresolvedValue = "\x00"
+
+// CertSANsValues are the values available to the templated certificate SANs, such as {{ .Name }}.tenants.example.com.
+type CertSANsValues struct {
+	Name                 string
+	Namespace            string
+	Address              string
+	LoadBalancerIP       string
+	LoadBalancerHostname string
+}
+
+// CertSANsSampleValues are used to validate the templated certificate SANs upon admission,
+// since the actual values are known only at reconciliation time.
+var CertSANsSampleValues = CertSANsValues{
+	Name:                 "tenant",
+	Namespace:            "default",
+	Address:              "10.0.0.1",
+	LoadBalancerIP:       "10.0.0.1",
+	LoadBalancerHostname: "lb.example.com",
+}
+
+// GetCertSANsValues returns the values of the templated certificate SANs for the given Tenant Control Plane:
+// the addresses not yet assigned, such as the load balancer ones, are left empty.
+func GetCertSANsValues(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) CertSANsValues {
+	values := CertSANsValues{
+		Name:      tenantControlPlane.GetName(),
+		Namespace: tenantControlPlane.GetNamespace(),
+	}
+
+	if address, _, err := tenantControlPlane.AssignedControlPlaneAddress(); err == nil {
+		values.Address = address
+	}
+
+	for _, ingress := range tenantControlPlane.Status.Kubernetes.Service.LoadBalancer.Ingress {
+		if len(values.LoadBalancerIP) == 0 {
+			values.LoadBalancerIP = ingress.IP
+		}
+
+		if len(values.LoadBalancerHostname) == 0 {
+			values.LoadBalancerHostname = ingress.Hostname
+		}
+	}
+
+	return values
+}
+
+// ResolveCertSANs returns the certificate SANs of the given Tenant Control Plane, rendering the templated ones:
+// in the host network mode, the addresses of the nodes eligible to run the Control Plane pods are added too.
+func ResolveCertSANs(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) ([]string, error) {
+	certSANs, err := RenderCertSANs(tenantControlPlane.Spec.NetworkProfile.CertSANs, GetCertSANsValues(tenantControlPlane))
+	if err != nil {
+		return nil, err
+	}
+
+	if hostNetwork := tenantControlPlane.Status.Kubernetes.HostNetwork; tenantControlPlane.HostNetwork() != nil && hostNetwork != nil {
+		certSANs = append(certSANs, hostNetwork.NodeAddresses...)
+	}
+
+	return certSANs, nil
+}
+
+// RenderCertSANs renders the templated certificate SANs with the given values:
+// the entries referring to an empty value, such as a load balancer address not yet assigned, are skipped.
+func RenderCertSANs(certSANs []string, values CertSANsValues) ([]string, error) {
+	if len(certSANs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := values
+	for _, value := range []*string{&placeholders.Name, &placeholders.Namespace, &placeholders.Address, &placeholders.LoadBalancerIP, &placeholders.LoadBalancerHostname} {
+		if len(*value) == 0 {
+			*value = certSANsUnresolvedValue
+		}
+	}
+
+	resolved := make([]string, 0, len(certSANs))
+
+	for _, certSAN := range certSANs {
+		if !strings.Contains(certSAN, "{{") {
+			resolved = append(resolved, certSAN)
+
+			continue
+		}
+
+		tmpl, err := template.New("certSAN").Option("missingkey=error").Parse(certSAN)
+		if err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot parse the certificate SAN %s", certSAN))
+		}
+
+		var sb strings.Builder
+		if err = tmpl.Execute(&sb, placeholders); err != nil {
+			return nil, errors.Wrap(err, fmt.Sprintf("cannot render the certificate SAN %s", certSAN))
+		}
+
+		rendered := strings.TrimSpace(sb.String())
+		if len(rendered) == 0 || strings.Contains(rendered, certSANsUnresolvedValue) {
+			continue
+		}
+
+		resolved = append(resolved, rendered)
+	}
+
+	return resolved, nil
+}
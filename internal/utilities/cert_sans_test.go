@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"slices"
+	"testing"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestRenderCertSANs(t *testing.T) {
+	values := CertSANsValues{Name: "tenant-00", Namespace: "default", LoadBalancerIP: "192.0.2.10"}
+
+	certSANs, err := RenderCertSANs([]string{
+		"api.example.com",
+		"{{ .Name }}.{{ .Namespace }}.tenants.example.com",
+		"{{ .LoadBalancerIP }}",
+		"{{ .LoadBalancerHostname }}",
+		"lb.{{ .Address }}.nip.io",
+	}, values)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"api.example.com", "tenant-00.default.tenants.example.com", "192.0.2.10"}
+	if !slices.Equal(certSANs, expected) {
+		t.Errorf("expected %v, got %v", expected, certSANs)
+	}
+}
+
+func TestRenderCertSANsInvalid(t *testing.T) {
+	for _, certSAN := range []string{"{{ .Unknown }}.example.com", "{{ .Name"} {
+		if _, err := RenderCertSANs([]string{certSAN}, CertSANsSampleValues); err == nil {
+			t.Errorf("expected %s to be rejected", certSAN)
+		}
+	}
+}
+
+func TestResolveCertSANsHostNetwork(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{}
+	tcp.Spec.NetworkProfile.CertSANs = []string{"api.example.com"}
+	tcp.Status.Kubernetes.HostNetwork = &kamajiv1alpha1.KubernetesHostNetworkStatus{NodeAddresses: []string{"192.0.2.1", "192.0.2.2"}}
+
+	certSANs, err := ResolveCertSANs(tcp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The node addresses are ignored once the host network mode is disabled.
+	if expected := []string{"api.example.com"}; !slices.Equal(certSANs, expected) {
+		t.Errorf("expected %v, got %v", expected, certSANs)
+	}
+
+	tcp.Spec.ControlPlane.Deployment.HostNetwork = &kamajiv1alpha1.HostNetworkSpec{}
+
+	if certSANs, err = ResolveCertSANs(tcp); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := []string{"api.example.com", "192.0.2.1", "192.0.2.2"}; !slices.Equal(certSANs, expected) {
+		t.Errorf("expected %v, got %v", expected, certSANs)
+	}
+}
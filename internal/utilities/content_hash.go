@@ -0,0 +1,111 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// observedResourceVersions tracks the resource version of the objects once aligned by a handler, keyed by handler and UID:
+// it's bounded since the entries of the deleted objects are never removed, and an evicted entry only costs a full comparison.
+var observedResourceVersions = lru.New(16384)
+
+// CreateOrUpdateWithContentHash wraps CreateOrUpdateWithConflict, skipping the update of the objects whose content is unchanged:
+// the hash of the mutated object is stored in a per-handler annotation, and the update is skipped when it matches the stored one,
+// as long as the object has not been changed since the last alignment by the handler, such as by a third party.
+// It cuts the no-op writes of the objects defaulted by the API Server, where the mutated object never matches the stored one.
+func CreateOrUpdateWithContentHash(ctx context.Context, client client.Client, handler string, resource client.Object, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	key := constants.ContentHashAnnotationPrefix + handler
+
+	res, err := CreateOrUpdateWithConflict(ctx, client, resource, contentHashMutateFn(key, resource, f))
+	if err != nil {
+		return res, err
+	}
+
+	observedResourceVersions.Add(key+"/"+string(resource.GetUID()), resource.GetResourceVersion())
+
+	return res, nil
+}
+
+func contentHashMutateFn(key string, resource client.Object, f controllerutil.MutateFn) controllerutil.MutateFn {
+	return func() error {
+		existing := resource.DeepCopyObject().(client.Object) //nolint:forcetypeassert
+
+		if err := f(); err != nil {
+			return err
+		}
+
+		hash, err := contentHash(resource)
+		if err != nil {
+			return errors.Wrap(err, "cannot compute the content hash")
+		}
+
+		if len(existing.GetResourceVersion()) > 0 && existing.GetAnnotations()[key] == hash {
+			if observed, ok := observedResourceVersions.Get(key + "/" + string(existing.GetUID())); ok && observed == existing.GetResourceVersion() {
+				// Restoring the retrieved object, CreateOrUpdate doesn't issue any write.
+				reflect.ValueOf(resource).Elem().Set(reflect.ValueOf(existing).Elem())
+
+				return nil
+			}
+		}
+
+		resource.SetAnnotations(MergeMaps(resource.GetAnnotations(), map[string]string{key: hash}))
+
+		return nil
+	}
+}
+
+// contentHash returns the hash of the object content, ignoring the type, the status, and the metadata managed by the API Server:
+// the hashes of the other handlers are ignored too, otherwise the handlers sharing an object would never converge.
+func contentHash(obj client.Object) (string, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return "", err
+	}
+
+	u := unstructured.Unstructured{Object: content}
+	u.SetAPIVersion("")
+	u.SetKind("")
+	u.SetResourceVersion("")
+	u.SetGeneration(0)
+	u.SetManagedFields(nil)
+	u.SetCreationTimestamp(metav1.Time{})
+
+	var annotations map[string]string
+
+	for k, v := range u.GetAnnotations() {
+		if strings.HasPrefix(k, constants.ContentHashAnnotationPrefix) {
+			continue
+		}
+
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[k] = v
+	}
+	// The empty annotations are removed, matching the objects having only the content hashes.
+	u.SetAnnotations(annotations)
+	unstructured.RemoveNestedField(u.Object, "status")
+
+	raw, err := json.Marshal(u.Object)
+	if err != nil {
+		return "", err
+	}
+
+	return md5Checksum(raw), nil
+}
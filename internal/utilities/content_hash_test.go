@@ -0,0 +1,100 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func TestCreateOrUpdateWithContentHash(t *testing.T) {
+	var updates int
+
+	// Mimicking the API Server defaulting, the mutated object never matches the stored one.
+	defaulting := func(obj client.Object) {
+		if cm, ok := obj.(*corev1.ConfigMap); ok {
+			cm.Data["defaulted"] = "true"
+		}
+	}
+
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			defaulting(obj)
+
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			updates++
+			defaulting(obj)
+
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+	ctx := context.Background()
+
+	apply := func(content string) controllerutil.OperationResult {
+		cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00-config", Namespace: "default"}}
+
+		result, err := CreateOrUpdateWithContentHash(ctx, c, "config", cm, func() error {
+			cm.Data = map[string]string{"content": content}
+
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return result
+	}
+
+	for _, tc := range []struct {
+		content  string
+		expected controllerutil.OperationResult
+		updates  int
+	}{
+		{content: "first", expected: controllerutil.OperationResultCreated, updates: 0},
+		{content: "first", expected: controllerutil.OperationResultNone, updates: 0},
+		{content: "first", expected: controllerutil.OperationResultNone, updates: 0},
+		{content: "second", expected: controllerutil.OperationResultUpdated, updates: 1},
+		{content: "second", expected: controllerutil.OperationResultNone, updates: 1},
+	} {
+		if result := apply(tc.content); result != tc.expected {
+			t.Errorf("expected the %s result for the %s content, got %s", tc.expected, tc.content, result)
+		}
+
+		if updates != tc.updates {
+			t.Errorf("expected %d updates for the %s content, got %d", tc.updates, tc.content, updates)
+		}
+	}
+
+	// A change by a third party is reverted, even if the desired content is unchanged.
+	var cm corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKey{Name: "tenant-00-config", Namespace: "default"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+
+	cm.Data["content"] = "drifted"
+	if err := c.Update(ctx, &cm); err != nil {
+		t.Fatal(err)
+	}
+
+	if result := apply("second"); result != controllerutil.OperationResultUpdated {
+		t.Errorf("expected the drifted object to be updated, got %s", result)
+	}
+
+	if err := c.Get(ctx, client.ObjectKey{Name: "tenant-00-config", Namespace: "default"}, &cm); err != nil {
+		t.Fatal(err)
+	}
+
+	if cm.Data["content"] != "second" {
+		t.Errorf("expected the drift to be reverted, got %s", cm.Data["content"])
+	}
+}
@@ -6,6 +6,7 @@ package utilities
 import (
 	"context"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/retry"
@@ -24,7 +25,12 @@ func CreateOrUpdateWithConflict(ctx context.Context, client client.Client, resou
 			}
 		}
 
-		res, scopeErr = controllerutil.CreateOrUpdate(ctx, client, resource, f)
+		mutateFn := f
+		if secret, ok := resource.(*corev1.Secret); ok {
+			mutateFn = secretRotationGenerationMutateFn(secret, f)
+		}
+
+		res, scopeErr = controllerutil.CreateOrUpdate(ctx, client, resource, mutateFn)
 
 		return scopeErr
 	})
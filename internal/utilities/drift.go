@@ -0,0 +1,73 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"sync"
+
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+type driftReportKey struct{}
+
+// DriftEntry is an object differing from the rendered one.
+type DriftEntry struct {
+	Kind      string
+	Namespace string
+	Name      string
+	// Diff is the difference between the current object, and the rendered one:
+	// it's empty for the missing objects.
+	Diff    string
+	Missing bool
+}
+
+// DriftReport collects the drifted objects, it's safe for concurrent use.
+type DriftReport struct {
+	mu      sync.Mutex
+	entries []DriftEntry
+}
+
+func (r *DriftReport) Add(entry DriftEntry) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, entry)
+}
+
+func (r *DriftReport) Entries() []DriftEntry {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]DriftEntry(nil), r.entries...)
+}
+
+// WithDriftReport returns a context making ServerSideApply perform dry-run requests only, regardless of the addon trait:
+// the drifted, and the missing objects, are recorded in the given report rather than being reconciled.
+func WithDriftReport(ctx context.Context, report *DriftReport) context.Context {
+	return context.WithValue(ctx, driftReportKey{}, report)
+}
+
+func driftReportFrom(ctx context.Context) *DriftReport {
+	report, _ := ctx.Value(driftReportKey{}).(*DriftReport)
+
+	return report
+}
+
+// DriftDiff returns the difference between the current object and the rendered one,
+// ignoring the metadata which is changing regardless of the object content: it's empty if they don't differ.
+func DriftDiff(current, rendered *unstructured.Unstructured) string {
+	return cmp.Diff(stripDriftMetadata(current), stripDriftMetadata(rendered))
+}
+
+func stripDriftMetadata(u *unstructured.Unstructured) map[string]any {
+	content := u.DeepCopy().Object
+	unstructured.RemoveNestedField(content, "metadata", "managedFields")
+	unstructured.RemoveNestedField(content, "metadata", "resourceVersion")
+	unstructured.RemoveNestedField(content, "metadata", "generation")
+	unstructured.RemoveNestedField(content, "status")
+
+	return content
+}
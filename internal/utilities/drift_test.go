@@ -0,0 +1,36 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestDriftDiff(t *testing.T) {
+	current := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "coredns", "resourceVersion": "10", "generation": int64(2)},
+		"data":     map[string]any{"Corefile": ".:53 { errors }"},
+		"status":   map[string]any{"replicas": int64(2)},
+	}}
+
+	rendered := current.DeepCopy()
+	rendered.SetResourceVersion("11")
+	rendered.SetGeneration(3)
+	unstructured.RemoveNestedField(rendered.Object, "status")
+
+	if diff := DriftDiff(current, rendered); len(diff) > 0 {
+		t.Errorf("expected no drift when only the volatile metadata differ, got %s", diff)
+	}
+
+	if err := unstructured.SetNestedField(rendered.Object, ".:53 { errors\nhealth }", "data", "Corefile"); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := DriftDiff(current, rendered); !strings.Contains(diff, "Corefile") {
+		t.Errorf("expected the drifted field to be reported, got %q", diff)
+	}
+}
@@ -0,0 +1,34 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// GetImageProfile retrieves the ImageProfile referenced by the given Tenant Control Plane:
+// a nil profile is returned when no reference is declared, resolving the images with no changes.
+// The FIPS-validated component images take precedence when the Tenant Control Plane runs in FIPS mode.
+func GetImageProfile(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*kamajiv1alpha1.ImageProfile, error) {
+	if len(tenantControlPlane.Spec.ImageProfile) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	var profile kamajiv1alpha1.ImageProfile
+	if err := c.Get(ctx, types.NamespacedName{Name: tenantControlPlane.Spec.ImageProfile}, &profile); err != nil {
+		return nil, errors.Wrap(err, "cannot retrieve the ImageProfile")
+	}
+
+	if tenantControlPlane.IsFIPS() {
+		return profile.WithFIPSComponents(), nil
+	}
+
+	return &profile, nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/apis/certificates"
+)
+
+const kubeletNodeUsernamePrefix = "system:node:"
+
+// ParseKubeletServingCSR parses the given kubelet serving CSR, returning the name of the node requesting it:
+// the CSR must be requested by the same node it's issued for, with the usages of a serving certificate.
+func ParseKubeletServingCSR(csr *certificatesv1.CertificateSigningRequest) (*x509.CertificateRequest, string, error) {
+	if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName {
+		return nil, "", fmt.Errorf("unexpected signer %s", csr.Spec.SignerName)
+	}
+
+	request, err := certificates.ParseCSR(csr.Spec.Request)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "cannot parse the certificate request")
+	}
+
+	usages := sets.NewString()
+	for _, usage := range csr.Spec.Usages {
+		usages.Insert(string(usage))
+	}
+
+	if err = certificates.ValidateKubeletServingCSR(request, usages); err != nil {
+		return nil, "", err
+	}
+
+	if csr.Spec.Username != request.Subject.CommonName {
+		return nil, "", fmt.Errorf("the CSR is requested by %s, rather than by %s", csr.Spec.Username, request.Subject.CommonName)
+	}
+
+	if !slices.Contains(csr.Spec.Groups, "system:nodes") {
+		return nil, "", errors.New("the requestor is not member of the system:nodes group")
+	}
+
+	return request, strings.TrimPrefix(request.Subject.CommonName, kubeletNodeUsernamePrefix), nil
+}
+
+// ValidateKubeletServingCSRAddresses verifies the subject alternative names of the kubelet serving CSR
+// are part of the addresses reported by the requesting node.
+func ValidateKubeletServingCSRAddresses(request *x509.CertificateRequest, node *corev1.Node) error {
+	dnsNames, ips := sets.New[string](), sets.New[string]()
+
+	for _, address := range node.Status.Addresses {
+		switch address.Type {
+		case corev1.NodeHostName, corev1.NodeInternalDNS, corev1.NodeExternalDNS:
+			dnsNames.Insert(address.Address)
+		case corev1.NodeInternalIP, corev1.NodeExternalIP:
+			if ip := net.ParseIP(address.Address); ip != nil {
+				ips.Insert(ip.String())
+			}
+		}
+	}
+
+	for _, dnsName := range request.DNSNames {
+		if !dnsNames.Has(dnsName) {
+			return fmt.Errorf("the DNS name %s is not an address of the node %s", dnsName, node.GetName())
+		}
+	}
+
+	for _, ip := range request.IPAddresses {
+		if !ips.Has(ip.String()) {
+			return fmt.Errorf("the IP %s is not an address of the node %s", ip.String(), node.GetName())
+		}
+	}
+
+	return nil
+}
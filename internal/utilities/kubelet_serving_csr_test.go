@@ -0,0 +1,102 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func kubeletServingCSR(t *testing.T, commonName string, dnsNames []string, ips []net.IP) *certificatesv1.CertificateSigningRequest {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:     pkix.Name{CommonName: commonName, Organization: []string{"system:nodes"}},
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &certificatesv1.CertificateSigningRequest{
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der}),
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Usages:     []certificatesv1.KeyUsage{certificatesv1.UsageDigitalSignature, certificatesv1.UsageServerAuth},
+			Username:   "system:node:worker-1",
+			Groups:     []string{"system:nodes", "system:authenticated"},
+		},
+	}
+}
+
+func TestParseKubeletServingCSR(t *testing.T) {
+	csr := kubeletServingCSR(t, "system:node:worker-1", []string{"worker-1"}, nil)
+
+	_, nodeName, err := ParseKubeletServingCSR(csr)
+	if err != nil {
+		t.Fatalf("expected valid CSR, got %v", err)
+	}
+
+	if nodeName != "worker-1" {
+		t.Errorf("expected node worker-1, got %s", nodeName)
+	}
+
+	csr.Spec.Username = "system:node:worker-2"
+	if _, _, err = ParseKubeletServingCSR(csr); err == nil {
+		t.Error("expected error for a CSR requested by another node")
+	}
+
+	csr = kubeletServingCSR(t, "system:node:worker-1", []string{"worker-1"}, nil)
+	csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1.UsageClientAuth)
+
+	if _, _, err = ParseKubeletServingCSR(csr); err == nil {
+		t.Error("expected error for a CSR with client usages")
+	}
+}
+
+func TestValidateKubeletServingCSRAddresses(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "worker-1"},
+		Status: corev1.NodeStatus{
+			Addresses: []corev1.NodeAddress{
+				{Type: corev1.NodeHostName, Address: "worker-1"},
+				{Type: corev1.NodeInternalIP, Address: "10.0.0.10"},
+			},
+		},
+	}
+
+	request, _, err := ParseKubeletServingCSR(kubeletServingCSR(t, "system:node:worker-1", []string{"worker-1"}, []net.IP{net.ParseIP("10.0.0.10")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ValidateKubeletServingCSRAddresses(request, node); err != nil {
+		t.Errorf("expected matching addresses, got %v", err)
+	}
+
+	request, _, err = ParseKubeletServingCSR(kubeletServingCSR(t, "system:node:worker-1", []string{"worker-1"}, []net.IP{net.ParseIP("10.0.0.11")}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = ValidateKubeletServingCSRAddresses(request, node); err == nil {
+		t.Error("expected error for an IP not reported by the node")
+	}
+}
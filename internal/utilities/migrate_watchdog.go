@@ -0,0 +1,74 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// MigrateWatchdogLeaseName is the Lease renewed by the migration Job in the kube-system Namespace of the Tenant Cluster:
+// the webhook freezing the Tenant Cluster during the migration is removed once it expires, such as when the Job is not running anymore.
+const MigrateWatchdogLeaseName = "kamaji-migrate-watchdog"
+
+func migrateWatchdogLease() *coordinationv1.Lease {
+	return &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      MigrateWatchdogLeaseName,
+			Namespace: metav1.NamespaceSystem,
+		},
+	}
+}
+
+// RenewMigrateWatchdog creates, or renews, the migration watchdog Lease on behalf of the given holder.
+func RenewMigrateWatchdog(ctx context.Context, c client.Client, holder string, duration time.Duration) error {
+	lease := migrateWatchdogLease()
+
+	_, err := controllerutil.CreateOrUpdate(ctx, c, lease, func() error {
+		now := metav1.NowMicro()
+
+		if ptr.Deref(lease.Spec.HolderIdentity, "") != holder {
+			lease.Spec.AcquireTime = &now
+		}
+
+		lease.Spec.HolderIdentity = ptr.To(holder)
+		lease.Spec.LeaseDurationSeconds = ptr.To(int32(duration.Seconds()))
+		lease.Spec.RenewTime = &now
+
+		return nil
+	})
+
+	return err
+}
+
+// GetMigrateWatchdog returns the migration watchdog Lease.
+func GetMigrateWatchdog(ctx context.Context, c client.Client) (*coordinationv1.Lease, error) {
+	lease := migrateWatchdogLease()
+
+	if err := c.Get(ctx, client.ObjectKeyFromObject(lease), lease); err != nil {
+		return nil, err
+	}
+
+	return lease, nil
+}
+
+// DeleteMigrateWatchdog deletes the migration watchdog Lease, if present.
+func DeleteMigrateWatchdog(ctx context.Context, c client.Client) error {
+	return client.IgnoreNotFound(c.Delete(ctx, migrateWatchdogLease()))
+}
+
+// IsMigrateWatchdogExpired returns true if the Lease has not been renewed within its duration.
+func IsMigrateWatchdogExpired(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return true
+	}
+
+	return now.After(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestMigrateWatchdog(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).Build()
+
+	if err := RenewMigrateWatchdog(ctx, c, "migrate-abcde", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	lease, err := GetMigrateWatchdog(ctx, c)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if IsMigrateWatchdogExpired(lease, time.Now()) {
+		t.Errorf("expected the renewed Lease not to be expired")
+	}
+
+	if !IsMigrateWatchdogExpired(lease, time.Now().Add(2*time.Minute)) {
+		t.Errorf("expected the Lease to be expired once its duration has elapsed")
+	}
+
+	acquired := lease.Spec.AcquireTime
+
+	if err = RenewMigrateWatchdog(ctx, c, "migrate-abcde", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+
+	if lease, err = GetMigrateWatchdog(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if !lease.Spec.AcquireTime.Equal(acquired) {
+		t.Errorf("expected the acquire time to be retained when renewed by the same holder")
+	}
+
+	if err = DeleteMigrateWatchdog(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = DeleteMigrateWatchdog(ctx, c); err != nil {
+		t.Errorf("expected the deletion of a missing Lease to be ignored, got %v", err)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// NamingValues are the values available to the naming template, such as hcp-{{ .Name }}.
+type NamingValues struct {
+	Name      string
+	Namespace string
+}
+
+// RenderObjectName renders the naming template of the given Tenant Control Plane,
+// returning its name when no template has been declared.
+func RenderObjectName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (string, error) {
+	if tenantControlPlane.Spec.Naming == nil || len(tenantControlPlane.Spec.Naming.Template) == 0 {
+		return tenantControlPlane.GetName(), nil
+	}
+
+	tmpl, err := template.New("naming").Option("missingkey=error").Parse(tenantControlPlane.Spec.Naming.Template)
+	if err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("cannot parse the naming template %s", tenantControlPlane.Spec.Naming.Template))
+	}
+
+	var sb strings.Builder
+	if err = tmpl.Execute(&sb, NamingValues{Name: tenantControlPlane.GetName(), Namespace: tenantControlPlane.GetNamespace()}); err != nil {
+		return "", errors.Wrap(err, fmt.Sprintf("cannot render the naming template %s", tenantControlPlane.Spec.Naming.Template))
+	}
+
+	name := strings.TrimSpace(sb.String())
+	if errs := validation.IsDNS1035Label(name); len(errs) > 0 {
+		return "", fmt.Errorf("the rendered name %s is not valid: %s", name, strings.Join(errs, ", "))
+	}
+
+	return name, nil
+}
+
+// ObjectName returns the base name of the objects generated for the given Tenant Control Plane:
+// the Deployment, and the Services, are named after it, and it prefixes the names of the other objects.
+// The naming template is validated upon admission, falling back to the Tenant Control Plane name when it cannot be rendered.
+func ObjectName(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
+	name, err := RenderObjectName(tenantControlPlane)
+	if err != nil {
+		return tenantControlPlane.GetName()
+	}
+
+	return name
+}
@@ -0,0 +1,43 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestObjectName(t *testing.T) {
+	tcp := &kamajiv1alpha1.TenantControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "team-a"}}
+
+	if name := ObjectName(tcp); name != "tenant-00" {
+		t.Errorf("expected the Tenant Control Plane name by default, got %s", name)
+	}
+
+	tcp.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "hcp-{{ .Namespace }}-{{ .Name }}"}
+
+	if name := ObjectName(tcp); name != "hcp-team-a-tenant-00" {
+		t.Errorf("unexpected rendered name %s", name)
+	}
+
+	if name := AddTenantPrefix("admin-kubeconfig", tcp); name != "hcp-team-a-tenant-00-admin-kubeconfig" {
+		t.Errorf("unexpected prefixed name %s", name)
+	}
+}
+
+func TestRenderObjectNameInvalid(t *testing.T) {
+	for _, template := range []string{"{{ .Unknown }}", "{{ .Name", "{{ .Namespace }}.{{ .Name }}", "-{{ .Name }}"} {
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{Name: "tenant-00", Namespace: "team-a"},
+			Spec:       kamajiv1alpha1.TenantControlPlaneSpec{Naming: &kamajiv1alpha1.NamingSpec{Template: template}},
+		}
+
+		if _, err := RenderObjectName(tcp); err == nil {
+			t.Errorf("expected %s to be rejected", template)
+		}
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// secretRotationGenerationMutateFn wraps the given MutateFn, tracking the rotation generation of the Kamaji Secrets:
+// the generation starts from 1 upon creation, and it's increased each time the content is generated again.
+// The label is restored when the MutateFn replaces all the labels.
+func secretRotationGenerationMutateFn(secret *corev1.Secret, f controllerutil.MutateFn) controllerutil.MutateFn {
+	return func() error {
+		previousData := make(map[string][]byte, len(secret.Data))
+		for k, v := range secret.Data {
+			previousData[k] = v
+		}
+
+		generation, _ := strconv.ParseInt(secret.GetLabels()[constants.RotationGenerationLabelKey], 10, 64)
+
+		if err := f(); err != nil {
+			return err
+		}
+
+		if secret.GetLabels()[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+			return nil
+		}
+
+		if generation == 0 || !equality.Semantic.DeepEqual(previousData, secret.Data) {
+			generation++
+		}
+
+		secret.SetLabels(MergeMaps(secret.GetLabels(), map[string]string{
+			constants.RotationGenerationLabelKey: strconv.FormatInt(generation, 10),
+		}))
+
+		return nil
+	}
+}
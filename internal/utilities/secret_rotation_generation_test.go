@@ -0,0 +1,69 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+func TestSecretRotationGeneration(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+	ctx := context.Background()
+
+	apply := func(content string) string {
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "tenant-00-ca", Namespace: "default"}}
+
+		if _, err := CreateOrUpdateWithConflict(ctx, c, secret, func() error {
+			// Replacing the labels, as most of the resources do.
+			secret.SetLabels(KamajiLabels("tenant-00", "ca"))
+			secret.Data = map[string][]byte{"ca.crt": []byte(content)}
+
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		return secret.GetLabels()[constants.RotationGenerationLabelKey]
+	}
+
+	for _, tc := range []struct {
+		content  string
+		expected string
+	}{
+		{content: "first", expected: "1"},
+		{content: "first", expected: "1"},
+		{content: "second", expected: "2"},
+		{content: "second", expected: "2"},
+		{content: "third", expected: "3"},
+	} {
+		if generation := apply(tc.content); generation != tc.expected {
+			t.Errorf("expected the rotation generation %s for the %s content, got %s", tc.expected, tc.content, generation)
+		}
+	}
+}
+
+func TestSecretRotationGenerationNotKamaji(t *testing.T) {
+	c := fake.NewClientBuilder().Build()
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "user-secret", Namespace: "default"}}
+
+	if _, err := CreateOrUpdateWithConflict(context.Background(), c, secret, func() error {
+		secret.Data = map[string][]byte{"key": []byte("value")}
+
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := secret.GetLabels()[constants.RotationGenerationLabelKey]; ok {
+		t.Errorf("expected no rotation generation for the Secrets not managed by Kamaji, got %v", secret.GetLabels())
+	}
+}
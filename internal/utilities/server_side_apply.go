@@ -0,0 +1,441 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+// FieldManager is the name of the field manager used by Kamaji when applying objects in the Tenant Cluster.
+const FieldManager = "kamaji"
+
+// maxConflictResolutions is the upper bound of apply attempts when ignoring the fields owned by other managers.
+const maxConflictResolutions = 5
+
+// ServerSideApply applies the object resulting from the given MutateFn using the server-side apply strategy:
+// the function is executed against an empty object, rather than the one retrieved from the API Server, since only the
+// fields owned by Kamaji must be declared. The conflicts with other field managers, and the drift of existing objects,
+// are handled according to the addon trait, and the given object is updated with the API Server response.
+func ServerSideApply(ctx context.Context, c client.Client, obj client.Object, trait kamajiv1alpha1.AddonApplyTrait, f controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	if err := f(); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(gvk)
+
+	var created bool
+
+	if err = c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+
+		created = true
+	}
+
+	driftDetection, report := trait.GetDriftDetection(), driftReportFrom(ctx)
+	// Objects are installed only once, the ones already present are left untouched.
+	if !created && driftDetection == kamajiv1alpha1.AddonDriftDetectionDisabled {
+		return controllerutil.OperationResultNone, fromUnstructured(current, obj)
+	}
+
+	if created && report != nil {
+		report.Add(DriftEntry{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Missing: true})
+
+		return controllerutil.OperationResultNone, nil
+	}
+
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return controllerutil.OperationResultNone, errors.Wrap(err, "cannot convert object to unstructured")
+	}
+
+	desired := &unstructured.Unstructured{Object: content}
+	desired.SetGroupVersionKind(gvk)
+	desired.SetResourceVersion("")
+	desired.SetUID("")
+	desired.SetManagedFields(nil)
+	unstructured.RemoveNestedField(desired.Object, "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(desired.Object, "spec", "template", "metadata", "creationTimestamp")
+	unstructured.RemoveNestedField(desired.Object, "status")
+
+	opts := []client.PatchOption{client.FieldOwner(FieldManager)}
+	if trait.ConflictPolicy != kamajiv1alpha1.AddonConflictPolicyIgnoreUserFields {
+		opts = append(opts, client.ForceOwnership)
+	}
+	// The drift is detected by applying the object in dry-run mode, and comparing the result with the current state.
+	dryRun := report != nil || !created && driftDetection == kamajiv1alpha1.AddonDriftDetectionWarnOnly
+	if dryRun {
+		opts = append(opts, client.DryRunAll)
+	}
+
+	if !created && !dryRun {
+		if err = upgradeManagedFields(ctx, c, current); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = c.Patch(ctx, desired, client.Apply, opts...)
+		if err == nil {
+			break
+		}
+
+		if !apierrors.IsConflict(err) || trait.ConflictPolicy != kamajiv1alpha1.AddonConflictPolicyIgnoreUserFields || attempt == maxConflictResolutions {
+			return controllerutil.OperationResultNone, err
+		}
+		// Removing the fields owned by other managers from the applied configuration:
+		// the API Server returns them as causes of the conflict error.
+		if err = removeConflictingFields(desired.Object, err); err != nil {
+			return controllerutil.OperationResultNone, err
+		}
+	}
+
+	if dryRun {
+		switch {
+		case report != nil:
+			if diff := DriftDiff(current, desired); len(diff) > 0 {
+				report.Add(DriftEntry{Kind: gvk.Kind, Namespace: obj.GetNamespace(), Name: obj.GetName(), Diff: diff})
+			}
+		case hasDrifted(current, desired):
+			log.FromContext(ctx).Info("drift detected, the object is not going to be reconciled", "kind", gvk.Kind, "namespace", obj.GetNamespace(), "name", obj.GetName())
+		}
+
+		return controllerutil.OperationResultNone, fromUnstructured(current, obj)
+	}
+
+	if err = fromUnstructured(desired, obj); err != nil {
+		return controllerutil.OperationResultNone, err
+	}
+
+	switch {
+	case created:
+		return controllerutil.OperationResultCreated, nil
+	case current.GetResourceVersion() != obj.GetResourceVersion():
+		return controllerutil.OperationResultUpdated, nil
+	default:
+		return controllerutil.OperationResultNone, nil
+	}
+}
+
+// upgradeManagedFields transfers the fields owned by the Update operations issued by Kamaji,
+// such as the ones of the objects created before the adoption of the server-side apply, to its apply field manager:
+// otherwise, they would be considered as owned by another manager, and ignored upon conflicts.
+func upgradeManagedFields(ctx context.Context, c client.Client, current *unstructured.Unstructured) error {
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, sets.New(FieldManager), FieldManager)
+	if err != nil {
+		return errors.Wrap(err, "cannot compute the managed fields upgrade")
+	}
+
+	if patch == nil {
+		return nil
+	}
+
+	if err = c.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch)); err != nil {
+		return errors.Wrap(err, "cannot upgrade the managed fields")
+	}
+
+	return nil
+}
+
+func fromUnstructured(u *unstructured.Unstructured, obj client.Object) error {
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, obj); err != nil {
+		return errors.Wrap(err, "cannot convert object from unstructured")
+	}
+
+	return nil
+}
+
+// hasDrifted compares the current object with the result of the dry-run apply,
+// ignoring the metadata which is changing regardless of the object content.
+func hasDrifted(current, applied *unstructured.Unstructured) bool {
+	return !equality.Semantic.DeepEqual(stripDriftMetadata(current), stripDriftMetadata(applied))
+}
+
+func removeConflictingFields(content map[string]any, conflictErr error) error {
+	var statusErr apierrors.APIStatus
+	if !errors.As(conflictErr, &statusErr) || statusErr.Status().Details == nil {
+		return conflictErr
+	}
+
+	var removed bool
+
+	for _, cause := range statusErr.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+
+		if err := RemoveFieldPath(content, cause.Field); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("cannot ignore conflicting field %s", cause.Field))
+		}
+
+		removed = true
+	}
+
+	if !removed {
+		return conflictErr
+	}
+
+	return nil
+}
+
+// RemoveFieldPath removes the field identified by the given path from the unstructured content.
+// The path follows the structured-merge-diff notation used in the server-side apply conflicts,
+// such as .spec.template.spec.containers[name="coredns"].image, or .metadata.labels.app.
+func RemoveFieldPath(content map[string]any, path string) error {
+	elements, err := parseFieldPath(path)
+	if err != nil {
+		return err
+	}
+
+	if len(elements) == 0 {
+		return fmt.Errorf("empty field path")
+	}
+
+	_, err = removeFieldPathElements(content, elements)
+
+	return err
+}
+
+func removeFieldPathElements(node any, elements []fieldPathElement) (any, error) {
+	element, last := elements[0], len(elements) == 1
+
+	switch value := node.(type) {
+	case map[string]any:
+		if element.field == nil {
+			return nil, fmt.Errorf("expected a list selector for an object")
+		}
+
+		// Field names can contain dots, such as labels and annotations keys,
+		// which are not escaped in the path notation: joining the following elements until a match is found.
+		key, consumed := *element.field, 1
+
+		child, ok := value[key]
+		for !ok && consumed < len(elements) && elements[consumed].field != nil {
+			key += "." + *elements[consumed].field
+			consumed++
+
+			child, ok = value[key]
+		}
+
+		if !ok {
+			// The field is not declared in the applied configuration: nothing to remove.
+			return value, nil
+		}
+
+		elements, last = elements[consumed-1:], consumed == len(elements)
+
+		if last {
+			delete(value, key)
+
+			return value, nil
+		}
+
+		updated, err := removeFieldPathElements(child, elements[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		value[key] = updated
+
+		return value, nil
+	case []any:
+		if element.field != nil {
+			return nil, fmt.Errorf("expected a field name for a list")
+		}
+
+		index := element.indexOf(value)
+		if index < 0 {
+			return value, nil
+		}
+
+		if last {
+			return append(value[:index:index], value[index+1:]...), nil
+		}
+
+		updated, err := removeFieldPathElements(value[index], elements[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		value[index] = updated
+
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// fieldPathElement is one of the elements of a structured-merge-diff path:
+// a field name, the keys of an associative list item, the value of a set item, or a list index.
+type fieldPathElement struct {
+	field *string
+	keys  map[string]any
+	value any
+	index *int
+}
+
+func (e fieldPathElement) indexOf(list []any) int {
+	for i, item := range list {
+		switch {
+		case e.index != nil:
+			if *e.index == i {
+				return i
+			}
+		case e.keys != nil:
+			obj, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+
+			matches := true
+
+			for k, v := range e.keys {
+				if !jsonEqual(obj[k], v) {
+					matches = false
+
+					break
+				}
+			}
+
+			if matches {
+				return i
+			}
+		default:
+			if jsonEqual(item, e.value) {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// jsonEqual compares values regardless of their numeric types,
+// since unstructured objects use int64 while decoded JSON values are float64.
+func jsonEqual(a, b any) bool {
+	x, xErr := json.Marshal(a)
+	y, yErr := json.Marshal(b)
+
+	return xErr == nil && yErr == nil && string(x) == string(y)
+}
+
+func parseFieldPath(path string) ([]fieldPathElement, error) {
+	var elements []fieldPathElement
+
+	for len(path) > 0 {
+		switch path[0] {
+		case '.':
+			end := strings.IndexAny(path[1:], ".[")
+			if end < 0 {
+				end = len(path) - 1
+			}
+
+			field := path[1 : end+1]
+			elements = append(elements, fieldPathElement{field: &field})
+			path = path[end+1:]
+		case '[':
+			end := closingBracket(path)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated selector in %s", path)
+			}
+
+			element, err := parseSelector(path[1:end])
+			if err != nil {
+				return nil, err
+			}
+
+			elements = append(elements, element)
+			path = path[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character %q in field path", path[0])
+		}
+	}
+
+	return elements, nil
+}
+
+// closingBracket returns the position of the bracket closing the selector, skipping the quoted values.
+func closingBracket(path string) int {
+	var quoted, escaped bool
+
+	for i := 1; i < len(path); i++ {
+		switch {
+		case escaped:
+			escaped = false
+		case path[i] == '\\' && quoted:
+			escaped = true
+		case path[i] == '"':
+			quoted = !quoted
+		case path[i] == ']' && !quoted:
+			return i
+		}
+	}
+
+	return -1
+}
+
+func parseSelector(selector string) (fieldPathElement, error) {
+	if index, err := strconv.Atoi(selector); err == nil {
+		return fieldPathElement{index: &index}, nil
+	}
+
+	if strings.HasPrefix(selector, "=") {
+		var value any
+		if err := json.Unmarshal([]byte(selector[1:]), &value); err != nil {
+			return fieldPathElement{}, errors.Wrap(err, "cannot decode set value")
+		}
+
+		return fieldPathElement{value: value}, nil
+	}
+
+	keys := map[string]any{}
+
+	for len(selector) > 0 {
+		separator := strings.Index(selector, "=")
+		if separator < 0 {
+			return fieldPathElement{}, fmt.Errorf("malformed key selector %s", selector)
+		}
+
+		key := selector[:separator]
+
+		decoder := json.NewDecoder(strings.NewReader(selector[separator+1:]))
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return fieldPathElement{}, errors.Wrap(err, "cannot decode key value")
+		}
+
+		keys[key] = value
+		selector = strings.TrimPrefix(selector[separator+1+int(decoder.InputOffset()):], ",")
+	}
+
+	return fieldPathElement{keys: keys}, nil
+}
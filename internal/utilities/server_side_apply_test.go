@@ -0,0 +1,272 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+)
+
+func TestRemoveFieldPath(t *testing.T) {
+	const manifest = `{
+  "metadata": {"labels": {"app": "coredns", "kubernetes.io/os": "linux"}},
+  "spec": {
+    "replicas": 2,
+    "template": {"spec": {
+      "containers": [
+        {"name": "coredns", "image": "coredns:v1", "ports": [{"containerPort": 53, "protocol": "UDP"}, {"containerPort": 53, "protocol": "TCP"}]},
+        {"name": "sidecar", "image": "sidecar:v1"}
+      ],
+      "finalizers": ["a", "b"]
+    }}
+  }
+}`
+
+	tests := map[string]string{
+		".spec.replicas":                                       `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["a","b"]}}}}`,
+		".metadata.labels.kubernetes.io/os":                    `{"metadata":{"labels":{"app":"coredns"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["a","b"]}}}}`,
+		`.spec.template.spec.containers[name="coredns"].image`: `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["a","b"]}}}}`,
+		`.spec.template.spec.containers[name="sidecar"]`:       `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]}],"finalizers":["a","b"]}}}}`,
+		`.spec.template.spec.containers[name="coredns"].ports[containerPort=53,protocol="TCP"]`: `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["a","b"]}}}}`,
+		`.spec.template.spec.finalizers[="a"]`:                                                  `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["b"]}}}}`,
+		`.spec.template.spec.containers[name="missing"].image`:                                  `{"metadata":{"labels":{"app":"coredns","kubernetes.io/os":"linux"}},"spec":{"replicas":2,"template":{"spec":{"containers":[{"image":"coredns:v1","name":"coredns","ports":[{"containerPort":53,"protocol":"UDP"},{"containerPort":53,"protocol":"TCP"}]},{"image":"sidecar:v1","name":"sidecar"}],"finalizers":["a","b"]}}}}`,
+	}
+
+	for path, expected := range tests {
+		var content map[string]any
+		if err := json.Unmarshal([]byte(manifest), &content); err != nil {
+			t.Fatalf("cannot decode manifest: %s", err.Error())
+		}
+
+		if err := RemoveFieldPath(content, path); err != nil {
+			t.Errorf("unexpected error removing %s: %s", path, err.Error())
+
+			continue
+		}
+
+		got, _ := json.Marshal(content)
+		if string(got) != expected {
+			t.Errorf("removing %s, expected %s, got %s", path, expected, got)
+		}
+	}
+
+	if err := RemoveFieldPath(map[string]any{}, `.spec[name="unterminated`); err == nil {
+		t.Errorf("expected error for malformed path")
+	}
+}
+
+const conflictingImageField = `.spec.template.spec.containers[name="coredns"].image`
+
+// applyRecorder emulates the server-side apply with the fake client, which doesn't support it:
+// the applied configurations are recorded, and the given number of attempts is rejected with a conflict on the image.
+type applyRecorder struct {
+	conflicts int
+	applied   []*unstructured.Unstructured
+	options   []*client.PatchOptions
+	upgrades  []string
+}
+
+func (r *applyRecorder) patch(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	data, err := patch.Data(obj)
+	if err != nil {
+		return err
+	}
+
+	switch patch.Type() {
+	case types.JSONPatchType:
+		r.upgrades = append(r.upgrades, string(data))
+
+		return nil
+	case types.ApplyPatchType:
+	default:
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	applied, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+
+	r.applied = append(r.applied, applied.DeepCopy())
+	r.options = append(r.options, (&client.PatchOptions{}).ApplyOptions(opts))
+
+	if len(r.applied) <= r.conflicts {
+		return apierrors.NewApplyConflict([]metav1.StatusCause{{
+			Type:    metav1.CauseTypeFieldManagerConflict,
+			Message: `conflict with "kubectl-edit" using apps/v1`,
+			Field:   conflictingImageField,
+		}}, "Apply failed with 1 conflict")
+	}
+
+	current := applied.DeepCopy()
+	if err = c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return c.Create(ctx, applied)
+	}
+
+	applied.SetResourceVersion(current.GetResourceVersion())
+
+	return c.Update(ctx, applied)
+}
+
+func newCoreDNSDeployment(image string) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "coredns", Image: image}}},
+			},
+		},
+	}
+}
+
+func applyCoreDNS(t *testing.T, recorder *applyRecorder, policy kamajiv1alpha1.AddonConflictPolicy, existing ...client.Object) error {
+	t.Helper()
+
+	c := fake.NewClientBuilder().
+		WithScheme(clientgoscheme.Scheme).
+		WithObjects(existing...).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: recorder.patch}).
+		Build()
+
+	deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "coredns"}}
+
+	_, err := ServerSideApply(context.Background(), c, deployment, kamajiv1alpha1.AddonApplyTrait{ConflictPolicy: policy}, func() error {
+		deployment.Spec = newCoreDNSDeployment("registry.k8s.io/coredns/coredns:v1.12.0").Spec
+
+		return nil
+	})
+
+	return err
+}
+
+func TestServerSideApplyIgnoreUserFields(t *testing.T) {
+	recorder := &applyRecorder{conflicts: 1}
+
+	if err := applyCoreDNS(t, recorder, kamajiv1alpha1.AddonConflictPolicyIgnoreUserFields, newCoreDNSDeployment("registry.k8s.io/coredns/coredns:v1.11.0")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(recorder.applied) != 2 {
+		t.Fatalf("expected the conflict to be resolved with a second apply, got %d attempts", len(recorder.applied))
+	}
+
+	for _, opts := range recorder.options {
+		if opts.Force != nil && *opts.Force {
+			t.Error("expected the ownership not to be forced")
+		}
+	}
+
+	containers, _, _ := unstructured.NestedSlice(recorder.applied[1].Object, "spec", "template", "spec", "containers")
+	if len(containers) != 1 {
+		t.Fatalf("expected the coredns container to be applied, got %v", containers)
+	}
+
+	if _, found := containers[0].(map[string]any)["image"]; found {
+		t.Errorf("expected the conflicting image to be removed from the applied configuration, got %v", containers[0])
+	}
+}
+
+func TestServerSideApplyConflictResolutionsLimit(t *testing.T) {
+	recorder := &applyRecorder{conflicts: maxConflictResolutions + 10}
+
+	err := applyCoreDNS(t, recorder, kamajiv1alpha1.AddonConflictPolicyIgnoreUserFields, newCoreDNSDeployment("registry.k8s.io/coredns/coredns:v1.11.0"))
+	if !apierrors.IsConflict(err) {
+		t.Fatalf("expected a conflict error, got %v", err)
+	}
+
+	if len(recorder.applied) != maxConflictResolutions+1 {
+		t.Errorf("expected %d apply attempts, got %d", maxConflictResolutions+1, len(recorder.applied))
+	}
+}
+
+func TestServerSideApplyForceOwnership(t *testing.T) {
+	recorder := &applyRecorder{}
+
+	if err := applyCoreDNS(t, recorder, kamajiv1alpha1.AddonConflictPolicyForce, newCoreDNSDeployment("registry.k8s.io/coredns/coredns:v1.11.0")); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(recorder.applied) != 1 || recorder.options[0].Force == nil || !*recorder.options[0].Force {
+		t.Fatalf("expected a single apply forcing the ownership, got %d attempts", len(recorder.applied))
+	}
+
+	if recorder.options[0].FieldManager != FieldManager {
+		t.Errorf("expected the %s field manager, got %s", FieldManager, recorder.options[0].FieldManager)
+	}
+}
+
+func TestServerSideApplyUpgradeManagedFields(t *testing.T) {
+	existing := newCoreDNSDeployment("registry.k8s.io/coredns/coredns:v1.11.0")
+	existing.SetManagedFields([]metav1.ManagedFieldsEntry{
+		{
+			Manager:    FieldManager,
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{"f:spec":{"f:containers":{"k:{\"name\":\"coredns\"}":{".":{},"f:image":{},"f:name":{}}}}}}}`)},
+		},
+		{
+			Manager:    "kubectl-edit",
+			Operation:  metav1.ManagedFieldsOperationUpdate,
+			APIVersion: "apps/v1",
+			FieldsType: "FieldsV1",
+			FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)},
+		},
+	})
+
+	recorder := &applyRecorder{}
+
+	if err := applyCoreDNS(t, recorder, kamajiv1alpha1.AddonConflictPolicyIgnoreUserFields, existing); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(recorder.upgrades) != 1 {
+		t.Fatalf("expected the managed fields to be upgraded once, got %d patches", len(recorder.upgrades))
+	}
+
+	var patch []struct {
+		Path  string          `json:"path"`
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(recorder.upgrades[0]), &patch); err != nil {
+		t.Fatalf("cannot decode the managed fields patch: %s", err)
+	}
+
+	var managedFields []metav1.ManagedFieldsEntry
+	if err := json.Unmarshal(patch[0].Value, &managedFields); patch[0].Path != "/metadata/managedFields" || err != nil {
+		t.Fatalf("unexpected managed fields patch %s", recorder.upgrades[0])
+	}
+
+	for _, entry := range managedFields {
+		switch entry.Manager {
+		case FieldManager:
+			if entry.Operation != metav1.ManagedFieldsOperationApply || !strings.Contains(string(entry.FieldsV1.Raw), "f:image") {
+				t.Errorf("expected the Kamaji fields to be owned by its apply field manager, got %+v", entry)
+			}
+		case "kubectl-edit":
+			if entry.Operation != metav1.ManagedFieldsOperationUpdate {
+				t.Errorf("expected the other managers to be left untouched, got %+v", entry)
+			}
+		}
+	}
+
+	if len(managedFields) != 2 {
+		t.Errorf("expected 2 managed fields entries, got %d", len(managedFields))
+	}
+}
@@ -6,19 +6,60 @@ package utilities
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	k8stypes "k8s.io/apimachinery/pkg/types"
 	clientset "k8s.io/client-go/kubernetes"
 	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
 	clientcmdapiv1 "k8s.io/client-go/tools/clientcmd/api/v1"
 	kubeadmconstants "k8s.io/kubernetes/cmd/kubeadm/app/constants"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
 )
 
+var (
+	// tenantClientQPS and tenantClientBurst are the default client-side rate limits of the Tenant Cluster clients:
+	// the zero values are falling back to the client-go defaults.
+	tenantClientQPS   float32
+	tenantClientBurst int
+)
+
+// SetTenantClientRateLimits sets the default QPS, and burst, of the clients interacting with the Tenant Clusters.
+func SetTenantClientRateLimits(qps float32, burst int) {
+	tenantClientQPS, tenantClientBurst = qps, burst
+}
+
+// TenantClientRateLimits returns the QPS, and burst, of the clients interacting with the given Tenant Control Plane:
+// the defaults are overridden by the client-qps, and client-burst, annotations of the Tenant Control Plane.
+func TenantClientRateLimits(tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (float32, int, error) {
+	qps, burst := tenantClientQPS, tenantClientBurst
+
+	if value, ok := tenantControlPlane.GetAnnotations()[kamajiv1alpha1.ClientQPSAnnotation]; ok {
+		parsed, err := strconv.ParseFloat(value, 32)
+		if err != nil || parsed <= 0 {
+			return tenantClientQPS, tenantClientBurst, fmt.Errorf("invalid %s annotation %q, it must be a positive number", kamajiv1alpha1.ClientQPSAnnotation, value)
+		}
+
+		qps = float32(parsed)
+	}
+
+	if value, ok := tenantControlPlane.GetAnnotations()[kamajiv1alpha1.ClientBurstAnnotation]; ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 {
+			return tenantClientQPS, tenantClientBurst, fmt.Errorf("invalid %s annotation %q, it must be a positive integer", kamajiv1alpha1.ClientBurstAnnotation, value)
+		}
+
+		burst = parsed
+	}
+
+	return qps, burst, nil
+}
+
 func GetTenantClient(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (client.Client, error) {
 	options := client.Options{}
 	config, err := GetRESTClientConfig(ctx, c, tenantControlPlane)
@@ -29,6 +70,40 @@ func GetTenantClient(ctx context.Context, c client.Client, tenantControlPlane *k
 	return client.New(config, options)
 }
 
+// GetTenantAdminClient returns a client for the Tenant Cluster using the admin kubeconfig, regardless of the
+// least-privilege mode: it must be used only for the operations not allowed to the soot user, such as granting its permissions.
+func GetTenantAdminClient(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (client.Client, error) {
+	kubeconfig, err := GetTenantAdminKubeconfig(ctx, c, tenantControlPlane)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(restClientConfig(kubeconfig, tenantControlPlane), client.Options{})
+}
+
+// GetRemoteClusterClient returns a client for a cluster other than the Tenant one, such as the cluster hosting the worker nodes,
+// using the kubeconfig stored in the referenced Secret of the Tenant Control Plane namespace.
+func GetRemoteClusterClient(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane, ref kamajiv1alpha1.KubeconfigSecretReference) (client.Client, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: ref.Name}, secret); err != nil {
+		return nil, err
+	}
+
+	kubeconfig, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("%s is not into kubeconfig secret", ref.Key)
+	}
+
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	config.Timeout = 10 * time.Second
+
+	return client.New(config, client.Options{})
+}
+
 func GetTenantClientSet(ctx context.Context, client client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*clientset.Clientset, error) {
 	config, err := GetRESTClientConfig(ctx, client, tenantControlPlane)
 	if err != nil {
@@ -38,7 +113,22 @@ func GetTenantClientSet(ctx context.Context, client client.Client, tenantControl
 	return clientset.NewForConfig(config)
 }
 
+// GetTenantKubeconfig returns the kubeconfig used by Kamaji to interact with the Tenant Cluster:
+// the scoped soot one when generated by the least-privilege mode, the admin one otherwise.
 func GetTenantKubeconfig(ctx context.Context, client client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*clientcmdapiv1.Config, error) {
+	if len(tenantControlPlane.Status.KubeConfig.Soot.SecretName) == 0 {
+		return GetTenantAdminKubeconfig(ctx, client, tenantControlPlane)
+	}
+
+	secretKubeconfig := &corev1.Secret{}
+	if err := client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.KubeConfig.Soot.SecretName}, secretKubeconfig); err != nil {
+		return nil, err
+	}
+
+	return DecodeKubeconfig(*secretKubeconfig, constants.SootKubeConfigFileName)
+}
+
+func GetTenantAdminKubeconfig(ctx context.Context, client client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (*clientcmdapiv1.Config, error) {
 	secretKubeconfig := &corev1.Secret{}
 	if err := client.Get(ctx, k8stypes.NamespacedName{Namespace: tenantControlPlane.GetNamespace(), Name: tenantControlPlane.Status.KubeConfig.Admin.SecretName}, secretKubeconfig); err != nil {
 		return nil, err
@@ -59,15 +149,28 @@ func GetRESTClientConfig(ctx context.Context, client client.Client, tenantContro
 		return nil, err
 	}
 
-	config := &restclient.Config{
-		Host: fmt.Sprintf("https://%s.%s.svc:%d", tenantControlPlane.GetName(), tenantControlPlane.GetNamespace(), tenantControlPlane.Spec.NetworkProfile.Port),
+	return restClientConfig(kubeconfig, tenantControlPlane), nil
+}
+
+func restClientConfig(kubeconfig *clientcmdapiv1.Config, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) *restclient.Config {
+	// The invalid annotations are rejected by the webhook, falling back to the defaults otherwise.
+	qps, burst, _ := TenantClientRateLimits(tenantControlPlane)
+
+	host := fmt.Sprintf("https://%s.%s.svc:%d", ObjectName(tenantControlPlane), tenantControlPlane.GetNamespace(), tenantControlPlane.Spec.NetworkProfile.Port)
+	// The Service of the Control Plane running in a target cluster is not resolvable from the management one.
+	if tenantControlPlane.TargetCluster() != nil && len(tenantControlPlane.Status.ControlPlaneEndpoint) > 0 {
+		host = "https://" + tenantControlPlane.Status.ControlPlaneEndpoint
+	}
+
+	return &restclient.Config{
+		Host: host,
 		TLSClientConfig: restclient.TLSClientConfig{
 			CAData:   kubeconfig.Clusters[0].Cluster.CertificateAuthorityData,
 			CertData: kubeconfig.AuthInfos[0].AuthInfo.ClientCertificateData,
 			KeyData:  kubeconfig.AuthInfos[0].AuthInfo.ClientKeyData,
 		},
 		Timeout: 10 * time.Second,
+		QPS:     qps,
+		Burst:   burst,
 	}
-
-	return config, nil
 }
@@ -39,7 +39,7 @@ func MergeMaps(maps ...map[string]string) map[string]string {
 }
 
 func AddTenantPrefix(name string, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) string {
-	return fmt.Sprintf("%s%s%s", tenantControlPlane.GetName(), separator, name)
+	return fmt.Sprintf("%s%s%s", ObjectName(tenantControlPlane), separator, name)
 }
 
 // EncodeToYaml returns the given object in yaml format and the error.
@@ -0,0 +1,280 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utilities
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stypes "k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+)
+
+// MirroredLabelKey is assigned to the Secrets, and the ConfigMaps, mirrored into the target cluster of a Tenant Control Plane.
+const MirroredLabelKey = "kamaji.clastix.io/mirrored"
+
+// WorkloadObjects are the objects running the Control Plane, created in the target cluster of the Tenant Control Plane.
+func WorkloadObjects() []client.ObjectList {
+	return []client.ObjectList{
+		&appsv1.DeploymentList{},
+		&corev1.ServiceList{},
+		&policyv1.PodDisruptionBudgetList{},
+		&networkingv1.IngressList{},
+		&networkingv1.NetworkPolicyList{},
+	}
+}
+
+// GetWorkloadClient returns the client handling the Control Plane workloads of the given Tenant Control Plane:
+// the management one, unless the pods run in a target cluster.
+func GetWorkloadClient(ctx context.Context, c client.Client, tenantControlPlane *kamajiv1alpha1.TenantControlPlane) (client.Client, error) {
+	targetCluster := tenantControlPlane.TargetCluster()
+	if targetCluster == nil {
+		return c, nil
+	}
+
+	target, err := GetRemoteClusterClient(ctx, c, tenantControlPlane, targetCluster.KubeconfigSecretRef)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot generate the target cluster client")
+	}
+
+	return &workloadClient{Client: c, target: target, tenantControlPlane: tenantControlPlane.GetName()}, nil
+}
+
+// workloadClient routes the workload objects to the target cluster, and any other object to the management one.
+// The owner references are removed from the objects written into the target cluster, since the garbage collector
+// would delete them, and the Secrets, and the ConfigMaps, referenced by the Deployments are mirrored from the management cluster.
+type workloadClient struct {
+	client.Client
+
+	target             client.Client
+	tenantControlPlane string
+}
+
+func (w *workloadClient) isWorkload(obj runtime.Object) bool {
+	switch obj.(type) {
+	case *appsv1.Deployment, *appsv1.DeploymentList,
+		*corev1.Service, *corev1.ServiceList,
+		*policyv1.PodDisruptionBudget, *policyv1.PodDisruptionBudgetList,
+		*networkingv1.Ingress, *networkingv1.IngressList,
+		*networkingv1.NetworkPolicy, *networkingv1.NetworkPolicyList,
+		// The nodes running the workloads, such as the ones eligible to the host network mode.
+		*corev1.Node, *corev1.NodeList:
+		return true
+	default:
+		return false
+	}
+}
+
+func (w *workloadClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+	if w.isWorkload(obj) {
+		return w.target.Get(ctx, key, obj, opts...)
+	}
+
+	return w.Client.Get(ctx, key, obj, opts...)
+}
+
+func (w *workloadClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	if w.isWorkload(list) {
+		return w.target.List(ctx, list, opts...)
+	}
+
+	return w.Client.List(ctx, list, opts...)
+}
+
+func (w *workloadClient) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if !w.isWorkload(obj) {
+		return w.Client.Create(ctx, obj, opts...)
+	}
+
+	if err := w.prepare(ctx, obj); err != nil {
+		return err
+	}
+
+	return w.target.Create(ctx, obj, opts...)
+}
+
+func (w *workloadClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !w.isWorkload(obj) {
+		return w.Client.Update(ctx, obj, opts...)
+	}
+
+	if err := w.prepare(ctx, obj); err != nil {
+		return err
+	}
+
+	return w.target.Update(ctx, obj, opts...)
+}
+
+func (w *workloadClient) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if !w.isWorkload(obj) {
+		return w.Client.Patch(ctx, obj, patch, opts...)
+	}
+
+	if err := w.prepare(ctx, obj); err != nil {
+		return err
+	}
+
+	return w.target.Patch(ctx, obj, patch, opts...)
+}
+
+func (w *workloadClient) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if w.isWorkload(obj) {
+		return w.target.Delete(ctx, obj, opts...)
+	}
+
+	return w.Client.Delete(ctx, obj, opts...)
+}
+
+func (w *workloadClient) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if w.isWorkload(obj) {
+		return w.target.DeleteAllOf(ctx, obj, opts...)
+	}
+
+	return w.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// prepare labels the workload with the Tenant Control Plane name, allowing its deletion along with the Tenant Control Plane,
+// removing the owner references, and mirroring the objects referenced by the Deployments.
+func (w *workloadClient) prepare(ctx context.Context, obj client.Object) error {
+	obj.SetLabels(MergeMaps(obj.GetLabels(), map[string]string{constants.ControlPlaneLabelKey: w.tenantControlPlane}))
+	obj.SetOwnerReferences(nil)
+
+	deployment, ok := obj.(*appsv1.Deployment)
+	if !ok {
+		return nil
+	}
+
+	secrets, configMaps := podTemplateReferences(deployment.Spec.Template.Spec)
+
+	for _, name := range secrets {
+		if err := w.mirror(ctx, &corev1.Secret{}, deployment.GetNamespace(), name); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range configMaps {
+		if err := w.mirror(ctx, &corev1.ConfigMap{}, deployment.GetNamespace(), name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// mirror copies the given Secret, or ConfigMap, from the management cluster to the target one:
+// the missing objects are skipped, since they could be optional, letting the kubelet report the failure otherwise.
+func (w *workloadClient) mirror(ctx context.Context, obj client.Object, namespace, name string) error {
+	if err := w.Client.Get(ctx, k8stypes.NamespacedName{Namespace: namespace, Name: name}, obj); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return nil
+		}
+
+		return errors.Wrap(err, fmt.Sprintf("cannot retrieve the object %s to mirror", name))
+	}
+
+	mirrored := obj.DeepCopyObject().(client.Object) //nolint:forcetypeassert
+	mirrored.SetResourceVersion("")
+	mirrored.SetUID("")
+	mirrored.SetManagedFields(nil)
+	mirrored.SetOwnerReferences(nil)
+	mirrored.SetCreationTimestamp(metav1.Time{})
+
+	if _, err := controllerutil.CreateOrUpdate(ctx, w.target, mirrored, func() error {
+		mirrored.SetLabels(MergeMaps(obj.GetLabels(), map[string]string{
+			constants.ControlPlaneLabelKey: w.tenantControlPlane,
+			MirroredLabelKey:               "true",
+		}))
+
+		switch source := obj.(type) {
+		case *corev1.Secret:
+			secret := mirrored.(*corev1.Secret) //nolint:forcetypeassert
+			secret.Type, secret.Data = source.Type, source.Data
+		case *corev1.ConfigMap:
+			configMap := mirrored.(*corev1.ConfigMap) //nolint:forcetypeassert
+			configMap.Data, configMap.BinaryData = source.Data, source.BinaryData
+		}
+
+		return nil
+	}); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("cannot mirror the object %s into the target cluster", name))
+	}
+
+	return nil
+}
+
+// podTemplateReferences returns the names of the Secrets, and the ConfigMaps, referenced by the given pod specification.
+func podTemplateReferences(spec corev1.PodSpec) (secrets []string, configMaps []string) {
+	seen := map[string]struct{}{}
+	add := func(list *[]string, kind, name string) {
+		if _, ok := seen[kind+"/"+name]; ok || len(name) == 0 {
+			return
+		}
+
+		seen[kind+"/"+name] = struct{}{}
+		*list = append(*list, name)
+	}
+
+	for _, ref := range spec.ImagePullSecrets {
+		add(&secrets, "secret", ref.Name)
+	}
+
+	for _, volume := range spec.Volumes {
+		switch {
+		case volume.Secret != nil:
+			add(&secrets, "secret", volume.Secret.SecretName)
+		case volume.ConfigMap != nil:
+			add(&configMaps, "configmap", volume.ConfigMap.Name)
+		case volume.Projected != nil:
+			for _, source := range volume.Projected.Sources {
+				if source.Secret != nil {
+					add(&secrets, "secret", source.Secret.Name)
+				}
+
+				if source.ConfigMap != nil {
+					add(&configMaps, "configmap", source.ConfigMap.Name)
+				}
+			}
+		}
+	}
+
+	for _, container := range append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...) {
+		for _, envFrom := range container.EnvFrom {
+			if envFrom.SecretRef != nil {
+				add(&secrets, "secret", envFrom.SecretRef.Name)
+			}
+
+			if envFrom.ConfigMapRef != nil {
+				add(&configMaps, "configmap", envFrom.ConfigMapRef.Name)
+			}
+		}
+
+		for _, env := range container.Env {
+			if env.ValueFrom == nil {
+				continue
+			}
+
+			if env.ValueFrom.SecretKeyRef != nil {
+				add(&secrets, "secret", env.ValueFrom.SecretKeyRef.Name)
+			}
+
+			if env.ValueFrom.ConfigMapKeyRef != nil {
+				add(&configMaps, "configmap", env.ValueFrom.ConfigMapKeyRef.Name)
+			}
+		}
+	}
+
+	return secrets, configMaps
+}
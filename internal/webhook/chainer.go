@@ -16,6 +16,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	"github.com/clastix/kamaji/internal/webhook/handlers"
+	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
 type handlersChainer struct {
@@ -25,6 +26,8 @@ type handlersChainer struct {
 //nolint:gocognit
 func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handlers.Handler) admission.HandlerFunc {
 	return func(ctx context.Context, req admission.Request) admission.Response {
+		ctx, warnings := utils.WithWarnings(ctx)
+
 		decodedObj, oldDecodedObj := object.DeepCopyObject(), object.DeepCopyObject()
 
 		switch req.Operation {
@@ -92,9 +95,9 @@ func (h handlersChainer) Handler(object runtime.Object, routeHandlers ...handler
 		}
 
 		if len(patches) > 0 {
-			return admission.Patched("patching required", patches...)
+			return admission.Patched("patching required", patches...).WithWarnings(*warnings...)
 		}
 
-		return admission.Allowed(fmt.Sprintf("%s operation allowed", strings.ToLower(string(req.Operation))))
+		return admission.Allowed(fmt.Sprintf("%s operation allowed", strings.ToLower(string(req.Operation)))).WithWarnings(*warnings...)
 	}
 }
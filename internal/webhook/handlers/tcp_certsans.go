@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
@@ -23,8 +24,14 @@ func (t TenantControlPlaneCertSANs) ValidateCertSANs(tcp *kamajiv1alpha1.TenantC
 		return nil
 	}
 
-	if err := validation.ValidateCertSANs(tcp.Spec.NetworkProfile.CertSANs, field.NewPath("spec.networkProfile.certSANs")); err != nil {
-		return err.ToAggregate()
+	// The templated entries are rendered with sample values, since the actual ones are known at reconciliation time.
+	certSANs, err := utilities.RenderCertSANs(tcp.Spec.NetworkProfile.CertSANs, utilities.CertSANsSampleValues)
+	if err != nil {
+		return err
+	}
+
+	if errs := validation.ValidateCertSANs(certSANs, field.NewPath("spec.networkProfile.certSANs")); errs != nil {
+		return errs.ToAggregate()
 	}
 
 	return nil
@@ -0,0 +1,43 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneClientRateLimits validates the annotations overriding the rate limits of the Tenant Cluster clients.
+type TenantControlPlaneClientRateLimits struct{}
+
+func (t TenantControlPlaneClientRateLimits) handle(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if _, _, err := utilities.TenantClientRateLimits(tcp); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func (t TenantControlPlaneClientRateLimits) OnCreate(object runtime.Object) AdmissionResponse {
+	return t.handle(object)
+}
+
+func (t TenantControlPlaneClientRateLimits) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneClientRateLimits) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return t.handle(object)
+}
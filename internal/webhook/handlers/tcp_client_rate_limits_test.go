@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Client Rate Limits Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneClientRateLimits
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneClientRateLimits{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation when no rate limit is overridden", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows creation when valid rate limits are provided", func() {
+		tcp.SetAnnotations(map[string]string{
+			kamajiv1alpha1.ClientQPSAnnotation:   "2.5",
+			kamajiv1alpha1.ClientBurstAnnotation: "5",
+		})
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies update when the burst is not a positive integer", func() {
+		tcp.SetAnnotations(map[string]string{
+			kamajiv1alpha1.ClientBurstAnnotation: "0",
+		})
+		_, err := t.OnUpdate(tcp, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring(kamajiv1alpha1.ClientBurstAnnotation))
+	})
+})
@@ -26,6 +26,10 @@ func (t TenantControlPlaneDataStore) OnCreate(object runtime.Object) AdmissionRe
 	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
+		if tcp.Spec.DedicatedDataStore != nil {
+			return nil, t.checkDedicated(tcp)
+		}
+
 		if tcp.Spec.DataStore != "" {
 			return nil, t.check(ctx, tcp.Spec.DataStore)
 		}
@@ -42,6 +46,10 @@ func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, _ runtime.O
 	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
+		if tcp.Spec.DedicatedDataStore != nil {
+			return nil, t.checkDedicated(tcp)
+		}
+
 		if tcp.Spec.DataStore != "" {
 			return nil, t.check(ctx, tcp.Spec.DataStore)
 		}
@@ -50,6 +58,16 @@ func (t TenantControlPlaneDataStore) OnUpdate(object runtime.Object, _ runtime.O
 	}
 }
 
+// checkDedicated ensures a shared DataStore is not referenced along with the dedicated one,
+// which is not checked for existence since generated upon the reconciliation.
+func (t TenantControlPlaneDataStore) checkDedicated(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.DataStore != "" && tcp.Spec.DataStore != tcp.DedicatedDataStoreName() {
+		return fmt.Errorf("the dataStore cannot be referenced along with a dedicated DataStore")
+	}
+
+	return nil
+}
+
 func (t TenantControlPlaneDataStore) check(ctx context.Context, dataStoreName string) error {
 	if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, &kamajiv1alpha1.DataStore{}); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -7,28 +7,46 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"strings"
 
 	"github.com/pkg/errors"
 	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	pointer "k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
+const (
+	defaultServiceCIDR = "10.96.0.0/16"
+	defaultPodCIDR     = "10.244.0.0/16"
+)
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=kamajidefaults,verbs=get;list;watch
+
 type TenantControlPlaneDefaults struct {
+	// Client is used to retrieve the KamajiDefaults object: if nil, only the built-in defaults are applied.
+	Client           client.Client
 	DefaultDatastore string
 }
 
 func (t TenantControlPlaneDefaults) OnCreate(object runtime.Object) AdmissionResponse {
-	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
 		original := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
 
+		defaults, err := t.kamajiDefaults(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot retrieve the KamajiDefaults")
+		}
+
 		defaulted := original.DeepCopy()
-		t.defaultUnsetFields(defaulted)
+		t.defaultUnsetFields(defaulted, defaults)
 
 		if len(defaulted.Spec.NetworkProfile.DNSServiceIPs) == 0 {
 			ip, _, err := net.ParseCIDR(defaulted.Spec.NetworkProfile.ServiceCIDR)
@@ -63,9 +81,53 @@ func (t TenantControlPlaneDefaults) OnUpdate(runtime.Object, runtime.Object) Adm
 	return utils.NilOp()
 }
 
-func (t TenantControlPlaneDefaults) defaultUnsetFields(tcp *kamajiv1alpha1.TenantControlPlane) {
-	if len(tcp.Spec.DataStore) == 0 && t.DefaultDatastore != "" {
-		tcp.Spec.DataStore = t.DefaultDatastore
+// kamajiDefaults returns the singleton KamajiDefaults object, or an empty one if not existing.
+func (t TenantControlPlaneDefaults) kamajiDefaults(ctx context.Context) (kamajiv1alpha1.KamajiDefaultsSpec, error) {
+	if t.Client == nil {
+		return kamajiv1alpha1.KamajiDefaultsSpec{}, nil
+	}
+
+	var defaults kamajiv1alpha1.KamajiDefaults
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: kamajiv1alpha1.KamajiDefaultsName}, &defaults); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return kamajiv1alpha1.KamajiDefaultsSpec{}, nil
+		}
+
+		return kamajiv1alpha1.KamajiDefaultsSpec{}, err
+	}
+
+	return defaults.Spec, nil
+}
+
+func (t TenantControlPlaneDefaults) defaultUnsetFields(tcp *kamajiv1alpha1.TenantControlPlane, defaults kamajiv1alpha1.KamajiDefaultsSpec) {
+	// The dedicated DataStore is generated by Kamaji, thus it's not defaulted.
+	if len(tcp.Spec.DataStore) == 0 && tcp.Spec.DedicatedDataStore == nil {
+		switch {
+		case defaults.DataStore != "":
+			tcp.Spec.DataStore = defaults.DataStore
+		case t.DefaultDatastore != "":
+			tcp.Spec.DataStore = t.DefaultDatastore
+		}
+	}
+
+	if len(tcp.Spec.Kubernetes.Version) == 0 {
+		tcp.Spec.Kubernetes.Version = defaults.KubernetesVersion
+	}
+
+	if len(tcp.Spec.ImageProfile) == 0 {
+		tcp.Spec.ImageProfile = defaults.ImageProfile
+	}
+
+	if len(tcp.Spec.NetworkProfile.ServiceCIDR) == 0 {
+		tcp.Spec.NetworkProfile.ServiceCIDR = defaultString(defaults.ServiceCIDR, defaultServiceCIDR)
+	}
+
+	if len(tcp.Spec.NetworkProfile.PodCIDR) == 0 {
+		tcp.Spec.NetworkProfile.PodCIDR = defaultString(defaults.PodCIDR, defaultPodCIDR)
+	}
+
+	if defaults.Addons != nil && reflect.DeepEqual(tcp.Spec.Addons, kamajiv1alpha1.AddonsSpec{}) {
+		tcp.Spec.Addons = *defaults.Addons.DeepCopy()
 	}
 
 	if tcp.Spec.ControlPlane.Deployment.Replicas == nil {
@@ -77,3 +139,11 @@ func (t TenantControlPlaneDefaults) defaultUnsetFields(tcp *kamajiv1alpha1.Tenan
 		tcp.Spec.DataStoreSchema = dss
 	}
 }
+
+func defaultString(value, fallback string) string {
+	if value != "" {
+		return value
+	}
+
+	return fallback
+}
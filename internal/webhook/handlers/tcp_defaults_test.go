@@ -10,7 +10,9 @@ import (
 	. "github.com/onsi/gomega"
 	"gomodules.xyz/jsonpatch/v2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
@@ -49,7 +51,7 @@ var _ = Describe("TCP Defaulting Webhook", func() {
 		It("should issue all required patches", func() {
 			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
 			Expect(err).ToNot(HaveOccurred())
-			Expect(ops).To(HaveLen(3))
+			Expect(ops).To(HaveLen(4))
 		})
 
 		It("should default the dataStore", func() {
@@ -69,8 +71,62 @@ var _ = Describe("TCP Defaulting Webhook", func() {
 		})
 	})
 
+	Describe("KamajiDefaults is declared", func() {
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(kamajiv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			t.Client = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&kamajiv1alpha1.KamajiDefaults{
+				ObjectMeta: metav1.ObjectMeta{Name: kamajiv1alpha1.KamajiDefaultsName},
+				Spec: kamajiv1alpha1.KamajiDefaultsSpec{
+					DataStore:         "postgresql",
+					KubernetesVersion: "v1.33.0",
+					PodCIDR:           "10.36.0.0/16",
+				},
+			}).Build()
+		})
+
+		It("should take precedence over the default dataStore", func() {
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElement(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/dataStore", Value: "postgresql"},
+			))
+		})
+
+		It("should default the Kubernetes version, and the Pod CIDR", func() {
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).To(ContainElements(
+				jsonpatch.Operation{Operation: "add", Path: "/spec/kubernetes/version", Value: "v1.33.0"},
+				jsonpatch.Operation{Operation: "add", Path: "/spec/networkProfile/podCidr", Value: "10.36.0.0/16"},
+			))
+		})
+
+		It("should not override the declared fields", func() {
+			tcp.Spec.Kubernetes.Version = "v1.32.0"
+
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).ToNot(ContainElement(HaveField("Path", "/spec/kubernetes/version")))
+		})
+	})
+
+	Describe("dedicated DataStore is declared", func() {
+		BeforeEach(func() {
+			tcp.Spec.DedicatedDataStore = &kamajiv1alpha1.DedicatedDataStoreSpec{Replicas: 3}
+		})
+
+		It("should not default the dataStore", func() {
+			ops, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ops).ToNot(ContainElement(HaveField("Path", "/spec/dataStore")))
+		})
+	})
+
 	Describe("fields are already set", func() {
 		BeforeEach(func() {
+			tcp.Spec.NetworkProfile.PodCIDR = "10.244.0.0/16"
 			tcp.Spec.DataStore = "etcd"
 			tcp.Spec.DataStoreSchema = "my_tcp"
 			tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(2))
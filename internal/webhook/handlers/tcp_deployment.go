@@ -18,6 +18,7 @@ import (
 
 	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
 	"github.com/clastix/kamaji/internal/builders/controlplane"
+	"github.com/clastix/kamaji/internal/utilities"
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
@@ -61,17 +62,22 @@ func (t TenantControlPlaneDeployment) OnUpdate(newObject runtime.Object, oldObje
 			return nil, nil
 		}
 
+		dataStoreName := tcp.Spec.DataStore
+		if dedicated := tcp.DedicatedDataStoreName(); dedicated != "" {
+			dataStoreName = dedicated
+		}
+
 		ds := kamajiv1alpha1.DataStore{}
-		if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Spec.DataStore}, &ds); err != nil {
+		if err := t.Client.Get(ctx, types.NamespacedName{Name: dataStoreName}, &ds); err != nil {
 			return nil, err
 		}
 		t.DeploymentBuilder.DataStore = ds
 
 		deployment := appsv1.Deployment{}
-		deployment.Name = tcp.Name
+		deployment.Name = utilities.ObjectName(tcp)
 		deployment.Namespace = tcp.Namespace
 
-		err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.Name, Namespace: tcp.Namespace}, &deployment)
+		err := t.Client.Get(ctx, types.NamespacedName{Name: deployment.Name, Namespace: tcp.Namespace}, &deployment)
 		if err != nil && !k8serrors.IsNotFound(err) {
 			return nil, nil
 		}
@@ -0,0 +1,72 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneEgressPolicy struct{}
+
+func (t TenantControlPlaneEgressPolicy) handle(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.Kubernetes.APIServer == nil || tcp.Spec.Kubernetes.APIServer.EgressPolicy == nil {
+		return nil
+	}
+
+	policy := tcp.Spec.Kubernetes.APIServer.EgressPolicy
+
+	for _, field := range []struct {
+		name  string
+		cidrs []string
+	}{
+		{name: "dataStoreCidrs", cidrs: policy.DataStoreCIDRs},
+		{name: "oidcIssuerCidrs", cidrs: policy.OIDCIssuerCIDRs},
+		{name: "webhookCidrs", cidrs: policy.WebhookCIDRs},
+	} {
+		for _, cidr := range field.cidrs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return fmt.Errorf("invalid egress policy %s CIDR %s, %s", field.name, cidr, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneEgressPolicy) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if err := t.handle(tcp); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func (t TenantControlPlaneEgressPolicy) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneEgressPolicy) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if err := t.handle(tcp); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
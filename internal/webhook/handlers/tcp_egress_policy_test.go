@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Egress Policy Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneEgressPolicy
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneEgressPolicy{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation when no egress policy is declared", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows creation when valid CIDRs are provided", func() {
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			EgressPolicy: &kamajiv1alpha1.APIServerEgressPolicySpec{
+				DataStoreCIDRs:  []string{"10.0.0.0/24"},
+				OIDCIssuerCIDRs: []string{"192.168.1.10/32"},
+				WebhookCIDRs:    []string{"fd00::/64"},
+			},
+		}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies update when the CIDRs are invalid", func() {
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			EgressPolicy: &kamajiv1alpha1.APIServerEgressPolicySpec{
+				WebhookCIDRs: []string{"10.0.0.1"},
+			},
+		}
+		_, err := t.OnUpdate(tcp, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("webhookCidrs"))
+	})
+})
@@ -0,0 +1,77 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/version"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	_ "k8s.io/kubernetes/pkg/features" // registering the Kubernetes feature gates
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneFeatureGates validates the feature gates against the ones known by the Tenant Control Plane version,
+// rejecting the unknown ones, the ones not yet available, or the ones locked to their default value.
+type TenantControlPlaneFeatureGates struct{}
+
+func (t TenantControlPlaneFeatureGates) handle(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	kubernetes := tcp.Spec.Kubernetes
+
+	components := map[string]map[string]bool{
+		"apiServer":         kubernetes.APIServerFeatureGates(),
+		"controllerManager": kubernetes.ControllerManagerFeatureGates(),
+		"scheduler":         kubernetes.SchedulerFeatureGates(),
+		"kubelet":           kubernetes.KubeletFeatureGates(),
+	}
+
+	ver, err := version.ParseGeneric(kubernetes.Version)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the desired Kubernetes version")
+	}
+
+	for component, gates := range components {
+		if len(gates) == 0 {
+			continue
+		}
+
+		featureGate := utilfeature.DefaultMutableFeatureGate.DeepCopy()
+		if err = featureGate.SetEmulationVersion(version.MajorMinor(ver.Major(), ver.Minor())); err != nil {
+			return errors.Wrap(err, "unable to validate the feature gates")
+		}
+
+		if err = featureGate.SetFromMap(gates); err != nil {
+			return fmt.Errorf("invalid %s feature gates for Kubernetes %s: %w", component, kubernetes.Version, err)
+		}
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneFeatureGates) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(tcp)
+	}
+}
+
+func (t TenantControlPlaneFeatureGates) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneFeatureGates) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(tcp)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Feature Gates Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneFeatureGates
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneFeatureGates{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				Kubernetes: kamajiv1alpha1.KubernetesSpec{
+					Version: "v1.30.0",
+				},
+			},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation when no feature gates are declared", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows creation when known feature gates are declared", func() {
+		tcp.Spec.Kubernetes.FeatureGates = map[string]bool{"InPlacePodVerticalScaling": true}
+		tcp.Spec.Kubernetes.ComponentFeatureGates.Kubelet = map[string]bool{"InPlacePodVerticalScaling": false}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies creation when unknown feature gates are declared", func() {
+		tcp.Spec.Kubernetes.FeatureGates = map[string]bool{"NonExistingGate": true}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies update when a component feature gate is not available in the Kubernetes version", func() {
+		tcp.Spec.Kubernetes.ComponentFeatureGates.Kubelet = map[string]bool{"DisableCPUQuotaWithExclusiveCPUs": true}
+		_, err := t.OnUpdate(tcp, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
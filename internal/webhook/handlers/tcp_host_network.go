@@ -0,0 +1,62 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneHostNetwork validates the host network mode of the Control Plane pods:
+// the worker nodes are reaching the kube-apiserver through the declared address, and the node connectivity addons,
+// running in the Control Plane pods, are not supported since their ports would be conflicting across the Tenant Control Planes.
+type TenantControlPlaneHostNetwork struct{}
+
+func (t TenantControlPlaneHostNetwork) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneHostNetwork) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneHostNetwork) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneHostNetwork) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	hostNetwork := tcp.HostNetwork()
+	if hostNetwork == nil {
+		return nil
+	}
+
+	if len(tcp.Spec.NetworkProfile.Address) == 0 {
+		return fmt.Errorf("the host network mode requires the Control Plane address, resolving to the nodes")
+	}
+
+	if tcp.Spec.Addons.Konnectivity != nil || tcp.Spec.Addons.WireGuard != nil {
+		return fmt.Errorf("the node connectivity addons are not supported in the host network mode")
+	}
+
+	if portRange := hostNetwork.PortRange; portRange.To-portRange.From+1 < 4 {
+		return fmt.Errorf("the host port range %d-%d must contain at least 4 ports", portRange.From, portRange.To)
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Host Network Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneHostNetwork
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneHostNetwork{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		tcp.Spec.NetworkProfile.Address = "192.168.1.10"
+		tcp.Spec.ControlPlane.Deployment.HostNetwork = &kamajiv1alpha1.HostNetworkSpec{
+			PortRange: kamajiv1alpha1.HostPortRange{From: 40000, To: 49999},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation with the host network mode", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies creation without the Control Plane address", func() {
+		tcp.Spec.NetworkProfile.Address = ""
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies creation with the Konnectivity addon", func() {
+		tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies update with a port range smaller than the allocated block", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.ControlPlane.Deployment.HostNetwork.PortRange = kamajiv1alpha1.HostPortRange{From: 40000, To: 40002}
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
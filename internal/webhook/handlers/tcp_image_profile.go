@@ -0,0 +1,63 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneImageProfile struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneImageProfile) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if tcp.Spec.ImageProfile != "" {
+			return nil, t.check(ctx, tcp.Spec.ImageProfile)
+		}
+
+		return nil, nil
+	}
+}
+
+func (t TenantControlPlaneImageProfile) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneImageProfile) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if tcp.Spec.ImageProfile != "" {
+			return nil, t.check(ctx, tcp.Spec.ImageProfile)
+		}
+
+		return nil, nil
+	}
+}
+
+func (t TenantControlPlaneImageProfile) check(ctx context.Context, imageProfileName string) error {
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: imageProfileName}, &kamajiv1alpha1.ImageProfile{}); err != nil {
+		if k8serrors.IsNotFound(err) {
+			return fmt.Errorf("%s ImageProfile does not exist", imageProfileName)
+		}
+
+		return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane ImageProfile check, %w", err)
+	}
+
+	return nil
+}
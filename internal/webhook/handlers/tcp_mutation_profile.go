@@ -0,0 +1,57 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+type TenantControlPlaneMutationProfile struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneMutationProfile) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.check(ctx, tcp.Spec.ControlPlane.Mutators)
+	}
+}
+
+func (t TenantControlPlaneMutationProfile) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneMutationProfile) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.check(ctx, tcp.Spec.ControlPlane.Mutators)
+	}
+}
+
+func (t TenantControlPlaneMutationProfile) check(ctx context.Context, mutationProfileNames []string) error {
+	for _, name := range mutationProfileNames {
+		if err := t.Client.Get(ctx, types.NamespacedName{Name: name}, &kamajiv1alpha1.MutationProfile{}); err != nil {
+			if k8serrors.IsNotFound(err) {
+				return fmt.Errorf("%s MutationProfile does not exist", name)
+			}
+
+			return fmt.Errorf("an unexpected error occurred upon Tenant Control Plane MutationProfile check, %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/utilities"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneNaming validates the naming template, which cannot be changed once set,
+// since the generated objects would be orphaned: the templates rendering the same name are allowed.
+type TenantControlPlaneNaming struct{}
+
+func (t TenantControlPlaneNaming) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if _, err := utilities.RenderObjectName(tcp); err != nil {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+}
+
+func (t TenantControlPlaneNaming) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneNaming) OnUpdate(object runtime.Object, prev runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), prev.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		name, err := utilities.RenderObjectName(newTCP)
+		if err != nil {
+			return nil, err
+		}
+
+		if previous := utilities.ObjectName(oldTCP); name != previous {
+			return nil, fmt.Errorf("changing the naming template is not supported, the generated objects are named %s", previous)
+		}
+
+		return nil, nil
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Naming Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneNaming
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneNaming{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation when a valid naming template is provided", func() {
+		tcp.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "hcp-{{ .Namespace }}-{{ .Name }}"}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies creation when the rendered name is not a DNS-1035 label", func() {
+		tcp.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "{{ .Namespace }}.{{ .Name }}"}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies creation when the template refers to an unknown value", func() {
+		tcp.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "{{ .Cluster }}"}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows update when the template renders the same name", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "{{ .Name }}"}
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies update when the rendered name changes", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.Naming = &kamajiv1alpha1.NamingSpec{Template: "hcp-{{ .Name }}"}
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("naming template"))
+	})
+})
@@ -0,0 +1,67 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneNodeConnectivity validates the API server node connectivity, warning when the API server
+// may not reach the kubelets, since neither the node connectivity addons, nor the routable nodes networks, are declared.
+type TenantControlPlaneNodeConnectivity struct{}
+
+func (t TenantControlPlaneNodeConnectivity) handle(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	addonEnabled := tcp.Spec.Addons.Konnectivity != nil || tcp.Spec.Addons.WireGuard != nil
+
+	nodeConnectivity := tcp.Spec.Kubernetes.NodeConnectivity()
+	if nodeConnectivity == nil || (len(nodeConnectivity.NodeCIDRs) == 0 && len(nodeConnectivity.HTTPConnectProxy) == 0) {
+		if !addonEnabled {
+			utils.Warn(ctx, "neither Konnectivity, WireGuard, nor the API server node connectivity, are declared: "+
+				"the API server may not reach the kubelets, failing the logs, exec, and port-forward requests")
+		}
+
+		return nil
+	}
+
+	for _, cidr := range nodeConnectivity.NodeCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid node connectivity nodeCidrs CIDR %s, %s", cidr, err.Error())
+		}
+	}
+
+	if len(nodeConnectivity.HTTPConnectProxy) > 0 && addonEnabled {
+		return fmt.Errorf("the node connectivity HTTP CONNECT proxy is mutually exclusive with Konnectivity, and WireGuard")
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneNodeConnectivity) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneNodeConnectivity) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneNodeConnectivity) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(ctx, tcp)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+var _ = Describe("TCP Node Connectivity Webhook", func() {
+	var (
+		ctx      context.Context
+		warnings *[]string
+		t        handlers.TenantControlPlaneNodeConnectivity
+		tcp      *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneNodeConnectivity{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		ctx, warnings = utils.WithWarnings(context.Background())
+	})
+
+	It("warns when the API server may not reach the kubelets", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(HaveLen(1))
+	})
+
+	It("doesn't warn when Konnectivity is enabled", func() {
+		tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(BeEmpty())
+	})
+
+	It("doesn't warn when the routable nodes networks are declared", func() {
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			NodeConnectivity: &kamajiv1alpha1.APIServerNodeConnectivitySpec{
+				NodeCIDRs: []string{"192.168.0.0/16"},
+			},
+		}
+
+		_, err := t.OnUpdate(tcp, tcp.DeepCopy())(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(BeEmpty())
+	})
+
+	It("denies the invalid nodes networks", func() {
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			NodeConnectivity: &kamajiv1alpha1.APIServerNodeConnectivitySpec{
+				NodeCIDRs: []string{"192.168.0.0"},
+			},
+		}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the HTTP CONNECT proxy along with Konnectivity", func() {
+		tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{}
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			NodeConnectivity: &kamajiv1alpha1.APIServerNodeConnectivitySpec{
+				HTTPConnectProxy: "http://bastion.tenant-00.example:3128",
+			},
+		}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,217 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneQuota rejects the Tenant Control Planes whose footprint, summed to the one of the other
+// Tenant Control Planes of the namespace, exceeds the control plane quota: the updates not increasing the footprint
+// are always allowed, letting the tenants scale down when the quota has been lowered.
+type TenantControlPlaneQuota struct {
+	Client client.Client
+}
+
+// controlPlaneFootprint is the number of pods, and the resources requested, by a Tenant Control Plane.
+type controlPlaneFootprint struct {
+	replicas int64
+	cpu      resource.Quantity
+	memory   resource.Quantity
+}
+
+func (f *controlPlaneFootprint) add(other controlPlaneFootprint) {
+	f.replicas += other.replicas
+	f.cpu.Add(other.cpu)
+	f.memory.Add(other.memory)
+}
+
+// exceeds returns true when any dimension of the footprint is greater than the other one.
+func (f controlPlaneFootprint) exceeds(other controlPlaneFootprint) bool {
+	return f.replicas > other.replicas || f.cpu.Cmp(other.cpu) > 0 || f.memory.Cmp(other.memory) > 0
+}
+
+// addContainer adds the given replicas of a container, accounting the limit when the request is not declared.
+func (f *controlPlaneFootprint) addContainer(replicas int64, requirements *corev1.ResourceRequirements) {
+	if requirements == nil {
+		return
+	}
+
+	for name, total := range map[corev1.ResourceName]*resource.Quantity{corev1.ResourceCPU: &f.cpu, corev1.ResourceMemory: &f.memory} {
+		quantity, ok := requirements.Requests[name]
+		if !ok {
+			quantity, ok = requirements.Limits[name]
+		}
+
+		if !ok {
+			continue
+		}
+
+		for range replicas {
+			total.Add(quantity)
+		}
+	}
+}
+
+func tenantControlPlaneFootprint(tcp *kamajiv1alpha1.TenantControlPlane) controlPlaneFootprint {
+	deployment := tcp.Spec.ControlPlane.Deployment
+
+	replicas := int64(2)
+	if deployment.Replicas != nil {
+		replicas = int64(*deployment.Replicas)
+	}
+
+	controllerManagerReplicas, schedulerReplicas := replicas, replicas
+
+	footprint := controlPlaneFootprint{replicas: replicas}
+	// With the Split topology, the controller-manager, and the scheduler, are running in their own pods.
+	if tcp.HasSplitTopology() {
+		if component := tcp.SplitComponent(kamajiv1alpha1.SplitComponentControllerManager); component != nil && component.Replicas != nil {
+			controllerManagerReplicas = int64(*component.Replicas)
+		}
+
+		if component := tcp.SplitComponent(kamajiv1alpha1.SplitComponentScheduler); component != nil && component.Replicas != nil {
+			schedulerReplicas = int64(*component.Replicas)
+		}
+
+		footprint.replicas += controllerManagerReplicas + schedulerReplicas
+	}
+
+	if resources := deployment.Resources; resources != nil {
+		footprint.addContainer(replicas, resources.APIServer)
+		footprint.addContainer(replicas, resources.Kine)
+		footprint.addContainer(controllerManagerReplicas, resources.ControllerManager)
+		footprint.addContainer(schedulerReplicas, resources.Scheduler)
+	}
+
+	return footprint
+}
+
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+// quota returns the control plane quota of the given namespace, the KamajiDefaults one overridden by the namespace annotations:
+// nil if no limits are declared.
+func (t TenantControlPlaneQuota) quota(ctx context.Context, namespace string) (*kamajiv1alpha1.ControlPlaneQuotaSpec, error) {
+	var quota kamajiv1alpha1.ControlPlaneQuotaSpec
+
+	var defaults kamajiv1alpha1.KamajiDefaults
+	switch err := t.Client.Get(ctx, types.NamespacedName{Name: kamajiv1alpha1.KamajiDefaultsName}, &defaults); {
+	case k8serrors.IsNotFound(err):
+		break
+	case err != nil:
+		return nil, errors.Wrap(err, "cannot retrieve the KamajiDefaults")
+	case defaults.Spec.ControlPlaneQuota != nil:
+		quota = *defaults.Spec.ControlPlaneQuota.DeepCopy()
+	}
+
+	var ns corev1.Namespace
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: namespace}, &ns); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("cannot retrieve the namespace %s", namespace))
+	}
+
+	annotations := ns.GetAnnotations()
+
+	if v, ok := annotations[kamajiv1alpha1.ControlPlaneQuotaReplicasAnnotation]; ok {
+		replicas, err := strconv.ParseInt(v, 10, 32)
+		if err != nil || replicas < 0 {
+			return nil, fmt.Errorf("invalid %s annotation of the namespace %s: %q", kamajiv1alpha1.ControlPlaneQuotaReplicasAnnotation, namespace, v)
+		}
+
+		quota.Replicas = ptr.To(int32(replicas))
+	}
+
+	for key, target := range map[string]**resource.Quantity{kamajiv1alpha1.ControlPlaneQuotaCPUAnnotation: &quota.CPU, kamajiv1alpha1.ControlPlaneQuotaMemoryAnnotation: &quota.Memory} {
+		v, ok := annotations[key]
+		if !ok {
+			continue
+		}
+
+		quantity, err := resource.ParseQuantity(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s annotation of the namespace %s: %q", key, namespace, v)
+		}
+
+		*target = &quantity
+	}
+
+	if quota.Replicas == nil && quota.CPU == nil && quota.Memory == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	return &quota, nil
+}
+
+func (t TenantControlPlaneQuota) handle(ctx context.Context, tcp, old *kamajiv1alpha1.TenantControlPlane) error {
+	footprint := tenantControlPlaneFootprint(tcp)
+	if old != nil && !footprint.exceeds(tenantControlPlaneFootprint(old)) {
+		return nil
+	}
+
+	quota, err := t.quota(ctx, tcp.GetNamespace())
+	if err != nil || quota == nil {
+		return err
+	}
+
+	var tcpList kamajiv1alpha1.TenantControlPlaneList
+	if err = t.Client.List(ctx, &tcpList, client.InNamespace(tcp.GetNamespace())); err != nil {
+		return errors.Wrap(err, "cannot list the Tenant Control Planes of the namespace")
+	}
+
+	total := footprint
+
+	for i := range tcpList.Items {
+		if tcpList.Items[i].GetName() == tcp.GetName() {
+			continue
+		}
+
+		total.add(tenantControlPlaneFootprint(&tcpList.Items[i]))
+	}
+
+	switch {
+	case quota.Replicas != nil && total.replicas > int64(*quota.Replicas):
+		return fmt.Errorf("the Tenant Control Planes of the namespace %s would run %d pods, exceeding the control plane quota of %d", tcp.GetNamespace(), total.replicas, *quota.Replicas)
+	case quota.CPU != nil && total.cpu.Cmp(*quota.CPU) > 0:
+		return fmt.Errorf("the Tenant Control Planes of the namespace %s would request %s CPU, exceeding the control plane quota of %s", tcp.GetNamespace(), total.cpu.String(), quota.CPU.String())
+	case quota.Memory != nil && total.memory.Cmp(*quota.Memory) > 0:
+		return fmt.Errorf("the Tenant Control Planes of the namespace %s would request %s memory, exceeding the control plane quota of %s", tcp.GetNamespace(), total.memory.String(), quota.Memory.String())
+	default:
+		return nil
+	}
+}
+
+func (t TenantControlPlaneQuota) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(ctx, tcp, nil)
+	}
+}
+
+func (t TenantControlPlaneQuota) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneQuota) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp, old := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(ctx, tcp, old)
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Quota Webhook", func() {
+	var (
+		ctx       context.Context
+		namespace *corev1.Namespace
+		defaults  *kamajiv1alpha1.KamajiDefaults
+		tcp       *kamajiv1alpha1.TenantControlPlane
+	)
+
+	newHandler := func(objects ...client.Object) handlers.TenantControlPlaneQuota {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(kamajiv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		return handlers.TenantControlPlaneQuota{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build(),
+		}
+	}
+
+	newTCP := func(name string, replicas int32, cpu string) *kamajiv1alpha1.TenantControlPlane {
+		tcp := &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "tenants",
+			},
+		}
+		tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(replicas)
+		tcp.Spec.ControlPlane.Deployment.Resources = &kamajiv1alpha1.ControlPlaneComponentsResources{
+			APIServer: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse(cpu)},
+			},
+		}
+
+		return tcp
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		namespace = &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenants"}}
+		defaults = &kamajiv1alpha1.KamajiDefaults{
+			ObjectMeta: metav1.ObjectMeta{Name: kamajiv1alpha1.KamajiDefaultsName},
+			Spec: kamajiv1alpha1.KamajiDefaultsSpec{
+				ControlPlaneQuota: &kamajiv1alpha1.ControlPlaneQuotaSpec{
+					Replicas: ptr.To(int32(4)),
+					CPU:      ptr.To(resource.MustParse("1")),
+				},
+			},
+		}
+		tcp = newTCP("tcp", 2, "250m")
+	})
+
+	It("allows any footprint when no quota is declared", func() {
+		t := newHandler(namespace)
+		tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(10))
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows the creation within the quota", func() {
+		t := newHandler(namespace, defaults, newTCP("other", 2, "250m"))
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the creation exceeding the replicas quota", func() {
+		t := newHandler(namespace, defaults, newTCP("other", 3, "100m"))
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the creation exceeding the CPU quota", func() {
+		t := newHandler(namespace, defaults)
+		tcp = newTCP("tcp", 2, "600m")
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("accounts the controller-manager, and the scheduler, pods with the Split topology", func() {
+		t := newHandler(namespace, defaults)
+		tcp.Spec.ControlPlane.Deployment.ComponentTopology = kamajiv1alpha1.ComponentTopologySplit
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("applies the namespace annotations overriding the quota", func() {
+		namespace.SetAnnotations(map[string]string{kamajiv1alpha1.ControlPlaneQuotaReplicasAnnotation: "10"})
+		t := newHandler(namespace, defaults, newTCP("other", 3, "100m"))
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the creation when the namespace annotation is invalid", func() {
+		namespace.SetAnnotations(map[string]string{kamajiv1alpha1.ControlPlaneQuotaCPUAnnotation: "a lot"})
+		t := newHandler(namespace)
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the updates not increasing the footprint", func() {
+		t := newHandler(namespace, defaults, newTCP("other", 4, "100m"))
+
+		_, err := t.OnUpdate(tcp, newTCP("tcp", 3, "250m"))(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = t.OnUpdate(newTCP("tcp", 3, "250m"), tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
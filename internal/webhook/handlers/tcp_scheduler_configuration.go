@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneSchedulerConfiguration rejects the scheduler configuration for the Kubernetes versions
+// not serving the kubescheduler.config.k8s.io/v1 API, since the older ones cannot be rendered.
+type TenantControlPlaneSchedulerConfiguration struct{}
+
+func (t TenantControlPlaneSchedulerConfiguration) handle(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.Spec.Kubernetes.Scheduler == nil || tcp.Spec.Kubernetes.Scheduler.Config == nil {
+		return nil
+	}
+
+	ver, err := semver.ParseTolerant(tcp.Spec.Kubernetes.Version)
+	if err != nil {
+		return errors.Wrap(err, "unable to parse the desired Kubernetes version")
+	}
+
+	if ver.LT(semver.MustParse(constants.SchedulerConfigurationMinVersion)) {
+		return fmt.Errorf("the scheduler configuration requires Kubernetes v%s, or greater", constants.SchedulerConfigurationMinVersion)
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneSchedulerConfiguration) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(tcp)
+	}
+}
+
+func (t TenantControlPlaneSchedulerConfiguration) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSchedulerConfiguration) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.handle(tcp)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Scheduler Configuration Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneSchedulerConfiguration
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneSchedulerConfiguration{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				Kubernetes: kamajiv1alpha1.KubernetesSpec{
+					Version: "v1.24.17",
+				},
+			},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation when no scheduler configuration is declared", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies creation when the Kubernetes version doesn't serve the v1 scheduler configuration", func() {
+		tcp.Spec.Kubernetes.Scheduler = &kamajiv1alpha1.SchedulerSpec{Config: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"kubescheduler.config.k8s.io/v1","kind":"KubeSchedulerConfiguration"}`)}}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("requires Kubernetes v1.25.0"))
+	})
+
+	It("allows update when the Kubernetes version serves the v1 scheduler configuration", func() {
+		tcp.Spec.Kubernetes.Version = "v1.25.0"
+		tcp.Spec.Kubernetes.Scheduler = &kamajiv1alpha1.SchedulerSpec{Config: &runtime.RawExtension{Raw: []byte(`{"apiVersion":"kubescheduler.config.k8s.io/v1","kind":"KubeSchedulerConfiguration"}`)}}
+		_, err := t.OnUpdate(tcp, tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,96 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/authentication/serviceaccount"
+	"k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/utilities"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneSecrets prevents the accidental changes to the Secrets generated for the Tenant Control Planes
+// by actors other than Kamaji, the cluster administrators, and the Kubernetes controllers, such as the garbage collector,
+// even when the kube-controller-manager doesn't use the service account credentials: the metadata changes, such as the rotation annotation, are still allowed.
+type TenantControlPlaneSecrets struct {
+	// Username is the Kamaji ServiceAccount one, in the system:serviceaccount:<namespace>:<name> form.
+	Username string
+}
+
+func (t TenantControlPlaneSecrets) OnCreate(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSecrets) OnDelete(object runtime.Object) AdmissionResponse {
+	return func(_ context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		secret := object.(*corev1.Secret) //nolint:forcetypeassert
+
+		tcp, ok := t.protected(secret, req)
+		if !ok {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("the Secret is managed by the Tenant Control Plane %s and cannot be deleted: annotate it with %s to rotate it", tcp, utilities.RotateCertificateRequestAnnotation)
+	}
+}
+
+func (t TenantControlPlaneSecrets) OnUpdate(object runtime.Object, prevObject runtime.Object) AdmissionResponse {
+	return func(_ context.Context, req admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		secret, prevSecret := object.(*corev1.Secret), prevObject.(*corev1.Secret) //nolint:forcetypeassert
+
+		tcp, ok := t.protected(prevSecret, req)
+		if !ok {
+			return nil, nil
+		}
+
+		switch {
+		case !equality.Semantic.DeepEqual(secret.Data, prevSecret.Data) || secret.Type != prevSecret.Type:
+			return nil, fmt.Errorf("the Secret is managed by the Tenant Control Plane %s and its content cannot be changed", tcp)
+		case !equality.Semantic.DeepEqual(secret.GetOwnerReferences(), prevSecret.GetOwnerReferences()):
+			return nil, fmt.Errorf("the Secret is managed by the Tenant Control Plane %s and its owner references cannot be changed", tcp)
+		}
+
+		for _, key := range []string{constants.ProjectNameLabelKey, constants.ControlPlaneLabelKey, constants.ControlPlaneLabelResource, constants.RotationGenerationLabelKey} {
+			if secret.GetLabels()[key] != prevSecret.GetLabels()[key] {
+				return nil, fmt.Errorf("the Secret is managed by the Tenant Control Plane %s and its %s label cannot be changed", tcp, key)
+			}
+		}
+
+		return nil, nil
+	}
+}
+
+// protected returns the Tenant Control Plane controlling the given Kamaji Secret,
+// false when the Secret isn't generated by Kamaji, or the request is issued by Kamaji, a Kubernetes controller, or a cluster administrator.
+func (t TenantControlPlaneSecrets) protected(secret *corev1.Secret, req admission.Request) (string, bool) {
+	if secret.GetLabels()[constants.ProjectNameLabelKey] != constants.ProjectNameLabelValue {
+		return "", false
+	}
+
+	tcp, ok := kamajiv1alpha1.OwningTenantControlPlane(secret)
+	if !ok {
+		return "", false
+	}
+
+	switch {
+	case req.UserInfo.Username == t.Username, req.UserInfo.Username == user.KubeControllerManager:
+		return "", false
+	case slices.Contains(req.UserInfo.Groups, serviceaccount.MakeNamespaceGroupName("kube-system")), slices.Contains(req.UserInfo.Groups, user.SystemPrivilegedGroup):
+		return "", false
+	}
+
+	return tcp.String(), true
+}
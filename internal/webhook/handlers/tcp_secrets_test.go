@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/constants"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Secrets Webhook", func() {
+	var (
+		ctx    context.Context
+		t      handlers.TenantControlPlaneSecrets
+		secret *corev1.Secret
+	)
+
+	request := func(username string, groups ...string) admission.Request {
+		return admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username, Groups: groups},
+		}}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		t = handlers.TenantControlPlaneSecrets{Username: "system:serviceaccount:kamaji-system:kamaji"}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tenant-00-ca",
+				Namespace: "default",
+				Labels: map[string]string{
+					constants.ProjectNameLabelKey:       constants.ProjectNameLabelValue,
+					constants.ControlPlaneLabelKey:      "tenant-00",
+					constants.ControlPlaneLabelResource: "ca",
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: kamajiv1alpha1.GroupVersion.String(),
+					Kind:       "TenantControlPlane",
+					Name:       "tenant-00",
+					Controller: ptr.To(true),
+				}},
+			},
+			Data: map[string][]byte{"ca.crt": []byte("certificate")},
+		}
+	})
+
+	It("denies the content changes by the users", func() {
+		updated := secret.DeepCopy()
+		updated.Data["ca.crt"] = []byte("tampered")
+
+		_, err := t.OnUpdate(updated, secret)(ctx, request("kubernetes-admin"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the Kamaji labels changes by the users", func() {
+		updated := secret.DeepCopy()
+		updated.Labels[constants.ControlPlaneLabelKey] = "tenant-01"
+
+		_, err := t.OnUpdate(updated, secret)(ctx, request("kubernetes-admin"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the rotation annotation by the users", func() {
+		updated := secret.DeepCopy()
+		updated.Annotations = map[string]string{"certs.kamaji.clastix.io/rotate": ""}
+
+		_, err := t.OnUpdate(updated, secret)(ctx, request("kubernetes-admin"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows the content changes by Kamaji", func() {
+		updated := secret.DeepCopy()
+		updated.Data["ca.crt"] = []byte("rotated")
+
+		_, err := t.OnUpdate(updated, secret)(ctx, request("system:serviceaccount:kamaji-system:kamaji"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the deletion by the users", func() {
+		_, err := t.OnDelete(secret)(ctx, request("kubernetes-admin"))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the deletion by the garbage collector", func() {
+		_, err := t.OnDelete(secret)(ctx, request("system:serviceaccount:kube-system:generic-garbage-collector", "system:serviceaccounts:kube-system"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows the deletion by the garbage collector once the owner is gone, without the service account credentials", func() {
+		secret.OwnerReferences[0].UID = "deleted-tenant-control-plane"
+
+		_, err := t.OnDelete(secret)(ctx, request("system:kube-controller-manager", "system:authenticated"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows the deletion by the cluster administrators", func() {
+		_, err := t.OnDelete(secret)(ctx, request("kubernetes-admin", "system:masters", "system:authenticated"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("ignores the Secrets not managed by Kamaji", func() {
+		secret.Labels = nil
+
+		_, err := t.OnDelete(secret)(ctx, request("kubernetes-admin"))
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
@@ -0,0 +1,191 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"gomodules.xyz/jsonpatch/v2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// baselineCapabilities are the capabilities allowed by the baseline Pod Security Standard.
+var baselineCapabilities = []corev1.Capability{
+	"AUDIT_WRITE", "CHOWN", "DAC_OVERRIDE", "FOWNER", "FSETID", "KILL", "MKNOD",
+	"NET_BIND_SERVICE", "SETFCAP", "SETGID", "SETPCAP", "SETUID", "SYS_CHROOT",
+}
+
+// TenantControlPlaneSecurityProfile verifies the security profile of the Control Plane pods against the Pod Security Admission
+// levels of the Tenant Control Plane namespace: the pods violating the enforced level would be rejected by the ReplicaSets,
+// hence the Tenant Control Plane is rejected, while the violations of the audit, and warn, levels are returned as warnings.
+// The updates leaving the pods security settings untouched are never rejected, such as with the namespaces exempted by the admission.
+type TenantControlPlaneSecurityProfile struct {
+	Client client.Client
+}
+
+//+kubebuilder:rbac:groups=core,resources=namespaces,verbs=get;list;watch
+
+func (t TenantControlPlaneSecurityProfile) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane, enforce bool) error {
+	var namespace corev1.Namespace
+	if err := t.Client.Get(ctx, types.NamespacedName{Name: tcp.GetNamespace()}, &namespace); err != nil {
+		return fmt.Errorf("cannot retrieve the Tenant Control Plane namespace: %w", err)
+	}
+
+	level, reason := t.level(tcp)
+
+	for _, mode := range []string{"enforce", "audit", "warn"} {
+		required := kamajiv1alpha1.PodSecurityLevel(namespace.GetLabels()["pod-security.kubernetes.io/"+mode])
+		if podSecurityRank(level) >= podSecurityRank(required) {
+			continue
+		}
+
+		if mode == "enforce" && enforce {
+			return fmt.Errorf("the Control Plane pods would violate the %s Pod Security level enforced by the %s namespace: %s", required, namespace.GetName(), reason)
+		}
+
+		utils.Warn(ctx, "the Control Plane pods violate the %s Pod Security level of the %s namespace %s mode: %s", required, namespace.GetName(), mode, reason)
+	}
+
+	deployment := tcp.Spec.ControlPlane.Deployment
+	if len(deployment.SecurityProfile) > 0 && (len(deployment.AdditionalContainers) > 0 || len(deployment.AdditionalInitContainers) > 0) {
+		utils.Warn(ctx, "the security profile is not applied to the additional containers, which must comply with the Pod Security levels on their own")
+	}
+
+	return nil
+}
+
+// level returns the highest Pod Security level satisfied by the Control Plane pods, along with the reason it's not a higher one.
+func (t TenantControlPlaneSecurityProfile) level(tcp *kamajiv1alpha1.TenantControlPlane) (kamajiv1alpha1.PodSecurityLevel, string) {
+	deployment := tcp.Spec.ControlPlane.Deployment
+
+	switch {
+	case tcp.Spec.Addons.WireGuard != nil:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the WireGuard sidecar requires the NET_ADMIN capability"
+	case deployment.HostNetwork != nil:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the host network mode requires the host network namespace"
+	}
+
+	switch deployment.SecurityProfile {
+	case kamajiv1alpha1.SecurityProfileRestricted:
+		return kamajiv1alpha1.PodSecurityLevelRestricted, ""
+	case kamajiv1alpha1.SecurityProfileCustom:
+		if deployment.SecurityContext == nil {
+			return kamajiv1alpha1.PodSecurityLevelBaseline, "the Custom security profile declares no security context"
+		}
+
+		return customSecurityLevel(deployment.SecurityContext.Pod, deployment.SecurityContext.Container)
+	default:
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "the Restricted security profile is required"
+	}
+}
+
+// customSecurityLevel evaluates the security contexts of the Custom security profile against the Pod Security Standards,
+// as far as the fields of the security contexts are concerned.
+func customSecurityLevel(pod *corev1.PodSecurityContext, container *corev1.SecurityContext) (kamajiv1alpha1.PodSecurityLevel, string) {
+	if pod == nil {
+		pod = &corev1.PodSecurityContext{}
+	}
+
+	if container == nil {
+		container = &corev1.SecurityContext{}
+	}
+
+	var added, dropped []corev1.Capability
+	if container.Capabilities != nil {
+		added, dropped = container.Capabilities.Add, container.Capabilities.Drop
+	}
+
+	seccomp := container.SeccompProfile
+	if seccomp == nil {
+		seccomp = pod.SeccompProfile
+	}
+
+	appArmor := container.AppArmorProfile
+	if appArmor == nil {
+		appArmor = pod.AppArmorProfile
+	}
+
+	switch {
+	case container.Privileged != nil && *container.Privileged:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the containers are privileged"
+	case slices.ContainsFunc(added, func(capability corev1.Capability) bool { return !slices.Contains(baselineCapabilities, capability) }):
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the containers add capabilities not allowed by the baseline level"
+	case seccomp != nil && seccomp.Type == corev1.SeccompProfileTypeUnconfined:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the seccomp profile is Unconfined"
+	case appArmor != nil && appArmor.Type == corev1.AppArmorProfileTypeUnconfined:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the AppArmor profile is Unconfined"
+	case container.ProcMount != nil && *container.ProcMount != corev1.DefaultProcMount:
+		return kamajiv1alpha1.PodSecurityLevelPrivileged, "the containers use a non-default /proc mount"
+	}
+
+	runAsNonRoot := (pod.RunAsNonRoot != nil && *pod.RunAsNonRoot) || (container.RunAsNonRoot != nil && *container.RunAsNonRoot)
+	runAsRoot := (pod.RunAsUser != nil && *pod.RunAsUser == 0) || (container.RunAsUser != nil && *container.RunAsUser == 0)
+
+	switch {
+	case container.AllowPrivilegeEscalation == nil || *container.AllowPrivilegeEscalation:
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "the privilege escalation must be disallowed"
+	case !slices.Contains(dropped, "ALL"):
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "all the capabilities must be dropped"
+	case slices.ContainsFunc(added, func(capability corev1.Capability) bool { return capability != "NET_BIND_SERVICE" }):
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "only the NET_BIND_SERVICE capability can be added"
+	case !runAsNonRoot || runAsRoot:
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "the containers must run as a non-root user"
+	case seccomp == nil:
+		return kamajiv1alpha1.PodSecurityLevelBaseline, "the RuntimeDefault, or a Localhost, seccomp profile must be declared"
+	}
+
+	return kamajiv1alpha1.PodSecurityLevelRestricted, ""
+}
+
+// podSecurityRank orders the Pod Security levels, the missing one being the privileged level.
+func podSecurityRank(level kamajiv1alpha1.PodSecurityLevel) int {
+	switch level {
+	case kamajiv1alpha1.PodSecurityLevelRestricted:
+		return 2
+	case kamajiv1alpha1.PodSecurityLevelBaseline:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (t TenantControlPlaneSecurityProfile) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp, true)
+	}
+}
+
+func (t TenantControlPlaneSecurityProfile) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneSecurityProfile) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp, old := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp, t.securityChanged(tcp, old))
+	}
+}
+
+// securityChanged returns true when the fields affecting the Pod Security level of the Control Plane pods have been changed.
+func (t TenantControlPlaneSecurityProfile) securityChanged(tcp, old *kamajiv1alpha1.TenantControlPlane) bool {
+	current, previous := tcp.Spec.ControlPlane.Deployment, old.Spec.ControlPlane.Deployment
+
+	return current.SecurityProfile != previous.SecurityProfile ||
+		!equality.Semantic.DeepEqual(current.SecurityContext, previous.SecurityContext) ||
+		(current.HostNetwork == nil) != (previous.HostNetwork == nil) ||
+		(tcp.Spec.Addons.WireGuard == nil) != (old.Spec.Addons.WireGuard == nil)
+}
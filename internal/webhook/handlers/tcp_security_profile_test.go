@@ -0,0 +1,118 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+var _ = Describe("TCP Security Profile Webhook", func() {
+	var (
+		ctx      context.Context
+		warnings *[]string
+		tcp      *kamajiv1alpha1.TenantControlPlane
+	)
+
+	newHandler := func(labels map[string]string) handlers.TenantControlPlaneSecurityProfile {
+		scheme := runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenants", Labels: labels}}
+
+		return handlers.TenantControlPlaneSecurityProfile{
+			Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace).Build(),
+		}
+	}
+
+	BeforeEach(func() {
+		ctx, warnings = utils.WithWarnings(context.Background())
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "tenants",
+			},
+		}
+	})
+
+	It("allows the Restricted profile in a restricted namespace", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "restricted"})
+		tcp.Spec.ControlPlane.Deployment.SecurityProfile = kamajiv1alpha1.SecurityProfileRestricted
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(BeEmpty())
+	})
+
+	It("denies the Baseline profile in a restricted namespace", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "restricted"})
+		tcp.Spec.ControlPlane.Deployment.SecurityProfile = kamajiv1alpha1.SecurityProfileBaseline
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("warns about the violations of the warn level", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "baseline", "pod-security.kubernetes.io/warn": "restricted"})
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(HaveLen(1))
+	})
+
+	It("denies the WireGuard sidecar in a baseline namespace", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "baseline"})
+		tcp.Spec.ControlPlane.Deployment.SecurityProfile = kamajiv1alpha1.SecurityProfileRestricted
+		tcp.Spec.Addons.WireGuard = &kamajiv1alpha1.WireGuardSpec{}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("evaluates the Custom security contexts", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "restricted"})
+		tcp.Spec.ControlPlane.Deployment.SecurityProfile = kamajiv1alpha1.SecurityProfileCustom
+		tcp.Spec.ControlPlane.Deployment.SecurityContext = &kamajiv1alpha1.ControlPlaneSecurityContext{
+			Pod: &corev1.PodSecurityContext{
+				RunAsNonRoot:    ptr.To(true),
+				SeccompProfile:  &corev1.SeccompProfile{Type: corev1.SeccompProfileTypeRuntimeDefault},
+				AppArmorProfile: &corev1.AppArmorProfile{Type: corev1.AppArmorProfileTypeRuntimeDefault},
+			},
+			Container: &corev1.SecurityContext{
+				AllowPrivilegeEscalation: ptr.To(false),
+				Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+			},
+		}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		tcp.Spec.ControlPlane.Deployment.SecurityContext.Container.Capabilities.Add = []corev1.Capability{"SYS_ADMIN"}
+
+		_, err = t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the updates leaving the security settings untouched", func() {
+		t := newHandler(map[string]string{"pod-security.kubernetes.io/enforce": "restricted"})
+		old := tcp.DeepCopy()
+		tcp.Spec.ControlPlane.Deployment.Replicas = ptr.To(int32(3))
+
+		_, err := t.OnUpdate(tcp, old)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,119 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneServiceAccountIssuer validates the service account issuers, and the API audiences, of the API server:
+// changing the main issuer is allowed only when the tokens it issued are still accepted, since they would be rejected otherwise.
+type TenantControlPlaneServiceAccountIssuer struct{}
+
+func (t TenantControlPlaneServiceAccountIssuer) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	for _, issuer := range tcp.Spec.Kubernetes.ServiceAccountIssuers() {
+		// The same check is performed by the API server upon the start.
+		if !strings.Contains(issuer, ":") {
+			continue
+		}
+
+		if _, err := url.ParseRequestURI(issuer); err != nil {
+			return fmt.Errorf("the service account issuer %s contains a colon, but it's not a valid URL: %s", issuer, err.Error())
+		}
+	}
+
+	for _, audience := range tcp.Spec.Kubernetes.APIAudiences() {
+		if len(strings.TrimSpace(audience)) == 0 || strings.Contains(audience, ",") {
+			return fmt.Errorf("the API audience %q must be a non empty string without commas", audience)
+		}
+	}
+	// The relying parties retrieve the discovery document from the issuer URL, rejecting the tokens otherwise.
+	if oidcDiscovery := tcp.Spec.Kubernetes.OIDCDiscovery(); oidcDiscovery != nil {
+		if errs := validation.IsDNS1123Subdomain(oidcDiscovery.Hostname); len(errs) > 0 {
+			return fmt.Errorf("the OIDC discovery hostname %s is not valid: %s", oidcDiscovery.Hostname, strings.Join(errs, ", "))
+		}
+
+		if issuer := tcp.Spec.Kubernetes.ServiceAccountIssuers()[0]; issuer != "https://"+oidcDiscovery.Hostname {
+			return fmt.Errorf("the service account issuer must be https://%s to publish the OIDC discovery documents, got %s", oidcDiscovery.Hostname, issuer)
+		}
+	}
+
+	return nil
+}
+
+// audiences returns the audiences of the tokens accepted by the API server, the main issuer when not declared.
+func (t TenantControlPlaneServiceAccountIssuer) audiences(tcp *kamajiv1alpha1.TenantControlPlane) []string {
+	if audiences := tcp.Spec.Kubernetes.APIAudiences(); len(audiences) > 0 {
+		return audiences
+	}
+
+	return tcp.Spec.Kubernetes.ServiceAccountIssuers()[:1]
+}
+
+func (t TenantControlPlaneServiceAccountIssuer) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneServiceAccountIssuer) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneServiceAccountIssuer) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp, old := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if err := t.validate(tcp); err != nil {
+			return nil, err
+		}
+
+		issuers, oldIssuers := tcp.Spec.Kubernetes.ServiceAccountIssuers(), old.Spec.Kubernetes.ServiceAccountIssuers()
+		audiences, oldAudiences := t.audiences(tcp), t.audiences(old)
+
+		if issuers[0] != oldIssuers[0] {
+			if !slices.Contains(issuers, oldIssuers[0]) {
+				return nil, fmt.Errorf("changing the service account issuer invalidates the issued tokens: "+
+					"add the previous issuer %s to the additionalServiceAccountIssuers until the tokens are refreshed", oldIssuers[0])
+			}
+
+			for _, audience := range oldAudiences {
+				if !slices.Contains(audiences, audience) {
+					return nil, fmt.Errorf("changing the service account issuer invalidates the tokens issued for the %s audience: "+
+						"add it to the apiAudiences until the tokens are refreshed", audience)
+				}
+			}
+
+			return nil, nil
+		}
+
+		for _, issuer := range oldIssuers {
+			if !slices.Contains(issuers, issuer) {
+				utils.Warn(ctx, "the tokens issued by %s are going to be rejected, ensure they have been refreshed", issuer)
+			}
+		}
+
+		for _, audience := range oldAudiences {
+			if !slices.Contains(audiences, audience) {
+				utils.Warn(ctx, "the tokens issued for the %s audience are going to be rejected, ensure they have been refreshed", audience)
+			}
+		}
+
+		return nil, nil
+	}
+}
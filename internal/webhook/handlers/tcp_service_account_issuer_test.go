@@ -0,0 +1,123 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+var _ = Describe("TCP Service Account Issuer Webhook", func() {
+	var (
+		ctx      context.Context
+		warnings *[]string
+		t        handlers.TenantControlPlaneServiceAccountIssuer
+		old      *kamajiv1alpha1.TenantControlPlane
+	)
+
+	const issuer = "https://oidc.tenant-00.example"
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneServiceAccountIssuer{}
+		old = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		ctx, warnings = utils.WithWarnings(context.Background())
+	})
+
+	It("denies the invalid issuer URL", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{ServiceAccountIssuer: "https://oidc tenant:00"}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the empty audiences", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{APIAudiences: []string{""}}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the OIDC discovery hostname not matching the issuer", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			OIDCDiscovery: &kamajiv1alpha1.APIServerOIDCDiscoverySpec{Hostname: "oidc.tenant-01.example"},
+		}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the OIDC discovery hostname matching the issuer", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			ServiceAccountIssuer: issuer,
+			OIDCDiscovery:        &kamajiv1alpha1.APIServerOIDCDiscoverySpec{Hostname: "oidc.tenant-00.example"},
+		}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies changing the issuer without accepting the previous one", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{ServiceAccountIssuer: issuer}
+
+		_, err := t.OnUpdate(tcp, old)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies changing the issuer without accepting the previous audience", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			ServiceAccountIssuer:            issuer,
+			AdditionalServiceAccountIssuers: []string{kamajiv1alpha1.DefaultServiceAccountIssuer},
+		}
+
+		_, err := t.OnUpdate(tcp, old)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows changing the issuer accepting the previous tokens", func() {
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			ServiceAccountIssuer:            issuer,
+			AdditionalServiceAccountIssuers: []string{kamajiv1alpha1.DefaultServiceAccountIssuer},
+			APIAudiences:                    []string{issuer, kamajiv1alpha1.DefaultServiceAccountIssuer},
+		}
+
+		_, err := t.OnUpdate(tcp, old)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(BeEmpty())
+	})
+
+	It("warns when the previous issuer is no longer accepted", func() {
+		old.Spec.Kubernetes.APIServer = &kamajiv1alpha1.APIServerSpec{
+			ServiceAccountIssuer:            issuer,
+			AdditionalServiceAccountIssuers: []string{kamajiv1alpha1.DefaultServiceAccountIssuer},
+			APIAudiences:                    []string{issuer, kamajiv1alpha1.DefaultServiceAccountIssuer},
+		}
+		tcp := old.DeepCopy()
+		tcp.Spec.Kubernetes.APIServer.AdditionalServiceAccountIssuers = nil
+		tcp.Spec.Kubernetes.APIServer.APIAudiences = nil
+
+		_, err := t.OnUpdate(tcp, old)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(HaveLen(2))
+	})
+})
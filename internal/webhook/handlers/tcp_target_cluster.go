@@ -0,0 +1,58 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// TenantControlPlaneTargetCluster validates the target cluster running the Control Plane pods, which cannot be changed once set,
+// since the workloads of the previous cluster would be orphaned: the dedicated DataStore is running in the management cluster,
+// thus it's not supported along with a target cluster, as are the dedicated Konnectivity servers, reached through their Service.
+type TenantControlPlaneTargetCluster struct{}
+
+func (t TenantControlPlaneTargetCluster) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(tcp)
+	}
+}
+
+func (t TenantControlPlaneTargetCluster) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneTargetCluster) OnUpdate(object runtime.Object, prev runtime.Object) AdmissionResponse {
+	return func(context.Context, admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), prev.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		if !equality.Semantic.DeepEqual(newTCP.TargetCluster(), oldTCP.TargetCluster()) {
+			return nil, fmt.Errorf("changing the target cluster is not supported, the Control Plane would be orphaned")
+		}
+
+		return nil, t.validate(newTCP)
+	}
+}
+
+func (t TenantControlPlaneTargetCluster) validate(tcp *kamajiv1alpha1.TenantControlPlane) error {
+	if tcp.TargetCluster() != nil && tcp.Spec.DedicatedDataStore != nil {
+		return fmt.Errorf("the dedicated DataStore is not supported along with a target cluster")
+	}
+
+	if konnectivity := tcp.Spec.Addons.Konnectivity; tcp.TargetCluster() != nil && konnectivity != nil && konnectivity.HasDeploymentPlacement() {
+		return fmt.Errorf("the Konnectivity server Deployment placement is not supported along with a target cluster")
+	}
+
+	return nil
+}
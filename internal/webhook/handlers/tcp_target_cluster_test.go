@@ -0,0 +1,80 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Target Cluster Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneTargetCluster
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneTargetCluster{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		tcp.Spec.ControlPlane.Deployment.TargetCluster = &kamajiv1alpha1.TargetClusterSpec{
+			KubeconfigSecretRef: kamajiv1alpha1.KubeconfigSecretReference{Name: "remote", Key: "kubeconfig"},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows creation with a target cluster", func() {
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies creation with a target cluster, and a dedicated DataStore", func() {
+		tcp.Spec.DedicatedDataStore = &kamajiv1alpha1.DedicatedDataStoreSpec{}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies creation with a target cluster, and the Konnectivity server Deployment placement", func() {
+		tcp.Spec.Addons.Konnectivity = &kamajiv1alpha1.KonnectivitySpec{
+			KonnectivityServerSpec: kamajiv1alpha1.KonnectivityServerSpec{Placement: kamajiv1alpha1.KonnectivityServerPlacementDeployment},
+		}
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("Konnectivity"))
+	})
+
+	It("allows update when the target cluster is unchanged", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.ControlPlane.Deployment.Replicas = nil
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies update when the target cluster is changed", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.ControlPlane.Deployment.TargetCluster.KubeconfigSecretRef.Name = "other"
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("target cluster"))
+	})
+
+	It("denies update when the target cluster is removed", func() {
+		newTCP := tcp.DeepCopy()
+		newTCP.Spec.ControlPlane.Deployment.TargetCluster = nil
+		_, err := t.OnUpdate(newTCP, tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
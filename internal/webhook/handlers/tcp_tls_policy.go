@@ -0,0 +1,97 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	cliflag "k8s.io/component-base/cli/flag"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/fips"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+// disableHTTP2MinVersion is the first Kubernetes version supporting the --disable-http2-serving flag.
+var disableHTTP2MinVersion = semver.MustParse("1.31.0")
+
+// TenantControlPlaneTLSPolicy validates the API server TLS policy, since the unknown cipher suites,
+// and the flags not supported by the Kubernetes version, would prevent the API server from starting.
+// The cipher suites of the Tenant Control Planes running in FIPS mode must be FIPS approved.
+type TenantControlPlaneTLSPolicy struct{}
+
+func (t TenantControlPlaneTLSPolicy) validate(ctx context.Context, tcp *kamajiv1alpha1.TenantControlPlane) error {
+	policy := tcp.Spec.Kubernetes.APIServerTLS()
+	if policy == nil {
+		return nil
+	}
+
+	if _, err := cliflag.TLSCipherSuites(policy.CipherSuites); err != nil {
+		return fmt.Errorf("the API server TLS policy is not valid: %w", err)
+	}
+
+	if tcp.IsFIPS() {
+		for _, cipherSuite := range policy.CipherSuites {
+			if !fips.IsApprovedCipherSuite(cipherSuite) {
+				return fmt.Errorf("the cipher suite %s is not FIPS approved, allowed ones are %s", cipherSuite, strings.Join(fips.CipherSuites, ", "))
+			}
+		}
+	}
+
+	if policy.DisableHTTP2 && len(tcp.Spec.Kubernetes.Version) > 0 {
+		version, err := semver.ParseTolerant(tcp.Spec.Kubernetes.Version)
+		if err != nil {
+			return fmt.Errorf("unable to parse the Kubernetes version %s: %w", tcp.Spec.Kubernetes.Version, err)
+		}
+
+		if version.LT(disableHTTP2MinVersion) {
+			return fmt.Errorf("disabling HTTP/2 requires Kubernetes v%s, or later", disableHTTP2MinVersion)
+		}
+	}
+
+	var insecure []string
+
+	for _, cipherSuite := range policy.CipherSuites {
+		if slices.Contains(cliflag.InsecureTLSCipherNames(), cipherSuite) {
+			insecure = append(insecure, cipherSuite)
+		}
+	}
+
+	if len(insecure) > 0 {
+		utils.Warn(ctx, "the API server accepts the insecure cipher suites %s", strings.Join(insecure, ", "))
+	}
+
+	if policy.MinVersion == "VersionTLS13" && len(policy.CipherSuites) > 0 {
+		utils.Warn(ctx, "the cipher suites are ignored with the TLS 1.3 minimum version, since they only apply to TLS 1.2")
+	}
+
+	return nil
+}
+
+func (t TenantControlPlaneTLSPolicy) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
+
+func (t TenantControlPlaneTLSPolicy) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneTLSPolicy) OnUpdate(object runtime.Object, _ runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.validate(ctx, tcp)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+var _ = Describe("TCP TLS Policy Webhook", func() {
+	var (
+		ctx      context.Context
+		warnings *[]string
+		t        handlers.TenantControlPlaneTLSPolicy
+		tcp      *kamajiv1alpha1.TenantControlPlane
+	)
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneTLSPolicy{}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				Kubernetes: kamajiv1alpha1.KubernetesSpec{
+					Version:   "v1.33.0",
+					APIServer: &kamajiv1alpha1.APIServerSpec{TLS: &kamajiv1alpha1.APIServerTLSSpec{}},
+				},
+			},
+		}
+		ctx, warnings = utils.WithWarnings(context.Background())
+	})
+
+	It("allows the known cipher suites", func() {
+		tcp.Spec.Kubernetes.APIServer.TLS.CipherSuites = []string{"TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256", "TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384"}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(BeEmpty())
+	})
+
+	It("denies the unknown cipher suites", func() {
+		tcp.Spec.Kubernetes.APIServer.TLS.CipherSuites = []string{"TLS_UNKNOWN"}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("warns about the insecure cipher suites", func() {
+		tcp.Spec.Kubernetes.APIServer.TLS.CipherSuites = []string{"TLS_RSA_WITH_RC4_128_SHA"}
+
+		_, err := t.OnUpdate(tcp, tcp.DeepCopy())(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(*warnings).To(HaveLen(1))
+	})
+
+	It("denies disabling HTTP/2 before Kubernetes v1.31", func() {
+		tcp.Spec.Kubernetes.Version = "v1.30.4"
+		tcp.Spec.Kubernetes.APIServer.TLS.DisableHTTP2 = true
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+
+		tcp.Spec.Kubernetes.Version = "v1.31.0"
+
+		_, err = t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the cipher suites not FIPS approved in FIPS mode", func() {
+		tcp.Spec.Compliance = &kamajiv1alpha1.ComplianceSpec{FIPS: true}
+		tcp.Spec.Kubernetes.APIServer.TLS.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_CHACHA20_POLY1305_SHA256"}
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+
+		tcp.Spec.Kubernetes.APIServer.TLS.CipherSuites = []string{"TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256"}
+
+		_, err = t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
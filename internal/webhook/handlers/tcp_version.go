@@ -7,6 +7,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/blang/semver"
 	"github.com/pkg/errors"
@@ -19,6 +20,13 @@ import (
 	"github.com/clastix/kamaji/internal/webhook/utils"
 )
 
+const (
+	// downgradeBackupMaxAge is the maximum age of the backup required to force a downgrade.
+	downgradeBackupMaxAge = time.Hour
+	// downgradeBackupClockSkew tolerates backup timestamps slightly in the future.
+	downgradeBackupClockSkew = 5 * time.Minute
+)
+
 type TenantControlPlaneVersion struct{}
 
 func (t TenantControlPlaneVersion) OnCreate(object runtime.Object) AdmissionResponse {
@@ -77,6 +85,12 @@ func (t TenantControlPlaneVersion) OnUpdate(object runtime.Object, oldObject run
 		switch {
 		case newVer.GT(supportedVer):
 			return nil, fmt.Errorf("unable to upgrade to a version greater than the supported one, actually %s", supportedVer.String())
+		case newVer.Major != oldVer.Major:
+			return nil, fmt.Errorf("unable to change the major version of a TenantControlPlane from %s to %s", oldVer.String(), newVer.String())
+		case newVer.Minor < oldVer.Minor:
+			if err := t.validateDowngrade(newTCP, oldVer, *newVer); err != nil {
+				return nil, err
+			}
 		case newVer.LT(oldVer):
 			return nil, fmt.Errorf("unable to downgrade a TenantControlPlane from %s to %s", oldVer.String(), newVer.String())
 		case newVer.Minor-oldVer.Minor > 1:
@@ -86,3 +100,34 @@ func (t TenantControlPlaneVersion) OnUpdate(object runtime.Object, oldObject run
 		return nil, nil
 	}
 }
+
+// validateDowngrade allows a downgrade to the previous minor version only if forced, and backed by a fresh backup:
+// the DataStore may contain objects persisted with storage versions unknown to the previous API Server.
+func (t TenantControlPlaneVersion) validateDowngrade(tcp *kamajiv1alpha1.TenantControlPlane, oldVer, newVer semver.Version) error {
+	annotations := tcp.GetAnnotations()
+
+	if oldVer.Minor-newVer.Minor > 1 {
+		return fmt.Errorf("unable to downgrade a TenantControlPlane from %s to %s, skipping a minor version", oldVer.String(), newVer.String())
+	}
+
+	if forced := annotations[kamajiv1alpha1.ForceDowngradeAnnotation]; t.normalizeKubernetesVersion(forced) != newVer.String() {
+		return fmt.Errorf("unable to downgrade a TenantControlPlane from %s to %s, the DataStore may contain objects not readable by the previous version: "+
+			"take a backup, and annotate the TenantControlPlane with %s=v%s, %s, and %s", oldVer.String(), newVer.String(),
+			kamajiv1alpha1.ForceDowngradeAnnotation, newVer.String(), kamajiv1alpha1.DowngradeBackupAnnotation, kamajiv1alpha1.DowngradeBackupTimestampAnnotation)
+	}
+
+	if len(annotations[kamajiv1alpha1.DowngradeBackupAnnotation]) == 0 {
+		return fmt.Errorf("unable to force the downgrade, the backup must be referenced with the %s annotation", kamajiv1alpha1.DowngradeBackupAnnotation)
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, annotations[kamajiv1alpha1.DowngradeBackupTimestampAnnotation])
+	if err != nil {
+		return fmt.Errorf("unable to force the downgrade, the %s annotation must be a RFC 3339 time", kamajiv1alpha1.DowngradeBackupTimestampAnnotation)
+	}
+
+	if age := time.Since(timestamp); age > downgradeBackupMaxAge || age < -downgradeBackupClockSkew {
+		return fmt.Errorf("unable to force the downgrade, the backup must have been taken in the last %s", downgradeBackupMaxAge.String())
+	}
+
+	return nil
+}
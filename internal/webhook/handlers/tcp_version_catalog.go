@@ -0,0 +1,74 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"gomodules.xyz/jsonpatch/v2"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/utils"
+)
+
+//+kubebuilder:rbac:groups=kamaji.clastix.io,resources=kubernetesversioncatalogs,verbs=get;list;watch
+
+// TenantControlPlaneVersionCatalog validates the Kubernetes version against the KubernetesVersionCatalog objects:
+// when none is declared, any version is allowed, according to the Kamaji supported one.
+type TenantControlPlaneVersionCatalog struct {
+	Client client.Client
+}
+
+func (t TenantControlPlaneVersionCatalog) OnCreate(object runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		tcp := object.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+
+		return nil, t.check(ctx, tcp.Spec.Kubernetes.Version, kamajiv1alpha1.KubernetesVersionDeprecated, kamajiv1alpha1.KubernetesVersionUnsupported)
+	}
+}
+
+func (t TenantControlPlaneVersionCatalog) OnDelete(runtime.Object) AdmissionResponse {
+	return utils.NilOp()
+}
+
+func (t TenantControlPlaneVersionCatalog) OnUpdate(object runtime.Object, oldObject runtime.Object) AdmissionResponse {
+	return func(ctx context.Context, _ admission.Request) ([]jsonpatch.JsonPatchOperation, error) {
+		newTCP, oldTCP := object.(*kamajiv1alpha1.TenantControlPlane), oldObject.(*kamajiv1alpha1.TenantControlPlane) //nolint:forcetypeassert
+		// The existing Tenant Control Planes running a no more supported version must be left manageable.
+		if newTCP.Spec.Kubernetes.Version == oldTCP.Spec.Kubernetes.Version {
+			return nil, nil
+		}
+
+		return nil, t.check(ctx, newTCP.Spec.Kubernetes.Version, kamajiv1alpha1.KubernetesVersionUnsupported)
+	}
+}
+
+func (t TenantControlPlaneVersionCatalog) check(ctx context.Context, version string, rejected ...kamajiv1alpha1.KubernetesVersionSupport) error {
+	var catalogs kamajiv1alpha1.KubernetesVersionCatalogList
+	if err := t.Client.List(ctx, &catalogs); err != nil {
+		return errors.Wrap(err, "cannot list the KubernetesVersionCatalog objects")
+	}
+
+	if len(catalogs.Items) == 0 {
+		return nil
+	}
+
+	entry := catalogs.Lookup(version)
+	if entry == nil {
+		return fmt.Errorf("the Kubernetes version %s is not listed in any KubernetesVersionCatalog", version)
+	}
+
+	for _, support := range rejected {
+		if entry.GetSupport() == support {
+			return fmt.Errorf("the Kubernetes version %s is %s according to the KubernetesVersionCatalog objects", version, support)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Version Catalog Webhook", func() {
+	var (
+		ctx context.Context
+		t   handlers.TenantControlPlaneVersionCatalog
+		tcp *kamajiv1alpha1.TenantControlPlane
+	)
+
+	newClient := func(objects ...client.Object) client.Client {
+		scheme := runtime.NewScheme()
+		Expect(kamajiv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	}
+
+	BeforeEach(func() {
+		t = handlers.TenantControlPlaneVersionCatalog{
+			Client: newClient(&kamajiv1alpha1.KubernetesVersionCatalog{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec: kamajiv1alpha1.KubernetesVersionCatalogSpec{
+					Versions: []kamajiv1alpha1.KubernetesVersionEntry{
+						{Version: "v1.31", Support: kamajiv1alpha1.KubernetesVersionUnsupported},
+						{Version: "v1.32", Support: kamajiv1alpha1.KubernetesVersionDeprecated},
+						{Version: "v1.33", Support: kamajiv1alpha1.KubernetesVersionSupported},
+					},
+				},
+			}),
+		}
+		tcp = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+		}
+		ctx = context.Background()
+	})
+
+	It("allows any version when no catalog is declared", func() {
+		t.Client = newClient()
+		tcp.Spec.Kubernetes.Version = "v1.20.0"
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("allows the creation with a supported version", func() {
+		tcp.Spec.Kubernetes.Version = "v1.33.1"
+
+		_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the creation with a deprecated, or a not listed, version", func() {
+		for _, version := range []string{"v1.32.4", "v1.30.0"} {
+			tcp.Spec.Kubernetes.Version = version
+
+			_, err := t.OnCreate(tcp)(ctx, admission.Request{})
+			Expect(err).To(HaveOccurred())
+		}
+	})
+
+	It("allows the upgrade to a deprecated version, denying the unsupported ones", func() {
+		oldTCP := tcp.DeepCopy()
+		oldTCP.Spec.Kubernetes.Version = "v1.31.0"
+
+		tcp.Spec.Kubernetes.Version = "v1.32.0"
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+
+		oldTCP.Spec.Kubernetes.Version = "v1.30.0"
+		tcp.Spec.Kubernetes.Version = "v1.31.0"
+		_, err = t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the updates of a Tenant Control Plane running an unsupported version", func() {
+		tcp.Spec.Kubernetes.Version = "v1.31.0"
+
+		_, err := t.OnUpdate(tcp, tcp.DeepCopy())(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+})
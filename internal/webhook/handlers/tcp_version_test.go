@@ -0,0 +1,109 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers_test
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	kamajiv1alpha1 "github.com/clastix/kamaji/api/v1alpha1"
+	"github.com/clastix/kamaji/internal/webhook/handlers"
+)
+
+var _ = Describe("TCP Version Webhook", func() {
+	var (
+		ctx            context.Context
+		t              handlers.TenantControlPlaneVersion
+		tcp, oldTCP    *kamajiv1alpha1.TenantControlPlane
+		forceDowngrade func(version string, backupTime time.Time)
+	)
+
+	BeforeEach(func() {
+		oldTCP = &kamajiv1alpha1.TenantControlPlane{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "tcp",
+				Namespace: "default",
+			},
+			Spec: kamajiv1alpha1.TenantControlPlaneSpec{
+				Kubernetes: kamajiv1alpha1.KubernetesSpec{
+					Version: "v1.33.1",
+				},
+			},
+		}
+		tcp = oldTCP.DeepCopy()
+		ctx = context.Background()
+
+		forceDowngrade = func(version string, backupTime time.Time) {
+			tcp.SetAnnotations(map[string]string{
+				kamajiv1alpha1.ForceDowngradeAnnotation:           version,
+				kamajiv1alpha1.DowngradeBackupAnnotation:          "velero/tcp-before-downgrade",
+				kamajiv1alpha1.DowngradeBackupTimestampAnnotation: backupTime.UTC().Format(time.RFC3339),
+			})
+		}
+	})
+
+	It("denies the patch downgrades", func() {
+		tcp.Spec.Kubernetes.Version = "v1.33.0"
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the patch upgrades", func() {
+		tcp.Spec.Kubernetes.Version = "v1.33.2"
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the minor downgrades if not forced", func() {
+		tcp.Spec.Kubernetes.Version = "v1.32.4"
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("allows the forced minor downgrades with a fresh backup", func() {
+		tcp.Spec.Kubernetes.Version = "v1.32.4"
+		forceDowngrade("v1.32.4", time.Now().Add(-10*time.Minute))
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("denies the forced minor downgrades with a stale, or a missing, backup", func() {
+		tcp.Spec.Kubernetes.Version = "v1.32.4"
+		forceDowngrade("v1.32.4", time.Now().Add(-2*time.Hour))
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+
+		forceDowngrade("v1.32.4", time.Now())
+		delete(tcp.Annotations, kamajiv1alpha1.DowngradeBackupAnnotation)
+
+		_, err = t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the forced downgrades to a different version", func() {
+		tcp.Spec.Kubernetes.Version = "v1.32.4"
+		forceDowngrade("v1.32.0", time.Now())
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("denies the forced downgrades skipping a minor version", func() {
+		tcp.Spec.Kubernetes.Version = "v1.31.0"
+		forceDowngrade("v1.31.0", time.Now())
+
+		_, err := t.OnUpdate(tcp, oldTCP)(ctx, admission.Request{})
+		Expect(err).To(HaveOccurred())
+	})
+})
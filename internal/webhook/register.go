@@ -8,11 +8,15 @@ import (
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/conversion"
 
 	webhookhandlers "github.com/clastix/kamaji/internal/webhook/handlers"
 	webhookroutes "github.com/clastix/kamaji/internal/webhook/routes"
 )
 
+// ConversionPath is the path serving the conversion of the TenantControlPlane objects between the API versions.
+const ConversionPath = "/convert"
+
 func Register(mgr controllerruntime.Manager, routes map[webhookroutes.Route][]webhookhandlers.Handler) error {
 	srv := mgr.GetWebhookServer()
 
@@ -27,5 +31,7 @@ func Register(mgr controllerruntime.Manager, routes map[webhookroutes.Route][]we
 		})
 	}
 
+	srv.Register(ConversionPath, conversion.NewWebhookHandler(mgr.GetScheme()))
+
 	return nil
 }
@@ -0,0 +1,21 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package routes
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+//+kubebuilder:webhook:path=/validate--v1-secret-tenantcontrolplane,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=secrets,verbs=update;delete,versions=v1,name=vtenantcontrolplanesecrets.kb.io,admissionReviewVersions=v1
+
+type TenantControlPlaneSecrets struct{}
+
+func (t TenantControlPlaneSecrets) GetPath() string {
+	return "/validate--v1-secret-tenantcontrolplane"
+}
+
+func (t TenantControlPlaneSecrets) GetObject() runtime.Object {
+	return &corev1.Secret{}
+}
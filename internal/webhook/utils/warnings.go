@@ -0,0 +1,26 @@
+// Copyright 2022 Clastix Labs
+// SPDX-License-Identifier: Apache-2.0
+
+package utils
+
+import (
+	"context"
+	"fmt"
+)
+
+type warningsKey struct{}
+
+// WithWarnings returns a context collecting the warnings of the handlers,
+// returned to the client along with the admission response.
+func WithWarnings(ctx context.Context) (context.Context, *[]string) {
+	warnings := &[]string{}
+
+	return context.WithValue(ctx, warningsKey{}, warnings), warnings
+}
+
+// Warn adds a warning to the admission response, it's a no-op if the context is not collecting them.
+func Warn(ctx context.Context, format string, args ...any) {
+	if warnings, ok := ctx.Value(warningsKey{}).(*[]string); ok {
+		*warnings = append(*warnings, fmt.Sprintf(format, args...))
+	}
+}
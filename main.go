@@ -11,14 +11,16 @@ import (
 	"github.com/clastix/kamaji/cmd"
 	"github.com/clastix/kamaji/cmd/manager"
 	"github.com/clastix/kamaji/cmd/migrate"
+	"github.com/clastix/kamaji/cmd/render"
 )
 
 func main() {
 	scheme := runtime.NewScheme()
 
-	root, mgr, migrator := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme)
+	root, mgr, migrator, renderer := cmd.NewCmd(scheme), manager.NewCmd(scheme), migrate.NewCmd(scheme), render.NewCmd(scheme)
 	root.AddCommand(mgr)
 	root.AddCommand(migrator)
+	root.AddCommand(renderer)
 
 	if err := root.Execute(); err != nil {
 		os.Exit(1)